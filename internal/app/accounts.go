@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+)
+
+var errNoAccountCalendars = errors.New("account has no matching calendars")
+
+func matchesAccount(c contract.Calendar, accounts []string) bool {
+	for _, a := range accounts {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			continue
+		}
+		if strings.EqualFold(c.Account, a) || strings.EqualFold(c.AccountType, a) {
+			return true
+		}
+	}
+	return false
+}
+
+func applyAccountFilter(ctx context.Context, be backend.Backend, f *backend.EventFilter, accounts []string) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+	cals, err := listCalendarsWithTimeout(ctx, be)
+	if err != nil {
+		return err
+	}
+	ids := []string{}
+	for _, c := range cals {
+		if !matchesAccount(c, accounts) {
+			continue
+		}
+		if len(f.Calendars) > 0 && !namesCalendar(f.Calendars, c) {
+			continue
+		}
+		ids = append(ids, c.ID)
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("%w: %s", errNoAccountCalendars, strings.Join(accounts, ", "))
+	}
+	f.Calendars = ids
+	return nil
+}
+
+func namesCalendar(names []string, c contract.Calendar) bool {
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n != "" && (n == c.ID || strings.EqualFold(n, c.Name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func failAccountFilter(p output.Printer, err error) error {
+	if errors.Is(err, errNoAccountCalendars) {
+		return failWithHint(p, contract.ErrNotFound, err, "List accounts with `acal calendars list --fields name,account,account_type`", 4)
+	}
+	return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+}
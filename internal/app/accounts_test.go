@@ -0,0 +1,42 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventsListAccountFilter(t *testing.T) {
+	start := time.Now().Add(2 * time.Hour).Truncate(time.Minute)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{
+			{ID: "home", Name: "Home", Writable: true, Account: "iCloud", AccountType: "caldav"},
+			{ID: "work", Name: "Work", Writable: true, Account: "me@example.com", AccountType: "caldav"},
+			{ID: "local", Name: "Scratch", Writable: true, Account: "On My Mac", AccountType: "local"},
+		},
+		Events: []contract.Event{
+			{ID: "e1", CalendarID: "home", CalendarName: "Home", Title: "Dentist", Start: start, End: start.Add(time.Hour)},
+			{ID: "e2", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: start, End: start.Add(time.Hour)},
+			{ID: "e3", CalendarID: "local", CalendarName: "Scratch", Title: "Notes", Start: start, End: start.Add(time.Hour)},
+		},
+	})
+
+	out := string(runWithBackend(t, fb, "events", "list", "--account", "icloud", "--plain", "--fields", "title"))
+	if strings.TrimSpace(out) != "Dentist" {
+		t.Fatalf("unexpected --account output %q", out)
+	}
+	out = string(runWithBackend(t, fb, "events", "query", "--account", "caldav", "--plain", "--fields", "title"))
+	if strings.TrimSpace(out) != "Dentist\nStandup" {
+		t.Fatalf("unexpected --account by type output %q", out)
+	}
+	out = string(runWithBackend(t, fb, "events", "search", "Standup", "--account", "caldav", "--calendar", "Work", "--plain", "--fields", "title"))
+	if strings.TrimSpace(out) != "Standup" {
+		t.Fatalf("unexpected --account with --calendar output %q", out)
+	}
+	if code := runEventsCmd(t, fb, "events", "list", "--account", "iCloud", "--calendar", "Scratch", "--json"); code != 4 {
+		t.Fatalf("expected exit 4 when no calendar matches, got %d", code)
+	}
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+const (
+	aliasPrefix     = "ev"
+	aliasMinLen     = 4
+	aliasMaxEntries = 20000
+	aliasAlphabet   = "23456789abcdefghjkmnpqrstuvwxyz"
+)
+
+type aliasEntry struct {
+	Alias string `json:"alias"`
+	ID    string `json:"id"`
+}
+
+type aliasIndex struct {
+	entries []aliasEntry
+	byAlias map[string]string
+	byID    map[string]string
+	dirty   bool
+}
+
+func aliasFilePath() string {
+	return statePath("aliases.json")
+}
+
+func loadAliasIndex() *aliasIndex {
+	x := &aliasIndex{byAlias: map[string]string{}, byID: map[string]string{}}
+	path := aliasFilePath()
+	if path == "" {
+		return x
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return x
+	}
+	if err := json.Unmarshal(raw, &x.entries); err != nil {
+		x.entries = nil
+		return x
+	}
+	for _, e := range x.entries {
+		x.byAlias[e.Alias] = e.ID
+		x.byID[e.ID] = e.Alias
+	}
+	return x
+}
+
+// save skips the state lock: callers may already hold it, and handles are deterministic.
+func (x *aliasIndex) save() error {
+	path := aliasFilePath()
+	if !x.dirty || path == "" {
+		return nil
+	}
+	entries := x.entries
+	if len(entries) > aliasMaxEntries {
+		entries = entries[len(entries)-aliasMaxEntries:]
+	}
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, raw, 0o644)
+}
+
+func (x *aliasIndex) assign(id string) string {
+	if a, ok := x.byID[id]; ok {
+		return a
+	}
+	sum := sha256.Sum256([]byte(id))
+	for n := aliasMinLen; n <= len(sum); n++ {
+		var b strings.Builder
+		b.WriteString(aliasPrefix)
+		for _, c := range sum[:n] {
+			b.WriteByte(aliasAlphabet[int(c)%len(aliasAlphabet)])
+		}
+		alias := b.String()
+		if _, taken := x.byAlias[alias]; taken {
+			continue
+		}
+		x.entries = append(x.entries, aliasEntry{Alias: alias, ID: id})
+		x.byAlias[alias] = id
+		x.byID[id] = alias
+		x.dirty = true
+		return alias
+	}
+	return ""
+}
+
+func withAlias(e *contract.Event) *contract.Event {
+	if e != nil && e.ID != "" {
+		x := loadAliasIndex()
+		e.Alias = x.assign(e.ID)
+		_ = x.save()
+	}
+	return e
+}
+
+func withEventsAlias(items []contract.Event) []contract.Event {
+	if len(items) == 0 {
+		return items
+	}
+	x := loadAliasIndex()
+	for i := range items {
+		if items[i].ID != "" {
+			items[i].Alias = x.assign(items[i].ID)
+		}
+	}
+	_ = x.save()
+	return items
+}
+
+func resolveAlias(ref string) string {
+	ref = strings.TrimSpace(ref)
+	key := strings.ToLower(ref)
+	if !strings.HasPrefix(key, aliasPrefix) || len(key) < len(aliasPrefix)+aliasMinLen {
+		return ref
+	}
+	if id, ok := loadAliasIndex().byAlias[key]; ok {
+		return id
+	}
+	return ref
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestAliasIndexAssignsStableHandles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", "")
+
+	x := loadAliasIndex()
+	a := x.assign("evt-1@792417600")
+	if !strings.HasPrefix(a, aliasPrefix) || len(a) != len(aliasPrefix)+aliasMinLen {
+		t.Fatalf("unexpected alias %q", a)
+	}
+	if again := x.assign("evt-1@792417600"); again != a {
+		t.Fatalf("alias changed: %q then %q", a, again)
+	}
+	if err := x.save(); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if got := loadAliasIndex().assign("evt-1@792417600"); got != a {
+		t.Fatalf("alias not stable across loads: %q vs %q", got, a)
+	}
+
+	// A collision on the short handle falls back to a longer one.
+	y := &aliasIndex{byAlias: map[string]string{a: "someone-else"}, byID: map[string]string{}}
+	if b := y.assign("evt-1@792417600"); b == a || !strings.HasPrefix(b, a) {
+		t.Fatalf("expected longer alias extending %q, got %q", a, b)
+	}
+}
+
+func TestAliasesResolveAcrossCommands(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", "")
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "long-uid-0001@1772000000", CalendarID: "work", CalendarName: "Work", Title: "Sync", Start: start, End: start.Add(time.Hour)},
+		},
+	})
+
+	var list struct {
+		Data []contract.Event `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "list", "--from", "today", "--to", "+2d", "--json"), &list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if len(list.Data) != 1 || list.Data[0].Alias == "" {
+		t.Fatalf("expected aliased event, got %+v", list.Data)
+	}
+	alias := strings.ToUpper(list.Data[0].Alias)
+
+	var show struct {
+		Data contract.Event `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "show", alias, "--json"), &show); err != nil {
+		t.Fatalf("decode show: %v", err)
+	}
+	if show.Data.ID != "long-uid-0001@1772000000" {
+		t.Fatalf("alias %s resolved to %q", alias, show.Data.ID)
+	}
+	runWithBackend(t, fb, "events", "update", alias, "--title", "Renamed", "--json")
+	if got, err := fb.GetEventByID(context.Background(), "long-uid-0001@1772000000"); err != nil || got.Title != "Renamed" {
+		t.Fatalf("update via alias failed: %+v, %v", got, err)
+	}
+	if got := resolveAlias("evzzzz"); got != "evzzzz" {
+		t.Fatalf("unknown alias should pass through, got %q", got)
+	}
+}
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+type annotationConfig struct {
+	Latitude  *float64 `toml:"latitude"`
+	Longitude *float64 `toml:"longitude"`
+	Commands  []string `toml:"commands"`
+}
+
+func (c annotationConfig) overlay(o annotationConfig) annotationConfig {
+	if o.Latitude != nil {
+		c.Latitude = o.Latitude
+	}
+	if o.Longitude != nil {
+		c.Longitude = o.Longitude
+	}
+	if len(o.Commands) > 0 {
+		c.Commands = o.Commands
+	}
+	return c
+}
+
+type dayAnnotation struct {
+	Provider string            `json:"provider"`
+	Text     string            `json:"text"`
+	Values   map[string]string `json:"values,omitempty"`
+}
+
+type dayAnnotator interface {
+	Name() string
+	Annotate(ctx context.Context, day time.Time) (*dayAnnotation, error)
+}
+
+func dayAnnotators(cfg annotationConfig) ([]dayAnnotator, error) {
+	var out []dayAnnotator
+	switch {
+	case cfg.Latitude != nil && cfg.Longitude != nil:
+		if math.Abs(*cfg.Latitude) > 90 || math.Abs(*cfg.Longitude) > 180 {
+			return nil, fmt.Errorf("invalid [annotations] location %g,%g", *cfg.Latitude, *cfg.Longitude)
+		}
+		out = append(out, sunAnnotator{lat: *cfg.Latitude, lon: *cfg.Longitude})
+	case cfg.Latitude != nil || cfg.Longitude != nil:
+		return nil, errors.New("[annotations] needs both latitude and longitude")
+	}
+	for _, c := range cfg.Commands {
+		if c = strings.TrimSpace(c); c != "" {
+			out = append(out, commandAnnotator{command: c, cfg: cfg})
+		}
+	}
+	return out, nil
+}
+
+func annotateDay(ctx context.Context, providers []dayAnnotator, day time.Time) ([]dayAnnotation, []contract.Warning) {
+	annotations := []dayAnnotation{}
+	var warnings []contract.Warning
+	for _, p := range providers {
+		a, err := p.Annotate(ctx, day)
+		if err != nil {
+			warnings = append(warnings, contract.Warning{Code: contract.WarnAnnotationUnavailable, Message: fmt.Sprintf("annotation provider %s failed: %v", p.Name(), err)})
+			continue
+		}
+		if a != nil && strings.TrimSpace(a.Text) != "" {
+			annotations = append(annotations, *a)
+		}
+	}
+	return annotations, warnings
+}
+
+type sunAnnotator struct {
+	lat, lon float64
+}
+
+func (sunAnnotator) Name() string { return "sun" }
+
+func (s sunAnnotator) Annotate(_ context.Context, day time.Time) (*dayAnnotation, error) {
+	rise, set, state := sunTimes(day, s.lat, s.lon)
+	switch state {
+	case sunAlwaysUp:
+		return &dayAnnotation{Provider: "sun", Text: "Sun does not set", Values: map[string]string{"state": "polar_day"}}, nil
+	case sunAlwaysDown:
+		return &dayAnnotation{Provider: "sun", Text: "Sun does not rise", Values: map[string]string{"state": "polar_night"}}, nil
+	}
+	loc := day.Location()
+	rise, set = rise.In(loc), set.In(loc)
+	return &dayAnnotation{
+		Provider: "sun",
+		Text:     fmt.Sprintf("Sunrise %s · Sunset %s · %s of daylight", rise.Format("15:04"), set.Format("15:04"), formatMinutes(int64(set.Sub(rise).Minutes()))),
+		Values:   map[string]string{"sunrise": rise.Format(time.RFC3339), "sunset": set.Format(time.RFC3339)},
+	}, nil
+}
+
+const (
+	sunRisesAndSets = iota
+	sunAlwaysUp
+	sunAlwaysDown
+)
+
+// sunTimes uses NOAA's low-precision sunrise equation; lon is east positive.
+func sunTimes(day time.Time, lat, lon float64) (time.Time, time.Time, int) {
+	const rad = math.Pi / 180
+	y, m, d := day.Date()
+	noon := time.Date(y, m, d, 12, 0, 0, 0, time.UTC)
+	n := math.Round(float64(noon.Unix())/86400 + 2440587.5 - 2451545.0 + 0.0008)
+	jStar := n - lon/360
+	meanAnomaly := math.Mod(357.5291+0.98560028*jStar, 360)
+	mr := meanAnomaly * rad
+	center := 1.9148*math.Sin(mr) + 0.0200*math.Sin(2*mr) + 0.0003*math.Sin(3*mr)
+	ecliptic := math.Mod(meanAnomaly+center+180+102.9372, 360) * rad
+	transit := 2451545.0 + jStar + 0.0053*math.Sin(mr) - 0.0069*math.Sin(2*ecliptic)
+	sinDecl := math.Sin(ecliptic) * math.Sin(23.4397*rad)
+	cosDecl := math.Cos(math.Asin(sinDecl))
+	cosHour := (math.Sin(-0.833*rad) - math.Sin(lat*rad)*sinDecl) / (math.Cos(lat*rad) * cosDecl)
+	switch {
+	case cosHour < -1:
+		return time.Time{}, time.Time{}, sunAlwaysUp
+	case cosHour > 1:
+		return time.Time{}, time.Time{}, sunAlwaysDown
+	}
+	hour := math.Acos(cosHour) / rad
+	return julianTime(transit - hour/360), julianTime(transit + hour/360), sunRisesAndSets
+}
+
+func julianTime(j float64) time.Time {
+	return time.Unix(int64(math.Round((j-2440587.5)*86400)), 0).UTC()
+}
+
+type commandAnnotator struct {
+	command string
+	cfg     annotationConfig
+}
+
+func (c commandAnnotator) Name() string { return filepath.Base(strings.Fields(c.command)[0]) }
+
+func (c commandAnnotator) Annotate(ctx context.Context, day time.Time) (*dayAnnotation, error) {
+	fields := strings.Fields(c.command)
+	date := day.Format("2006-01-02")
+	cmd := exec.CommandContext(ctx, fields[0], append(fields[1:], date)...)
+	cmd.Env = append(os.Environ(), "ACAL_DAY="+date, "ACAL_TZ="+day.Location().String())
+	if c.cfg.Latitude != nil && c.cfg.Longitude != nil {
+		cmd.Env = append(cmd.Env,
+			"ACAL_LATITUDE="+strconv.FormatFloat(*c.cfg.Latitude, 'f', -1, 64),
+			"ACAL_LONGITUDE="+strconv.FormatFloat(*c.cfg.Longitude, 'f', -1, 64))
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(strings.TrimSpace(string(exitErr.Stderr))) > 0 {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	return parseAnnotationOutput(c.Name(), out)
+}
+
+func parseAnnotationOutput(provider string, out []byte) (*dayAnnotation, error) {
+	text := strings.TrimSpace(string(out))
+	if text == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(text, "{") {
+		var a dayAnnotation
+		if err := json.Unmarshal([]byte(text), &a); err != nil {
+			return nil, fmt.Errorf("invalid JSON output: %w", err)
+		}
+		a.Provider = firstNonEmpty(a.Provider, provider)
+		return &a, nil
+	}
+	line, _, _ := strings.Cut(text, "\n")
+	return &dayAnnotation{Provider: provider, Text: strings.TrimSpace(line)}, nil
+}
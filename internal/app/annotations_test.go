@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestSunTimes(t *testing.T) {
+	athens, err := time.LoadLocation("Europe/Athens")
+	if err != nil {
+		t.Skip("tzdata unavailable")
+	}
+	rise, set, state := sunTimes(time.Date(2026, 6, 21, 0, 0, 0, 0, athens), 37.9838, 23.7275)
+	near := func(got time.Time, hh, mm int) bool {
+		want := time.Date(2026, 6, 21, hh, mm, 0, 0, athens)
+		d := got.Sub(want)
+		return state == sunRisesAndSets && d > -2*time.Minute && d < 2*time.Minute
+	}
+	if !near(rise, 6, 2) || !near(set, 20, 50) {
+		t.Fatalf("unexpected Athens solstice sun times: %s %s", rise.In(athens), set.In(athens))
+	}
+	if _, _, state := sunTimes(time.Date(2026, 6, 21, 0, 0, 0, 0, time.UTC), 69.65, 18.96); state != sunAlwaysUp {
+		t.Fatalf("expected polar day in Tromsø, got %d", state)
+	}
+	if _, _, state := sunTimes(time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC), 69.65, 18.96); state != sunAlwaysDown {
+		t.Fatalf("expected polar night in Tromsø, got %d", state)
+	}
+}
+
+type failingAnnotator struct{}
+
+func (failingAnnotator) Name() string { return "weather" }
+
+func (failingAnnotator) Annotate(context.Context, time.Time) (*dayAnnotation, error) {
+	return nil, os.ErrNotExist
+}
+
+func TestAnnotateDayKeepsGoingAfterProviderFailure(t *testing.T) {
+	lat, lon := 51.5074, -0.1278
+	providers, err := dayAnnotators(annotationConfig{Latitude: &lat, Longitude: &lon})
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations, warnings := annotateDay(context.Background(), append([]dayAnnotator{failingAnnotator{}}, providers...), time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+	if len(annotations) != 1 || annotations[0].Provider != "sun" || annotations[0].Values["sunrise"] == "" {
+		t.Fatalf("expected the sun annotation, got %+v", annotations)
+	}
+	if len(warnings) != 1 || warnings[0].Code != contract.WarnAnnotationUnavailable {
+		t.Fatalf("expected one provider warning, got %+v", warnings)
+	}
+	if _, err := dayAnnotators(annotationConfig{Latitude: &lat}); err == nil {
+		t.Fatalf("expected latitude without longitude to fail")
+	}
+
+	a, err := parseAnnotationOutput("weather", []byte(`{"text":"12°C, light rain","values":{"temp_c":"12"}}`))
+	if err != nil || a.Provider != "weather" || a.Text != "12°C, light rain" || a.Values["temp_c"] != "12" {
+		t.Fatalf("unexpected JSON provider output: %+v %v", a, err)
+	}
+	a, err = parseAnnotationOutput("weather", []byte("Sunny\nignored\n"))
+	if err != nil || a.Text != "Sunny" {
+		t.Fatalf("unexpected text provider output: %+v %v", a, err)
+	}
+}
+
+func TestDigestRendersAnnotations(t *testing.T) {
+	cfg := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(cfg, []byte("[annotations]\nlatitude = 51.5074\nlongitude = -0.1278\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ACAL_CONFIG", cfg)
+	fb := backend.NewMockBackend(backend.MockFixture{})
+	var env struct {
+		Data digest `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "digest", "--for", "2026-03-20", "--tz", "UTC", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data.Annotations) != 1 || env.Data.Annotations[0].Text != "Sunrise 06:03 · Sunset 18:12 · 12h08m of daylight" || !strings.Contains(env.Data.Content, "Sunrise 06:03") {
+		t.Fatalf("unexpected annotations: %+v", env.Data.Annotations)
+	}
+	var agenda struct {
+		Meta map[string]any `json:"meta"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "agenda", "--day", "2026-03-20", "--tz", "UTC", "--no-annotations", "--json"), &agenda); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := agenda.Meta["annotations"]; ok {
+		t.Fatalf("expected --no-annotations to skip providers, got %+v", agenda.Meta)
+	}
+}
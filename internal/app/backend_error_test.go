@@ -1,10 +1,17 @@
 package app
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
 )
 
 func TestBackendErrorMetaFromDeadline(t *testing.T) {
@@ -38,3 +45,15 @@ func TestBackendContextErrorMessageContainsPhase(t *testing.T) {
 		t.Fatalf("expected phase-aware message, got: %q", err.Error())
 	}
 }
+
+func TestFailWithHintMapsBackendUnavailable(t *testing.T) {
+	var stderr bytes.Buffer
+	p := output.Printer{Mode: output.ModeJSON, Err: &stderr, Out: io.Discard, SchemaVersion: "v1"}
+	err := failWithHint(p, contract.ErrGeneric, fmt.Errorf("osascript backend requires macOS: %w", backend.ErrBackendUnavailable), "Update failed", 1)
+	if got := ExitCode(err); got != 6 {
+		t.Fatalf("exit code mismatch: got=%d want=6", got)
+	}
+	if !strings.Contains(stderr.String(), string(contract.ErrBackendUnavailable)) {
+		t.Fatalf("expected BACKEND_UNAVAILABLE code, got %s", stderr.String())
+	}
+}
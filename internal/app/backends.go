@@ -0,0 +1,67 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agis/acal/internal/backend"
+)
+
+type backendConfig struct {
+	Type        string   `toml:"type"`
+	CalDAVURL   string   `toml:"caldav_url"`
+	CalDAVUser  string   `toml:"caldav_user"`
+	PasswordEnv string   `toml:"password_env"`
+	MockFile    string   `toml:"mock_file"`
+	Calendars   []string `toml:"calendars"`
+}
+
+func newAggregateBackend(configs map[string]backendConfig) (backend.Backend, error) {
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("--backend all requires [backends.<name>] entries in config")
+	}
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	members := make([]backend.NamedBackend, 0, len(names))
+	for _, name := range names {
+		cfg := configs[name]
+		be, err := backendFromConfig(name, cfg)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, backend.NamedBackend{Name: name, Backend: be, Calendars: cfg.Calendars})
+	}
+	return backend.NewMultiBackend(members), nil
+}
+
+func backendFromConfig(name string, cfg backendConfig) (backend.Backend, error) {
+	if strings.Contains(name, ":") {
+		return nil, fmt.Errorf("backend name %q must not contain ':'", name)
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.Type)) {
+	case "osascript":
+		return backend.NewOsaScriptBackend(), nil
+	case "caldav":
+		if strings.TrimSpace(cfg.CalDAVURL) == "" {
+			return nil, fmt.Errorf("backend %q: caldav_url is required", name)
+		}
+		return backend.NewCalDAVBackend(backend.CalDAVConfig{
+			URL:      cfg.CalDAVURL,
+			User:     cfg.CalDAVUser,
+			Password: env(firstNonEmpty(cfg.PasswordEnv, "ACAL_CALDAV_PASSWORD")),
+		}), nil
+	case "mock":
+		if strings.TrimSpace(cfg.MockFile) == "" {
+			return nil, fmt.Errorf("backend %q: mock_file is required", name)
+		}
+		return backend.LoadMockBackend(cfg.MockFile)
+	case "":
+		return nil, fmt.Errorf("backend %q: type is required (osascript|caldav|mock)", name)
+	default:
+		return nil, fmt.Errorf("backend %q: unknown type: %s", name, cfg.Type)
+	}
+}
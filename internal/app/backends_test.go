@@ -0,0 +1,124 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestSelectBackendAggregateFromConfig(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmp := t.TempDir()
+	if err := os.Chdir(tmp); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	t.Setenv("HOME", tmp)
+
+	cfg := `backend = "all"
+
+[backends.apple]
+type = "osascript"
+calendars = ["Home"]
+
+[backends.fastmail]
+type = "caldav"
+caldav_url = "https://caldav.fastmail.com/dav/calendars/user/me/"
+caldav_user = "me"
+password_env = "FASTMAIL_PASSWORD"
+`
+	if err := os.WriteFile(filepath.Join(tmp, ".acal.toml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := resolveGlobalOptions(newTestCmd(), &globalOptions{Profile: "default", Backend: "osascript", SchemaVersion: "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resolved.Backends) != 2 || resolved.Backends["apple"].Calendars[0] != "Home" {
+		t.Fatalf("unexpected backends config: %+v", resolved.Backends)
+	}
+
+	be, err := selectBackend(resolved)
+	if err != nil {
+		t.Fatalf("selectBackend all failed: %v", err)
+	}
+	if _, ok := be.(*backend.MultiBackend); !ok {
+		t.Fatalf("expected multi backend, got %T", be)
+	}
+
+	resolved.Backend = "fastmail"
+	be, err = selectBackend(resolved)
+	if err != nil {
+		t.Fatalf("selectBackend named failed: %v", err)
+	}
+	if _, ok := be.(*backend.CalDAVBackend); !ok {
+		t.Fatalf("expected caldav backend, got %T", be)
+	}
+}
+
+func TestBackendFromConfigErrors(t *testing.T) {
+	cases := map[string]backendConfig{
+		"missing-type": {},
+		"bad-type":     {Type: "exchange"},
+		"no-url":       {Type: "caldav"},
+		"bad:name":     {Type: "osascript"},
+	}
+	for name, cfg := range cases {
+		if _, err := backendFromConfig(name, cfg); err == nil {
+			t.Fatalf("expected error for %s", name)
+		}
+	}
+	if _, err := newAggregateBackend(nil); err == nil {
+		t.Fatalf("expected error for empty aggregate backend")
+	}
+}
+
+func TestMockBackendEndToEnd(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	fixture := filepath.Join("testdata", "mock", "events.json")
+
+	cmd := NewRootCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"events", "list", "--backend", "mock", "--mock-file", fixture, "--from", "2026-03-02", "--to", "2026-03-04", "--calendar", "Work", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	var env struct {
+		Data []contract.Event `json:"data"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(env.Data) != 2 || env.Data[0].Title != "Standup" || env.Data[1].Title != "Design review" {
+		t.Fatalf("unexpected events: %+v", env.Data)
+	}
+
+	cmd = NewRootCommand()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"events", "add", "--backend", "mock", "--mock-file", fixture, "--calendar", "Holidays", "--title", "x", "--start", "2026-03-05T10:00", "--duration", "30m", "--json"})
+	if got := ExitCode(cmd.Execute()); got == 0 {
+		t.Fatalf("expected read-only calendar failure")
+	}
+
+	cmd = NewRootCommand()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"calendars", "list", "--backend", "mock", "--json"})
+	if got := ExitCode(cmd.Execute()); got != 2 {
+		t.Fatalf("expected missing mock file usage error, got %d", got)
+	}
+}
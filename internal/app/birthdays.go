@@ -0,0 +1,34 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+var errBirthdaysUnsupported = errors.New("backend does not expose contact birthdays")
+
+func withBirthdaysCalendar(ctx context.Context, be backend.Backend, cals []contract.Calendar) []contract.Calendar {
+	items, err := listBirthdaysWithTimeout(ctx, be)
+	if err != nil || len(items) == 0 {
+		return cals
+	}
+	return append(cals, backend.BirthdaysCalendar())
+}
+
+func withBirthdayEvents(ctx context.Context, be backend.Backend, items []contract.Event, calendars []string, from, to time.Time, loc *time.Location) ([]contract.Event, []contract.Warning) {
+	if len(calendars) > 0 && !namesCalendar(calendars, backend.BirthdaysCalendar()) {
+		return items, nil
+	}
+	bs, err := listBirthdaysWithTimeout(ctx, be)
+	if err != nil {
+		return items, []contract.Warning{{Code: contract.WarnBirthdaysUnavailable, Message: "birthdays unavailable: " + err.Error()}}
+	}
+	merged := append(items, withEventsETag(backend.BirthdayEvents(bs, from, to, loc))...)
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Start.Before(merged[j].Start) })
+	return merged, nil
+}
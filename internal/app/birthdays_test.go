@@ -0,0 +1,70 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func runWithBackend(t *testing.T, be backend.Backend, args ...string) []byte {
+	t.Helper()
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return be, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+	cmd := NewRootCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute %v failed: %v", args, err)
+	}
+	return stdout.Bytes()
+}
+
+func TestBirthdaysInCalendarsAndViews(t *testing.T) {
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "sync", CalendarID: "work", CalendarName: "Work", Title: "Sync", Start: time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 3, 11, 0, 0, 0, time.UTC)},
+		},
+		Birthdays: []backend.Birthday{{ContactID: "c1", Name: "Ada", Month: time.March, Day: 3}},
+	})
+
+	var cals struct {
+		Data []contract.Calendar `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "calendars", "list", "--json"), &cals); err != nil {
+		t.Fatal(err)
+	}
+	if len(cals.Data) != 2 || cals.Data[1].ID != backend.BirthdaysCalendarID || cals.Data[1].Writable {
+		t.Fatalf("expected read-only birthdays calendar, got %+v", cals.Data)
+	}
+
+	var day struct {
+		Data []contract.Event `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "today", "--day", "2026-03-03", "--tz", "UTC", "--json"), &day); err != nil {
+		t.Fatal(err)
+	}
+	if len(day.Data) != 1 {
+		t.Fatalf("expected birthdays to be opt-in, got %+v", day.Data)
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "today", "--day", "2026-03-03", "--tz", "UTC", "--include-birthdays", "--json"), &day); err != nil {
+		t.Fatal(err)
+	}
+	if len(day.Data) != 2 || day.Data[0].Title != "Ada's Birthday" || day.Data[1].ID != "sync" {
+		t.Fatalf("expected birthday before sync, got %+v", day.Data)
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "today", "--day", "2026-03-03", "--tz", "UTC", "--include-birthdays", "--calendar", "Work", "--json"), &day); err != nil {
+		t.Fatal(err)
+	}
+	if len(day.Data) != 1 || day.Data[0].Title != "Sync" {
+		t.Fatalf("expected --calendar to exclude birthdays, got %+v", day.Data)
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/timeparse"
+)
+
+type calendarDefaults struct {
+	Reminder string `toml:"reminder"`
+	Duration string `toml:"duration"`
+	Color    string `toml:"color"`
+}
+
+func calendarDefaultsFor(all map[string]calendarDefaults, cal string) (calendarDefaults, bool) {
+	cal = strings.TrimSpace(cal)
+	if cal == "" || len(all) == 0 {
+		return calendarDefaults{}, false
+	}
+	if d, ok := all[cal]; ok {
+		return d, true
+	}
+	for k, d := range all {
+		if strings.EqualFold(k, cal) {
+			return d, true
+		}
+	}
+	return calendarDefaults{}, false
+}
+
+func (d calendarDefaults) duration() (time.Duration, bool, error) {
+	if strings.TrimSpace(d.Duration) == "" {
+		return 0, false, nil
+	}
+	v, err := timeparse.ParseDuration(d.Duration)
+	if err == nil && v <= 0 {
+		err = fmt.Errorf("must be positive")
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid calendar_defaults duration %q: %w", d.Duration, err)
+	}
+	return v, true, nil
+}
+
+func (d calendarDefaults) applyReminder(in *backend.EventCreateInput) (bool, error) {
+	if in.ReminderOffset != nil || strings.TrimSpace(d.Reminder) == "" {
+		return false, nil
+	}
+	offset, err := normalizeReminderOffset(d.Reminder)
+	if err != nil {
+		return false, fmt.Errorf("invalid calendar_defaults reminder %q: %w", d.Reminder, err)
+	}
+	in.ReminderOffset = &offset
+	return true, nil
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestCalendarDefaultsApplyOnAdd(t *testing.T) {
+	cfg := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(cfg, []byte("[calendar_defaults.Work]\nreminder = \"-10m\"\nduration = \"25m\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ACAL_CONFIG", cfg)
+	fb := backend.NewMockBackend(backend.MockFixture{Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}, {ID: "home", Name: "Home", Writable: true}}})
+	var env struct {
+		Data contract.Event `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "add", "--calendar", "work", "--title", "Sync", "--start", "2026-03-03T10:00:00Z", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if got := env.Data.End.Sub(env.Data.Start); got != 25*time.Minute {
+		t.Fatalf("expected the 25m default duration, got %v", got)
+	}
+	if r, _ := fb.GetReminderOffset(t.Context(), env.Data.ID); r == nil || *r != -10*time.Minute {
+		t.Fatalf("expected the -10m default reminder, got %v", r)
+	}
+	if applied, _ := env.Meta["calendar_defaults"].([]any); len(applied) != 2 {
+		t.Fatalf("expected both defaults reported, got %v", env.Meta)
+	}
+
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "add", "--calendar", "Work", "--title", "Long", "--start", "2026-03-03T13:00:00Z", "--duration", "1h", "--reminder", "5m", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if got := env.Data.End.Sub(env.Data.Start); got != time.Hour {
+		t.Fatalf("explicit --duration must win, got %v", got)
+	}
+	if r, _ := fb.GetReminderOffset(t.Context(), env.Data.ID); r == nil || *r != -5*time.Minute {
+		t.Fatalf("explicit --reminder must win, got %v", r)
+	}
+
+	if err := json.Unmarshal(runWithBackend(t, fb, "quick-add", "2026-03-04 09:00 Standup @Work", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if got := env.Data.End.Sub(env.Data.Start); got != 25*time.Minute {
+		t.Fatalf("expected quick-add to use the calendar default duration, got %v", got)
+	}
+	if r, _ := fb.GetReminderOffset(t.Context(), env.Data.ID); r == nil {
+		t.Fatal("expected quick-add to apply the default reminder")
+	}
+	env.Meta = nil
+	if err := json.Unmarshal(runWithBackend(t, fb, "quick-add", "2026-03-04 11:00 Errands @Home", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if got := env.Data.End.Sub(env.Data.Start); got != time.Hour || env.Meta["calendar_defaults"] != nil {
+		t.Fatalf("calendars without defaults keep the 1h default, got %v %v", got, env.Meta)
+	}
+}
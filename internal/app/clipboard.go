@@ -0,0 +1,105 @@
+package app
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+var readClipboard = func() (string, error) {
+	out, err := exec.Command("pbpaste").Output()
+	if err != nil {
+		return "", fmt.Errorf("read clipboard with pbpaste: %w", err)
+	}
+	return string(out), nil
+}
+
+var (
+	titleLineRe   = regexp.MustCompile(`(?i)^\s*(subject|title|event|what)\s*:\s*(.+)$`)
+	labelLineRe   = regexp.MustCompile(`(?i)^\s*(when|where|location|date|time|date and time|date & time|from|to|cc)\s*:`)
+	leftoverPunct = " \t-–—,;:·|@"
+)
+
+func parseClipboardText(text string, now time.Time, loc *time.Location, calendar string, dur time.Duration, allDay bool) (backend.EventCreateInput, string, error) {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "\r\n", "\n")
+	if text == "" {
+		return backend.EventCreateInput{}, "", errors.New("clipboard is empty")
+	}
+	start, end, matched, ok := guessEmailTime(text, now, loc, dur)
+	if !ok {
+		for _, line := range strings.Split(text, "\n") {
+			tokens := strings.Fields(line)
+			for i := range tokens {
+				s, n, hasTime, err := parseQuickAddStart(tokens[i:], now, loc)
+				if err != nil || (!hasTime && !allDay) {
+					continue
+				}
+				start, end, matched, ok = s, s.Add(dur), strings.Join(tokens[i:i+n], " "), true
+				break
+			}
+			if ok {
+				break
+			}
+		}
+	}
+	if !ok {
+		return backend.EventCreateInput{}, "", errors.New("no date and time found; include e.g. \"Mar 4 at 3pm\" or \"tomorrow 15:00\"")
+	}
+
+	title := ""
+	for _, line := range strings.Split(text, "\n") {
+		if m := titleLineRe.FindStringSubmatch(line); m != nil {
+			title = m[2]
+			break
+		}
+	}
+	if title == "" {
+		for _, line := range strings.Split(text, "\n") {
+			if labelLineRe.MatchString(line) {
+				continue
+			}
+			if t := strings.Trim(strings.Replace(line, matched, "", 1), leftoverPunct); t != "" {
+				title = t
+				break
+			}
+		}
+	}
+	title = inviteTitle(strings.Join(strings.Fields(title), " "))
+	if title == "" {
+		return backend.EventCreateInput{}, "", errors.New("missing title")
+	}
+	if strings.TrimSpace(calendar) == "" {
+		return backend.EventCreateInput{}, "", errors.New("missing calendar; pass --calendar")
+	}
+	in := backend.EventCreateInput{Calendar: strings.TrimSpace(calendar), Title: title, Start: start, End: end, AllDay: allDay}
+	if m := whereLineRe.FindStringSubmatch(text); m != nil {
+		in.Location = strings.TrimSpace(m[2])
+	}
+	in.URL = meetingURL(contract.Event{Location: in.Location, Notes: text})
+	if allDay {
+		y, m, d := start.Date()
+		in.Start = time.Date(y, m, d, 0, 0, 0, 0, loc)
+		in.End = in.Start.Add(24 * time.Hour)
+	}
+	return in, matched, nil
+}
+
+func promptYesNo(in io.Reader, out io.Writer, question string) (bool, error) {
+	if _, err := fmt.Fprintf(out, "%s [y/N]: ", question); err != nil {
+		return false, err
+	}
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	a := strings.ToLower(strings.TrimSpace(line))
+	return a == "y" || a == "yes", nil
+}
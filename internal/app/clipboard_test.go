@@ -0,0 +1,75 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestParseClipboardText(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	in, matched, err := parseClipboardText("Subject: Design review\nWhen: Mar 4, 2026 2:00 - 3:30pm\nWhere: Room 12\n", now, time.UTC, "Work", time.Hour, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in.Title != "Design review" || in.Location != "Room 12" || in.Start.Hour() != 14 || in.End.Sub(in.Start) != 90*time.Minute || matched != "Mar 4, 2026 2:00 - 3:30pm" {
+		t.Fatalf("unexpected extraction: %+v (%q)", in, matched)
+	}
+
+	in, matched, err = parseClipboardText("Lunch with Sam tomorrow 12:30\nhttps://zoom.us/j/42\n", now, time.UTC, "Personal", 45*time.Minute, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in.Title != "Lunch with Sam" || matched != "tomorrow 12:30" || in.URL != "https://zoom.us/j/42" || !in.Start.Equal(time.Date(2026, 3, 3, 12, 30, 0, 0, time.UTC)) || in.End.Sub(in.Start) != 45*time.Minute {
+		t.Fatalf("unexpected extraction: %+v (%q)", in, matched)
+	}
+
+	if _, _, err := parseClipboardText("just some words", now, time.UTC, "Work", time.Hour, false); err == nil {
+		t.Fatal("expected an error without a date and time")
+	}
+}
+
+func TestQuickAddFromClipboardProposesByDefault(t *testing.T) {
+	t.Setenv("ACAL_NOW", "2026-03-02T09:00:00Z")
+	t.Cleanup(func() { pinnedNow.Store(nil) })
+	prev := readClipboard
+	readClipboard = func() (string, error) { return "Standup\nfri 10:00\n", nil }
+	t.Cleanup(func() { readClipboard = prev })
+	fb := backend.NewMockBackend(backend.MockFixture{Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}}})
+
+	var env struct {
+		Data []dryRunPreview `json:"data"`
+		Meta map[string]any  `json:"meta"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "quick-add", "--from-clipboard", "--calendar", "Work", "--tz", "UTC", "--no-input", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Meta["dry_run"] != true || env.Meta["matched"] != "fri 10:00" || len(env.Data) != 1 || env.Data[0].After.Title != "Standup" {
+		t.Fatalf("expected a proposal, got %+v", env)
+	}
+	if got, _ := fb.ListEvents(t.Context(), backend.EventFilter{From: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)}); len(got) != 0 {
+		t.Fatalf("proposal must not create events, got %d", len(got))
+	}
+
+	out := string(runWithBackend(t, fb, "quick-add", "--from-clipboard", "--calendar", "Work", "--tz", "UTC", "--yes", "--json"))
+	if !strings.Contains(out, `"title":"Standup"`) && !strings.Contains(out, `"title": "Standup"`) {
+		t.Fatalf("expected the event to be created with --yes, got %s", out)
+	}
+	if code := runEventsCmd(t, fb, "quick-add", "text", "--from-clipboard", "--calendar", "Work"); code == 0 {
+		t.Fatal("expected text together with --from-clipboard to be rejected")
+	}
+}
+
+func TestPromptYesNo(t *testing.T) {
+	var out strings.Builder
+	if ok, err := promptYesNo(strings.NewReader("Yes\n"), &out, "Create?"); err != nil || !ok || out.String() != "Create? [y/N]: " {
+		t.Fatalf("expected yes, got %v %v %q", ok, err, out.String())
+	}
+	if ok, err := promptYesNo(strings.NewReader("\n"), &out, "Create?"); err != nil || ok {
+		t.Fatalf("empty answer must mean no, got %v %v", ok, err)
+	}
+}
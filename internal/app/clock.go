@@ -0,0 +1,56 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/agis/acal/internal/timeparse"
+)
+
+var pinnedNow atomic.Pointer[time.Time]
+
+// currentTime honors --now/ACAL_NOW; record timestamps keep the wall clock.
+func currentTime() time.Time {
+	if t := pinnedNow.Load(); t != nil {
+		return *t
+	}
+	return time.Now()
+}
+
+func setPinnedNow(s string) error {
+	pinnedNow.Store(nil)
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return invalidField("now", s, fmt.Errorf("invalid --now %q: use RFC3339 like 2026-03-02T09:00:00Z", s))
+	}
+	pinnedNow.Store(&t)
+	return nil
+}
+
+var weekStartDay atomic.Pointer[time.Weekday]
+
+func currentWeekStart() time.Weekday {
+	if ws := weekStartDay.Load(); ws != nil {
+		return *ws
+	}
+	return time.Monday
+}
+
+func setWeekStart(configured, locale string) error {
+	ws := timeparse.DefaultWeekStart(locale)
+	if strings.TrimSpace(configured) != "" {
+		v, err := parseWeekStart(configured)
+		if err != nil {
+			return fmt.Errorf("invalid week_start %q: use monday, sunday, or saturday", configured)
+		}
+		ws = v
+	}
+	weekStartDay.Store(&ws)
+	return nil
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestNowPinsRelativeInputs(t *testing.T) {
+	t.Cleanup(func() { pinnedNow.Store(nil) })
+	day := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Pinned", Start: day, End: day.Add(time.Hour)},
+			{ID: "b", CalendarID: "work", CalendarName: "Work", Title: "Next day", Start: day.AddDate(0, 0, 1), End: day.AddDate(0, 0, 1).Add(time.Hour)},
+		},
+	})
+	out := string(runWithBackend(t, fb, "events", "list", "--now", "2026-03-02T08:00:00Z", "--tz", "UTC", "--from", "today", "--to", "today", "--plain", "--fields", "title"))
+	if strings.TrimSpace(out) != "Pinned" {
+		t.Fatalf("--now not honored: %q", out)
+	}
+
+	t.Setenv("ACAL_NOW", "2026-03-01T08:00:00Z")
+	out = string(runWithBackend(t, fb, "events", "list", "--tz", "UTC", "--from", "tomorrow", "--to", "tomorrow", "--plain", "--fields", "title"))
+	if strings.TrimSpace(out) != "Pinned" {
+		t.Fatalf("ACAL_NOW not honored: %q", out)
+	}
+
+	if code := runEventsCmd(t, fb, "events", "list", "--now", "yesterday", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for invalid --now, got %d", code)
+	}
+}
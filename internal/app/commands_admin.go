@@ -19,6 +19,7 @@ type statusResult struct {
 	TZ            string                 `json:"tz,omitempty"`
 	OutputMode    string                 `json:"output_mode"`
 	SchemaVersion string                 `json:"schema_version"`
+	CalendarDB    string                 `json:"calendar_db,omitempty"`
 	Checks        []contract.DoctorCheck `json:"checks"`
 	NextSteps     []string               `json:"next_steps,omitempty"`
 	ReasonCodes   []string               `json:"degraded_reason_codes,omitempty"`
@@ -54,7 +55,7 @@ func newDoctorCmd(opts *globalOptions) *cobra.Command {
 				"degraded":              setup.Degraded,
 				"degraded_reason_codes": reasonCodes,
 			}
-			warnings := setup.Notes
+			warnings := warningsWithCode(contract.WarnEnvironmentDegraded, setup.Notes)
 			if p.EffectiveSuccessMode() == output.ModePlain {
 				return printDoctorPlain(cmd.OutOrStdout(), checks, setup, reasonCodes)
 			}
@@ -92,6 +93,7 @@ func newStatusCmd(opts *globalOptions) *cobra.Command {
 				TZ:            ro.TZ,
 				OutputMode:    string(p.EffectiveSuccessMode()),
 				SchemaVersion: ro.SchemaVersion,
+				CalendarDB:    checkPath(checks, "calendar_db"),
 				Checks:        checks,
 				NextSteps:     setup.NextSteps,
 				ReasonCodes:   reasonCodes,
@@ -144,7 +146,7 @@ func newStatusCmd(opts *globalOptions) *cobra.Command {
 					"degraded":              setup.Degraded,
 					"degraded_reason_codes": reasons,
 					"next_steps":            setup.NextSteps,
-				}, map[string]any{"count": len(setup.NextSteps)}, setup.Notes)
+				}, map[string]any{"count": len(setup.NextSteps)}, warningsWithCode(contract.WarnEnvironmentDegraded, setup.Notes))
 			}
 			if !setup.Ready && derr != nil {
 				return WrapPrinted(6, derr)
@@ -176,6 +178,7 @@ func newCalendarsCmd(opts *globalOptions) *cobra.Command {
 				_ = p.Error(contract.ErrBackendUnavailable, err.Error(), "Run `acal doctor` for remediation")
 				return WrapPrinted(6, err)
 			}
+			items = applyWritePolicy(ctx, withBirthdaysCalendar(ctx, be, items))
 			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items)}, nil)
 		},
 	}
@@ -241,7 +244,7 @@ func printDoctorPlain(out io.Writer, checks []contract.DoctorCheck, setup setupR
 		_, _ = fmt.Fprintf(out, "reasons=%s\n", strings.Join(reasonCodes, ","))
 	}
 	for _, c := range checks {
-		_, _ = fmt.Fprintf(out, "[%s] %s: %s\n", c.Status, c.Name, c.Message)
+		printCheckPlain(out, c)
 	}
 	for _, step := range setup.NextSteps {
 		_, _ = fmt.Fprintf(out, "next: %s\n", step)
@@ -254,8 +257,28 @@ func printStatusPlain(out io.Writer, res statusResult) error {
 	if len(res.ReasonCodes) > 0 {
 		_, _ = fmt.Fprintf(out, "reasons=%s\n", strings.Join(res.ReasonCodes, ","))
 	}
+	if res.CalendarDB != "" {
+		_, _ = fmt.Fprintf(out, "calendar_db=%s\n", res.CalendarDB)
+	}
 	for _, c := range res.Checks {
-		_, _ = fmt.Fprintf(out, "[%s] %s: %s\n", c.Status, c.Name, c.Message)
+		printCheckPlain(out, c)
 	}
 	return nil
 }
+
+func printCheckPlain(out io.Writer, c contract.DoctorCheck) {
+	if c.Path != "" {
+		_, _ = fmt.Fprintf(out, "[%s] %s: %s (%s)\n", c.Status, c.Name, c.Message, c.Path)
+		return
+	}
+	_, _ = fmt.Fprintf(out, "[%s] %s: %s\n", c.Status, c.Name, c.Message)
+}
+
+func checkPath(checks []contract.DoctorCheck, name string) string {
+	for _, c := range checks {
+		if c.Name == name {
+			return c.Path
+		}
+	}
+	return ""
+}
@@ -92,7 +92,7 @@ func TestDoctorAndCalendarsCommands(t *testing.T) {
 		},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	var out bytes.Buffer
@@ -126,7 +126,7 @@ func TestDoctorFailureProducesSinglePayload(t *testing.T) {
 		doctorErr: errors.New("osascript missing"),
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	var out bytes.Buffer
@@ -155,12 +155,12 @@ func TestStatusCommand(t *testing.T) {
 		checks: []contract.DoctorCheck{
 			{Name: "osascript", Status: "ok"},
 			{Name: "calendar_access", Status: "ok"},
-			{Name: "calendar_db", Status: "ok"},
+			{Name: "calendar_db", Status: "ok", Path: "/Users/me/Library/Calendars/Calendar.sqlitedb"},
 			{Name: "calendar_db_read", Status: "ok"},
 		},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	var out bytes.Buffer
@@ -181,6 +181,21 @@ func TestStatusCommand(t *testing.T) {
 	if !strings.Contains(got, "\"degraded_reason_codes\":") {
 		t.Fatalf("expected degraded_reason_codes in output: %q", got)
 	}
+	if !strings.Contains(got, `"calendar_db": "/Users/me/Library/Calendars/Calendar.sqlitedb"`) {
+		t.Fatalf("expected the calendar database path in output: %q", got)
+	}
+
+	out.Reset()
+	cmd = NewRootCommand()
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"status", "--plain"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("plain status failed: %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, "calendar_db=/Users/me/Library/Calendars/Calendar.sqlitedb\n") || !strings.Contains(got, "[ok] calendar_db:  (/Users/me/Library/Calendars/Calendar.sqlitedb)") {
+		t.Fatalf("expected the path in plain status: %q", got)
+	}
 }
 
 func TestStatusCommandNotReadyExitCode(t *testing.T) {
@@ -191,7 +206,7 @@ func TestStatusCommandNotReadyExitCode(t *testing.T) {
 		doctorErr: errors.New("osascript missing"),
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -217,7 +232,7 @@ func TestStatusCommandReportsEffectiveOutputMode(t *testing.T) {
 		},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	var out bytes.Buffer
@@ -244,7 +259,7 @@ func TestDoctorDegradedMatchesStatusReadiness(t *testing.T) {
 		doctorErr: errors.New("calendar db denied"),
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -272,7 +287,7 @@ func TestStatusPlainOutputIsHumanReadable(t *testing.T) {
 		},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -299,7 +314,7 @@ func TestStatusExplainProvidesNextSteps(t *testing.T) {
 		doctorErr: errors.New("calendar db denied"),
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -327,7 +342,7 @@ func TestFailOnDegradedBlocksNonHealthCommands(t *testing.T) {
 		calendars: []contract.Calendar{{ID: "cal-1", Name: "Work", Writable: true}},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -356,7 +371,7 @@ func TestVerboseIncludesTimingsMetadata(t *testing.T) {
 		},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -0,0 +1,235 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+var auditKinds = []string{"stale_recurring", "cancelled", "solo_meeting", "double_booked", "short_gap"}
+
+type auditFinding struct {
+	Kind     string    `json:"kind"`
+	Action   string    `json:"action"`
+	Title    string    `json:"title"`
+	Calendar string    `json:"calendar"`
+	Start    time.Time `json:"start"`
+	IDs      []string  `json:"ids"`
+	Detail   string    `json:"detail"`
+}
+
+type auditOptions struct {
+	Now        time.Time
+	StaleAfter time.Duration
+	MaxGap     time.Duration
+}
+
+func auditCalendar(ev contract.Event) string {
+	return firstNonEmpty(ev.CalendarName, ev.CalendarID)
+}
+
+func auditStaleRecurring(items []contract.Event, o auditOptions) []auditFinding {
+	groups := map[string][]contract.Event{}
+	order := []string{}
+	for _, ev := range items {
+		uid := eventUID(ev.ID)
+		if uid == ev.ID || ev.Status == contract.StatusCancelled {
+			continue
+		}
+		if _, ok := groups[uid]; !ok {
+			order = append(order, uid)
+		}
+		groups[uid] = append(groups[uid], ev)
+	}
+	out := []auditFinding{}
+	for _, uid := range order {
+		occ := groups[uid]
+		if len(occ) < 2 {
+			continue
+		}
+		var updated time.Time
+		ids := make([]string, 0, len(occ))
+		for _, ev := range occ {
+			if ev.UpdatedAt.After(updated) {
+				updated = ev.UpdatedAt
+			}
+			ids = append(ids, ev.ID)
+		}
+		if updated.IsZero() || o.Now.Sub(updated) < o.StaleAfter {
+			continue
+		}
+		days := int(o.Now.Sub(updated).Hours() / 24)
+		out = append(out, auditFinding{
+			Kind: "stale_recurring", Action: "delete", Title: occ[0].Title, Calendar: auditCalendar(occ[0]), Start: occ[0].Start, IDs: ids,
+			Detail: fmt.Sprintf("series %s: %d occurrences, unchanged for %d days", uid, len(occ), days),
+		})
+	}
+	return out
+}
+
+func auditBusyTimeline(items []contract.Event, o auditOptions) []auditFinding {
+	timed := []contract.Event{}
+	for _, ev := range items {
+		if ev.AllDay || ev.Status == contract.StatusCancelled || ev.Availability == contract.AvailabilityFree {
+			continue
+		}
+		timed = append(timed, ev)
+	}
+	out := []auditFinding{}
+	for _, r := range buildConflictRows(timed, false) {
+		out = append(out, auditFinding{
+			Kind: "double_booked", Action: "review", Title: r.LeftTitle + " / " + r.RightTitle, Calendar: r.LeftCalendar, Start: r.OverlapStart,
+			IDs: []string{r.LeftID, r.RightID}, Detail: fmt.Sprintf("overlap of %s", formatMinutes(r.OverlapMinutes)),
+		})
+	}
+	if o.MaxGap <= 0 {
+		return out
+	}
+	sort.SliceStable(timed, func(i, j int) bool { return timed[i].Start.Before(timed[j].Start) })
+	var last *contract.Event
+	for i := range timed {
+		ev := &timed[i]
+		if last != nil {
+			gap := ev.Start.Sub(last.End)
+			ly, lm, ld := last.End.Date()
+			ey, em, ed := ev.Start.Date()
+			if gap > 0 && gap <= o.MaxGap && ly == ey && lm == em && ld == ed {
+				out = append(out, auditFinding{
+					Kind: "short_gap", Action: "move", Title: last.Title + " → " + ev.Title, Calendar: auditCalendar(*ev), Start: last.End,
+					IDs: []string{last.ID, ev.ID}, Detail: fmt.Sprintf("%s gap between meetings", formatMinutes(int64(gap.Minutes()))),
+				})
+			}
+		}
+		if last == nil || ev.End.After(last.End) {
+			last = ev
+		}
+	}
+	return out
+}
+
+func buildAuditFindings(items []contract.Event, o auditOptions) []auditFinding {
+	out := auditStaleRecurring(items, o)
+	for _, ev := range items {
+		if ev.Status == contract.StatusCancelled {
+			out = append(out, auditFinding{Kind: "cancelled", Action: "delete", Title: ev.Title, Calendar: auditCalendar(ev), Start: ev.Start, IDs: []string{ev.ID}, Detail: "cancelled but still on the calendar"})
+			continue
+		}
+		if ev.AllDay {
+			continue
+		}
+		if people := extractAttendees(ev.Notes); len(people) == 1 {
+			out = append(out, auditFinding{Kind: "solo_meeting", Action: "review", Title: ev.Title, Calendar: auditCalendar(ev), Start: ev.Start, IDs: []string{ev.ID}, Detail: "only attendee: " + people[0]})
+		}
+	}
+	out = append(out, auditBusyTimeline(items, o)...)
+	rank := map[string]int{}
+	for i, k := range auditKinds {
+		rank[k] = i
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return rank[out[i].Kind] < rank[out[j].Kind]
+		}
+		return out[i].Start.Before(out[j].Start)
+	})
+	return out
+}
+
+func auditBatchLines(findings []auditFinding) []batchLine {
+	out := []batchLine{}
+	seen := map[string]bool{}
+	for _, f := range findings {
+		if f.Action != "delete" || len(f.IDs) == 0 {
+			continue
+		}
+		line := batchLine{Op: "delete", ID: f.IDs[0]}
+		if f.Kind == "stale_recurring" {
+			line.Scope = "future"
+		}
+		if !seen[line.ID] {
+			seen[line.ID] = true
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+func newEventsAuditCmd(opts *globalOptions) *cobra.Command {
+	var from, to, staleAfterS, maxGapS string
+	var calendars, kinds []string
+	var asBatch bool
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Report stale series, ghost and solo meetings, double-bookings, and short gaps",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "events.audit")
+			if err != nil {
+				return err
+			}
+			for _, k := range kinds {
+				if !containsString(auditKinds, k) {
+					return failWithHint(p, contract.ErrInvalidUsage, invalidField("kind", k, fmt.Errorf("invalid --kind: %s", k)), "Use "+strings.Join(auditKinds, ", "), 2)
+				}
+			}
+			staleAfter, err := timeparse.ParseDuration(staleAfterS)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			maxGap, err := timeparse.ParseDuration(maxGapS)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			f, err := buildEventFilterWithTZ(from, to, calendars, 0, ro.TZ)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			items, err := listEventsWithTimeout(ctx, be, f)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			findings := buildAuditFindings(items, auditOptions{Now: currentTime(), StaleAfter: staleAfter, MaxGap: maxGap})
+			if len(kinds) > 0 {
+				kept := []auditFinding{}
+				for _, fd := range findings {
+					if containsString(kinds, fd.Kind) {
+						kept = append(kept, fd)
+					}
+				}
+				findings = kept
+			}
+			if asBatch {
+				var b strings.Builder
+				for _, line := range auditBatchLines(findings) {
+					raw, _ := json.Marshal(line)
+					b.Write(raw)
+					b.WriteByte('\n')
+				}
+				_, _ = fmt.Fprint(c.OutOrStdout(), b.String())
+				return nil
+			}
+			byKind := map[string]int{}
+			for _, fd := range findings {
+				byKind[fd.Kind]++
+			}
+			meta := map[string]any{"count": len(findings), "events_scanned": len(items), "by_kind": byKind}
+			return successWithMeta(ctx, p, ro, findings, meta, nil)
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "today", "Range start")
+	cmd.Flags().StringVar(&to, "to", "+30d", "Range end")
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	cmd.Flags().StringSliceVar(&kinds, "kind", nil, "Only report these kinds (repeatable): "+strings.Join(auditKinds, ", "))
+	cmd.Flags().StringVar(&staleAfterS, "stale-after", "90d", "Flag recurring series not modified for this long")
+	cmd.Flags().StringVar(&maxGapS, "max-gap", "15m", "Flag gaps between meetings up to this long (0 disables)")
+	cmd.Flags().BoolVar(&asBatch, "batch", false, "Print `events batch` delete lines for delete findings instead of the report")
+	return cmd
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func auditFixture() *backend.MockBackend {
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC) }
+	old := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	ev := func(id, title string, start, end time.Time) contract.Event {
+		return contract.Event{ID: id, CalendarID: "work", CalendarName: "Work", Title: title, Start: start, End: end, UpdatedAt: at(1, 0, 0)}
+	}
+	s1, s2 := ev("s1@1772532000", "Weekly sync", at(3, 10, 0), at(3, 10, 30)), ev("s1@1773136800", "Weekly sync", at(10, 10, 0), at(10, 10, 30))
+	s1.UpdatedAt, s2.UpdatedAt = old, old
+	cancelled := ev("c1", "Old review", at(4, 9, 0), at(4, 10, 0))
+	cancelled.Status = contract.StatusCancelled
+	solo := ev("m1", "Chat", at(5, 11, 0), at(5, 11, 30))
+	solo.Notes = "Attendees: lee@example.com"
+	return backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{s1, s2, cancelled, solo,
+			ev("a1", "Planning", at(6, 13, 0), at(6, 14, 0)),
+			ev("a2", "Interview", at(6, 13, 30), at(6, 14, 30)),
+			ev("a3", "1:1", at(6, 14, 40), at(6, 15, 0)),
+		},
+	})
+}
+
+func TestEventsAudit(t *testing.T) {
+	t.Setenv("ACAL_NOW", "2026-03-02T08:00:00Z")
+	t.Cleanup(func() { pinnedNow.Store(nil) })
+	fb := auditFixture()
+	var env struct {
+		Data []auditFinding `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	args := []string{"events", "audit", "--from", "2026-03-02", "--to", "2026-03-12", "--tz", "UTC", "--json"}
+	if err := json.Unmarshal(runWithBackend(t, fb, args...), &env); err != nil {
+		t.Fatal(err)
+	}
+	kinds := []string{}
+	for _, f := range env.Data {
+		kinds = append(kinds, f.Kind)
+	}
+	if strings.Join(kinds, ",") != "stale_recurring,cancelled,solo_meeting,double_booked,short_gap" {
+		t.Fatalf("unexpected findings: %+v", env.Data)
+	}
+	if got := env.Data[0].IDs; len(got) != 2 || got[0] != "s1@1772532000" {
+		t.Fatalf("expected both stale occurrences, got %v", got)
+	}
+	if got := env.Data[4].IDs; got[0] != "a2" || got[1] != "a3" {
+		t.Fatalf("expected the gap after the later-ending meeting, got %v", got)
+	}
+
+	out := string(runWithBackend(t, fb, append(args, "--batch")...))
+	want := "{\"op\":\"delete\",\"id\":\"s1@1772532000\",\"scope\":\"future\"}\n{\"op\":\"delete\",\"id\":\"c1\"}\n"
+	if out != want {
+		t.Fatalf("unexpected batch output:\n%s", out)
+	}
+
+	if err := json.Unmarshal(runWithBackend(t, fb, append(args, "--kind", "short_gap", "--stale-after", "104w")...), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 1 || env.Data[0].Kind != "short_gap" {
+		t.Fatalf("expected --kind to narrow the report: %+v", env.Data)
+	}
+	if code := runEventsCmd(t, fb, "events", "audit", "--kind", "bogus", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for an unknown kind, got %d", code)
+	}
+}
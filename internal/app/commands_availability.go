@@ -0,0 +1,207 @@
+package app
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+type availabilityPage struct {
+	Title           string    `json:"title"`
+	Format          string    `json:"format"`
+	TZ              string    `json:"tz"`
+	From            time.Time `json:"from"`
+	To              time.Time `json:"to"`
+	DurationMinutes int64     `json:"duration_minutes"`
+	Slots           []slotRow `json:"slots"`
+	Content         string    `json:"content"`
+}
+
+func availabilityFormat(format, outPath string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "html":
+		return "html", nil
+	case "markdown", "md":
+		return "markdown", nil
+	case "":
+	default:
+		return "", invalidField("format", format, fmt.Errorf("invalid --format: %s", format))
+	}
+	switch strings.ToLower(filepath.Ext(outPath)) {
+	case ".html", ".htm":
+		return "html", nil
+	default:
+		return "markdown", nil
+	}
+}
+
+func renderAvailability(pg availabilityPage, loc *time.Location) string {
+	dayLayout := "Monday, January 2"
+	clock := func(t time.Time) string { return t.In(loc).Format("15:04") }
+	summary := fmt.Sprintf("%d-minute slots, %s to %s. Times are %s.", pg.DurationMinutes,
+		pg.From.In(loc).Format("Jan 2"), rangeLastDay(pg.To).In(loc).Format("Jan 2, 2006"), loc.String())
+
+	type dayGroup struct {
+		heading string
+		lines   []string
+	}
+	days := []dayGroup{}
+	for _, s := range pg.Slots {
+		heading := s.Start.In(loc).Format(dayLayout)
+		if len(days) == 0 || days[len(days)-1].heading != heading {
+			days = append(days, dayGroup{heading: heading})
+		}
+		d := &days[len(days)-1]
+		d.lines = append(d.lines, clock(s.Start)+"–"+clock(s.End))
+	}
+
+	var b strings.Builder
+	if pg.Format == "html" {
+		b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+		b.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+		b.WriteString("<title>" + html.EscapeString(pg.Title) + "</title>\n")
+		b.WriteString("<style>body{font-family:system-ui,sans-serif;max-width:40rem;margin:2rem auto;padding:0 1rem;line-height:1.5}li{font-variant-numeric:tabular-nums}</style>\n")
+		b.WriteString("</head>\n<body>\n")
+		b.WriteString("<h1>" + html.EscapeString(pg.Title) + "</h1>\n")
+		b.WriteString("<p>" + html.EscapeString(summary) + "</p>\n")
+		if len(days) == 0 {
+			b.WriteString("<p>No bookable times in this range.</p>\n")
+		}
+		for _, d := range days {
+			b.WriteString("<h2>" + html.EscapeString(d.heading) + "</h2>\n<ul>\n")
+			for _, l := range d.lines {
+				b.WriteString("<li>" + html.EscapeString(l) + "</li>\n")
+			}
+			b.WriteString("</ul>\n")
+		}
+		b.WriteString("</body>\n</html>\n")
+		return b.String()
+	}
+	b.WriteString("# " + escapeMarkdown(pg.Title) + "\n\n")
+	b.WriteString(summary + "\n")
+	if len(days) == 0 {
+		b.WriteString("\nNo bookable times in this range.\n")
+	}
+	for _, d := range days {
+		b.WriteString("\n## " + d.heading + "\n\n")
+		for _, l := range d.lines {
+			b.WriteString("- " + l + "\n")
+		}
+	}
+	return b.String()
+}
+
+func newAvailabilityCmd(opts *globalOptions) *cobra.Command {
+	availability := &cobra.Command{Use: "availability", Short: "Share free time"}
+
+	var calendars []string
+	var fromS, toS, between, durationS, stepS, format, outPath, displayTZ, title string
+	var includeAllDay, skipHolidays, ignoreQuiet bool
+	publish := &cobra.Command{
+		Use:   "publish",
+		Short: "Render bookable slots as a shareable HTML or Markdown page",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "availability.publish")
+			if err != nil {
+				return err
+			}
+			format, err = availabilityFormat(format, outPath)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --format html|markdown, or an --out path ending in .html or .md", 2)
+			}
+			f, err := buildEventFilterWithTZ(fromS, toS, calendars, 0, ro.TZ)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
+			}
+			dur, err := timeparse.ParseDuration(durationS)
+			if err != nil || dur <= 0 {
+				if err == nil {
+					err = fmt.Errorf("--duration must be positive")
+				}
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			step := dur
+			if strings.TrimSpace(stepS) != "" {
+				step, err = timeparse.ParseDuration(stepS)
+				if err != nil || step <= 0 {
+					if err == nil {
+						err = fmt.Errorf("--step must be positive")
+					}
+					return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+				}
+			}
+			startHour, startMinute, endHour, endMinute, err := parseBetweenRange(between)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --between HH:MM-HH:MM or 9am-5pm", 2)
+			}
+			loc := resolveLocation(ro.TZ)
+			displayLoc := loc
+			if strings.TrimSpace(displayTZ) != "" {
+				displayLoc, err = time.LoadLocation(strings.TrimSpace(displayTZ))
+				if err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, invalidField("display-tz", displayTZ, fmt.Errorf("invalid --display-tz: %w", err)), "Use an IANA zone like America/New_York", 2)
+				}
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			items, err := listEventsWithTimeout(ctx, be, f)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			slots, _ := freeSlots(buildBusyBlocks(items, includeAllDay), f.From, f.To, startHour, startMinute, endHour, endMinute, dur, step, quietHoursFor(ro, ignoreQuiet), loc)
+			if skipHolidays {
+				hs, err := loadHolidays(ctx, be, ro, f.From, f.To)
+				if err != nil {
+					return failHolidays(p, err)
+				}
+				slots = excludeHolidaySlots(slots, holidayDates(hs), loc)
+			}
+			pg := availabilityPage{
+				Title:           firstNonEmpty(strings.TrimSpace(title), "Availability"),
+				Format:          format,
+				TZ:              displayLoc.String(),
+				From:            f.From,
+				To:              f.To,
+				DurationMinutes: int64(dur.Minutes()),
+				Slots:           slots,
+			}
+			pg.Content = renderAvailability(pg, displayLoc)
+			meta := map[string]any{"count": len(slots), "format": format, "tz": pg.TZ, "events_scanned": len(items)}
+			if outPath != "" && outPath != "-" {
+				if err := writeFileAtomic(outPath, []byte(pg.Content), 0o644); err != nil {
+					return failWithHint(p, contract.ErrGeneric, err, "Check destination path permissions", 1)
+				}
+				return successWithMeta(ctx, p, ro, map[string]any{"path": outPath, "slots": len(slots)}, meta, nil)
+			}
+			// Piped output stays HTML/Markdown unless JSON is asked for.
+			if p.Mode == output.ModeJSON || p.Mode == output.ModeJSONL {
+				return successWithMeta(ctx, p, ro, pg, meta, nil)
+			}
+			_, _ = fmt.Fprint(c.OutOrStdout(), pg.Content)
+			return nil
+		},
+	}
+	publish.Flags().StringVar(&outPath, "out", "-", "Output file path (.html or .md) or - for stdout")
+	publish.Flags().StringVar(&format, "format", "", "Page format: html|markdown (default from --out extension, else markdown)")
+	publish.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	publish.Flags().StringVar(&fromS, "from", "today", "Range start")
+	publish.Flags().StringVar(&toS, "to", "+14d", "Range end")
+	publish.Flags().StringVar(&between, "between", "09:00-17:00", "Daily window as HH:MM-HH:MM or 9am-5pm")
+	publish.Flags().StringVar(&durationS, "duration", "30m", "Bookable slot length")
+	publish.Flags().StringVar(&stepS, "step", "", "Candidate step (default --duration)")
+	publish.Flags().StringVar(&displayTZ, "display-tz", "", "Timezone to show times in (default --tz)")
+	publish.Flags().StringVar(&title, "title", "", "Page heading (default Availability)")
+	publish.Flags().BoolVar(&includeAllDay, "include-all-day", false, "Include all-day events as busy")
+	publish.Flags().BoolVar(&skipHolidays, "skip-holidays", false, "Drop slots that fall on public holidays")
+	publish.Flags().BoolVar(&ignoreQuiet, "ignore-quiet-hours", false, "Offer slots that overlap quiet_hours")
+	availability.AddCommand(publish)
+	return availability
+}
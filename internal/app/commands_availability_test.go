@@ -0,0 +1,64 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestAvailabilityFormatFromFlagOrExtension(t *testing.T) {
+	cases := []struct{ format, out, want string }{
+		{"", "avail.html", "html"},
+		{"", "avail.md", "markdown"},
+		{"", "-", "markdown"},
+		{"html", "avail.md", "html"},
+	}
+	for _, c := range cases {
+		got, err := availabilityFormat(c.format, c.out)
+		if err != nil || got != c.want {
+			t.Fatalf("availabilityFormat(%q,%q)=%q,%v want %q", c.format, c.out, got, err, c.want)
+		}
+	}
+	if _, err := availabilityFormat("pdf", "x"); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+}
+
+func TestAvailabilityPublishWritesPage(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "e1", CalendarID: "work", CalendarName: "Work", Title: "Secret project", Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)},
+		},
+	})
+	out := string(runWithBackend(t, fb, "availability", "publish", "--from", "2026-03-02", "--to", "2026-03-02T12:00:00Z",
+		"--between", "09:00-12:00", "--duration", "1h", "--tz", "UTC", "--display-tz", "Europe/Berlin", "--plain"))
+	if strings.Contains(out, "Secret project") {
+		t.Fatalf("page leaked event details:\n%s", out)
+	}
+	for _, want := range []string{"# Availability", "## Monday, March 2", "- 11:00–12:00", "- 12:00–13:00", "Europe/Berlin"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "10:00–11:00") {
+		t.Fatalf("busy hour listed as bookable:\n%s", out)
+	}
+
+	path := filepath.Join(t.TempDir(), "avail.html")
+	runWithBackend(t, fb, "availability", "publish", "--from", "2026-03-02", "--to", "2026-03-02T12:00:00Z",
+		"--between", "09:00-12:00", "--duration", "1h", "--tz", "UTC", "--out", path, "--json")
+	page, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(page), "<!DOCTYPE html>") || !strings.Contains(string(page), "<li>10:00–11:00</li>") {
+		t.Fatalf("unexpected html page:\n%s", page)
+	}
+}
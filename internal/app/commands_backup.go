@@ -0,0 +1,240 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+type backupArchive struct {
+	SchemaVersion string              `json:"schema_version"`
+	CreatedAt     time.Time           `json:"created_at"`
+	From          time.Time           `json:"from"`
+	To            time.Time           `json:"to"`
+	Calendars     []contract.Calendar `json:"calendars"`
+	Events        []contract.Event    `json:"events"`
+}
+
+type backupSummary struct {
+	Path      string    `json:"path"`
+	Calendars int       `json:"calendars"`
+	Events    int       `json:"events"`
+	Bytes     int       `json:"bytes"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+}
+
+type restoreRow struct {
+	SourceID string    `json:"source_id"`
+	ID       string    `json:"id,omitempty"`
+	Calendar string    `json:"calendar"`
+	Title    string    `json:"title"`
+	Start    time.Time `json:"start"`
+	Status   string    `json:"status"`
+	Error    string    `json:"error,omitempty"`
+}
+
+func encodeBackup(a backupArchive, compress bool) ([]byte, error) {
+	raw, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if !compress {
+		return raw, nil
+	}
+	var b bytes.Buffer
+	zw := gzip.NewWriter(&b)
+	if _, err := zw.Write(raw); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func decodeBackup(raw []byte) (backupArchive, error) {
+	var a backupArchive
+	if len(raw) > 2 && raw[0] == 0x1f && raw[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return a, err
+		}
+		defer zr.Close()
+		if raw, err = io.ReadAll(zr); err != nil {
+			return a, err
+		}
+	}
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return a, fmt.Errorf("invalid backup archive: %w", err)
+	}
+	if a.SchemaVersion == "" {
+		return a, errors.New("invalid backup archive: missing schema_version")
+	}
+	return a, nil
+}
+
+func parseCalendarMap(pairs []string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, pair := range pairs {
+		from, to, ok := strings.Cut(pair, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid --calendar-map %q: want old=new", pair)
+		}
+		out[strings.ToLower(from)] = to
+	}
+	return out, nil
+}
+
+func restoreTarget(e contract.Event, cals []contract.Calendar, mapping map[string]string) (string, bool) {
+	for _, key := range []string{e.CalendarName, e.CalendarID} {
+		if to, ok := mapping[strings.ToLower(strings.TrimSpace(key))]; ok && key != "" {
+			return to, true
+		}
+	}
+	for _, c := range cals {
+		if (e.CalendarID != "" && c.ID == e.CalendarID) || (e.CalendarID == "" && c.Name == e.CalendarName) {
+			return firstNonEmpty(e.CalendarName, e.CalendarID), c.Writable
+		}
+	}
+	return firstNonEmpty(e.CalendarName, e.CalendarID), true
+}
+
+func newBackupCmd(opts *globalOptions) *cobra.Command {
+	var calendars []string
+	var fromS, toS, outPath string
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Export calendars and events to a JSON archive",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "backup")
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(outPath) == "" {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--out is required"), "Pass --out backup.json.gz", 2)
+			}
+			f, err := buildEventFilterWithTZ(fromS, toS, calendars, 0, ro.TZ)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			cals, err := listCalendarsWithTimeout(ctx, be)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			items, err := listEventsWithTimeout(ctx, be, f)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			archive := backupArchive{SchemaVersion: contract.SchemaVersion, CreatedAt: time.Now().UTC(), From: f.From, To: f.To, Calendars: cals, Events: items}
+			raw, err := encodeBackup(archive, strings.HasSuffix(strings.ToLower(outPath), ".gz"))
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Unable to encode backup", 1)
+			}
+			if err := writeFileAtomic(outPath, raw, 0o600); err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Check destination path permissions", 1)
+			}
+			summary := backupSummary{Path: outPath, Calendars: len(cals), Events: len(items), Bytes: len(raw), From: f.From, To: f.To}
+			return successWithMeta(ctx, p, ro, summary, map[string]any{"count": len(items)}, nil)
+		},
+	}
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable; default all)")
+	cmd.Flags().StringVar(&fromS, "from", "-1825d", "Range start")
+	cmd.Flags().StringVar(&toS, "to", "+1825d", "Range end")
+	cmd.Flags().StringVar(&outPath, "out", "", "Archive path (.gz suffix compresses)")
+	return cmd
+}
+
+func newRestoreCmd(opts *globalOptions) *cobra.Command {
+	var filePath string
+	var calendarMap []string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Re-create events from a backup archive",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "restore")
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(filePath) == "" {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--file is required"), "Pass --file backup.json.gz or --file -", 2)
+			}
+			mapping, err := parseCalendarMap(calendarMap)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --calendar-map old=new (repeatable)", 2)
+			}
+			var raw []byte
+			if filePath == "-" {
+				raw, err = io.ReadAll(os.Stdin)
+			} else {
+				raw, err = os.ReadFile(filePath)
+			}
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Check --file path or stdin data", 2)
+			}
+			archive, err := decodeBackup(raw)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Pass an archive written by `acal backup`", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			txID := batchTxID()
+			rows := make([]restoreRow, 0, len(archive.Events))
+			created, skipped, failed := 0, 0, 0
+			for i, e := range archive.Events {
+				target, writable := restoreTarget(e, archive.Calendars, mapping)
+				row := restoreRow{SourceID: e.ID, Calendar: target, Title: e.Title, Start: e.Start}
+				switch {
+				case !writable:
+					row.Status = "skipped"
+					row.Error = "read-only calendar; map it with --calendar-map"
+					skipped++
+				case dryRun:
+					row.Status = "planned"
+				default:
+					in := backend.EventCreateInput{Calendar: target, Title: e.Title, Start: e.Start, End: e.End, Location: e.Location, Notes: e.Notes, URL: e.URL, AllDay: e.AllDay, Status: e.Status, Sensitivity: e.Sensitivity}
+					item, addErr := addEventWithTimeout(ctx, be, in)
+					if addErr != nil {
+						row.Status = "failed"
+						row.Error = addErr.Error()
+						failed++
+						break
+					}
+					row.Status = "created"
+					created++
+					if item != nil {
+						row.ID = item.ID
+						_ = appendHistory(historyEntry{Type: "add", TxID: txID, OpID: batchOpID(i+1, "add"), EventID: item.ID, Created: item})
+					}
+				}
+				rows = append(rows, row)
+			}
+			meta := map[string]any{"count": len(rows), "created": created, "skipped": skipped, "failed": failed, "dry_run": dryRun, "tx_id": txID}
+			if failed > 0 {
+				_ = p.Success(rows, meta, nil)
+				return WrapPrinted(1, fmt.Errorf("restore completed with %d error(s)", failed))
+			}
+			return successWithMeta(ctx, p, ro, rows, meta, nil)
+		},
+	}
+	cmd.Flags().StringVar(&filePath, "file", "", "Archive path or - for stdin")
+	cmd.Flags().StringSliceVar(&calendarMap, "calendar-map", nil, "Map source calendar to target: old=new (repeatable)")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview without writing")
+	return cmd
+}
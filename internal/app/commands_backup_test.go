@@ -0,0 +1,87 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestBackupAndRestoreWithCalendarMap(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	now := time.Now().UTC().Truncate(time.Minute)
+	src := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}, {ID: "holidays", Name: "Holidays"}},
+		Events: []contract.Event{
+			{ID: "standup", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: now.Add(24 * time.Hour), End: now.Add(25 * time.Hour), Location: "Room 1", Notes: "agenda\nacal:tags=team", URL: "https://example.com"},
+			{ID: "bank", CalendarID: "holidays", CalendarName: "Holidays", Title: "Bank holiday", Start: now.Add(48 * time.Hour), End: now.Add(72 * time.Hour), AllDay: true},
+		},
+	})
+	out := filepath.Join(t.TempDir(), "backup.json.gz")
+	var summary struct {
+		Data backupSummary `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, src, "backup", "--out", out, "--json"), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Data.Events != 2 || summary.Data.Calendars != 2 {
+		t.Fatalf("unexpected summary: %+v", summary.Data)
+	}
+	raw, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive, err := decodeBackup(raw)
+	if err != nil || len(archive.Events) != 2 || archive.Events[0].Notes != "agenda\nacal:tags=team" {
+		t.Fatalf("unexpected archive: %+v (%v)", archive, err)
+	}
+
+	dst := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "personal", Name: "Personal", Writable: true}},
+	})
+	var restored struct {
+		Data []restoreRow   `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, dst, "restore", "--file", out, "--calendar-map", "work=Personal", "--json"), &restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Meta["created"] != float64(1) || restored.Meta["skipped"] != float64(1) {
+		t.Fatalf("unexpected restore meta: %v", restored.Meta)
+	}
+	var createdID string
+	for _, r := range restored.Data {
+		if r.SourceID == "standup" {
+			createdID = r.ID
+			if r.Status != "created" || r.Calendar != "Personal" {
+				t.Fatalf("unexpected standup row: %+v", r)
+			}
+		} else if r.Status != "skipped" {
+			t.Fatalf("expected read-only holiday to be skipped, got %+v", r)
+		}
+	}
+	item, err := dst.GetEventByID(t.Context(), createdID)
+	if err != nil || item.CalendarName != "Personal" || item.Location != "Room 1" || item.URL != "https://example.com" {
+		t.Fatalf("unexpected restored event: %+v (%v)", item, err)
+	}
+}
+
+func TestRestoreRejectsBadInput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "bogus.json")
+	if err := os.WriteFile(path, []byte(`{"events":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fb := &scopeCaptureBackend{}
+	if code := runEventsCmd(t, fb, "restore", "--file", path, "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for archive without schema_version, got %d", code)
+	}
+	if code := runEventsCmd(t, fb, "restore", "--file", path, "--calendar-map", "Work", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for malformed calendar map, got %d", code)
+	}
+}
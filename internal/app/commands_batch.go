@@ -3,12 +3,14 @@ package app
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/agis/acal/internal/backend"
 	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
 	"github.com/agis/acal/internal/timeparse"
 	"github.com/spf13/cobra"
 )
@@ -31,6 +33,7 @@ type batchLine struct {
 type batchExecResult struct {
 	View    map[string]any
 	History *historyEntry
+	Preview *dryRunPreview
 }
 
 func newEventsBatchCmd(opts *globalOptions) *cobra.Command {
@@ -38,6 +41,8 @@ func newEventsBatchCmd(opts *globalOptions) *cobra.Command {
 	var dryRun bool
 	var continueOnError bool
 	var strict bool
+	var createdByAcal bool
+	var review bool
 	cmd := &cobra.Command{
 		Use:   "batch",
 		Short: "Apply add/update/delete operations from JSONL",
@@ -56,32 +61,75 @@ func newEventsBatchCmd(opts *globalOptions) *cobra.Command {
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Check file path or stdin", 2)
 			}
+			lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+			var warnings []contract.Warning
+			var lineNos []int
+			dropped := 0
+			if review {
+				if ro.NoInput {
+					return failWithHint(p, contract.ErrInvalidUsage, errors.New("--review cannot run with --no-input"), "Drop --no-input, or preview with --dry-run instead", 2)
+				}
+				ops := []reviewLine{}
+				for i, line := range lines {
+					if s := strings.TrimSpace(line); s != "" {
+						ops = append(ops, reviewLine{Line: i + 1, Op: s})
+					}
+				}
+				kept, err := reviewInEditor(ops, c.ErrOrStderr())
+				if err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Set $EDITOR and run from a terminal, or preview with --dry-run instead", 2)
+				}
+				lines, lineNos, dropped = make([]string, len(kept)), make([]int, len(kept)), len(ops)-len(kept)
+				for i, k := range kept {
+					lines[i], lineNos[i] = k.Op, k.Line
+				}
+				if len(kept) == 0 {
+					warnings = append(warnings, contract.Warning{Code: contract.WarnNothingToRun, Message: "review kept no operations; nothing was run"})
+				}
+			}
 			loc := resolveLocation(ro.TZ)
 			ctx, cancel := commandContext(ro)
 			defer cancel()
+			var uids map[string]bool
+			if createdByAcal {
+				if uids, err = createdEventUIDs(); err != nil {
+					return failWithHint(p, contract.ErrGeneric, err, "Check history file permissions (`acal state path`)", 1)
+				}
+			}
 			txID := batchTxID()
-			lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
 			results := make([]map[string]any, 0)
+			previews := []dryRunPreview{}
 			errorsCount := 0
 			for i, line := range lines {
 				s := strings.TrimSpace(line)
 				if s == "" {
 					continue
 				}
+				n := i + 1
+				if lineNos != nil {
+					n = lineNos[i]
+				}
 				var row batchLine
 				if err := json.Unmarshal([]byte(s), &row); err != nil {
 					errorsCount++
-					results = append(results, map[string]any{"tx_id": txID, "op_id": batchOpID(i+1, "parse"), "line": i + 1, "ok": false, "error": "invalid json"})
+					results = append(results, map[string]any{"tx_id": txID, "op_id": batchOpID(n, "parse"), "line": n, "ok": false, "error": "invalid json"})
 					if !continueOnError {
 						break
 					}
 					continue
 				}
-				opID := batchOpID(i+1, row.Op)
-				execRes, execErr := executeBatchLine(ctx, be, row, loc, dryRun)
+				opID := batchOpID(n, row.Op)
+				var execRes batchExecResult
+				var execErr error
+				if op := strings.ToLower(strings.TrimSpace(row.Op)); uids != nil && (op == "update" || op == "delete") {
+					execErr = checkCreatedByAcal(uids, resolveAlias(row.ID))
+				}
+				if execErr == nil {
+					execRes, execErr = executeBatchLine(ctx, be, row, loc, dryRun)
+				}
 				if execErr != nil {
 					errorsCount++
-					results = append(results, map[string]any{"tx_id": txID, "op_id": opID, "line": i + 1, "op": row.Op, "ok": false, "error": execErr.Error()})
+					results = append(results, map[string]any{"tx_id": txID, "op_id": opID, "line": n, "op": row.Op, "ok": false, "error": execErr.Error()})
 					if !continueOnError {
 						break
 					}
@@ -92,42 +140,68 @@ func newEventsBatchCmd(opts *globalOptions) *cobra.Command {
 					execRes.History.OpID = opID
 					if histErr := appendHistory(*execRes.History); histErr != nil {
 						errorsCount++
-						results = append(results, map[string]any{"tx_id": txID, "op_id": opID, "line": i + 1, "op": row.Op, "ok": false, "error": "failed to append history"})
+						results = append(results, map[string]any{"tx_id": txID, "op_id": opID, "line": n, "op": row.Op, "ok": false, "error": "failed to append history"})
 						if !continueOnError {
 							break
 						}
 						continue
 					}
 				}
+				if uids != nil && execRes.History != nil && execRes.History.Type == "add" {
+					uids[eventUID(execRes.History.EventID)] = true
+				}
 				res := execRes.View
+				if execRes.Preview != nil {
+					pv := finishPreviews(p, []dryRunPreview{*execRes.Preview})[0]
+					res["before"], res["after"], res["changes"] = pv.Before, pv.After, pv.Changes
+					previews = append(previews, pv)
+				}
 				res["tx_id"] = txID
-				res["op_id"] = batchOpID(i+1, row.Op)
-				res["line"] = i + 1
+				res["op_id"] = batchOpID(n, row.Op)
+				res["line"] = n
 				res["ok"] = true
 				results = append(results, res)
 			}
 			meta := map[string]any{"count": len(results), "errors": errorsCount, "dry_run": dryRun, "tx_id": txID}
+			if review {
+				meta["reviewed"], meta["review_dropped"] = true, dropped
+			}
+			if dryRun && p.EffectiveSuccessMode() == output.ModePlain {
+				renderDryRun(p.Out, previews, p.FormatTime)
+				for _, r := range results {
+					if r["ok"] == false {
+						_, _ = fmt.Fprintf(p.Out, "! line %v: %v\n", r["line"], r["error"])
+					}
+				}
+				if errorsCount > 0 {
+					return WrapPrinted(1, fmt.Errorf("batch completed with %d error(s)", errorsCount))
+				}
+				return nil
+			}
 			if errorsCount > 0 {
-				_ = p.Success(results, meta, nil)
+				_ = p.Success(results, meta, commandWarnings(ctx, warnings))
 				return WrapPrinted(1, fmt.Errorf("batch completed with %d error(s)", errorsCount))
 			}
-			return successWithMeta(ctx, p, ro, results, meta, nil)
+			return successWithMeta(ctx, p, ro, results, meta, warnings)
 		},
 	}
 	cmd.Flags().StringVar(&filePath, "file", "", "JSONL file path or - for stdin")
+	cmd.Flags().BoolVar(&review, "review", false, "Open the operations in $EDITOR first and run only the lines kept")
 	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview without writing")
 	cmd.Flags().BoolVar(&continueOnError, "continue-on-error", true, "Continue processing after row errors")
 	cmd.Flags().BoolVar(&strict, "strict", false, "Fail fast on first row error")
+	cmd.Flags().BoolVar(&createdByAcal, "created-by-acal", false, "Reject update/delete rows for events not created through acal")
 	return cmd
 }
 
 func executeBatchLine(ctx context.Context, be backend.Backend, row batchLine, loc *time.Location, dryRun bool) (batchExecResult, error) {
+	row.ID = resolveAlias(row.ID)
 	switch strings.ToLower(strings.TrimSpace(row.Op)) {
 	case "add":
 		if strings.TrimSpace(row.Calendar) == "" || row.Title == nil || row.Start == nil {
 			return batchExecResult{}, fmt.Errorf("add requires calendar, title, start")
 		}
-		start, err := timeparse.ParseDateTime(*row.Start, time.Now(), loc)
+		start, err := timeparse.ParseDateTime(*row.Start, currentTime(), loc)
 		if err != nil {
 			return batchExecResult{}, fmt.Errorf("invalid add.start")
 		}
@@ -149,7 +223,8 @@ func executeBatchLine(ctx context.Context, be backend.Backend, row batchLine, lo
 			in.AllDay = *row.AllDay
 		}
 		if dryRun {
-			return batchExecResult{View: map[string]any{"op": "add", "input": in}}, nil
+			pv := previewAdd(in)
+			return batchExecResult{View: map[string]any{"op": "add"}, Preview: &pv}, nil
 		}
 		ev, err := addEventWithTimeout(ctx, be, in)
 		if err != nil {
@@ -185,14 +260,14 @@ func executeBatchLine(ctx context.Context, be backend.Backend, row batchLine, lo
 			in.AllDay = row.AllDay
 		}
 		if row.Start != nil {
-			ts, parseErr := timeparse.ParseDateTime(*row.Start, time.Now(), loc)
+			ts, parseErr := timeparse.ParseDateTime(*row.Start, currentTime(), loc)
 			if parseErr != nil {
 				return batchExecResult{}, fmt.Errorf("invalid update.start")
 			}
 			in.Start = &ts
 		}
 		if row.End != nil || row.Duration != nil {
-			base := time.Now()
+			base := currentTime()
 			if in.Start != nil {
 				base = *in.Start
 			}
@@ -202,13 +277,14 @@ func executeBatchLine(ctx context.Context, be backend.Backend, row batchLine, lo
 			}
 			in.End = &end
 		}
-		if dryRun {
-			return batchExecResult{View: map[string]any{"op": "update", "id": row.ID, "input": in}}, nil
-		}
 		prev, err := getEventByIDWithTimeout(ctx, be, row.ID)
 		if err != nil {
 			return batchExecResult{}, fmt.Errorf("unable to snapshot event before update: %w", err)
 		}
+		if dryRun {
+			pv := previewUpdate(prev, in)
+			return batchExecResult{View: map[string]any{"op": "update", "id": row.ID}, Preview: &pv}, nil
+		}
 		next, err := updateEventWithTimeout(ctx, be, row.ID, in)
 		if err != nil {
 			return batchExecResult{}, err
@@ -225,13 +301,14 @@ func executeBatchLine(ctx context.Context, be backend.Backend, row batchLine, lo
 		if err != nil {
 			return batchExecResult{}, err
 		}
-		if dryRun {
-			return batchExecResult{View: map[string]any{"op": "delete", "id": row.ID, "scope": scope}}, nil
-		}
 		ev, err := getEventByIDWithTimeout(ctx, be, row.ID)
 		if err != nil {
 			return batchExecResult{}, fmt.Errorf("unable to snapshot event before delete: %w", err)
 		}
+		if dryRun {
+			pv := previewDelete(ev)
+			return batchExecResult{View: map[string]any{"op": "delete", "id": row.ID, "scope": scope}, Preview: &pv}, nil
+		}
 		if err := deleteEventWithTimeout(ctx, be, row.ID, scope); err != nil {
 			return batchExecResult{}, err
 		}
@@ -246,7 +323,7 @@ func executeBatchLine(ctx context.Context, be backend.Backend, row batchLine, lo
 
 func resolveBatchEnd(row batchLine, start time.Time, loc *time.Location) (time.Time, error) {
 	if row.End != nil {
-		end, err := timeparse.ParseDateTime(*row.End, time.Now(), loc)
+		end, err := timeparse.ParseDateTime(*row.End, currentTime(), loc)
 		if err != nil {
 			return time.Time{}, fmt.Errorf("invalid end")
 		}
@@ -256,7 +333,7 @@ func resolveBatchEnd(row batchLine, start time.Time, loc *time.Location) (time.T
 		return end, nil
 	}
 	if row.Duration != nil {
-		d, err := time.ParseDuration(*row.Duration)
+		d, err := timeparse.ParseDuration(*row.Duration)
 		if err != nil || d <= 0 {
 			return time.Time{}, fmt.Errorf("invalid duration")
 		}
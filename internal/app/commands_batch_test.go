@@ -30,12 +30,12 @@ func TestExecuteBatchLineAddDryRun(t *testing.T) {
 func TestEventsBatchDryRun(t *testing.T) {
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	f := filepath.Join(t.TempDir(), "ops.jsonl")
-	content := "{\"op\":\"add\",\"calendar\":\"Work\",\"title\":\"Plan\",\"start\":\"2026-02-20T09:00\",\"duration\":\"30m\"}\n" +
-		"{\"op\":\"delete\",\"id\":\"evt@792417600\"}\n"
+	content := "{\"op\":\"delete\",\"id\":\"evt@792417600\"}\n" +
+		"{\"op\":\"add\",\"calendar\":\"Work\",\"title\":\"Plan\",\"start\":\"2026-02-20T09:00\",\"duration\":\"30m\"}\n"
 	if err := os.WriteFile(f, []byte(content), 0o644); err != nil {
 		t.Fatalf("write failed: %v", err)
 	}
@@ -56,7 +56,7 @@ func TestEventsBatchDryRun(t *testing.T) {
 func TestEventsBatchMalformedJSONL(t *testing.T) {
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	f := filepath.Join(t.TempDir(), "ops.jsonl")
@@ -77,7 +77,7 @@ func TestEventsBatchMalformedJSONL(t *testing.T) {
 func TestEventsBatchStrictFailsFast(t *testing.T) {
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	f := filepath.Join(t.TempDir(), "ops.jsonl")
@@ -99,7 +99,7 @@ func TestEventsBatchStrictFailsFast(t *testing.T) {
 func TestEventsBatchIncludesOpID(t *testing.T) {
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	f := filepath.Join(t.TempDir(), "ops.jsonl")
@@ -139,6 +139,7 @@ func TestEventsBatchIncludesOpID(t *testing.T) {
 func TestEventsBatchWritesHistoryWithTxAndOp(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
 
 	base := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
 	fb := &scopeCaptureBackend{
@@ -151,7 +152,7 @@ func TestEventsBatchWritesHistoryWithTxAndOp(t *testing.T) {
 		},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	f := filepath.Join(t.TempDir(), "ops.jsonl")
@@ -179,3 +180,65 @@ func TestEventsBatchWritesHistoryWithTxAndOp(t *testing.T) {
 		t.Fatalf("expected tx/op identifiers in history: %+v", entries[0])
 	}
 }
+
+func TestEventsBatchReviewRunsKeptLines(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	fb := &scopeCaptureBackend{}
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+	origTerminal := openReviewTerminal
+	openReviewTerminal = func() (*os.File, error) { return os.Open(os.DevNull) }
+	t.Cleanup(func() { openReviewTerminal = origTerminal })
+
+	dir := t.TempDir()
+	editor := filepath.Join(dir, "editor.sh")
+	script := "#!/bin/sh\ngrep -v delete \"$1\" > \"$1.tmp\" && mv \"$1.tmp\" \"$1\"\n"
+	if err := os.WriteFile(editor, []byte(script), 0o755); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", editor)
+
+	f := filepath.Join(dir, "ops.jsonl")
+	content := "{\"op\":\"delete\",\"id\":\"evt@792417600\"}\n" +
+		"{\"op\":\"add\",\"calendar\":\"Work\",\"title\":\"Plan\",\"start\":\"2026-02-20T09:00\",\"duration\":\"30m\"}\n"
+	if err := os.WriteFile(f, []byte(content), 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	cmd := NewRootCommand()
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"events", "batch", "--file", f, "--review", "--tz", "UTC", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if fb.addCalls != 1 || fb.deleteCalls != 0 {
+		t.Fatalf("expected only the kept add to run, got adds=%d deletes=%d", fb.addCalls, fb.deleteCalls)
+	}
+	var env struct {
+		Data []map[string]any `json:"data"`
+		Meta map[string]any   `json:"meta"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &env); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if env.Meta["reviewed"] != true || env.Meta["review_dropped"] != float64(1) {
+		t.Fatalf("unexpected review meta: %#v", env.Meta)
+	}
+	if len(env.Data) != 1 || env.Data[0]["line"] != float64(2) {
+		t.Fatalf("expected the kept add to report input line 2, got %#v", env.Data)
+	}
+
+	cmd = NewRootCommand()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"events", "batch", "--file", f, "--review", "--no-input", "--json"})
+	if code := ExitCode(cmd.Execute()); code != 2 {
+		t.Fatalf("expected exit code 2 with --no-input, got %d", code)
+	}
+}
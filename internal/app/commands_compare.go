@@ -0,0 +1,207 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+type compareRow struct {
+	Group           string `json:"group"`
+	Key             string `json:"key"`
+	BaselineCount   int    `json:"baseline_count"`
+	CurrentCount    int    `json:"current_count"`
+	CountDelta      int    `json:"count_delta"`
+	BaselineMinutes int64  `json:"baseline_minutes"`
+	CurrentMinutes  int64  `json:"current_minutes"`
+	MinutesDelta    int64  `json:"minutes_delta"`
+	Trend           string `json:"trend"`
+}
+
+type titlePattern struct {
+	raw string
+	re  *regexp.Regexp
+}
+
+type loadTally struct {
+	count   int
+	minutes int64
+}
+
+func parseTitlePatterns(values []string) ([]titlePattern, error) {
+	out := make([]titlePattern, 0, len(values))
+	for _, v := range values {
+		re, err := regexp.Compile("(?i)" + v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --title-pattern %q: %w", v, err)
+		}
+		out = append(out, titlePattern{raw: v, re: re})
+	}
+	return out, nil
+}
+
+func tallyLoad(items []contract.Event, from, to time.Time, patterns []titlePattern) map[[2]string]loadTally {
+	out := map[[2]string]loadTally{}
+	add := func(group, key string, minutes int64) {
+		t := out[[2]string{group, key}]
+		t.count++
+		t.minutes += minutes
+		out[[2]string{group, key}] = t
+	}
+	for _, e := range items {
+		if e.AllDay || e.Availability == contract.AvailabilityFree || e.Status == contract.StatusCancelled {
+			continue
+		}
+		start, end := maxTime(e.Start, from), minTime(e.End, to)
+		if !start.Before(end) {
+			continue
+		}
+		minutes := int64(end.Sub(start).Minutes())
+		add("total", "all", minutes)
+		add("calendar", firstNonEmpty(e.CalendarName, e.CalendarID), minutes)
+		for _, p := range patterns {
+			if p.re.MatchString(e.Title) {
+				add("pattern", p.raw, minutes)
+			}
+		}
+	}
+	return out
+}
+
+func compareLoads(baseline, current map[[2]string]loadTally, patterns []titlePattern) []compareRow {
+	keys := map[[2]string]bool{{"total", "all"}: true}
+	for k := range baseline {
+		keys[k] = true
+	}
+	for k := range current {
+		keys[k] = true
+	}
+	for _, p := range patterns {
+		keys[[2]string{"pattern", p.raw}] = true
+	}
+	groupOrder := map[string]int{"total": 0, "calendar": 1, "pattern": 2}
+	patternOrder := map[string]int{}
+	for i, p := range patterns {
+		patternOrder[p.raw] = i
+	}
+	sorted := make([][2]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a[0] != b[0] {
+			return groupOrder[a[0]] < groupOrder[b[0]]
+		}
+		if a[0] == "pattern" {
+			return patternOrder[a[1]] < patternOrder[b[1]]
+		}
+		return a[1] < b[1]
+	})
+	rows := make([]compareRow, 0, len(sorted))
+	for _, k := range sorted {
+		b, c := baseline[k], current[k]
+		row := compareRow{
+			Group:           k[0],
+			Key:             k[1],
+			BaselineCount:   b.count,
+			CurrentCount:    c.count,
+			CountDelta:      c.count - b.count,
+			BaselineMinutes: b.minutes,
+			CurrentMinutes:  c.minutes,
+			MinutesDelta:    c.minutes - b.minutes,
+			Trend:           "flat",
+		}
+		switch {
+		case row.MinutesDelta > 0 || (row.MinutesDelta == 0 && row.CountDelta > 0):
+			row.Trend = "up"
+		case row.MinutesDelta < 0 || (row.MinutesDelta == 0 && row.CountDelta < 0):
+			row.Trend = "down"
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func newCompareCmd(opts *globalOptions) *cobra.Command {
+	var of, weekStart, fromS, toS, baseFromS, baseToS string
+	var calendars, patternsS []string
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare meeting load between two ranges (default: this week vs last week)",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "compare")
+			if err != nil {
+				return err
+			}
+			patterns, err := parseTitlePatterns(patternsS)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use a Go regular expression, e.g. --title-pattern '1:1|one-on-one'", 2)
+			}
+			loc := resolveLocation(ro.TZ)
+			var current, baseline backend.EventFilter
+			if c.Flags().Changed("from") || c.Flags().Changed("to") {
+				if current, err = buildEventFilterWithTZ(fromS, toS, calendars, 0, ro.TZ); err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Pass both --from and --to", 2)
+				}
+			} else {
+				anchor, err := timeparse.ParseDateTime(of, currentTime(), loc)
+				if err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Use --of as today, tomorrow, +Nd, or YYYY-MM-DD", 2)
+				}
+				ws, err := parseWeekStart(weekStart)
+				if err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Use --week-start monday|sunday|saturday", 2)
+				}
+				current = backend.EventFilter{Calendars: calendars}
+				current.From, current.To = weekBounds(anchor, ws)
+				baseline = backend.EventFilter{Calendars: calendars}
+				baseline.From, baseline.To = weekBounds(current.From.AddDate(0, 0, -7), ws)
+			}
+			if c.Flags().Changed("baseline-from") || c.Flags().Changed("baseline-to") {
+				if baseline, err = buildEventFilterWithTZ(baseFromS, baseToS, calendars, 0, ro.TZ); err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Pass both --baseline-from and --baseline-to", 2)
+				}
+			} else if baseline.From.IsZero() {
+				span := current.To.Sub(current.From)
+				baseline = backend.EventFilter{From: current.From.Add(-span), To: current.From, Calendars: calendars}
+			}
+			current.Overlap, baseline.Overlap = true, true
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			currentItems, err := listEventsWithTimeout(ctx, be, current)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			baselineItems, err := listEventsWithTimeout(ctx, be, baseline)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			rows := compareLoads(tallyLoad(baselineItems, baseline.From, baseline.To, patterns), tallyLoad(currentItems, current.From, current.To, patterns), patterns)
+			meta := map[string]any{
+				"count":         len(rows),
+				"from":          current.From,
+				"to":            current.To,
+				"baseline_from": baseline.From,
+				"baseline_to":   baseline.To,
+			}
+			return successWithMeta(ctx, p, ro, rows, meta, nil)
+		},
+	}
+	cmd.Flags().StringVar(&of, "of", "today", "Date selector within the current week")
+	cmd.Flags().StringVar(&weekStart, "week-start", "", "Week start day: monday|sunday|saturday (default week_start config, then locale)")
+	cmd.Flags().StringVar(&fromS, "from", "", "Current range start (overrides --of)")
+	cmd.Flags().StringVar(&toS, "to", "", "Current range end (overrides --of)")
+	cmd.Flags().StringVar(&baseFromS, "baseline-from", "", "Baseline range start (default: the equal-length range before --from)")
+	cmd.Flags().StringVar(&baseToS, "baseline-to", "", "Baseline range end")
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	cmd.Flags().StringArrayVar(&patternsS, "title-pattern", nil, "Also group by title regex, case-insensitive (repeatable)")
+	return cmd
+}
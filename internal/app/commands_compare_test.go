@@ -0,0 +1,78 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestCompareLoads(t *testing.T) {
+	patterns, err := parseTitlePatterns([]string{"1:1|one-on-one", "standup"})
+	if err != nil {
+		t.Fatalf("parseTitlePatterns failed: %v", err)
+	}
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7).Add(-time.Second)
+	at := func(day, h int) time.Time { return from.AddDate(0, 0, day).Add(time.Duration(h) * time.Hour) }
+	baseline := tallyLoad([]contract.Event{
+		{CalendarName: "Work", Title: "Standup", Start: at(0, 9), End: at(0, 10)},
+		{CalendarName: "Work", Title: "1:1 Ann", Start: at(1, 9), End: at(1, 10)},
+	}, from, to, patterns)
+	current := tallyLoad([]contract.Event{
+		{CalendarName: "Work", Title: "Standup", Start: at(0, 9), End: at(0, 10)},
+		{CalendarName: "Work", Title: "One-on-one Bo", Start: at(1, 9), End: at(1, 11)},
+		{CalendarName: "Home", Title: "Dentist", Start: at(2, 9), End: at(2, 10)},
+		{CalendarName: "Work", Title: "Offsite", Start: at(3, 0), End: at(4, 0), AllDay: true},
+		{CalendarName: "Work", Title: "Focus", Start: at(3, 9), End: at(3, 12), Availability: contract.AvailabilityFree},
+		{CalendarName: "Work", Title: "Late", Start: at(6, 23), End: at(7, 1)},
+	}, from, to, patterns)
+
+	rows := compareLoads(baseline, current, patterns)
+	want := []compareRow{
+		{Group: "total", Key: "all", BaselineCount: 2, CurrentCount: 4, CountDelta: 2, BaselineMinutes: 120, CurrentMinutes: 299, MinutesDelta: 179, Trend: "up"},
+		{Group: "calendar", Key: "Home", CurrentCount: 1, CountDelta: 1, CurrentMinutes: 60, MinutesDelta: 60, Trend: "up"},
+		{Group: "calendar", Key: "Work", BaselineCount: 2, CurrentCount: 3, CountDelta: 1, BaselineMinutes: 120, CurrentMinutes: 239, MinutesDelta: 119, Trend: "up"},
+		{Group: "pattern", Key: "1:1|one-on-one", BaselineCount: 1, CurrentCount: 1, BaselineMinutes: 60, CurrentMinutes: 120, MinutesDelta: 60, Trend: "up"},
+		{Group: "pattern", Key: "standup", BaselineCount: 1, CurrentCount: 1, BaselineMinutes: 60, CurrentMinutes: 60, Trend: "flat"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Fatalf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+	if _, err := parseTitlePatterns([]string{"("}); err == nil {
+		t.Fatal("expected invalid pattern error")
+	}
+}
+
+func TestCompareCmdDefaultsToPreviousWeek(t *testing.T) {
+	thisWeek := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	lastWeek := thisWeek.AddDate(0, 0, -7)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Sync", Start: lastWeek, End: lastWeek.Add(time.Hour)},
+			{ID: "b", CalendarID: "work", CalendarName: "Work", Title: "Sync", Start: thisWeek, End: thisWeek.Add(30 * time.Minute)},
+		},
+	})
+	var env struct {
+		Data []compareRow   `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	out := runWithBackend(t, fb, "compare", "--of", "2026-03-04", "--tz", "UTC", "--json")
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(env.Data) != 2 || env.Data[0].MinutesDelta != -30 || env.Data[0].Trend != "down" {
+		t.Fatalf("unexpected rows: %+v", env.Data)
+	}
+	if env.Meta["baseline_from"] != "2026-02-23T00:00:00Z" || env.Meta["from"] != "2026-03-02T00:00:00Z" {
+		t.Fatalf("unexpected ranges: %+v", env.Meta)
+	}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+func sortDeletedEvents(items []backend.DeletedEvent) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.DeletedAt.IsZero() != b.DeletedAt.IsZero() {
+			return b.DeletedAt.IsZero()
+		}
+		if !a.DeletedAt.Equal(b.DeletedAt) {
+			return a.DeletedAt.After(b.DeletedAt)
+		}
+		return a.Start.Before(b.Start)
+	})
+}
+
+func newEventsDeletedCmd(opts *globalOptions) *cobra.Command {
+	var sinceS string
+	var calendars []string
+	cmd := &cobra.Command{
+		Use:   "deleted",
+		Short: "List events recently removed from calendars",
+		Long:  "List events recently removed from calendars, including ones deleted on other devices or by organizers. On macOS this reads the tombstones the Calendar database keeps until changes sync, so it only reaches back a short while and may lack a title or time for some events.",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "events.deleted")
+			if err != nil {
+				return err
+			}
+			d, err := timeparse.ParseDuration(sinceS)
+			if err == nil && d <= 0 {
+				err = errors.New("--since must be positive")
+			}
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			since := currentTime().Add(-d)
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			items, err := listDeletedEventsWithTimeout(ctx, be, since)
+			if errors.Is(err, backend.ErrDeletedUnsupported) {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Events deleted through acal are listed by `acal events trash`", 6)
+			}
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			if len(calendars) > 0 {
+				kept := []backend.DeletedEvent{}
+				for _, it := range items {
+					if namesCalendar(calendars, contract.Calendar{ID: it.CalendarID, Name: it.CalendarName}) {
+						kept = append(kept, it)
+					}
+				}
+				items = kept
+			}
+			sortDeletedEvents(items)
+			undated := 0
+			for _, it := range items {
+				if it.DeletedAt.IsZero() {
+					undated++
+				}
+			}
+			var warnings []contract.Warning
+			if undated > 0 {
+				warnings = append(warnings, contract.Warning{Code: contract.WarnDeletionUndated, Message: fmt.Sprintf("%d deletion(s) have no recorded time and are listed regardless of --since", undated)})
+			}
+			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items), "since": since}, warnings)
+		},
+	}
+	cmd.Flags().StringVar(&sinceS, "since", "7d", "How far back to look (e.g. 24h, 7d)")
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	return cmd
+}
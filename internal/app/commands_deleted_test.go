@@ -0,0 +1,56 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventsDeleted(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}, {ID: "home", Name: "Home", Writable: true}},
+		Events: []contract.Event{
+			{ID: "sync", CalendarID: "work", CalendarName: "Work", Title: "Sync", Start: start, End: start.Add(time.Hour)},
+		},
+		Deleted: []backend.DeletedEvent{
+			{ID: "old", CalendarID: "work", CalendarName: "Work", Title: "Old", DeletedAt: time.Now().Add(-30 * 24 * time.Hour)},
+			{ID: "bare", CalendarID: "home", CalendarName: "Home"},
+		},
+	})
+	runWithBackend(t, fb, "events", "delete", "sync", "--force", "--json")
+
+	var env struct {
+		Data     []backend.DeletedEvent `json:"data"`
+		Meta     map[string]any         `json:"meta"`
+		Warnings []string               `json:"warnings"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "deleted", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 2 || env.Data[0].ID != "sync" || env.Data[0].Title != "Sync" || !env.Data[0].Start.Equal(start) || env.Data[1].ID != "bare" {
+		t.Fatalf("unexpected deleted events: %+v", env.Data)
+	}
+	if env.Meta["count"] != float64(2) || len(env.Warnings) != 1 {
+		t.Fatalf("unexpected meta/warnings: %+v %v", env.Meta, env.Warnings)
+	}
+
+	env.Meta = nil
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "deleted", "--since", "60d", "--calendar", "Work", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 2 || env.Data[0].ID != "sync" || env.Data[1].ID != "old" {
+		t.Fatalf("unexpected filtered deleted events: %+v", env.Data)
+	}
+
+	if code := runEventsCmd(t, &scopeCaptureBackend{}, "events", "deleted", "--json"); code != 6 {
+		t.Fatalf("expected exit 6 without tombstone support, got %d", code)
+	}
+	if code := runEventsCmd(t, fb, "events", "deleted", "--since", "soon", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for bad --since, got %d", code)
+	}
+}
@@ -0,0 +1,292 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type commandDescription struct {
+	Name         string            `json:"name"`
+	Usage        string            `json:"usage"`
+	Short        string            `json:"short"`
+	Aliases      []string          `json:"aliases,omitempty"`
+	Args         []argDescription  `json:"args"`
+	Flags        []flagDescription `json:"flags"`
+	Examples     []string          `json:"examples,omitempty"`
+	Constraints  []string          `json:"constraints,omitempty"`
+	Subcommands  []string          `json:"subcommands,omitempty"`
+	OutputSchema string            `json:"output_schema,omitempty"`
+}
+
+type argDescription struct {
+	Name       string `json:"name"`
+	Required   bool   `json:"required"`
+	Repeatable bool   `json:"repeatable,omitempty"`
+}
+
+type flagDescription struct {
+	Name       string   `json:"name"`
+	Shorthand  string   `json:"shorthand,omitempty"`
+	Type       string   `json:"type"`
+	Default    string   `json:"default,omitempty"`
+	Usage      string   `json:"usage"`
+	Repeatable bool     `json:"repeatable,omitempty"`
+	Choices    []string `json:"choices,omitempty"`
+	Required   bool     `json:"required,omitempty"`
+	Global     bool     `json:"global,omitempty"`
+}
+
+type describeSpec struct {
+	Required    []string
+	Constraints []string
+	Examples    []string
+}
+
+var describeSpecs = map[string]describeSpec{
+	"events.list": {
+		Constraints: []string{"--to must not be earlier than --from", "--range sets --from and --to together and cannot be combined with them"},
+		Examples:    []string{"acal events list --from today --to +7d --json", "acal events list --range last-month --calendar Work --json"},
+	},
+	"events.search": {
+		Constraints: []string{"--top must not be negative", "--rank and --top match query words anywhere in title, location, or notes rather than the whole query"},
+		Examples:    []string{"acal events search standup --from today --to +7d --json", `acal events search "the sync with Alex" --top 3 --json`},
+	},
+	"events.add": {
+		Required:    []string{"calendar", "title", "start"},
+		Constraints: []string{"use either --end or --duration, not both", "--end must be after --start", "--force only applies with --no-conflict", "--ignore-quiet-hours only applies with --no-conflict"},
+		Examples:    []string{`acal events add --calendar Work --title "Planning" --start "2026-03-03 10:00" --duration 45m --json`, `acal events add --calendar Work --title "Offsite" --start 2026-03-10 --all-day --json`},
+	},
+	"events.update": {
+		Constraints: []string{"use either --end or --duration, not both"},
+		Examples:    []string{`acal events update <event-id> --title "Planning (moved)" --start "2026-03-03 11:00" --json`, "acal events update <event-id> --scope this --location 'Room 2' --dry-run --json"},
+	},
+	"events.move": {
+		Constraints: []string{"use exactly one of --to, --by, or --to-next-free", "--end cannot be combined with --to-next-free"},
+		Examples:    []string{"acal events move <event-id> --by 30m --json", "acal events move <event-id> --to-next-free --between 9am-5pm --json"},
+	},
+	"events.delete": {
+		Constraints: []string{"--soft and --hard are mutually exclusive", "non-interactive delete requires --force or --confirm <event-id>"},
+		Examples:    []string{"acal events delete <event-id> --force --json", "acal events delete <event-id> --confirm <event-id> --scope future --json"},
+	},
+	"events.copy": {
+		Required: []string{"to"},
+		Examples: []string{"acal events copy <event-id> --to 'next monday 10:00' --json"},
+	},
+	"events.split": {
+		Constraints: []string{"use exactly one of --at or --after"},
+		Examples:    []string{"acal events split <event-id> --at 14:00 --number --json"},
+	},
+	"events.reveal": {
+		Constraints: []string{"--app must be calendar or fantastical", "opening needs macOS; --dry-run only prints the URL"},
+		Examples:    []string{"acal events reveal @next", "acal events reveal <event-id> --app fantastical --dry-run --json"},
+	},
+	"handle": {
+		Constraints: []string{"the URL scheme must be x-acal", "actions: add, query, quick-add, reveal, search, show", "only the listed parameters of each action are accepted; global flags come from the command line"},
+		Examples:    []string{"acal handle 'x-acal://add?calendar=Work&title=Lunch&start=tomorrow%2012:00&duration=45m' --json", "acal handle 'x-acal://query?from=today&to=%2B7d&where=title~standup' --json"},
+	},
+	"quick-add": {
+		Examples: []string{`acal quick-add "tomorrow 10:00 Standup @Work 30m" --json`, `acal quick-add "friday 3pm Dentist" --calendar Personal --no-conflict --json`},
+	},
+	"slots": {
+		Constraints: []string{"--to must not be earlier than --from"},
+		Examples:    []string{"acal slots --from tomorrow --to +3d --duration 45m --between 9am-5pm --json"},
+	},
+	"lint": {
+		Constraints: []string{"--rule must be one of title-prefix, meeting-video-link, long-block-break", "exits 1 when any violation is found"},
+		Examples:    []string{"acal lint --from today --to +7d --json", "acal lint --rule title-prefix --prefix \"[Eng]\" --json"},
+	},
+	"daemon": {
+		Constraints: []string{"--days must be at least 1", "requires a backend that reports calendar changes (osascript)", "only one daemon may serve the state directory"},
+		Examples:    []string{"acal daemon --days 60", "acal daemon status --json"},
+	},
+	"freebusy": {
+		Examples: []string{"acal freebusy --range this-week --json", "acal freebusy --from today --to +7d --format ics"},
+	},
+	"agenda": {
+		Examples: []string{"acal agenda --day tomorrow --json"},
+	},
+	"ooo.add": {
+		Required: []string{"calendar", "from"},
+		Examples: []string{"acal ooo add --calendar Work --from next-week --to next-week --weekdays mon,tue,wed,thu,fri --json"},
+	},
+	"time.parse": {
+		Examples: []string{"acal time parse 'next friday 3pm' --json", "acal time parse last-month --json"},
+	},
+}
+
+var flagChoicesPattern = regexp.MustCompile(`(?:^|[\s:])([a-z0-9_-]+(?:\|[a-z0-9_-]+)+)(?:$|[\s),])`)
+
+func flagChoices(usage string) []string {
+	m := flagChoicesPattern.FindStringSubmatch(usage)
+	if m == nil {
+		return nil
+	}
+	return strings.Split(m[1], "|")
+}
+
+func describeFlagType(t string) (string, bool) {
+	switch t {
+	case "bool":
+		return "boolean", false
+	case "int", "int32", "int64", "uint":
+		return "integer", false
+	case "float32", "float64":
+		return "number", false
+	case "duration":
+		return "duration", false
+	case "stringSlice", "stringArray":
+		return "string", true
+	case "intSlice":
+		return "integer", true
+	default:
+		return "string", false
+	}
+}
+
+func describeFlag(f *pflag.Flag, required, global bool) flagDescription {
+	typ, repeatable := describeFlagType(f.Value.Type())
+	d := flagDescription{
+		Name:       f.Name,
+		Shorthand:  f.Shorthand,
+		Type:       typ,
+		Usage:      f.Usage,
+		Repeatable: repeatable,
+		Choices:    flagChoices(f.Usage),
+		Required:   required,
+		Global:     global,
+	}
+	if f.DefValue != "" && f.DefValue != "[]" && !(typ == "boolean" && f.DefValue == "false") {
+		d.Default = f.DefValue
+	}
+	return d
+}
+
+func describeArgs(use string) []argDescription {
+	fields := strings.Fields(use)
+	out := []argDescription{}
+	for _, f := range fields[1:] {
+		repeatable := strings.HasSuffix(f, "...")
+		f = strings.TrimSuffix(f, "...")
+		switch {
+		case strings.HasPrefix(f, "<") && strings.HasSuffix(f, ">"):
+			out = append(out, argDescription{Name: strings.Trim(f, "<>"), Required: true, Repeatable: repeatable})
+		case strings.HasPrefix(f, "[") && strings.HasSuffix(f, "]"):
+			out = append(out, argDescription{Name: strings.Trim(f, "[]"), Repeatable: repeatable})
+		}
+	}
+	return out
+}
+
+func commandName(cmd *cobra.Command) string {
+	parts := []string{}
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		parts = append([]string{c.Name()}, parts...)
+	}
+	return strings.Join(parts, ".")
+}
+
+func describable(cmd *cobra.Command) bool {
+	return !cmd.Hidden && cmd.Deprecated == "" && cmd.Name() != "help" && cmd.Name() != "completion"
+}
+
+func describeCommand(cmd *cobra.Command, withGlobal bool) commandDescription {
+	name := commandName(cmd)
+	spec := describeSpecs[name]
+	d := commandDescription{
+		Name:        name,
+		Usage:       cmd.UseLine(),
+		Short:       cmd.Short,
+		Aliases:     cmd.Aliases,
+		Args:        describeArgs(cmd.Use),
+		Flags:       []flagDescription{},
+		Examples:    spec.Examples,
+		Constraints: spec.Constraints,
+	}
+	cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Name != "help" && !f.Hidden {
+			d.Flags = append(d.Flags, describeFlag(f, containsString(spec.Required, f.Name), false))
+		}
+	})
+	if withGlobal {
+		cmd.InheritedFlags().VisitAll(func(f *pflag.Flag) {
+			if !f.Hidden {
+				d.Flags = append(d.Flags, describeFlag(f, false, true))
+			}
+		})
+	}
+	for _, sub := range cmd.Commands() {
+		if describable(sub) {
+			d.Subcommands = append(d.Subcommands, commandName(sub))
+		}
+	}
+	if _, ok := schemaCommands[name]; ok {
+		d.OutputSchema = "acal schema " + name
+	}
+	return d
+}
+
+func findDescribedCommand(root *cobra.Command, args []string) (*cobra.Command, bool) {
+	path := strings.FieldsFunc(strings.Join(args, " "), func(r rune) bool { return r == '.' || r == ' ' })
+	cur := root
+	for _, part := range path {
+		var next *cobra.Command
+		for _, sub := range cur.Commands() {
+			if describable(sub) && (sub.Name() == part || containsString(sub.Aliases, part)) {
+				next = sub
+				break
+			}
+		}
+		if next == nil {
+			return nil, false
+		}
+		cur = next
+	}
+	return cur, cur != root
+}
+
+func allDescribable(cmd *cobra.Command, out *[]*cobra.Command) {
+	for _, sub := range cmd.Commands() {
+		if describable(sub) {
+			*out = append(*out, sub)
+			allDescribable(sub, out)
+		}
+	}
+}
+
+func newDescribeCmd(opts *globalOptions) *cobra.Command {
+	var withGlobal bool
+	cmd := &cobra.Command{
+		Use:   "describe [command]",
+		Short: "Describe commands, flags, and constraints as machine-readable metadata",
+		RunE: func(c *cobra.Command, args []string) error {
+			p, _, _, err := buildContext(c, opts, "describe")
+			if err != nil {
+				return err
+			}
+			cmds := []*cobra.Command{}
+			if len(args) == 0 {
+				allDescribable(c.Root(), &cmds)
+				sort.SliceStable(cmds, func(i, j int) bool { return commandName(cmds[i]) < commandName(cmds[j]) })
+			} else {
+				target, ok := findDescribedCommand(c.Root(), args)
+				if !ok {
+					return failWithHint(p, contract.ErrNotFound, fmt.Errorf("unknown command: %s", strings.Join(args, " ")), "Run `acal describe` to list all commands", 4)
+				}
+				cmds = append(cmds, target)
+			}
+			out := make([]commandDescription, 0, len(cmds))
+			for _, target := range cmds {
+				out = append(out, describeCommand(target, withGlobal))
+			}
+			return p.Success(out, map[string]any{"count": len(out)}, nil)
+		},
+	}
+	cmd.Flags().BoolVar(&withGlobal, "global", false, "Also list the global flags each command inherits")
+	return cmd
+}
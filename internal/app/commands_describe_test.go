@@ -0,0 +1,77 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/agis/acal/internal/backend"
+)
+
+func TestDescribeEventsAdd(t *testing.T) {
+	fb := backend.NewMockBackend(backend.MockFixture{})
+	var env struct {
+		Data []commandDescription `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "describe", "events", "add", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 1 || env.Data[0].Name != "events.add" || env.Data[0].OutputSchema != "acal schema events.add" {
+		t.Fatalf("unexpected description: %+v", env.Data)
+	}
+	flags := map[string]flagDescription{}
+	for _, f := range env.Data[0].Flags {
+		flags[f.Name] = f
+	}
+	if !flags["calendar"].Required || !flags["title"].Required || flags["location"].Required {
+		t.Fatalf("required flags not marked: %+v", flags)
+	}
+	if got := strings.Join(flags["status"].Choices, ","); got != "confirmed,tentative,cancelled,none" {
+		t.Fatalf("status choices = %q", got)
+	}
+	if flags["dry-run"].Type != "boolean" || flags["dry-run"].Shorthand != "n" {
+		t.Fatalf("dry-run = %+v", flags["dry-run"])
+	}
+	if _, ok := flags["json"]; ok {
+		t.Fatal("global flags must only appear with --global")
+	}
+
+	if err := json.Unmarshal(runWithBackend(t, fb, "describe", "events.move", "--global", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	args, global := env.Data[0].Args, false
+	for _, f := range env.Data[0].Flags {
+		global = global || (f.Name == "json" && f.Global)
+	}
+	if len(args) != 1 || args[0].Name != "event-id" || !args[0].Required || !global {
+		t.Fatalf("unexpected events.move description: %+v", env.Data[0])
+	}
+	if code := runEventsCmd(t, fb, "describe", "events.nope"); code != 4 {
+		t.Fatalf("unknown command exit = %d, want 4", code)
+	}
+}
+
+func TestDescribeSpecsMatchCommands(t *testing.T) {
+	root := NewRootCommand()
+	for name, spec := range describeSpecs {
+		cmd, ok := findDescribedCommand(root, []string{name})
+		if !ok {
+			t.Fatalf("describeSpecs has unknown command %q", name)
+		}
+		for _, f := range spec.Required {
+			if cmd.Flags().Lookup(f) == nil {
+				t.Fatalf("%s: required flag --%s does not exist", name, f)
+			}
+		}
+		for _, ex := range spec.Examples {
+			if !strings.HasPrefix(ex, "acal "+strings.ReplaceAll(name, ".", " ")) {
+				t.Fatalf("%s: example %q runs another command", name, ex)
+			}
+			for _, tok := range strings.Fields(ex) {
+				if f := strings.TrimPrefix(tok, "--"); f != tok && cmd.Flags().Lookup(f) == nil && cmd.InheritedFlags().Lookup(f) == nil {
+					t.Fatalf("%s: example %q uses unknown flag %s", name, ex, tok)
+				}
+			}
+		}
+	}
+}
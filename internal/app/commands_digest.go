@@ -0,0 +1,262 @@
+package app
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+type digest struct {
+	Date        string           `json:"date"`
+	Format      string           `json:"format"`
+	Events      []contract.Event `json:"events"`
+	Conflicts   []conflictRow    `json:"conflicts"`
+	FreeGaps    []slotRow        `json:"free_gaps"`
+	BusyMinutes int64            `json:"busy_minutes"`
+	Annotations []dayAnnotation  `json:"annotations"`
+	Content     string           `json:"content"`
+}
+
+func buildDigest(items []contract.Event, day time.Time, windowStart, windowEnd time.Time, minGap time.Duration) digest {
+	blocks := buildBusyBlocks(items, false)
+	busy := int64(0)
+	for _, b := range blocks {
+		busy += b.Minutes
+	}
+	conflicts := buildConflictRows(items, false)
+	if conflicts == nil {
+		conflicts = []conflictRow{}
+	}
+	return digest{
+		Date:        day.Format("2006-01-02"),
+		Events:      items,
+		Conflicts:   conflicts,
+		FreeGaps:    buildFreeGaps(blocks, windowStart, windowEnd, minGap),
+		BusyMinutes: busy,
+		Annotations: []dayAnnotation{},
+	}
+}
+
+func buildFreeGaps(blocks []busyBlock, from, to time.Time, minGap time.Duration) []slotRow {
+	gaps := []slotRow{}
+	add := func(start, end time.Time) {
+		if end.Sub(start) >= minGap && end.After(start) {
+			gaps = append(gaps, slotRow{Start: start, End: end, Minutes: int64(end.Sub(start).Minutes())})
+		}
+	}
+	cursor := from
+	for _, b := range blocks {
+		if !b.End.After(cursor) {
+			continue
+		}
+		if !b.Start.Before(to) {
+			break
+		}
+		add(cursor, minTime(b.Start, to))
+		cursor = b.End
+	}
+	if cursor.Before(to) {
+		add(cursor, to)
+	}
+	return gaps
+}
+
+func renderDigest(d digest, format string, loc *time.Location) string {
+	day, _ := time.ParseInLocation("2006-01-02", d.Date, loc)
+	heading := "Agenda for " + day.Format("Monday, January 2, 2006")
+	summary := fmt.Sprintf("%d event(s), %s busy, %d conflict(s)", len(d.Events), formatMinutes(d.BusyMinutes), len(d.Conflicts))
+	clock := func(t time.Time) string { return t.In(loc).Format("15:04") }
+
+	timeline := make([]string, 0, len(d.Events))
+	for _, e := range d.Events {
+		when := clock(e.Start) + "–" + clock(e.End)
+		if e.AllDay {
+			when = "All day"
+		}
+		timeline = append(timeline, digestLine(format, when, e.Title, digestEventDetail(e)))
+	}
+	conflicts := make([]string, 0, len(d.Conflicts))
+	for _, c := range d.Conflicts {
+		conflicts = append(conflicts, digestLine(format, clock(c.OverlapStart)+"–"+clock(c.OverlapEnd), c.LeftTitle+" / "+c.RightTitle, formatMinutes(c.OverlapMinutes)+" overlap"))
+	}
+	gaps := make([]string, 0, len(d.FreeGaps))
+	for _, g := range d.FreeGaps {
+		gaps = append(gaps, digestLine(format, clock(g.Start)+"–"+clock(g.End), "", formatMinutes(g.Minutes)))
+	}
+
+	var b strings.Builder
+	if format == "html" {
+		b.WriteString("<h1>" + html.EscapeString(heading) + "</h1>\n")
+		for _, a := range d.Annotations {
+			b.WriteString("<p>" + html.EscapeString(a.Text) + "</p>\n")
+		}
+		b.WriteString("<p>" + html.EscapeString(summary) + "</p>\n")
+	} else {
+		b.WriteString("# " + heading + "\n\n")
+		for _, a := range d.Annotations {
+			b.WriteString(escapeMarkdown(a.Text) + "  \n")
+		}
+		b.WriteString(summary + "\n")
+	}
+	for _, sec := range []struct {
+		title string
+		lines []string
+		empty string
+	}{
+		{"Timeline", timeline, "No events."},
+		{"Conflicts", conflicts, "No conflicts."},
+		{"Free time", gaps, "No free gaps."},
+	} {
+		if format == "html" {
+			b.WriteString("<h2>" + sec.title + "</h2>\n")
+			if len(sec.lines) == 0 {
+				b.WriteString("<p>" + sec.empty + "</p>\n")
+				continue
+			}
+			b.WriteString("<ul>\n" + strings.Join(sec.lines, "\n") + "\n</ul>\n")
+			continue
+		}
+		b.WriteString("\n## " + sec.title + "\n\n")
+		if len(sec.lines) == 0 {
+			b.WriteString(sec.empty + "\n")
+			continue
+		}
+		b.WriteString(strings.Join(sec.lines, "\n") + "\n")
+	}
+	return b.String()
+}
+
+func digestLine(format, when, title, detail string) string {
+	if format == "html" {
+		s := "<li>" + html.EscapeString(when)
+		if title != "" {
+			s += " <strong>" + html.EscapeString(title) + "</strong>"
+		}
+		if detail != "" {
+			s += " (" + html.EscapeString(detail) + ")"
+		}
+		return s + "</li>"
+	}
+	s := "- " + when
+	if title != "" {
+		s += " **" + escapeMarkdown(title) + "**"
+	}
+	if detail != "" {
+		s += " (" + escapeMarkdown(detail) + ")"
+	}
+	return s
+}
+
+func digestEventDetail(e contract.Event) string {
+	parts := []string{}
+	for _, v := range []string{firstNonEmpty(e.CalendarName, e.CalendarID), e.Location, e.MeetingURL} {
+		if v = strings.TrimSpace(v); v != "" && !containsString(parts, v) {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`).Replace(s)
+}
+
+func formatMinutes(m int64) string {
+	switch {
+	case m < 60:
+		return fmt.Sprintf("%dm", m)
+	case m%60 == 0:
+		return fmt.Sprintf("%dh", m/60)
+	default:
+		return fmt.Sprintf("%dh%02dm", m/60, m%60)
+	}
+}
+
+func newDigestCmd(opts *globalOptions) *cobra.Command {
+	var day, format, outPath, between, minGapS string
+	var calendars []string
+	var noAnnotations bool
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "Render a daily digest (timeline, conflicts, free gaps) as Markdown or HTML",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "digest")
+			if err != nil {
+				return err
+			}
+			format = strings.ToLower(strings.TrimSpace(format))
+			if format != "markdown" && format != "html" {
+				return failWithHint(p, contract.ErrInvalidUsage, invalidField("format", format, fmt.Errorf("invalid --format: %s", format)), "Use --format markdown|html", 2)
+			}
+			loc := resolveLocation(ro.TZ)
+			anchor, err := timeparse.ParseDateTime(day, currentTime(), loc)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --for as today, tomorrow, +Nd, or YYYY-MM-DD", 2)
+			}
+			startHour, startMinute, endHour, endMinute, err := parseBetweenRange(between)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --between HH:MM-HH:MM or 9am-5pm", 2)
+			}
+			minGap, err := timeparse.ParseDuration(minGapS)
+			if err != nil || minGap < 0 {
+				if err == nil {
+					err = fmt.Errorf("--min-gap must not be negative")
+				}
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			providers, err := dayAnnotators(ro.Annotations)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Set latitude and longitude together in [annotations]", 2)
+			}
+			start, end := dayBounds(anchor)
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: start, To: end, Calendars: calendars, Overlap: true})
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			if p.HidePrivate {
+				items = output.MaskPrivate(items).([]contract.Event)
+			}
+			windowStart := time.Date(start.Year(), start.Month(), start.Day(), startHour, startMinute, 0, 0, loc)
+			windowEnd := time.Date(start.Year(), start.Month(), start.Day(), endHour, endMinute, 0, 0, loc)
+			d := buildDigest(markContinued(items, start), start, windowStart, windowEnd, minGap)
+			d.Format = format
+			var warnings []contract.Warning
+			if !noAnnotations {
+				d.Annotations, warnings = annotateDay(ctx, providers, start)
+			}
+			d.Content = renderDigest(d, format, loc)
+			meta := map[string]any{"count": len(d.Events), "day": d.Date, "format": format, "conflicts": len(d.Conflicts)}
+			if outPath != "" && outPath != "-" {
+				if err := writeFileAtomic(outPath, []byte(d.Content), 0o644); err != nil {
+					return failWithHint(p, contract.ErrGeneric, err, "Check destination path permissions", 1)
+				}
+				return successWithMeta(ctx, p, ro, map[string]any{"path": outPath, "events": len(d.Events)}, meta, warnings)
+			}
+			// Piped output stays Markdown/HTML unless JSON is asked for.
+			if p.Mode == output.ModeJSON || p.Mode == output.ModeJSONL {
+				return successWithMeta(ctx, p, ro, d, meta, warnings)
+			}
+			p.PrintWarnings(warnings)
+			_, _ = fmt.Fprint(c.OutOrStdout(), d.Content)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&day, "for", "today", "Day selector")
+	cmd.Flags().StringVar(&format, "format", "markdown", "Digest format: markdown|html")
+	cmd.Flags().StringVar(&outPath, "out", "-", "Output file path or - for stdout")
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	cmd.Flags().StringVar(&between, "between", "09:00-17:00", "Working window for free gaps as HH:MM-HH:MM or 9am-5pm")
+	cmd.Flags().StringVar(&minGapS, "min-gap", "30m", "Shortest free gap to list")
+	cmd.Flags().BoolVar(&noAnnotations, "no-annotations", false, "Skip [annotations] providers such as sunrise/sunset")
+	return cmd
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestBuildFreeGaps(t *testing.T) {
+	day := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	blocks := []busyBlock{
+		{Start: at(8, 0), End: at(9, 30)},
+		{Start: at(10, 0), End: at(11, 0)},
+		{Start: at(16, 0), End: at(18, 0)},
+	}
+	gaps := buildFreeGaps(blocks, at(9, 0), at(17, 0), 45*time.Minute)
+	if len(gaps) != 1 || !gaps[0].Start.Equal(at(11, 0)) || !gaps[0].End.Equal(at(16, 0)) || gaps[0].Minutes != 300 {
+		t.Fatalf("unexpected gaps: %+v", gaps)
+	}
+	if gaps := buildFreeGaps(nil, at(9, 0), at(17, 0), 0); len(gaps) != 1 || gaps[0].Minutes != 480 {
+		t.Fatalf("expected whole window free, got %+v", gaps)
+	}
+}
+
+func TestDigestMarkdownAndHTML(t *testing.T) {
+	day := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: at(9, 0), End: at(9, 30), URL: "https://meet.google.com/abc"},
+			{ID: "b", CalendarID: "work", CalendarName: "Work", Title: "R&D <review>", Start: at(9, 15), End: at(10, 0)},
+		},
+	})
+
+	md := string(runWithBackend(t, fb, "digest", "--for", "2026-03-03", "--tz", "UTC"))
+	for _, want := range []string{
+		"# Agenda for Tuesday, March 3, 2026",
+		"2 event(s), 1h busy, 1 conflict(s)",
+		"- 09:00–09:30 **Standup** (Work, https://meet.google.com/abc)",
+		"- 09:15–09:30 **Standup / R&D <review>** (15m overlap)",
+		"- 10:00–17:00 (7h)",
+	} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("markdown digest missing %q:\n%s", want, md)
+		}
+	}
+
+	out := filepath.Join(t.TempDir(), "digest.html")
+	runWithBackend(t, fb, "digest", "--for", "2026-03-03", "--tz", "UTC", "--format", "html", "--out", out, "--json")
+	raw, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read digest: %v", err)
+	}
+	if got := string(raw); !strings.Contains(got, "<strong>R&amp;D &lt;review&gt;</strong>") || !strings.Contains(got, "<h2>Free time</h2>") {
+		t.Fatalf("unexpected html digest:\n%s", got)
+	}
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+func newErrorsCmd(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "errors",
+		Short: "List error codes with exit codes and retryability",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, _, _, err := buildContext(cmd, opts, "errors")
+			if err != nil {
+				return err
+			}
+			rows := append([]contract.ErrorCodeInfo(nil), contract.ErrorCodes...)
+			return p.Success(rows, map[string]any{"count": len(rows)}, nil)
+		},
+	}
+}
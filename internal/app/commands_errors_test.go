@@ -0,0 +1,127 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestErrorsCommandListsRegistry(t *testing.T) {
+	cmd := NewRootCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"errors", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	var got struct {
+		Command string                   `json:"command"`
+		Data    []contract.ErrorCodeInfo `json:"data"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if got.Command != "errors" || len(got.Data) != len(contract.ErrorCodes) {
+		t.Fatalf("unexpected errors output: %+v", got)
+	}
+	byCode := map[contract.ErrorCode]contract.ErrorCodeInfo{}
+	for _, info := range got.Data {
+		byCode[info.Code] = info
+	}
+	if info := byCode[contract.ErrConcurrency]; info.ExitCode != 7 || !info.Retryable {
+		t.Fatalf("unexpected concurrency entry: %+v", info)
+	}
+	if info := byCode[contract.ErrInvalidUsage]; info.ExitCode != 2 || info.Retryable {
+		t.Fatalf("unexpected invalid usage entry: %+v", info)
+	}
+}
+
+func TestErrorCodesAreUniqueAndMapFromExit(t *testing.T) {
+	seen := map[int]bool{}
+	for _, info := range contract.ErrorCodes {
+		if seen[info.ExitCode] {
+			t.Fatalf("duplicate exit code %d", info.ExitCode)
+		}
+		seen[info.ExitCode] = true
+		if got := errorCodeForExit(info.ExitCode); got != info.Code {
+			t.Fatalf("errorCodeForExit(%d)=%s, want %s", info.ExitCode, got, info.Code)
+		}
+	}
+	if got := errorCodeForExit(42); got != contract.ErrGeneric {
+		t.Fatalf("expected generic fallback, got %s", got)
+	}
+}
+
+func TestErrorEnvelopeIncludesRetryable(t *testing.T) {
+	cmd := NewRootCommand()
+	var stderr bytes.Buffer
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"--json", "--backend", "mock", "--mock-file", "testdata/mock/events.json", "events", "show", "missing"})
+	err := cmd.Execute()
+	if ExitCode(err) != 4 {
+		t.Fatalf("expected exit 4, got %d (%v)", ExitCode(err), err)
+	}
+	if got := stderr.String(); !strings.Contains(got, `"retryable": false`) {
+		t.Fatalf("expected retryable=false in envelope, got: %q", got)
+	}
+
+	stderr.Reset()
+	origArgs := os.Args
+	os.Args = []string{"acal", "--json", "status"}
+	t.Cleanup(func() { os.Args = origArgs })
+	renderTopLevelError(cmd, Wrap(6, io.ErrUnexpectedEOF))
+	if got := stderr.String(); !strings.Contains(got, `"code": "BACKEND_UNAVAILABLE"`) || !strings.Contains(got, `"retryable": true`) {
+		t.Fatalf("expected retryable backend error, got: %q", got)
+	}
+}
+
+func TestErrorEnvelopeDetailsNameInvalidField(t *testing.T) {
+	cmd := NewRootCommand()
+	var stderr bytes.Buffer
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"--json", "--backend", "mock", "--mock-file", "testdata/mock/events.json", "events", "list", "--from", "someday"})
+	if code := ExitCode(cmd.Execute()); code != 2 {
+		t.Fatalf("expected exit 2, got %d", code)
+	}
+	var env contract.ErrorEnvelope
+	if err := json.Unmarshal(stderr.Bytes(), &env); err != nil {
+		t.Fatalf("decode error envelope: %v (%q)", err, stderr.String())
+	}
+	d := env.Error.Details
+	if d == nil || len(d.Fields) != 1 || d.Fields[0].Field != "from" || d.Fields[0].Value != "someday" || d.Fields[0].Message != env.Error.Message {
+		t.Fatalf("expected details.fields for --from, got %+v", d)
+	}
+}
+
+func TestErrorDetailsFromBackendErrors(t *testing.T) {
+	if d := errorDetails(errors.New("plain")); d != nil {
+		t.Fatalf("expected no details for a plain error, got %+v", d)
+	}
+	out := strings.Repeat("x", 2*maxStderrExcerpt) + "execution error: Calendar got an error (-1728)"
+	err := fmt.Errorf("update event: %w", &backend.CommandError{Command: "osascript", Output: out})
+	d := errorDetails(err)
+	if d == nil || d.Backend == nil || d.Backend.Command != "osascript" {
+		t.Fatalf("expected backend command details, got %+v", d)
+	}
+	if !strings.HasSuffix(d.Backend.Stderr, "(-1728)") || len(d.Backend.Stderr) > maxStderrExcerpt+len("…") {
+		t.Fatalf("expected the tail of stderr, got %d bytes %q", len(d.Backend.Stderr), d.Backend.Stderr)
+	}
+	deadline := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	err = &backendContextError{Phase: "list_events", Kind: "timeout", Deadline: &deadline, Err: context.DeadlineExceeded}
+	if d := errorDetails(err); d == nil || d.Backend == nil || d.Backend.Kind != "timeout" || d.Backend.Deadline != "2026-03-02T09:00:00Z" {
+		t.Fatalf("expected timeout details, got %+v", d)
+	}
+}
@@ -18,76 +18,10 @@ import (
 func newEventsCmd(opts *globalOptions) *cobra.Command {
 	events := &cobra.Command{Use: "events", Short: "Event resources"}
 
-	type conflictRow struct {
-		LeftID           string    `json:"left_id"`
-		LeftTitle        string    `json:"left_title"`
-		LeftCalendar     string    `json:"left_calendar"`
-		RightID          string    `json:"right_id"`
-		RightTitle       string    `json:"right_title"`
-		RightCalendar    string    `json:"right_calendar"`
-		OverlapStart     time.Time `json:"overlap_start"`
-		OverlapEnd       time.Time `json:"overlap_end"`
-		OverlapMinutes   int64     `json:"overlap_minutes"`
-		SameCalendarOnly bool      `json:"same_calendar_only"`
-	}
-
-	buildConflictRows := func(items []contract.Event, includeAllDay bool) []conflictRow {
-		if len(items) < 2 {
-			return nil
-		}
-		eventsCopy := make([]contract.Event, 0, len(items))
-		for _, it := range items {
-			if !includeAllDay && it.AllDay {
-				continue
-			}
-			eventsCopy = append(eventsCopy, it)
-		}
-		if len(eventsCopy) < 2 {
-			return nil
-		}
-		sort.Slice(eventsCopy, func(i, j int) bool {
-			if eventsCopy[i].Start.Equal(eventsCopy[j].Start) {
-				if eventsCopy[i].End.Equal(eventsCopy[j].End) {
-					return eventsCopy[i].ID < eventsCopy[j].ID
-				}
-				return eventsCopy[i].End.Before(eventsCopy[j].End)
-			}
-			return eventsCopy[i].Start.Before(eventsCopy[j].Start)
-		})
-
-		rows := make([]conflictRow, 0)
-		for i := 0; i < len(eventsCopy); i++ {
-			for j := i + 1; j < len(eventsCopy); j++ {
-				if !eventsCopy[j].Start.Before(eventsCopy[i].End) {
-					break
-				}
-				overlapStart := maxTime(eventsCopy[i].Start, eventsCopy[j].Start)
-				overlapEnd := minTime(eventsCopy[i].End, eventsCopy[j].End)
-				if !overlapStart.Before(overlapEnd) {
-					continue
-				}
-				leftCal := firstNonEmpty(eventsCopy[i].CalendarName, eventsCopy[i].CalendarID)
-				rightCal := firstNonEmpty(eventsCopy[j].CalendarName, eventsCopy[j].CalendarID)
-				rows = append(rows, conflictRow{
-					LeftID:           eventsCopy[i].ID,
-					LeftTitle:        eventsCopy[i].Title,
-					LeftCalendar:     leftCal,
-					RightID:          eventsCopy[j].ID,
-					RightTitle:       eventsCopy[j].Title,
-					RightCalendar:    rightCal,
-					OverlapStart:     overlapStart,
-					OverlapEnd:       overlapEnd,
-					OverlapMinutes:   int64(overlapEnd.Sub(overlapStart).Minutes()),
-					SameCalendarOnly: leftCal == rightCal,
-				})
-			}
-		}
-		return rows
-	}
-
-	var listCalendars []string
-	var listFrom, listTo string
+	var listCalendars, listAccounts []string
+	var listFrom, listTo, listFormat string
 	var listLimit int
+	var listVideoOnly bool
 	list := &cobra.Command{
 		Use:   "list",
 		Short: "List events",
@@ -96,27 +30,57 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			listFormat, err = parseLauncherFormat(listFormat)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --format alfred|raycast, or omit it", 2)
+			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
 			f, err := buildEventFilterWithTZ(listFrom, listTo, listCalendars, listLimit, ro.TZ)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --from and --to with RFC3339, YYYY-MM-DD, or relative values", 2)
 			}
+			if err := applyAccountFilter(ctx, be, &f, listAccounts); err != nil {
+				return failAccountFilter(p, err)
+			}
+			f.Fields = eventProjection(p, videoCallNeeds(listVideoOnly)...)
+			if listFormat == "" && p.EffectiveSuccessMode() == output.ModeJSONL {
+				err := streamEventsWithTimeout(ctx, be, f, func(e contract.Event) error {
+					if listVideoOnly && !e.IsVideoCall {
+						return nil
+					}
+					return p.StreamItem(e)
+				})
+				if err != nil {
+					return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+				}
+				return nil
+			}
 			items, err := listEventsWithTimeout(ctx, be, f)
 			if err != nil {
 				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
 			}
+			if listVideoOnly {
+				items = onlyVideoCalls(items)
+			}
+			if listFormat != "" {
+				return printLauncher(cmd, p, ro, listFormat, items)
+			}
 			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items)}, nil)
 		},
 	}
 	list.Flags().StringSliceVar(&listCalendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	list.Flags().StringSliceVar(&listAccounts, "account", nil, "Account name or type, e.g. iCloud or local (repeatable)")
 	list.Flags().StringVar(&listFrom, "from", "today", "Range start")
 	list.Flags().StringVar(&listTo, "to", "+7d", "Range end")
 	list.Flags().IntVar(&listLimit, "limit", 0, "Limit results")
+	list.Flags().BoolVar(&listVideoOnly, "only-video-calls", false, "Only events with a video-call link")
+	list.Flags().StringVar(&listFormat, "format", "", "Launcher output: alfred (Script Filter JSON)|raycast")
 
-	var searchCalendars []string
-	var searchFrom, searchTo, searchField string
-	var searchLimit int
+	var searchCalendars, searchAccounts []string
+	var searchFrom, searchTo, searchField, searchFormat string
+	var searchLimit, searchTop int
+	var searchRank bool
 	search := &cobra.Command{
 		Use:   "search <query>",
 		Short: "Search events",
@@ -126,27 +90,115 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			searchFormat, err = parseLauncherFormat(searchFormat)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --format alfred|raycast, or omit it", 2)
+			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
 			f, err := buildEventFilterWithTZ(searchFrom, searchTo, searchCalendars, searchLimit, ro.TZ)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
 			}
-			f.Query = args[0]
+			if err := applyAccountFilter(ctx, be, &f, searchAccounts); err != nil {
+				return failAccountFilter(p, err)
+			}
+			text, who := splitPeopleQuery(args[0], ro.People)
+			mentionsAll := func(e contract.Event) bool {
+				for _, w := range who {
+					if !w.mentions(e.Notes) {
+						return false
+					}
+				}
+				return true
+			}
+			if searchRank || cmd.Flags().Changed("top") {
+				field := strings.ToLower(strings.TrimSpace(searchField))
+				if !containsString([]string{"all", "title", "location", "notes"}, field) {
+					return failWithHint(p, contract.ErrInvalidUsage, invalidField("field", searchField, fmt.Errorf("invalid --field %q", searchField)), "Use --field title|location|notes|all", 2)
+				}
+				if searchTop < 0 {
+					return failWithHint(p, contract.ErrInvalidUsage, errors.New("--top must not be negative"), "Use --top N with N >= 1, or 0 for all hits", 2)
+				}
+				f.Limit = 0
+				items, err := listEventsWithTimeout(ctx, be, f)
+				if err != nil {
+					return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+				}
+				ranked := rankEvents(items, text, who, field, currentTime())
+				meta := map[string]any{"ranked": true, "matches": len(ranked), "terms": searchTerms(text)}
+				if len(who) > 0 {
+					meta["people"] = who
+				}
+				for _, n := range []int{searchTop, searchLimit} {
+					if n > 0 && len(ranked) > n {
+						ranked = ranked[:n]
+					}
+				}
+				meta["count"] = len(ranked)
+				if searchFormat != "" {
+					hits := make([]contract.Event, 0, len(ranked))
+					for _, r := range ranked {
+						hits = append(hits, r.Event)
+					}
+					return printLauncher(cmd, p, ro, searchFormat, hits)
+				}
+				return successWithMeta(ctx, p, ro, ranked, meta, nil)
+			}
+			f.Query = text
 			f.Field = searchField
+			if len(who) > 0 {
+				f.Limit = 0
+			}
+			if searchFormat == "" && p.EffectiveSuccessMode() == output.ModeJSONL {
+				streamed := 0
+				err := streamEventsWithTimeout(ctx, be, f, func(e contract.Event) error {
+					if !mentionsAll(e) || (searchLimit > 0 && streamed >= searchLimit) {
+						return nil
+					}
+					streamed++
+					return p.StreamItem(e)
+				})
+				if err != nil {
+					return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+				}
+				return nil
+			}
 			items, err := listEventsWithTimeout(ctx, be, f)
 			if err != nil {
 				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
 			}
-			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items)}, nil)
+			meta := map[string]any{}
+			if len(who) > 0 {
+				kept := items[:0]
+				for _, e := range items {
+					if mentionsAll(e) {
+						kept = append(kept, e)
+					}
+				}
+				if items = kept; searchLimit > 0 && len(items) > searchLimit {
+					items = items[:searchLimit]
+				}
+				meta["people"] = who
+			}
+			if searchFormat != "" {
+				return printLauncher(cmd, p, ro, searchFormat, items)
+			}
+			meta["count"] = len(items)
+			return successWithMeta(ctx, p, ro, items, meta, nil)
 		},
 	}
 	search.Flags().StringSliceVar(&searchCalendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	search.Flags().StringSliceVar(&searchAccounts, "account", nil, "Account name or type, e.g. iCloud or local (repeatable)")
 	search.Flags().StringVar(&searchFrom, "from", "today", "Range start")
 	search.Flags().StringVar(&searchTo, "to", "+30d", "Range end")
 	search.Flags().StringVar(&searchField, "field", "all", "Search field: title|location|notes|all")
 	search.Flags().IntVar(&searchLimit, "limit", 0, "Limit results")
+	search.Flags().BoolVar(&searchRank, "rank", false, "Match query words anywhere and sort hits by relevance score")
+	search.Flags().IntVar(&searchTop, "top", 0, "Return only the N most relevant hits (implies --rank)")
+	search.Flags().StringVar(&searchFormat, "format", "", "Launcher output: alfred (Script Filter JSON)|raycast")
 
+	var showContext bool
 	show := &cobra.Command{
 		Use:   "show <event-id>",
 		Short: "Show one event",
@@ -158,15 +210,29 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
-			item, err := getEventByIDWithTimeout(ctx, be, args[0])
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
 			if err != nil {
-				return failWithHint(p, contract.ErrNotFound, err, "Check ID with `acal events list --fields id,title,start`", 4)
+				return failEventRef(p, err)
 			}
-			return successWithMeta(ctx, p, ro, item, map[string]any{"count": 1}, nil)
+			item, err := getEventByRef(ctx, be, id, currentTime())
+			if err != nil {
+				return failWithHint(p, contract.ErrNotFound, withEventIDs(err, id), "Check ID with `acal events list --fields id,title,start`", 4)
+			}
+			if !showContext {
+				return successWithMeta(ctx, p, ro, item, map[string]any{"count": 1}, nil)
+			}
+			from, to := eventContextWindow(*item, resolveLocation(ro.TZ))
+			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: from, To: to, Overlap: true})
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			c := buildEventContext(*item, items)
+			return successWithMeta(ctx, p, ro, c, eventContextMeta(c), nil)
 		},
 	}
+	show.Flags().BoolVar(&showContext, "context", false, "Include previous/next events on the same day and conflicting events")
 
-	var queryCalendars, wheres []string
+	var queryCalendars, queryAccounts, wheres []string
 	var queryFrom, queryTo, sortField, order string
 	var queryLimit int
 	query := &cobra.Command{
@@ -183,37 +249,44 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
 			}
-			items, err := listEventsWithTimeout(ctx, be, f)
-			if err != nil {
-				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
-			}
 			preds, err := parsePredicates(wheres)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use clauses like title~\"walk\" or calendar==\"Work\"", 2)
 			}
-			items, err = applyPredicates(items, preds)
-			if err != nil {
+			preds = expandPredicatePeople(preds, ro.People)
+			if err := validatePredicates(preds); err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Check --where field/operator/value", 2)
 			}
-			sortEvents(items, sortField, order)
-			if queryLimit > 0 && len(items) > queryLimit {
-				items = items[:queryLimit]
+			if err := applyAccountFilter(ctx, be, &f, queryAccounts); err != nil {
+				return failAccountFilter(p, err)
+			}
+			needs := []string{sortField}
+			for _, pr := range preds {
+				needs = append(needs, pr.field)
+			}
+			f.Fields = eventProjection(p, needs...)
+			recordRequestQuery(ctx, preds, sortField, order)
+			items, err := runEventQuery(ctx, be, f, preds, sortField, order, queryLimit)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
 			}
 			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items)}, nil)
 		},
 	}
 	query.Flags().StringSliceVar(&queryCalendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	query.Flags().StringSliceVar(&queryAccounts, "account", nil, "Account name or type, e.g. iCloud or local (repeatable)")
 	query.Flags().StringVar(&queryFrom, "from", "today", "Range start")
 	query.Flags().StringVar(&queryTo, "to", "+30d", "Range end")
 	query.Flags().StringSliceVar(&wheres, "where", nil, "Predicate clause (repeatable)")
-	query.Flags().StringVar(&sortField, "sort", "start", "Sort field: start|end|title|updated_at|calendar")
+	query.Flags().StringVar(&sortField, "sort", "start", "Sort field: start|end|title|created_at|updated_at|calendar")
 	query.Flags().StringVar(&order, "order", "asc", "Sort order: asc|desc")
 	query.Flags().IntVar(&queryLimit, "limit", 0, "Limit results")
 
 	var conflictsCalendars []string
 	var conflictsFrom, conflictsTo string
 	var conflictsLimit int
-	var conflictsIncludeAllDay bool
+	var conflictsIncludeAllDay, conflictsRecurring bool
+	var conflictsMinOccurrences int
 	conflicts := &cobra.Command{
 		Use:   "conflicts",
 		Short: "Detect overlapping events in a time range",
@@ -233,6 +306,17 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
 			}
 			rows := buildConflictRows(items, conflictsIncludeAllDay)
+			if conflictsRecurring {
+				series := buildRecurringConflictRows(rows, conflictsMinOccurrences)
+				meta := map[string]any{
+					"count":           len(series),
+					"events_scanned":  len(items),
+					"include_all_day": conflictsIncludeAllDay,
+					"recurring":       true,
+					"min_occurrences": max(conflictsMinOccurrences, 2),
+				}
+				return successWithMeta(ctx, p, ro, series, meta, nil)
+			}
 			meta := map[string]any{
 				"count":           len(rows),
 				"events_scanned":  len(items),
@@ -246,9 +330,11 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 	conflicts.Flags().StringVar(&conflictsTo, "to", "+30d", "Range end")
 	conflicts.Flags().IntVar(&conflictsLimit, "limit", 0, "Limit scanned events before conflict analysis")
 	conflicts.Flags().BoolVar(&conflictsIncludeAllDay, "include-all-day", false, "Include all-day events in overlap detection")
+	conflicts.Flags().BoolVar(&conflictsRecurring, "recurring", false, "Report series that clash repeatedly instead of each occurrence")
+	conflicts.Flags().IntVar(&conflictsMinOccurrences, "min-occurrences", 2, "Clashes needed for a series pair to count as standing (with --recurring)")
 
-	var addCalendar, addTitle, addStart, addEnd, addDuration, addLocation, addNotes, addNotesFile, addURL, addRepeat string
-	var addAllDay, addDryRun bool
+	var addCalendar, addTitle, addStart, addEnd, addDuration, addLocation, addNotes, addNotesFile, addURL, addRepeat, addInput, addStatus, addAvailability, addSensitivity, addReminder string
+	var addAllDay, addDryRun, addNoConflict, addForce, addIgnoreQuiet bool
 	add := &cobra.Command{
 		Use:   "add",
 		Short: "Create an event",
@@ -259,16 +345,38 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
+			if addInput != "" {
+				if addInput == "-" && addNotesFile == "-" {
+					err = errors.New("--input and --notes-file cannot both read stdin")
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Pass notes inside the input JSON instead", 2)
+				}
+				in, inErr := readEventInput(addInput)
+				if inErr == nil {
+					inErr = applyEventInput(cmd, in, nil)
+				}
+				if inErr != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, inErr, "Pass an event object like `acal events show <id> --json` prints", 2)
+				}
+			}
 			if addCalendar == "" || addTitle == "" || addStart == "" {
 				err = errors.New("--calendar, --title, and --start are required")
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Provide required fields", 2)
 			}
 			loc := resolveLocation(ro.TZ)
-			startT, err := timeparse.ParseDateTime(addStart, time.Now(), loc)
+			startT, err := timeparse.ParseDateTime(addStart, currentTime(), loc)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Invalid --start format", 2)
 			}
-			endT, err := resolveEnd(addEnd, addDuration, startT, loc)
+			defaults, _ := calendarDefaultsFor(ro.CalendarDefaults, addCalendar)
+			applied := []string{}
+			durationS := addDuration
+			if d, ok, derr := defaults.duration(); derr != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, derr, "Fix calendar_defaults in config", 2)
+			} else if ok && addEnd == "" && addDuration == "" {
+				durationS = d.String()
+				applied = append(applied, "duration")
+			}
+			endT, err := resolveEnd(addEnd, durationS, startT, loc)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --end or --duration", 2)
 			}
@@ -280,6 +388,34 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 				}
 			}
 			in := backend.EventCreateInput{Calendar: addCalendar, Title: addTitle, Start: startT, End: endT, Location: addLocation, Notes: notes, URL: addURL, AllDay: addAllDay}
+			if in.Status, err = parseEventStatus(addStatus); err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --status confirmed|tentative|cancelled|none", 2)
+			}
+			if cmd.Flags().Changed("availability") {
+				if in.Availability, err = parseAvailability(addAvailability); err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Use --availability busy|free", 2)
+				}
+			}
+			if cmd.Flags().Changed("sensitivity") {
+				if in.Sensitivity, err = parseSensitivity(addSensitivity); err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Use --sensitivity public|private|confidential", 2)
+				}
+			}
+			if addReminder != "" {
+				offset, err := normalizeReminderOffset(addReminder)
+				if err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Use --reminder -10m, 1h, 1d", 2)
+				}
+				in.ReminderOffset = &offset
+			} else if ok, err := defaults.applyReminder(&in); err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Fix calendar_defaults in config", 2)
+			} else if ok {
+				applied = append(applied, "reminder")
+			}
+			meta := map[string]any{"count": 1, "repeat": addRepeat}
+			if len(applied) > 0 {
+				meta["calendar_defaults"] = applied
+			}
 			spec, err := parseRepeatSpec(addRepeat, startT)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --repeat daily*5 | weekly:mon,wed*6 | monthly*3 | yearly*2", 2)
@@ -287,8 +423,13 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			if spec.Frequency != "" {
 				in.RepeatRule = canonicalRepeatRule(spec)
 			}
+			if addNoConflict && !addForce {
+				if err := checkNoConflict(ctx, p, be, in, loc, quietHoursFor(ro, addIgnoreQuiet)); err != nil {
+					return err
+				}
+			}
 			if addDryRun {
-				return successWithMeta(ctx, p, ro, in, map[string]any{"dry_run": true, "count": 1, "repeat": addRepeat}, nil)
+				return successDryRun(ctx, p, ro, []dryRunPreview{previewAdd(in)}, meta, nil)
 			}
 			item, err := addEventWithTimeout(ctx, be, in)
 			if err != nil {
@@ -297,26 +438,35 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			if item != nil {
 				_ = appendHistory(historyEntry{Type: "add", EventID: item.ID, Created: item})
 			}
-			return successWithMeta(ctx, p, ro, item, map[string]any{"count": 1, "repeat": addRepeat}, nil)
+			return successWithMeta(ctx, p, ro, item, meta, nil)
 		},
 	}
 	add.Flags().StringVar(&addCalendar, "calendar", "", "Calendar ID or name")
 	add.Flags().StringVar(&addTitle, "title", "", "Event title")
 	add.Flags().StringVar(&addStart, "start", "", "Start datetime")
 	add.Flags().StringVar(&addEnd, "end", "", "End datetime")
-	add.Flags().StringVar(&addDuration, "duration", "", "Duration (e.g. 30m)")
+	add.Flags().StringVar(&addDuration, "duration", "", "Duration (e.g. 30m, 1h30m, 2d, 90 minutes)")
 	add.Flags().StringVar(&addLocation, "location", "", "Location")
 	add.Flags().StringVar(&addNotes, "notes", "", "Notes")
 	add.Flags().StringVar(&addNotesFile, "notes-file", "", "Notes path or - for stdin")
 	add.Flags().StringVar(&addURL, "url", "", "URL")
 	add.Flags().StringVar(&addRepeat, "repeat", "", "Repeat rule: daily*5, weekly:mon,wed*6, monthly*3, yearly*2")
 	add.Flags().BoolVar(&addAllDay, "all-day", false, "All-day event")
+	add.Flags().StringVar(&addStatus, "status", "", "Event status: confirmed|tentative|cancelled|none")
+	add.Flags().StringVar(&addAvailability, "availability", "", "Show as: busy|free")
+	add.Flags().StringVar(&addSensitivity, "sensitivity", "", "Privacy: public|private|confidential")
+	add.Flags().StringVar(&addReminder, "reminder", "", "Reminder before start (e.g. 10m, 1h; default from calendar_defaults)")
 	add.Flags().BoolVarP(&addDryRun, "dry-run", "n", false, "Preview without writing")
+	add.Flags().BoolVar(&addNoConflict, "no-conflict", false, "Refuse to create the event if it overlaps an existing one or quiet hours (exit 5)")
+	add.Flags().BoolVar(&addIgnoreQuiet, "ignore-quiet-hours", false, "Let --no-conflict accept times inside quiet_hours")
+	add.Flags().BoolVar(&addForce, "force", false, "Create even if --no-conflict finds an overlap")
+	add.Flags().StringVar(&addInput, "input", "", "Event JSON path or - for stdin (flags take precedence)")
 
-	var upTitle, upStart, upEnd, upDuration, upLocation, upNotes, upNotesFile, upURL, upScope, upRepeat string
+	var upTitle, upStart, upEnd, upDuration, upLocation, upNotes, upNotesFile, upURL, upScope, upRepeat, upInput, upStatus, upAvailability, upSensitivity string
 	var upAllDay bool
 	var upAllDaySet, upDryRun bool
 	var ifMatch int
+	var upIfMatchETag string
 	update := &cobra.Command{
 		Use:   "update <event-id>",
 		Short: "Update an event",
@@ -328,6 +478,33 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
+			if upInput != "" {
+				if upInput == "-" && upNotesFile == "-" {
+					err = errors.New("--input and --notes-file cannot both read stdin")
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Pass notes inside the input JSON instead", 2)
+				}
+				in, inErr := readEventInput(upInput)
+				if inErr == nil && in.ID != nil && *in.ID != "" && *in.ID != id {
+					inErr = fmt.Errorf("input id %q does not match %q", *in.ID, id)
+				}
+				if inErr != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, inErr, "Pass an event object like `acal events show <id> --json` prints", 2)
+				}
+				baseNotes := func() (string, error) {
+					item, getErr := getEventByIDWithTimeout(ctx, be, id)
+					if getErr != nil {
+						return "", getErr
+					}
+					return item.Notes, nil
+				}
+				if inErr = applyEventInput(cmd, in, baseNotes); inErr != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, inErr, "Pass an event object like `acal events show <id> --json` prints", 2)
+				}
+			}
 			scope, err := parseRecurrenceScope(upScope)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --scope auto|this|future|series", 2)
@@ -351,7 +528,7 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 				patch.Notes = &notes
 			}
 			if cmd.Flags().Changed("repeat") {
-				spec, specErr := parseRepeatSpec(upRepeat, time.Now())
+				spec, specErr := parseRepeatSpec(upRepeat, currentTime())
 				if specErr != nil {
 					return failWithHint(p, contract.ErrInvalidUsage, specErr, "Use --repeat daily*5 | weekly:mon,wed*6 | monthly*3 | yearly*2", 2)
 				}
@@ -361,6 +538,27 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			if cmd.Flags().Changed("url") {
 				patch.URL = &upURL
 			}
+			if cmd.Flags().Changed("status") {
+				status, statusErr := parseEventStatus(upStatus)
+				if statusErr != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, statusErr, "Use --status confirmed|tentative|cancelled|none", 2)
+				}
+				patch.Status = &status
+			}
+			if cmd.Flags().Changed("availability") {
+				availability, availErr := parseAvailability(upAvailability)
+				if availErr != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, availErr, "Use --availability busy|free", 2)
+				}
+				patch.Availability = &availability
+			}
+			if cmd.Flags().Changed("sensitivity") {
+				sensitivity, sensErr := parseSensitivity(upSensitivity)
+				if sensErr != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, sensErr, "Use --sensitivity public|private|confidential", 2)
+				}
+				patch.Sensitivity = &sensitivity
+			}
 			if cmd.Flags().Changed("all-day") {
 				upAllDaySet = true
 			}
@@ -368,7 +566,7 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 				patch.AllDay = &upAllDay
 			}
 			if cmd.Flags().Changed("start") {
-				t, e := timeparse.ParseDateTime(upStart, time.Now(), loc)
+				t, e := timeparse.ParseDateTime(upStart, currentTime(), loc)
 				if e != nil {
 					return failWithHint(p, contract.ErrInvalidUsage, e, "Invalid --start", 2)
 				}
@@ -379,7 +577,7 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 				if current != nil {
 					return nil
 				}
-				item, getErr := getEventByIDWithTimeout(ctx, be, args[0])
+				item, getErr := getEventByIDWithTimeout(ctx, be, id)
 				if getErr != nil {
 					return getErr
 				}
@@ -395,8 +593,28 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 					return failWithHint(p, contract.ErrConcurrency, err, "Re-fetch event and retry", 7)
 				}
 			}
+			if upIfMatchETag != "" {
+				if getErr := getCurrent(); getErr != nil {
+					return failWithHint(p, contract.ErrNotFound, getErr, "Unable to verify etag for --if-match-etag", 4)
+				}
+				if current.ETag != upIfMatchETag {
+					err = fmt.Errorf("etag mismatch: current=%s expected=%s", current.ETag, upIfMatchETag)
+					return failWithHint(p, contract.ErrConcurrency, err, "Re-fetch event and retry", 7)
+				}
+			}
+			// Drop fields the backend cannot write when they would not change anything.
+			if patch.Availability != nil {
+				if getErr := getCurrent(); getErr == nil && current.Availability == *patch.Availability {
+					patch.Availability = nil
+				}
+			}
+			if patch.Sensitivity != nil {
+				if getErr := getCurrent(); getErr == nil && firstNonEmpty(current.Sensitivity, contract.SensitivityPublic) == *patch.Sensitivity {
+					patch.Sensitivity = nil
+				}
+			}
 			if cmd.Flags().Changed("end") || cmd.Flags().Changed("duration") {
-				base := time.Now()
+				base := currentTime()
 				if patch.Start == nil {
 					if getErr := getCurrent(); getErr == nil && current != nil {
 						base = current.Start
@@ -415,9 +633,12 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 				patch.End = &t
 			}
 			if upDryRun {
-				return successWithMeta(ctx, p, ro, patch, map[string]any{"dry_run": true}, nil)
+				if getErr := getCurrent(); getErr != nil {
+					return failWithHint(p, contract.ErrNotFound, getErr, "Dry run needs the current event; check ID with `acal events list --fields id,title,start`", 4)
+				}
+				return successDryRun(ctx, p, ro, []dryRunPreview{previewUpdate(current, patch)}, map[string]any{"scope": patch.Scope}, nil)
 			}
-			item, err := updateEventWithTimeout(ctx, be, args[0], patch)
+			item, err := updateEventWithTimeout(ctx, be, id, patch)
 			if err != nil {
 				return failWithHint(p, contract.ErrGeneric, err, "Update failed", 1)
 			}
@@ -427,7 +648,7 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 				}
 			}
 			if current != nil {
-				_ = appendHistory(historyEntry{Type: "update", EventID: args[0], Prev: current, Next: item})
+				_ = appendHistory(historyEntry{Type: "update", EventID: id, Prev: current, Next: item})
 			}
 			return successWithMeta(ctx, p, ro, item, map[string]any{"count": 1}, nil)
 		},
@@ -435,20 +656,26 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 	update.Flags().StringVar(&upTitle, "title", "", "Event title")
 	update.Flags().StringVar(&upStart, "start", "", "Start datetime")
 	update.Flags().StringVar(&upEnd, "end", "", "End datetime")
-	update.Flags().StringVar(&upDuration, "duration", "", "Duration (e.g. 30m)")
+	update.Flags().StringVar(&upDuration, "duration", "", "Duration (e.g. 30m, 1h30m, 2d, 90 minutes)")
 	update.Flags().StringVar(&upLocation, "location", "", "Location")
 	update.Flags().StringVar(&upNotes, "notes", "", "Notes")
 	update.Flags().StringVar(&upNotesFile, "notes-file", "", "Notes path or - for stdin")
 	update.Flags().StringVar(&upURL, "url", "", "URL")
 	update.Flags().StringVar(&upRepeat, "repeat", "", "Repeat metadata rule")
 	update.Flags().BoolVar(&upAllDay, "all-day", false, "All-day event")
+	update.Flags().StringVar(&upStatus, "status", "", "Event status: confirmed|tentative|cancelled|none")
+	update.Flags().StringVar(&upAvailability, "availability", "", "Show as: busy|free")
+	update.Flags().StringVar(&upSensitivity, "sensitivity", "", "Privacy: public|private|confidential")
 	update.Flags().StringVar(&upScope, "scope", "auto", "Recurrence scope: auto|this|future|series")
 	update.Flags().IntVar(&ifMatch, "if-match-seq", 0, "Require matching sequence number")
+	update.Flags().StringVar(&upIfMatchETag, "if-match-etag", "", "Require matching etag")
 	update.Flags().BoolVarP(&upDryRun, "dry-run", "n", false, "Preview without writing")
+	update.Flags().StringVar(&upInput, "input", "", "Event JSON path or - for stdin (flags take precedence)")
 
-	var mvTo, mvBy, mvEnd, mvDuration, mvScope string
+	var mvTo, mvBy, mvEnd, mvDuration, mvScope, mvBetween, mvStep, mvWithin string
+	var mvBusyCalendars []string
 	var mvIfMatch int
-	var mvDryRun bool
+	var mvDryRun, mvNextFree, mvWeekends, mvIncludeAllDay, mvIgnoreQuiet bool
 	move := &cobra.Command{
 		Use:   "move <event-id>",
 		Short: "Move an event to a new time",
@@ -460,46 +687,93 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
 			scope, err := parseRecurrenceScope(mvScope)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --scope auto|this|future|series", 2)
 			}
-			if (mvTo == "" && mvBy == "") || (mvTo != "" && mvBy != "") {
-				err = errors.New("use exactly one of --to or --by")
-				return failWithHint(p, contract.ErrInvalidUsage, err, "Set --to <datetime> or --by <duration>", 2)
+			modes := 0
+			for _, set := range []bool{mvTo != "", mvBy != "", mvNextFree} {
+				if set {
+					modes++
+				}
+			}
+			if modes != 1 {
+				err = errors.New("use exactly one of --to, --by, or --to-next-free")
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Set --to <datetime>, --by <duration>, or --to-next-free", 2)
+			}
+			if mvNextFree && mvEnd != "" {
+				err = errors.New("--end cannot be combined with --to-next-free")
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --duration to change the length", 2)
 			}
 			loc := resolveLocation(ro.TZ)
 			var by time.Duration
 			start := time.Time{}
 			if mvTo != "" {
-				start, err = timeparse.ParseDateTime(mvTo, time.Now(), loc)
+				start, err = timeparse.ParseDateTime(mvTo, currentTime(), loc)
 				if err != nil {
 					return failWithHint(p, contract.ErrInvalidUsage, err, "Invalid --to datetime", 2)
 				}
-			} else {
-				by, err = time.ParseDuration(mvBy)
+			} else if mvBy != "" {
+				by, err = timeparse.ParseDuration(mvBy)
 				parseErr := err
 				if parseErr != nil || by == 0 {
 					if parseErr == nil {
 						parseErr = errors.New("--by must not be zero")
 					}
-					return failWithHint(p, contract.ErrInvalidUsage, parseErr, "Use a duration like +30m, -1h, 2h", 2)
+					return failWithHint(p, contract.ErrInvalidUsage, parseErr, durationHint, 2)
 				}
 			}
-			current, getErr := getEventByIDWithTimeout(ctx, be, args[0])
+			current, getErr := getEventByIDWithTimeout(ctx, be, id)
 			if getErr != nil {
-				return failWithHint(p, contract.ErrNotFound, getErr, "Check ID with `acal events list --fields id,title,start`", 4)
+				return failWithHint(p, contract.ErrNotFound, withEventIDs(getErr, id), "Check ID with `acal events list --fields id,title,start`", 4)
 			}
 			if mvIfMatch > 0 && current.Sequence != mvIfMatch {
 				err = fmt.Errorf("sequence mismatch: current=%d expected=%d", current.Sequence, mvIfMatch)
 				return failWithHint(p, contract.ErrConcurrency, err, "Re-fetch event and retry", 7)
 			}
-			if mvTo == "" {
+			if mvBy != "" {
 				start = current.Start.Add(by)
 			}
 
 			var end time.Time
-			if mvEnd != "" || mvDuration != "" {
+			meta := map[string]any{}
+			if mvNextFree {
+				d := current.End.Sub(current.Start)
+				if mvDuration != "" {
+					d, err = timeparse.ParseDuration(mvDuration)
+				}
+				if err == nil && d <= 0 {
+					err = errors.New("duration must be positive")
+				}
+				if err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+				}
+				slot, found, err := nextFreeSlot(ctx, be, current, d, nextFreeOptions{
+					Between: mvBetween, Step: mvStep, Within: mvWithin, Calendars: mvBusyCalendars,
+					Weekends: mvWeekends, IncludeAllDay: mvIncludeAllDay, Quiet: quietHoursFor(ro, mvIgnoreQuiet), Loc: loc,
+				})
+				if err != nil {
+					if errors.Is(err, errNextFreeUsage) {
+						return failWithHint(p, contract.ErrInvalidUsage, err, "Use --between HH:MM-HH:MM with positive --step and --within", 2)
+					}
+					return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+				}
+				if !found {
+					err = fmt.Errorf("no free %s slot within %s after %s", formatMinutes(int64(d.Minutes())), mvWithin, current.Start.In(loc).Format("2006-01-02 15:04"))
+					hint := "Widen --within or --between, or pass --weekends"
+					if len(quietHoursFor(ro, mvIgnoreQuiet)) > 0 {
+						hint += " or --ignore-quiet-hours"
+					}
+					return failWithHint(p, contract.ErrConflict, err, hint, 5)
+				}
+				start, end = slot.Start, slot.End
+				meta["previous_start"] = current.Start
+				meta["shifted_minutes"] = int64(start.Sub(current.Start).Minutes())
+			} else if mvEnd != "" || mvDuration != "" {
 				end, err = resolveEnd(mvEnd, mvDuration, start, loc)
 				if err != nil {
 					return failWithHint(p, contract.ErrInvalidUsage, err, "Use --end or --duration", 2)
@@ -519,26 +793,35 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 				Scope: scope,
 			}
 			if mvDryRun {
-				return successWithMeta(ctx, p, ro, patch, map[string]any{"dry_run": true}, nil)
+				return successDryRun(ctx, p, ro, []dryRunPreview{previewUpdate(current, patch)}, meta, nil)
 			}
-			item, err := updateEventWithTimeout(ctx, be, args[0], patch)
+			item, err := updateEventWithTimeout(ctx, be, id, patch)
 			if err != nil {
 				return failWithHint(p, contract.ErrGeneric, err, "Move failed", 1)
 			}
-			_ = appendHistory(historyEntry{Type: "update", EventID: args[0], Prev: current, Next: item})
-			return successWithMeta(ctx, p, ro, item, map[string]any{"count": 1}, nil)
+			_ = appendHistory(historyEntry{Type: "update", EventID: id, Prev: current, Next: item})
+			meta["count"] = 1
+			return successWithMeta(ctx, p, ro, item, meta, nil)
 		},
 	}
 	move.Flags().StringVar(&mvTo, "to", "", "New start datetime")
-	move.Flags().StringVar(&mvBy, "by", "", "Offset duration (e.g. 30m, -1h)")
+	move.Flags().StringVar(&mvBy, "by", "", "Offset duration (e.g. 30m, -1h, 1d)")
 	move.Flags().StringVar(&mvEnd, "end", "", "New end datetime")
 	move.Flags().StringVar(&mvDuration, "duration", "", "New duration from start (e.g. 45m)")
 	move.Flags().StringVar(&mvScope, "scope", "auto", "Recurrence scope: auto|this|future|series")
 	move.Flags().IntVar(&mvIfMatch, "if-match-seq", 0, "Require matching sequence number")
 	move.Flags().BoolVarP(&mvDryRun, "dry-run", "n", false, "Preview without writing")
+	move.Flags().BoolVar(&mvNextFree, "to-next-free", false, "Move to the earliest free slot after the current start")
+	move.Flags().StringVar(&mvBetween, "between", "09:00-17:00", "Working hours for --to-next-free (HH:MM-HH:MM or 9am-5pm)")
+	move.Flags().StringVar(&mvStep, "step", "15m", "Candidate step for --to-next-free")
+	move.Flags().StringVar(&mvWithin, "within", "14d", "How far ahead --to-next-free searches")
+	move.Flags().StringSliceVar(&mvBusyCalendars, "busy-calendar", nil, "Calendars that count as busy for --to-next-free (default all)")
+	move.Flags().BoolVar(&mvWeekends, "weekends", false, "Allow --to-next-free to land on Saturdays and Sundays")
+	move.Flags().BoolVar(&mvIncludeAllDay, "include-all-day", false, "Count all-day events as busy for --to-next-free")
+	move.Flags().BoolVar(&mvIgnoreQuiet, "ignore-quiet-hours", false, "Let --to-next-free land inside quiet_hours")
 
 	var cpTo, cpDuration, cpCalendar, cpTitle string
-	var cpDryRun bool
+	var cpDryRun, cpNoConflict, cpForce, cpIgnoreQuiet bool
 	copyCmd := &cobra.Command{
 		Use:   "copy <event-id>",
 		Short: "Copy an event to a new time",
@@ -550,30 +833,34 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
 			if cpTo == "" {
 				err = errors.New("--to is required")
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Set --to <datetime> for the copied event start", 2)
 			}
 			loc := resolveLocation(ro.TZ)
-			start, err := timeparse.ParseDateTime(cpTo, time.Now(), loc)
+			start, err := timeparse.ParseDateTime(cpTo, currentTime(), loc)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Invalid --to datetime", 2)
 			}
 			var explicitDuration *time.Duration
 			if cpDuration != "" {
-				d, parseErr := time.ParseDuration(cpDuration)
+				d, parseErr := timeparse.ParseDuration(cpDuration)
 				err = parseErr
 				if err != nil || d <= 0 {
 					if err == nil {
 						err = errors.New("--duration must be positive")
 					}
-					return failWithHint(p, contract.ErrInvalidUsage, err, "Use a positive duration like 30m or 1h", 2)
+					return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
 				}
 				explicitDuration = &d
 			}
-			current, err := getEventByIDWithTimeout(ctx, be, args[0])
+			current, err := getEventByRef(ctx, be, id, currentTime())
 			if err != nil {
-				return failWithHint(p, contract.ErrNotFound, err, "Check ID with `acal events list --fields id,title,start`", 4)
+				return failWithHint(p, contract.ErrNotFound, withEventIDs(err, id), "Check ID with `acal events list --fields id,title,start`", 4)
 			}
 			duration := current.End.Sub(current.Start)
 			if explicitDuration != nil {
@@ -599,17 +886,24 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 				title = current.Title
 			}
 			in := backend.EventCreateInput{
-				Calendar: calendar,
-				Title:    title,
-				Start:    start,
-				End:      start.Add(duration),
-				Location: current.Location,
-				Notes:    current.Notes,
-				URL:      current.URL,
-				AllDay:   current.AllDay,
+				Calendar:    calendar,
+				Title:       title,
+				Start:       start,
+				End:         start.Add(duration),
+				Location:    current.Location,
+				Notes:       current.Notes,
+				URL:         current.URL,
+				Status:      current.Status,
+				Sensitivity: current.Sensitivity,
+				AllDay:      current.AllDay,
+			}
+			if cpNoConflict && !cpForce {
+				if err := checkNoConflict(ctx, p, be, in, loc, quietHoursFor(ro, cpIgnoreQuiet)); err != nil {
+					return err
+				}
 			}
 			if cpDryRun {
-				return successWithMeta(ctx, p, ro, in, map[string]any{"dry_run": true}, nil)
+				return successDryRun(ctx, p, ro, []dryRunPreview{previewAdd(in)}, nil, nil)
 			}
 			item, err := addEventWithTimeout(ctx, be, in)
 			if err != nil {
@@ -626,10 +920,14 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 	copyCmd.Flags().StringVar(&cpCalendar, "calendar", "", "Destination calendar (defaults to source)")
 	copyCmd.Flags().StringVar(&cpTitle, "title", "", "Override copied title")
 	copyCmd.Flags().BoolVarP(&cpDryRun, "dry-run", "n", false, "Preview without writing")
+	copyCmd.Flags().BoolVar(&cpNoConflict, "no-conflict", false, "Refuse to create the copy if it overlaps an existing event or quiet hours (exit 5)")
+	copyCmd.Flags().BoolVar(&cpIgnoreQuiet, "ignore-quiet-hours", false, "Let --no-conflict accept times inside quiet_hours")
+	copyCmd.Flags().BoolVar(&cpForce, "force", false, "Copy even if --no-conflict finds an overlap")
 
-	var delForce, delDryRun bool
+	var delForce, delDryRun, delSoft, delHard, delCreatedByAcal bool
 	var delConfirm, delScope string
 	var delIfMatch int
+	var delIfMatchETag string
 	deleteCmd := &cobra.Command{
 		Use:   "delete <event-id>",
 		Short: "Delete an event",
@@ -641,12 +939,25 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
-			if !delForce && delConfirm != args[0] {
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
+			if delCreatedByAcal {
+				uids, err := createdEventUIDs()
+				if err != nil {
+					return failWithHint(p, contract.ErrGeneric, err, "Check history file permissions (`acal state path`)", 1)
+				}
+				if err := checkCreatedByAcal(uids, id); err != nil {
+					return failWithHint(p, contract.ErrPermissionDenied, err, "Only events acal added (see `acal events mine`) can be deleted with --created-by-acal", 3)
+				}
+			}
+			if !delForce && delConfirm != id && delConfirm != strings.TrimSpace(args[0]) {
 				if ro.NoInput || !stdinInteractive() {
 					err = errors.New("non-interactive delete requires --force or --confirm <event-id>")
 					return failWithHint(p, contract.ErrInvalidUsage, err, "Add --confirm exactly matching the event ID", 2)
 				}
-				ok, promptErr := promptConfirmID(os.Stdin, cmd.ErrOrStderr(), args[0])
+				ok, promptErr := promptConfirmID(os.Stdin, cmd.ErrOrStderr(), id)
 				if promptErr != nil {
 					return failWithHint(p, contract.ErrInvalidUsage, promptErr, "Use --force or --confirm <event-id> in non-interactive mode", 2)
 				}
@@ -659,11 +970,22 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --scope auto|this|future|series", 2)
 			}
+			if delSoft && delHard {
+				err = errors.New("--soft and --hard are mutually exclusive")
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Pick one of --soft or --hard", 2)
+			}
+			soft := (ro.SoftDelete || delSoft) && !delHard
 			if delDryRun {
-				item := &contract.Event{ID: args[0]}
-				return successWithMeta(ctx, p, ro, item, map[string]any{"dry_run": true, "scope": scope, "lookup_skipped": true}, nil)
+				item, err := snapshotForPreview(ctx, be, id)
+				if err != nil {
+					return failWithHint(p, contract.ErrNotFound, withEventIDs(err, id), "Check ID with `acal events list --fields id,title,start`", 4)
+				}
+				return successDryRun(ctx, p, ro, []dryRunPreview{previewDelete(item)}, map[string]any{"scope": scope, "soft": soft}, nil)
+			}
+			item, getErr := getEventByIDWithTimeout(ctx, be, id)
+			if getErr != nil && soft {
+				return failWithHint(p, contract.ErrNotFound, getErr, "Soft delete needs the event snapshot; use --hard to delete without it", 4)
 			}
-			item, getErr := getEventByIDWithTimeout(ctx, be, args[0])
 			if getErr == nil && delIfMatch > 0 && item.Sequence != delIfMatch {
 				err = fmt.Errorf("sequence mismatch: current=%d expected=%d", item.Sequence, delIfMatch)
 				return failWithHint(p, contract.ErrConcurrency, err, "Re-fetch event and retry", 7)
@@ -671,27 +993,51 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			if getErr != nil && delIfMatch > 0 {
 				return failWithHint(p, contract.ErrNotFound, getErr, "Unable to verify sequence for --if-match-seq", 4)
 			}
-			if err := deleteEventWithTimeout(ctx, be, args[0], scope); err != nil {
+			if delIfMatchETag != "" {
+				if getErr != nil {
+					return failWithHint(p, contract.ErrNotFound, getErr, "Unable to verify etag for --if-match-etag", 4)
+				}
+				if item.ETag != delIfMatchETag {
+					err = fmt.Errorf("etag mismatch: current=%s expected=%s", item.ETag, delIfMatchETag)
+					return failWithHint(p, contract.ErrConcurrency, err, "Re-fetch event and retry", 7)
+				}
+			}
+			if soft {
+				if err := checkSoftDeleteScope(ctx, be, id, scope, item); err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Use --scope this to trash one occurrence, or --hard to delete the series", 2)
+				}
+				if err := addToTrash(trashEntry{EventID: id, Scope: scope, Event: *item}); err != nil {
+					return failWithHint(p, contract.ErrGeneric, err, "Unable to archive event to trash; use --hard to skip it", 1)
+				}
+			}
+			if err := deleteEventWithTimeout(ctx, be, id, scope); err != nil {
+				if soft {
+					_ = removeFromTrash(id)
+				}
 				return failWithHint(p, contract.ErrGeneric, err, "Delete failed", 1)
 			}
 			if item != nil {
-				_ = appendHistory(historyEntry{Type: "delete", EventID: args[0], Deleted: item})
+				_ = appendHistory(historyEntry{Type: "delete", EventID: id, Deleted: item})
 			}
-			return successWithMeta(ctx, p, ro, map[string]any{"deleted": true, "id": args[0], "scope": scope}, map[string]any{"count": 1}, nil)
+			return successWithMeta(ctx, p, ro, map[string]any{"deleted": true, "id": id, "scope": scope, "trashed": soft}, map[string]any{"count": 1}, nil)
 		},
 	}
 	deleteCmd.Flags().BoolVarP(&delForce, "force", "f", false, "Force delete without confirmation")
 	deleteCmd.Flags().StringVar(&delConfirm, "confirm", "", "Confirm exact event ID")
 	deleteCmd.Flags().StringVar(&delScope, "scope", "auto", "Recurrence scope: auto|this|future|series")
 	deleteCmd.Flags().IntVar(&delIfMatch, "if-match-seq", 0, "Require matching sequence number")
+	deleteCmd.Flags().StringVar(&delIfMatchETag, "if-match-etag", "", "Require matching etag")
 	deleteCmd.Flags().BoolVarP(&delDryRun, "dry-run", "n", false, "Preview without writing")
+	deleteCmd.Flags().BoolVar(&delSoft, "soft", false, "Archive the event to the trash before deleting (see events restore)")
+	deleteCmd.Flags().BoolVar(&delHard, "hard", false, "Delete immediately even when soft_delete is enabled")
+	deleteCmd.Flags().BoolVar(&delCreatedByAcal, "created-by-acal", false, "Refuse unless the event was created through acal")
 
-	var remindAt string
+	var remindAt, remindSnooze string
 	var remindClear, remindDryRun bool
 	var remindIfMatch int
 	remind := &cobra.Command{
 		Use:   "remind <event-id>",
-		Short: "Set or clear reminder metadata for an event",
+		Short: "Set, snooze, or clear an event's reminder",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			p, be, ro, err := buildContext(cmd, opts, "events.remind")
@@ -700,20 +1046,42 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
-			if (strings.TrimSpace(remindAt) == "") == !remindClear {
-				return failWithHint(p, contract.ErrInvalidUsage, errors.New("use exactly one of --at or --clear"), "Set --at <duration> or --clear", 2)
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
+			set := 0
+			for _, on := range []bool{strings.TrimSpace(remindAt) != "", strings.TrimSpace(remindSnooze) != "", remindClear} {
+				if on {
+					set++
+				}
+			}
+			if set != 1 {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("use exactly one of --at, --snooze, or --clear"), "Set --at <duration>, --snooze <duration>, or --clear", 2)
 			}
 			var parsedOffset *time.Duration
-			if !remindClear {
+			if strings.TrimSpace(remindAt) != "" {
 				offset, parseErr := normalizeReminderOffset(remindAt)
 				if parseErr != nil {
-					return failWithHint(p, contract.ErrInvalidUsage, parseErr, "Use duration like -15m, 10m, 1h", 2)
+					return failWithHint(p, contract.ErrInvalidUsage, parseErr, durationHint, 2)
 				}
 				parsedOffset = &offset
 			}
-			item, err := getEventByIDWithTimeout(ctx, be, args[0])
+			var snoozeUntil *time.Time
+			if strings.TrimSpace(remindSnooze) != "" {
+				d, parseErr := timeparse.ParseDuration(remindSnooze)
+				if parseErr == nil && d <= 0 {
+					parseErr = errors.New("--snooze must be positive")
+				}
+				if parseErr != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, parseErr, durationHint, 2)
+				}
+				at := currentTime().Add(d).Truncate(time.Second)
+				snoozeUntil = &at
+			}
+			item, err := getEventByIDWithTimeout(ctx, be, id)
 			if err != nil {
-				return failWithHint(p, contract.ErrNotFound, err, "Check ID with `acal events list --fields id,title,start`", 4)
+				return failWithHint(p, contract.ErrNotFound, withEventIDs(err, id), "Check ID with `acal events list --fields id,title,start`", 4)
 			}
 			if remindIfMatch > 0 && item.Sequence != remindIfMatch {
 				err = fmt.Errorf("sequence mismatch: current=%d expected=%d", item.Sequence, remindIfMatch)
@@ -721,21 +1089,26 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 			}
 			meta := map[string]any{"count": 1}
 			patch := backend.EventUpdateInput{Scope: backend.ScopeAuto}
-			if remindClear {
+			switch {
+			case remindClear:
 				patch.ClearReminder = true
 				meta["cleared"] = true
-			} else {
+			case snoozeUntil != nil:
+				// An absolute trigger leaves the event itself where it is.
+				patch.ReminderAt = snoozeUntil
+				meta["snoozed_until"] = snoozeUntil.In(resolveLocation(ro.TZ)).Format(time.RFC3339)
+			default:
 				patch.ReminderOffset = parsedOffset
 				meta["offset"] = parsedOffset.String()
 			}
 			if remindDryRun {
 				return successWithMeta(ctx, p, ro, patch, meta, nil)
 			}
-			updated, err := updateEventWithTimeout(ctx, be, args[0], patch)
+			updated, err := updateEventWithTimeout(ctx, be, id, patch)
 			if err != nil {
 				return failWithHint(p, contract.ErrGeneric, err, "Reminder update failed", 1)
 			}
-			observed, verifyErr := reminderOffsetWithTimeout(ctx, be, args[0])
+			observed, verifyErr := reminderOffsetWithTimeout(ctx, be, id)
 			if verifyErr != nil {
 				return failWithHint(p, contract.ErrGeneric, verifyErr, "Reminder updated but verification failed; retry `acal events show <id>`", 1)
 			}
@@ -745,25 +1118,99 @@ func newEventsCmd(opts *globalOptions) *cobra.Command {
 				}
 				meta["verified"] = true
 			}
+			if patch.ReminderAt != nil {
+				if observed == nil {
+					return failWithHint(p, contract.ErrGeneric, errors.New("reminder snooze verification failed"), "No reminder found after snoozing", 1)
+				}
+				meta["verified"] = true
+			}
 			if patch.ReminderOffset != nil {
 				if observed == nil || *observed != *patch.ReminderOffset {
 					return failWithHint(p, contract.ErrGeneric, errors.New("reminder offset verification failed"), "Observed reminder does not match requested offset", 1)
 				}
 				meta["verified"] = true
 			}
-			_ = appendHistory(historyEntry{Type: "update", EventID: args[0], Prev: item, Next: updated})
+			_ = appendHistory(historyEntry{Type: "update", EventID: id, Prev: item, Next: updated})
 			return successWithMeta(ctx, p, ro, updated, meta, nil)
 		},
 	}
-	remind.Flags().StringVar(&remindAt, "at", "", "Reminder offset (e.g. -15m, 1h)")
+	remind.Flags().StringVar(&remindAt, "at", "", "Reminder offset (e.g. -15m, 1h, 1d)")
+	remind.Flags().StringVar(&remindSnooze, "snooze", "", "Push the reminder to this long from now (e.g. 10m), without moving the event")
 	remind.Flags().BoolVar(&remindClear, "clear", false, "Clear reminder metadata marker")
 	remind.Flags().IntVar(&remindIfMatch, "if-match-seq", 0, "Require matching sequence number")
 	remind.Flags().BoolVarP(&remindDryRun, "dry-run", "n", false, "Preview without writing")
 
-	events.AddCommand(list, search, show, query, conflicts, newEventsExportCmd(opts), newEventsImportCmd(opts), newEventsBatchCmd(opts), add, update, copyCmd, move, deleteCmd, remind, newEventsQuickAddCmd(opts))
+	events.AddCommand(list, search, show, query, conflicts, newEventsAuditCmd(opts), newEventsExportCmd(opts), newEventsImportCmd(opts), newEventsFromEmailCmd(opts), newEventsBatchCmd(opts), add, update, copyCmd, move, newEventsResizeCmd(opts, "extend", 1), newEventsResizeCmd(opts, "shorten", -1), newEventsSplitCmd(opts), newEventsMergeCmd(opts), deleteCmd, remind, newEventsTagCmd(opts), newEventsMirrorCmd(opts), newEventsNotesTemplateCmd(opts), newEventsTrashCmd(opts), newEventsDeletedCmd(opts), newEventsRestoreCmd(opts), newEventsQuickAddCmd(opts), newEventsSeriesCmd(opts), newEventsMineCmd(opts), newEventsRSVPCmd(opts), newEventsRevealCmd(opts))
 	return events
 }
 
+type conflictRow struct {
+	LeftID           string    `json:"left_id"`
+	LeftTitle        string    `json:"left_title"`
+	LeftCalendar     string    `json:"left_calendar"`
+	RightID          string    `json:"right_id"`
+	RightTitle       string    `json:"right_title"`
+	RightCalendar    string    `json:"right_calendar"`
+	OverlapStart     time.Time `json:"overlap_start"`
+	OverlapEnd       time.Time `json:"overlap_end"`
+	OverlapMinutes   int64     `json:"overlap_minutes"`
+	SameCalendarOnly bool      `json:"same_calendar_only"`
+}
+
+func buildConflictRows(items []contract.Event, includeAllDay bool) []conflictRow {
+	if len(items) < 2 {
+		return nil
+	}
+	eventsCopy := make([]contract.Event, 0, len(items))
+	for _, it := range items {
+		if !includeAllDay && it.AllDay {
+			continue
+		}
+		eventsCopy = append(eventsCopy, it)
+	}
+	if len(eventsCopy) < 2 {
+		return nil
+	}
+	sort.Slice(eventsCopy, func(i, j int) bool {
+		if eventsCopy[i].Start.Equal(eventsCopy[j].Start) {
+			if eventsCopy[i].End.Equal(eventsCopy[j].End) {
+				return eventsCopy[i].ID < eventsCopy[j].ID
+			}
+			return eventsCopy[i].End.Before(eventsCopy[j].End)
+		}
+		return eventsCopy[i].Start.Before(eventsCopy[j].Start)
+	})
+
+	rows := make([]conflictRow, 0)
+	for i := 0; i < len(eventsCopy); i++ {
+		for j := i + 1; j < len(eventsCopy); j++ {
+			if !eventsCopy[j].Start.Before(eventsCopy[i].End) {
+				break
+			}
+			overlapStart := maxTime(eventsCopy[i].Start, eventsCopy[j].Start)
+			overlapEnd := minTime(eventsCopy[i].End, eventsCopy[j].End)
+			if !overlapStart.Before(overlapEnd) {
+				continue
+			}
+			leftCal := firstNonEmpty(eventsCopy[i].CalendarName, eventsCopy[i].CalendarID)
+			rightCal := firstNonEmpty(eventsCopy[j].CalendarName, eventsCopy[j].CalendarID)
+			rows = append(rows, conflictRow{
+				LeftID:           eventsCopy[i].ID,
+				LeftTitle:        eventsCopy[i].Title,
+				LeftCalendar:     leftCal,
+				RightID:          eventsCopy[j].ID,
+				RightTitle:       eventsCopy[j].Title,
+				RightCalendar:    rightCal,
+				OverlapStart:     overlapStart,
+				OverlapEnd:       overlapEnd,
+				OverlapMinutes:   int64(overlapEnd.Sub(overlapStart).Minutes()),
+				SameCalendarOnly: leftCal == rightCal,
+			})
+		}
+	}
+	return rows
+}
+
 func minTime(a, b time.Time) time.Time {
 	if a.Before(b) {
 		return a
@@ -782,6 +1229,26 @@ func failWithHint(printer output.Printer, code contract.ErrorCode, err error, hi
 	if err == nil {
 		err = errors.New("unknown error")
 	}
+	if errors.Is(err, backend.ErrPreconditionFailed) {
+		code = contract.ErrConcurrency
+		exitCode = 7
+		hint = "Re-fetch event and retry"
+	}
+	if errors.Is(err, backend.ErrBackendUnavailable) {
+		code = contract.ErrBackendUnavailable
+		exitCode = 6
+		hint = "Use --backend caldav or --backend mock on this platform"
+	}
+	if errors.Is(err, backend.ErrUnsupportedSchema) {
+		code = contract.ErrUnsupportedSchema
+		exitCode = 8
+		hint = "Run `acal doctor`; this macOS Calendar database layout needs a newer acal"
+	}
+	if errors.Is(err, errCalendarWriteDenied) {
+		code = contract.ErrPermissionDenied
+		exitCode = 3
+		hint = "Calendar is excluded by writable_calendars/protected_calendars in config"
+	}
 	meta := backendErrorMeta(err)
 	if meta != nil {
 		code = contract.ErrBackendUnavailable
@@ -794,6 +1261,6 @@ func failWithHint(printer output.Printer, code contract.ErrorCode, err error, hi
 			hint = "Retry command; operation was canceled"
 		}
 	}
-	_ = printer.ErrorWithMeta(code, err.Error(), hint, meta)
+	_ = printer.ErrorWithDetails(code, err.Error(), hint, errorDetails(err), meta)
 	return WrapPrinted(exitCode, err)
 }
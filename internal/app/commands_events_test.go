@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 	"testing"
@@ -41,7 +42,8 @@ func (b *scopeCaptureBackend) ListCalendars(context.Context) ([]contract.Calenda
 	return nil, nil
 }
 
-func (b *scopeCaptureBackend) ListEvents(context.Context, backend.EventFilter) ([]contract.Event, error) {
+func (b *scopeCaptureBackend) ListEvents(_ context.Context, f backend.EventFilter) ([]contract.Event, error) {
+	b.lastFilter = f
 	if b.listErr != nil {
 		return nil, b.listErr
 	}
@@ -99,7 +101,7 @@ func (b *scopeCaptureBackend) DeleteEvent(_ context.Context, _ string, scope bac
 func TestEventsUpdatePassesScope(t *testing.T) {
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -117,7 +119,7 @@ func TestEventsUpdatePassesScope(t *testing.T) {
 func TestEventsDeletePassesScope(t *testing.T) {
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -132,10 +134,29 @@ func TestEventsDeletePassesScope(t *testing.T) {
 	}
 }
 
+func TestEventsUpdatePreconditionFailedMapsToConcurrency(t *testing.T) {
+	fb := &scopeCaptureBackend{updateErr: fmt.Errorf("caldav PUT /cal/work/a.ics: %w", backend.ErrPreconditionFailed)}
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+
+	cmd := NewRootCommand()
+	var stderr bytes.Buffer
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"events", "update", "evt@792417600", "--title", "new", "--json"})
+	if got := ExitCode(cmd.Execute()); got != 7 {
+		t.Fatalf("exit code mismatch: got=%d want=7", got)
+	}
+	if !strings.Contains(stderr.String(), string(contract.ErrConcurrency)) {
+		t.Fatalf("expected concurrency error code, got %s", stderr.String())
+	}
+}
+
 func TestEventsVerboseEmitsDiagnostics(t *testing.T) {
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -192,7 +213,7 @@ func TestEventsUpdateValidationMatrix(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			origFactory := backendFactory
-			backendFactory = func(string) (backend.Backend, error) { return tc.backend, nil }
+			backendFactory = func(*globalOptions) (backend.Backend, error) { return tc.backend, nil }
 			t.Cleanup(func() { backendFactory = origFactory })
 
 			cmd := NewRootCommand()
@@ -258,7 +279,7 @@ func TestEventsDeleteValidationMatrix(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			origFactory := backendFactory
-			backendFactory = func(string) (backend.Backend, error) { return tc.backend, nil }
+			backendFactory = func(*globalOptions) (backend.Backend, error) { return tc.backend, nil }
 			t.Cleanup(func() { backendFactory = origFactory })
 
 			cmd := NewRootCommand()
@@ -287,7 +308,7 @@ func TestEventsMovePassesPatch(t *testing.T) {
 		},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -362,7 +383,7 @@ func TestEventsMoveValidationMatrix(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			origFactory := backendFactory
-			backendFactory = func(string) (backend.Backend, error) { return tc.backend, nil }
+			backendFactory = func(*globalOptions) (backend.Backend, error) { return tc.backend, nil }
 			t.Cleanup(func() { backendFactory = origFactory })
 
 			cmd := NewRootCommand()
@@ -395,7 +416,7 @@ func TestEventsCopyPassesAddInput(t *testing.T) {
 		},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -460,7 +481,7 @@ func TestEventsCopyValidationMatrix(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			origFactory := backendFactory
-			backendFactory = func(string) (backend.Backend, error) { return tc.backend, nil }
+			backendFactory = func(*globalOptions) (backend.Backend, error) { return tc.backend, nil }
 			t.Cleanup(func() { backendFactory = origFactory })
 
 			cmd := NewRootCommand()
@@ -486,7 +507,7 @@ func TestEventsConflictsJSON(t *testing.T) {
 		{ID: "e3", Title: "Lunch", CalendarName: "Personal", Start: base.Add(2 * time.Hour), End: base.Add(3 * time.Hour)},
 	}}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -534,7 +555,7 @@ func TestEventsConflictsExcludesAllDayByDefault(t *testing.T) {
 		{ID: "e2", Title: "Standup", CalendarName: "Work", Start: base.Add(9 * time.Hour), End: base.Add(10 * time.Hour)},
 	}}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -565,7 +586,7 @@ func TestEventsRemindDryRunSetsReminderPatch(t *testing.T) {
 		},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -590,7 +611,7 @@ func TestEventsRemindClearCallsUpdate(t *testing.T) {
 		},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -616,7 +637,7 @@ func TestEventsRemindSetVerifiesReadback(t *testing.T) {
 		},
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -643,7 +664,7 @@ func TestEventsRemindVerificationFailure(t *testing.T) {
 		remindErr: errors.New("readback failed"),
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -655,3 +676,351 @@ func TestEventsRemindVerificationFailure(t *testing.T) {
 		t.Fatalf("expected exit code 1, got %d err=%v", code, err)
 	}
 }
+
+func TestEventsRemindSnoozeSetsAbsoluteReminder(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: "standup", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: start, End: start.Add(15 * time.Minute)},
+	}})
+	var env struct {
+		Data contract.Event `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	out := runWithBackend(t, fb, "events", "remind", "standup", "--snooze", "10m", "--now", "2026-03-02T09:05:00Z", "--tz", "UTC", "--json")
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if env.Meta["snoozed_until"] != "2026-03-02T09:15:00Z" || env.Meta["verified"] != true {
+		t.Fatalf("unexpected meta: %v", env.Meta)
+	}
+	if !env.Data.Start.Equal(start) {
+		t.Fatalf("snooze must not move the event, got start %s", env.Data.Start)
+	}
+	if got, _ := fb.GetReminderOffset(context.Background(), "standup"); got == nil || *got != 15*time.Minute {
+		t.Fatalf("expected the alarm 15m after the start, got %v", got)
+	}
+	if code := runEventsCmd(t, fb, "events", "remind", "standup", "--snooze", "10m", "--at", "-5m"); code != 2 {
+		t.Fatalf("expected exit 2 for --snooze with --at, got %d", code)
+	}
+	if code := runEventsCmd(t, fb, "events", "remind", "standup", "--snooze", "0m"); code != 2 {
+		t.Fatalf("expected exit 2 for a zero snooze, got %d", code)
+	}
+}
+
+func TestEventsAddAndUpdateStatusAvailability(t *testing.T) {
+	fb := &scopeCaptureBackend{}
+	if code := runEventsCmd(t, fb, "events", "add", "--calendar", "Work", "--title", "Focus", "--start", "2026-03-02T09:00:00Z", "--duration", "1h", "--status", "tentative", "--availability", "free", "--json"); code != 0 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	if fb.addInput.Status != contract.StatusTentative || fb.addInput.Availability != contract.AvailabilityFree {
+		t.Fatalf("unexpected add input: %+v", fb.addInput)
+	}
+	if code := runEventsCmd(t, fb, "events", "add", "--calendar", "Work", "--title", "Focus", "--start", "2026-03-02T09:00:00Z", "--availability", "maybe", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for invalid availability, got %d", code)
+	}
+
+	fb = &scopeCaptureBackend{getEvent: &contract.Event{ID: "evt@792417600", Start: time.Now(), Availability: contract.AvailabilityBusy}}
+	if code := runEventsCmd(t, fb, "events", "update", "evt@792417600", "--status", "none", "--availability", "busy", "--json"); code != 0 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	if fb.updateInput.Status == nil || *fb.updateInput.Status != "" {
+		t.Fatalf("expected status cleared, got %+v", fb.updateInput.Status)
+	}
+	if fb.updateInput.Availability != nil {
+		t.Fatalf("expected unchanged availability to be dropped, got %q", *fb.updateInput.Availability)
+	}
+	if code := runEventsCmd(t, fb, "events", "update", "evt@792417600", "--status", "maybe", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for invalid status, got %d", code)
+	}
+}
+
+func TestEventsAddAndUpdateSensitivity(t *testing.T) {
+	fb := &scopeCaptureBackend{}
+	if code := runEventsCmd(t, fb, "events", "add", "--calendar", "Work", "--title", "Doctor", "--start", "2026-03-02T09:00:00Z", "--duration", "1h", "--sensitivity", "Private", "--json"); code != 0 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	if fb.addInput.Sensitivity != contract.SensitivityPrivate {
+		t.Fatalf("unexpected add input: %+v", fb.addInput)
+	}
+	if code := runEventsCmd(t, fb, "events", "add", "--calendar", "Work", "--title", "Doctor", "--start", "2026-03-02T09:00:00Z", "--sensitivity", "secret", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for invalid sensitivity, got %d", code)
+	}
+
+	fb = &scopeCaptureBackend{getEvent: &contract.Event{ID: "evt@792417600", Start: time.Now()}}
+	if code := runEventsCmd(t, fb, "events", "update", "evt@792417600", "--sensitivity", "public", "--json"); code != 0 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	if fb.updateInput.Sensitivity != nil {
+		t.Fatalf("expected unchanged sensitivity to be dropped, got %q", *fb.updateInput.Sensitivity)
+	}
+	if code := runEventsCmd(t, fb, "events", "update", "evt@792417600", "--sensitivity", "confidential", "--json"); code != 0 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	if fb.updateInput.Sensitivity == nil || *fb.updateInput.Sensitivity != contract.SensitivityConfidential {
+		t.Fatalf("expected confidential patch, got %+v", fb.updateInput.Sensitivity)
+	}
+}
+
+func TestHidePrivateMasksEvents(t *testing.T) {
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Therapy", Location: "Clinic", Start: start, End: start.Add(time.Hour), Sensitivity: contract.SensitivityPrivate},
+			{ID: "b", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)},
+		},
+	})
+	out := string(runWithBackend(t, fb, "events", "list", "--from", "2026-03-03", "--to", "2026-03-04", "--hide-private", "--plain", "--fields", "id,title,location"))
+	if strings.Contains(out, "Therapy") || strings.Contains(out, "Clinic") {
+		t.Fatalf("private details leaked: %q", out)
+	}
+	if !strings.Contains(out, "a\tPrivate event\t") || !strings.Contains(out, "b\tStandup") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	t.Setenv("ACAL_HIDE_PRIVATE", "1")
+	out = string(runWithBackend(t, fb, "events", "show", "a", "--json"))
+	if strings.Contains(out, "Therapy") {
+		t.Fatalf("ACAL_HIDE_PRIVATE did not mask: %q", out)
+	}
+}
+
+func TestLocaleParsesAndFormatsPlainDates(t *testing.T) {
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: start, End: start.Add(time.Hour)},
+		},
+	})
+	out := string(runWithBackend(t, fb, "events", "list", "--locale", "de", "--tz", "UTC", "--from", "3. März 2026", "--to", "4. März 2026", "--plain", "--fields", "title,start"))
+	if strings.TrimSpace(out) != "Standup\tDi 03.03.2026 10:00" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if code := runEventsCmd(t, fb, "events", "list", "--locale", "xx", "--from", "today", "--to", "+1d", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for unknown locale, got %d", code)
+	}
+}
+
+func TestTimeFormatTwelveHourPlainOutput(t *testing.T) {
+	start := time.Date(2026, 3, 3, 15, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Review", Start: start, End: start.Add(time.Hour)},
+		},
+	})
+	out := string(runWithBackend(t, fb, "events", "list", "--time-format", "12h", "--tz", "UTC", "--from", "2026-03-03 2pm", "--to", "2026-03-03 5pm", "--plain", "--fields", "title,start"))
+	if strings.TrimSpace(out) != "Review\tTue 2026-03-03 3:00pm" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if code := runEventsCmd(t, fb, "events", "list", "--time-format", "13h", "--from", "today", "--to", "+1d", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for invalid time format, got %d", code)
+	}
+}
+
+func TestEventsMoveAndRemindAcceptDayAndWordDurations(t *testing.T) {
+	base := time.Date(2026, 2, 20, 10, 0, 0, 0, time.UTC)
+	fb := &scopeCaptureBackend{getEvent: &contract.Event{ID: "evt@792417600", Start: base, End: base.Add(30 * time.Minute), Sequence: 1}}
+	if code := runEventsCmd(t, fb, "events", "move", "evt@792417600", "--by", "1d", "--json"); code != 0 {
+		t.Fatalf("move exit code %d", code)
+	}
+	if got := fb.updateInput.Start.Format(time.RFC3339); got != "2026-02-21T10:00:00Z" {
+		t.Fatalf("unexpected moved start %s", got)
+	}
+	if code := runEventsCmd(t, fb, "events", "remind", "evt@792417600", "--at", "1 hour", "--json"); code != 0 {
+		t.Fatalf("remind exit code %d", code)
+	}
+	if fb.reminder == nil || *fb.reminder != -time.Hour {
+		t.Fatalf("unexpected reminder %v", fb.reminder)
+	}
+	if code := runEventsCmd(t, fb, "events", "move", "evt@792417600", "--by", "5 fortnights", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for bad duration, got %d", code)
+	}
+}
+
+func TestEventsMoveToNextFree(t *testing.T) {
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC) }
+	ev := func(id string, start, end time.Time) contract.Event {
+		return contract.Event{ID: id, CalendarID: "work", CalendarName: "Work", Title: id, Start: start, End: end}
+	}
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			ev("review", at(3, 10, 0), at(3, 11, 0)),
+			ev("offsite", at(3, 10, 30), at(3, 12, 0)),
+			ev("workshop", at(3, 13, 0), at(3, 16, 30)),
+			ev("retro", at(6, 16, 0), at(6, 17, 0)),
+		},
+	})
+	var env struct {
+		Data []dryRunPreview `json:"data"`
+		Meta map[string]any  `json:"meta"`
+	}
+	args := []string{"events", "move", "review", "--to-next-free", "--tz", "UTC", "--dry-run", "--json"}
+	if err := json.Unmarshal(runWithBackend(t, fb, args...), &env); err != nil {
+		t.Fatal(err)
+	}
+	if !env.Data[0].After.Start.Equal(at(3, 12, 0)) || !env.Data[0].After.End.Equal(at(3, 13, 0)) || env.Meta["shifted_minutes"] != float64(120) {
+		t.Fatalf("expected the gap at 12:00, got %+v %+v", env.Data, env.Meta)
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, append(args, "--between", "09:00-12:30")...), &env); err != nil {
+		t.Fatal(err)
+	}
+	if !env.Data[0].After.Start.Equal(at(4, 9, 0)) {
+		t.Fatalf("expected the next morning when the gap is outside working hours, got %v", env.Data[0].After.Start)
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "move", "retro", "--to-next-free", "--tz", "UTC", "--dry-run", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if !env.Data[0].After.Start.Equal(at(9, 9, 0)) {
+		t.Fatalf("expected Friday's last slot to roll over the weekend, got %v", env.Data[0].After.Start)
+	}
+	if code := runEventsCmd(t, fb, "events", "move", "review", "--to-next-free", "--tz", "UTC", "--within", "1h", "--json"); code != 5 {
+		t.Fatalf("expected exit 5 when no slot fits, got %d", code)
+	}
+	if code := runEventsCmd(t, fb, "events", "move", "review", "--to-next-free", "--by", "1h", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for conflicting modes, got %d", code)
+	}
+}
+
+func TestEventsExtendAndShorten(t *testing.T) {
+	base := time.Date(2026, 2, 20, 10, 0, 0, 0, time.UTC)
+	fb := &scopeCaptureBackend{getEvent: &contract.Event{ID: "evt@792417600", Start: base, End: base.Add(30 * time.Minute), Sequence: 3}}
+	if code := runEventsCmd(t, fb, "events", "extend", "evt@792417600", "--by", "15m", "--scope", "this", "--json"); code != 0 {
+		t.Fatalf("extend exit code %d", code)
+	}
+	if fb.updateInput.Start != nil || !fb.updateInput.End.Equal(base.Add(45*time.Minute)) || fb.updateInput.Scope != backend.ScopeThis {
+		t.Fatalf("expected only the end to move: %+v", fb.updateInput)
+	}
+	if code := runEventsCmd(t, fb, "events", "shorten", "evt@792417600", "--by", "10m", "--json"); code != 0 {
+		t.Fatalf("shorten exit code %d", code)
+	}
+	if !fb.updateInput.End.Equal(base.Add(20 * time.Minute)) {
+		t.Fatalf("unexpected shortened end %v", fb.updateInput.End)
+	}
+	if code := runEventsCmd(t, fb, "events", "shorten", "evt@792417600", "--by", "30m", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 when shortening to nothing, got %d", code)
+	}
+	if code := runEventsCmd(t, fb, "events", "extend", "evt@792417600", "--by", "-5m", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for a negative --by, got %d", code)
+	}
+	if code := runEventsCmd(t, fb, "events", "extend", "evt@792417600", "--if-match-seq", "2", "--json"); code != 7 {
+		t.Fatalf("expected exit 7 on sequence mismatch, got %d", code)
+	}
+}
+
+func TestPlainFieldsProjectEventFilter(t *testing.T) {
+	fb := &scopeCaptureBackend{}
+	if code := runEventsCmd(t, fb, "events", "list", "--from", "today", "--to", "+1d", "--plain", "--fields", "id,title,start"); code != 0 {
+		t.Fatalf("list exit code %d", code)
+	}
+	if f := fb.lastFilter; len(f.Fields) == 0 || f.Wants("notes") || f.Wants("location") || !f.Wants("title") {
+		t.Fatalf("expected narrow projection, got %v", f.Fields)
+	}
+	if code := runEventsCmd(t, fb, "events", "query", "--from", "today", "--to", "+1d", "--where", "location~room", "--plain", "--fields", "id,tags"); code != 0 {
+		t.Fatalf("query exit code %d", code)
+	}
+	if f := fb.lastFilter; !f.Wants("location") || !f.Wants("notes") || f.Wants("url") {
+		t.Fatalf("expected predicate and tag sources in projection, got %v", f.Fields)
+	}
+	if code := runEventsCmd(t, fb, "events", "list", "--from", "today", "--to", "+1d", "--json", "--fields", "id,title"); code != 0 {
+		t.Fatalf("json list exit code %d", code)
+	}
+	if fb.lastFilter.Fields != nil {
+		t.Fatalf("json output must fetch full events, got %v", fb.lastFilter.Fields)
+	}
+}
+
+func TestEventsListJSONLStreamsDerivedEvents(t *testing.T) {
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	events := []contract.Event{
+		{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: start, End: start.Add(time.Hour), URL: "https://zoom.us/j/1"},
+		{ID: "b", CalendarID: "work", CalendarName: "Work", Title: "Therapy", Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour), Sensitivity: contract.SensitivityPrivate},
+	}
+	fb := backend.NewMockBackend(backend.MockFixture{Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}}, Events: events})
+	out := runWithBackend(t, fb, "events", "list", "--from", "2026-03-03", "--to", "2026-03-04", "--jsonl", "--hide-private")
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSONL records, got %q", out)
+	}
+	var first, second contract.Event
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if first.Alias == "" || first.ETag == "" || !first.IsVideoCall {
+		t.Fatalf("streamed event missing derived fields: %+v", first)
+	}
+	if second.Title != "Private event" {
+		t.Fatalf("streamed private event not masked: %+v", second)
+	}
+
+	out = runWithBackend(t, fb, "events", "list", "--from", "2026-03-03", "--to", "2026-03-04", "--jsonl", "--only-video-calls")
+	if n := strings.Count(strings.TrimSpace(string(out)), "\n"); n != 0 || !strings.Contains(string(out), `"id":"a"`) {
+		t.Fatalf("expected only the video call, got %q", out)
+	}
+
+	plain := &scopeCaptureBackend{events: events}
+	if out := runWithBackend(t, plain, "events", "search", "Standup", "--jsonl"); strings.Count(string(out), "\n") != 2 {
+		t.Fatalf("non-streaming backend should still emit every event, got %q", out)
+	}
+}
+
+func TestEventsAddNoConflict(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "sync", CalendarID: "work", CalendarName: "Work", Title: "Sync", Start: start, End: start.Add(time.Hour)},
+			{ID: "lunch", CalendarID: "work", CalendarName: "Work", Title: "Lunch", Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour), Availability: contract.AvailabilityFree},
+		},
+	})
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+	run := func(args ...string) (int, string) {
+		cmd := NewRootCommand()
+		var stderr bytes.Buffer
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(&stderr)
+		cmd.SetArgs(append(args, "--tz", "UTC", "--json"))
+		return ExitCode(cmd.Execute()), stderr.String()
+	}
+
+	code, stderr := run("events", "add", "--calendar", "Work", "--title", "Review", "--start", "2026-03-03T10:30:00Z", "--duration", "1h", "--no-conflict")
+	var env struct {
+		Error contract.ErrorBody `json:"error"`
+		Meta  struct {
+			Conflicts []contract.Event `json:"conflicts"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal([]byte(stderr), &env); err != nil {
+		t.Fatalf("decode error envelope: %v (%q)", err, stderr)
+	}
+	if code != 5 || env.Error.Code != contract.ErrConflict || len(env.Meta.Conflicts) != 1 || env.Meta.Conflicts[0].ID != "sync" {
+		t.Fatalf("expected conflict with sync, got exit %d %+v", code, env)
+	}
+	if env.Error.Details == nil || strings.Join(env.Error.Details.EventIDs, ",") != "sync" {
+		t.Fatalf("expected details.event_ids [sync], got %+v", env.Error.Details)
+	}
+	if code, _ := run("events", "add", "--calendar", "Work", "--title", "Review", "--start", "2026-03-03T10:30:00Z", "--duration", "1h", "--no-conflict", "--force"); code != 0 {
+		t.Fatalf("--force should override --no-conflict, got exit %d", code)
+	}
+	if code, _ := run("events", "add", "--calendar", "Work", "--title", "Walk", "--start", "2026-03-03T12:00:00Z", "--duration", "1h", "--no-conflict"); code != 0 {
+		t.Fatalf("free events should not conflict, got exit %d", code)
+	}
+	if code, _ := run("events", "copy", "sync", "--to", "2026-03-03T12:30:00Z", "--no-conflict"); code != 5 {
+		t.Fatalf("copy onto the new walk should conflict, got exit %d", code)
+	}
+	if code, _ := run("events", "quick-add", "2026-03-03 10:15 Catch-up @Work 15m", "--no-conflict"); code != 5 {
+		t.Fatalf("quick-add onto sync should conflict, got exit %d", code)
+	}
+	if code, _ := run("events", "quick-add", "2026-03-03 16:00 Catch-up @Work 15m", "--no-conflict"); code != 0 {
+		t.Fatalf("quick-add into a free hour should succeed, got exit %d", code)
+	}
+}
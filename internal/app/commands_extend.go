@@ -0,0 +1,80 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+func newEventsResizeCmd(opts *globalOptions, name string, sign time.Duration) *cobra.Command {
+	var by, scopeS string
+	var ifMatch int
+	var dryRun bool
+	verb := "Extend"
+	if sign < 0 {
+		verb = "Shorten"
+	}
+	cmd := &cobra.Command{
+		Use:   name + " <event-id>",
+		Short: verb + " an event by moving only its end time",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			p, be, ro, err := buildContext(c, opts, "events."+name)
+			if err != nil {
+				return err
+			}
+			delta, err := timeparse.ParseDuration(by)
+			if err == nil && delta <= 0 {
+				err = errors.New("--by must be positive")
+			}
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			scope, err := parseRecurrenceScope(scopeS)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --scope auto|this|future|series", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
+			current, err := getEventByIDWithTimeout(ctx, be, id)
+			if err != nil {
+				return failWithHint(p, contract.ErrNotFound, withEventIDs(err, id), "Check ID with `acal events list --fields id,title,start`", 4)
+			}
+			if ifMatch > 0 && current.Sequence != ifMatch {
+				err = fmt.Errorf("sequence mismatch: current=%d expected=%d", current.Sequence, ifMatch)
+				return failWithHint(p, contract.ErrConcurrency, err, "Re-fetch event and retry", 7)
+			}
+			end := current.End.Add(sign * delta)
+			if !end.After(current.Start) {
+				err = fmt.Errorf("cannot shorten by %s: event is only %s long", formatMinutes(int64(delta.Minutes())), formatMinutes(int64(current.End.Sub(current.Start).Minutes())))
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use a smaller --by or `acal events delete`", 2)
+			}
+			patch := backend.EventUpdateInput{End: &end, Scope: scope}
+			meta := map[string]any{"previous_end": current.End, "minutes": int64(end.Sub(current.Start).Minutes())}
+			if dryRun {
+				return successDryRun(ctx, p, ro, []dryRunPreview{previewUpdate(current, patch)}, meta, nil)
+			}
+			item, err := updateEventWithTimeout(ctx, be, id, patch)
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, verb+" failed", 1)
+			}
+			_ = appendHistory(historyEntry{Type: "update", EventID: id, Prev: current, Next: item})
+			meta["count"] = 1
+			return successWithMeta(ctx, p, ro, item, meta, nil)
+		},
+	}
+	cmd.Flags().StringVar(&by, "by", "15m", "How much to move the end time (e.g. 15m, 1h)")
+	cmd.Flags().StringVar(&scopeS, "scope", "auto", "Recurrence scope: auto|this|future|series")
+	cmd.Flags().IntVar(&ifMatch, "if-match-seq", 0, "Require matching sequence number")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview without writing")
+	return cmd
+}
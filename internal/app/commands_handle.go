@@ -0,0 +1,152 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const handleScheme = "x-acal"
+
+type handleAction struct {
+	Path   []string
+	Arg    string
+	Params []string
+	Write  bool
+}
+
+var handleActions = map[string]handleAction{
+	"add": {Path: []string{"events", "add"}, Params: []string{
+		"calendar", "title", "start", "end", "duration", "all-day", "location", "notes", "url",
+		"availability", "status", "reminder", "repeat", "sensitivity", "no-conflict", "dry-run",
+	}, Write: true},
+	"quick-add": {Path: []string{"quick-add"}, Arg: "text", Params: []string{"calendar", "duration", "all-day", "no-conflict", "dry-run"}, Write: true},
+	"query":     {Path: []string{"events", "query"}, Params: []string{"from", "to", "calendar", "account", "where", "sort", "order", "limit"}},
+	"search":    {Path: []string{"events", "search"}, Arg: "q", Params: []string{"from", "to", "calendar", "account", "field", "limit"}},
+	"show":      {Path: []string{"events", "show"}, Arg: "id", Params: []string{"context"}},
+	"reveal":    {Path: []string{"events", "reveal"}, Arg: "id", Params: []string{"app", "dry-run"}},
+}
+
+func handleArgs(raw string, allowWrite bool) ([]string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	if !strings.EqualFold(u.Scheme, handleScheme) {
+		return nil, fmt.Errorf("unsupported URL scheme %q (want %s://)", u.Scheme, handleScheme)
+	}
+	name := strings.ToLower(strings.Trim(u.Host+u.Path, "/"))
+	if u.Opaque != "" {
+		name = strings.ToLower(strings.Trim(u.Opaque, "/"))
+	}
+	action, ok := handleActions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown action %q (use %s)", name, strings.Join(handleActionNames(), "|"))
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	args := append([]string{}, action.Path...)
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var positional []string
+	for _, k := range keys {
+		flag := strings.ReplaceAll(strings.ToLower(k), "_", "-")
+		switch {
+		case action.Arg != "" && flag == action.Arg:
+			positional = append(positional, query[k]...)
+		case containsString(action.Params, flag):
+			for _, v := range query[k] {
+				args = append(args, "--"+flag+"="+v)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported parameter %q for %s", k, name)
+		}
+	}
+	if action.Write && !allowWrite {
+		args = append(args, "--dry-run")
+	}
+	if action.Arg != "" {
+		if len(positional) != 1 || strings.TrimSpace(positional[0]) == "" {
+			return nil, fmt.Errorf("%s needs exactly one %q parameter", name, action.Arg)
+		}
+		args = append(args, "--", positional[0])
+	}
+	return args, nil
+}
+
+func handleActionNames() []string {
+	names := make([]string, 0, len(handleActions))
+	for name := range handleActions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func fillBoolParams(args []string, flags *pflag.FlagSet) []string {
+	for i, a := range args {
+		name, value, ok := strings.Cut(strings.TrimPrefix(a, "--"), "=")
+		if !ok || value != "" || !strings.HasPrefix(a, "--") {
+			continue
+		}
+		if f := flags.Lookup(name); f != nil && f.Value.Type() == "bool" {
+			args[i] = "--" + name
+		}
+	}
+	return args
+}
+
+func globalFlagArgs(flags *pflag.FlagSet) []string {
+	var out []string
+	flags.Visit(func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			for _, v := range sv.GetSlice() {
+				out = append(out, "--"+f.Name+"="+v)
+			}
+			return
+		}
+		out = append(out, "--"+f.Name+"="+f.Value.String())
+	})
+	return out
+}
+
+func newHandleCmd(opts *globalOptions) *cobra.Command {
+	var allowWrite bool
+	cmd := &cobra.Command{
+		Use:   "handle <x-acal://action?params>",
+		Short: "Run the command an x-acal:// URL names",
+		Long:  "Run the command an x-acal:// URL names, for URL handlers and bookmarklets.\n\nActions: " + strings.Join(handleActionNames(), ", ") + ". Query parameters become that command's flags (x-acal://add?calendar=Work&title=Lunch&start=tomorrow%2012:00); global flags are taken from this invocation only. add and quick-add only preview unless --allow-write is set.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := handleArgs(args[0], allowWrite)
+			if err != nil {
+				p, _, _, berr := buildContext(cmd, opts, "handle")
+				if berr != nil {
+					return berr
+				}
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use x-acal://<action>?<param>=<value>; see `acal handle --help`", 2)
+			}
+			sub, _ := newRootCommand()
+			sub.SetOut(cmd.OutOrStdout())
+			sub.SetErr(cmd.ErrOrStderr())
+			sub.SetIn(cmd.InOrStdin())
+			if found, _, ferr := sub.Find(target); ferr == nil {
+				target = fillBoolParams(target, found.Flags())
+			}
+			sub.SetArgs(append(globalFlagArgs(cmd.Root().PersistentFlags()), target...))
+			return sub.Execute()
+		},
+	}
+	cmd.Flags().BoolVar(&allowWrite, "allow-write", false, "Let add and quick-add URLs write instead of previewing")
+	return cmd
+}
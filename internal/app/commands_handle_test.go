@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/pflag"
+)
+
+func TestHandleArgs(t *testing.T) {
+	got, err := handleArgs("x-acal://add?title=Lunch%20%26%20walk&calendar=Work&start=tomorrow+12:00&all_day", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"events", "add", "--all-day=", "--calendar=Work", "--start=tomorrow 12:00", "--title=Lunch & walk"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected args:\n got %q\nwant %q", got, want)
+	}
+	got, err = handleArgs("x-acal:quick-add?text=-fri%2010:00%20Standup", false)
+	if err != nil || !reflect.DeepEqual(got, []string{"quick-add", "--dry-run", "--", "-fri 10:00 Standup"}) {
+		t.Fatalf("unexpected quick-add args: %q %v", got, err)
+	}
+	for _, bad := range []string{
+		"https://example.com/add?title=x",
+		"x-acal://delete?id=abc",
+		"x-acal://add?input=/etc/passwd",
+		"x-acal://add?backend=mock",
+		"x-acal://show",
+	} {
+		if _, err := handleArgs(bad, true); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestHandleRunsURLCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fb := backend.NewMockBackend(backend.MockFixture{Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}}})
+	var env struct {
+		Command string         `json:"command"`
+		Data    contract.Event `json:"data"`
+	}
+	url := "x-acal://add?calendar=Work&title=Lunch&start=2026-03-03T12:00:00Z&duration=45m"
+	runWithBackend(t, fb, "handle", url, "--tz", "UTC", "--json")
+	day := backend.EventFilter{From: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)}
+	if items, _ := fb.ListEvents(context.Background(), day); len(items) != 0 {
+		t.Fatalf("expected a URL write without --allow-write to only preview, got %+v", items)
+	}
+	out := runWithBackend(t, fb, "handle", url, "--allow-write", "--tz", "UTC", "--json")
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("%v: %s", err, out)
+	}
+	if env.Command != "events.add" || env.Data.Title != "Lunch" || env.Data.End.Sub(env.Data.Start) != 45*time.Minute {
+		t.Fatalf("expected the event to be added, got %+v", env)
+	}
+	if items, _ := fb.ListEvents(context.Background(), day); len(items) != 1 {
+		t.Fatalf("expected --allow-write to create the event, got %+v", items)
+	}
+}
+
+func TestGlobalFlagArgsRepeatsSliceValues(t *testing.T) {
+	fs := pflag.NewFlagSet("acal", pflag.ContinueOnError)
+	fs.StringSlice("calendar", nil, "")
+	fs.String("tz", "", "")
+	fs.Bool("json", false, "")
+	if err := fs.Parse([]string{"--calendar", "Work,Home", "--tz", "UTC", "--json"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"--calendar=Work", "--calendar=Home", "--json=true", "--tz=UTC"}
+	if got := globalFlagArgs(fs); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected args:\n got %q\nwant %q", got, want)
+	}
+}
@@ -0,0 +1,267 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+	"github.com/spf13/cobra"
+)
+
+type holiday struct {
+	Date   string `json:"date"`
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+func newHolidaysCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{Use: "holidays", Short: "Public holidays from a holidays calendar or ICS file"}
+	cmd.AddCommand(newHolidaysListCmd(opts))
+	return cmd
+}
+
+func newHolidaysListCmd(opts *globalOptions) *cobra.Command {
+	var fromS, toS, calendar, file string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List public holidays in a range",
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "holidays.list")
+			if err != nil {
+				return err
+			}
+			if c.Flags().Changed("calendar") {
+				ro.HolidaysCalendar = calendar
+			}
+			if c.Flags().Changed("file") {
+				ro.HolidaysFile = file
+			}
+			f, err := buildEventFilterWithTZ(fromS, toS, nil, 0, ro.TZ)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			rows, err := loadHolidays(ctx, be, ro, f.From, f.To)
+			if err != nil {
+				return failHolidays(p, err)
+			}
+			return successWithMeta(ctx, p, ro, rows, map[string]any{"count": len(rows), "from": f.From.Format("2006-01-02"), "to": rangeLastDay(f.To).Format("2006-01-02")}, nil)
+		},
+	}
+	cmd.Flags().StringVar(&fromS, "from", "today", "Range start")
+	cmd.Flags().StringVar(&toS, "to", "+365d", "Range end")
+	cmd.Flags().StringVar(&calendar, "calendar", "", "Holidays calendar ID or name (default: first calendar named *Holiday*)")
+	cmd.Flags().StringVar(&file, "file", "", "Read holidays from an ICS file instead of a calendar")
+	return cmd
+}
+
+var (
+	errNoHolidayCalendar = errors.New("no holidays calendar found")
+	errHolidaysFile      = errors.New("unable to read holidays file")
+)
+
+func failHolidays(p output.Printer, err error) error {
+	switch {
+	case errors.Is(err, errNoHolidayCalendar):
+		return failWithHint(p, contract.ErrNotFound, err, "Enable the Holidays calendar in Calendar.app, or set holidays_calendar / holidays_file in config", 4)
+	case errors.Is(err, errHolidaysFile):
+		return failWithHint(p, contract.ErrInvalidUsage, err, "Check the holidays ICS file path", 2)
+	default:
+		return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+	}
+}
+
+func loadHolidays(ctx context.Context, be backend.Backend, ro *globalOptions, from, to time.Time) ([]holiday, error) {
+	loc := resolveLocation(ro.TZ)
+	var items []contract.Event
+	source := strings.TrimSpace(ro.HolidaysFile)
+	if source != "" {
+		raw, err := readICSInput(source)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %v", errHolidaysFile, source, err)
+		}
+		events, _ := parseICSEvents(raw, "", loc)
+		for _, ev := range events {
+			starts := []time.Time{ev.Start}
+			if ev.RRule != "" {
+				expanded, ok := yearlyRuleStarts(ev.RRule, ev.Start, to, loc)
+				if ok {
+					starts = expanded
+				} else {
+					backend.RecordWarning(ctx, contract.WarnRecurrenceNotExpanded, fmt.Sprintf("%s: RRULE %s not expanded; only its first date is listed", ev.Title, ev.RRule))
+				}
+			}
+			for _, st := range starts {
+				items = append(items, contract.Event{Title: ev.Title, Start: st, End: st.Add(ev.End.Sub(ev.Start)), AllDay: ev.AllDay})
+			}
+		}
+	} else {
+		cals, err := listCalendarsWithTimeout(ctx, be)
+		if err != nil {
+			return nil, err
+		}
+		cal, err := resolveHolidayCalendar(cals, ro.HolidaysCalendar)
+		if err != nil {
+			return nil, err
+		}
+		source = cal.Name
+		items, err = listEventsWithTimeout(ctx, be, backend.EventFilter{From: from, To: to, Calendars: []string{cal.ID}, Overlap: true})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return expandHolidays(items, source, from, to, loc), nil
+}
+
+// yearlyRuleStarts expands FREQ=YEARLY rules, with an optional BYMONTH and an
+// ordinal BYDAY such as 4TH or -1MO, into starts before to.
+func yearlyRuleStarts(rrule string, start, to time.Time, loc *time.Location) ([]time.Time, bool) {
+	interval, count, month := 1, 0, start.Month()
+	var until time.Time
+	var byDay string
+	for _, part := range strings.Split(rrule, ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		var err error
+		switch key {
+		case "FREQ":
+			if value != "YEARLY" {
+				return nil, false
+			}
+		case "INTERVAL":
+			if interval, err = strconv.Atoi(value); err != nil || interval <= 0 {
+				return nil, false
+			}
+		case "COUNT":
+			if count, err = strconv.Atoi(value); err != nil || count <= 0 {
+				return nil, false
+			}
+		case "UNTIL":
+			var ok bool
+			if len(value) == 8 {
+				value = ";VALUE=DATE:" + value
+			} else {
+				value = ":" + value
+			}
+			if until, _, ok = parseICSDate("UNTIL"+value, loc); !ok {
+				return nil, false
+			}
+		case "BYMONTH":
+			m, err := strconv.Atoi(value)
+			if err != nil || m < 1 || m > 12 {
+				return nil, false
+			}
+			month = time.Month(m)
+		case "BYDAY":
+			byDay = value
+		case "WKST", "":
+		default:
+			return nil, false
+		}
+	}
+	var n int
+	var wd time.Weekday
+	if byDay != "" {
+		i := strings.IndexFunc(byDay, func(r rune) bool { return r >= 'A' && r <= 'Z' })
+		var err error
+		if i <= 0 || len(byDay)-i != 2 {
+			return nil, false
+		}
+		if n, err = strconv.Atoi(byDay[:i]); err != nil || n == 0 || n < -5 || n > 5 {
+			return nil, false
+		}
+		var ok bool
+		if wd, ok = icsWeekdays[byDay[i:]]; !ok {
+			return nil, false
+		}
+	}
+	var out []time.Time
+	for year, seen := start.Year(), 0; year <= to.Year() && (count == 0 || seen < count); year += interval {
+		d := time.Date(year, month, start.Day(), start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+		if byDay != "" {
+			d = nthWeekday(year, month, wd, n, start)
+		}
+		if d.Month() != month || d.Before(start) {
+			continue
+		}
+		if !until.IsZero() && d.After(until) {
+			break
+		}
+		seen++
+		if d.Before(to) {
+			out = append(out, d)
+		}
+	}
+	return out, true
+}
+
+var icsWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+func nthWeekday(year int, month time.Month, wd time.Weekday, n int, clock time.Time) time.Time {
+	day := func(d int) time.Time {
+		return time.Date(year, month, d, clock.Hour(), clock.Minute(), clock.Second(), 0, clock.Location())
+	}
+	if n > 0 {
+		first := day(1)
+		return first.AddDate(0, 0, (int(wd)-int(first.Weekday())+7)%7+(n-1)*7)
+	}
+	last := day(1).AddDate(0, 1, -1)
+	return last.AddDate(0, 0, -((int(last.Weekday())-int(wd)+7)%7)+(n+1)*7)
+}
+
+func resolveHolidayCalendar(cals []contract.Calendar, name string) (contract.Calendar, error) {
+	name = strings.TrimSpace(name)
+	for _, c := range cals {
+		if name != "" && (c.ID == name || strings.EqualFold(c.Name, name)) {
+			return c, nil
+		}
+		if name == "" && strings.Contains(strings.ToLower(c.Name), "holiday") {
+			return c, nil
+		}
+	}
+	if name != "" {
+		return contract.Calendar{}, fmt.Errorf("%w: %s", errNoHolidayCalendar, name)
+	}
+	return contract.Calendar{}, errNoHolidayCalendar
+}
+
+func expandHolidays(items []contract.Event, source string, from, to time.Time, loc *time.Location) []holiday {
+	start, _ := dayBounds(from.In(loc))
+	end, _ := dayBounds(rangeLastDay(to).In(loc))
+	seen := map[string]bool{}
+	rows := []holiday{}
+	for _, e := range items {
+		first, last := eventDaySpan(e, loc)
+		for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+			if d.Before(start) || d.After(end) {
+				continue
+			}
+			key := d.Format("2006-01-02")
+			if seen[key+"\x00"+e.Title] {
+				continue
+			}
+			seen[key+"\x00"+e.Title] = true
+			rows = append(rows, holiday{Date: key, Name: e.Title, Source: source})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Date < rows[j].Date })
+	return rows
+}
+
+func holidayDates(rows []holiday) map[string]bool {
+	out := make(map[string]bool, len(rows))
+	for _, h := range rows {
+		out[h.Date] = true
+	}
+	return out
+}
@@ -0,0 +1,157 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestResolveHolidayCalendar(t *testing.T) {
+	cals := []contract.Calendar{{ID: "work", Name: "Work"}, {ID: "hol", Name: "US Holidays"}}
+	if c, err := resolveHolidayCalendar(cals, ""); err != nil || c.ID != "hol" {
+		t.Fatalf("expected auto-detected holidays calendar, got %+v %v", c, err)
+	}
+	if c, err := resolveHolidayCalendar(cals, "work"); err != nil || c.ID != "work" {
+		t.Fatalf("expected explicit calendar, got %+v %v", c, err)
+	}
+	if _, err := resolveHolidayCalendar(cals[:1], ""); !errors.Is(err, errNoHolidayCalendar) {
+		t.Fatalf("expected errNoHolidayCalendar, got %v", err)
+	}
+}
+
+func TestExpandHolidaysSplitsMultiDayAndClipsRange(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 12, d, 0, 0, 0, 0, time.UTC) }
+	items := []contract.Event{
+		{Title: "Christmas break", Start: day(24), End: day(27), AllDay: true},
+		{Title: "New Year's Eve", Start: day(31), End: day(31).AddDate(0, 0, 1), AllDay: true},
+	}
+	rows := expandHolidays(items, "Holidays", day(25), day(31).Add(12*time.Hour), time.UTC)
+	want := []string{"2026-12-25", "2026-12-26", "2026-12-31"}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %+v", len(want), rows)
+	}
+	for i, d := range want {
+		if rows[i].Date != d || rows[i].Source != "Holidays" {
+			t.Fatalf("row %d: got %+v, want date %s", i, rows[i], d)
+		}
+	}
+}
+
+func TestHolidaysListFromCalendarAndFile(t *testing.T) {
+	fixture := filepath.Join("testdata", "mock", "events.json")
+	run := func(args ...string) []holiday {
+		t.Helper()
+		root := NewRootCommand()
+		var stdout bytes.Buffer
+		root.SetOut(&stdout)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"holidays", "list", "--backend", "mock", "--mock-file", fixture, "--tz", "UTC", "--json"}, args...))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("execute failed: %v", err)
+		}
+		var env struct {
+			Data []holiday `json:"data"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+		return env.Data
+	}
+
+	rows := run("--from", "2026-03-01", "--to", "2026-03-31")
+	if len(rows) != 1 || rows[0].Date != "2026-03-03" || rows[0].Name != "Bank holiday" || rows[0].Source != "Holidays" {
+		t.Fatalf("unexpected calendar holidays: %+v", rows)
+	}
+
+	ics := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:h1\r\nSUMMARY:Founders Day\r\nDTSTART;VALUE=DATE:20260310\r\nDTEND;VALUE=DATE:20260311\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	path := filepath.Join(t.TempDir(), "holidays.ics")
+	if err := os.WriteFile(path, []byte(ics), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rows = run("--from", "2026-03-01", "--to", "2026-03-31", "--file", path)
+	if len(rows) != 1 || rows[0].Date != "2026-03-10" || rows[0].Name != "Founders Day" || rows[0].Source != path {
+		t.Fatalf("unexpected file holidays: %+v", rows)
+	}
+}
+
+func TestSlotsSkipHolidays(t *testing.T) {
+	fixture := filepath.Join("testdata", "mock", "events.json")
+	root := NewRootCommand()
+	var stdout bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"slots", "--backend", "mock", "--mock-file", fixture, "--tz", "UTC", "--from", "2026-03-03", "--to", "2026-03-05", "--between", "09:00-10:00", "--duration", "1h", "--step", "1h", "--skip-holidays", "--json"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	var env struct {
+		Data []slotRow      `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(env.Data) != 2 || env.Data[0].Start.Format("2006-01-02") != "2026-03-04" || env.Data[1].Start.Format("2006-01-02") != "2026-03-05" {
+		t.Fatalf("expected slots on 2026-03-04 and the --to day, got %+v", env.Data)
+	}
+	if env.Meta["holidays_skipped"] != float64(1) {
+		t.Fatalf("expected holidays_skipped=1, got %v", env.Meta["holidays_skipped"])
+	}
+}
+
+func TestBuildOOOBlocksSkipsHolidays(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	blocks, err := buildOOOBlocks(from, to, nil, map[string]bool{"2026-03-03": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 2 || !blocks[0][1].Equal(time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)) || !blocks[1][0].Equal(to) {
+		t.Fatalf("expected holiday to split the block, got %v", blocks)
+	}
+}
+
+func TestHolidaysFileExpandsYearlyRules(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\nSUMMARY:Independence Day\r\nDTSTART;VALUE=DATE:20200704\r\nDTEND;VALUE=DATE:20200705\r\nRRULE:FREQ=YEARLY\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nSUMMARY:Thanksgiving\r\nDTSTART;VALUE=DATE:20201126\r\nDTEND;VALUE=DATE:20201127\r\nRRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=4TH\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nSUMMARY:Memorial Day\r\nDTSTART;VALUE=DATE:20200525\r\nDTEND;VALUE=DATE:20200526\r\nRRULE:FREQ=YEARLY;BYMONTH=5;BYDAY=-1MO;UNTIL=20250101\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nSUMMARY:Payday\r\nDTSTART;VALUE=DATE:20260101\r\nDTEND;VALUE=DATE:20260102\r\nRRULE:FREQ=MONTHLY\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	path := filepath.Join(t.TempDir(), "holidays.ics")
+	if err := os.WriteFile(path, []byte(ics), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	root := NewRootCommand()
+	var stdout bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"holidays", "list", "--backend", "mock", "--mock-file", filepath.Join("testdata", "mock", "events.json"), "--tz", "UTC", "--from", "2026-01-01", "--to", "2026-12-31", "--file", path, "--json"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	var env struct {
+		Data         []holiday              `json:"data"`
+		WarningCodes []contract.WarningCode `json:"warning_codes"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, h := range env.Data {
+		got[h.Name] = h.Date
+	}
+	if len(got) != 3 || got["Payday"] != "2026-01-01" || got["Independence Day"] != "2026-07-04" || got["Thanksgiving"] != "2026-11-26" {
+		t.Fatalf("unexpected holidays: %+v", env.Data)
+	}
+	if len(env.WarningCodes) != 1 || env.WarningCodes[0] != contract.WarnRecurrenceNotExpanded {
+		t.Fatalf("expected one warning for the monthly rule, got %v", env.WarningCodes)
+	}
+}
@@ -16,16 +16,20 @@ import (
 
 func newEventsExportCmd(opts *globalOptions) *cobra.Command {
 	var calendars []string
-	var fromS, toS, outPath string
+	var fromS, toS, outPath, format string
 	var limit int
 	cmd := &cobra.Command{
 		Use:   "export",
-		Short: "Export events to ICS",
+		Short: "Export events to ICS, org-mode, or TaskPaper",
 		RunE: func(c *cobra.Command, _ []string) error {
 			p, be, ro, err := buildContext(c, opts, "events.export")
 			if err != nil {
 				return err
 			}
+			format = strings.ToLower(strings.TrimSpace(format))
+			if !containsString(exportFormats, format) {
+				return failWithHint(p, contract.ErrInvalidUsage, invalidField("format", format, fmt.Errorf("invalid --format: %s", format)), "Use --format ics|org|taskpaper", 2)
+			}
 			f, err := buildEventFilterWithTZ(fromS, toS, calendars, limit, ro.TZ)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
@@ -36,18 +40,29 @@ func newEventsExportCmd(opts *globalOptions) *cobra.Command {
 			if err != nil {
 				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
 			}
-			ics := buildICS(items)
+			var doc string
+			switch format {
+			case "org":
+				doc = buildOrg(items, resolveLocation(ro.TZ))
+			case "taskpaper":
+				doc = buildTaskPaper(items, resolveLocation(ro.TZ))
+			default:
+				doc = buildICS(items)
+			}
 			meta := map[string]any{"count": len(items)}
+			if format != "ics" {
+				meta["format"] = format
+			}
 			if strings.TrimSpace(outPath) != "" {
-				if err := os.WriteFile(outPath, []byte(ics), 0o644); err != nil {
+				if err := os.WriteFile(outPath, []byte(doc), 0o644); err != nil {
 					return failWithHint(p, contract.ErrGeneric, err, "Check destination path permissions", 1)
 				}
 				return successWithMeta(ctx, p, ro, map[string]any{"path": outPath, "events": len(items)}, meta, nil)
 			}
 			if m := p.EffectiveSuccessMode(); m == output.ModeJSON || m == output.ModeJSONL {
-				return successWithMeta(ctx, p, ro, map[string]any{"ics": ics, "events": len(items)}, meta, nil)
+				return successWithMeta(ctx, p, ro, map[string]any{format: doc, "events": len(items)}, meta, nil)
 			}
-			_, _ = fmt.Fprint(c.OutOrStdout(), ics)
+			_, _ = fmt.Fprint(c.OutOrStdout(), doc)
 			return nil
 		},
 	}
@@ -56,6 +71,7 @@ func newEventsExportCmd(opts *globalOptions) *cobra.Command {
 	cmd.Flags().StringVar(&toS, "to", "+30d", "Range end")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit events exported")
 	cmd.Flags().StringVar(&outPath, "out", "", "Output file path (default stdout)")
+	cmd.Flags().StringVar(&format, "format", "ics", "Export format: ics|org|taskpaper")
 	return cmd
 }
 
@@ -91,7 +107,11 @@ func newEventsImportCmd(opts *globalOptions) *cobra.Command {
 				return failWithHint(p, contract.ErrInvalidUsage, errors.New("strict import rejected warnings"), "Fix ICS warnings or omit --strict", 2)
 			}
 			if dryRun {
-				return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items), "dry_run": true, "warnings": len(warnings)}, warnings)
+				previews := make([]dryRunPreview, 0, len(items))
+				for _, in := range items {
+					previews = append(previews, previewAdd(in))
+				}
+				return successDryRun(ctx, p, ro, previews, map[string]any{"warnings": len(warnings)}, warningsWithCode(contract.WarnICSEventSkipped, warnings))
 			}
 			created := make([]contract.Event, 0, len(items))
 			for _, in := range items {
@@ -103,7 +123,7 @@ func newEventsImportCmd(opts *globalOptions) *cobra.Command {
 					created = append(created, *ev)
 				}
 			}
-			return successWithMeta(ctx, p, ro, created, map[string]any{"count": len(created), "warnings": len(warnings)}, warnings)
+			return successWithMeta(ctx, p, ro, created, map[string]any{"count": len(created), "warnings": len(warnings)}, warningsWithCode(contract.WarnICSEventSkipped, warnings))
 		},
 	}
 	cmd.Flags().StringVar(&filePath, "file", "", "ICS file path or - for stdin")
@@ -147,17 +167,67 @@ func buildICS(items []contract.Event) string {
 		if strings.TrimSpace(e.URL) != "" {
 			b.WriteString("URL:" + escapeICSText(e.URL) + "\r\n")
 		}
+		if e.Status != "" {
+			b.WriteString("STATUS:" + strings.ToUpper(e.Status) + "\r\n")
+		}
+		switch e.Availability {
+		case contract.AvailabilityFree:
+			b.WriteString("TRANSP:TRANSPARENT\r\n")
+		case contract.AvailabilityBusy:
+			b.WriteString("TRANSP:OPAQUE\r\n")
+		}
+		if e.Sensitivity != "" {
+			b.WriteString("CLASS:" + strings.ToUpper(e.Sensitivity) + "\r\n")
+		}
 		b.WriteString("END:VEVENT\r\n")
 	}
 	b.WriteString("END:VCALENDAR\r\n")
 	return b.String()
 }
 
+func buildFreebusyICS(blocks []busyBlock, from, to, stamp time.Time) string {
+	const layout = "20060102T150405Z"
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("PRODID:-//acal//EN\r\n")
+	b.WriteString("METHOD:PUBLISH\r\n")
+	b.WriteString("BEGIN:VFREEBUSY\r\n")
+	b.WriteString(fmt.Sprintf("UID:acal-freebusy-%d-%d\r\n", from.Unix(), to.Unix()))
+	b.WriteString("DTSTAMP:" + stamp.UTC().Format(layout) + "\r\n")
+	b.WriteString("DTSTART:" + from.UTC().Format(layout) + "\r\n")
+	b.WriteString("DTEND:" + to.UTC().Format(layout) + "\r\n")
+	for _, bl := range blocks {
+		b.WriteString("FREEBUSY;FBTYPE=BUSY:" + bl.Start.UTC().Format(layout) + "/" + bl.End.UTC().Format(layout) + "\r\n")
+	}
+	b.WriteString("END:VFREEBUSY\r\n")
+	b.WriteString("END:VCALENDAR\r\n")
+	return b.String()
+}
+
 func escapeICSText(v string) string {
 	replacer := strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\n", "\\n", "\r", "")
 	return replacer.Replace(v)
 }
 
+func unescapeICSText(v string) string {
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		if v[i] == '\\' && i+1 < len(v) {
+			i++
+			switch v[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(v[i])
+			}
+			continue
+		}
+		b.WriteByte(v[i])
+	}
+	return b.String()
+}
+
 func readICSInput(path string) (string, error) {
 	if strings.TrimSpace(path) == "-" {
 		b, err := io.ReadAll(os.Stdin)
@@ -173,17 +243,31 @@ func readICSInput(path string) (string, error) {
 	return string(b), nil
 }
 
+type icsEvent struct {
+	backend.EventCreateInput
+	RRule string
+}
+
 func parseICS(raw, calendar string, loc *time.Location) ([]backend.EventCreateInput, []string) {
+	events, warnings := parseICSEvents(raw, calendar, loc)
+	items := make([]backend.EventCreateInput, 0, len(events))
+	for _, e := range events {
+		items = append(items, e.EventCreateInput)
+	}
+	return items, warnings
+}
+
+func parseICSEvents(raw, calendar string, loc *time.Location) ([]icsEvent, []string) {
 	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
 	inEvent := false
 	kv := map[string]string{}
-	items := make([]backend.EventCreateInput, 0)
+	items := make([]icsEvent, 0)
 	warnings := make([]string, 0)
 	flush := func() {
 		if !inEvent {
 			return
 		}
-		title := strings.TrimSpace(kv["SUMMARY"])
+		title := strings.TrimSpace(unescapeICSText(kv["SUMMARY"]))
 		if title == "" {
 			title = "Untitled"
 		}
@@ -193,16 +277,18 @@ func parseICS(raw, calendar string, loc *time.Location) ([]backend.EventCreateIn
 			warnings = append(warnings, "skipped VEVENT with invalid DTSTART/DTEND")
 			return
 		}
-		items = append(items, backend.EventCreateInput{
+		status, _ := parseEventStatus(kv["STATUS"])
+		items = append(items, icsEvent{EventCreateInput: backend.EventCreateInput{
 			Calendar: calendar,
 			Title:    title,
 			Start:    start,
 			End:      end,
-			Location: strings.TrimSpace(kv["LOCATION"]),
-			Notes:    strings.TrimSpace(kv["DESCRIPTION"]),
-			URL:      strings.TrimSpace(kv["URL"]),
+			Location: strings.TrimSpace(unescapeICSText(kv["LOCATION"])),
+			Notes:    strings.TrimSpace(unescapeICSText(kv["DESCRIPTION"])),
+			URL:      strings.TrimSpace(unescapeICSText(kv["URL"])),
 			AllDay:   allDayStart || allDayEnd,
-		})
+			Status:   status,
+		}, RRule: strings.ToUpper(kv["RRULE"])})
 	}
 
 	for _, line := range lines {
@@ -232,7 +318,7 @@ func parseICS(raw, calendar string, loc *time.Location) ([]backend.EventCreateIn
 			key = strings.SplitN(keyRaw, ";", 2)[0]
 		}
 		if key == "DTSTART" || key == "DTEND" {
-			kv[key] = keyRaw + ":" + value
+			kv[key] = strings.TrimSpace(parts[0]) + ":" + value
 			continue
 		}
 		kv[key] = value
@@ -251,6 +337,15 @@ func parseICSDate(raw string, loc *time.Location) (time.Time, bool, bool) {
 	}
 	key := strings.ToUpper(parts[0])
 	val := strings.TrimSpace(parts[1])
+	// Honor TZID when it names a zone Go knows; Windows names from Outlook
+	// fall back to loc.
+	for _, param := range strings.Split(parts[0], ";")[1:] {
+		if name, v, ok := strings.Cut(param, "="); ok && strings.EqualFold(name, "TZID") {
+			if tz, err := time.LoadLocation(strings.Trim(v, `"`)); err == nil {
+				loc = tz
+			}
+		}
+	}
 	if strings.Contains(key, "VALUE=DATE") {
 		t, err := time.ParseInLocation("20060102", val, loc)
 		if err != nil {
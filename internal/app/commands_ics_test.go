@@ -25,7 +25,7 @@ func TestBuildICSContainsCalendarAndEvent(t *testing.T) {
 func TestEventsExportWritesFile(t *testing.T) {
 	fb := &scopeCaptureBackend{events: []contract.Event{{ID: "e1", Title: "Standup", Start: time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC), End: time.Date(2026, 2, 20, 9, 30, 0, 0, time.UTC)}}}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	out := filepath.Join(t.TempDir(), "out.ics")
@@ -48,7 +48,7 @@ func TestEventsExportWritesFile(t *testing.T) {
 func TestEventsExportJSONContainsICS(t *testing.T) {
 	fb := &scopeCaptureBackend{events: []contract.Event{{ID: "e1", Title: "Standup", Start: time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC), End: time.Date(2026, 2, 20, 9, 30, 0, 0, time.UTC)}}}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -85,10 +85,19 @@ func TestParseICS(t *testing.T) {
 	}
 }
 
+func TestParseICSUnescapesText(t *testing.T) {
+	start := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
+	raw := buildICS([]contract.Event{{ID: "a", Title: "Review; Q1, draft", Location: "Room 4, Floor 2", Notes: "one\ntwo \\ three", Start: start, End: start.Add(time.Hour)}})
+	items, _ := parseICS(raw, "Work", time.UTC)
+	if len(items) != 1 || items[0].Title != "Review; Q1, draft" || items[0].Location != "Room 4, Floor 2" || items[0].Notes != "one\ntwo \\ three" {
+		t.Fatalf("text did not round-trip: %+v", items)
+	}
+}
+
 func TestEventsImportDryRun(t *testing.T) {
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	f := filepath.Join(t.TempDir(), "in.ics")
@@ -111,7 +120,7 @@ func TestEventsImportDryRun(t *testing.T) {
 func TestEventsImportMalformedICS(t *testing.T) {
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	f := filepath.Join(t.TempDir(), "bad.ics")
@@ -132,7 +141,7 @@ func TestEventsImportMalformedICS(t *testing.T) {
 func TestEventsImportStrictRejectsWarnings(t *testing.T) {
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	f := filepath.Join(t.TempDir(), "warn.ics")
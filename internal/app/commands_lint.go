@@ -0,0 +1,273 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+var lintRules = []string{"title-prefix", "meeting-video-link", "long-block-break"}
+
+type lintConfig struct {
+	TitlePrefixes []string `toml:"title_prefixes"`
+	RequireVideo  *bool    `toml:"require_video"`
+	MaxBlock      string   `toml:"max_block"`
+	MinBreak      string   `toml:"min_break"`
+}
+
+func (c lintConfig) overlay(o lintConfig) lintConfig {
+	if len(o.TitlePrefixes) > 0 {
+		c.TitlePrefixes = o.TitlePrefixes
+	}
+	if o.RequireVideo != nil {
+		c.RequireVideo = o.RequireVideo
+	}
+	if o.MaxBlock != "" {
+		c.MaxBlock = o.MaxBlock
+	}
+	if o.MinBreak != "" {
+		c.MinBreak = o.MinBreak
+	}
+	return c
+}
+
+type lintViolation struct {
+	Rule     string    `json:"rule"`
+	Title    string    `json:"title"`
+	Calendar string    `json:"calendar"`
+	Start    time.Time `json:"start"`
+	IDs      []string  `json:"ids"`
+	Message  string    `json:"message"`
+	Fix      string    `json:"fix"`
+}
+
+type lintOptions struct {
+	Prefixes     []string
+	RequireVideo bool
+	MaxBlock     time.Duration
+	MinBreak     time.Duration
+}
+
+func (o lintOptions) active() []string {
+	out := []string{}
+	if len(o.Prefixes) > 0 {
+		out = append(out, "title-prefix")
+	}
+	if o.RequireVideo {
+		out = append(out, "meeting-video-link")
+	}
+	if o.MaxBlock > 0 {
+		out = append(out, "long-block-break")
+	}
+	return out
+}
+
+func lintTitlePrefix(ev contract.Event, prefixes []string) (lintViolation, bool) {
+	title := strings.ToLower(strings.TrimSpace(ev.Title))
+	for _, pre := range prefixes {
+		if strings.HasPrefix(title, strings.ToLower(pre)) {
+			return lintViolation{}, false
+		}
+	}
+	return lintViolation{
+		Rule: "title-prefix", Title: ev.Title, Calendar: auditCalendar(ev), Start: ev.Start, IDs: []string{ev.ID},
+		Message: "title does not start with " + strings.Join(prefixes, " or "),
+		Fix:     fmt.Sprintf("acal events update %s --title %q", ev.ID, strings.TrimSpace(prefixes[0]+" "+ev.Title)),
+	}, true
+}
+
+func lintVideoLink(ev contract.Event) (lintViolation, bool) {
+	people := extractAttendees(ev.Notes)
+	if len(people) == 0 || ev.MeetingURL != "" || strings.TrimSpace(ev.Location) != "" {
+		return lintViolation{}, false
+	}
+	return lintViolation{
+		Rule: "meeting-video-link", Title: ev.Title, Calendar: auditCalendar(ev), Start: ev.Start, IDs: []string{ev.ID},
+		Message: fmt.Sprintf("meeting with %d attendee(s) has no video link or location", len(people)),
+		Fix:     fmt.Sprintf("acal events update %s --url <video-link>", ev.ID),
+	}, true
+}
+
+func lintLongBlocks(busy []contract.Event, o lintOptions) []lintViolation {
+	sort.SliceStable(busy, func(i, j int) bool { return busy[i].Start.Before(busy[j].Start) })
+	out := []lintViolation{}
+	flush := func(chain []contract.Event, end time.Time) {
+		if len(chain) == 0 || end.Sub(chain[0].Start) <= o.MaxBlock {
+			return
+		}
+		v := lintViolation{Rule: "long-block-break", Title: chain[0].Title, Calendar: auditCalendar(chain[0]), Start: chain[0].Start}
+		for _, ev := range chain {
+			v.IDs = append(v.IDs, ev.ID)
+		}
+		v.Message = fmt.Sprintf("%s without a break of %s (max %s)", formatMinutes(int64(end.Sub(chain[0].Start).Minutes())), formatMinutes(int64(o.MinBreak.Minutes())), formatMinutes(int64(o.MaxBlock.Minutes())))
+		if len(chain) == 1 {
+			v.Fix = fmt.Sprintf("acal events split %s --after %s", chain[0].ID, formatMinutes(int64(o.MaxBlock.Minutes())))
+		} else {
+			over := chain[len(chain)-1]
+			for _, ev := range chain[1:] {
+				if ev.End.Sub(chain[0].Start) > o.MaxBlock {
+					over = ev
+					break
+				}
+			}
+			v.Title = over.Title
+			v.IDs = append([]string{over.ID}, removeString(v.IDs, over.ID)...)
+			v.Fix = fmt.Sprintf("acal events move %s --by %s", over.ID, formatMinutes(int64(max(o.MinBreak, time.Minute).Minutes())))
+		}
+		out = append(out, v)
+	}
+	var chain []contract.Event
+	var end time.Time
+	for _, ev := range busy {
+		if len(chain) > 0 && ev.Start.Sub(end) >= max(o.MinBreak, time.Nanosecond) {
+			flush(chain, end)
+			chain = nil
+		}
+		if len(chain) == 0 || ev.End.After(end) {
+			end = ev.End
+		}
+		chain = append(chain, ev)
+	}
+	flush(chain, end)
+	return out
+}
+
+func removeString(items []string, v string) []string {
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		if it != v {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+func buildLintViolations(items []contract.Event, o lintOptions) []lintViolation {
+	out := []lintViolation{}
+	busy := []contract.Event{}
+	for _, ev := range items {
+		if ev.AllDay || ev.Status == contract.StatusCancelled {
+			continue
+		}
+		if len(o.Prefixes) > 0 {
+			if v, ok := lintTitlePrefix(ev, o.Prefixes); ok {
+				out = append(out, v)
+			}
+		}
+		if o.RequireVideo {
+			if v, ok := lintVideoLink(ev); ok {
+				out = append(out, v)
+			}
+		}
+		if ev.Availability != contract.AvailabilityFree {
+			busy = append(busy, ev)
+		}
+	}
+	if o.MaxBlock > 0 {
+		out = append(out, lintLongBlocks(busy, o)...)
+	}
+	rank := map[string]int{}
+	for i, r := range lintRules {
+		rank[r] = i
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Rule != out[j].Rule {
+			return rank[out[i].Rule] < rank[out[j].Rule]
+		}
+		return out[i].Start.Before(out[j].Start)
+	})
+	return out
+}
+
+func newLintCmd(opts *globalOptions) *cobra.Command {
+	var from, to, maxBlockS, minBreakS string
+	var calendars, rules, prefixes []string
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check events against naming, video-link, and break rules",
+		Long:  "Check timed events in a range against the [lint] rules in config and report each violation with its rule ID, event IDs, and a suggested fix. Exits 1 when any violation is found.",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "lint")
+			if err != nil {
+				return err
+			}
+			for _, r := range rules {
+				if !containsString(lintRules, r) {
+					return failWithHint(p, contract.ErrInvalidUsage, invalidField("rule", r, fmt.Errorf("invalid --rule: %s", r)), "Use "+strings.Join(lintRules, ", "), 2)
+				}
+			}
+			cfg := ro.Lint
+			if c.Flags().Changed("prefix") {
+				cfg.TitlePrefixes = prefixes
+			}
+			if c.Flags().Changed("max-block") {
+				cfg.MaxBlock = maxBlockS
+			}
+			if c.Flags().Changed("min-break") {
+				cfg.MinBreak = minBreakS
+			}
+			o := lintOptions{Prefixes: cfg.TitlePrefixes, RequireVideo: cfg.RequireVideo == nil || *cfg.RequireVideo}
+			if o.MaxBlock, err = timeparse.ParseDuration(firstNonEmpty(cfg.MaxBlock, "2h")); err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("max_block: %w", err), durationHint, 2)
+			}
+			if o.MinBreak, err = timeparse.ParseDuration(firstNonEmpty(cfg.MinBreak, "10m")); err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("min_break: %w", err), durationHint, 2)
+			}
+			if o.MaxBlock < 0 || o.MinBreak < 0 {
+				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("max_block and min_break must not be negative"), durationHint, 2)
+			}
+			if len(rules) > 0 {
+				if !containsString(rules, "title-prefix") {
+					o.Prefixes = nil
+				}
+				if !containsString(rules, "meeting-video-link") {
+					o.RequireVideo = false
+				}
+				if !containsString(rules, "long-block-break") {
+					o.MaxBlock = 0
+				}
+			}
+			var warnings []contract.Warning
+			if containsString(rules, "title-prefix") && len(o.Prefixes) == 0 {
+				warnings = append(warnings, contract.Warning{Code: contract.WarnRuleDisabled, Message: "title-prefix is off: set [lint] title_prefixes or pass --prefix"})
+			}
+			f, err := buildEventFilterWithTZ(from, to, calendars, 0, ro.TZ)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			items, err := listEventsWithTimeout(ctx, be, f)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			violations := buildLintViolations(items, o)
+			byRule := map[string]int{}
+			for _, v := range violations {
+				byRule[v.Rule]++
+			}
+			meta := map[string]any{"count": len(violations), "events_scanned": len(items), "rules": o.active(), "by_rule": byRule}
+			if err := successWithMeta(ctx, p, ro, violations, meta, warnings); err != nil {
+				return err
+			}
+			if len(violations) > 0 {
+				return Wrap(1, fmt.Errorf("%d lint violation(s)", len(violations)))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "today", "Range start")
+	cmd.Flags().StringVar(&to, "to", "+14d", "Range end")
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	cmd.Flags().StringSliceVar(&rules, "rule", nil, "Only check these rules (repeatable): "+strings.Join(lintRules, ", "))
+	cmd.Flags().StringArrayVar(&prefixes, "prefix", nil, "Required title prefix (repeatable; overrides [lint] title_prefixes)")
+	cmd.Flags().StringVar(&maxBlockS, "max-block", "", "Longest stretch without a break (default from [lint] max_block, else 2h; 0 disables)")
+	cmd.Flags().StringVar(&minBreakS, "min-break", "", "Shortest gap that counts as a break (default from [lint] min_break, else 10m)")
+	return cmd
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestBuildLintViolations(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 3, 3, h, m, 0, 0, time.UTC) }
+	items := []contract.Event{
+		{ID: "a", Title: "Standup", Start: at(9, 0), End: at(9, 15), Notes: "Attendees: ana@example.com, bo@example.com"},
+		{ID: "b", Title: "[Eng] Review", Start: at(9, 15), End: at(11, 0), Location: "Room 1", Notes: "Attendees: ana@example.com"},
+		{ID: "c", Title: "[eng] Planning", Start: at(11, 5), End: at(11, 45)},
+		{ID: "d", Title: "[Eng] Offsite", Start: at(13, 0), End: at(16, 0)},
+		{ID: "e", Title: "Lunch", Start: at(12, 0), End: at(12, 30), Availability: contract.AvailabilityFree},
+		{ID: "f", Title: "Holiday", Start: at(0, 0), End: at(0, 0).AddDate(0, 0, 1), AllDay: true},
+	}
+	got := buildLintViolations(items, lintOptions{Prefixes: []string{"[Eng]"}, RequireVideo: true, MaxBlock: 2 * time.Hour, MinBreak: 10 * time.Minute})
+	type row struct{ rule, first, fix string }
+	want := []row{
+		{"title-prefix", "a", `acal events update a --title "[Eng] Standup"`},
+		{"title-prefix", "e", `acal events update e --title "[Eng] Lunch"`},
+		{"meeting-video-link", "a", "acal events update a --url <video-link>"},
+		{"long-block-break", "c", "acal events move c --by 10m"},
+		{"long-block-break", "d", "acal events split d --after 2h"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d violations, got %+v", len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Rule != w.rule || got[i].IDs[0] != w.first || got[i].Fix != w.fix {
+			t.Fatalf("violation %d: got %+v, want %+v", i, got[i], w)
+		}
+	}
+	if ids := got[3].IDs; len(ids) != 3 || ids[1] != "a" || ids[2] != "b" {
+		t.Fatalf("expected the whole chain in ids, got %v", ids)
+	}
+}
+
+func TestLintCommandExitsOneOnViolations(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	start := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Sync", Start: start, End: start.Add(30 * time.Minute)},
+	}})
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+	run := func(args ...string) ([]byte, int) {
+		cmd := NewRootCommand()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs(append(args, "--from", "2026-03-03", "--to", "2026-03-04", "--json"))
+		code := ExitCode(cmd.Execute())
+		return stdout.Bytes(), code
+	}
+
+	out, code := run("lint", "--prefix", "[Eng]")
+	if code != 1 {
+		t.Fatalf("expected exit 1 with violations, got %d", code)
+	}
+	var env struct {
+		Data []lintViolation `json:"data"`
+		Meta map[string]any  `json:"meta"`
+	}
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if len(env.Data) != 1 || env.Data[0].Rule != "title-prefix" || env.Meta["count"] != float64(1) {
+		t.Fatalf("unexpected report: %+v meta=%v", env.Data, env.Meta)
+	}
+	if _, code := run("lint"); code != 0 {
+		t.Fatalf("expected exit 0 without a prefix rule, got %d", code)
+	}
+	if _, code := run("lint", "--rule", "naming"); code != 2 {
+		t.Fatalf("expected exit 2 for an unknown rule, got %d", code)
+	}
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+func mergeEvents(items []*contract.Event) (backend.EventCreateInput, error) {
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Start.Before(items[j].Start) })
+	first := items[0]
+	in := backend.EventCreateInput{
+		Calendar:     firstNonEmpty(first.CalendarID, first.CalendarName),
+		Start:        first.Start,
+		End:          first.End,
+		AllDay:       first.AllDay,
+		Status:       first.Status,
+		Availability: first.Availability,
+		Sensitivity:  first.Sensitivity,
+	}
+	notes := []string{}
+	for i, ev := range items {
+		if ev.CalendarID != first.CalendarID {
+			return backend.EventCreateInput{}, fmt.Errorf("%s is on %s, not %s", ev.ID, firstNonEmpty(ev.CalendarName, ev.CalendarID), firstNonEmpty(first.CalendarName, first.CalendarID))
+		}
+		if ev.AllDay != first.AllDay {
+			return backend.EventCreateInput{}, errors.New("cannot merge all-day and timed events")
+		}
+		if i > 0 && ev.Start.After(in.End) {
+			return backend.EventCreateInput{}, fmt.Errorf("%s starts %s after the previous event ends", ev.ID, formatMinutes(int64(ev.Start.Sub(in.End).Minutes())))
+		}
+		if ev.End.After(in.End) {
+			in.End = ev.End
+		}
+		in.Title = firstNonEmpty(in.Title, ev.Title)
+		in.Location = firstNonEmpty(in.Location, ev.Location)
+		in.URL = firstNonEmpty(in.URL, ev.URL)
+		if n := strings.TrimSpace(ev.Notes); n != "" && !containsString(notes, n) {
+			notes = append(notes, n)
+		}
+	}
+	in.Notes = strings.Join(notes, "\n\n")
+	return in, nil
+}
+
+func newEventsMergeCmd(opts *globalOptions) *cobra.Command {
+	var title string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "merge <event-id> <event-id>...",
+		Short: "Merge adjacent or overlapping events into one",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			p, be, ro, err := buildContext(c, opts, "events.merge")
+			if err != nil {
+				return err
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			items := make([]*contract.Event, 0, len(args))
+			seen := map[string]bool{}
+			for _, ref := range args {
+				id, err := resolveEventRef(ctx, be, ref, currentTime())
+				if err != nil {
+					return failEventRef(p, err)
+				}
+				if seen[id] {
+					return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("event %s given twice", id), "Pass each event once", 2)
+				}
+				seen[id] = true
+				ev, err := getEventByIDWithTimeout(ctx, be, id)
+				if err != nil {
+					return failWithHint(p, contract.ErrNotFound, withEventIDs(err, id), "Check ID with `acal events list --fields id,title,start`", 4)
+				}
+				items = append(items, ev)
+			}
+			in, err := mergeEvents(items)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Merge events on one calendar that touch or overlap", 2)
+			}
+			if strings.TrimSpace(title) != "" {
+				in.Title = title
+			}
+			ids := make([]string, 0, len(items))
+			for _, ev := range items {
+				ids = append(ids, ev.ID)
+			}
+			meta := map[string]any{"merged_ids": ids}
+			if dryRun {
+				previews := []dryRunPreview{previewAdd(in)}
+				for _, ev := range items {
+					previews = append(previews, previewDelete(ev))
+				}
+				return successDryRun(ctx, p, ro, previews, meta, nil)
+			}
+			created, err := addEventWithTimeout(ctx, be, in)
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Merge failed; nothing was deleted", 1)
+			}
+			txID := batchTxID()
+			_ = appendHistory(historyEntry{Type: "add", TxID: txID, OpID: batchOpID(1, "add"), EventID: created.ID, Created: created})
+			for i, ev := range items {
+				if err := deleteEventWithTimeout(ctx, be, ev.ID, backend.ScopeAuto); err != nil {
+					return failWithHint(p, contract.ErrGeneric, fmt.Errorf("merged event created but deleting %s failed: %w", ev.ID, err), "Delete the remaining originals with `acal events delete`", 1)
+				}
+				_ = appendHistory(historyEntry{Type: "delete", TxID: txID, OpID: batchOpID(i+2, "delete"), EventID: ev.ID, Deleted: ev})
+			}
+			meta["count"], meta["tx_id"] = 1, txID
+			return successWithMeta(ctx, p, ro, created, meta, nil)
+		},
+	}
+	cmd.Flags().StringVar(&title, "title", "", "Title for the merged event (default the earliest event's)")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview the merged event without writing")
+	return cmd
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventsMerge(t *testing.T) {
+	at := func(hour, min int) time.Time { return time.Date(2026, 3, 3, hour, min, 0, 0, time.UTC) }
+	ev := func(id, cal, title, notes string, start, end time.Time) contract.Event {
+		return contract.Event{ID: id, CalendarID: cal, CalendarName: cal, Title: title, Notes: notes, Start: start, End: end}
+	}
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "work", Writable: true}, {ID: "home", Name: "home", Writable: true}},
+		Events: []contract.Event{
+			ev("b", "work", "Review", "second", at(10, 0), at(11, 0)),
+			ev("a", "work", "Prep", "first", at(9, 0), at(10, 0)),
+			ev("c", "work", "Wrap-up", "", at(10, 45), at(11, 30)),
+			ev("far", "work", "Later", "", at(15, 0), at(16, 0)),
+			ev("h", "home", "Errand", "", at(11, 30), at(12, 0)),
+		},
+	})
+	if code := runEventsCmd(t, fb, "events", "merge", "a", "far", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for a gap, got %d", code)
+	}
+	if code := runEventsCmd(t, fb, "events", "merge", "c", "h", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 across calendars, got %d", code)
+	}
+	var env struct {
+		Data contract.Event `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "merge", "b", "a", "c", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Data.Title != "Prep" || env.Data.Notes != "first\n\nsecond" || !env.Data.Start.Equal(at(9, 0)) || !env.Data.End.Equal(at(11, 30)) {
+		t.Fatalf("unexpected merged event: %+v", env.Data)
+	}
+	left, err := fb.ListEvents(t.Context(), backend.EventFilter{From: at(0, 0), To: at(23, 0), Calendars: []string{"work"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) != 2 {
+		t.Fatalf("expected originals deleted, left %+v", left)
+	}
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+var errNotCreatedByAcal = errors.New("event was not created by acal")
+
+// eventUID drops a trailing @<occurrence> from an event ID.
+func eventUID(id string) string {
+	id = strings.TrimSpace(id)
+	if i := strings.LastIndex(id, "@"); i > 0 {
+		if _, err := strconv.ParseInt(id[i+1:], 10, 64); err == nil {
+			return id[:i]
+		}
+	}
+	return id
+}
+
+func createdEventUIDs() (map[string]bool, error) {
+	entries, err := readHistory()
+	if err != nil {
+		return nil, err
+	}
+	uids := map[string]bool{}
+	for _, e := range entries {
+		if e.Type != "add" {
+			continue
+		}
+		id := e.EventID
+		if e.Created != nil {
+			id = firstNonEmpty(e.Created.ID, id)
+		}
+		if id != "" {
+			uids[eventUID(id)] = true
+		}
+	}
+	return uids, nil
+}
+
+func checkCreatedByAcal(uids map[string]bool, id string) error {
+	if !uids[eventUID(id)] {
+		return fmt.Errorf("%w: %s", errNotCreatedByAcal, id)
+	}
+	return nil
+}
+
+func newEventsMineCmd(opts *globalOptions) *cobra.Command {
+	var calendars []string
+	var from, to string
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "mine",
+		Short: "List events created through acal",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(cmd, opts, "events.mine")
+			if err != nil {
+				return err
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			f, err := buildEventFilterWithTZ(from, to, calendars, 0, ro.TZ)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --from and --to with RFC3339, YYYY-MM-DD, or relative values", 2)
+			}
+			uids, err := createdEventUIDs()
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Check history file permissions (`acal state path`)", 1)
+			}
+			items := []contract.Event{}
+			if len(uids) > 0 {
+				err = streamEventsWithTimeout(ctx, be, f, func(e contract.Event) error {
+					if uids[eventUID(e.ID)] && (limit <= 0 || len(items) < limit) {
+						items = append(items, e)
+					}
+					return nil
+				})
+				if err != nil {
+					return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+				}
+			}
+			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items), "tracked": len(uids)}, nil)
+		},
+	}
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	cmd.Flags().StringVar(&from, "from", "today", "Range start")
+	cmd.Flags().StringVar(&to, "to", "+30d", "Range end")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Limit results")
+	return cmd
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventUIDDropsOccurrenceSuffix(t *testing.T) {
+	cases := map[string]string{
+		"ABC-1@792417600":      "ABC-1",
+		"ABC-1":                "ABC-1",
+		"caldav:x@example.com": "caldav:x@example.com",
+		" evt@1 ":              "evt",
+	}
+	for in, want := range cases {
+		if got := eventUID(in); got != want {
+			t.Fatalf("eventUID(%q)=%q want %q", in, got, want)
+		}
+	}
+}
+
+func TestEventsMineAndCreatedByAcalGuards(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	start := time.Now().Add(2 * time.Hour).Truncate(time.Minute)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "foreign", CalendarID: "work", CalendarName: "Work", Title: "Board meeting", Start: start, End: start.Add(time.Hour)},
+		},
+	})
+	if code := runEventsCmd(t, fb, "events", "add", "--calendar", "Work", "--title", "Bot sync", "--start", start.Format(time.RFC3339), "--duration", "30m", "--json"); code != 0 {
+		t.Fatalf("add exit code %d", code)
+	}
+	out := string(runWithBackend(t, fb, "events", "mine", "--from", "today", "--to", "+2d", "--plain", "--fields", "title"))
+	if strings.TrimSpace(out) != "Bot sync" {
+		t.Fatalf("unexpected mine output %q", out)
+	}
+
+	if code := runEventsCmd(t, fb, "events", "delete", "foreign", "--force", "--created-by-acal", "--json"); code != 3 {
+		t.Fatalf("expected exit 3 deleting a foreign event, got %d", code)
+	}
+	ops := filepath.Join(t.TempDir(), "ops.jsonl")
+	if err := os.WriteFile(ops, []byte(`{"op":"delete","id":"foreign"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code := runEventsCmd(t, fb, "events", "batch", "--file", ops, "--created-by-acal", "--json"); code != 1 {
+		t.Fatalf("expected batch row error, got exit %d", code)
+	}
+	if _, err := fb.GetEventByID(t.Context(), "foreign"); err != nil {
+		t.Fatalf("foreign event should survive: %v", err)
+	}
+	own := strings.TrimSpace(string(runWithBackend(t, fb, "events", "mine", "--from", "today", "--to", "+2d", "--plain", "--fields", "id")))
+	if code := runEventsCmd(t, fb, "events", "delete", own, "--force", "--created-by-acal", "--json"); code != 0 {
+		t.Fatalf("deleting an acal-created event exit %d", code)
+	}
+}
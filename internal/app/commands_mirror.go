@@ -0,0 +1,296 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+const mirrorMarkerPrefix = "acal:mirror-of="
+
+type mirrorAction struct {
+	Action   string    `json:"action"`
+	SourceID string    `json:"source_id"`
+	MirrorID string    `json:"mirror_id,omitempty"`
+	Title    string    `json:"title"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Error    string    `json:"error,omitempty"`
+}
+
+func newEventsMirrorCmd(opts *globalOptions) *cobra.Command {
+	var calendars, wheres []string
+	var fromS, toS, target string
+	var noDelete, dryRun bool
+	cmd := &cobra.Command{
+		Use:   "mirror",
+		Short: "Keep copies of matching events in another calendar",
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "events.mirror")
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(target) == "" {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--target is required"), "Set --target <calendar>", 2)
+			}
+			f, err := buildEventFilterWithTZ(fromS, toS, calendars, 0, ro.TZ)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
+			}
+			preds, err := parsePredicates(wheres)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use clauses like title~\"interview\" or calendar==\"Work\"", 2)
+			}
+			preds = expandPredicatePeople(preds, ro.People)
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			sources, err := listEventsWithTimeout(ctx, be, f)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			sources, err = applyPredicates(sources, preds)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Check --where field/operator/value", 2)
+			}
+			tf := f
+			tf.Calendars = []string{target}
+			mirrors, err := listEventsWithTimeout(ctx, be, tf)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			plan := planMirror(sources, mirrors, target, !noDelete, f.From, f.To)
+			meta := map[string]any{"count": len(plan), "target": target}
+			if dryRun {
+				meta["dry_run"] = true
+				meta["actions"] = countMirrorActions(plan)
+				return successWithMeta(ctx, p, ro, plan, meta, nil)
+			}
+			var warnings []contract.Warning
+			for i := range plan {
+				if err := applyMirrorAction(ctx, be, &plan[i], sources, mirrors, target); err != nil {
+					plan[i].Error = err.Error()
+					warnings = append(warnings, contract.Warning{Code: contract.WarnPartialFailure, Message: "mirror " + plan[i].Action + " failed for " + plan[i].SourceID + ": " + err.Error()})
+				}
+			}
+			meta["actions"] = countMirrorActions(plan)
+			return successWithMeta(ctx, p, ro, plan, meta, warnings)
+		},
+	}
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Source calendar ID or name (repeatable)")
+	cmd.Flags().StringSliceVar(&wheres, "where", nil, "Predicate clause for source events (repeatable)")
+	cmd.Flags().StringVar(&fromS, "from", "today", "Range start")
+	cmd.Flags().StringVar(&toS, "to", "+30d", "Range end")
+	cmd.Flags().StringVar(&target, "target", "", "Calendar ID or name that receives mirror copies")
+	cmd.Flags().BoolVar(&noDelete, "no-delete", false, "Keep mirrors whose source no longer matches")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview without writing")
+	return cmd
+}
+
+func planMirror(sources, mirrors []contract.Event, target string, prune bool, from, to time.Time) []mirrorAction {
+	mirrorsByUID := map[string][]contract.Event{}
+	for _, m := range mirrors {
+		key := parseMirrorMarker(m.Notes)
+		if key == "" {
+			continue
+		}
+		if at, ok := mirrorSourceStart(key); ok && (at.Before(from) || !at.Before(to)) {
+			continue
+		}
+		uid := eventUID(key)
+		mirrorsByUID[uid] = append(mirrorsByUID[uid], m)
+	}
+	sourcesByUID := map[string][]contract.Event{}
+	uids := []string{}
+	for _, s := range sources {
+		if parseMirrorMarker(s.Notes) != "" || isTargetCalendar(s, target) {
+			continue
+		}
+		uid := eventUID(s.ID)
+		if _, ok := sourcesByUID[uid]; !ok {
+			uids = append(uids, uid)
+		}
+		sourcesByUID[uid] = append(sourcesByUID[uid], s)
+	}
+	plan := []mirrorAction{}
+	for _, uid := range uids {
+		srcs, ms := sourcesByUID[uid], mirrorsByUID[uid]
+		delete(mirrorsByUID, uid)
+		pairs, unmatched := pairMirrors(srcs, ms)
+		for i, s := range srcs {
+			m, ok := pairs[i]
+			switch {
+			case !ok:
+				plan = append(plan, mirrorAction{Action: "create", SourceID: s.ID, Title: s.Title, Start: s.Start, End: s.End})
+			case mirrorDiffers(s, m):
+				plan = append(plan, mirrorAction{Action: "update", SourceID: s.ID, MirrorID: m.ID, Title: s.Title, Start: s.Start, End: s.End})
+			default:
+				plan = append(plan, mirrorAction{Action: "unchanged", SourceID: s.ID, MirrorID: m.ID, Title: s.Title, Start: s.Start, End: s.End})
+			}
+		}
+		mirrorsByUID[uid] = unmatched
+	}
+	if prune {
+		for _, m := range mirrors {
+			key := parseMirrorMarker(m.Notes)
+			if key == "" || !containsMirror(mirrorsByUID[eventUID(key)], m.ID) {
+				continue
+			}
+			plan = append(plan, mirrorAction{Action: "delete", SourceID: key, MirrorID: m.ID, Title: m.Title, Start: m.Start, End: m.End})
+		}
+	}
+	return plan
+}
+
+func pairMirrors(srcs, ms []contract.Event) (map[int]contract.Event, []contract.Event) {
+	pairs := map[int]contract.Event{}
+	used := make([]bool, len(ms))
+	for i, s := range srcs {
+		for j, m := range ms {
+			if at, ok := mirrorSourceStart(parseMirrorMarker(m.Notes)); !used[j] && ok && at.Equal(s.Start) {
+				pairs[i], used[j] = m, true
+				break
+			}
+		}
+	}
+	j := 0
+	for i := range srcs {
+		if _, ok := pairs[i]; ok {
+			continue
+		}
+		for j < len(ms) && used[j] {
+			j++
+		}
+		if j == len(ms) {
+			break
+		}
+		pairs[i], used[j] = ms[j], true
+	}
+	left := []contract.Event{}
+	for j, m := range ms {
+		if !used[j] {
+			left = append(left, m)
+		}
+	}
+	return pairs, left
+}
+
+func containsMirror(items []contract.Event, id string) bool {
+	for _, e := range items {
+		if e.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func applyMirrorAction(ctx context.Context, be backend.Backend, a *mirrorAction, sources, mirrors []contract.Event, target string) error {
+	switch a.Action {
+	case "create":
+		src := findEvent(sources, a.SourceID)
+		in := backend.EventCreateInput{Calendar: target, Title: src.Title, Start: src.Start, End: src.End, AllDay: src.AllDay, Location: src.Location, URL: src.URL, Notes: mirrorNotes(src)}
+		item, err := addEventWithTimeout(ctx, be, in)
+		if err != nil {
+			return err
+		}
+		if item != nil {
+			a.MirrorID = item.ID
+			_ = appendHistory(historyEntry{Type: "add", EventID: item.ID, Created: item})
+		}
+	case "update":
+		src := findEvent(sources, a.SourceID)
+		prev := findEvent(mirrors, a.MirrorID)
+		notes := setTagsMarker(mirrorNotes(src), parseTagsMarker(prev.Notes))
+		patch := backend.EventUpdateInput{Title: &src.Title, Start: &src.Start, End: &src.End, AllDay: &src.AllDay, Location: &src.Location, URL: &src.URL, Notes: &notes, Scope: backend.ScopeAuto}
+		updated, err := updateEventWithTimeout(ctx, be, a.MirrorID, patch)
+		if err != nil {
+			return err
+		}
+		_ = appendHistory(historyEntry{Type: "update", EventID: a.MirrorID, Prev: &prev, Next: updated})
+	case "delete":
+		prev := findEvent(mirrors, a.MirrorID)
+		if err := deleteEventWithTimeout(ctx, be, a.MirrorID, backend.ScopeAuto); err != nil {
+			return err
+		}
+		_ = appendHistory(historyEntry{Type: "delete", EventID: a.MirrorID, Deleted: &prev})
+	}
+	return nil
+}
+
+func mirrorNotes(src contract.Event) string {
+	return setMirrorMarker(clearTagsMarker(src.Notes), mirrorKey(src))
+}
+
+// mirrorKey is <series uid>@<occurrence start unix>.
+func mirrorKey(src contract.Event) string {
+	return eventUID(src.ID) + "@" + strconv.FormatInt(src.Start.Unix(), 10)
+}
+
+func mirrorSourceStart(key string) (time.Time, bool) {
+	i := strings.LastIndex(key, "@")
+	if i <= 0 {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(key[i+1:], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0), true
+}
+
+func mirrorDiffers(src, m contract.Event) bool {
+	return src.Title != m.Title || !src.Start.Equal(m.Start) || !src.End.Equal(m.End) || src.AllDay != m.AllDay ||
+		src.Location != m.Location || src.URL != m.URL || mirrorNotes(src) != clearTagsMarker(m.Notes)
+}
+
+func isTargetCalendar(e contract.Event, target string) bool {
+	return e.CalendarID == target || strings.EqualFold(e.CalendarName, strings.TrimSpace(target))
+}
+
+func findEvent(items []contract.Event, id string) contract.Event {
+	for _, e := range items {
+		if e.ID == id {
+			return e
+		}
+	}
+	return contract.Event{}
+}
+
+func countMirrorActions(plan []mirrorAction) map[string]int {
+	out := map[string]int{"create": 0, "update": 0, "delete": 0, "unchanged": 0}
+	for _, a := range plan {
+		out[a.Action]++
+	}
+	return out
+}
+
+func parseMirrorMarker(notes string) string {
+	for _, line := range strings.Split(notes, "\n") {
+		s := strings.TrimSpace(line)
+		if strings.HasPrefix(s, mirrorMarkerPrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(s, mirrorMarkerPrefix))
+		}
+	}
+	return ""
+}
+
+func setMirrorMarker(notes, origin string) string {
+	lines := strings.Split(notes, "\n")
+	out := make([]string, 0, len(lines)+1)
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), mirrorMarkerPrefix) {
+			continue
+		}
+		out = append(out, line)
+	}
+	clean := strings.TrimRight(strings.Join(out, "\n"), "\n")
+	if clean == "" {
+		return mirrorMarkerPrefix + origin
+	}
+	return clean + "\n" + mirrorMarkerPrefix + origin
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventsMirrorCreatesUpdatesAndDeletes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	mb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}, {ID: "personal", Name: "Personal", Writable: true}},
+		Events: []contract.Event{
+			{ID: "int-1", CalendarID: "work", CalendarName: "Work", Title: "Interview: Ana", Start: start, End: start.Add(time.Hour), Notes: "acal:tags=hiring"},
+			{ID: "sync-1", CalendarID: "work", CalendarName: "Work", Title: "Sync", Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)},
+		},
+	})
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return mb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+
+	run := func() []mirrorAction {
+		t.Helper()
+		cmd := NewRootCommand()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs([]string{"events", "mirror", "--calendar", "Work", "--where", "title~interview", "--target", "Personal", "--from", "2026-03-02", "--to", "2026-03-03", "--tz", "UTC", "--json"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("execute failed: %v", err)
+		}
+		var env struct {
+			Data []mirrorAction `json:"data"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+		return env.Data
+	}
+
+	plan := run()
+	if len(plan) != 1 || plan[0].Action != "create" || plan[0].MirrorID == "" {
+		t.Fatalf("unexpected first plan: %+v", plan)
+	}
+	mirror, err := mb.GetEventByID(context.Background(), plan[0].MirrorID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mirror.CalendarName != "Personal" || parseMirrorMarker(mirror.Notes) != fmt.Sprintf("int-1@%d", start.Unix()) || len(parseTagsMarker(mirror.Notes)) != 0 {
+		t.Fatalf("unexpected mirror: %+v", mirror)
+	}
+
+	if plan = run(); len(plan) != 1 || plan[0].Action != "unchanged" {
+		t.Fatalf("expected unchanged mirror, got %+v", plan)
+	}
+
+	moved := start.Add(30 * time.Minute)
+	if _, err := mb.UpdateEvent(context.Background(), "int-1", backend.EventUpdateInput{Start: &moved}); err != nil {
+		t.Fatal(err)
+	}
+	mirrorID := plan[0].MirrorID
+	if plan = run(); len(plan) != 1 || plan[0].Action != "update" || plan[0].MirrorID != mirrorID {
+		t.Fatalf("expected an in-place update, got %+v", plan)
+	}
+	mirror, _ = mb.GetEventByID(context.Background(), plan[0].MirrorID)
+	if !mirror.Start.Equal(moved) {
+		t.Fatalf("mirror start not updated: %s", mirror.Start)
+	}
+
+	if err := mb.DeleteEvent(context.Background(), "int-1", backend.ScopeAuto); err != nil {
+		t.Fatal(err)
+	}
+	if plan = run(); len(plan) != 1 || plan[0].Action != "delete" {
+		t.Fatalf("expected delete, got %+v", plan)
+	}
+	if _, err := mb.GetEventByID(context.Background(), plan[0].MirrorID); err == nil {
+		t.Fatalf("expected mirror to be deleted")
+	}
+}
+
+func TestSetMirrorMarkerReplacesExisting(t *testing.T) {
+	got := setMirrorMarker("agenda\nacal:mirror-of=old", "new@1")
+	if got != "agenda\nacal:mirror-of=new@1" || parseMirrorMarker(got) != "new@1" {
+		t.Fatalf("unexpected notes: %q", got)
+	}
+}
+
+func TestPlanMirrorKeysOnSeriesUID(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	mirrorOf := func(id string, key string, start time.Time) contract.Event {
+		return contract.Event{ID: id, CalendarName: "Personal", Title: "Standup", Start: start, End: start.Add(time.Hour), Notes: setMirrorMarker("", key)}
+	}
+	// Yesterday's mirror is outside the window, and the 10:00 occurrence
+	// moved to 11:00, which changes its backend ID.
+	mirrors := []contract.Event{
+		mirrorOf("m-0", fmt.Sprintf("standup@%d", at(-14).Unix()), at(-14)),
+		mirrorOf("m-1", fmt.Sprintf("standup@%d", at(10).Unix()), at(10)),
+	}
+	sources := []contract.Event{{ID: fmt.Sprintf("standup@%d", at(11).Unix()), CalendarName: "Work", Title: "Standup", Start: at(11), End: at(12)}}
+	plan := planMirror(sources, mirrors, "Personal", true, day, day.AddDate(0, 0, 1))
+	if len(plan) != 1 || plan[0].Action != "update" || plan[0].MirrorID != "m-1" {
+		t.Fatalf("expected the moved occurrence to update its mirror and nothing else, got %+v", plan)
+	}
+}
@@ -0,0 +1,161 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+const soonThreshold = 10 * time.Minute
+
+type barItem struct {
+	Icon    string
+	Label   string
+	Tooltip string
+	Class   string
+	URL     string
+}
+
+func buildBarItem(e *contract.Event, now time.Time, clock, icon, videoIcon string) barItem {
+	if e == nil {
+		return barItem{Icon: icon, Class: "none"}
+	}
+	it := barItem{Icon: icon, URL: e.MeetingURL}
+	if e.IsVideoCall && videoIcon != "" {
+		it.Icon = videoIcon
+	}
+	title := firstNonEmpty(strings.TrimSpace(e.Title), "(untitled)")
+	switch {
+	case !e.Start.After(now):
+		it.Class = "ongoing"
+		it.Label = title + " · " + formatMinutes(int64(e.End.Sub(now).Round(time.Minute).Minutes())) + " left"
+	case e.Start.Sub(now) < time.Hour:
+		it.Class = "upcoming"
+		if e.Start.Sub(now) <= soonThreshold {
+			it.Class = "soon"
+		}
+		it.Label = title + " in " + formatMinutes(int64(e.Start.Sub(now).Round(time.Minute).Minutes()))
+	default:
+		it.Class = "upcoming"
+		when := e.Start.Format(clock)
+		if y, m, d := e.Start.Date(); y != now.Year() || m != now.Month() || d != now.Day() {
+			when = e.Start.Format("Mon") + " " + when
+		}
+		it.Label = title + " at " + when
+	}
+	lines := []string{title, e.Start.Format(clock) + "–" + e.End.Format(clock) + " · " + firstNonEmpty(e.CalendarName, e.CalendarID)}
+	if e.Location != "" {
+		lines = append(lines, e.Location)
+	}
+	if e.MeetingURL != "" {
+		lines = append(lines, e.MeetingURL)
+	}
+	it.Tooltip = strings.Join(lines, "\n")
+	return it
+}
+
+func renderWaybar(it barItem) string {
+	text := ""
+	if it.Label != "" {
+		text = it.Icon + " " + it.Label
+	}
+	pango := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	raw, _ := json.Marshal(map[string]string{"text": pango.Replace(text), "tooltip": pango.Replace(it.Tooltip), "class": it.Class, "alt": it.Class})
+	return string(raw) + "\n"
+}
+
+func renderSketchybar(it barItem) string {
+	click := "open -a Calendar"
+	if it.URL != "" {
+		click = "open " + shellQuote(it.URL)
+	}
+	drawing := "on"
+	if it.Label == "" {
+		drawing = "off"
+	}
+	props := []string{
+		"icon=" + shellQuote(it.Icon),
+		"label=" + shellQuote(it.Label),
+		"click_script=" + shellQuote(click),
+		"drawing=" + drawing,
+	}
+	return strings.Join(props, " ") + "\n"
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func newNextCmd(opts *globalOptions) *cobra.Command {
+	var calendars []string
+	var withinS, format, icon, videoIcon string
+	cmd := &cobra.Command{
+		Use:   "next",
+		Short: "Show the meeting in progress or the next one, optionally for a status bar",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "next")
+			if err != nil {
+				return err
+			}
+			format = strings.ToLower(strings.TrimSpace(format))
+			if format != "" && format != "sketchybar" && format != "waybar" {
+				return failWithHint(p, contract.ErrInvalidUsage, invalidField("format", format, fmt.Errorf("invalid --format: %s", format)), "Use --format sketchybar|waybar, or omit it for the event itself", 2)
+			}
+			within, err := timeparse.ParseDuration(withinS)
+			if err != nil || within <= 0 {
+				if err == nil {
+					err = errors.New("--within must be positive")
+				}
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			clock, _ := timeparse.ClockLayout(ro.TimeFormat)
+			loc := resolveLocation(ro.TZ)
+			now := currentTime().In(loc)
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: now, To: now.Add(within), Calendars: calendars, Overlap: true})
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			if p.HidePrivate {
+				items = output.MaskPrivate(items).([]contract.Event)
+			}
+			e := currentEvent(items, now)
+			if e == nil {
+				e = nextEvent(items, now)
+			}
+			if e != nil {
+				e.Start, e.End = e.Start.In(loc), e.End.In(loc)
+			}
+			if format != "" {
+				it := buildBarItem(e, now, clock, icon, videoIcon)
+				line := renderWaybar(it)
+				if format == "sketchybar" {
+					line = renderSketchybar(it)
+				}
+				_, _ = fmt.Fprint(c.OutOrStdout(), line)
+				return nil
+			}
+			if e == nil {
+				return failWithHint(p, contract.ErrNotFound, fmt.Errorf("no meeting in progress or starting within %s", withinS), "Widen --within or check `acal agenda`", 4)
+			}
+			meta := map[string]any{"count": 1, "in_progress": !e.Start.After(now), "starts_in_minutes": int64(e.Start.Sub(now).Round(time.Minute).Minutes())}
+			return successWithMeta(ctx, p, ro, e, meta, nil)
+		},
+	}
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	cmd.Flags().StringVar(&withinS, "within", "24h", "How far ahead to look for the next meeting")
+	cmd.Flags().StringVar(&format, "format", "", "Status-bar line: sketchybar|waybar")
+	cmd.Flags().StringVar(&icon, "icon", "📅", "Status-bar icon")
+	cmd.Flags().StringVar(&videoIcon, "video-icon", "🎥", "Status-bar icon for video calls")
+	return cmd
+}
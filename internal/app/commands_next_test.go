@@ -0,0 +1,62 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestBuildBarItemLabels(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 55, 0, 0, time.UTC)
+	e := &contract.Event{Title: "Standup", CalendarName: "Work", Start: now.Add(5 * time.Minute), End: now.Add(20 * time.Minute)}
+	if it := buildBarItem(e, now, "15:04", "C", "V"); it.Label != "Standup in 5m" || it.Class != "soon" || it.Icon != "C" {
+		t.Fatalf("unexpected soon item: %+v", it)
+	}
+	if it := buildBarItem(e, now.Add(10*time.Minute), "15:04", "C", "V"); it.Label != "Standup · 10m left" || it.Class != "ongoing" {
+		t.Fatalf("unexpected ongoing item: %+v", it)
+	}
+	later := &contract.Event{Title: "Review", Start: now.Add(25 * time.Hour), End: now.Add(26 * time.Hour), IsVideoCall: true}
+	if it := buildBarItem(later, now, "15:04", "C", "V"); it.Label != "Review at Tue 10:55" || it.Icon != "V" {
+		t.Fatalf("unexpected later item: %+v", it)
+	}
+	if it := buildBarItem(nil, now, "15:04", "C", "V"); it.Label != "" || it.Class != "none" {
+		t.Fatalf("unexpected empty item: %+v", it)
+	}
+}
+
+func TestNextStatusBarFormats(t *testing.T) {
+	t.Setenv("ACAL_NOW", "2026-03-02T09:50:00Z")
+	t.Cleanup(func() { pinnedNow.Store(nil) })
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "e1", CalendarID: "work", CalendarName: "Work", Title: "Q&A <prep>", Start: start, End: start.Add(30 * time.Minute), URL: "https://zoom.us/j/123"},
+		},
+	})
+
+	var wb map[string]string
+	if err := json.Unmarshal(runWithBackend(t, fb, "next", "--format", "waybar", "--tz", "UTC"), &wb); err != nil {
+		t.Fatalf("waybar output is not JSON: %v", err)
+	}
+	if wb["text"] != "🎥 Q&amp;A &lt;prep&gt; in 10m" || wb["class"] != "soon" {
+		t.Fatalf("unexpected waybar line: %+v", wb)
+	}
+
+	sb := string(runWithBackend(t, fb, "next", "--format", "sketchybar", "--tz", "UTC"))
+	if !strings.Contains(sb, "label='Q&A <prep> in 10m'") || !strings.Contains(sb, `click_script='open '\''https://zoom.us/j/123'\'''`) {
+		t.Fatalf("unexpected sketchybar line: %q", sb)
+	}
+
+	empty := string(runWithBackend(t, fb, "next", "--format", "sketchybar", "--within", "5m", "--tz", "UTC"))
+	if !strings.Contains(empty, "label='' ") || !strings.Contains(empty, "drawing=off") {
+		t.Fatalf("expected cleared widget, got %q", empty)
+	}
+	if code := runEventsCmd(t, fb, "next", "--within", "5m", "--json"); code != 4 {
+		t.Fatalf("expected exit 4 without a bar format, got %d", code)
+	}
+}
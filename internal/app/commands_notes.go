@@ -0,0 +1,274 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+	"github.com/spf13/cobra"
+)
+
+const notesMarkerPrefix = "acal:notes="
+
+const defaultNotesTemplate = `# {{.Title}}
+
+- Date: {{.Date}}
+- Time: {{if .AllDay}}all day{{else}}{{.Start}}-{{.End}}{{end}}
+- Calendar: {{.Calendar}}
+{{- if .Location}}
+- Location: {{.Location}}
+{{- end}}
+{{- if .URL}}
+- Link: {{.URL}}
+{{- end}}
+- Event: ` + "`{{.EventID}}`" + `
+
+## Attendees
+{{range .Attendees}}
+- {{.}}
+{{- else}}
+-
+{{- end}}
+
+## Agenda
+{{range .Agenda}}
+- {{.}}
+{{- else}}
+-
+{{- end}}
+
+## Notes
+
+## Action items
+
+- [ ]
+`
+
+type notesTemplateData struct {
+	EventID   string
+	Title     string
+	Calendar  string
+	Date      string
+	Start     string
+	End       string
+	AllDay    bool
+	Location  string
+	URL       string
+	Attendees []string
+	Agenda    []string
+	Notes     string
+}
+
+type notesScaffold struct {
+	EventID    string `json:"event_id"`
+	Path       string `json:"path,omitempty"`
+	Content    string `json:"content"`
+	Backlinked bool   `json:"backlinked"`
+}
+
+func newEventsNotesTemplateCmd(opts *globalOptions) *cobra.Command {
+	var outPath, templatePath string
+	var backlink, force bool
+	cmd := &cobra.Command{
+		Use:   "notes-template <event-id>",
+		Short: "Generate a Markdown meeting-notes file from an event",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, be, ro, err := buildContext(cmd, opts, "events.notes-template")
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("template") {
+				ro.NotesTemplate = templatePath
+			}
+			if backlink && strings.TrimSpace(outPath) == "" {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--backlink requires --out"), "Pass --out <file.md>", 2)
+			}
+			tmpl, err := loadNotesTemplate(ro.NotesTemplate)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Check the notes template path and text/template syntax", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
+			item, err := getEventByRef(ctx, be, id, currentTime())
+			if err != nil {
+				return failWithHint(p, contract.ErrNotFound, withEventIDs(err, id), "Check ID with `acal events list --fields id,title,start`", 4)
+			}
+			var b strings.Builder
+			if err := tmpl.Execute(&b, buildNotesTemplateData(*item, resolveLocation(ro.TZ))); err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Check template fields against `acal events notes-template --help`", 2)
+			}
+			res := notesScaffold{EventID: item.ID, Content: b.String()}
+			if strings.TrimSpace(outPath) == "" {
+				if p.EffectiveSuccessMode() == output.ModePlain {
+					_, _ = fmt.Fprint(cmd.OutOrStdout(), res.Content)
+					return nil
+				}
+				return successWithMeta(ctx, p, ro, res, map[string]any{"count": 1}, nil)
+			}
+			if res.Path, err = filepath.Abs(outPath); err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Check --out path", 2)
+			}
+			if _, err := os.Stat(res.Path); err == nil && !force {
+				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("%s already exists", res.Path), "Pass --force to overwrite", 2)
+			}
+			if err := os.WriteFile(res.Path, []byte(res.Content), 0o644); err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Check --out directory permissions", 1)
+			}
+			var warnings []contract.Warning
+			if backlink {
+				notes := setNotesMarker(item.Notes, res.Path)
+				updated, err := updateEventWithTimeout(ctx, be, item.ID, backend.EventUpdateInput{Notes: &notes, Scope: backend.ScopeAuto})
+				if err != nil {
+					warnings = append(warnings, contract.Warning{Code: contract.WarnPartialFailure, Message: "unable to write backlink: " + err.Error()})
+				} else {
+					_ = appendHistory(historyEntry{Type: "update", EventID: item.ID, Prev: item, Next: updated})
+					res.Backlinked = true
+				}
+			}
+			return successWithMeta(ctx, p, ro, res, map[string]any{"count": 1}, warnings)
+		},
+	}
+	cmd.Flags().StringVar(&outPath, "out", "", "Write the notes file here (default: print it)")
+	cmd.Flags().StringVar(&templatePath, "template", "", "Go text/template file (fields: .Title .Date .Start .End .AllDay .Calendar .Location .URL .Attendees .Agenda .Notes .EventID)")
+	cmd.Flags().BoolVar(&backlink, "backlink", false, "Add an "+notesMarkerPrefix+"<path> line to the event notes")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing --out file")
+	return cmd
+}
+
+func loadNotesTemplate(path string) (*template.Template, error) {
+	text := defaultNotesTemplate
+	if strings.TrimSpace(path) != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		text = string(raw)
+	}
+	return template.New("notes").Option("missingkey=error").Parse(text)
+}
+
+func buildNotesTemplateData(e contract.Event, loc *time.Location) notesTemplateData {
+	notes := stripACALMarkers(e.Notes)
+	return notesTemplateData{
+		EventID:   e.ID,
+		Title:     e.Title,
+		Calendar:  e.CalendarName,
+		Date:      e.Start.In(loc).Format("Monday, 2006-01-02"),
+		Start:     e.Start.In(loc).Format("15:04"),
+		End:       e.End.In(loc).Format("15:04"),
+		AllDay:    e.AllDay,
+		Location:  e.Location,
+		URL:       e.URL,
+		Attendees: extractAttendees(notes),
+		Agenda:    extractAgenda(notes),
+		Notes:     notes,
+	}
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+func extractAttendees(notes string) []string {
+	out := []string{}
+	for _, line := range strings.Split(notes, "\n") {
+		s := strings.TrimSpace(line)
+		lower := strings.ToLower(s)
+		for _, prefix := range []string{"attendees:", "invitees:", "participants:"} {
+			if strings.HasPrefix(lower, prefix) {
+				for _, name := range strings.Split(s[len(prefix):], ",") {
+					if name = strings.TrimSpace(name); name != "" && !containsString(out, name) {
+						out = append(out, name)
+					}
+				}
+			}
+		}
+	}
+	for _, email := range emailPattern.FindAllString(notes, -1) {
+		if !containsString(out, email) && !containsAttendee(out, email) {
+			out = append(out, email)
+		}
+	}
+	return out
+}
+
+func containsAttendee(items []string, email string) bool {
+	for _, it := range items {
+		if strings.Contains(it, email) {
+			return true
+		}
+	}
+	return false
+}
+
+func extractAgenda(notes string) []string {
+	lines := strings.Split(notes, "\n")
+	var out []string
+	inAgenda := false
+	for _, line := range lines {
+		s := strings.TrimSpace(line)
+		if strings.EqualFold(strings.TrimSuffix(strings.TrimLeft(s, "# "), ":"), "agenda") {
+			inAgenda, out = true, nil
+			continue
+		}
+		if inAgenda {
+			if s == "" {
+				break
+			}
+			out = append(out, trimBullet(s))
+			continue
+		}
+		if item := trimBullet(s); item != s && item != "" {
+			out = append(out, item)
+		}
+	}
+	if out == nil {
+		return []string{}
+	}
+	return out
+}
+
+var bulletPattern = regexp.MustCompile(`^(?:[-*•]|\d+[.)])\s+`)
+
+func trimBullet(s string) string {
+	return bulletPattern.ReplaceAllString(s, "")
+}
+
+func stripACALMarkers(notes string) string {
+	lines := strings.Split(notes, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "acal:") {
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+func setNotesMarker(notes, path string) string {
+	lines := strings.Split(notes, "\n")
+	out := make([]string, 0, len(lines)+1)
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), notesMarkerPrefix) {
+			continue
+		}
+		out = append(out, line)
+	}
+	clean := strings.TrimRight(strings.Join(out, "\n"), "\n")
+	if clean == "" {
+		return notesMarkerPrefix + path
+	}
+	return clean + "\n" + notesMarkerPrefix + path
+}
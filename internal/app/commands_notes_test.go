@@ -0,0 +1,79 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestExtractAttendeesAndAgenda(t *testing.T) {
+	notes := "Attendees: Alice <alice@example.com>, Bob\nCc carol@example.com\n\nAgenda:\n1. Roadmap\n- Hiring\n\n- stray bullet\nacal:tags=work"
+	clean := stripACALMarkers(notes)
+	if got := extractAttendees(clean); !reflect.DeepEqual(got, []string{"Alice <alice@example.com>", "Bob", "carol@example.com"}) {
+		t.Fatalf("unexpected attendees: %#v", got)
+	}
+	if got := extractAgenda(clean); !reflect.DeepEqual(got, []string{"Roadmap", "Hiring"}) {
+		t.Fatalf("unexpected agenda: %#v", got)
+	}
+	if got := extractAgenda("- one\n* two\nplain"); !reflect.DeepEqual(got, []string{"one", "two"}) {
+		t.Fatalf("expected bullets as agenda fallback, got %#v", got)
+	}
+}
+
+func TestSetNotesMarkerReplacesExisting(t *testing.T) {
+	got := setNotesMarker("Prep doc\nacal:notes=/old.md\nacal:tags=work", "/new.md")
+	if got != "Prep doc\nacal:tags=work\nacal:notes=/new.md" {
+		t.Fatalf("unexpected notes: %q", got)
+	}
+}
+
+func TestNotesTemplateWritesFileAndBacklink(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	start := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events:    []contract.Event{{ID: "review", CalendarID: "work", CalendarName: "Work", Title: "Design review", Start: start, End: start.Add(time.Hour), Location: "Room 4A", Notes: "Agenda:\n- Mockups\n\nattendees: Dana"}},
+	})
+	out := filepath.Join(t.TempDir(), "review.md")
+	var env struct {
+		Data notesScaffold `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "notes-template", "review", "--out", out, "--backlink", "--tz", "UTC", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("expected notes file: %v", err)
+	}
+	for _, want := range []string{"# Design review", "- Time: 15:00-16:00", "- Location: Room 4A", "## Attendees\n\n- Dana", "## Agenda\n\n- Mockups"} {
+		if !strings.Contains(string(raw), want) {
+			t.Fatalf("expected %q in notes file:\n%s", want, raw)
+		}
+	}
+	if !env.Data.Backlinked || env.Data.Path != out {
+		t.Fatalf("unexpected scaffold result: %+v", env.Data)
+	}
+	item, _ := fb.GetEventByID(t.Context(), "review")
+	if !strings.HasSuffix(item.Notes, notesMarkerPrefix+out) {
+		t.Fatalf("expected backlink marker, got %q", item.Notes)
+	}
+
+	tmpl := filepath.Join(t.TempDir(), "custom.tmpl")
+	if err := os.WriteFile(tmpl, []byte("{{.Title}} @ {{.Calendar}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	env.Data = notesScaffold{}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "notes-template", "review", "--template", tmpl, "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Data.Content != "Design review @ Work\n" || env.Data.Path != "" {
+		t.Fatalf("unexpected custom template output: %+v", env.Data)
+	}
+}
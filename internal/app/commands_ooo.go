@@ -0,0 +1,248 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+const (
+	oooTag         = "ooo"
+	oooConflictTag = "ooo-conflict"
+)
+
+type oooPeriod struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	CalendarName string    `json:"calendar_name"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Days         int       `json:"days"`
+}
+
+type oooResult struct {
+	Blocks    []backend.EventCreateInput `json:"blocks"`
+	Created   []contract.Event           `json:"created,omitempty"`
+	Conflicts []contract.Event           `json:"conflicts"`
+	Flagged   []string                   `json:"flagged,omitempty"`
+}
+
+func newOOOCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{Use: "ooo", Short: "Manage out-of-office blocks"}
+	cmd.AddCommand(newOOOAddCmd(opts), newOOOListCmd(opts))
+	return cmd
+}
+
+func newOOOAddCmd(opts *globalOptions) *cobra.Command {
+	var calendar, fromS, toS, weekdaysS, title, notes string
+	var flagConflicts, skipHolidays, dryRun bool
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Create all-day out-of-office blocks and report conflicting events",
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "ooo.add")
+			if err != nil {
+				return err
+			}
+			if calendar == "" || fromS == "" {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--calendar and --from are required"), "Provide required fields", 2)
+			}
+			loc := resolveLocation(ro.TZ)
+			now := currentTime()
+			from, _, ok := rangeKeyword(fromS, loc)
+			if !ok {
+				if from, err = timeparse.ParseDateTime(fromS, now, loc); err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, invalidField("from", fromS, fmt.Errorf("invalid --from: %w", err)), "Use --from as today, +Nd, YYYY-MM-DD, or a range keyword like next-week", 2)
+				}
+			}
+			to := from
+			if toS != "" {
+				// A keyword's end is exclusive; blocks run through its last day.
+				if _, end, ok := rangeKeyword(toS, loc); ok {
+					to = end.AddDate(0, 0, -1)
+				} else if to, err = timeparse.ParseDateTime(toS, now, loc); err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, invalidField("to", toS, fmt.Errorf("invalid --to: %w", err)), "Use --to as +Nd, YYYY-MM-DD, or a range keyword like next-week", 2)
+				}
+			}
+			var weekdays []time.Weekday
+			if strings.TrimSpace(weekdaysS) != "" {
+				if weekdays, err = parseWeekdays(weekdaysS); err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Use --weekdays mon,tue,wed,thu,fri", 2)
+				}
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			var skip map[string]bool
+			if skipHolidays {
+				lo, _ := dayBounds(from)
+				_, hi := dayBounds(to)
+				hs, err := loadHolidays(ctx, be, ro, lo, hi)
+				if err != nil {
+					return failHolidays(p, err)
+				}
+				skip = holidayDates(hs)
+			}
+			blocks, err := buildOOOBlocks(from, to, weekdays, skip)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use an inclusive --from/--to date range", 2)
+			}
+			inputs := make([]backend.EventCreateInput, 0, len(blocks))
+			for _, b := range blocks {
+				inputs = append(inputs, backend.EventCreateInput{Calendar: calendar, Title: title, Start: b[0], End: b[1], AllDay: true, Notes: setTagsMarker(notes, []string{oooTag})})
+			}
+			windowStart, windowEnd := blocks[0][0], blocks[len(blocks)-1][1]
+			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: windowStart, To: windowEnd, Overlap: true})
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			res := oooResult{Blocks: inputs, Conflicts: oooConflicts(items, blocks)}
+			meta := map[string]any{"count": len(inputs), "conflicts": len(res.Conflicts), "from": windowStart.Format("2006-01-02"), "to": windowEnd.AddDate(0, 0, -1).Format("2006-01-02")}
+			if dryRun {
+				meta["dry_run"] = true
+				return successWithMeta(ctx, p, ro, res, meta, nil)
+			}
+			for _, in := range inputs {
+				item, err := addEventWithTimeout(ctx, be, in)
+				if err != nil {
+					return failWithHint(p, contract.ErrGeneric, err, "Check calendar name and permissions", 1)
+				}
+				if item != nil {
+					_ = appendHistory(historyEntry{Type: "add", EventID: item.ID, Created: item})
+					res.Created = append(res.Created, *item)
+				}
+			}
+			var warnings []contract.Warning
+			if flagConflicts {
+				for _, e := range res.Conflicts {
+					tags, _ := applyTagOps(parseTagsMarker(e.Notes), []string{oooConflictTag})
+					next := setTagsMarker(e.Notes, tags)
+					updated, err := updateEventWithTimeout(ctx, be, e.ID, backend.EventUpdateInput{Notes: &next, Scope: backend.ScopeAuto})
+					if err != nil {
+						warnings = append(warnings, contract.Warning{Code: contract.WarnPartialFailure, Message: fmt.Sprintf("unable to flag %s: %v", e.ID, err)})
+						continue
+					}
+					prev := e
+					_ = appendHistory(historyEntry{Type: "update", EventID: e.ID, Prev: &prev, Next: updated})
+					res.Flagged = append(res.Flagged, e.ID)
+				}
+			}
+			return successWithMeta(ctx, p, ro, res, meta, warnings)
+		},
+	}
+	cmd.Flags().StringVar(&calendar, "calendar", "", "Calendar ID or name for OOO blocks")
+	cmd.Flags().StringVar(&fromS, "from", "", "First OOO day")
+	cmd.Flags().StringVar(&toS, "to", "", "Last OOO day, inclusive (defaults to --from)")
+	cmd.Flags().StringVar(&weekdaysS, "weekdays", "", "Only block these weekdays (e.g. mon,tue,wed,thu,fri)")
+	cmd.Flags().StringVar(&title, "title", "Out of office", "Block title")
+	cmd.Flags().StringVar(&notes, "notes", "", "Block notes")
+	cmd.Flags().BoolVar(&flagConflicts, "flag-conflicts", false, "Tag conflicting events with "+oooConflictTag)
+	cmd.Flags().BoolVar(&skipHolidays, "skip-holidays", false, "Leave public holidays out of the blocks")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview without writing")
+	return cmd
+}
+
+func newOOOListCmd(opts *globalOptions) *cobra.Command {
+	var calendars []string
+	var fromS, toS string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List upcoming out-of-office periods",
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "ooo.list")
+			if err != nil {
+				return err
+			}
+			f, err := buildEventFilterWithTZ(fromS, toS, calendars, 0, ro.TZ)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			items, err := listEventsWithTimeout(ctx, be, f)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			rows := buildOOOPeriods(items)
+			return successWithMeta(ctx, p, ro, rows, map[string]any{"count": len(rows)}, nil)
+		},
+	}
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	cmd.Flags().StringVar(&fromS, "from", "today", "Range start")
+	cmd.Flags().StringVar(&toS, "to", "+90d", "Range end")
+	return cmd
+}
+
+func buildOOOBlocks(from, to time.Time, weekdays []time.Weekday, skip map[string]bool) ([][2]time.Time, error) {
+	start, _ := dayBounds(from)
+	last, _ := dayBounds(to)
+	if last.Before(start) {
+		return nil, fmt.Errorf("--to must not be earlier than --from")
+	}
+	var blocks [][2]time.Time
+	for day := start; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if len(weekdays) > 0 && !containsWeekday(weekdays, day.Weekday()) {
+			continue
+		}
+		if skip[day.Format("2006-01-02")] {
+			continue
+		}
+		next := day.AddDate(0, 0, 1)
+		if n := len(blocks); n > 0 && blocks[n-1][1].Equal(day) {
+			blocks[n-1][1] = next
+			continue
+		}
+		blocks = append(blocks, [2]time.Time{day, next})
+	}
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no days selected between %s and %s", start.Format("2006-01-02"), last.Format("2006-01-02"))
+	}
+	return blocks, nil
+}
+
+func oooConflicts(items []contract.Event, blocks [][2]time.Time) []contract.Event {
+	out := []contract.Event{}
+	for _, e := range items {
+		if e.AllDay || containsString(e.Tags, oooTag) {
+			continue
+		}
+		for _, b := range blocks {
+			if e.Start.Before(b[1]) && e.End.After(b[0]) {
+				out = append(out, e)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func buildOOOPeriods(items []contract.Event) []oooPeriod {
+	rows := []oooPeriod{}
+	for _, e := range items {
+		if !containsString(parseTagsMarker(e.Notes), oooTag) {
+			continue
+		}
+		days := int(e.End.Sub(e.Start).Round(24*time.Hour) / (24 * time.Hour))
+		if days < 1 {
+			days = 1
+		}
+		rows = append(rows, oooPeriod{ID: e.ID, Title: e.Title, CalendarName: e.CalendarName, Start: e.Start, End: e.End, Days: days})
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Start.Before(rows[j].Start) })
+	return rows
+}
+
+func containsWeekday(items []time.Weekday, v time.Weekday) bool {
+	for _, it := range items {
+		if it == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestBuildOOOBlocksMergesConsecutiveWeekdays(t *testing.T) {
+	from := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	wds, err := parseWeekdays("mon,tue,wed,thu,fri")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks, err := buildOOOBlocks(from, to, wds, nil)
+	if err != nil {
+		t.Fatalf("buildOOOBlocks error: %v", err)
+	}
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 blocks around the weekend, got %v", blocks)
+	}
+	if !blocks[0][0].Equal(from) || !blocks[0][1].Equal(time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected first block: %v", blocks[0])
+	}
+	if !blocks[1][0].Equal(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)) || !blocks[1][1].Equal(time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected second block: %v", blocks[1])
+	}
+	if _, err := buildOOOBlocks(to, from, nil, nil); err == nil {
+		t.Fatalf("expected error for reversed range")
+	}
+}
+
+func TestOOOAddAndListWithMockBackend(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	fixture := filepath.Join("testdata", "mock", "events.json")
+
+	root := NewRootCommand()
+	var stdout bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"ooo", "add", "--backend", "mock", "--mock-file", fixture, "--calendar", "Work", "--from", "2026-03-02", "--to", "2026-03-03", "--tz", "UTC", "--dry-run", "--json"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	var env struct {
+		Data struct {
+			Blocks    []json.RawMessage `json:"blocks"`
+			Conflicts []contract.Event  `json:"conflicts"`
+		} `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(env.Data.Blocks) != 1 || env.Data.Conflicts == nil || len(env.Data.Conflicts) != 2 {
+		t.Fatalf("unexpected dry-run result: %s", stdout.String())
+	}
+	if env.Meta["dry_run"] != true || env.Meta["to"] != "2026-03-03" {
+		t.Fatalf("unexpected meta: %#v", env.Meta)
+	}
+
+	root = NewRootCommand()
+	stdout.Reset()
+	root.SetOut(&stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"ooo", "add", "--backend", "mock", "--mock-file", fixture, "--calendar", "Work", "--from", "2026-03-02", "--to", "2026-03-03", "--tz", "UTC", "--flag-conflicts", "--json"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	var written struct {
+		Data oooResult `json:"data"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &written); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(written.Data.Created) != 1 || !written.Data.Created[0].AllDay || len(written.Data.Flagged) != 2 {
+		t.Fatalf("unexpected write result: %s", stdout.String())
+	}
+	if got := written.Data.Created[0].Tags; len(got) != 1 || got[0] != oooTag {
+		t.Fatalf("expected ooo tag on created block, got %v", got)
+	}
+
+	items := []contract.Event{
+		{ID: "a", Title: "Out of office", Start: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), AllDay: true, Notes: "acal:tags=ooo"},
+		{ID: "b", Title: "Standup", Start: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 2, 9, 15, 0, 0, time.UTC)},
+	}
+	rows := buildOOOPeriods(items)
+	if len(rows) != 1 || rows[0].ID != "a" || rows[0].Days != 2 {
+		t.Fatalf("unexpected periods: %+v", rows)
+	}
+}
+
+func TestOOOAddFlagsEventsSpanningIntoWindow(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "release", CalendarID: "work", CalendarName: "Work", Title: "Release night", Start: time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 2, 2, 0, 0, 0, time.UTC)},
+		},
+	})
+	var env struct {
+		Data oooResult `json:"data"`
+	}
+	out := runWithBackend(t, fb, "ooo", "add", "--calendar", "Work", "--from", "2026-03-02", "--to", "2026-03-03", "--tz", "UTC", "--dry-run", "--json")
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(env.Data.Conflicts) != 1 || env.Data.Conflicts[0].ID != "release" {
+		t.Fatalf("expected the event that started before the window to conflict, got %+v", env.Data.Conflicts)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
 	"github.com/agis/acal/internal/timeparse"
 	"github.com/spf13/cobra"
 )
@@ -25,7 +26,7 @@ type slotRow struct {
 
 func newFreebusyCmd(opts *globalOptions) *cobra.Command {
 	var calendars []string
-	var fromS, toS string
+	var fromS, toS, format string
 	var limit int
 	var includeAllDay bool
 	cmd := &cobra.Command{
@@ -36,6 +37,10 @@ func newFreebusyCmd(opts *globalOptions) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			format = strings.ToLower(strings.TrimSpace(format))
+			if format != "" && format != "ics" {
+				return failWithHint(p, contract.ErrInvalidUsage, invalidField("format", format, fmt.Errorf("invalid --format: %s", format)), "Use --format ics, or omit it for the usual output", 2)
+			}
 			f, err := buildEventFilterWithTZ(fromS, toS, calendars, limit, ro.TZ)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
@@ -51,7 +56,17 @@ func newFreebusyCmd(opts *globalOptions) *cobra.Command {
 			for _, b := range blocks {
 				minutes += b.Minutes
 			}
-			return successWithMeta(ctx, p, ro, blocks, map[string]any{"count": len(blocks), "busy_minutes": minutes, "events_scanned": len(items), "include_all_day": includeAllDay}, nil)
+			meta := map[string]any{"count": len(blocks), "busy_minutes": minutes, "events_scanned": len(items), "include_all_day": includeAllDay}
+			if format != "ics" {
+				return successWithMeta(ctx, p, ro, blocks, meta, nil)
+			}
+			ics := buildFreebusyICS(blocks, f.From, f.To, currentTime())
+			// Only an explicit --json/--jsonl wraps the document in an envelope.
+			if p.Mode == output.ModeJSON || p.Mode == output.ModeJSONL {
+				return successWithMeta(ctx, p, ro, map[string]any{"ics": ics, "busy_blocks": len(blocks)}, meta, nil)
+			}
+			_, _ = fmt.Fprint(c.OutOrStdout(), ics)
+			return nil
 		},
 	}
 	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
@@ -59,6 +74,7 @@ func newFreebusyCmd(opts *globalOptions) *cobra.Command {
 	cmd.Flags().StringVar(&toS, "to", "+30d", "Range end")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit events scanned")
 	cmd.Flags().BoolVar(&includeAllDay, "include-all-day", false, "Include all-day events in busy calculation")
+	cmd.Flags().StringVar(&format, "format", "", "Output format: ics for an RFC 5545 VFREEBUSY calendar")
 	return cmd
 }
 
@@ -67,7 +83,9 @@ func newSlotsCmd(opts *globalOptions) *cobra.Command {
 	var fromS, toS, between string
 	var durationS, stepS string
 	var limit int
-	var includeAllDay bool
+	var includeAllDay, skipHolidays, avoidFocus, ignoreQuiet bool
+	var participantsS []string
+	var minFit float64
 	cmd := &cobra.Command{
 		Use:   "slots",
 		Short: "Find available slots in a range",
@@ -80,26 +98,37 @@ func newSlotsCmd(opts *globalOptions) *cobra.Command {
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
 			}
-			dur, err := time.ParseDuration(durationS)
+			dur, err := timeparse.ParseDuration(durationS)
 			if err != nil || dur <= 0 {
 				if err == nil {
 					err = fmt.Errorf("--duration must be positive")
 				}
-				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --duration like 30m or 1h", 2)
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
 			}
-			step, err := time.ParseDuration(stepS)
+			step, err := timeparse.ParseDuration(stepS)
 			if err != nil || step <= 0 {
 				if err == nil {
 					err = fmt.Errorf("--step must be positive")
 				}
-				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --step like 15m or 30m", 2)
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
 			}
 			if step > dur {
 				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("--step must not exceed --duration"), "Set --step <= --duration", 2)
 			}
 			startHour, startMinute, endHour, endMinute, err := parseBetweenRange(between)
 			if err != nil {
-				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --between HH:MM-HH:MM", 2)
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --between HH:MM-HH:MM or 9am-5pm", 2)
+			}
+			people := make([]participant, 0, len(participantsS))
+			for _, v := range participantsS {
+				pt, err := parseParticipant(v)
+				if err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Use --participant tz=Europe/Athens[,name=Ana][,hours=09:00-17:00][,weekends=true]", 2)
+				}
+				people = append(people, pt)
+			}
+			if minFit < 0 || minFit > 1 {
+				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("--min-fit must be between 0 and 1"), "Use e.g. --min-fit 0.5", 2)
 			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
@@ -109,32 +138,65 @@ func newSlotsCmd(opts *globalOptions) *cobra.Command {
 			}
 			blocks := buildBusyBlocks(items, includeAllDay)
 			loc := resolveLocation(ro.TZ)
-			anchorStart, err := timeparse.ParseDateTime(fromS, time.Now(), loc)
-			if err != nil {
-				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from", 2)
+			quiet := quietHoursFor(ro, ignoreQuiet)
+			slots, quietSkipped := freeSlots(blocks, f.From, f.To, startHour, startMinute, endHour, endMinute, dur, step, quiet, loc)
+			meta := map[string]any{"count": len(slots), "duration_minutes": int64(dur.Minutes()), "events_scanned": len(items)}
+			if len(quiet) > 0 {
+				meta["quiet_skipped"] = quietSkipped
 			}
-			anchorEnd, err := timeparse.ParseDateTime(toS, time.Now(), loc)
-			if err != nil {
-				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --to", 2)
+			if skipHolidays {
+				hs, err := loadHolidays(ctx, be, ro, f.From, f.To)
+				if err != nil {
+					return failHolidays(p, err)
+				}
+				slots = excludeHolidaySlots(slots, holidayDates(hs), loc)
+				meta["count"] = len(slots)
+				meta["holidays_skipped"] = len(hs)
 			}
-			if anchorEnd.Before(anchorStart) {
-				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("--to must not be earlier than --from"), "Adjust range", 2)
+			var warnings []contract.Warning
+			if avoidFocus {
+				before := len(slots)
+				slots = excludeFocusSlots(slots, ro.FocusPeriods, loc)
+				meta["count"], meta["focus_skipped"] = len(slots), before-len(slots)
+				if len(ro.FocusPeriods) == 0 {
+					warnings = append(warnings, contract.Warning{Code: contract.WarnFocusPeriodsMissing, Message: "no Focus periods found in [focus] config or macOS Focus schedules"})
+				}
+			}
+			if len(people) > 0 {
+				ranked := rankFairSlots(slots, people, minFit)
+				meta["count"], meta["participants"] = len(ranked), len(people)
+				return successWithMeta(ctx, p, ro, ranked, meta, warnings)
 			}
-			slots := buildSlots(blocks, anchorStart, anchorEnd, startHour, startMinute, endHour, endMinute, dur, step)
-			return successWithMeta(ctx, p, ro, slots, map[string]any{"count": len(slots), "duration_minutes": int64(dur.Minutes()), "events_scanned": len(items)}, nil)
+			return successWithMeta(ctx, p, ro, slots, meta, warnings)
 		},
 	}
 	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
 	cmd.Flags().StringVar(&fromS, "from", "today", "Range start")
 	cmd.Flags().StringVar(&toS, "to", "+14d", "Range end")
-	cmd.Flags().StringVar(&between, "between", "09:00-17:00", "Daily window as HH:MM-HH:MM")
+	cmd.Flags().StringVar(&between, "between", "09:00-17:00", "Daily window as HH:MM-HH:MM or 9am-5pm")
 	cmd.Flags().StringVar(&durationS, "duration", "30m", "Required slot duration")
 	cmd.Flags().StringVar(&stepS, "step", "15m", "Candidate step")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit events scanned")
 	cmd.Flags().BoolVar(&includeAllDay, "include-all-day", false, "Include all-day events as busy")
+	cmd.Flags().BoolVar(&skipHolidays, "skip-holidays", false, "Drop slots that fall on public holidays")
+	cmd.Flags().BoolVar(&ignoreQuiet, "ignore-quiet-hours", false, "Keep slots that overlap quiet_hours")
+	cmd.Flags().BoolVar(&avoidFocus, "avoid-focus", false, "Drop slots that overlap a Focus period ([focus] config or macOS Focus schedules)")
+	cmd.Flags().StringArrayVar(&participantsS, "participant", nil, "Score slots for a participant: tz=<zone>[,name=..][,hours=09:00-17:00][,weekends=true] (repeatable)")
+	cmd.Flags().Float64Var(&minFit, "min-fit", 0, "With --participant: drop slots any participant fits less than this (0-1)")
 	return cmd
 }
 
+func excludeHolidaySlots(slots []slotRow, holidays map[string]bool, loc *time.Location) []slotRow {
+	out := make([]slotRow, 0, len(slots))
+	for _, s := range slots {
+		if holidays[s.Start.In(loc).Format("2006-01-02")] {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
 func buildBusyBlocks(items []contract.Event, includeAllDay bool) []busyBlock {
 	if len(items) == 0 {
 		return nil
@@ -144,6 +206,9 @@ func buildBusyBlocks(items []contract.Event, includeAllDay bool) []busyBlock {
 		if !includeAllDay && it.AllDay {
 			continue
 		}
+		if it.Availability == contract.AvailabilityFree || it.Status == contract.StatusCancelled {
+			continue
+		}
 		if !it.Start.Before(it.End) {
 			continue
 		}
@@ -179,13 +244,13 @@ func buildBusyBlocks(items []contract.Event, includeAllDay bool) []busyBlock {
 func parseBetweenRange(v string) (int, int, int, int, error) {
 	parts := strings.Split(strings.TrimSpace(v), "-")
 	if len(parts) != 2 {
-		return 0, 0, 0, 0, fmt.Errorf("invalid --between: %s", v)
+		return 0, 0, 0, 0, invalidField("between", v, fmt.Errorf("invalid --between: %s", v))
 	}
-	aH, aM, err := parseClock(strings.TrimSpace(parts[0]))
+	aH, aM, err := timeparse.ParseClock(strings.TrimSpace(parts[0]))
 	if err != nil {
 		return 0, 0, 0, 0, err
 	}
-	bH, bM, err := parseClock(strings.TrimSpace(parts[1]))
+	bH, bM, err := timeparse.ParseClock(strings.TrimSpace(parts[1]))
 	if err != nil {
 		return 0, 0, 0, 0, err
 	}
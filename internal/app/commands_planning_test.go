@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +28,19 @@ func TestBuildBusyBlocksMergesRanges(t *testing.T) {
 	}
 }
 
+func TestBuildBusyBlocksSkipsFreeAndCancelled(t *testing.T) {
+	base := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
+	items := []contract.Event{
+		{Start: base, End: base.Add(time.Hour), Availability: contract.AvailabilityFree},
+		{Start: base.Add(2 * time.Hour), End: base.Add(3 * time.Hour), Status: contract.StatusCancelled},
+		{Start: base.Add(4 * time.Hour), End: base.Add(5 * time.Hour), Status: contract.StatusTentative, Availability: contract.AvailabilityBusy},
+	}
+	blocks := buildBusyBlocks(items, false)
+	if len(blocks) != 1 || !blocks[0].Start.Equal(base.Add(4*time.Hour)) {
+		t.Fatalf("expected only the busy tentative event, got %+v", blocks)
+	}
+}
+
 func TestSlotsCommandFindsGaps(t *testing.T) {
 	base := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
 	fb := &scopeCaptureBackend{events: []contract.Event{
@@ -34,7 +48,7 @@ func TestSlotsCommandFindsGaps(t *testing.T) {
 		{ID: "e2", Start: base.Add(60 * time.Minute), End: base.Add(90 * time.Minute)},
 	}}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -67,7 +81,7 @@ func TestFreebusyCommandJSON(t *testing.T) {
 		{ID: "e2", Start: base.Add(30 * time.Minute), End: base.Add(90 * time.Minute)},
 	}}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -91,3 +105,50 @@ func TestFreebusyCommandJSON(t *testing.T) {
 		t.Fatalf("expected 90 merged minutes, got %d", got.Data[0].Minutes)
 	}
 }
+
+func TestParseBetweenRangeAcceptsTwelveHourClock(t *testing.T) {
+	aH, aM, bH, bM, err := parseBetweenRange("9:30am-5pm")
+	if err != nil || aH != 9 || aM != 30 || bH != 17 || bM != 0 {
+		t.Fatalf("parseBetweenRange = %d:%d-%d:%d, %v", aH, aM, bH, bM, err)
+	}
+	if _, _, _, _, err := parseBetweenRange("5pm-9am"); err == nil {
+		t.Fatal("expected end-before-start error")
+	}
+}
+
+func TestFreebusyFormatICS(t *testing.T) {
+	base := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
+	fb := &scopeCaptureBackend{events: []contract.Event{
+		{ID: "e1", Start: base, End: base.Add(45 * time.Minute)},
+		{ID: "e2", Start: base.Add(30 * time.Minute), End: base.Add(90 * time.Minute)},
+		{ID: "e3", Start: base.Add(5 * time.Hour), End: base.Add(6 * time.Hour)},
+	}}
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+
+	cmd := NewRootCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"freebusy", "--from", "2026-02-20", "--to", "2026-02-21", "--tz", "UTC", "--format", "ics", "--now", "2026-02-19T12:00:00Z"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"BEGIN:VFREEBUSY\r\n",
+		"DTSTAMP:20260219T120000Z\r\n",
+		"DTSTART:20260220T000000Z\r\n",
+		"FREEBUSY;FBTYPE=BUSY:20260220T090000Z/20260220T103000Z\r\n",
+		"FREEBUSY;FBTYPE=BUSY:20260220T140000Z/20260220T150000Z\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\"data\"") {
+		t.Fatalf("ics output should not be wrapped in an envelope:\n%s", out)
+	}
+}
@@ -2,9 +2,9 @@ package app
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 
@@ -12,6 +12,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	errQueryExists   = errors.New("query already exists")
+	errQueryNotFound = errors.New("query not found")
+)
+
 type savedQuery struct {
 	Name      string   `json:"name"`
 	From      string   `json:"from"`
@@ -24,11 +29,7 @@ type savedQuery struct {
 }
 
 func queriesFilePath() string {
-	base := defaultUserConfigPath()
-	if strings.TrimSpace(base) == "" {
-		return ""
-	}
-	return filepath.Join(filepath.Dir(base), "queries.json")
+	return statePath("queries.json")
 }
 
 func loadSavedQueries() (map[string]savedQuery, error) {
@@ -58,14 +59,24 @@ func writeSavedQueries(store map[string]savedQuery) error {
 	if path == "" {
 		return nil
 	}
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
-		return err
-	}
 	b, err := json.MarshalIndent(store, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, b, 0o644)
+	return writeFileAtomic(path, b, 0o644)
+}
+
+func updateSavedQueries(fn func(store map[string]savedQuery) error) error {
+	return withStateLock(func() error {
+		store, err := loadSavedQueries()
+		if err != nil {
+			return err
+		}
+		if err := fn(store); err != nil {
+			return err
+		}
+		return writeSavedQueries(store)
+	})
 }
 
 func newQueriesCmd(opts *globalOptions) *cobra.Command {
@@ -88,18 +99,21 @@ func newQueriesCmd(opts *globalOptions) *cobra.Command {
 			if name == "" {
 				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("name is required"), "Provide a preset name", 2)
 			}
-			store, err := loadSavedQueries()
-			if err != nil {
-				return failWithHint(p, contract.ErrGeneric, err, "Check queries file permissions", 1)
-			}
-			if _, exists := store[name]; exists && !overwrite {
-				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("query already exists: %s", name), "Use --overwrite to replace existing query", 2)
+			q := savedQuery{Name: name, From: saveFrom, To: saveTo, Calendars: saveCalendars, Wheres: saveWheres, Sort: saveSort, Order: saveOrder, Limit: saveLimit}
+			err = updateSavedQueries(func(store map[string]savedQuery) error {
+				if _, exists := store[name]; exists && !overwrite {
+					return fmt.Errorf("%w: %s", errQueryExists, name)
+				}
+				store[name] = q
+				return nil
+			})
+			if errors.Is(err, errQueryExists) {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --overwrite to replace existing query", 2)
 			}
-			store[name] = savedQuery{Name: name, From: saveFrom, To: saveTo, Calendars: saveCalendars, Wheres: saveWheres, Sort: saveSort, Order: saveOrder, Limit: saveLimit}
-			if err := writeSavedQueries(store); err != nil {
+			if err != nil {
 				return failWithHint(p, contract.ErrGeneric, err, "Unable to persist query preset", 1)
 			}
-			return p.Success(store[name], map[string]any{"saved": true}, nil)
+			return p.Success(q, map[string]any{"saved": true}, nil)
 		},
 	}
 	save.Flags().StringVar(&saveFrom, "from", "today", "Range start")
@@ -141,16 +155,18 @@ func newQueriesCmd(opts *globalOptions) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			store, err := loadSavedQueries()
-			if err != nil {
-				return failWithHint(p, contract.ErrGeneric, err, "Check queries file permissions", 1)
-			}
 			name := strings.TrimSpace(args[0])
-			if _, ok := store[name]; !ok {
-				return failWithHint(p, contract.ErrNotFound, fmt.Errorf("query not found: %s", name), "Run `acal queries list` to inspect names", 4)
+			err = updateSavedQueries(func(store map[string]savedQuery) error {
+				if _, ok := store[name]; !ok {
+					return fmt.Errorf("%w: %s", errQueryNotFound, name)
+				}
+				delete(store, name)
+				return nil
+			})
+			if errors.Is(err, errQueryNotFound) {
+				return failWithHint(p, contract.ErrNotFound, err, "Run `acal queries list` to inspect names", 4)
 			}
-			delete(store, name)
-			if err := writeSavedQueries(store); err != nil {
+			if err != nil {
 				return failWithHint(p, contract.ErrGeneric, err, "Unable to persist query store", 1)
 			}
 			return p.Success(map[string]any{"deleted": true, "name": name}, map[string]any{"count": 1}, nil)
@@ -180,21 +196,18 @@ func newQueriesCmd(opts *globalOptions) *cobra.Command {
 			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
-			items, err := listEventsWithTimeout(ctx, be, f)
-			if err != nil {
-				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
-			}
 			preds, err := parsePredicates(q.Wheres)
 			if err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Saved query has invalid predicates; re-save it", 2)
 			}
-			items, err = applyPredicates(items, preds)
-			if err != nil {
+			preds = expandPredicatePeople(preds, ro.People)
+			if err := validatePredicates(preds); err != nil {
 				return failWithHint(p, contract.ErrInvalidUsage, err, "Saved query predicates failed; re-save it", 2)
 			}
-			sortEvents(items, q.Sort, q.Order)
-			if q.Limit > 0 && len(items) > q.Limit {
-				items = items[:q.Limit]
+			recordRequestQuery(ctx, preds, q.Sort, q.Order)
+			items, err := runEventQuery(ctx, be, f, preds, q.Sort, q.Order, q.Limit)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
 			}
 			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items), "name": q.Name}, nil)
 		},
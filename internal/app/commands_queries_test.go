@@ -16,10 +16,11 @@ import (
 func TestQueriesSaveListDelete(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
 
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -50,6 +51,7 @@ func TestQueriesSaveListDelete(t *testing.T) {
 func TestQueriesRun(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
 
 	base := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
 	fb := &scopeCaptureBackend{events: []contract.Event{
@@ -57,7 +59,7 @@ func TestQueriesRun(t *testing.T) {
 		{ID: "e2", Title: "Planning", Start: base.Add(24 * time.Hour), End: base.Add(25 * time.Hour)},
 	}}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -90,13 +92,14 @@ func TestQueriesRun(t *testing.T) {
 func TestQuerySaveRunThenBatchDryRunWorkflow(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
 
 	base := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
 	fb := &scopeCaptureBackend{events: []contract.Event{
 		{ID: "e1", Title: "Standup", Start: base, End: base.Add(30 * time.Minute)},
 	}}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
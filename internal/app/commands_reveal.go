@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+var revealApps = []string{"calendar", "fantastical"}
+
+var openURL = func(ctx context.Context, target string) error {
+	out, err := exec.CommandContext(ctx, "open", target).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("open %s: %s", target, msg)
+		}
+		return fmt.Errorf("open %s: %w", target, err)
+	}
+	return nil
+}
+
+type revealResult struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	App    string `json:"app"`
+	URL    string `json:"url"`
+	Opened bool   `json:"opened"`
+}
+
+func revealURL(app string, e contract.Event) string {
+	if app == "fantastical" {
+		return "x-fantastical3://show/calendar/" + e.Start.Format("2006-01-02")
+	}
+	uid, _ := backend.EventUID(e.ID)
+	return fmt.Sprintf("ical://ekevent/%s/%s?method=show&options=more", e.Start.UTC().Format("20060102T150405Z"), url.PathEscape(uid))
+}
+
+func newEventsRevealCmd(opts *globalOptions) *cobra.Command {
+	var app string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "reveal <event-id>",
+		Short: "Open an event in Calendar.app or Fantastical",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, be, ro, err := buildContext(cmd, opts, "events.reveal")
+			if err != nil {
+				return err
+			}
+			app = strings.ToLower(strings.TrimSpace(app))
+			if !containsString(revealApps, app) {
+				err := fmt.Errorf("unknown app %q", app)
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --app calendar|fantastical", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
+			item, err := getEventByRef(ctx, be, id, currentTime())
+			if err != nil {
+				return failWithHint(p, contract.ErrNotFound, withEventIDs(err, id), "Check ID with `acal events list --fields id,title,start`", 4)
+			}
+			e := *item
+			e.Start = e.Start.In(resolveLocation(ro.TZ))
+			res := revealResult{ID: item.ID, Title: item.Title, App: app, URL: revealURL(app, e)}
+			if !dryRun {
+				if err := openURL(ctx, res.URL); err != nil {
+					return failWithHint(p, contract.ErrGeneric, err, "Revealing events needs macOS `open`; use --dry-run to print the URL", 1)
+				}
+				res.Opened = true
+			}
+			return successWithMeta(ctx, p, ro, res, map[string]any{"count": 1}, nil)
+		},
+	}
+	cmd.Flags().StringVar(&app, "app", "calendar", "App to open the event in: calendar|fantastical")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Print the URL without opening it")
+	return cmd
+}
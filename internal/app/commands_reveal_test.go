@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventsRevealOpensAppURL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	start := time.Date(2026, 3, 2, 23, 30, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: "ABC 1@794280600", CalendarName: "Work", Title: "Review", Start: start, End: start.Add(time.Hour)},
+	}})
+	var opened []string
+	prev := openURL
+	openURL = func(_ context.Context, target string) error {
+		opened = append(opened, target)
+		return nil
+	}
+	t.Cleanup(func() { openURL = prev })
+
+	var env struct {
+		Data revealResult `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "reveal", "ABC 1@794280600", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	want := "ical://ekevent/20260302T233000Z/ABC%201?method=show&options=more"
+	if !env.Data.Opened || env.Data.URL != want || len(opened) != 1 || opened[0] != want {
+		t.Fatalf("expected Calendar.app URL %q to open, got %+v %v", want, env.Data, opened)
+	}
+
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "reveal", "ABC 1@794280600", "--app", "Fantastical", "--tz", "Europe/Athens", "--dry-run", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Data.Opened || env.Data.App != "fantastical" || env.Data.URL != "x-fantastical3://show/calendar/2026-03-03" || len(opened) != 1 {
+		t.Fatalf("expected a Fantastical dry run on the local day, got %+v %v", env.Data, opened)
+	}
+}
@@ -0,0 +1,234 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+type roomStatus struct {
+	Room       string      `json:"room"`
+	CalendarID string      `json:"calendar_id"`
+	Free       bool        `json:"free"`
+	Busy       []busyBlock `json:"busy"`
+}
+
+var errNoRooms = errors.New("no rooms configured")
+
+func resolveRooms(ctx context.Context, be backend.Backend, rooms []string) ([]contract.Calendar, []contract.Warning, error) {
+	if len(rooms) == 0 {
+		return nil, nil, errNoRooms
+	}
+	cals, err := listCalendarsWithTimeout(ctx, be)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := []contract.Calendar{}
+	warnings := []contract.Warning{}
+	seen := map[string]bool{}
+	for _, r := range rooms {
+		found := false
+		for _, c := range cals {
+			if namesCalendar([]string{r}, c) {
+				found = true
+				if !seen[c.ID] {
+					seen[c.ID] = true
+					out = append(out, c)
+				}
+				break
+			}
+		}
+		if !found {
+			warnings = append(warnings, contract.Warning{Code: contract.WarnRoomUnmatched, Message: fmt.Sprintf("room %q matches no calendar", r)})
+		}
+	}
+	return out, warnings, nil
+}
+
+func checkRoom(ctx context.Context, be backend.Backend, cal contract.Calendar, start, end time.Time, includeAllDay bool) (roomStatus, error) {
+	items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: start, To: end, Calendars: []string{cal.ID}, Overlap: true})
+	if err != nil {
+		return roomStatus{}, err
+	}
+	st := roomStatus{Room: firstNonEmpty(cal.Name, cal.ID), CalendarID: cal.ID, Busy: []busyBlock{}}
+	for _, b := range buildBusyBlocks(items, includeAllDay) {
+		if b.Start.Before(end) && b.End.After(start) {
+			st.Busy = append(st.Busy, b)
+		}
+	}
+	st.Free = len(st.Busy) == 0
+	return st, nil
+}
+
+func parseRoomWindow(at, durationS string, loc *time.Location) (time.Time, time.Time, error) {
+	if strings.TrimSpace(at) == "" {
+		return time.Time{}, time.Time{}, errors.New("--at is required")
+	}
+	start, err := timeparse.ParseDateTime(at, currentTime(), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --at: %w", err)
+	}
+	dur, err := timeparse.ParseDuration(durationS)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if dur <= 0 {
+		return time.Time{}, time.Time{}, errors.New("--duration must be positive")
+	}
+	return start, start.Add(dur), nil
+}
+
+func failRooms(p output.Printer, err error) error {
+	if errors.Is(err, errNoRooms) {
+		return failWithHint(p, contract.ErrInvalidUsage, err, "List room calendars in config: rooms = [\"Room A\", \"Room B\"] (or ACAL_ROOMS)", 2)
+	}
+	return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+}
+
+func newRoomsCmd(opts *globalOptions) *cobra.Command {
+	rooms := &cobra.Command{Use: "rooms", Short: "Find and book rooms kept as resource calendars"}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List configured rooms and their calendars",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "rooms.list")
+			if err != nil {
+				return err
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			cals, warnings, err := resolveRooms(ctx, be, ro.Rooms)
+			if err != nil {
+				return failRooms(p, err)
+			}
+			return successWithMeta(ctx, p, ro, cals, map[string]any{"count": len(cals)}, warnings)
+		},
+	}
+
+	var at, durationS string
+	var only []string
+	var all, includeAllDay bool
+	free := &cobra.Command{
+		Use:   "free",
+		Short: "List rooms that are free for a time window",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "rooms.free")
+			if err != nil {
+				return err
+			}
+			start, end, err := parseRoomWindow(at, durationS, resolveLocation(ro.TZ))
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, `Use --at "tomorrow 14:00" --duration 1h`, 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			cals, warnings, err := resolveRooms(ctx, be, ro.Rooms)
+			if err != nil {
+				return failRooms(p, err)
+			}
+			rows := []roomStatus{}
+			freeCount := 0
+			for _, cal := range cals {
+				if len(only) > 0 && !namesCalendar(only, cal) {
+					continue
+				}
+				st, err := checkRoom(ctx, be, cal, start, end, includeAllDay)
+				if err != nil {
+					return failRooms(p, err)
+				}
+				if st.Free {
+					freeCount++
+				}
+				if st.Free || all {
+					rows = append(rows, st)
+				}
+			}
+			meta := map[string]any{"count": len(rows), "free": freeCount, "start": start, "end": end}
+			return successWithMeta(ctx, p, ro, rows, meta, warnings)
+		},
+	}
+	free.Flags().StringVar(&at, "at", "", "Start of the window (e.g. \"tomorrow 14:00\")")
+	free.Flags().StringVar(&durationS, "duration", "1h", "Length of the window")
+	free.Flags().StringSliceVar(&only, "room", nil, "Only check these rooms (repeatable)")
+	free.Flags().BoolVar(&all, "all", false, "Include busy rooms with their busy blocks")
+	free.Flags().BoolVar(&includeAllDay, "include-all-day", false, "Count all-day events as busy")
+
+	var bookAt, bookDurationS, title, notes string
+	var force, dryRun, bookAllDay bool
+	book := &cobra.Command{
+		Use:   "book <room>",
+		Short: "Put a hold on a room's calendar if it is free",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			p, be, ro, err := buildContext(c, opts, "rooms.book")
+			if err != nil {
+				return err
+			}
+			start, end, err := parseRoomWindow(bookAt, bookDurationS, resolveLocation(ro.TZ))
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, `Use --at "tomorrow 14:00" --duration 1h`, 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			cals, warnings, err := resolveRooms(ctx, be, ro.Rooms)
+			if err != nil {
+				return failRooms(p, err)
+			}
+			var room *contract.Calendar
+			for i := range cals {
+				if namesCalendar(args, cals[i]) {
+					room = &cals[i]
+					break
+				}
+			}
+			if room == nil {
+				return failWithHint(p, contract.ErrNotFound, fmt.Errorf("not a configured room: %s", args[0]), "See `acal rooms list`; rooms come from the rooms config key", 4)
+			}
+			st, err := checkRoom(ctx, be, *room, start, end, bookAllDay)
+			if err != nil {
+				return failRooms(p, err)
+			}
+			if !st.Free && !force {
+				b := st.Busy[0]
+				err := fmt.Errorf("%s is busy %s–%s", st.Room, b.Start.In(resolveLocation(ro.TZ)).Format("2006-01-02 15:04"), b.End.In(resolveLocation(ro.TZ)).Format("15:04"))
+				return failWithHint(p, contract.ErrConflict, err, "Pick another time or room (`acal rooms free`), or pass --force", 5)
+			}
+			in := backend.EventCreateInput{Calendar: room.ID, Title: firstNonEmpty(strings.TrimSpace(title), "Room hold"), Start: start, End: end, Notes: notes}
+			meta := map[string]any{"room": st.Room, "was_free": st.Free}
+			if dryRun {
+				meta["dry_run"] = true
+				return successWithMeta(ctx, p, ro, in, meta, warnings)
+			}
+			item, err := addEventWithTimeout(ctx, be, in)
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Check that the room calendar is writable", 1)
+			}
+			if item != nil {
+				_ = appendHistory(historyEntry{Type: "add", EventID: item.ID, Created: item})
+			}
+			meta["count"] = 1
+			return successWithMeta(ctx, p, ro, item, meta, warnings)
+		},
+	}
+	book.Flags().StringVar(&bookAt, "at", "", "Start of the hold (e.g. \"tomorrow 14:00\")")
+	book.Flags().StringVar(&bookDurationS, "duration", "1h", "Length of the hold")
+	book.Flags().StringVar(&title, "title", "", "Event title (default Room hold)")
+	book.Flags().StringVar(&notes, "notes", "", "Event notes")
+	book.Flags().BoolVar(&force, "force", false, "Book even if the room is busy")
+	book.Flags().BoolVar(&bookAllDay, "include-all-day", false, "Count all-day events as busy")
+	book.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Check and preview without writing")
+
+	rooms.AddCommand(list, free, book)
+	return rooms
+}
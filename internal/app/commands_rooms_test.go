@@ -0,0 +1,75 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func roomsFixture() *backend.MockBackend {
+	start := time.Date(2026, 3, 3, 14, 30, 0, 0, time.UTC)
+	return backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{
+			{ID: "r1", Name: "Aurora", Writable: true},
+			{ID: "r2", Name: "Borealis", Writable: true},
+			{ID: "work", Name: "Work", Writable: true},
+		},
+		Events: []contract.Event{
+			{ID: "b1", CalendarID: "r1", CalendarName: "Aurora", Title: "Offsite prep", Start: start, End: start.Add(time.Hour)},
+			{ID: "w1", CalendarID: "work", CalendarName: "Work", Title: "Not a room", Start: start, End: start.Add(time.Hour)},
+		},
+	})
+}
+
+func TestRoomsFree(t *testing.T) {
+	t.Setenv("ACAL_ROOMS", "Aurora,Borealis,Missing")
+	fb := roomsFixture()
+	var env struct {
+		Data     []roomStatus   `json:"data"`
+		Meta     map[string]any `json:"meta"`
+		Warnings []string       `json:"warnings"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "rooms", "free", "--at", "2026-03-03T14:00:00Z", "--duration", "1h", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 1 || env.Data[0].Room != "Borealis" || env.Meta["free"] != float64(1) || len(env.Warnings) != 1 {
+		t.Fatalf("unexpected free rooms: %+v", env)
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "rooms", "free", "--at", "2026-03-03T14:00:00Z", "--all", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 2 || env.Data[0].Free || len(env.Data[0].Busy) != 1 {
+		t.Fatalf("expected Aurora listed as busy with --all: %+v", env.Data)
+	}
+}
+
+func TestRoomsBook(t *testing.T) {
+	t.Setenv("ACAL_ROOMS", "Aurora,Borealis")
+	fb := roomsFixture()
+	if code := runEventsCmd(t, fb, "rooms", "book", "aurora", "--at", "2026-03-03T14:00:00Z", "--json"); code != 5 {
+		t.Fatalf("expected conflict exit 5 for a busy room, got %d", code)
+	}
+	if code := runEventsCmd(t, fb, "rooms", "book", "Work", "--at", "2026-03-03T14:00:00Z", "--json"); code != 4 {
+		t.Fatalf("expected exit 4 for a calendar that is not a room, got %d", code)
+	}
+	var env struct {
+		Data contract.Event `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "rooms", "book", "Borealis", "--at", "2026-03-03T14:00:00Z", "--title", "Design sync", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Data.CalendarID != "r2" || env.Data.Title != "Design sync" {
+		t.Fatalf("unexpected hold: %+v", env.Data)
+	}
+	if code := runEventsCmd(t, fb, "rooms", "book", "Borealis", "--at", "2026-03-03T14:30:00Z", "--json"); code != 5 {
+		t.Fatalf("expected the new hold to make Borealis busy, got %d", code)
+	}
+
+	t.Setenv("ACAL_ROOMS", "")
+	if code := runEventsCmd(t, fb, "rooms", "list", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 without configured rooms, got %d", code)
+	}
+}
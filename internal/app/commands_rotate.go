@@ -0,0 +1,194 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+type rotationRow struct {
+	Occurrence  int        `json:"occurrence"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email,omitempty"`
+	WindowStart time.Time  `json:"window_start"`
+	WindowEnd   time.Time  `json:"window_end"`
+	Start       *time.Time `json:"start,omitempty"`
+	End         *time.Time `json:"end,omitempty"`
+	Title       string     `json:"title"`
+	ID          string     `json:"id,omitempty"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+}
+
+func planRotation(names []string, blocks []busyBlock, from time.Time, every time.Duration, n int,
+	startHour, startMinute, endHour, endMinute int, dur, step time.Duration, weekends bool, quiet []focusPeriod, loc *time.Location) []rotationRow {
+	rows := make([]rotationRow, 0, n)
+	for i := 0; i < n; i++ {
+		ws := from.Add(time.Duration(i) * every)
+		we := ws.Add(every)
+		row := rotationRow{Occurrence: i + 1, Name: names[i%len(names)], WindowStart: ws, WindowEnd: we, Status: "no_slot"}
+		slots, _ := freeSlots(blocks, ws, we, startHour, startMinute, endHour, endMinute, dur, step, quiet, loc)
+		for _, s := range slots {
+			if wd := s.Start.Weekday(); !weekends && (wd == time.Saturday || wd == time.Sunday) {
+				continue
+			}
+			start, end := s.Start, s.End
+			row.Start, row.End, row.Status = &start, &end, "planned"
+			blocks = append(blocks, busyBlock{Start: start, End: end, Minutes: s.Minutes})
+			break
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func newRotateCmd(opts *globalOptions) *cobra.Command {
+	var names, busyCalendars []string
+	var calendar, fromS, everyS, durationS, stepS, between, titleS string
+	var count int
+	var dryRun, weekends, includeAllDay, ignoreQuiet bool
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Schedule a rotation of 1:1s, one per period, in the first free slot",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "rotate")
+			if err != nil {
+				return err
+			}
+			people := []string{}
+			for _, n := range names {
+				if n = strings.TrimSpace(n); n != "" {
+					people = append(people, n)
+				}
+			}
+			if len(people) == 0 {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--with is required"), "Pass --with alice,bob,carol", 2)
+			}
+			resolved, err := resolvePeopleNames(people, ro.People)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Add the handle under [people] in config, or pass the name without @", 2)
+			}
+			for i, r := range resolved {
+				people[i] = r.display()
+			}
+			if strings.TrimSpace(calendar) == "" {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--calendar is required"), "Pass --calendar target calendar", 2)
+			}
+			every, err := timeparse.ParseDuration(everyS)
+			if err == nil && every <= 0 {
+				err = errors.New("--every must be positive")
+			}
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			dur, err := timeparse.ParseDuration(durationS)
+			if err == nil && (dur <= 0 || dur > every) {
+				err = errors.New("--duration must be positive and no longer than --every")
+			}
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			step, err := timeparse.ParseDuration(stepS)
+			if err == nil && step <= 0 {
+				err = errors.New("--step must be positive")
+			}
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			startHour, startMinute, endHour, endMinute, err := parseBetweenRange(between)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --between HH:MM-HH:MM or 9am-5pm", 2)
+			}
+			tmpl, err := template.New("title").Option("missingkey=error").Parse(titleS)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("invalid --title: %w", err), "Use e.g. --title \"1:1 with {{.Name}}\"", 2)
+			}
+			if count <= 0 {
+				count = len(people)
+			}
+			loc := resolveLocation(ro.TZ)
+			from, err := timeparse.ParseDateTime(fromS, currentTime(), loc)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from", 2)
+			}
+			until := from.Add(time.Duration(count) * every)
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: from, To: until, Calendars: busyCalendars, Overlap: true})
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			rows := planRotation(people, buildBusyBlocks(items, includeAllDay), from, every, count, startHour, startMinute, endHour, endMinute, dur, step, weekends, quietHoursFor(ro, ignoreQuiet), loc)
+			warnings := []contract.Warning{}
+			for i := range rows {
+				rows[i].Email = resolved[(rows[i].Occurrence-1)%len(resolved)].Email
+				var b strings.Builder
+				if err := tmpl.Execute(&b, map[string]any{"Name": rows[i].Name, "Email": rows[i].Email, "N": rows[i].Occurrence}); err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("invalid --title: %w", err), "Use {{.Name}}, {{.Email}}, and {{.N}} in --title", 2)
+				}
+				rows[i].Title = b.String()
+				if rows[i].Status == "no_slot" {
+					warnings = append(warnings, contract.Warning{Code: contract.WarnNoSlot, Message: fmt.Sprintf("no free %s slot for %s between %s and %s", formatMinutes(int64(dur.Minutes())), rows[i].Name, rows[i].WindowStart.Format("2006-01-02"), rows[i].WindowEnd.Format("2006-01-02"))})
+				}
+			}
+			meta := map[string]any{"count": len(rows), "people": len(people), "events_scanned": len(items)}
+			if dryRun {
+				meta["dry_run"] = true
+				return successWithMeta(ctx, p, ro, rows, meta, warnings)
+			}
+			txID := batchTxID()
+			created, failed := 0, 0
+			for i := range rows {
+				r := &rows[i]
+				if r.Status != "planned" {
+					continue
+				}
+				in := backend.EventCreateInput{Calendar: calendar, Title: r.Title, Start: *r.Start, End: *r.End}
+				if r.Email != "" {
+					in.Notes = "Attendees: " + r.Email
+				}
+				item, err := addEventWithTimeout(ctx, be, in)
+				if err != nil {
+					r.Status, r.Error = "failed", err.Error()
+					failed++
+					continue
+				}
+				r.Status = "created"
+				if item != nil {
+					r.ID = item.ID
+					_ = appendHistory(historyEntry{Type: "add", TxID: txID, OpID: batchOpID(r.Occurrence, "add"), EventID: item.ID, Created: item})
+				}
+				created++
+			}
+			meta["created"], meta["failed"], meta["tx_id"] = created, failed, txID
+			if failed > 0 {
+				_ = successWithMeta(ctx, p, ro, rows, meta, warnings)
+				return WrapPrinted(1, fmt.Errorf("%d of %d rotation events failed", failed, created+failed))
+			}
+			return successWithMeta(ctx, p, ro, rows, meta, warnings)
+		},
+	}
+	cmd.Flags().StringSliceVar(&names, "with", nil, "People to rotate through, in order (names or @handles; comma-separated or repeatable)")
+	cmd.Flags().StringVar(&calendar, "calendar", "", "Calendar to create the 1:1s in")
+	cmd.Flags().StringSliceVar(&busyCalendars, "busy-calendar", nil, "Calendars that count as busy (default all)")
+	cmd.Flags().StringVar(&fromS, "from", "today", "Start of the first period")
+	cmd.Flags().StringVar(&everyS, "every", "1w", "Period length; one 1:1 per period")
+	cmd.Flags().IntVar(&count, "count", 0, "Number of 1:1s (default one round through --with)")
+	cmd.Flags().StringVar(&durationS, "duration", "30m", "Meeting length")
+	cmd.Flags().StringVar(&stepS, "step", "15m", "Candidate step within a period")
+	cmd.Flags().StringVar(&between, "between", "09:00-17:00", "Daily window as HH:MM-HH:MM or 9am-5pm")
+	cmd.Flags().StringVar(&titleS, "title", "1:1 with {{.Name}}", "Event title template ({{.Name}}, {{.Email}}, {{.N}})")
+	cmd.Flags().BoolVar(&weekends, "weekends", false, "Allow slots on Saturdays and Sundays")
+	cmd.Flags().BoolVar(&includeAllDay, "include-all-day", false, "Include all-day events as busy")
+	cmd.Flags().BoolVar(&ignoreQuiet, "ignore-quiet-hours", false, "Allow slots that overlap quiet_hours")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview the plan without creating events")
+	return cmd
+}
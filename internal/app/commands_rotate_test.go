@@ -0,0 +1,61 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestRotateSchedulesChain(t *testing.T) {
+	busy := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events:    []contract.Event{{ID: "e1", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: busy, End: busy.Add(time.Hour)}},
+	})
+	var env struct {
+		Data     []rotationRow  `json:"data"`
+		Meta     map[string]any `json:"meta"`
+		Warnings []string       `json:"warnings"`
+	}
+	args := []string{"rotate", "--with", "alice,bob,carol", "--every", "1d", "--count", "4", "--duration", "30m",
+		"--from", "2026-03-02T00:00:00Z", "--calendar", "Work", "--tz", "UTC", "--json"}
+	if err := json.Unmarshal(runWithBackend(t, fb, append(args, "--dry-run")...), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 4 || env.Meta["dry_run"] != true {
+		t.Fatalf("unexpected plan: %+v", env)
+	}
+	first := env.Data[0]
+	if first.Name != "alice" || first.Status != "planned" || first.Start.Hour() != 10 || first.Title != "1:1 with alice" {
+		t.Fatalf("expected alice after the standup, got %+v", first)
+	}
+	if env.Data[3].Name != "alice" || env.Data[3].Start.Day() != 5 {
+		t.Fatalf("expected the rotation to wrap back to alice on day 4, got %+v", env.Data[3])
+	}
+	if evs, _ := fb.ListEvents(t.Context(), backend.EventFilter{From: busy.Add(-time.Hour), To: busy.AddDate(0, 0, 5)}); len(evs) != 1 {
+		t.Fatalf("dry run must not create events, got %d", len(evs))
+	}
+
+	if err := json.Unmarshal(runWithBackend(t, fb, args...), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Meta["created"] != float64(4) || env.Data[1].ID == "" || env.Data[1].Status != "created" {
+		t.Fatalf("expected four created 1:1s: %+v", env)
+	}
+
+	// Friday then Saturday: weekends are skipped unless --weekends.
+	weekend := []string{"rotate", "--with", "dana", "--every", "1d", "--count", "2", "--from", "2026-03-06T00:00:00Z",
+		"--calendar", "Work", "--tz", "UTC", "--title", "Sync {{.N}} with {{.Name}}", "--dry-run", "--json"}
+	if err := json.Unmarshal(runWithBackend(t, fb, weekend...), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Data[0].Title != "Sync 1 with dana" || env.Data[1].Status != "no_slot" || len(env.Warnings) != 1 {
+		t.Fatalf("expected Saturday to have no slot: %+v", env)
+	}
+	if code := runEventsCmd(t, fb, "rotate", "--calendar", "Work", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 without --with, got %d", code)
+	}
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+func newEventsRSVPCmd(opts *globalOptions) *cobra.Command {
+	var scope, comment, as string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "rsvp <event-id> accept|decline|tentative",
+		Short: "Answer an invitation by setting your participation status",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, be, ro, err := buildContext(cmd, opts, "events.rsvp")
+			if err != nil {
+				return err
+			}
+			response := strings.ToLower(strings.TrimSpace(args[1]))
+			if _, ok := backend.RSVPResponses[response]; !ok {
+				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("invalid response %q", args[1]), "Use accept, decline, or tentative", 2)
+			}
+			recScope, err := parseRecurrenceScope(scope)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --scope auto|this|series", 2)
+			}
+			attendee := strings.TrimSpace(as)
+			if strings.HasPrefix(attendee, "@") {
+				who, ok := lookupPerson(ro.People, attendee)
+				if !ok || who.Email == "" {
+					err = fmt.Errorf("unknown person %s: known handles are %s", attendee, knownHandles(ro.People))
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Add the handle with an email under [people] in config", 2)
+				}
+				attendee = who.Email
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
+			in := backend.RSVPInput{Response: response, Comment: comment, Attendee: attendee, Scope: recScope}
+			meta := map[string]any{"count": 1, "response": response}
+			if dryRun {
+				item, err := getEventByIDWithTimeout(ctx, be, id)
+				if err != nil {
+					return failWithHint(p, contract.ErrNotFound, err, "Check ID with `acal events list --fields id,title,start`", 4)
+				}
+				meta["dry_run"] = true
+				return successWithMeta(ctx, p, ro, backend.RSVPResult{Event: *item, Attendee: attendee, Response: response, Comment: comment}, meta, nil)
+			}
+			res, err := respondToEventWithTimeout(ctx, be, id, in)
+			switch {
+			case errors.Is(err, backend.ErrRSVPUnsupported):
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "EventKit and Calendar.app scripting cannot answer invitations; use the caldav backend or Calendar.app", 6)
+			case errors.Is(err, backend.ErrNotInvited):
+				return failWithHint(p, contract.ErrNotFound, err, "Pass --as with the address the invitation was sent to", 4)
+			case err != nil:
+				return failWithHint(p, contract.ErrGeneric, err, "RSVP failed", 1)
+			}
+			return successWithMeta(ctx, p, ro, res, meta, nil)
+		},
+	}
+	cmd.Flags().StringVar(&comment, "comment", "", "Note sent with the reply, where the server supports it")
+	cmd.Flags().StringVar(&as, "as", "", "Address (or @handle) to answer as; defaults to the account's address")
+	cmd.Flags().StringVar(&scope, "scope", "auto", "Recurrence scope: auto|this|series")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview without writing")
+	return cmd
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventsRSVP(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fb := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: "inv", CalendarID: "work", CalendarName: "Work", Title: "Planning", Start: time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 3, 11, 0, 0, 0, time.UTC)},
+	}})
+
+	var first struct {
+		Data backend.RSVPResult `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "rsvp", "inv", "Accept", "--comment", "see you", "--json"), &first); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if first.Data.Response != "accept" || first.Data.Previous != "needs-action" || first.Data.Comment != "see you" || first.Data.Event.Title != "Planning" {
+		t.Fatalf("unexpected rsvp result: %+v", first.Data)
+	}
+	var second struct {
+		Data backend.RSVPResult `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "rsvp", "inv", "decline", "--json"), &second); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if second.Data.Previous != "accepted" {
+		t.Fatalf("expected the earlier answer as previous, got %+v", second.Data)
+	}
+
+	if code := runEventsCmd(t, fb, "events", "rsvp", "inv", "maybe"); code != 2 {
+		t.Fatalf("expected exit 2 for an unknown response, got %d", code)
+	}
+	if code := runEventsCmd(t, &scopeCaptureBackend{}, "events", "rsvp", "evt", "accept"); code != 6 {
+		t.Fatalf("expected exit 6 for a backend without RSVP support, got %d", code)
+	}
+}
@@ -0,0 +1,322 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+	"github.com/spf13/cobra"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var supportedSchemaVersions = []string{contract.SchemaVersion}
+
+var schemaTypes = map[string]reflect.Type{
+	"audit_finding":       reflect.TypeOf(auditFinding{}),
+	"availability_page":   reflect.TypeOf(availabilityPage{}),
+	"backup_summary":      reflect.TypeOf(backupSummary{}),
+	"busy_block":          reflect.TypeOf(busyBlock{}),
+	"calendar":            reflect.TypeOf(contract.Calendar{}),
+	"command_description": reflect.TypeOf(commandDescription{}),
+	"compare_row":         reflect.TypeOf(compareRow{}),
+	"conflict":            reflect.TypeOf(conflictRow{}),
+	"daemon_status":       reflect.TypeOf(daemonStatus{}),
+	"day_summary":         reflect.TypeOf(daySummary{}),
+	"deleted_event":       reflect.TypeOf(backend.DeletedEvent{}),
+	"digest":              reflect.TypeOf(digest{}),
+	"doctor_check":        reflect.TypeOf(contract.DoctorCheck{}),
+	"dry_run_preview":     reflect.TypeOf(dryRunPreview{}),
+	"error_code":          reflect.TypeOf(contract.ErrorCodeInfo{}),
+	"event":               reflect.TypeOf(contract.Event{}),
+	"event_context":       reflect.TypeOf(eventContext{}),
+	"fair_slot":           reflect.TypeOf(fairSlot{}),
+	"focus_day":           reflect.TypeOf(focusDay{}),
+	"holiday":             reflect.TypeOf(holiday{}),
+	"lint_violation":      reflect.TypeOf(lintViolation{}),
+	"mirror_action":       reflect.TypeOf(mirrorAction{}),
+	"month_grid":          reflect.TypeOf(monthGrid{}),
+	"notes_scaffold":      reflect.TypeOf(notesScaffold{}),
+	"ooo_period":          reflect.TypeOf(oooPeriod{}),
+	"recurring_conflict":  reflect.TypeOf(recurringConflictRow{}),
+	"restore_row":         reflect.TypeOf(restoreRow{}),
+	"reveal_result":       reflect.TypeOf(revealResult{}),
+	"room_status":         reflect.TypeOf(roomStatus{}),
+	"rotation_row":        reflect.TypeOf(rotationRow{}),
+	"rsvp":                reflect.TypeOf(backend.RSVPResult{}),
+	"saved_query":         reflect.TypeOf(savedQuery{}),
+	"search_hit":          reflect.TypeOf(searchHit{}),
+	"selftest_check":      reflect.TypeOf(selftestCheck{}),
+	"series":              reflect.TypeOf(backend.Series{}),
+	"slot":                reflect.TypeOf(slotRow{}),
+	"state_file":          reflect.TypeOf(stateFile{}),
+	"time_parse":          reflect.TypeOf(timeParseResult{}),
+	"timeline_day":        reflect.TypeOf(timelineDay{}),
+	"trash_entry":         reflect.TypeOf(trashEntry{}),
+}
+
+type schemaCommandData struct {
+	Type string
+	List bool
+}
+
+var schemaCommands = map[string]schemaCommandData{
+	"agenda":                {Type: "event", List: true},
+	"availability.publish":  {Type: "availability_page"},
+	"backup":                {Type: "backup_summary"},
+	"calendars.list":        {Type: "calendar", List: true},
+	"compare":               {Type: "compare_row", List: true},
+	"daemon":                {Type: "daemon_status"},
+	"daemon.status":         {Type: "daemon_status"},
+	"describe":              {Type: "command_description", List: true},
+	"digest":                {Type: "digest"},
+	"doctor":                {Type: "doctor_check", List: true},
+	"errors":                {Type: "error_code", List: true},
+	"events.add":            {Type: "event"},
+	"events.audit":          {Type: "audit_finding", List: true},
+	"events.conflicts":      {Type: "conflict", List: true},
+	"events.copy":           {Type: "event"},
+	"events.deleted":        {Type: "deleted_event", List: true},
+	"events.extend":         {Type: "event"},
+	"events.from-email":     {Type: "event", List: true},
+	"events.list":           {Type: "event", List: true},
+	"events.merge":          {Type: "event"},
+	"events.mine":           {Type: "event", List: true},
+	"events.mirror":         {Type: "mirror_action", List: true},
+	"events.move":           {Type: "event"},
+	"events.notes-template": {Type: "notes_scaffold"},
+	"events.query":          {Type: "event", List: true},
+	"events.restore":        {Type: "event"},
+	"events.reveal":         {Type: "reveal_result"},
+	"events.rsvp":           {Type: "rsvp"},
+	"events.search":         {Type: "event", List: true},
+	"events.series":         {Type: "series"},
+	"events.shorten":        {Type: "event"},
+	"events.show":           {Type: "event"},
+	"events.split":          {Type: "event", List: true},
+	"events.tag":            {Type: "event"},
+	"events.trash":          {Type: "trash_entry", List: true},
+	"events.update":         {Type: "event"},
+	"freebusy":              {Type: "busy_block", List: true},
+	"holidays.list":         {Type: "holiday", List: true},
+	"lint":                  {Type: "lint_violation", List: true},
+	"month":                 {Type: "event", List: true},
+	"next":                  {Type: "event"},
+	"ooo.list":              {Type: "ooo_period", List: true},
+	"queries.list":          {Type: "saved_query", List: true},
+	"queries.run":           {Type: "event", List: true},
+	"restore":               {Type: "restore_row", List: true},
+	"rooms.book":            {Type: "event"},
+	"rooms.free":            {Type: "room_status", List: true},
+	"rooms.list":            {Type: "calendar", List: true},
+	"rotate":                {Type: "rotation_row", List: true},
+	"selftest":              {Type: "selftest_check", List: true},
+	"slots":                 {Type: "slot", List: true},
+	"state.clear":           {Type: "state_file", List: true},
+	"state.path":            {Type: "state_file", List: true},
+	"stats":                 {Type: "focus_day", List: true},
+	"time.parse":            {Type: "time_parse"},
+	"today":                 {Type: "event", List: true},
+	"upcoming":              {Type: "event", List: true},
+	"week":                  {Type: "event", List: true},
+}
+
+func newSchemaCmd(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema [type|command]",
+		Short: "Print JSON Schema for output envelopes and data types",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, _, ro, err := buildContext(cmd, opts, "schema")
+			if err != nil {
+				return err
+			}
+			if !containsString(supportedSchemaVersions, ro.SchemaVersion) {
+				err = fmt.Errorf("unsupported schema version: %s", ro.SchemaVersion)
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --schema-version "+strings.Join(supportedSchemaVersions, "|"), 2)
+			}
+			name := ""
+			if len(args) == 1 {
+				name = strings.TrimSpace(args[0])
+			}
+			doc, err := buildSchemaDocument(name, ro.SchemaVersion)
+			if err != nil {
+				return failWithHint(p, contract.ErrNotFound, err, "Run `acal schema` to list available types and commands", 4)
+			}
+			if p.EffectiveSuccessMode() == output.ModePlain {
+				b, err := json.MarshalIndent(doc, "", "  ")
+				if err != nil {
+					return failWithHint(p, contract.ErrGeneric, err, "Unable to render schema", 1)
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(b))
+				return nil
+			}
+			return p.Success(doc, map[string]any{"schema_version": ro.SchemaVersion, "name": name}, nil)
+		},
+	}
+}
+
+func buildSchemaDocument(name, version string) (map[string]any, error) {
+	if name == "" {
+		types := make(map[string]any, len(schemaTypes))
+		for k, t := range schemaTypes {
+			types[k] = jsonSchemaForType(t)
+		}
+		commands := make(map[string]any, len(schemaCommands))
+		for k := range schemaCommands {
+			commands[k] = envelopeSchema(k)
+		}
+		return map[string]any{
+			"$schema":  jsonSchemaDraft,
+			"$id":      schemaID(version, "index"),
+			"title":    "acal " + version + " schemas",
+			"version":  version,
+			"types":    types,
+			"commands": commands,
+			"envelope": envelopeSchema(""),
+			"error":    withSchemaHeader(jsonSchemaForType(reflect.TypeOf(contract.ErrorEnvelope{})), version, "error", "acal error envelope"),
+		}, nil
+	}
+	switch name {
+	case "envelope":
+		return withSchemaHeader(envelopeSchema(""), version, name, "acal success envelope"), nil
+	case "error":
+		return withSchemaHeader(jsonSchemaForType(reflect.TypeOf(contract.ErrorEnvelope{})), version, name, "acal error envelope"), nil
+	}
+	if t, ok := schemaTypes[name]; ok {
+		return withSchemaHeader(jsonSchemaForType(t), version, name, name), nil
+	}
+	if _, ok := schemaCommands[name]; ok {
+		return withSchemaHeader(envelopeSchema(name), version, name, name+" envelope"), nil
+	}
+	return nil, fmt.Errorf("unknown schema: %s (known: %s)", name, strings.Join(schemaNames(), ", "))
+}
+
+func envelopeSchema(command string) map[string]any {
+	s := jsonSchemaForType(reflect.TypeOf(contract.SuccessEnvelope{}))
+	if command == "" {
+		return s
+	}
+	props := s["properties"].(map[string]any)
+	props["command"] = map[string]any{"type": "string", "const": command}
+	spec := schemaCommands[command]
+	data := jsonSchemaForType(schemaTypes[spec.Type])
+	if spec.List {
+		data = map[string]any{"type": []string{"array", "null"}, "items": data}
+	}
+	props["data"] = data
+	return s
+}
+
+func withSchemaHeader(s map[string]any, version, name, title string) map[string]any {
+	out := map[string]any{
+		"$schema": jsonSchemaDraft,
+		"$id":     schemaID(version, name),
+		"title":   title,
+	}
+	for k, v := range s {
+		out[k] = v
+	}
+	return out
+}
+
+func schemaID(version, name string) string {
+	return fmt.Sprintf("https://github.com/agisilaos/acal/schema/%s/%s.json", version, name)
+}
+
+func schemaNames() []string {
+	names := []string{"envelope", "error"}
+	for k := range schemaTypes {
+		names = append(names, k)
+	}
+	for k := range schemaCommands {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func jsonSchemaForType(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return jsonSchemaForType(t.Elem())
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": []string{"array", "null"}, "items": jsonSchemaForType(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": []string{"object", "null"}, "additionalProperties": jsonSchemaForType(t.Elem())}
+	case reflect.Interface:
+		return map[string]any{}
+	case reflect.Struct:
+		props := map[string]any{}
+		required := make([]string, 0, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, omitEmpty, skip := jsonFieldName(f)
+			if skip {
+				continue
+			}
+			props[name] = jsonSchemaForType(f.Type)
+			if !omitEmpty {
+				required = append(required, name)
+			}
+		}
+		s := map[string]any{"type": "object", "properties": props, "additionalProperties": false}
+		if len(required) > 0 {
+			s["required"] = required
+		}
+		return s
+	default:
+		return map[string]any{}
+	}
+}
+
+func jsonFieldName(f reflect.StructField) (string, bool, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = f.Name
+	}
+	omitEmpty := false
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+func containsString(items []string, v string) bool {
+	for _, it := range items {
+		if it == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+func TestJSONSchemaForEventType(t *testing.T) {
+	s := jsonSchemaForType(schemaTypes["event"])
+	props, ok := s["properties"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected properties map, got %#v", s["properties"])
+	}
+	start, ok := props["start"].(map[string]any)
+	if !ok || start["format"] != "date-time" {
+		t.Fatalf("expected start to be date-time, got %#v", props["start"])
+	}
+	if got := props["sequence"].(map[string]any)["type"]; got != "integer" {
+		t.Fatalf("expected sequence integer, got %v", got)
+	}
+	required, _ := s["required"].([]string)
+	if !containsString(required, "id") || !containsString(required, "calendar_id") {
+		t.Fatalf("expected id and calendar_id required, got %v", required)
+	}
+}
+
+func TestSchemaCommandEnvelopeForEventsList(t *testing.T) {
+	cmd := NewRootCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"schema", "events.list", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	var got struct {
+		Data struct {
+			Schema     string `json:"$schema"`
+			Properties struct {
+				Command map[string]any `json:"command"`
+				Data    struct {
+					Items struct {
+						Properties map[string]any `json:"properties"`
+					} `json:"items"`
+				} `json:"data"`
+			} `json:"properties"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if got.Data.Schema != jsonSchemaDraft {
+		t.Fatalf("unexpected $schema: %q", got.Data.Schema)
+	}
+	if got.Data.Properties.Command["const"] != "events.list" {
+		t.Fatalf("expected command const, got %#v", got.Data.Properties.Command)
+	}
+	if _, ok := got.Data.Properties.Data.Items.Properties["title"]; !ok {
+		t.Fatalf("expected event item properties, got %#v", got.Data.Properties.Data.Items.Properties)
+	}
+}
+
+func TestSchemaCommandRejectsUnknownVersionAndName(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "unknown version", args: []string{"schema", "--schema-version", "v9", "--json"}, want: 2},
+		{name: "unknown name", args: []string{"schema", "nope", "--json"}, want: 4},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewRootCommand()
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tc.args)
+			if got := ExitCode(cmd.Execute()); got != tc.want {
+				t.Fatalf("exit code mismatch: got=%d want=%d", got, tc.want)
+			}
+		})
+	}
+}
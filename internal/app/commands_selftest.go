@@ -0,0 +1,213 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+type selftestCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Cases   int    `json:"cases"`
+	Message string `json:"message,omitempty"`
+}
+
+var selftestNow = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+func selftestBackend() *backend.MockBackend {
+	return backend.NewMockBackend(backend.MockFixture{Calendars: []contract.Calendar{
+		{ID: "work", Name: "Work", Writable: true},
+		{ID: "import", Name: "Import", Writable: true},
+	}})
+}
+
+func runSelftestCheck(name string, fn func() (int, error)) (out selftestCheck) {
+	out = selftestCheck{Name: name, Status: "pass"}
+	defer func() {
+		if r := recover(); r != nil {
+			out.Status, out.Message = "fail", fmt.Sprintf("panic: %v", r)
+		}
+	}()
+	n, err := fn()
+	out.Cases = n
+	if err != nil {
+		out.Status, out.Message = "fail", err.Error()
+	}
+	return out
+}
+
+func selftestICSRoundTrip(ctx context.Context) (int, error) {
+	be := selftestBackend()
+	day := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	inputs := []backend.EventCreateInput{
+		{Calendar: "Work", Title: "Planning", Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)},
+		{Calendar: "Work", Title: "Review; Q1, draft", Start: day.Add(13 * time.Hour), End: day.Add(14*time.Hour + 30*time.Minute), Location: "Room 4, Floor 2", Notes: "Line one\nLine two \\ backslash", URL: "https://example.com/a?b=c;d", Status: contract.StatusTentative},
+		{Calendar: "Work", Title: "Offsite", Start: day.AddDate(0, 0, 1), End: day.AddDate(0, 0, 3), AllDay: true},
+		{Calendar: "Work", Title: "Cancelled sync", Start: day.Add(16 * time.Hour), End: day.Add(16*time.Hour + 15*time.Minute), Status: contract.StatusCancelled},
+	}
+	for _, in := range inputs {
+		if _, err := be.AddEvent(ctx, in); err != nil {
+			return 0, fmt.Errorf("seed: %w", err)
+		}
+	}
+	filter := backend.EventFilter{From: day.AddDate(0, 0, -1), To: day.AddDate(0, 0, 7), Calendars: []string{"Work"}}
+	exported, err := be.ListEvents(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	parsed, warnings := parseICS(buildICS(exported), "Import", time.UTC)
+	if len(warnings) > 0 {
+		return len(inputs), fmt.Errorf("import warnings: %s", strings.Join(warnings, "; "))
+	}
+	for _, in := range parsed {
+		if _, err := be.AddEvent(ctx, in); err != nil {
+			return len(inputs), fmt.Errorf("re-import: %w", err)
+		}
+	}
+	filter.Calendars = []string{"Import"}
+	imported, err := be.ListEvents(ctx, filter)
+	if err != nil {
+		return len(inputs), err
+	}
+	if len(imported) != len(exported) {
+		return len(inputs), fmt.Errorf("exported %d event(s), imported %d", len(exported), len(imported))
+	}
+	for i := range exported {
+		a, b := exported[i], imported[i]
+		if a.Title != b.Title || !a.Start.Equal(b.Start) || !a.End.Equal(b.End) || a.AllDay != b.AllDay ||
+			a.Location != b.Location || a.Notes != b.Notes || a.URL != b.URL || a.Status != b.Status {
+			return len(inputs), fmt.Errorf("event %q changed in round trip: %+v vs %+v", a.Title, a, b)
+		}
+	}
+	return len(inputs), nil
+}
+
+func selftestQuickAddEquivalence(ctx context.Context) (int, error) {
+	cases := []struct {
+		text, start, duration string
+		allDay                bool
+	}{
+		{text: "tomorrow 10:00 Standup @Work 30m", start: "tomorrow 10:00", duration: "30m"},
+		{text: "2026-03-05 3pm Review @Work 90 minutes", start: "2026-03-05 15:00", duration: "90m"},
+		{text: "2026-03-06 Offsite @Work", start: "2026-03-06", duration: "24h", allDay: true},
+	}
+	be := selftestBackend()
+	for _, tc := range cases {
+		quick, err := parseQuickAddInput(tc.text, selftestNow, time.UTC, "", time.Hour, tc.allDay)
+		if err != nil {
+			return len(cases), fmt.Errorf("quick-add %q: %w", tc.text, err)
+		}
+		start, err := timeparse.ParseDateTime(tc.start, selftestNow, time.UTC)
+		if err != nil {
+			return len(cases), fmt.Errorf("--start %q: %w", tc.start, err)
+		}
+		end, err := resolveEnd("", tc.duration, start, time.UTC)
+		if err != nil {
+			return len(cases), fmt.Errorf("--duration %q: %w", tc.duration, err)
+		}
+		viaQuick, err := be.AddEvent(ctx, quick)
+		if err != nil {
+			return len(cases), err
+		}
+		viaAdd, err := be.AddEvent(ctx, backend.EventCreateInput{Calendar: "Work", Title: quick.Title, Start: start, End: end, AllDay: tc.allDay})
+		if err != nil {
+			return len(cases), err
+		}
+		if !viaQuick.Start.Equal(viaAdd.Start) || !viaQuick.End.Equal(viaAdd.End) || viaQuick.AllDay != viaAdd.AllDay || viaQuick.CalendarID != viaAdd.CalendarID {
+			return len(cases), fmt.Errorf("quick-add %q differs from events add: %s-%s vs %s-%s", tc.text, viaQuick.Start, viaQuick.End, viaAdd.Start, viaAdd.End)
+		}
+	}
+	return len(cases), nil
+}
+
+func selftestPredicateFuzz(seed int64, n int) (int, error) {
+	r := rand.New(rand.NewSource(seed))
+	fields := []string{"title", "calendar", "location", "notes", "status", "availability", "sensitivity", "tag", "start", "end"}
+	ops := []string{"==", "!=", "~", ">=", "<=", ">", "<"}
+	values := []string{"Standup", "standup", "1:1", "confirmed", "free", "2026-03-03T09:00:00Z", "today", "x y", "\"quoted\"", "Ünïcode"}
+	junk := []rune("=!~<>\" \t:;,\\abcXYZ09")
+	e := contract.Event{Title: "Standup", CalendarName: "Work", Start: selftestNow, End: selftestNow.Add(time.Hour), Status: contract.StatusConfirmed, Notes: "acal:tags=ops"}
+	for i := 0; i < n; i++ {
+		field, op, value := fields[r.Intn(len(fields))], ops[r.Intn(len(ops))], values[r.Intn(len(values))]
+		clause := field + op + value
+		if i%4 == 3 {
+			// Mangle a well-formed clause into arbitrary noise.
+			b := []rune(clause)
+			for j := r.Intn(4) + 1; j > 0; j-- {
+				b[r.Intn(len(b))] = junk[r.Intn(len(junk))]
+			}
+			clause = string(b)
+		}
+		preds, err := parsePredicates([]string{clause})
+		if err != nil {
+			if i%4 != 3 {
+				return i + 1, fmt.Errorf("well-formed clause %q rejected: %v", clause, err)
+			}
+			continue
+		}
+		ok, matchErr := matchesAll(e, preds)
+		if i%4 == 3 || matchErr != nil {
+			continue
+		}
+		if field == "title" && op == "==" && ok != strings.EqualFold(e.Title, strings.Trim(value, "\"")) {
+			return i + 1, fmt.Errorf("clause %q matched=%v, want %v", clause, ok, !ok)
+		}
+	}
+	for _, bad := range []string{"title", "==x", "title==", "  "} {
+		if preds, err := parsePredicates([]string{bad}); err == nil && len(preds) > 0 {
+			return n, fmt.Errorf("malformed clause %q accepted", bad)
+		}
+	}
+	return n, nil
+}
+
+func newSelftestCmd(opts *globalOptions) *cobra.Command {
+	var seed int64
+	var fuzzCases int
+	cmd := &cobra.Command{
+		Use:   "selftest",
+		Short: "Run built-in round-trip checks against an in-memory backend",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, _, ro, err := buildContext(c, opts, "selftest")
+			if err != nil {
+				return err
+			}
+			if fuzzCases <= 0 {
+				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("--fuzz-cases must be positive"), "Use --fuzz-cases 500", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			checks := []selftestCheck{
+				runSelftestCheck("ics_roundtrip", func() (int, error) { return selftestICSRoundTrip(ctx) }),
+				runSelftestCheck("quickadd_equivalence", func() (int, error) { return selftestQuickAddEquivalence(ctx) }),
+				runSelftestCheck("predicate_fuzz", func() (int, error) { return selftestPredicateFuzz(seed, fuzzCases) }),
+			}
+			failed := 0
+			for _, ch := range checks {
+				if ch.Status != "pass" {
+					failed++
+				}
+			}
+			meta := map[string]any{"count": len(checks), "failed": failed, "seed": seed}
+			if err := successWithMeta(ctx, p, ro, checks, meta, nil); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return Wrap(1, fmt.Errorf("%d selftest check(s) failed", failed))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Int64Var(&seed, "seed", 1, "Seed for generated fuzz cases")
+	cmd.Flags().IntVar(&fuzzCases, "fuzz-cases", 500, "Number of generated --where clauses")
+	return cmd
+}
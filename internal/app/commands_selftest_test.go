@@ -0,0 +1,40 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSelftestCommandPasses(t *testing.T) {
+	var env struct {
+		Data []selftestCheck `json:"data"`
+		Meta map[string]any  `json:"meta"`
+	}
+	out := runWithBackend(t, selftestBackend(), "selftest", "--seed", "7", "--fuzz-cases", "200", "--json")
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(env.Data) != 3 || env.Meta["failed"] != float64(0) {
+		t.Fatalf("unexpected report: %+v %+v", env.Data, env.Meta)
+	}
+	for _, ch := range env.Data {
+		if ch.Status != "pass" || ch.Cases == 0 {
+			t.Fatalf("check %s did not pass: %+v", ch.Name, ch)
+		}
+	}
+}
+
+func TestRunSelftestCheckReportsPanics(t *testing.T) {
+	got := runSelftestCheck("boom", func() (int, error) { panic("bad input") })
+	if got.Status != "fail" || got.Message != "panic: bad input" {
+		t.Fatalf("unexpected check: %+v", got)
+	}
+}
+
+func TestSelftestPredicateFuzzAcrossSeeds(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		if _, err := selftestPredicateFuzz(seed, 300); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+func splitPoint(ev *contract.Event, at, after string, loc *time.Location) (time.Time, error) {
+	if (at == "") == (after == "") {
+		return time.Time{}, errors.New("use exactly one of --at or --after")
+	}
+	if after != "" {
+		d, err := timeparse.ParseDuration(after)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return ev.Start.Add(d), nil
+	}
+	if h, m, err := timeparse.ParseClock(strings.TrimSpace(at)); err == nil {
+		s := ev.Start.In(loc)
+		return time.Date(s.Year(), s.Month(), s.Day(), h, m, 0, 0, loc), nil
+	}
+	return timeparse.ParseDateTime(at, currentTime(), loc)
+}
+
+func newEventsSplitCmd(opts *globalOptions) *cobra.Command {
+	var at, after string
+	var suffixes []string
+	var number, dryRun bool
+	cmd := &cobra.Command{
+		Use:   "split <event-id>",
+		Short: "Split an event into two back-to-back events",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			p, be, ro, err := buildContext(c, opts, "events.split")
+			if err != nil {
+				return err
+			}
+			if number {
+				suffixes = []string{" (1/2)", " (2/2)"}
+			}
+			if len(suffixes) != 0 && len(suffixes) != 2 {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--suffix takes exactly two values"), `Use --suffix " (prep), (review)" or --number`, 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
+			current, err := getEventByIDWithTimeout(ctx, be, id)
+			if err != nil {
+				return failWithHint(p, contract.ErrNotFound, err, "Check ID with `acal events list --fields id,title,start`", 4)
+			}
+			if current.AllDay {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("cannot split an all-day event"), "Split timed events only", 2)
+			}
+			loc := resolveLocation(ro.TZ)
+			cut, err := splitPoint(current, at, after, loc)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, `Use --at 14:00 or --after 45m`, 2)
+			}
+			if !cut.After(current.Start) || !cut.Before(current.End) {
+				err = fmt.Errorf("split point %s is outside the event (%s–%s)", cut.In(loc).Format("2006-01-02 15:04"), current.Start.In(loc).Format("15:04"), current.End.In(loc).Format("15:04"))
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Pick a time between the event's start and end", 2)
+			}
+			firstTitle, secondTitle := current.Title, current.Title
+			if len(suffixes) == 2 {
+				firstTitle, secondTitle = current.Title+suffixes[0], current.Title+suffixes[1]
+			}
+			patch := backend.EventUpdateInput{End: &cut}
+			if firstTitle != current.Title {
+				patch.Title = &firstTitle
+			}
+			in := backend.EventCreateInput{
+				Calendar:     firstNonEmpty(current.CalendarID, current.CalendarName),
+				Title:        secondTitle,
+				Start:        cut,
+				End:          current.End,
+				Location:     current.Location,
+				Notes:        current.Notes,
+				URL:          current.URL,
+				Status:       current.Status,
+				Availability: current.Availability,
+				Sensitivity:  current.Sensitivity,
+			}
+			meta := map[string]any{"split_at": cut}
+			if dryRun {
+				return successDryRun(ctx, p, ro, []dryRunPreview{previewUpdate(current, patch), previewAdd(in)}, meta, nil)
+			}
+			updated, err := updateEventWithTimeout(ctx, be, id, patch)
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Split failed", 1)
+			}
+			txID := batchTxID()
+			_ = appendHistory(historyEntry{Type: "update", TxID: txID, OpID: batchOpID(1, "update"), EventID: id, Prev: current, Next: updated})
+			created, err := addEventWithTimeout(ctx, be, in)
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "The first half was shortened; run `acal history undo` to restore it", 1)
+			}
+			_ = appendHistory(historyEntry{Type: "add", TxID: txID, OpID: batchOpID(2, "add"), EventID: created.ID, Created: created})
+			meta["count"], meta["tx_id"] = 2, txID
+			return successWithMeta(ctx, p, ro, []contract.Event{*updated, *created}, meta, nil)
+		},
+	}
+	cmd.Flags().StringVar(&at, "at", "", "Split time (clock time on the event's day, or a datetime)")
+	cmd.Flags().StringVar(&after, "after", "", "Split this long after the start (e.g. 45m)")
+	cmd.Flags().StringSliceVar(&suffixes, "suffix", nil, "Two title suffixes for the first and second half")
+	cmd.Flags().BoolVar(&number, "number", false, `Suffix titles with " (1/2)" and " (2/2)"`)
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview both halves without writing")
+	return cmd
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventsSplit(t *testing.T) {
+	start := time.Date(2026, 3, 3, 13, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{{ID: "deep", CalendarID: "work", CalendarName: "Work", Title: "Deep work", Location: "Desk", Notes: "no slack",
+			Start: start, End: start.Add(3 * time.Hour)}},
+	})
+	var preview struct {
+		Data []dryRunPreview `json:"data"`
+		Meta map[string]any  `json:"meta"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "split", "deep", "--at", "14:00", "--tz", "UTC", "--dry-run", "--json"), &preview); err != nil {
+		t.Fatal(err)
+	}
+	if len(preview.Data) != 2 || preview.Data[0].Op != "update" || !preview.Data[0].After.End.Equal(start.Add(time.Hour)) ||
+		preview.Data[1].Op != "add" || !preview.Data[1].After.Start.Equal(start.Add(time.Hour)) || preview.Meta["dry_run"] != true {
+		t.Fatalf("unexpected preview: %+v", preview)
+	}
+	var env struct {
+		Data []contract.Event `json:"data"`
+		Meta map[string]any   `json:"meta"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "split", "deep", "--after", "90m", "--number", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	first, second := env.Data[0], env.Data[1]
+	if first.ID != "deep" || first.Title != "Deep work (1/2)" || !first.End.Equal(start.Add(90*time.Minute)) {
+		t.Fatalf("unexpected first half: %+v", first)
+	}
+	if second.ID == "" || second.Title != "Deep work (2/2)" || second.Location != "Desk" || second.Notes != "no slack" ||
+		!second.Start.Equal(first.End) || !second.End.Equal(start.Add(3*time.Hour)) {
+		t.Fatalf("unexpected second half: %+v", second)
+	}
+	for _, args := range [][]string{
+		{"events", "split", "deep", "--after", "3h", "--json"},
+		{"events", "split", "deep", "--at", "14:00", "--after", "1h", "--json"},
+		{"events", "split", "deep", "--after", "1h", "--suffix", "a", "--json"},
+	} {
+		if code := runEventsCmd(t, fb, args...); code != 2 {
+			t.Fatalf("expected exit 2 for %v, got %d", args, code)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+type stateFile struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Exists bool   `json:"exists"`
+	Bytes  int64  `json:"bytes"`
+}
+
+func listStateFiles(names []string) []stateFile {
+	rows := make([]stateFile, 0, len(names))
+	for _, name := range names {
+		row := stateFile{Name: name, Path: statePath(name)}
+		if info, err := os.Stat(row.Path); err == nil {
+			row.Exists = true
+			row.Bytes = info.Size()
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func newStateCmd(opts *globalOptions) *cobra.Command {
+	state := &cobra.Command{Use: "state", Short: "Inspect and clear mutable state (history, redo, saved queries)"}
+
+	path := &cobra.Command{
+		Use:   "path",
+		Short: "Show the state directory and files",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, _, _, err := buildContext(cmd, opts, "state.path")
+			if err != nil {
+				return err
+			}
+			dir := stateDir()
+			if dir == "" {
+				return failWithHint(p, contract.ErrGeneric, errors.New("unable to resolve state directory"), "Set HOME or XDG_STATE_HOME", 1)
+			}
+			rows := listStateFiles(stateFileNames)
+			return p.Success(rows, map[string]any{"dir": dir, "count": len(rows)}, nil)
+		},
+	}
+
+	var clearQueries, clearForce, clearDryRun bool
+	clear := &cobra.Command{
+		Use:   "clear",
+		Short: "Delete write history and redo stack",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, _, _, err := buildContext(cmd, opts, "state.clear")
+			if err != nil {
+				return err
+			}
+			if stateDir() == "" {
+				return failWithHint(p, contract.ErrGeneric, errors.New("unable to resolve state directory"), "Set HOME or XDG_STATE_HOME", 1)
+			}
+			names := []string{"history.jsonl", "redo.jsonl"}
+			if clearQueries {
+				names = append(names, "queries.json")
+			}
+			rows := listStateFiles(names)
+			if clearDryRun {
+				return p.Success(rows, map[string]any{"dry_run": true, "count": len(rows)}, nil)
+			}
+			if !clearForce {
+				err = errors.New("state clear requires --force")
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Preview with --dry-run, then rerun with --force", 2)
+			}
+			cleared := 0
+			err = withStateLock(func() error {
+				for _, row := range rows {
+					if err := os.Remove(row.Path); err != nil && !os.IsNotExist(err) {
+						return err
+					}
+					if row.Exists {
+						cleared++
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Check permissions on "+filepath.Dir(rows[0].Path), 1)
+			}
+			return p.Success(rows, map[string]any{"cleared": cleared, "count": len(rows)}, nil)
+		},
+	}
+	clear.Flags().BoolVar(&clearQueries, "queries", false, "Also delete saved queries")
+	clear.Flags().BoolVarP(&clearForce, "force", "f", false, "Delete without confirmation")
+	clear.Flags().BoolVarP(&clearDryRun, "dry-run", "n", false, "Preview without deleting")
+
+	state.AddCommand(path, clear)
+	return state
+}
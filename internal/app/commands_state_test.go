@@ -0,0 +1,102 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func runStateCmd(t *testing.T, args ...string) ([]stateFile, map[string]any, int) {
+	t.Helper()
+	cmd := NewRootCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs(append(args, "--json"))
+	code := ExitCode(cmd.Execute())
+	var env struct {
+		Data []stateFile    `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	if code == 0 {
+		if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+			t.Fatalf("decode %s: %v", stdout.String(), err)
+		}
+	}
+	return env.Data, env.Meta, code
+}
+
+func TestStateFilesMigrateFromConfigDir(t *testing.T) {
+	cfg := t.TempDir()
+	stateHome := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", cfg)
+	t.Setenv("XDG_STATE_HOME", stateHome)
+	legacy := filepath.Join(cfg, "acal")
+	if err := os.MkdirAll(legacy, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(legacy, "history.jsonl"), []byte(`{"type":"add","event_id":"evt-1"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(legacy, "queries.json"), []byte(`{"next7":{"name":"next7","from":"today","to":"+7d"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := readHistory()
+	if err != nil || len(entries) != 1 || entries[0].EventID != "evt-1" {
+		t.Fatalf("expected migrated history, got %+v (%v)", entries, err)
+	}
+	if _, err := os.Stat(filepath.Join(legacy, "history.jsonl")); !os.IsNotExist(err) {
+		t.Fatalf("expected legacy history to be moved, stat err=%v", err)
+	}
+
+	rows, meta, code := runStateCmd(t, "state", "path")
+	if code != 0 || meta["dir"] != filepath.Join(stateHome, "acal") {
+		t.Fatalf("unexpected state path result: code=%d meta=%v", code, meta)
+	}
+	got := map[string]bool{}
+	for _, r := range rows {
+		got[r.Name] = r.Exists
+	}
+	if !got["history.jsonl"] || !got["queries.json"] || got["redo.jsonl"] {
+		t.Fatalf("unexpected state files: %+v", rows)
+	}
+}
+
+func TestStateClear(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	if err := appendHistory(historyEntry{Type: "add", EventID: "evt-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateSavedQueries(func(store map[string]savedQuery) error {
+		store["next7"] = savedQuery{Name: "next7"}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, code := runStateCmd(t, "state", "clear"); code != 2 {
+		t.Fatalf("expected clear without --force to exit 2, got %d", code)
+	}
+	if _, meta, code := runStateCmd(t, "state", "clear", "--dry-run"); code != 0 || meta["dry_run"] != true {
+		t.Fatalf("unexpected dry-run result: code=%d meta=%v", code, meta)
+	}
+	if entries, _ := readHistory(); len(entries) != 1 {
+		t.Fatalf("dry-run must not delete history, got %d entries", len(entries))
+	}
+	if _, meta, code := runStateCmd(t, "state", "clear", "--force"); code != 0 || meta["cleared"] != float64(2) {
+		t.Fatalf("unexpected clear result: code=%d meta=%v", code, meta)
+	}
+	if entries, _ := readHistory(); len(entries) != 0 {
+		t.Fatalf("expected empty history, got %d entries", len(entries))
+	}
+	if store, _ := loadSavedQueries(); len(store) != 1 {
+		t.Fatalf("saved queries must survive without --queries, got %d", len(store))
+	}
+}
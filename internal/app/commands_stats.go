@@ -0,0 +1,242 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+type focusDay struct {
+	Date               string  `json:"date"`
+	WindowMinutes      int64   `json:"window_minutes"`
+	Meetings           int     `json:"meetings"`
+	BusyMinutes        int64   `json:"busy_minutes"`
+	FreeMinutes        int64   `json:"free_minutes"`
+	FocusMinutes       int64   `json:"focus_minutes"`
+	LongestFreeMinutes int64   `json:"longest_free_minutes"`
+	ContextSwitches    int     `json:"context_switches"`
+	Fragmentation      float64 `json:"fragmentation"`
+}
+
+func buildFocusDay(items []contract.Event, windowStart, windowEnd time.Time, minFocus time.Duration, includeAllDay bool) focusDay {
+	inWindow := make([]contract.Event, 0, len(items))
+	for _, e := range items {
+		if !e.Start.Before(windowEnd) || !e.End.After(windowStart) {
+			continue
+		}
+		e.Start, e.End = maxTime(e.Start, windowStart), minTime(e.End, windowEnd)
+		inWindow = append(inWindow, e)
+	}
+	blocks := buildBusyBlocks(inWindow, includeAllDay)
+	gaps := buildFreeGaps(blocks, windowStart, windowEnd, time.Minute)
+
+	d := focusDay{
+		Date:          windowStart.Format("2006-01-02"),
+		WindowMinutes: int64(windowEnd.Sub(windowStart).Minutes()),
+	}
+	for _, b := range blocks {
+		d.BusyMinutes += b.Minutes
+		for _, e := range inWindow {
+			if blocksTime(e, includeAllDay) && e.Start.After(b.Start) && e.Start.Before(b.End) {
+				d.ContextSwitches++
+			}
+		}
+	}
+	for _, e := range inWindow {
+		if blocksTime(e, includeAllDay) {
+			d.Meetings++
+		}
+	}
+	for _, g := range gaps {
+		d.FreeMinutes += g.Minutes
+		if g.Minutes > d.LongestFreeMinutes {
+			d.LongestFreeMinutes = g.Minutes
+		}
+		if g.End.Sub(g.Start) >= minFocus {
+			d.FocusMinutes += g.Minutes
+		}
+	}
+	if segments := len(blocks) + len(gaps); segments > 1 {
+		d.ContextSwitches += segments - 1
+	}
+	if d.FreeMinutes > 0 {
+		d.Fragmentation = math.Round(float64(d.FreeMinutes-d.FocusMinutes)/float64(d.FreeMinutes)*100) / 100
+	}
+	return d
+}
+
+func blocksTime(e contract.Event, includeAllDay bool) bool {
+	return (includeAllDay || !e.AllDay) && e.Availability != contract.AvailabilityFree && e.Status != contract.StatusCancelled
+}
+
+const upcomingWindow = 7 * 24 * time.Hour
+
+type statsMetric struct {
+	Name  string
+	Help  string
+	Value float64
+}
+
+func buildStatsMetrics(items []contract.Event, now, windowStart, windowEnd time.Time, minFocus time.Duration, includeAllDay bool) []statsMetric {
+	dayStart, dayEnd := dayBounds(now)
+	today := []contract.Event{}
+	upcoming := 0
+	for _, e := range items {
+		if e.Status == contract.StatusCancelled {
+			continue
+		}
+		if e.Start.After(now) && e.Start.Before(now.Add(upcomingWindow)) {
+			upcoming++
+		}
+		if e.Start.Before(dayEnd) && e.End.After(dayStart) {
+			today = append(today, e)
+		}
+	}
+	conflicts := 0
+	for _, c := range buildConflictRows(today, includeAllDay) {
+		if c.OverlapStart.Before(dayEnd) && c.OverlapEnd.After(dayStart) {
+			conflicts++
+		}
+	}
+	whole := buildFocusDay(today, dayStart, dayEnd, minFocus, includeAllDay)
+	focus := buildFocusDay(today, windowStart, windowEnd, minFocus, includeAllDay)
+	return []statsMetric{
+		{"acal_upcoming_events", "Events starting in the next 7 days.", float64(upcoming)},
+		{"acal_busy_minutes_today", "Minutes of today covered by busy events.", float64(whole.BusyMinutes)},
+		{"acal_conflicts_today", "Overlapping event pairs today.", float64(conflicts)},
+		{"acal_meetings_today", "Busy events in today's working window.", float64(focus.Meetings)},
+		{"acal_focus_minutes_today", "Free minutes in today's working window in stretches of at least --min-focus.", float64(focus.FocusMinutes)},
+		{"acal_context_switches_today", "Context switches in today's working window.", float64(focus.ContextSwitches)},
+		{"acal_fragmentation_today", "Share of today's free working time in gaps shorter than --min-focus.", focus.Fragmentation},
+	}
+}
+
+func renderOpenMetrics(metrics []statsMetric) string {
+	var b strings.Builder
+	for _, m := range metrics {
+		b.WriteString("# HELP " + m.Name + " " + m.Help + "\n")
+		b.WriteString("# TYPE " + m.Name + " gauge\n")
+		b.WriteString(m.Name + " " + strconv.FormatFloat(m.Value, 'f', -1, 64) + "\n")
+	}
+	b.WriteString("# EOF\n")
+	return b.String()
+}
+
+func newStatsCmd(opts *globalOptions) *cobra.Command {
+	var calendars []string
+	var fromS, toS, between, minFocusS, format, outPath string
+	var includeAllDay, skipWeekends bool
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Per-day focus time, context switches, and fragmentation",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "stats")
+			if err != nil {
+				return err
+			}
+			format = strings.ToLower(strings.TrimSpace(format))
+			if format != "" && format != "openmetrics" {
+				return failWithHint(p, contract.ErrInvalidUsage, invalidField("format", format, fmt.Errorf("invalid --format: %s", format)), "Use --format openmetrics, or omit it for per-day rows", 2)
+			}
+			if outPath != "" && format == "" {
+				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("--out requires --format openmetrics"), "Add --format openmetrics", 2)
+			}
+			f, err := buildEventFilterWithTZ(fromS, toS, calendars, 0, ro.TZ)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
+			}
+			minFocus, err := timeparse.ParseDuration(minFocusS)
+			if err != nil || minFocus <= 0 {
+				if err == nil {
+					err = fmt.Errorf("--min-focus must be positive")
+				}
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			startHour, startMinute, endHour, endMinute, err := parseBetweenRange(between)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --between HH:MM-HH:MM or 9am-5pm", 2)
+			}
+			// Whole days are scored, so widen the range to their bounds.
+			loc := resolveLocation(ro.TZ)
+			firstDay, _ := dayBounds(f.From.In(loc))
+			lastDay, lastEnd := dayBounds(rangeLastDay(f.To).In(loc))
+			f.From, f.To, f.Overlap = firstDay, lastEnd, true
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			if format == "openmetrics" {
+				now := currentTime().In(loc)
+				today, _ := dayBounds(now)
+				f.From, f.To = today, now.Add(upcomingWindow)
+				items, err := listEventsWithTimeout(ctx, be, f)
+				if err != nil {
+					return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+				}
+				windowStart := time.Date(today.Year(), today.Month(), today.Day(), startHour, startMinute, 0, 0, loc)
+				windowEnd := time.Date(today.Year(), today.Month(), today.Day(), endHour, endMinute, 0, 0, loc)
+				metrics := buildStatsMetrics(items, now, windowStart, windowEnd, minFocus, includeAllDay)
+				text := renderOpenMetrics(metrics)
+				meta := map[string]any{"count": len(metrics), "format": format, "events_scanned": len(items)}
+				if outPath != "" && outPath != "-" {
+					if err := writeFileAtomic(outPath, []byte(text), 0o644); err != nil {
+						return failWithHint(p, contract.ErrGeneric, err, "Check destination path permissions", 1)
+					}
+					return successWithMeta(ctx, p, ro, map[string]any{"path": outPath, "metrics": len(metrics)}, meta, nil)
+				}
+				if p.Mode == output.ModeJSON || p.Mode == output.ModeJSONL {
+					return successWithMeta(ctx, p, ro, map[string]any{"openmetrics": text, "metrics": len(metrics)}, meta, nil)
+				}
+				_, _ = fmt.Fprint(c.OutOrStdout(), text)
+				return nil
+			}
+			items, err := listEventsWithTimeout(ctx, be, f)
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			days := []focusDay{}
+			var focus int64
+			var switches int
+			var fragmentation float64
+			for day := firstDay; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+				if skipWeekends && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+					continue
+				}
+				windowStart := time.Date(day.Year(), day.Month(), day.Day(), startHour, startMinute, 0, 0, loc)
+				windowEnd := time.Date(day.Year(), day.Month(), day.Day(), endHour, endMinute, 0, 0, loc)
+				d := buildFocusDay(items, windowStart, windowEnd, minFocus, includeAllDay)
+				days = append(days, d)
+				focus += d.FocusMinutes
+				switches += d.ContextSwitches
+				fragmentation += d.Fragmentation
+			}
+			meta := map[string]any{
+				"count":                  len(days),
+				"events_scanned":         len(items),
+				"min_focus_minutes":      int64(minFocus.Minutes()),
+				"total_focus_minutes":    focus,
+				"total_context_switches": switches,
+			}
+			if len(days) > 0 {
+				meta["avg_fragmentation"] = math.Round(fragmentation/float64(len(days))*100) / 100
+			}
+			return successWithMeta(ctx, p, ro, days, meta, nil)
+		},
+	}
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	cmd.Flags().StringVar(&fromS, "from", "-7d", "Range start")
+	cmd.Flags().StringVar(&toS, "to", "today", "Range end")
+	cmd.Flags().StringVar(&between, "between", "09:00-17:00", "Daily working window as HH:MM-HH:MM or 9am-5pm")
+	cmd.Flags().StringVar(&minFocusS, "min-focus", "1h", "Shortest free stretch that counts as focus time")
+	cmd.Flags().BoolVar(&includeAllDay, "include-all-day", false, "Count all-day events as busy")
+	cmd.Flags().BoolVar(&skipWeekends, "skip-weekends", false, "Leave Saturdays and Sundays out")
+	cmd.Flags().StringVar(&format, "format", "", "Output format: openmetrics for today's gauges in Prometheus text form")
+	cmd.Flags().StringVar(&outPath, "out", "", "With --format openmetrics: file to write atomically (e.g. a node_exporter textfile), or - for stdout")
+	return cmd
+}
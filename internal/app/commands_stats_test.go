@@ -0,0 +1,100 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestBuildFocusDayMetrics(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	items := []contract.Event{
+		{ID: "a", Start: at(9, 30), End: at(10, 0)},
+		{ID: "b", Start: at(10, 0), End: at(10, 30)},
+		{ID: "c", Start: at(11, 0), End: at(11, 30)},
+		{ID: "free", Start: at(13, 0), End: at(14, 0), Availability: contract.AvailabilityFree},
+		{ID: "offsite", Start: day, End: day.AddDate(0, 0, 1), AllDay: true},
+	}
+	d := buildFocusDay(items, at(9, 0), at(17, 0), time.Hour, false)
+	// free 09:00-09:30, busy 09:30-10:30 (a then b), free 10:30-11:00,
+	// busy 11:00-11:30, free 11:30-17:00.
+	if d.Meetings != 3 || d.BusyMinutes != 90 || d.FreeMinutes != 390 {
+		t.Fatalf("unexpected totals: %+v", d)
+	}
+	if d.LongestFreeMinutes != 330 || d.FocusMinutes != 330 {
+		t.Fatalf("unexpected focus: %+v", d)
+	}
+	if d.ContextSwitches != 5 {
+		t.Fatalf("expected 4 segment changes plus a→b, got %d", d.ContextSwitches)
+	}
+	if d.Fragmentation != 0.15 {
+		t.Fatalf("expected fragmentation 0.15, got %v", d.Fragmentation)
+	}
+
+	empty := buildFocusDay(nil, at(9, 0), at(17, 0), time.Hour, false)
+	if empty.ContextSwitches != 0 || empty.Fragmentation != 0 || empty.FocusMinutes != 480 {
+		t.Fatalf("unexpected empty day: %+v", empty)
+	}
+}
+
+func TestStatsCommandPlain(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "e1", CalendarID: "work", CalendarName: "Work", Title: "Sync", Start: day.Add(12 * time.Hour), End: day.Add(13 * time.Hour)},
+		},
+	})
+	out := string(runWithBackend(t, fb, "stats", "--from", "2026-03-02", "--to", "2026-03-03", "--tz", "UTC", "--plain"))
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one row per day, got %q", out)
+	}
+	if lines[0] != "2026-03-02\t1\t60\t420\t240\t2\t0" {
+		t.Fatalf("unexpected first day %q", lines[0])
+	}
+	if lines[1] != "2026-03-03\t0\t0\t480\t480\t0\t0" {
+		t.Fatalf("unexpected second day %q", lines[1])
+	}
+}
+
+func TestStatsOpenMetricsTextfile(t *testing.T) {
+	t.Setenv("ACAL_NOW", "2026-03-02T08:00:00Z")
+	t.Cleanup(func() { pinnedNow.Store(nil) })
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "e1", CalendarID: "work", CalendarName: "Work", Title: "Sync", Start: day.Add(10 * time.Hour), End: day.Add(11 * time.Hour)},
+			{ID: "e2", CalendarID: "work", CalendarName: "Work", Title: "Review", Start: day.Add(10*time.Hour + 30*time.Minute), End: day.Add(12 * time.Hour)},
+			{ID: "e3", CalendarID: "work", CalendarName: "Work", Title: "Planning", Start: day.Add(50 * time.Hour), End: day.Add(51 * time.Hour)},
+			{ID: "e4", CalendarID: "work", CalendarName: "Work", Title: "Next month", Start: day.AddDate(0, 1, 0), End: day.AddDate(0, 1, 0).Add(time.Hour)},
+		},
+	})
+	path := filepath.Join(t.TempDir(), "acal.prom")
+	runWithBackend(t, fb, "stats", "--format", "openmetrics", "--out", path, "--tz", "UTC", "--json")
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(raw)
+	for _, want := range []string{
+		"# TYPE acal_upcoming_events gauge\nacal_upcoming_events 3\n",
+		"acal_busy_minutes_today 120\n",
+		"acal_conflicts_today 1\n",
+		"acal_meetings_today 2\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("missing %q in:\n%s", want, text)
+		}
+	}
+	if !strings.HasSuffix(text, "# EOF\n") {
+		t.Fatalf("expected # EOF terminator:\n%s", text)
+	}
+}
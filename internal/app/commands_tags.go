@@ -0,0 +1,79 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+func newEventsTagCmd(opts *globalOptions) *cobra.Command {
+	var scope string
+	var ifMatch int
+	var remove []string
+	var clear, dryRun bool
+	cmd := &cobra.Command{
+		Use:   "tag <event-id> [+tag|-tag ...]",
+		Short: "Add or remove event tags stored as notes markers",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, be, ro, err := buildContext(cmd, opts, "events.tag")
+			if err != nil {
+				return err
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
+			ops := append([]string{}, args[1:]...)
+			for _, tag := range remove {
+				ops = append(ops, "-"+tag)
+			}
+			if len(ops) == 0 && !clear {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("no tag changes provided"), "Pass +tag arguments, --remove <tag>, or --clear", 2)
+			}
+			recScope, err := parseRecurrenceScope(scope)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use --scope auto|this|future|series", 2)
+			}
+			item, err := getEventByIDWithTimeout(ctx, be, id)
+			if err != nil {
+				return failWithHint(p, contract.ErrNotFound, err, "Check ID with `acal events list --fields id,title,start`", 4)
+			}
+			if ifMatch > 0 && item.Sequence != ifMatch {
+				err = fmt.Errorf("sequence mismatch: current=%d expected=%d", item.Sequence, ifMatch)
+				return failWithHint(p, contract.ErrConcurrency, err, "Re-fetch event and retry", 7)
+			}
+			current := parseTagsMarker(item.Notes)
+			if clear {
+				current = nil
+			}
+			tags, err := applyTagOps(current, ops)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use tags like +work or -1on1", 2)
+			}
+			notes := setTagsMarker(item.Notes, tags)
+			patch := backend.EventUpdateInput{Notes: &notes, Scope: recScope}
+			meta := map[string]any{"count": 1, "tags": tags}
+			if dryRun {
+				return successWithMeta(ctx, p, ro, patch, meta, nil)
+			}
+			updated, err := updateEventWithTimeout(ctx, be, id, patch)
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Tag update failed", 1)
+			}
+			_ = appendHistory(historyEntry{Type: "update", EventID: id, Prev: item, Next: updated})
+			return successWithMeta(ctx, p, ro, updated, meta, nil)
+		},
+	}
+	cmd.Flags().StringVar(&scope, "scope", "auto", "Recurrence scope: auto|this|future|series")
+	cmd.Flags().IntVar(&ifMatch, "if-match-seq", 0, "Require matching sequence number")
+	cmd.Flags().StringArrayVar(&remove, "remove", nil, "Tag to remove (repeatable; same as -tag after --)")
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove all tags before applying changes")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview without writing")
+	return cmd
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventsTagUpdatesNotesMarker(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fb := &scopeCaptureBackend{getEvent: &contract.Event{ID: "evt@792417600", Start: time.Now(), Notes: "agenda\nacal:tags=1on1,work", Sequence: 1}}
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+
+	cmd := NewRootCommand()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"events", "tag", "evt@792417600", "+Focus", "--remove", "1on1", "--scope", "this", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if fb.updateInput.Notes == nil || *fb.updateInput.Notes != "agenda\nacal:tags=focus,work" {
+		t.Fatalf("unexpected notes patch: %#v", fb.updateInput.Notes)
+	}
+	if fb.updateInput.Scope != backend.ScopeThis {
+		t.Fatalf("scope mismatch: got=%q want=%q", fb.updateInput.Scope, backend.ScopeThis)
+	}
+}
+
+func TestEventsTagRequiresChanges(t *testing.T) {
+	fb := &scopeCaptureBackend{}
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+
+	cmd := NewRootCommand()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"events", "tag", "evt@792417600", "--json"})
+	err := cmd.Execute()
+	if code := ExitCode(err); code != 2 {
+		t.Fatalf("expected exit 2, got %d (%v)", code, err)
+	}
+	if fb.updateCalls != 0 {
+		t.Fatalf("expected no update calls, got %d", fb.updateCalls)
+	}
+}
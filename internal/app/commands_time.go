@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+type timeParseResult struct {
+	Input     string    `json:"input"`
+	Resolved  time.Time `json:"resolved"`
+	TZ        string    `json:"tz"`
+	Now       time.Time `json:"now"`
+	Weekday   string    `json:"weekday"`
+	HasClock  bool      `json:"has_clock"`
+	DayStart  time.Time `json:"day_start"`
+	DayEnd    time.Time `json:"day_end"`
+	AsFrom    time.Time `json:"as_from"`
+	AsTo      time.Time `json:"as_to"`
+	DaysAhead int       `json:"days_ahead"`
+}
+
+func explainTime(input string, now time.Time, loc *time.Location) (timeParseResult, error) {
+	var asTo time.Time
+	resolved, end, ok := timeparse.ParseRange(input, now, loc, currentWeekStart())
+	if ok {
+		asTo = end
+	} else {
+		var err error
+		if resolved, err = timeparse.ParseDateTime(input, now, loc); err != nil {
+			return timeParseResult{}, err
+		}
+		asTo = rangeEnd(resolved, toBound(rangeToBound.Load()))
+	}
+	resolved = resolved.In(loc)
+	dayStart, dayEnd := dayBounds(resolved)
+	today, _ := dayBounds(now.In(loc))
+	return timeParseResult{
+		Input:     input,
+		Resolved:  resolved,
+		TZ:        loc.String(),
+		Now:       now.In(loc),
+		Weekday:   resolved.Weekday().String(),
+		HasClock:  !resolved.Equal(dayStart),
+		DayStart:  dayStart,
+		DayEnd:    dayEnd,
+		AsFrom:    resolved,
+		AsTo:      asTo,
+		DaysAhead: int(dayStart.Sub(today).Round(24*time.Hour) / (24 * time.Hour)),
+	}, nil
+}
+
+func newTimeCmd(opts *globalOptions) *cobra.Command {
+	timeCmd := &cobra.Command{Use: "time", Short: "Date and time utilities"}
+	parse := &cobra.Command{
+		Use:   "parse <expr>",
+		Short: "Show how acal interprets a date/time expression",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, _, ro, err := buildContext(cmd, opts, "time.parse")
+			if err != nil {
+				return err
+			}
+			res, err := explainTime(strings.TrimSpace(args[0]), currentTime(), resolveLocation(ro.TZ))
+			if err != nil {
+				err = fmt.Errorf("cannot parse %q: %w", args[0], err)
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use RFC3339, YYYY-MM-DD [HH:MM|3pm], today/tomorrow, ±Nd, a weekday, a month-name date, or a range keyword like this-week", 2)
+			}
+			return p.Success(res, map[string]any{"count": 1}, nil)
+		},
+	}
+	timeCmd.AddCommand(parse)
+	return timeCmd
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+)
+
+func TestExplainTimeReportsDayBoundsAndRangeUse(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, loc) // Monday
+	res, err := explainTime("friday", now, loc)
+	if err != nil {
+		t.Fatalf("explainTime: %v", err)
+	}
+	if got := res.Resolved.Format(time.RFC3339); got != "2026-03-06T00:00:00+01:00" {
+		t.Fatalf("unexpected resolved %s", got)
+	}
+	if res.Weekday != "Friday" || res.HasClock || res.DaysAhead != 4 || res.TZ != "Europe/Berlin" {
+		t.Fatalf("unexpected explanation %+v", res)
+	}
+	if got := res.AsTo.Format(time.RFC3339); got != "2026-03-07T00:00:00+01:00" {
+		t.Fatalf("midnight --to should cover the day, got %s", got)
+	}
+
+	res, err = explainTime("tomorrow 3pm", now, loc)
+	if err != nil {
+		t.Fatalf("explainTime: %v", err)
+	}
+	if !res.HasClock || !res.AsTo.Equal(res.Resolved) || res.DayStart.Format("2006-01-02") != "2026-03-03" {
+		t.Fatalf("unexpected explanation %+v", res)
+	}
+	if _, err := explainTime("next fortnight", now, loc); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
+
+func TestTimeParseCommandHonorsNowAndTZ(t *testing.T) {
+	t.Cleanup(func() { pinnedNow.Store(nil) })
+	fb := backend.NewMockBackend(backend.MockFixture{})
+	out := runWithBackend(t, fb, "time", "parse", "+1d", "--now", "2026-03-02T23:30:00Z", "--tz", "Asia/Tokyo", "--json")
+	var env struct {
+		Data timeParseResult `json:"data"`
+	}
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got := env.Data.Resolved.Format(time.RFC3339); got != "2026-03-04T00:00:00+09:00" {
+		t.Fatalf("unexpected resolved %s", got)
+	}
+	if code := runEventsCmd(t, fb, "time", "parse", "whenever", "--json"); code != 2 {
+		t.Fatalf("expected exit 2, got %d", code)
+	}
+}
@@ -0,0 +1,200 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+const upcomingCacheFile = "upcoming-cache.json"
+
+type upcomingCache struct {
+	Key       string           `json:"key"`
+	FetchedAt time.Time        `json:"fetched_at"`
+	To        time.Time        `json:"to"`
+	Events    []contract.Event `json:"events"`
+}
+
+func upcomingCacheKey(ro *globalOptions, calendars []string) string {
+	cals := append([]string(nil), calendars...)
+	sort.Strings(cals)
+	return strings.Join([]string{ro.Backend, ro.Profile, ro.TZ, strings.Join(cals, ","), fmt.Sprint(ro.HidePrivate)}, "|")
+}
+
+func loadUpcomingCache(key string, now, until time.Time, ttl time.Duration) ([]contract.Event, bool) {
+	dir := stateDir()
+	if dir == "" || ttl <= 0 {
+		return nil, false
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, upcomingCacheFile))
+	if err != nil {
+		return nil, false
+	}
+	var c upcomingCache
+	if json.Unmarshal(raw, &c) != nil || c.Key != key {
+		return nil, false
+	}
+	if now.Before(c.FetchedAt) || now.Sub(c.FetchedAt) >= ttl || c.To.Before(until) {
+		return nil, false
+	}
+	return c.Events, true
+}
+
+// saveUpcomingCache is best effort.
+func saveUpcomingCache(c upcomingCache) {
+	dir := stateDir()
+	if dir == "" {
+		return
+	}
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	_ = writeFileAtomic(filepath.Join(dir, upcomingCacheFile), raw, 0o600)
+}
+
+func truncateTitle(s string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	if max == 1 {
+		return "…"
+	}
+	return string([]rune(s)[:max-1]) + "…"
+}
+
+func statusLabel(e *contract.Event, now time.Time, maxTitle int) string {
+	if e == nil {
+		return ""
+	}
+	title := truncateTitle(firstNonEmpty(strings.TrimSpace(e.Title), "(untitled)"), maxTitle)
+	if !e.Start.After(now) {
+		return title + " · " + formatMinutes(int64(e.End.Sub(now).Round(time.Minute).Minutes())) + " left"
+	}
+	return title + " in " + formatMinutes(int64(e.Start.Sub(now).Round(time.Minute).Minutes()))
+}
+
+var statusColors = map[string][2]string{
+	"ongoing":  {"red", "r"},
+	"soon":     {"yellow", "y"},
+	"upcoming": {"green", "g"},
+}
+
+// renderTmux doubles # since it starts a tmux format.
+func renderTmux(icon, label, class string, color bool) string {
+	if label == "" {
+		return "\n"
+	}
+	text := strings.ReplaceAll(strings.TrimSpace(icon+" "+label), "#", "##")
+	if c, ok := statusColors[class]; ok && color {
+		text = "#[fg=" + c[0] + "]" + text + "#[default]"
+	}
+	return text + "\n"
+}
+
+func renderScreen(icon, label, class string, color bool) string {
+	if label == "" {
+		return "\n"
+	}
+	text := strings.ReplaceAll(strings.TrimSpace(icon+" "+label), "%", "%%")
+	if c, ok := statusColors[class]; ok && color {
+		text = "%{" + c[1] + "}" + text + "%{-}"
+	}
+	return text + "\n"
+}
+
+func newUpcomingCmd(opts *globalOptions) *cobra.Command {
+	var calendars []string
+	var withinS, format, icon, videoIcon string
+	var cacheTTL time.Duration
+	var maxTitle int
+	cmd := &cobra.Command{
+		Use:   "upcoming",
+		Short: "Show what is coming up soon, optionally as a tmux or screen status string",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "upcoming")
+			if err != nil {
+				return err
+			}
+			format = strings.ToLower(strings.TrimSpace(format))
+			if format != "" && format != "tmux" && format != "screen" {
+				return failWithHint(p, contract.ErrInvalidUsage, invalidField("format", format, fmt.Errorf("invalid --format: %s", format)), "Use --format tmux|screen, or omit it for the events themselves", 2)
+			}
+			within, err := timeparse.ParseDuration(withinS)
+			if err != nil || within <= 0 {
+				if err == nil {
+					err = errors.New("--within must be positive")
+				}
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			if cacheTTL < 0 {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--cache must not be negative"), "Use --cache 30s, or 0 to always query the backend", 2)
+			}
+			loc := resolveLocation(ro.TZ)
+			now := currentTime().In(loc)
+			until := now.Add(within)
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			key := upcomingCacheKey(ro, calendars)
+			items, cached := loadUpcomingCache(key, now, until, cacheTTL)
+			if !cached {
+				// Fetch one cache interval further so cached calls still cover --within.
+				to := until.Add(cacheTTL)
+				items, err = listEventsWithTimeout(ctx, be, backend.EventFilter{From: now, To: to, Calendars: calendars, Overlap: true})
+				if err != nil {
+					return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+				}
+				if p.HidePrivate {
+					items = output.MaskPrivate(items).([]contract.Event)
+				}
+				if cacheTTL > 0 {
+					saveUpcomingCache(upcomingCache{Key: key, FetchedAt: now, To: to, Events: items})
+				}
+			}
+			rows := []contract.Event{}
+			for _, e := range timedEvents(items) {
+				if e.End.After(now) && e.Start.Before(until) {
+					e.Start, e.End = e.Start.In(loc), e.End.In(loc)
+					rows = append(rows, e)
+				}
+			}
+			if format != "" {
+				e := currentEvent(rows, now)
+				if e == nil {
+					e = nextEvent(rows, now)
+				}
+				it := buildBarItem(e, now, "15:04", icon, videoIcon)
+				label := statusLabel(e, now, maxTitle)
+				line := renderTmux(it.Icon, label, it.Class, !p.NoColor)
+				if format == "screen" {
+					line = renderScreen(it.Icon, label, it.Class, !p.NoColor)
+				}
+				_, _ = fmt.Fprint(c.OutOrStdout(), line)
+				return nil
+			}
+			meta := map[string]any{"count": len(rows), "within_minutes": int64(within.Minutes()), "cached": cached}
+			return successWithMeta(ctx, p, ro, rows, meta, nil)
+		},
+	}
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name (repeatable)")
+	cmd.Flags().StringVar(&withinS, "within", "2h", "How far ahead to look")
+	cmd.Flags().StringVar(&format, "format", "", "Status string: tmux|screen")
+	cmd.Flags().DurationVar(&cacheTTL, "cache", 30*time.Second, "Reuse the last backend answer for this long (0 to disable)")
+	cmd.Flags().IntVar(&maxTitle, "max-title", 24, "Truncate titles in status strings to this many characters (0 for no limit)")
+	cmd.Flags().StringVar(&icon, "icon", "📅", "Status icon")
+	cmd.Flags().StringVar(&videoIcon, "video-icon", "🎥", "Status icon for video calls")
+	return cmd
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestRenderStatusStrings(t *testing.T) {
+	if got := renderTmux("C", "#1 sync in 5m", "soon", true); got != "#[fg=yellow]C ##1 sync in 5m#[default]\n" {
+		t.Fatalf("unexpected tmux line: %q", got)
+	}
+	if got := renderTmux("C", "Sync in 5m", "soon", false); got != "C Sync in 5m\n" {
+		t.Fatalf("unexpected uncolored tmux line: %q", got)
+	}
+	if got := renderScreen("C", "100% review · 5m left", "ongoing", true); got != "%{r}C 100%% review · 5m left%{-}\n" {
+		t.Fatalf("unexpected screen line: %q", got)
+	}
+	if got := renderTmux("C", "", "none", true); got != "\n" {
+		t.Fatalf("expected empty line, got %q", got)
+	}
+	if got := truncateTitle("Quarterly planning", 10); got != "Quarterly…" {
+		t.Fatalf("unexpected truncation: %q", got)
+	}
+}
+
+func TestUpcomingTmuxUsesCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("ACAL_NOW", "2026-03-02T09:00:00Z")
+	t.Cleanup(func() { pinnedNow.Store(nil) })
+	start := time.Date(2026, 3, 2, 10, 20, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "e1", CalendarID: "work", CalendarName: "Work", Title: "Planning", Start: start, End: start.Add(time.Hour)},
+		},
+	})
+	if got := string(runWithBackend(t, fb, "upcoming", "--format", "tmux", "--icon", "C")); got != "#[fg=green]C Planning in 1h20m#[default]\n" {
+		t.Fatalf("unexpected tmux line: %q", got)
+	}
+
+	empty := backend.NewMockBackend(backend.MockFixture{Calendars: []contract.Calendar{{ID: "work", Name: "Work"}}})
+	t.Setenv("ACAL_NOW", "2026-03-02T09:00:10Z")
+	pinnedNow.Store(nil)
+	if got := string(runWithBackend(t, empty, "upcoming", "--format", "tmux", "--icon", "C", "--no-color")); got != "C Planning in 1h20m\n" {
+		t.Fatalf("expected cached event, got %q", got)
+	}
+	if got := string(runWithBackend(t, empty, "upcoming", "--format", "tmux", "--cache", "0")); got != "\n" {
+		t.Fatalf("expected backend to be queried with --cache 0, got %q", got)
+	}
+
+	var env struct {
+		Data []contract.Event `json:"data"`
+		Meta map[string]any   `json:"meta"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "upcoming", "--within", "30m", "--cache", "0", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 0 || env.Meta["cached"] != false {
+		t.Fatalf("expected nothing within 30m, got %+v", env)
+	}
+}
@@ -1,10 +1,11 @@
 package app
 
 import (
-	"time"
+	"errors"
 
 	"github.com/agis/acal/internal/backend"
 	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
 	"github.com/agis/acal/internal/timeparse"
 	"github.com/spf13/cobra"
 )
@@ -13,6 +14,7 @@ func newAgendaCmd(opts *globalOptions) *cobra.Command {
 	var day string
 	var calendars []string
 	var limit int
+	var includeBirthdays, videoOnly, noAnnotations bool
 	cmd := &cobra.Command{
 		Use:   "agenda",
 		Short: "Human-friendly agenda for a day",
@@ -22,25 +24,46 @@ func newAgendaCmd(opts *globalOptions) *cobra.Command {
 				return err
 			}
 			loc := resolveLocation(ro.TZ)
-			start, err := timeparse.ParseDateTime(day, time.Now(), loc)
+			start, err := timeparse.ParseDateTime(day, currentTime(), loc)
 			if err != nil {
 				_ = p.Error(contract.ErrInvalidUsage, err.Error(), "Use day as today, tomorrow, +Nd, or YYYY-MM-DD")
 				return WrapPrinted(2, err)
 			}
-			end := start.Add(24*time.Hour - time.Second)
+			end := start.AddDate(0, 0, 1)
+			providers, err := dayAnnotators(ro.Annotations)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Set latitude and longitude together in [annotations]", 2)
+			}
 			ctx, cancel := commandContext(ro)
 			defer cancel()
-			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: start, To: end, Calendars: calendars, Limit: limit})
+			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: start, To: end, Calendars: calendars, Limit: limit, Overlap: true, Fields: eventProjection(p, videoCallNeeds(videoOnly)...)})
 			if err != nil {
 				_ = p.Error(contract.ErrBackendUnavailable, err.Error(), "Run `acal doctor` for remediation")
 				return WrapPrinted(6, err)
 			}
-			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items), "day": start.Format("2006-01-02")}, nil)
+			var warnings []contract.Warning
+			if includeBirthdays {
+				items, warnings = withBirthdayEvents(ctx, be, items, calendars, start, end, loc)
+			}
+			if videoOnly {
+				items = onlyVideoCalls(items)
+			}
+			items = markContinued(items, start)
+			meta := map[string]any{"count": len(items), "day": start.Format("2006-01-02")}
+			if len(providers) > 0 && !noAnnotations {
+				annotations, annotationWarnings := annotateDay(ctx, providers, start)
+				meta["annotations"] = annotations
+				warnings = append(warnings, annotationWarnings...)
+			}
+			return successWithMeta(ctx, p, ro, items, meta, warnings)
 		},
 	}
 	cmd.Flags().StringVar(&day, "day", "today", "Day selector")
 	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit results")
+	cmd.Flags().BoolVar(&includeBirthdays, "include-birthdays", false, "Include contact birthdays")
+	cmd.Flags().BoolVar(&videoOnly, "only-video-calls", false, "Only events with a video-call link")
+	cmd.Flags().BoolVar(&noAnnotations, "no-annotations", false, "Skip [annotations] providers such as sunrise/sunset")
 	return cmd
 }
 
@@ -48,7 +71,7 @@ func newTodayCmd(opts *globalOptions) *cobra.Command {
 	var day string
 	var calendars []string
 	var limit int
-	var summary bool
+	var summary, timeline, includeBirthdays, videoOnly bool
 	cmd := &cobra.Command{
 		Use:   "today",
 		Short: "List events for a day (defaults to today)",
@@ -57,8 +80,11 @@ func newTodayCmd(opts *globalOptions) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if summary && timeline {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--summary and --timeline are mutually exclusive"), "Pick one view", 2)
+			}
 			loc := resolveLocation(ro.TZ)
-			anchor, err := timeparse.ParseDateTime(day, time.Now(), loc)
+			anchor, err := timeparse.ParseDateTime(day, currentTime(), loc)
 			if err != nil {
 				_ = p.Error(contract.ErrInvalidUsage, err.Error(), "Use --day as today, tomorrow, +Nd, or YYYY-MM-DD")
 				return WrapPrinted(2, err)
@@ -66,22 +92,36 @@ func newTodayCmd(opts *globalOptions) *cobra.Command {
 			start, end := dayBounds(anchor)
 			ctx, cancel := commandContext(ro)
 			defer cancel()
-			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: start, To: end, Calendars: calendars, Limit: limit})
+			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: start, To: end, Calendars: calendars, Limit: limit, Overlap: true, Fields: eventProjection(p, videoCallNeeds(videoOnly)...)})
 			if err != nil {
 				_ = p.Error(contract.ErrBackendUnavailable, err.Error(), "Run `acal doctor` for remediation")
 				return WrapPrinted(6, err)
 			}
+			var warnings []contract.Warning
+			if includeBirthdays {
+				items, warnings = withBirthdayEvents(ctx, be, items, calendars, start, end, loc)
+			}
+			if videoOnly {
+				items = onlyVideoCalls(items)
+			}
+			items = markContinued(items, start)
+			if timeline {
+				return successTimeline(ctx, p, ro, buildTimeline(items, start, end, loc), loc, map[string]any{"view": "day", "day": start.Format("2006-01-02")}, warnings)
+			}
 			if summary {
 				rows := summarizeEventsByDay(items, start, end, loc)
-				return successWithMeta(ctx, p, ro, rows, map[string]any{"count": len(rows), "view": "day", "day": start.Format("2006-01-02"), "summary": true}, nil)
+				return successWithMeta(ctx, p, ro, rows, map[string]any{"count": len(rows), "view": "day", "day": start.Format("2006-01-02"), "summary": true}, warnings)
 			}
-			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items), "view": "day", "day": start.Format("2006-01-02")}, nil)
+			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items), "view": "day", "day": start.Format("2006-01-02")}, warnings)
 		},
 	}
 	cmd.Flags().StringVar(&day, "day", "today", "Day selector")
 	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit results")
+	cmd.Flags().BoolVar(&includeBirthdays, "include-birthdays", false, "Include contact birthdays")
+	cmd.Flags().BoolVar(&videoOnly, "only-video-calls", false, "Only events with a video-call link")
 	cmd.Flags().BoolVar(&summary, "summary", false, "Group by day with counts")
+	cmd.Flags().BoolVar(&timeline, "timeline", false, "Draw the day as a time bar with conflicts highlighted")
 	return cmd
 }
 
@@ -90,7 +130,7 @@ func newWeekCmd(opts *globalOptions) *cobra.Command {
 	var weekStart string
 	var calendars []string
 	var limit int
-	var summary bool
+	var summary, timeline, includeBirthdays, videoOnly bool
 	cmd := &cobra.Command{
 		Use:   "week",
 		Short: "List events for a week",
@@ -99,37 +139,54 @@ func newWeekCmd(opts *globalOptions) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if summary && timeline {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--summary and --timeline are mutually exclusive"), "Pick one view", 2)
+			}
 			loc := resolveLocation(ro.TZ)
-			anchor, err := timeparse.ParseDateTime(of, time.Now(), loc)
+			anchor, err := timeparse.ParseDateTime(of, currentTime(), loc)
 			if err != nil {
 				_ = p.Error(contract.ErrInvalidUsage, err.Error(), "Use --of as today, tomorrow, +Nd, or YYYY-MM-DD")
 				return WrapPrinted(2, err)
 			}
 			ws, err := parseWeekStart(weekStart)
 			if err != nil {
-				_ = p.Error(contract.ErrInvalidUsage, err.Error(), "Use --week-start monday|sunday")
+				_ = p.Error(contract.ErrInvalidUsage, err.Error(), "Use --week-start monday|sunday|saturday")
 				return WrapPrinted(2, err)
 			}
 			start, end := weekBounds(anchor, ws)
 			ctx, cancel := commandContext(ro)
 			defer cancel()
-			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: start, To: end, Calendars: calendars, Limit: limit})
+			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: start, To: end, Calendars: calendars, Limit: limit, Overlap: true, Fields: eventProjection(p, videoCallNeeds(videoOnly)...)})
 			if err != nil {
 				_ = p.Error(contract.ErrBackendUnavailable, err.Error(), "Run `acal doctor` for remediation")
 				return WrapPrinted(6, err)
 			}
+			var warnings []contract.Warning
+			if includeBirthdays {
+				items, warnings = withBirthdayEvents(ctx, be, items, calendars, start, end, loc)
+			}
+			if videoOnly {
+				items = onlyVideoCalls(items)
+			}
+			items = markContinued(items, start)
+			if timeline {
+				return successTimeline(ctx, p, ro, buildTimeline(items, start, end, loc), loc, map[string]any{"view": "week", "from": start.Format("2006-01-02"), "to": rangeLastDay(end).Format("2006-01-02"), "week_start": ws.String()}, warnings)
+			}
 			if summary {
 				rows := summarizeEventsByDay(items, start, end, loc)
-				return successWithMeta(ctx, p, ro, rows, map[string]any{"count": len(rows), "view": "week", "from": start.Format("2006-01-02"), "to": end.Format("2006-01-02"), "week_start": ws.String(), "summary": true}, nil)
+				return successWithMeta(ctx, p, ro, rows, map[string]any{"count": len(rows), "view": "week", "from": start.Format("2006-01-02"), "to": rangeLastDay(end).Format("2006-01-02"), "week_start": ws.String(), "summary": true}, warnings)
 			}
-			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items), "view": "week", "from": start.Format("2006-01-02"), "to": end.Format("2006-01-02"), "week_start": ws.String()}, nil)
+			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items), "view": "week", "from": start.Format("2006-01-02"), "to": rangeLastDay(end).Format("2006-01-02"), "week_start": ws.String()}, warnings)
 		},
 	}
 	cmd.Flags().StringVar(&of, "of", "today", "Date selector within target week")
-	cmd.Flags().StringVar(&weekStart, "week-start", "monday", "Week start day: monday|sunday")
+	cmd.Flags().StringVar(&weekStart, "week-start", "", "Week start day: monday|sunday|saturday (default week_start config, then locale)")
 	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit results")
+	cmd.Flags().BoolVar(&includeBirthdays, "include-birthdays", false, "Include contact birthdays")
+	cmd.Flags().BoolVar(&videoOnly, "only-video-calls", false, "Only events with a video-call link")
 	cmd.Flags().BoolVar(&summary, "summary", false, "Group by day with counts")
+	cmd.Flags().BoolVar(&timeline, "timeline", false, "Draw each day as a time bar with conflicts highlighted")
 	return cmd
 }
 
@@ -137,7 +194,8 @@ func newMonthCmd(opts *globalOptions) *cobra.Command {
 	var month string
 	var calendars []string
 	var limit int
-	var summary bool
+	var summary, grid, includeBirthdays bool
+	var weekStart string
 	cmd := &cobra.Command{
 		Use:   "month",
 		Short: "List events for a month",
@@ -147,29 +205,50 @@ func newMonthCmd(opts *globalOptions) *cobra.Command {
 				return err
 			}
 			loc := resolveLocation(ro.TZ)
-			anchor, err := parseMonthOrDate(month, time.Now(), loc)
+			anchor, err := parseMonthOrDate(month, currentTime(), loc)
 			if err != nil {
 				_ = p.Error(contract.ErrInvalidUsage, err.Error(), "Use --month as YYYY-MM, YYYY-MM-DD, or relative day syntax")
 				return WrapPrinted(2, err)
 			}
+			ws, err := parseWeekStart(weekStart)
+			if err != nil {
+				_ = p.Error(contract.ErrInvalidUsage, err.Error(), "Use --week-start monday|sunday|saturday")
+				return WrapPrinted(2, err)
+			}
 			start, end := monthBounds(anchor)
 			ctx, cancel := commandContext(ro)
 			defer cancel()
-			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: start, To: end, Calendars: calendars, Limit: limit})
+			items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: start, To: end, Calendars: calendars, Limit: limit, Overlap: true})
 			if err != nil {
 				_ = p.Error(contract.ErrBackendUnavailable, err.Error(), "Run `acal doctor` for remediation")
 				return WrapPrinted(6, err)
 			}
+			var warnings []contract.Warning
+			if includeBirthdays {
+				items, warnings = withBirthdayEvents(ctx, be, items, calendars, start, end, loc)
+			}
+			items = markContinued(items, start)
+			if grid {
+				g := buildMonthGrid(summarizeEventsByDay(items, start, end, loc), start, ws, currentTime())
+				if p.EffectiveSuccessMode() == output.ModePlain {
+					renderMonthGrid(c.OutOrStdout(), g, start)
+					return nil
+				}
+				return successWithMeta(ctx, p, ro, g, map[string]any{"count": len(items), "view": "month", "month": start.Format("2006-01"), "from": start.Format("2006-01-02"), "to": rangeLastDay(end).Format("2006-01-02"), "week_start": ws.String(), "grid": true}, warnings)
+			}
 			if summary {
 				rows := summarizeEventsByDay(items, start, end, loc)
-				return successWithMeta(ctx, p, ro, rows, map[string]any{"count": len(rows), "view": "month", "month": start.Format("2006-01"), "from": start.Format("2006-01-02"), "to": end.Format("2006-01-02"), "summary": true}, nil)
+				return successWithMeta(ctx, p, ro, rows, map[string]any{"count": len(rows), "view": "month", "month": start.Format("2006-01"), "from": start.Format("2006-01-02"), "to": rangeLastDay(end).Format("2006-01-02"), "summary": true}, warnings)
 			}
-			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items), "view": "month", "month": start.Format("2006-01"), "from": start.Format("2006-01-02"), "to": end.Format("2006-01-02")}, nil)
+			return successWithMeta(ctx, p, ro, items, map[string]any{"count": len(items), "view": "month", "month": start.Format("2006-01"), "from": start.Format("2006-01-02"), "to": rangeLastDay(end).Format("2006-01-02")}, warnings)
 		},
 	}
 	cmd.Flags().StringVar(&month, "month", "today", "Month selector: YYYY-MM, YYYY-MM-DD, today, +Nd")
 	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID or name")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit results")
+	cmd.Flags().BoolVar(&includeBirthdays, "include-birthdays", false, "Include contact birthdays")
 	cmd.Flags().BoolVar(&summary, "summary", false, "Group by day with counts")
+	cmd.Flags().BoolVar(&grid, "grid", false, "Render a calendar grid with per-day event counts")
+	cmd.Flags().StringVar(&weekStart, "week-start", "", "Grid week start day: monday|sunday|saturday (default week_start config, then locale)")
 	return cmd
 }
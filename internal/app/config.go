@@ -12,14 +12,42 @@ import (
 )
 
 type fileConfig struct {
-	Backend        string                `toml:"backend"`
-	TZ             string                `toml:"tz"`
-	Timeout        string                `toml:"timeout"`
-	FailOnDegraded *bool                 `toml:"fail_on_degraded"`
-	Output         string                `toml:"output"`
-	Fields         string                `toml:"fields"`
-	Profile        string                `toml:"profile"`
-	Profiles       map[string]fileConfig `toml:"profiles"`
+	Backend            string                      `toml:"backend"`
+	TZ                 string                      `toml:"tz"`
+	Timeout            string                      `toml:"timeout"`
+	Retries            *int                        `toml:"retries"`
+	RetryBackoff       string                      `toml:"retry_backoff"`
+	MaxWritesPerSec    *float64                    `toml:"max_writes_per_sec"`
+	SharedReads        *bool                       `toml:"shared_reads"`
+	UseDaemon          *bool                       `toml:"use_daemon"`
+	FailOnDegraded     *bool                       `toml:"fail_on_degraded"`
+	Output             string                      `toml:"output"`
+	Fields             string                      `toml:"fields"`
+	Profile            string                      `toml:"profile"`
+	CalDAVURL          string                      `toml:"caldav_url"`
+	CalDAVUser         string                      `toml:"caldav_user"`
+	MockFile           string                      `toml:"mock_file"`
+	HolidaysCalendar   string                      `toml:"holidays_calendar"`
+	HolidaysFile       string                      `toml:"holidays_file"`
+	NotesTemplate      string                      `toml:"notes_template"`
+	WritableCalendars  []string                    `toml:"writable_calendars"`
+	ProtectedCalendars []string                    `toml:"protected_calendars"`
+	Rooms              []string                    `toml:"rooms"`
+	QuietHours         []string                    `toml:"quiet_hours"`
+	SoftDelete         *bool                       `toml:"soft_delete"`
+	HidePrivate        *bool                       `toml:"hide_private"`
+	Locale             string                      `toml:"locale"`
+	WeekStart          string                      `toml:"week_start"`
+	TimeFormat         string                      `toml:"time_format"`
+	IDFormat           string                      `toml:"id_format"`
+	Theme              string                      `toml:"theme"`
+	Backends           map[string]backendConfig    `toml:"backends"`
+	CalendarDefaults   map[string]calendarDefaults `toml:"calendar_defaults"`
+	People             map[string]any              `toml:"people"`
+	Focus              map[string]focusConfig      `toml:"focus"`
+	Lint               lintConfig                  `toml:"lint"`
+	Annotations        annotationConfig            `toml:"annotations"`
+	Profiles           map[string]fileConfig       `toml:"profiles"`
 }
 
 func resolveGlobalOptions(cmd *cobra.Command, defaults *globalOptions) (*globalOptions, error) {
@@ -77,12 +105,122 @@ func applyFileConfig(dst *globalOptions, cfg fileConfig, profile string) {
 			dst.Timeout = d
 		}
 	}
+	if cfg.Retries != nil && *cfg.Retries >= 0 {
+		dst.Retries = *cfg.Retries
+	}
+	if cfg.RetryBackoff != "" {
+		if d, err := time.ParseDuration(cfg.RetryBackoff); err == nil && d > 0 {
+			dst.RetryBackoff = d
+		}
+	}
+	if cfg.MaxWritesPerSec != nil && *cfg.MaxWritesPerSec >= 0 {
+		dst.MaxWritesPerSec = *cfg.MaxWritesPerSec
+	}
+	if cfg.SharedReads != nil {
+		dst.SharedReads = *cfg.SharedReads
+	}
+	if cfg.UseDaemon != nil {
+		dst.UseDaemon = *cfg.UseDaemon
+	}
 	if cfg.FailOnDegraded != nil {
 		dst.FailOnDegraded = *cfg.FailOnDegraded
 	}
 	if cfg.Fields != "" {
 		dst.Fields = cfg.Fields
 	}
+	if cfg.CalDAVURL != "" {
+		dst.CalDAVURL = cfg.CalDAVURL
+	}
+	if cfg.CalDAVUser != "" {
+		dst.CalDAVUser = cfg.CalDAVUser
+	}
+	if cfg.MockFile != "" {
+		dst.MockFile = cfg.MockFile
+	}
+	if cfg.HolidaysCalendar != "" {
+		dst.HolidaysCalendar = cfg.HolidaysCalendar
+	}
+	if cfg.HolidaysFile != "" {
+		dst.HolidaysFile = cfg.HolidaysFile
+	}
+	if cfg.NotesTemplate != "" {
+		dst.NotesTemplate = cfg.NotesTemplate
+	}
+	if cfg.WritableCalendars != nil {
+		dst.WritableCalendars = cfg.WritableCalendars
+	}
+	if cfg.ProtectedCalendars != nil {
+		dst.ProtectedCalendars = cfg.ProtectedCalendars
+	}
+	if cfg.Rooms != nil {
+		dst.Rooms = cfg.Rooms
+	}
+	if cfg.QuietHours != nil {
+		dst.QuietHours = cfg.QuietHours
+	}
+	if cfg.SoftDelete != nil {
+		dst.SoftDelete = *cfg.SoftDelete
+	}
+	if cfg.HidePrivate != nil {
+		dst.HidePrivate = *cfg.HidePrivate
+	}
+	if cfg.Locale != "" {
+		dst.Locale = cfg.Locale
+	}
+	if cfg.WeekStart != "" {
+		dst.WeekStart = cfg.WeekStart
+	}
+	if cfg.TimeFormat != "" {
+		dst.TimeFormat = cfg.TimeFormat
+	}
+	if cfg.IDFormat != "" {
+		dst.IDFormat = cfg.IDFormat
+	}
+	if cfg.Theme != "" {
+		dst.Theme = cfg.Theme
+	}
+	if len(cfg.Backends) > 0 {
+		merged := make(map[string]backendConfig, len(dst.Backends)+len(cfg.Backends))
+		for k, v := range dst.Backends {
+			merged[k] = v
+		}
+		for k, v := range cfg.Backends {
+			merged[k] = v
+		}
+		dst.Backends = merged
+	}
+	if len(cfg.CalendarDefaults) > 0 {
+		merged := make(map[string]calendarDefaults, len(dst.CalendarDefaults)+len(cfg.CalendarDefaults))
+		for k, v := range dst.CalendarDefaults {
+			merged[k] = v
+		}
+		for k, v := range cfg.CalendarDefaults {
+			merged[k] = v
+		}
+		dst.CalendarDefaults = merged
+	}
+	if len(cfg.People) > 0 {
+		merged := make(map[string]person, len(dst.People)+len(cfg.People))
+		for k, v := range dst.People {
+			merged[k] = v
+		}
+		for k, v := range peopleFromConfig(cfg.People) {
+			merged[k] = v
+		}
+		dst.People = merged
+	}
+	if len(cfg.Focus) > 0 {
+		merged := make(map[string]focusConfig, len(dst.Focus)+len(cfg.Focus))
+		for k, v := range dst.Focus {
+			merged[k] = v
+		}
+		for k, v := range cfg.Focus {
+			merged[k] = v
+		}
+		dst.Focus = merged
+	}
+	dst.Lint = dst.Lint.overlay(cfg.Lint)
+	dst.Annotations = dst.Annotations.overlay(cfg.Annotations)
 	if cfg.Output != "" {
 		switch strings.ToLower(cfg.Output) {
 		case "json":
@@ -105,6 +243,21 @@ func mergeFileConfig(base, overlay fileConfig) fileConfig {
 	if overlay.Timeout != "" {
 		base.Timeout = overlay.Timeout
 	}
+	if overlay.Retries != nil {
+		base.Retries = overlay.Retries
+	}
+	if overlay.RetryBackoff != "" {
+		base.RetryBackoff = overlay.RetryBackoff
+	}
+	if overlay.MaxWritesPerSec != nil {
+		base.MaxWritesPerSec = overlay.MaxWritesPerSec
+	}
+	if overlay.SharedReads != nil {
+		base.SharedReads = overlay.SharedReads
+	}
+	if overlay.UseDaemon != nil {
+		base.UseDaemon = overlay.UseDaemon
+	}
 	if overlay.FailOnDegraded != nil {
 		base.FailOnDegraded = overlay.FailOnDegraded
 	}
@@ -117,6 +270,99 @@ func mergeFileConfig(base, overlay fileConfig) fileConfig {
 	if overlay.Profile != "" {
 		base.Profile = overlay.Profile
 	}
+	if overlay.CalDAVURL != "" {
+		base.CalDAVURL = overlay.CalDAVURL
+	}
+	if overlay.CalDAVUser != "" {
+		base.CalDAVUser = overlay.CalDAVUser
+	}
+	if overlay.MockFile != "" {
+		base.MockFile = overlay.MockFile
+	}
+	if overlay.HolidaysCalendar != "" {
+		base.HolidaysCalendar = overlay.HolidaysCalendar
+	}
+	if overlay.HolidaysFile != "" {
+		base.HolidaysFile = overlay.HolidaysFile
+	}
+	if overlay.NotesTemplate != "" {
+		base.NotesTemplate = overlay.NotesTemplate
+	}
+	if overlay.WritableCalendars != nil {
+		base.WritableCalendars = overlay.WritableCalendars
+	}
+	if overlay.ProtectedCalendars != nil {
+		base.ProtectedCalendars = overlay.ProtectedCalendars
+	}
+	if overlay.Rooms != nil {
+		base.Rooms = overlay.Rooms
+	}
+	if overlay.QuietHours != nil {
+		base.QuietHours = overlay.QuietHours
+	}
+	if overlay.SoftDelete != nil {
+		base.SoftDelete = overlay.SoftDelete
+	}
+	if overlay.HidePrivate != nil {
+		base.HidePrivate = overlay.HidePrivate
+	}
+	if overlay.Locale != "" {
+		base.Locale = overlay.Locale
+	}
+	if overlay.WeekStart != "" {
+		base.WeekStart = overlay.WeekStart
+	}
+	if overlay.TimeFormat != "" {
+		base.TimeFormat = overlay.TimeFormat
+	}
+	if overlay.IDFormat != "" {
+		base.IDFormat = overlay.IDFormat
+	}
+	if overlay.Theme != "" {
+		base.Theme = overlay.Theme
+	}
+	if len(overlay.Backends) > 0 {
+		merged := make(map[string]backendConfig, len(base.Backends)+len(overlay.Backends))
+		for k, v := range base.Backends {
+			merged[k] = v
+		}
+		for k, v := range overlay.Backends {
+			merged[k] = v
+		}
+		base.Backends = merged
+	}
+	if len(overlay.CalendarDefaults) > 0 {
+		merged := make(map[string]calendarDefaults, len(base.CalendarDefaults)+len(overlay.CalendarDefaults))
+		for k, v := range base.CalendarDefaults {
+			merged[k] = v
+		}
+		for k, v := range overlay.CalendarDefaults {
+			merged[k] = v
+		}
+		base.CalendarDefaults = merged
+	}
+	if len(overlay.People) > 0 {
+		merged := make(map[string]any, len(base.People)+len(overlay.People))
+		for k, v := range base.People {
+			merged[k] = v
+		}
+		for k, v := range overlay.People {
+			merged[k] = v
+		}
+		base.People = merged
+	}
+	if len(overlay.Focus) > 0 {
+		merged := make(map[string]focusConfig, len(base.Focus)+len(overlay.Focus))
+		for k, v := range base.Focus {
+			merged[k] = v
+		}
+		for k, v := range overlay.Focus {
+			merged[k] = v
+		}
+		base.Focus = merged
+	}
+	base.Lint = base.Lint.overlay(overlay.Lint)
+	base.Annotations = base.Annotations.overlay(overlay.Annotations)
 	return base
 }
 
@@ -132,6 +378,29 @@ func applyEnv(dst *globalOptions) {
 			dst.Timeout = d
 		}
 	}
+	for _, key := range []string{"ACAL_OSASCRIPT_RETRIES", "ACAL_RETRIES"} {
+		if n, err := strconv.Atoi(env(key)); err == nil && n >= 0 {
+			dst.Retries = n
+		}
+	}
+	for _, key := range []string{"ACAL_OSASCRIPT_RETRY_BACKOFF", "ACAL_RETRY_BACKOFF"} {
+		if d, err := time.ParseDuration(env(key)); err == nil && d > 0 {
+			dst.RetryBackoff = d
+		}
+	}
+	if f, err := strconv.ParseFloat(env("ACAL_MAX_WRITES_PER_SEC"), 64); err == nil && f >= 0 {
+		dst.MaxWritesPerSec = f
+	}
+	if v := env("ACAL_SHARED_READS"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			dst.SharedReads = b
+		}
+	}
+	if v := env("ACAL_USE_DAEMON"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			dst.UseDaemon = b
+		}
+	}
 	if v := env("ACAL_FAIL_ON_DEGRADED"); v != "" {
 		if b, err := strconv.ParseBool(v); err == nil {
 			dst.FailOnDegraded = b
@@ -140,6 +409,58 @@ func applyEnv(dst *globalOptions) {
 	if v := env("ACAL_FIELDS"); v != "" {
 		dst.Fields = v
 	}
+	if v := env("ACAL_CALDAV_URL"); v != "" {
+		dst.CalDAVURL = v
+	}
+	if v := env("ACAL_CALDAV_USER"); v != "" {
+		dst.CalDAVUser = v
+	}
+	if v := env("ACAL_MOCK_FILE"); v != "" {
+		dst.MockFile = v
+	}
+	if v := env("ACAL_HOLIDAYS_CALENDAR"); v != "" {
+		dst.HolidaysCalendar = v
+	}
+	if v := env("ACAL_HOLIDAYS_FILE"); v != "" {
+		dst.HolidaysFile = v
+	}
+	if v := env("ACAL_NOTES_TEMPLATE"); v != "" {
+		dst.NotesTemplate = v
+	}
+	if v := env("ACAL_ROOMS"); v != "" {
+		dst.Rooms = splitCSV(v)
+	}
+	if v := env("ACAL_QUIET_HOURS"); v != "" {
+		dst.QuietHours = splitCSV(v)
+	}
+	if v := env("ACAL_SOFT_DELETE"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			dst.SoftDelete = b
+		}
+	}
+	if v := env("ACAL_HIDE_PRIVATE"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			dst.HidePrivate = b
+		}
+	}
+	if v := env("ACAL_LOCALE"); v != "" {
+		dst.Locale = v
+	}
+	if v := env("ACAL_WEEK_START"); v != "" {
+		dst.WeekStart = v
+	}
+	if v := env("ACAL_TIME_FORMAT"); v != "" {
+		dst.TimeFormat = v
+	}
+	if v := env("ACAL_ID_FORMAT"); v != "" {
+		dst.IDFormat = v
+	}
+	if v := env("ACAL_THEME"); v != "" {
+		dst.Theme = v
+	}
+	if v := env("ACAL_NOW"); v != "" {
+		dst.Now = v
+	}
 	if v := env("ACAL_OUTPUT"); v != "" {
 		switch strings.ToLower(v) {
 		case "json":
@@ -165,9 +486,21 @@ func applyFlags(cmd *cobra.Command, dst, fromFlags *globalOptions) {
 	copyIfChanged(cmd, "jsonl", func() { dst.JSONL = fromFlags.JSONL })
 	copyIfChanged(cmd, "plain", func() { dst.Plain = fromFlags.Plain })
 	copyIfChanged(cmd, "fields", func() { dst.Fields = fromFlags.Fields })
+	copyIfChanged(cmd, "header", func() { dst.Header = fromFlags.Header })
+	copyIfChanged(cmd, "no-header", func() { dst.NoHeader = fromFlags.NoHeader })
 	copyIfChanged(cmd, "quiet", func() { dst.Quiet = fromFlags.Quiet })
 	copyIfChanged(cmd, "verbose", func() { dst.Verbose = fromFlags.Verbose })
+	copyIfChanged(cmd, "echo-request", func() { dst.EchoRequest = fromFlags.EchoRequest })
+	copyIfChanged(cmd, "now", func() { dst.Now = fromFlags.Now })
+	copyIfChanged(cmd, "to-inclusive", func() { dst.ToInclusive = fromFlags.ToInclusive })
+	copyIfChanged(cmd, "to-exclusive", func() { dst.ToExclusive = fromFlags.ToExclusive })
+	copyIfChanged(cmd, "range", func() { dst.Range = fromFlags.Range })
 	copyIfChanged(cmd, "no-color", func() { dst.NoColor = fromFlags.NoColor })
+	copyIfChanged(cmd, "hide-private", func() { dst.HidePrivate = fromFlags.HidePrivate })
+	copyIfChanged(cmd, "locale", func() { dst.Locale = fromFlags.Locale })
+	copyIfChanged(cmd, "time-format", func() { dst.TimeFormat = fromFlags.TimeFormat })
+	copyIfChanged(cmd, "id-format", func() { dst.IDFormat = fromFlags.IDFormat })
+	copyIfChanged(cmd, "theme", func() { dst.Theme = fromFlags.Theme })
 	copyIfChanged(cmd, "no-input", func() { dst.NoInput = fromFlags.NoInput })
 	copyIfChanged(cmd, "fail-on-degraded", func() { dst.FailOnDegraded = fromFlags.FailOnDegraded })
 	copyIfChanged(cmd, "profile", func() { dst.Profile = fromFlags.Profile })
@@ -175,7 +508,13 @@ func applyFlags(cmd *cobra.Command, dst, fromFlags *globalOptions) {
 	copyIfChanged(cmd, "backend", func() { dst.Backend = fromFlags.Backend })
 	copyIfChanged(cmd, "tz", func() { dst.TZ = fromFlags.TZ })
 	copyIfChanged(cmd, "timeout", func() { dst.Timeout = fromFlags.Timeout })
+	copyIfChanged(cmd, "retries", func() { dst.Retries = fromFlags.Retries })
+	copyIfChanged(cmd, "retry-backoff", func() { dst.RetryBackoff = fromFlags.RetryBackoff })
+	copyIfChanged(cmd, "max-writes-per-sec", func() { dst.MaxWritesPerSec = fromFlags.MaxWritesPerSec })
 	copyIfChanged(cmd, "schema-version", func() { dst.SchemaVersion = fromFlags.SchemaVersion })
+	copyIfChanged(cmd, "caldav-url", func() { dst.CalDAVURL = fromFlags.CalDAVURL })
+	copyIfChanged(cmd, "caldav-user", func() { dst.CalDAVUser = fromFlags.CalDAVUser })
+	copyIfChanged(cmd, "mock-file", func() { dst.MockFile = fromFlags.MockFile })
 
 	// If exactly one output mode flag is explicitly set, it overrides env/config output mode.
 	modeSet := 0
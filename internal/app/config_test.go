@@ -152,6 +152,138 @@ func TestResolveGlobalOptionsFailOnDegraded(t *testing.T) {
 	}
 }
 
+func TestResolveGlobalOptionsCalDAV(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmp := t.TempDir()
+	if err := os.Chdir(tmp); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	t.Setenv("HOME", tmp)
+	t.Setenv("ACAL_CALDAV_USER", "env-user")
+	cfg := "backend='caldav'\ncaldav_url='https://dav.example.com/cal/'\ncaldav_user='cfg-user'\n"
+	if err := os.WriteFile(filepath.Join(tmp, ".acal.toml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	defaults := &globalOptions{Profile: "default", Backend: "osascript", SchemaVersion: "v1"}
+	cmd := newTestCmd()
+	if err := cmd.ParseFlags([]string{"--caldav-url", "https://flag.example.com/"}); err != nil {
+		t.Fatal(err)
+	}
+	defaults.CalDAVURL = "https://flag.example.com/"
+	resolved, err := resolveGlobalOptions(cmd, defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Backend != "caldav" {
+		t.Fatalf("expected caldav backend from config, got %q", resolved.Backend)
+	}
+	if resolved.CalDAVURL != "https://flag.example.com/" {
+		t.Fatalf("expected flag caldav url, got %q", resolved.CalDAVURL)
+	}
+	if resolved.CalDAVUser != "env-user" {
+		t.Fatalf("expected env caldav user, got %q", resolved.CalDAVUser)
+	}
+}
+
+func TestResolveGlobalOptionsRetries(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmp := t.TempDir()
+	if err := os.Chdir(tmp); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	t.Setenv("HOME", tmp)
+	t.Setenv("ACAL_OSASCRIPT_RETRIES", "5")
+	if err := os.WriteFile(filepath.Join(tmp, ".acal.toml"), []byte("retries=2\nretry_backoff='1s'\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	defaults := &globalOptions{Profile: "default", Backend: "osascript", RetryBackoff: 200 * time.Millisecond, SchemaVersion: "v1"}
+	cmd := newTestCmd()
+	resolved, err := resolveGlobalOptions(cmd, defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Retries != 5 || resolved.RetryBackoff != time.Second {
+		t.Fatalf("expected env retries and config backoff, got retries=%d backoff=%s", resolved.Retries, resolved.RetryBackoff)
+	}
+
+	t.Setenv("ACAL_RETRIES", "1")
+	if err := cmd.ParseFlags([]string{"--retry-backoff", "50ms"}); err != nil {
+		t.Fatal(err)
+	}
+	defaults.RetryBackoff = 50 * time.Millisecond
+	resolved, err = resolveGlobalOptions(cmd, defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Retries != 1 || resolved.RetryBackoff != 50*time.Millisecond {
+		t.Fatalf("expected ACAL_RETRIES and flag backoff, got retries=%d backoff=%s", resolved.Retries, resolved.RetryBackoff)
+	}
+}
+
+func TestResolveGlobalOptionsMaxWritesPerSec(t *testing.T) {
+	t.Setenv("ACAL_MAX_WRITES_PER_SEC", "0.5")
+	defaults := &globalOptions{Profile: "default", Backend: "osascript", MaxWritesPerSec: 4, SchemaVersion: "v1"}
+	cmd := newTestCmd()
+	resolved, err := resolveGlobalOptions(cmd, defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.MaxWritesPerSec != 0.5 {
+		t.Fatalf("expected env write rate, got %v", resolved.MaxWritesPerSec)
+	}
+	if err := cmd.ParseFlags([]string{"--max-writes-per-sec", "0"}); err != nil {
+		t.Fatal(err)
+	}
+	defaults.MaxWritesPerSec = 0
+	resolved, err = resolveGlobalOptions(cmd, defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.MaxWritesPerSec != 0 {
+		t.Fatalf("expected flag to disable pacing, got %v", resolved.MaxWritesPerSec)
+	}
+}
+
+func TestResolveGlobalOptionsSharedReads(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmp := t.TempDir()
+	if err := os.Chdir(tmp); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	t.Setenv("HOME", tmp)
+	if err := os.WriteFile(filepath.Join(tmp, ".acal.toml"), []byte("shared_reads=true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defaults := &globalOptions{Profile: "default", Backend: "osascript", SchemaVersion: "v1"}
+	resolved, err := resolveGlobalOptions(newTestCmd(), defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resolved.SharedReads {
+		t.Fatalf("expected config to turn shared reads on")
+	}
+	t.Setenv("ACAL_SHARED_READS", "false")
+	if resolved, err = resolveGlobalOptions(newTestCmd(), defaults); err != nil || resolved.SharedReads {
+		t.Fatalf("expected env to turn shared reads back off, got %v %v", resolved.SharedReads, err)
+	}
+}
+
 func newTestCmd() *cobra.Command {
 	cmd := &cobra.Command{Use: "test"}
 	cmd.Flags().Bool("json", false, "")
@@ -169,5 +301,11 @@ func newTestCmd() *cobra.Command {
 	cmd.Flags().String("tz", "", "")
 	cmd.Flags().Duration("timeout", 15*time.Second, "")
 	cmd.Flags().String("schema-version", "v1", "")
+	cmd.Flags().String("caldav-url", "", "")
+	cmd.Flags().String("caldav-user", "", "")
+	cmd.Flags().String("mock-file", "", "")
+	cmd.Flags().Int("retries", 0, "")
+	cmd.Flags().Duration("retry-backoff", 200*time.Millisecond, "")
+	cmd.Flags().Float64("max-writes-per-sec", 4, "")
 	return cmd
 }
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"sort"
+	"time"
+)
+
+type recurringConflictRow struct {
+	LeftSeries     string    `json:"left_series"`
+	LeftTitle      string    `json:"left_title"`
+	LeftCalendar   string    `json:"left_calendar"`
+	RightSeries    string    `json:"right_series"`
+	RightTitle     string    `json:"right_title"`
+	RightCalendar  string    `json:"right_calendar"`
+	Occurrences    int       `json:"occurrences"`
+	Weekday        string    `json:"weekday,omitempty"`
+	FirstOverlap   time.Time `json:"first_overlap"`
+	LastOverlap    time.Time `json:"last_overlap"`
+	OverlapMinutes int64     `json:"overlap_minutes"`
+	OccurrenceIDs  []string  `json:"occurrence_ids"`
+}
+
+func buildRecurringConflictRows(rows []conflictRow, minOccurrences int) []recurringConflictRow {
+	if minOccurrences < 2 {
+		minOccurrences = 2
+	}
+	type pairKey struct{ left, right string }
+	groups := map[pairKey]*recurringConflictRow{}
+	weekdays := map[pairKey]map[time.Weekday]bool{}
+	order := []pairKey{}
+	for _, r := range rows {
+		left, right := eventUID(r.LeftID), eventUID(r.RightID)
+		leftTitle, leftCal, rightTitle, rightCal := r.LeftTitle, r.LeftCalendar, r.RightTitle, r.RightCalendar
+		if right < left {
+			left, right = right, left
+			leftTitle, leftCal, rightTitle, rightCal = rightTitle, rightCal, leftTitle, leftCal
+		}
+		if left == right {
+			continue
+		}
+		k := pairKey{left, right}
+		g, ok := groups[k]
+		if !ok {
+			g = &recurringConflictRow{
+				LeftSeries: left, LeftTitle: leftTitle, LeftCalendar: leftCal,
+				RightSeries: right, RightTitle: rightTitle, RightCalendar: rightCal,
+				FirstOverlap: r.OverlapStart, LastOverlap: r.OverlapStart,
+			}
+			groups[k] = g
+			weekdays[k] = map[time.Weekday]bool{}
+			order = append(order, k)
+		}
+		g.Occurrences++
+		g.OverlapMinutes += r.OverlapMinutes
+		g.OccurrenceIDs = append(g.OccurrenceIDs, r.LeftID, r.RightID)
+		if r.OverlapStart.Before(g.FirstOverlap) {
+			g.FirstOverlap = r.OverlapStart
+		}
+		if r.OverlapStart.After(g.LastOverlap) {
+			g.LastOverlap = r.OverlapStart
+		}
+		weekdays[k][r.OverlapStart.Weekday()] = true
+	}
+	out := make([]recurringConflictRow, 0, len(order))
+	for _, k := range order {
+		g := groups[k]
+		if g.Occurrences < minOccurrences {
+			continue
+		}
+		if len(weekdays[k]) == 1 {
+			g.Weekday = g.FirstOverlap.Weekday().String()
+		}
+		out = append(out, *g)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Occurrences != out[j].Occurrences {
+			return out[i].Occurrences > out[j].Occurrences
+		}
+		return out[i].FirstOverlap.Before(out[j].FirstOverlap)
+	})
+	return out
+}
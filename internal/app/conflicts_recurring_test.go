@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestBuildRecurringConflictRowsGroupsSeriesPairs(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	var items []contract.Event
+	for w := 0; w < 3; w++ {
+		day := monday.AddDate(0, 0, 7*w)
+		items = append(items,
+			contract.Event{ID: fmt.Sprintf("standup@%d", day.Unix()), Title: "Standup", CalendarName: "Work", Start: day, End: day.Add(30 * time.Minute)},
+			contract.Event{ID: fmt.Sprintf("planning@%d", day.Unix()), Title: "Planning", CalendarName: "Team", Start: day.Add(15 * time.Minute), End: day.Add(time.Hour)},
+		)
+	}
+	oneOff := monday.AddDate(0, 0, 2)
+	items = append(items,
+		contract.Event{ID: "dentist@1", Title: "Dentist", Start: oneOff, End: oneOff.Add(time.Hour)},
+		contract.Event{ID: "call@2", Title: "Call", Start: oneOff, End: oneOff.Add(time.Hour)},
+	)
+
+	got := buildRecurringConflictRows(buildConflictRows(items, false), 2)
+	if len(got) != 1 {
+		t.Fatalf("expected one standing conflict, got %+v", got)
+	}
+	r := got[0]
+	if r.LeftSeries != "planning" || r.RightSeries != "standup" || r.Occurrences != 3 || r.Weekday != "Monday" {
+		t.Fatalf("unexpected row %+v", r)
+	}
+	if r.OverlapMinutes != 45 || len(r.OccurrenceIDs) != 6 || !r.LastOverlap.Equal(monday.AddDate(0, 0, 14).Add(15*time.Minute)) {
+		t.Fatalf("unexpected totals %+v", r)
+	}
+	if got := buildRecurringConflictRows(buildConflictRows(items, false), 4); len(got) != 0 {
+		t.Fatalf("min occurrences not honored: %+v", got)
+	}
+}
@@ -0,0 +1,366 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	daemonDirName      = "daemon"
+	daemonSocketName   = "daemon.sock"
+	daemonLookbackDays = 7
+	daemonDialTimeout  = 100 * time.Millisecond
+	daemonReplyTimeout = 30 * time.Second
+)
+
+func daemonSocketPath() string {
+	dir := stateDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, daemonDirName, daemonSocketName)
+}
+
+// listenDaemonSocket creates socket inside a user-only directory.
+func listenDaemonSocket(socket string) (net.Listener, error) {
+	dir := filepath.Dir(socket)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return net.Listen("unix", socket)
+}
+
+func daemonBackendKey(ro *globalOptions) string {
+	return strings.Join([]string{strings.ToLower(strings.TrimSpace(ro.Backend)), ro.MockFile, ro.CalDAVURL, os.Getenv("ACAL_CALENDAR_DB")}, "|")
+}
+
+type daemonRequest struct {
+	Op      string               `json:"op"`
+	Backend string               `json:"backend,omitempty"`
+	Stamp   string               `json:"stamp,omitempty"`
+	Filter  *backend.EventFilter `json:"filter,omitempty"`
+}
+
+type daemonReply struct {
+	Hit    bool             `json:"hit,omitempty"`
+	Reason string           `json:"reason,omitempty"`
+	Events []contract.Event `json:"events,omitempty"`
+	Status *daemonStatus    `json:"status,omitempty"`
+	Error  string           `json:"error,omitempty"`
+}
+
+type daemonStatus struct {
+	Running   bool       `json:"running"`
+	Socket    string     `json:"socket"`
+	PID       int        `json:"pid,omitempty"`
+	Backend   string     `json:"backend,omitempty"`
+	From      *time.Time `json:"from,omitempty"`
+	To        *time.Time `json:"to,omitempty"`
+	Events    int        `json:"events"`
+	LoadedAt  *time.Time `json:"loaded_at,omitempty"`
+	Refreshes int        `json:"refreshes"`
+	Served    int        `json:"served"`
+}
+
+type eventSnapshot struct {
+	from, to time.Time
+	stamp    string
+	events   []contract.Event
+	loadedAt time.Time
+}
+
+func (s eventSnapshot) covers(f backend.EventFilter) bool {
+	return !s.loadedAt.IsZero() && !f.From.Before(s.from) && !f.To.After(s.to)
+}
+
+type eventDaemon struct {
+	be        backend.Backend
+	stamper   backend.ChangeStamper
+	ro        *globalOptions
+	key       string
+	days      int
+	mu        sync.Mutex
+	snap      eventSnapshot
+	refreshes int
+	served    int
+}
+
+func (d *eventDaemon) bounds(now time.Time) (time.Time, time.Time) {
+	y, m, day := now.In(resolveLocation(d.ro.TZ)).Date()
+	loc := resolveLocation(d.ro.TZ)
+	return time.Date(y, m, day-daemonLookbackDays, 0, 0, 0, 0, loc), time.Date(y, m, day+d.days+1, 0, 0, 0, 0, loc)
+}
+
+func (d *eventDaemon) current(stamp string) (eventSnapshot, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	from, to := d.bounds(currentTime())
+	if !d.snap.loadedAt.IsZero() && d.snap.stamp == stamp && d.snap.from.Equal(from) && d.snap.to.Equal(to) {
+		return d.snap, nil
+	}
+	ctx, cancel := commandContext(d.ro)
+	defer cancel()
+	// Read the stamp first so a change during the read triggers another reload.
+	fresh, err := d.stamper.ChangeStamp(ctx)
+	if err != nil {
+		return eventSnapshot{}, err
+	}
+	items, err := d.be.ListEvents(ctx, backend.EventFilter{From: from, To: to, Overlap: true})
+	if err != nil {
+		return eventSnapshot{}, err
+	}
+	d.snap = eventSnapshot{from: from, to: to, stamp: fresh, events: items, loadedAt: time.Now().UTC()}
+	d.refreshes++
+	return d.snap, nil
+}
+
+func (d *eventDaemon) status(socket string) *daemonStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	st := &daemonStatus{Running: true, Socket: socket, PID: os.Getpid(), Backend: d.ro.Backend, Events: len(d.snap.events), Refreshes: d.refreshes, Served: d.served}
+	if !d.snap.loadedAt.IsZero() {
+		from, to, loaded := d.snap.from, d.snap.to, d.snap.loadedAt
+		st.From, st.To, st.LoadedAt = &from, &to, &loaded
+	}
+	return st
+}
+
+func (d *eventDaemon) handle(req daemonRequest, socket string) daemonReply {
+	switch req.Op {
+	case "status":
+		return daemonReply{Status: d.status(socket)}
+	case "list_events":
+		if req.Filter == nil {
+			return daemonReply{Error: "list_events requires a filter"}
+		}
+		if req.Backend != d.key {
+			return daemonReply{Reason: "backend differs"}
+		}
+		snap, err := d.current(req.Stamp)
+		if err != nil {
+			return daemonReply{Error: err.Error()}
+		}
+		if !snap.covers(*req.Filter) {
+			return daemonReply{Reason: "range outside the cached window"}
+		}
+		d.mu.Lock()
+		d.served++
+		d.mu.Unlock()
+		return daemonReply{Hit: true, Events: backend.FilterEvents(snap.events, *req.Filter)}
+	default:
+		return daemonReply{Error: fmt.Sprintf("unknown op %q", req.Op)}
+	}
+}
+
+func (d *eventDaemon) serve(ctx context.Context, ln net.Listener, socket string) error {
+	go func() {
+		<-ctx.Done()
+		_ = ln.Close()
+	}()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			_ = conn.SetDeadline(time.Now().Add(daemonReplyTimeout))
+			var req daemonRequest
+			if err := json.NewDecoder(conn).Decode(&req); err != nil {
+				return
+			}
+			_ = json.NewEncoder(conn).Encode(d.handle(req, socket))
+		}()
+	}
+}
+
+func (d *eventDaemon) watch(ctx context.Context, poll time.Duration, errw func(error)) {
+	t := time.NewTicker(poll)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		stamp, err := d.stamper.ChangeStamp(ctx)
+		if err == nil {
+			_, err = d.current(stamp)
+		}
+		if err != nil && ctx.Err() == nil {
+			errw(err)
+		}
+	}
+}
+
+type daemonClientContextKey struct{}
+
+type daemonClient struct {
+	socket string
+	key    string
+}
+
+func daemonListEvents(ctx context.Context, be backend.Backend, f backend.EventFilter) ([]contract.Event, bool) {
+	c, ok := ctx.Value(daemonClientContextKey{}).(daemonClient)
+	if !ok {
+		return nil, false
+	}
+	stamper, ok := be.(backend.ChangeStamper)
+	if !ok {
+		return nil, false
+	}
+	if _, err := os.Stat(c.socket); err != nil {
+		return nil, false
+	}
+	start := time.Now()
+	stamp, err := stamper.ChangeStamp(ctx)
+	if err != nil {
+		return nil, false
+	}
+	reply, err := callDaemon(ctx, c.socket, daemonRequest{Op: "list_events", Backend: c.key, Stamp: stamp, Filter: &f})
+	if err != nil || !reply.Hit {
+		return nil, false
+	}
+	recordTiming(ctx, "daemon.list_events", time.Since(start))
+	return reply.Events, true
+}
+
+func callDaemon(ctx context.Context, socket string, req daemonRequest) (daemonReply, error) {
+	dialer := net.Dialer{Timeout: daemonDialTimeout}
+	conn, err := dialer.DialContext(ctx, "unix", socket)
+	if err != nil {
+		return daemonReply{}, err
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(daemonReplyTimeout)
+	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+		deadline = dl
+	}
+	_ = conn.SetDeadline(deadline)
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return daemonReply{}, err
+	}
+	var reply daemonReply
+	if err := json.NewDecoder(conn).Decode(&reply); err != nil {
+		return daemonReply{}, err
+	}
+	if reply.Error != "" {
+		return reply, errors.New(reply.Error)
+	}
+	return reply, nil
+}
+
+func newDaemonCmd(opts *globalOptions) *cobra.Command {
+	var days int
+	var poll time.Duration
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Serve a warm event cache to other acal invocations",
+		Long: "Keep events from a week ago to --days ahead in memory, reloaded when the calendar changes, and answer event reads from other acal invocations over a unix socket in the state dir.\n\n" +
+			"Runs in the foreground until interrupted; use launchd or a terminal multiplexer to keep it running.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(cmd, opts, "daemon")
+			if err != nil {
+				return err
+			}
+			if days < 1 {
+				return failWithHint(p, contract.ErrInvalidUsage, invalidField("days", fmt.Sprint(days), errors.New("--days must be at least 1")), "Use --days N with N >= 1", 2)
+			}
+			if poll <= 0 {
+				return failWithHint(p, contract.ErrInvalidUsage, invalidField("poll", poll.String(), errors.New("--poll must be positive")), "Use --poll like 2s", 2)
+			}
+			stamper, ok := be.(backend.ChangeStamper)
+			if !ok {
+				return failWithHint(p, contract.ErrInvalidUsage, fmt.Errorf("backend %s cannot report calendar changes", ro.Backend), "Run the daemon with --backend osascript", 2)
+			}
+			socket := daemonSocketPath()
+			if socket == "" {
+				return failWithHint(p, contract.ErrGeneric, errors.New("unable to resolve state directory"), "Set HOME or XDG_STATE_HOME", 1)
+			}
+			if _, err := os.Stat(socket); err == nil {
+				if _, err := callDaemon(context.Background(), socket, daemonRequest{Op: "status"}); err == nil {
+					return failWithHint(p, contract.ErrConflict, fmt.Errorf("a daemon is already serving %s", socket), "Check it with `acal daemon status`", 5)
+				}
+				_ = os.Remove(socket)
+			}
+			d := &eventDaemon{be: be, stamper: stamper, ro: ro, key: daemonBackendKey(ro), days: days}
+			ctx, cancel := commandContext(ro)
+			stamp, err := stamper.ChangeStamp(ctx)
+			if err == nil {
+				_, err = d.current(stamp)
+			}
+			cancel()
+			if err != nil {
+				return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+			}
+			ln, err := listenDaemonSocket(socket)
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Check permissions on the state directory", 1)
+			}
+			defer os.Remove(socket)
+
+			logf := func(format string, args ...any) {
+				if !ro.Quiet {
+					_, _ = fmt.Fprintf(p.Err, "acal daemon: "+format+"\n", args...)
+				}
+			}
+			st := d.status(socket)
+			logf("serving %d events from %s to %s on %s", st.Events, st.From.Format("2006-01-02"), st.To.Format("2006-01-02"), socket)
+			runCtx, stop := context.WithCancel(interruptContext)
+			defer stop()
+			go d.watch(runCtx, poll, func(err error) { logf("reload failed: %v", err) })
+			if err := d.serve(runCtx, ln, socket); err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "", 1)
+			}
+			st = d.status(socket)
+			st.Running = false
+			return p.Success(st, map[string]any{"served": st.Served, "refreshes": st.Refreshes}, nil)
+		},
+	}
+	cmd.Flags().IntVar(&days, "days", 30, "Days ahead to keep in memory")
+	cmd.Flags().DurationVar(&poll, "poll", 2*time.Second, "How often to check the calendar for changes")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show whether a daemon is running and what it holds",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, _, _, err := buildContext(cmd, opts, "daemon.status")
+			if err != nil {
+				return err
+			}
+			return successDaemonStatus(p, daemonSocketPath())
+		},
+	})
+	return cmd
+}
+
+func successDaemonStatus(p output.Printer, socket string) error {
+	st := &daemonStatus{Socket: socket}
+	if socket != "" {
+		if reply, err := callDaemon(context.Background(), socket, daemonRequest{Op: "status"}); err == nil && reply.Status != nil {
+			st = reply.Status
+		}
+	}
+	return p.Success(st, map[string]any{"running": st.Running}, nil)
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventDaemonServesWarmSnapshot(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	pinnedNow.Store(&now)
+	t.Cleanup(func() { pinnedNow.Store(nil) })
+
+	be := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: "a", CalendarName: "Work", Title: "Standup", Start: now.Add(time.Hour), End: now.Add(90 * time.Minute)},
+		{ID: "b", CalendarName: "Home", Title: "Dinner", Start: now.Add(10 * time.Hour), End: now.Add(11 * time.Hour)},
+	}})
+	ro := &globalOptions{Backend: "mock", TZ: "UTC", Timeout: 5 * time.Second}
+	d := &eventDaemon{be: be, stamper: be, ro: ro, key: daemonBackendKey(ro), days: 7}
+
+	dir, err := os.MkdirTemp("", "acald")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socket := filepath.Join(dir, daemonDirName, daemonSocketName)
+	ln, err := listenDaemonSocket(socket)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	if info, err := os.Stat(filepath.Dir(socket)); err != nil || info.Mode().Perm() != 0o700 {
+		t.Fatalf("expected the socket in a 0700 directory, got %v %v", info, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- d.serve(ctx, ln, socket) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	client := context.WithValue(context.Background(), daemonClientContextKey{}, daemonClient{socket: socket, key: d.key})
+	day := backend.EventFilter{From: now.Truncate(24 * time.Hour), To: now.Truncate(24 * time.Hour).Add(24 * time.Hour), Calendars: []string{"Work"}, Overlap: true}
+	items, ok := daemonListEvents(client, be, day)
+	if !ok || len(items) != 1 || items[0].ID != "a" {
+		t.Fatalf("expected a hit with the Work event, got %v %+v", ok, items)
+	}
+
+	if _, err := be.AddEvent(context.Background(), backend.EventCreateInput{Calendar: "Work", Title: "Review", Start: now.Add(3 * time.Hour), End: now.Add(4 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	items, ok = daemonListEvents(client, be, day)
+	if !ok || len(items) != 2 {
+		t.Fatalf("expected the daemon to reload after a write, got %v %+v", ok, items)
+	}
+
+	far := backend.EventFilter{From: now.AddDate(0, 2, 0), To: now.AddDate(0, 2, 1)}
+	if _, ok := daemonListEvents(client, be, far); ok {
+		t.Fatalf("expected a miss outside the cached window")
+	}
+	other := context.WithValue(context.Background(), daemonClientContextKey{}, daemonClient{socket: socket, key: "osascript|||"})
+	if _, ok := daemonListEvents(other, be, day); ok {
+		t.Fatalf("expected a miss for a different backend")
+	}
+	t.Setenv("ACAL_CALENDAR_DB", filepath.Join(dir, "Other.sqlitedb"))
+	if daemonBackendKey(ro) == d.key {
+		t.Fatalf("expected ACAL_CALENDAR_DB to change the backend key")
+	}
+
+	reply, err := callDaemon(context.Background(), socket, daemonRequest{Op: "status"})
+	if err != nil || reply.Status == nil {
+		t.Fatalf("status failed: %v %+v", err, reply)
+	}
+	if !reply.Status.Running || reply.Status.Refreshes != 2 || reply.Status.Served != 2 || reply.Status.Events != 3 {
+		t.Fatalf("unexpected status: %+v", reply.Status)
+	}
+}
+
+func TestDaemonListEventsWithoutDaemon(t *testing.T) {
+	be := backend.NewMockBackend(backend.MockFixture{})
+	ctx := context.WithValue(context.Background(), daemonClientContextKey{}, daemonClient{socket: filepath.Join(t.TempDir(), daemonSocketName), key: "mock||"})
+	if _, ok := daemonListEvents(ctx, be, backend.EventFilter{From: time.Now(), To: time.Now().Add(time.Hour)}); ok {
+		t.Fatalf("expected no hit without a running daemon")
+	}
+}
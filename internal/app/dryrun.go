@@ -0,0 +1,223 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+)
+
+type dryRunPreview struct {
+	Op      string          `json:"op"`
+	ID      string          `json:"id,omitempty"`
+	Before  *contract.Event `json:"before"`
+	After   *contract.Event `json:"after"`
+	Changes []fieldChange   `json:"changes"`
+	extra   []fieldChange
+}
+
+type fieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+func previewAdd(in backend.EventCreateInput) dryRunPreview {
+	ev := contract.Event{
+		CalendarName: in.Calendar,
+		Title:        in.Title,
+		Start:        in.Start,
+		End:          in.End,
+		AllDay:       in.AllDay,
+		Location:     in.Location,
+		Notes:        in.Notes,
+		URL:          in.URL,
+		Status:       in.Status,
+		Availability: in.Availability,
+		Sensitivity:  in.Sensitivity,
+		Tags:         []string{},
+	}
+	pv := dryRunPreview{Op: "add", After: &ev}
+	if in.ReminderOffset != nil {
+		pv.extra = append(pv.extra, fieldChange{Field: "reminder", After: in.ReminderOffset.String()})
+	}
+	if in.RepeatRule != "" {
+		pv.extra = append(pv.extra, fieldChange{Field: "repeat", After: in.RepeatRule})
+	}
+	return pv
+}
+
+func previewUpdate(current *contract.Event, in backend.EventUpdateInput) dryRunPreview {
+	next := *current
+	set := func(dst *string, v *string) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	set(&next.Title, in.Title)
+	set(&next.Location, in.Location)
+	set(&next.Notes, in.Notes)
+	set(&next.URL, in.URL)
+	set(&next.Status, in.Status)
+	set(&next.Availability, in.Availability)
+	set(&next.Sensitivity, in.Sensitivity)
+	if in.Start != nil {
+		next.Start = *in.Start
+	}
+	if in.End != nil {
+		next.End = *in.End
+	}
+	if in.AllDay != nil {
+		next.AllDay = *in.AllDay
+	}
+	pv := dryRunPreview{Op: "update", ID: current.ID, Before: current, After: &next}
+	if in.ReminderOffset != nil {
+		pv.extra = append(pv.extra, fieldChange{Field: "reminder", After: in.ReminderOffset.String()})
+	} else if in.ClearReminder {
+		pv.extra = append(pv.extra, fieldChange{Field: "reminder", Before: "set", After: nil})
+	}
+	if in.RepeatRule != nil {
+		pv.extra = append(pv.extra, fieldChange{Field: "repeat", After: *in.RepeatRule})
+	}
+	return pv
+}
+
+func previewDelete(current *contract.Event) dryRunPreview {
+	return dryRunPreview{Op: "delete", ID: current.ID, Before: current}
+}
+
+func snapshotForPreview(ctx context.Context, be backend.Backend, id string) (*contract.Event, error) {
+	ev, err := getEventByIDWithTimeout(ctx, be, id)
+	if err != nil {
+		return nil, fmt.Errorf("dry run cannot read %s: %w", id, err)
+	}
+	return ev, nil
+}
+
+func previewFields(ev *contract.Event) []fieldChange {
+	if ev == nil {
+		return nil
+	}
+	return []fieldChange{
+		{Field: "calendar", After: firstNonEmpty(ev.CalendarName, ev.CalendarID)},
+		{Field: "title", After: ev.Title},
+		{Field: "start", After: ev.Start},
+		{Field: "end", After: ev.End},
+		{Field: "all_day", After: ev.AllDay},
+		{Field: "location", After: ev.Location},
+		{Field: "notes", After: ev.Notes},
+		{Field: "url", After: ev.URL},
+		{Field: "status", After: ev.Status},
+		{Field: "availability", After: ev.Availability},
+		{Field: "sensitivity", After: ev.Sensitivity},
+	}
+}
+
+func (pv dryRunPreview) changes() []fieldChange {
+	out := []fieldChange{}
+	before, after := previewFields(pv.Before), previewFields(pv.After)
+	for i := range max(len(before), len(after)) {
+		c := fieldChange{}
+		if i < len(before) {
+			c.Field, c.Before = before[i].Field, before[i].After
+		}
+		if i < len(after) {
+			c.Field, c.After = after[i].Field, after[i].After
+		}
+		if !sameChangeValue(c.Before, c.After) {
+			out = append(out, c)
+		}
+	}
+	return append(out, pv.extra...)
+}
+
+func sameChangeValue(a, b any) bool {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	if a == nil || a == "" || a == false {
+		return b == nil || b == "" || b == false
+	}
+	return a == b
+}
+
+func finishPreviews(p output.Printer, previews []dryRunPreview) []dryRunPreview {
+	if p.HidePrivate {
+		previews = output.MaskPrivate(previews).([]dryRunPreview)
+	}
+	for i := range previews {
+		previews[i].Changes = previews[i].changes()
+	}
+	return previews
+}
+
+func successDryRun(ctx context.Context, p output.Printer, ro *globalOptions, previews []dryRunPreview, meta map[string]any, warnings []contract.Warning) error {
+	previews = finishPreviews(p, previews)
+	if meta == nil {
+		meta = map[string]any{}
+	}
+	meta["dry_run"] = true
+	if _, ok := meta["count"]; !ok {
+		meta["count"] = len(previews)
+	}
+	if p.EffectiveSuccessMode() == output.ModePlain {
+		p.PrintWarnings(commandWarnings(ctx, warnings))
+		if !p.Quiet {
+			renderDryRun(p.Out, previews, p.FormatTime)
+		}
+		return nil
+	}
+	return successWithMeta(ctx, p, ro, previews, meta, warnings)
+}
+
+var dryRunMarks = map[string]string{"add": "+", "update": "~", "delete": "-"}
+
+func renderDryRun(w io.Writer, previews []dryRunPreview, formatTime func(time.Time) string) {
+	if formatTime == nil {
+		formatTime = func(t time.Time) string { return t.Format(time.RFC3339) }
+	}
+	show := func(v any) string {
+		switch x := v.(type) {
+		case nil:
+			return "-"
+		case time.Time:
+			return formatTime(x)
+		case string:
+			if x == "" {
+				return `""`
+			}
+			return fmt.Sprintf("%q", x)
+		default:
+			return fmt.Sprint(x)
+		}
+	}
+	for _, pv := range previews {
+		title := ""
+		if pv.Before != nil {
+			title = pv.Before.Title
+		} else if pv.After != nil {
+			title = pv.After.Title
+		}
+		head := dryRunMarks[pv.Op] + " " + pv.Op
+		if pv.ID != "" {
+			head += " " + pv.ID
+		}
+		_, _ = fmt.Fprintf(w, "%s %s\n", head, show(title))
+		for _, c := range pv.Changes {
+			switch {
+			case pv.Op == "add":
+				_, _ = fmt.Fprintf(w, "    %s: %s\n", c.Field, show(c.After))
+			case pv.Op == "update":
+				_, _ = fmt.Fprintf(w, "    %s: %s -> %s\n", c.Field, show(c.Before), show(c.After))
+			}
+		}
+		if pv.Op == "update" && len(pv.Changes) == 0 {
+			_, _ = fmt.Fprintln(w, "    (no changes)")
+		}
+	}
+}
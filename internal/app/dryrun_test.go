@@ -0,0 +1,59 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestDryRunPreviewBeforeAfter(t *testing.T) {
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "plan", CalendarID: "work", CalendarName: "Work", Title: "Planning", Start: start, End: start.Add(time.Hour)},
+			{ID: "doc", CalendarID: "work", CalendarName: "Work", Title: "Doctor", Sensitivity: contract.SensitivityPrivate, Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)},
+		},
+	})
+	var env struct {
+		Data []dryRunPreview `json:"data"`
+		Meta map[string]any  `json:"meta"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "update", "plan", "--title", "Planning v2", "--start", "2026-03-03T11:00:00Z", "--dry-run", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	pv := env.Data[0]
+	if pv.Op != "update" || pv.Before.Title != "Planning" || pv.After.Title != "Planning v2" || env.Meta["dry_run"] != true {
+		t.Fatalf("unexpected preview: %+v", pv)
+	}
+	if len(pv.Changes) != 2 || pv.Changes[0].Field != "title" || pv.Changes[1].Field != "start" {
+		t.Fatalf("expected title and start changes, got %+v", pv.Changes)
+	}
+
+	out := string(runWithBackend(t, fb, "events", "update", "plan", "--title", "Planning v2", "--dry-run", "--plain"))
+	if out != "~ update plan \"Planning\"\n    title: \"Planning\" -> \"Planning v2\"\n" {
+		t.Fatalf("unexpected plain diff: %q", out)
+	}
+
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "delete", "plan", "--force", "--dry-run", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if pv := env.Data[0]; pv.Op != "delete" || pv.Before == nil || pv.Before.Title != "Planning" || pv.After != nil {
+		t.Fatalf("unexpected delete preview: %+v", pv)
+	}
+	if code := runEventsCmd(t, fb, "events", "delete", "missing", "--force", "--dry-run", "--json"); code != 4 {
+		t.Fatalf("dry-run delete of a missing event exit = %d, want 4", code)
+	}
+
+	out = string(runWithBackend(t, fb, "events", "update", "doc", "--location", "Clinic", "--dry-run", "--hide-private", "--json"))
+	if strings.Contains(out, "Doctor") || strings.Contains(out, "Clinic") {
+		t.Fatalf("--hide-private leaked a private event into the preview: %s", out)
+	}
+	if got, _ := fb.GetEventByID(t.Context(), "plan"); got == nil || got.Title != "Planning" {
+		t.Fatalf("dry runs must not write, got %+v", got)
+	}
+}
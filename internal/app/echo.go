@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+)
+
+type requestEcho struct {
+	From      *time.Time    `json:"from,omitempty"`
+	To        *time.Time    `json:"to,omitempty"`
+	TZ        string        `json:"tz"`
+	Calendars []string      `json:"calendars,omitempty"`
+	Limit     int           `json:"limit,omitempty"`
+	Query     string        `json:"query,omitempty"`
+	Field     string        `json:"field,omitempty"`
+	Where     []echoClause  `json:"where,omitempty"`
+	Sort      *echoOrdering `json:"sort,omitempty"`
+}
+
+type echoClause struct {
+	Field string `json:"field"`
+	Op    string `json:"op"`
+	Value string `json:"value"`
+}
+
+type echoOrdering struct {
+	Field string `json:"field"`
+	Order string `json:"order"`
+}
+
+type requestEchoContextKey struct{}
+
+type requestRecorder struct {
+	mu   sync.Mutex
+	echo requestEcho
+	seen bool
+}
+
+func recordRequestFilter(ctx context.Context, f backend.EventFilter) {
+	rec, _ := ctx.Value(requestEchoContextKey{}).(*requestRecorder)
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if rec.seen {
+		return
+	}
+	rec.seen = true
+	from, to := f.From, f.To
+	rec.echo.From, rec.echo.To = &from, &to
+	rec.echo.Calendars = append([]string(nil), f.Calendars...)
+	rec.echo.Limit = f.Limit
+	rec.echo.Query, rec.echo.Field = f.Query, f.Field
+}
+
+func recordRequestQuery(ctx context.Context, preds []predicate, sortField, order string) {
+	rec, _ := ctx.Value(requestEchoContextKey{}).(*requestRecorder)
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.echo.Where = make([]echoClause, 0, len(preds))
+	for _, p := range preds {
+		rec.echo.Where = append(rec.echo.Where, echoClause{Field: p.field, Op: p.op, Value: p.value})
+	}
+	rec.echo.Sort = &echoOrdering{Field: firstNonEmpty(sortField, "start"), Order: firstNonEmpty(order, "asc")}
+}
+
+func requestFromContext(ctx context.Context, tz string) *requestEcho {
+	rec, _ := ctx.Value(requestEchoContextKey{}).(*requestRecorder)
+	if rec == nil {
+		return nil
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	out := rec.echo
+	out.TZ = resolveLocation(tz).String()
+	return &out
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEchoRequestReportsResolvedQuery(t *testing.T) {
+	fb := backend.NewMockBackend(backend.MockFixture{Calendars: []contract.Calendar{{ID: "work", Name: "Work"}}})
+	out := runWithBackend(t, fb, "events", "query", "--tz", "Europe/Berlin", "--from", "2026-03-03", "--to", "2026-03-05", "--calendar", "Work", "--where", "title~sync", "--sort", "title", "--limit", "5", "--echo-request", "--json")
+	var env struct {
+		Request requestEcho `json:"request"`
+	}
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	r := env.Request
+	if r.TZ != "Europe/Berlin" || r.From == nil || r.To == nil {
+		t.Fatalf("missing resolved range: %+v", r)
+	}
+	if got := r.From.Format(time.RFC3339); got != "2026-03-03T00:00:00+01:00" {
+		t.Fatalf("unexpected from %s", got)
+	}
+	if got := r.To.Format(time.RFC3339); got != "2026-03-06T00:00:00+01:00" {
+		t.Fatalf("unexpected to %s", got)
+	}
+	if len(r.Calendars) != 1 || r.Limit != 5 || len(r.Where) != 1 || r.Where[0] != (echoClause{Field: "title", Op: "~", Value: "sync"}) {
+		t.Fatalf("unexpected echo %+v", r)
+	}
+	if r.Sort == nil || *r.Sort != (echoOrdering{Field: "title", Order: "asc"}) {
+		t.Fatalf("unexpected sort echo %+v", r.Sort)
+	}
+
+	out = runWithBackend(t, fb, "events", "list", "--from", "2026-03-03", "--to", "2026-03-04", "--json")
+	if strings.Contains(string(out), `"request"`) {
+		t.Fatalf("request block must be opt-in: %s", out)
+	}
+}
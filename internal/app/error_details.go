@@ -0,0 +1,80 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+const maxStderrExcerpt = 1000
+
+type fieldError struct {
+	Field string
+	Value string
+	Err   error
+}
+
+func (e *fieldError) Error() string { return e.Err.Error() }
+
+func (e *fieldError) Unwrap() error { return e.Err }
+
+func invalidField(field, value string, err error) error {
+	return &fieldError{Field: field, Value: value, Err: err}
+}
+
+type eventIDsError struct {
+	IDs []string
+	Err error
+}
+
+func (e *eventIDsError) Error() string { return e.Err.Error() }
+
+func (e *eventIDsError) Unwrap() error { return e.Err }
+
+func withEventIDs(err error, ids ...string) error {
+	return &eventIDsError{IDs: ids, Err: err}
+}
+
+func errorDetails(err error) *contract.ErrorDetails {
+	d := &contract.ErrorDetails{}
+	var fe *fieldError
+	if errors.As(err, &fe) {
+		d.Fields = []contract.FieldError{{Field: fe.Field, Value: fe.Value, Message: fe.Err.Error()}}
+	}
+	var ie *eventIDsError
+	if errors.As(err, &ie) {
+		d.EventIDs = ie.IDs
+	}
+	var bce *backendContextError
+	if errors.As(err, &bce) {
+		d.Backend = &contract.BackendErrorDetails{Phase: bce.Phase, Kind: bce.Kind}
+		if bce.Deadline != nil {
+			d.Backend.Deadline = bce.Deadline.Format(time.RFC3339)
+		}
+	}
+	var ce *backend.CommandError
+	if errors.As(err, &ce) {
+		if d.Backend == nil {
+			d.Backend = &contract.BackendErrorDetails{}
+		}
+		d.Backend.Command = ce.Command
+		d.Backend.Stderr = stderrExcerpt(ce.Output)
+	}
+	if d.Fields == nil && d.EventIDs == nil && d.Backend == nil {
+		return nil
+	}
+	return d
+}
+
+func stderrExcerpt(s string) string {
+	if len(s) <= maxStderrExcerpt {
+		return s
+	}
+	cut := len(s) - maxStderrExcerpt
+	for cut < len(s) && s[cut]&0xC0 == 0x80 {
+		cut++
+	}
+	return "…" + s[cut:]
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+func eventETag(e contract.Event) string {
+	h := sha256.New()
+	for _, v := range []string{
+		e.CalendarID,
+		e.Title,
+		e.Start.UTC().Format(time.RFC3339Nano),
+		e.End.UTC().Format(time.RFC3339Nano),
+		strconv.FormatBool(e.AllDay),
+		e.Location,
+		strings.TrimRight(e.Notes, "\n"),
+		e.URL,
+		e.Status,
+		e.Availability,
+		e.Sensitivity,
+	} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func withETag(e *contract.Event) *contract.Event {
+	if e != nil {
+		e.ETag = eventETag(*e)
+	}
+	return e
+}
+
+func withEventsETag(items []contract.Event) []contract.Event {
+	for i := range items {
+		withETag(&items[i])
+	}
+	return items
+}
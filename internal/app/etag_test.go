@@ -0,0 +1,72 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventETagStableAndSensitive(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	base := contract.Event{ID: "evt@1", CalendarID: "work", Title: "Planning", Start: start, End: start.Add(time.Hour), Notes: "agenda\n"}
+	tag := eventETag(base)
+	if len(tag) != 16 {
+		t.Fatalf("unexpected etag length: %q", tag)
+	}
+
+	same := base
+	same.Sequence = 9
+	same.Start = start.In(time.FixedZone("X", 3600))
+	same.Notes = "agenda"
+	if got := eventETag(same); got != tag {
+		t.Fatalf("etag changed for equivalent event: %s vs %s", got, tag)
+	}
+
+	changed := base
+	changed.Title = "Planning v2"
+	if got := eventETag(changed); got == tag {
+		t.Fatalf("etag did not change after title edit")
+	}
+}
+
+func TestEventsUpdateIfMatchETag(t *testing.T) {
+	current := &contract.Event{ID: "evt@792417600", Title: "Planning", Start: time.Now(), End: time.Now().Add(time.Hour)}
+	tag := eventETag(*current)
+
+	fb := &scopeCaptureBackend{getEvent: current}
+	if code := runEventsCmd(t, fb, "events", "update", "evt@792417600", "--if-match-etag", "deadbeefdeadbeef", "--title", "x", "--json"); code != 7 {
+		t.Fatalf("expected exit 7 on etag mismatch, got %d", code)
+	}
+	if fb.updateCalls != 0 {
+		t.Fatalf("update should not run on etag mismatch")
+	}
+
+	fb = &scopeCaptureBackend{getEvent: current}
+	if code := runEventsCmd(t, fb, "events", "update", "evt@792417600", "--if-match-etag", tag, "--title", "x", "--json"); code != 0 {
+		t.Fatalf("expected exit 0 on etag match, got %d", code)
+	}
+	if fb.updateCalls != 1 {
+		t.Fatalf("expected one update call, got %d", fb.updateCalls)
+	}
+}
+
+func TestEventsDeleteIfMatchETag(t *testing.T) {
+	current := &contract.Event{ID: "evt@792417600", Title: "Planning", Start: time.Now(), End: time.Now().Add(time.Hour)}
+
+	fb := &scopeCaptureBackend{getEvent: current}
+	if code := runEventsCmd(t, fb, "events", "delete", "evt@792417600", "--force", "--if-match-etag", "deadbeefdeadbeef", "--json"); code != 7 {
+		t.Fatalf("expected exit 7 on etag mismatch, got %d", code)
+	}
+	if fb.deleteCalls != 0 {
+		t.Fatalf("delete should not run on etag mismatch")
+	}
+
+	fb = &scopeCaptureBackend{getEvent: current}
+	if code := runEventsCmd(t, fb, "events", "delete", "evt@792417600", "--force", "--if-match-etag", eventETag(*current), "--json"); code != 0 {
+		t.Fatalf("expected exit 0 on etag match, got %d", code)
+	}
+	if fb.deleteCalls != 1 {
+		t.Fatalf("expected one delete call, got %d", fb.deleteCalls)
+	}
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"sort"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+type eventContext struct {
+	Event     contract.Event   `json:"event"`
+	Previous  *contract.Event  `json:"previous"`
+	Next      *contract.Event  `json:"next"`
+	Conflicts []contract.Event `json:"conflicts"`
+}
+
+func eventContextWindow(e contract.Event, loc *time.Location) (time.Time, time.Time) {
+	dayStart, _ := dayBounds(e.Start.In(loc))
+	_, dayEnd := dayBounds(e.End.Add(-time.Nanosecond).In(loc))
+	if dayEnd.Before(dayStart) {
+		_, dayEnd = dayBounds(e.Start.In(loc))
+	}
+	return dayStart, dayEnd
+}
+
+func buildEventContext(target contract.Event, items []contract.Event) eventContext {
+	out := eventContext{Event: target, Conflicts: []contract.Event{}}
+	others := make([]contract.Event, 0, len(items))
+	for _, it := range items {
+		if it.ID == target.ID || it.AllDay {
+			continue
+		}
+		others = append(others, it)
+	}
+	sort.SliceStable(others, func(i, j int) bool { return others[i].Start.Before(others[j].Start) })
+	for i := range others {
+		it := others[i]
+		switch {
+		case !target.AllDay && it.Start.Before(target.End) && it.End.After(target.Start):
+			out.Conflicts = append(out.Conflicts, it)
+		case !it.End.After(target.Start):
+			if out.Previous == nil || it.End.After(out.Previous.End) {
+				out.Previous = &others[i]
+			}
+		case !it.Start.Before(target.End):
+			if out.Next == nil {
+				out.Next = &others[i]
+			}
+		}
+	}
+	return out
+}
+
+func eventContextMeta(c eventContext) map[string]any {
+	meta := map[string]any{"count": 1, "conflicts": len(c.Conflicts)}
+	if c.Previous != nil {
+		meta["gap_before_minutes"] = int64(c.Event.Start.Sub(c.Previous.End).Minutes())
+	}
+	if c.Next != nil {
+		meta["gap_after_minutes"] = int64(c.Next.Start.Sub(c.Event.End).Minutes())
+	}
+	return meta
+}
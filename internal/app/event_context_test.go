@@ -0,0 +1,59 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestBuildEventContext(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 3, 2, h, m, 0, 0, time.UTC) }
+	target := contract.Event{ID: "t", Start: at(12, 0), End: at(13, 0)}
+	items := []contract.Event{
+		target,
+		{ID: "early", Start: at(8, 0), End: at(9, 0)},
+		{ID: "prev", Start: at(10, 0), End: at(11, 30)},
+		{ID: "overlap", Start: at(12, 30), End: at(14, 0)},
+		{ID: "next", Start: at(13, 15), End: at(13, 45)},
+		{ID: "later", Start: at(16, 0), End: at(17, 0)},
+		{ID: "holiday", Start: at(0, 0), End: at(0, 0).AddDate(0, 0, 1), AllDay: true},
+	}
+	c := buildEventContext(target, items)
+	if c.Previous == nil || c.Previous.ID != "prev" {
+		t.Fatalf("expected prev, got %+v", c.Previous)
+	}
+	if c.Next == nil || c.Next.ID != "next" {
+		t.Fatalf("expected next, got %+v", c.Next)
+	}
+	if len(c.Conflicts) != 1 || c.Conflicts[0].ID != "overlap" {
+		t.Fatalf("expected overlap conflict only, got %+v", c.Conflicts)
+	}
+	meta := eventContextMeta(c)
+	if meta["gap_before_minutes"] != int64(30) || meta["gap_after_minutes"] != int64(15) {
+		t.Fatalf("unexpected gaps: %v", meta)
+	}
+}
+
+func TestEventsShowContext(t *testing.T) {
+	fb := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: "a", CalendarName: "Work", Title: "A", Start: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)},
+		{ID: "b", CalendarName: "Work", Title: "B", Start: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 2, 11, 0, 0, 0, time.UTC)},
+		{ID: "c", CalendarName: "Work", Title: "C", Start: time.Date(2026, 3, 2, 10, 30, 0, 0, time.UTC), End: time.Date(2026, 3, 2, 11, 30, 0, 0, time.UTC)},
+	}})
+	var env struct {
+		Data eventContext   `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "show", "b", "--context", "--tz", "UTC", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Data.Event.ID != "b" || env.Data.Previous == nil || env.Data.Previous.ID != "a" || env.Data.Next != nil {
+		t.Fatalf("unexpected context: %+v", env.Data)
+	}
+	if len(env.Data.Conflicts) != 1 || env.Data.Conflicts[0].ID != "c" || env.Meta["conflicts"] != float64(1) {
+		t.Fatalf("unexpected conflicts: %+v meta=%v", env.Data.Conflicts, env.Meta)
+	}
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// eventInput uses pointer fields to tell absent from empty.
+type eventInput struct {
+	ID           *string   `json:"id"`
+	CalendarID   *string   `json:"calendar_id"`
+	CalendarName *string   `json:"calendar_name"`
+	Title        *string   `json:"title"`
+	Start        *string   `json:"start"`
+	End          *string   `json:"end"`
+	AllDay       *bool     `json:"all_day"`
+	Location     *string   `json:"location"`
+	Notes        *string   `json:"notes"`
+	URL          *string   `json:"url"`
+	Status       *string   `json:"status"`
+	Availability *string   `json:"availability"`
+	Sensitivity  *string   `json:"sensitivity"`
+	Tags         *[]string `json:"tags"`
+}
+
+func readEventInput(path string) (eventInput, error) {
+	var in eventInput
+	raw, err := readTextInput(path)
+	if err != nil {
+		return in, err
+	}
+	body := []byte(strings.TrimSpace(raw))
+	if len(body) == 0 {
+		return in, errors.New("event input is empty")
+	}
+	var env struct {
+		SchemaVersion string          `json:"schema_version"`
+		Data          json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(body, &env) == nil && env.SchemaVersion != "" && len(env.Data) > 0 {
+		body = env.Data
+	}
+	if err := json.Unmarshal(body, &in); err != nil {
+		return in, fmt.Errorf("event input must be a single event JSON object: %w", err)
+	}
+	return in, nil
+}
+
+func applyEventInput(cmd *cobra.Command, in eventInput, baseNotes func() (string, error)) error {
+	flags := cmd.Flags()
+	set := func(name string, v *string) error {
+		if v == nil || flags.Lookup(name) == nil || flags.Changed(name) {
+			return nil
+		}
+		return flags.Set(name, *v)
+	}
+	calendar := in.CalendarName
+	if in.CalendarID != nil && strings.TrimSpace(*in.CalendarID) != "" {
+		calendar = in.CalendarID
+	}
+	for _, f := range []struct {
+		name string
+		v    *string
+	}{{"calendar", calendar}, {"title", in.Title}, {"start", in.Start}, {"location", in.Location}, {"url", in.URL}, {"status", in.Status}, {"availability", in.Availability}, {"sensitivity", in.Sensitivity}} {
+		if err := set(f.name, f.v); err != nil {
+			return fmt.Errorf("invalid %s in event input: %w", f.name, err)
+		}
+	}
+	if !flags.Changed("duration") {
+		if err := set("end", in.End); err != nil {
+			return fmt.Errorf("invalid end in event input: %w", err)
+		}
+	}
+	if in.AllDay != nil {
+		v := strconv.FormatBool(*in.AllDay)
+		if err := set("all-day", &v); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("notes") || flags.Changed("notes-file") || (in.Notes == nil && in.Tags == nil) {
+		return nil
+	}
+	notes := ""
+	if in.Notes != nil {
+		notes = *in.Notes
+	} else if baseNotes != nil {
+		current, err := baseNotes()
+		if err != nil {
+			return err
+		}
+		notes = current
+	}
+	if in.Tags != nil {
+		tags, err := applyTagOps(nil, *in.Tags)
+		if err != nil {
+			return err
+		}
+		notes = setTagsMarker(notes, tags)
+	}
+	return set("notes", &notes)
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func writeEventInput(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func runEventsCmd(t *testing.T, fb backend.Backend, args ...string) int {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+	cmd := NewRootCommand()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs(args)
+	return ExitCode(cmd.Execute())
+}
+
+func TestEventsAddFromInputEnvelope(t *testing.T) {
+	path := writeEventInput(t, `{"schema_version":"v1","command":"events.show","data":{"id":"old@1","calendar_id":"work","calendar_name":"Work","title":"Planning","start":"2026-03-02T09:00:00Z","end":"2026-03-02T10:00:00Z","all_day":false,"location":"Room 1","notes":"Bring laptop","url":"","sequence":3,"tags":["q1"]}}`)
+	fb := &scopeCaptureBackend{}
+	if code := runEventsCmd(t, fb, "events", "add", "--input", path, "--title", "Planning v2", "--json"); code != 0 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	in := fb.addInput
+	if in.Calendar != "work" || in.Title != "Planning v2" || in.Location != "Room 1" {
+		t.Fatalf("unexpected add input: %+v", in)
+	}
+	if !in.Start.Equal(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)) || !in.End.Equal(time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected times: %s - %s", in.Start, in.End)
+	}
+	if in.Notes != "Bring laptop\nacal:tags=q1" {
+		t.Fatalf("expected tags marker in notes, got %q", in.Notes)
+	}
+}
+
+func TestEventsUpdateFromInputPatchesOnlySentFields(t *testing.T) {
+	path := writeEventInput(t, `{"id":"evt@792417600","title":"Renamed","tags":["focus"]}`)
+	fb := &scopeCaptureBackend{getEvent: &contract.Event{ID: "evt@792417600", Start: time.Now(), Notes: "keep me\nacal:tags=old"}}
+	if code := runEventsCmd(t, fb, "events", "update", "evt@792417600", "--input", path, "--json"); code != 0 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	patch := fb.updateInput
+	if patch.Title == nil || *patch.Title != "Renamed" {
+		t.Fatalf("expected title patch, got %+v", patch.Title)
+	}
+	if patch.Notes == nil || *patch.Notes != "keep me\nacal:tags=focus" {
+		t.Fatalf("expected retagged notes, got %+v", patch.Notes)
+	}
+	if patch.Start != nil || patch.End != nil || patch.Location != nil || patch.AllDay != nil || patch.URL != nil {
+		t.Fatalf("unexpected fields patched: %+v", patch)
+	}
+}
+
+func TestEventsInputValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		args []string
+	}{
+		{name: "list input", body: `[{"title":"x"}]`, args: []string{"events", "add"}},
+		{name: "missing required", body: `{"title":"x"}`, args: []string{"events", "add"}},
+		{name: "bad start", body: `{"calendar_name":"Work","title":"x","start":"someday"}`, args: []string{"events", "add"}},
+		{name: "id mismatch", body: `{"id":"other@1","title":"x"}`, args: []string{"events", "update", "evt@792417600"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fb := &scopeCaptureBackend{}
+			args := append(tc.args, "--input", writeEventInput(t, tc.body), "--json")
+			if code := runEventsCmd(t, fb, args...); code != 2 {
+				t.Fatalf("expected exit 2, got %d", code)
+			}
+			if fb.addCalls+fb.updateCalls != 0 {
+				t.Fatalf("expected no writes, got add=%d update=%d", fb.addCalls, fb.updateCalls)
+			}
+		})
+	}
+}
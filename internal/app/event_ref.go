@@ -0,0 +1,147 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+)
+
+const eventRefHorizon = 30 * 24 * time.Hour
+
+const uidRefHorizon = 366 * 24 * time.Hour
+
+var (
+	errUnknownEventRef = errors.New("unknown event reference")
+	errEventRefNoMatch = errors.New("no event matches reference")
+)
+
+var eventRefNames = []string{"@next", "@current", "@last-created"}
+
+// resolveEventRef leaves a bare UID alone so it still names the whole series.
+func resolveEventRef(ctx context.Context, be backend.Backend, ref string, now time.Time) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if !strings.HasPrefix(ref, "@") {
+		return resolveAlias(ref), nil
+	}
+	switch strings.ToLower(ref) {
+	case "@next", "@current":
+		items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: now, To: now.Add(eventRefHorizon), Overlap: true})
+		if err != nil {
+			return "", err
+		}
+		var e *contract.Event
+		if strings.EqualFold(ref, "@next") {
+			e = nextEvent(items, now)
+		} else {
+			e = currentEvent(items, now)
+		}
+		if e == nil {
+			return "", fmt.Errorf("%w %s", errEventRefNoMatch, ref)
+		}
+		return e.ID, nil
+	case "@last-created":
+		entries, err := readHistory()
+		if err != nil {
+			return "", err
+		}
+		if id := lastCreatedID(entries); id != "" {
+			return id, nil
+		}
+		return "", fmt.Errorf("%w %s", errEventRefNoMatch, ref)
+	default:
+		return "", fmt.Errorf("%w: %s", errUnknownEventRef, ref)
+	}
+}
+
+// getEventByRef retries a bare UID as the series' next occurrence.
+func getEventByRef(ctx context.Context, be backend.Backend, id string, now time.Time) (*contract.Event, error) {
+	item, err := getEventByIDWithTimeout(ctx, be, id)
+	if err == nil || ctx.Err() != nil {
+		return item, err
+	}
+	if occ := resolveUIDRef(ctx, be, id, now); occ != id {
+		return getEventByIDWithTimeout(ctx, be, occ)
+	}
+	return nil, err
+}
+
+func resolveUIDRef(ctx context.Context, be backend.Backend, ref string, now time.Time) string {
+	if _, ok := backend.EventUID(ref); ok || ref == "" {
+		return ref
+	}
+	items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: now, To: now.Add(uidRefHorizon), Overlap: true})
+	if err != nil {
+		return ref
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Start.Before(items[j].Start) })
+	for _, e := range items {
+		if uid, _ := backend.EventUID(e.ID); e.ID == ref || uid == ref {
+			return e.ID
+		}
+	}
+	return ref
+}
+
+func failEventRef(p output.Printer, err error) error {
+	switch {
+	case errors.Is(err, errUnknownEventRef):
+		return failWithHint(p, contract.ErrInvalidUsage, err, "Use an event ID or one of "+strings.Join(eventRefNames, ", "), 2)
+	case errors.Is(err, errEventRefNoMatch):
+		return failWithHint(p, contract.ErrNotFound, err, "Check upcoming events with `acal agenda` or recent writes with `acal history list`", 4)
+	default:
+		return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+	}
+}
+
+func timedEvents(items []contract.Event) []contract.Event {
+	out := make([]contract.Event, 0, len(items))
+	for _, e := range items {
+		if !e.AllDay {
+			out = append(out, e)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Start.Before(out[j].Start) })
+	return out
+}
+
+func nextEvent(items []contract.Event, now time.Time) *contract.Event {
+	for _, e := range timedEvents(items) {
+		if e.Start.After(now) {
+			return &e
+		}
+	}
+	return nil
+}
+
+func currentEvent(items []contract.Event, now time.Time) *contract.Event {
+	var out *contract.Event
+	for _, e := range timedEvents(items) {
+		if !e.Start.After(now) && e.End.After(now) {
+			out = &e
+		}
+	}
+	return out
+}
+
+func lastCreatedID(entries []historyEntry) string {
+	deleted := map[string]bool{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		h := entries[i]
+		switch h.Type {
+		case "delete":
+			deleted[h.EventID] = true
+		case "add":
+			if h.EventID != "" && !deleted[h.EventID] {
+				return h.EventID
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestResolveEventRefSelection(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 15, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return time.Date(2026, 3, 2, h, m, 0, 0, time.UTC) }
+	items := []contract.Event{
+		{ID: "allday", Start: at(0, 0), End: at(0, 0).AddDate(0, 0, 1), AllDay: true},
+		{ID: "later", Start: at(14, 0), End: at(15, 0)},
+		{ID: "long", Start: at(9, 0), End: at(12, 0)},
+		{ID: "now", Start: at(10, 0), End: at(10, 30)},
+		{ID: "soon", Start: at(11, 0), End: at(11, 30)},
+	}
+	if e := nextEvent(items, now); e == nil || e.ID != "soon" {
+		t.Fatalf("expected soon as @next, got %+v", e)
+	}
+	if e := currentEvent(items, now); e == nil || e.ID != "now" {
+		t.Fatalf("expected most recently started event as @current, got %+v", e)
+	}
+	if e := currentEvent(items, at(16, 0)); e != nil {
+		t.Fatalf("expected no current event, got %+v", e)
+	}
+	history := []historyEntry{
+		{Type: "add", EventID: "a"},
+		{Type: "add", EventID: "b"},
+		{Type: "update", EventID: "a"},
+		{Type: "delete", EventID: "b"},
+	}
+	if id := lastCreatedID(history); id != "a" {
+		t.Fatalf("expected a, got %q", id)
+	}
+}
+
+func TestResolveEventRefPassthroughAndErrors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fb := backend.NewMockBackend(backend.MockFixture{})
+	now := time.Now()
+	if id, err := resolveEventRef(context.Background(), fb, "abc@123", now); err != nil || id != "abc@123" {
+		t.Fatalf("expected plain ID passthrough, got %q %v", id, err)
+	}
+	if _, err := resolveEventRef(context.Background(), fb, "@yesterday", now); !errors.Is(err, errUnknownEventRef) {
+		t.Fatalf("expected unknown reference error, got %v", err)
+	}
+	for _, ref := range []string{"@next", "@current", "@last-created"} {
+		if _, err := resolveEventRef(context.Background(), fb, ref, now); !errors.Is(err, errEventRefNoMatch) {
+			t.Fatalf("%s: expected no-match error, got %v", ref, err)
+		}
+	}
+}
+
+func TestEventsShowResolvesRefs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now().UTC().Truncate(time.Minute)
+	fb := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: "running", CalendarName: "Work", Title: "Running", Start: now.Add(-30 * time.Minute), End: now.Add(30 * time.Minute)},
+		{ID: "upcoming", CalendarName: "Work", Title: "Upcoming", Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour)},
+	}})
+	for ref, want := range map[string]string{"@current": "running", "@next": "upcoming"} {
+		var env struct {
+			Data contract.Event `json:"data"`
+		}
+		if err := json.Unmarshal(runWithBackend(t, fb, "events", "show", ref, "--json"), &env); err != nil {
+			t.Fatal(err)
+		}
+		if env.Data.ID != want {
+			t.Fatalf("%s: expected %s, got %s", ref, want, env.Data.ID)
+		}
+	}
+}
+
+func TestIDFormatOutputAndUIDRefs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now().UTC().Truncate(time.Minute)
+	past, next := now.Add(-24*time.Hour), now.Add(24*time.Hour)
+	fb := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: fmt.Sprintf("standup@%d", past.Unix()), CalendarName: "Work", Title: "Standup", Start: past, End: past.Add(15 * time.Minute)},
+		{ID: fmt.Sprintf("standup@%d", next.Unix()), CalendarName: "Work", Title: "Standup", Start: next, End: next.Add(15 * time.Minute)},
+	}})
+
+	var env struct {
+		Data []contract.Event `json:"data"`
+	}
+	from := now.Add(-48 * time.Hour).Format(time.RFC3339)
+	to := now.Add(48 * time.Hour).Format(time.RFC3339)
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "list", "--from", from, "--to", to, "--id-format", "uid", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 2 || env.Data[0].ID != "standup" || env.Data[1].ID != "standup" {
+		t.Fatalf("expected uid-only IDs, got %+v", env.Data)
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "list", "--from", from, "--to", to, "--id-format", "short", "--json"), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 2 || env.Data[0].ID != env.Data[0].Alias || env.Data[0].ID == "" {
+		t.Fatalf("expected alias IDs, got %+v", env.Data)
+	}
+
+	item, err := getEventByRef(context.Background(), fb, "standup", now)
+	if err != nil || item.ID != fmt.Sprintf("standup@%d", next.Unix()) {
+		t.Fatalf("expected reads to resolve the next occurrence, got %+v %v", item, err)
+	}
+	if _, err := getEventByRef(context.Background(), fb, "gone", now); err == nil {
+		t.Fatalf("expected an unknown uid to stay not found")
+	}
+	// Write commands keep a bare UID, so --scope auto still means the series.
+	if id, _ := resolveEventRef(context.Background(), fb, "standup", now); id != "standup" {
+		t.Fatalf("expected a bare uid to pass through for writes, got %q", id)
+	}
+	alias := env.Data[0].Alias
+	// runWithBackend fails the test unless --confirm accepts the argument as given.
+	runWithBackend(t, fb, "events", "delete", alias, "--confirm", alias, "--no-input", "--dry-run", "--json")
+	if _, err := eventIDFormatter("long"); err == nil {
+		t.Fatalf("expected an invalid --id-format to fail")
+	}
+}
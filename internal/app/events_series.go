@@ -0,0 +1,92 @@
+package app
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+func seriesUID(id string) string {
+	id = strings.TrimSpace(id)
+	if i := strings.LastIndex(id, "@"); i > 0 {
+		if _, err := strconv.ParseInt(id[i+1:], 10, 64); err == nil {
+			return id[:i]
+		}
+	}
+	return id
+}
+
+func seriesFromEvents(uid string, items []contract.Event) *backend.Series {
+	s := &backend.Series{UID: uid, ExceptionDates: []time.Time{}, Occurrences: []backend.SeriesOccurrence{}}
+	for _, e := range items {
+		if seriesUID(e.ID) != uid {
+			continue
+		}
+		if len(s.Occurrences) == 0 {
+			s.CalendarID, s.CalendarName, s.Title, s.Start, s.End = e.CalendarID, e.CalendarName, e.Title, e.Start, e.End
+		}
+		s.Occurrences = append(s.Occurrences, backend.SeriesOccurrence{ID: e.ID, Start: e.Start, End: e.End})
+	}
+	return s
+}
+
+func seriesMeta(s *backend.Series) map[string]any {
+	detached := 0
+	for _, o := range s.Occurrences {
+		if o.Detached {
+			detached++
+		}
+	}
+	return map[string]any{"count": len(s.Occurrences), "detached": detached, "exceptions": len(s.ExceptionDates), "recurring": s.Rule != ""}
+}
+
+func newEventsSeriesCmd(opts *globalOptions) *cobra.Command {
+	var fromS, toS string
+	cmd := &cobra.Command{
+		Use:   "series <uid|event-id>",
+		Short: "Inspect a recurring series: rule, exceptions, and occurrences",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, be, ro, err := buildContext(cmd, opts, "events.series")
+			if err != nil {
+				return err
+			}
+			f, err := buildEventFilterWithTZ(fromS, toS, nil, 0, ro.TZ)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Use valid --from/--to values", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			id, err := resolveEventRef(ctx, be, args[0], currentTime())
+			if err != nil {
+				return failEventRef(p, err)
+			}
+			uid := seriesUID(id)
+			var warnings []contract.Warning
+			s, err := inspectSeriesWithTimeout(ctx, be, uid, f.From, f.To)
+			if errors.Is(err, backend.ErrSeriesUnsupported) {
+				items, listErr := listEventsWithTimeout(ctx, be, f)
+				if listErr != nil {
+					return failWithHint(p, contract.ErrBackendUnavailable, listErr, "Run `acal doctor` for remediation", 6)
+				}
+				s, err = seriesFromEvents(uid, items), nil
+				if len(s.Occurrences) == 0 {
+					err = errors.New("no occurrences found for " + uid)
+				}
+				warnings = append(warnings, contract.Warning{Code: contract.WarnRecurrenceUnavailable, Message: "backend does not expose recurrence details; showing occurrences only"})
+			}
+			if err != nil {
+				return failWithHint(p, contract.ErrNotFound, err, "Check ID with `acal events list --fields id,title,start` or widen --from/--to", 4)
+			}
+			return successWithMeta(ctx, p, ro, s, seriesMeta(s), warnings)
+		},
+	}
+	cmd.Flags().StringVar(&fromS, "from", "today", "Range start for listed occurrences")
+	cmd.Flags().StringVar(&toS, "to", "+180d", "Range end for listed occurrences")
+	return cmd
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+type seriesInspectorBackend struct {
+	*backend.MockBackend
+	series  *backend.Series
+	lastUID string
+}
+
+func (b *seriesInspectorBackend) InspectSeries(_ context.Context, uid string, _, _ time.Time) (*backend.Series, error) {
+	b.lastUID = uid
+	return b.series, nil
+}
+
+func TestSeriesUID(t *testing.T) {
+	cases := map[string]string{
+		"evt-1@792417600":       "evt-1",
+		"evt-1":                 "evt-1",
+		"user@example.com":      "user@example.com",
+		"apple:evt@1@792417600": "apple:evt@1",
+	}
+	for in, want := range cases {
+		if got := seriesUID(in); got != want {
+			t.Fatalf("seriesUID(%q)=%q want %q", in, got, want)
+		}
+	}
+}
+
+func TestEventsSeriesUsesInspector(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	orig := start.AddDate(0, 0, 7)
+	fb := &seriesInspectorBackend{
+		MockBackend: backend.NewMockBackend(backend.MockFixture{}),
+		series: &backend.Series{
+			UID:            "evt-1",
+			Rule:           "FREQ=WEEKLY;COUNT=3",
+			ExceptionDates: []time.Time{start.AddDate(0, 0, 14)},
+			Occurrences: []backend.SeriesOccurrence{
+				{ID: "evt-1@1", Start: start, End: start.Add(time.Hour)},
+				{ID: "evt-1@2", Start: orig.Add(time.Hour), End: orig.Add(2 * time.Hour), Detached: true, OriginalStart: &orig},
+			},
+		},
+	}
+	out := runWithBackend(t, fb, "events", "series", "evt-1@792417600", "--json")
+	if fb.lastUID != "evt-1" {
+		t.Fatalf("expected occurrence suffix stripped, got %q", fb.lastUID)
+	}
+	var env struct {
+		Data     backend.Series `json:"data"`
+		Meta     map[string]any `json:"meta"`
+		Warnings []string       `json:"warnings"`
+	}
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	if env.Data.Rule != "FREQ=WEEKLY;COUNT=3" || len(env.Data.Occurrences) != 2 || !env.Data.Occurrences[1].Detached {
+		t.Fatalf("unexpected series: %+v", env.Data)
+	}
+	if env.Meta["detached"] != float64(1) || env.Meta["exceptions"] != float64(1) || env.Meta["recurring"] != true {
+		t.Fatalf("unexpected meta: %+v", env.Meta)
+	}
+	if len(env.Warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", env.Warnings)
+	}
+}
+
+func TestEventsSeriesFallsBackToOccurrences(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Events: []contract.Event{
+			{ID: "evt-1@1", CalendarName: "Work", Title: "Standup", Start: start, End: start.Add(time.Hour)},
+			{ID: "evt-2@1", CalendarName: "Work", Title: "Other", Start: start, End: start.Add(time.Hour)},
+			{ID: "evt-1@2", CalendarName: "Work", Title: "Standup", Start: start.AddDate(0, 0, 7), End: start.AddDate(0, 0, 7).Add(time.Hour)},
+		},
+	})
+	out := runWithBackend(t, fb, "events", "series", "evt-1", "--from", "2026-03-01", "--to", "2026-03-31", "--json")
+	var env struct {
+		Data         backend.Series         `json:"data"`
+		Warnings     []string               `json:"warnings"`
+		WarningCodes []contract.WarningCode `json:"warning_codes"`
+	}
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	if env.Data.Title != "Standup" || len(env.Data.Occurrences) != 2 || env.Data.Rule != "" {
+		t.Fatalf("unexpected fallback series: %+v", env.Data)
+	}
+	if len(env.Warnings) != 1 || len(env.WarningCodes) != 1 || env.WarningCodes[0] != contract.WarnRecurrenceUnavailable {
+		t.Fatalf("expected fallback warning, got %v %v", env.Warnings, env.WarningCodes)
+	}
+
+	if code := runEventsCmd(t, fb, "events", "series", "missing", "--from", "2026-03-01", "--to", "2026-03-31", "--json"); code != 4 {
+		t.Fatalf("expected exit 4 for unknown series, got %d", code)
+	}
+}
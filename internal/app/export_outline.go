@@ -0,0 +1,122 @@
+package app
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+var exportFormats = []string{"ics", "org", "taskpaper"}
+
+func orgTimestamp(e contract.Event, loc *time.Location) string {
+	start, end := e.Start.In(loc), e.End.In(loc)
+	day := func(t time.Time) string { return t.Format("2006-01-02 Mon") }
+	if e.AllDay {
+		last := end.AddDate(0, 0, -1)
+		if !last.After(start) {
+			return "<" + day(start) + ">"
+		}
+		return "<" + day(start) + ">--<" + day(last) + ">"
+	}
+	sy, sm, sd := start.Date()
+	if ey, em, ed := end.Date(); sy == ey && sm == em && sd == ed {
+		return "<" + day(start) + " " + start.Format("15:04") + "-" + end.Format("15:04") + ">"
+	}
+	return "<" + day(start) + " " + start.Format("15:04") + ">--<" + day(end) + " " + end.Format("15:04") + ">"
+}
+
+func orgTag(tag string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_@#%", r) {
+			return r
+		}
+		return '_'
+	}, tag)
+}
+
+func indentLines(text, prefix string) string {
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = prefix + l
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func buildOrg(items []contract.Event, loc *time.Location) string {
+	var b strings.Builder
+	for _, e := range items {
+		b.WriteString("* " + strings.ReplaceAll(firstNonEmpty(strings.TrimSpace(e.Title), "(untitled)"), "\n", " "))
+		if len(e.Tags) > 0 {
+			tags := make([]string, 0, len(e.Tags))
+			for _, t := range e.Tags {
+				tags = append(tags, orgTag(t))
+			}
+			b.WriteString(" :" + strings.Join(tags, ":") + ":")
+		}
+		b.WriteString("\n  SCHEDULED: " + orgTimestamp(e, loc) + "\n")
+		b.WriteString("  :PROPERTIES:\n")
+		for _, kv := range [][2]string{
+			{"ID", e.ID},
+			{"CALENDAR", firstNonEmpty(e.CalendarName, e.CalendarID)},
+			{"LOCATION", e.Location},
+			{"URL", firstNonEmpty(e.MeetingURL, e.URL)},
+		} {
+			if v := strings.TrimSpace(strings.ReplaceAll(kv[1], "\n", " ")); v != "" {
+				b.WriteString("  :" + kv[0] + ": " + v + "\n")
+			}
+		}
+		b.WriteString("  :END:\n")
+		if strings.TrimSpace(e.Notes) != "" {
+			b.WriteString(indentLines(e.Notes, "  "))
+		}
+	}
+	return b.String()
+}
+
+func taskPaperValue(v string) string {
+	return strings.NewReplacer("(", "[", ")", "]", "\n", " ").Replace(strings.TrimSpace(v))
+}
+
+func buildTaskPaper(items []contract.Event, loc *time.Location) string {
+	order := []string{}
+	byCal := map[string][]contract.Event{}
+	for _, e := range items {
+		cal := firstNonEmpty(e.CalendarName, e.CalendarID, "Calendar")
+		if _, ok := byCal[cal]; !ok {
+			order = append(order, cal)
+		}
+		byCal[cal] = append(byCal[cal], e)
+	}
+	var b strings.Builder
+	for i, cal := range order {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(strings.ReplaceAll(cal, ":", "") + ":\n")
+		for _, e := range byCal[cal] {
+			layout := "2006-01-02 15:04"
+			start, end := e.Start.In(loc), e.End.In(loc)
+			if e.AllDay {
+				layout = "2006-01-02"
+				if end = end.AddDate(0, 0, -1); end.Before(start) {
+					end = start
+				}
+			}
+			b.WriteString("\t- " + strings.ReplaceAll(firstNonEmpty(strings.TrimSpace(e.Title), "(untitled)"), "\n", " "))
+			b.WriteString(" @start(" + start.Format(layout) + ") @end(" + end.Format(layout) + ")")
+			if e.Location != "" {
+				b.WriteString(" @location(" + taskPaperValue(e.Location) + ")")
+			}
+			for _, t := range e.Tags {
+				b.WriteString(" @" + orgTag(t))
+			}
+			b.WriteString(" @id(" + taskPaperValue(e.ID) + ")\n")
+			if strings.TrimSpace(e.Notes) != "" {
+				b.WriteString(indentLines(e.Notes, "\t\t"))
+			}
+		}
+	}
+	return b.String()
+}
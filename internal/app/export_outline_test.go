@@ -0,0 +1,71 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func outlineFixture() []contract.Event {
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	return []contract.Event{
+		{ID: "e1", CalendarName: "Work", Title: "Design review", Location: "Room (4)", Start: start, End: start.Add(time.Hour), Tags: []string{"q2-plan"}, Notes: "* agenda\nsecond line"},
+		{ID: "e2", CalendarName: "Home", Title: "Trip", Start: day, End: day.AddDate(0, 0, 3), AllDay: true},
+	}
+}
+
+func TestBuildOrgScheduledEntries(t *testing.T) {
+	got := buildOrg(outlineFixture(), time.UTC)
+	want := "* Design review :q2_plan:\n" +
+		"  SCHEDULED: <2026-03-02 Mon 10:00-11:00>\n" +
+		"  :PROPERTIES:\n" +
+		"  :ID: e1\n" +
+		"  :CALENDAR: Work\n" +
+		"  :LOCATION: Room (4)\n" +
+		"  :END:\n" +
+		"  * agenda\n" +
+		"  second line\n" +
+		"* Trip\n" +
+		"  SCHEDULED: <2026-03-04 Wed>--<2026-03-06 Fri>\n" +
+		"  :PROPERTIES:\n" +
+		"  :ID: e2\n" +
+		"  :CALENDAR: Home\n" +
+		"  :END:\n"
+	if got != want {
+		t.Fatalf("unexpected org output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestBuildTaskPaperProjectsPerCalendar(t *testing.T) {
+	got := buildTaskPaper(outlineFixture(), time.UTC)
+	want := "Work:\n" +
+		"\t- Design review @start(2026-03-02 10:00) @end(2026-03-02 11:00) @location(Room [4]) @q2_plan @id(e1)\n" +
+		"\t\t* agenda\n" +
+		"\t\tsecond line\n" +
+		"\n" +
+		"Home:\n" +
+		"\t- Trip @start(2026-03-04) @end(2026-03-06) @id(e2)\n"
+	if got != want {
+		t.Fatalf("unexpected taskpaper output:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestEventsExportFormatFlag(t *testing.T) {
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "e1", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC), End: time.Date(2026, 2, 20, 9, 30, 0, 0, time.UTC)},
+		},
+	})
+	out := string(runWithBackend(t, fb, "events", "export", "--from", "2026-02-20", "--to", "2026-02-21", "--tz", "UTC", "--format", "org", "--plain"))
+	if !strings.HasPrefix(out, "* Standup\n  SCHEDULED: <2026-02-20 Fri 09:00-09:30>\n") {
+		t.Fatalf("unexpected org export:\n%s", out)
+	}
+	if code := runEventsCmd(t, fb, "events", "export", "--format", "csv", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for unknown format, got %d", code)
+	}
+}
@@ -0,0 +1,222 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+)
+
+type focusConfig struct {
+	Days  string `toml:"days"`
+	Hours string `toml:"hours"`
+}
+
+type focusPeriod struct {
+	Name   string
+	Days   []time.Weekday
+	Start  int
+	End    int
+	Source string
+}
+
+func (fp focusPeriod) onDay(wd time.Weekday) bool {
+	return len(fp.Days) == 0 || containsWeekday(fp.Days, wd)
+}
+
+func (fp focusPeriod) covers(t time.Time) bool {
+	m := t.Hour()*60 + t.Minute()
+	switch {
+	case fp.Start == fp.End:
+		return fp.onDay(t.Weekday())
+	case fp.Start < fp.End:
+		return fp.onDay(t.Weekday()) && m >= fp.Start && m < fp.End
+	case m >= fp.Start:
+		return fp.onDay(t.Weekday())
+	case m < fp.End:
+		return fp.onDay(t.AddDate(0, 0, -1).Weekday())
+	}
+	return false
+}
+
+func (fp focusPeriod) overlaps(start, end time.Time, loc *time.Location) bool {
+	first, _ := dayBounds(start.In(loc))
+	for day := first.AddDate(0, 0, -1); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !fp.onDay(day.Weekday()) {
+			continue
+		}
+		ps := time.Date(day.Year(), day.Month(), day.Day(), 0, fp.Start, 0, 0, loc)
+		pe := time.Date(day.Year(), day.Month(), day.Day(), 0, fp.End, 0, 0, loc)
+		if !pe.After(ps) {
+			pe = pe.AddDate(0, 0, 1)
+		}
+		if ps.Before(end) && start.Before(pe) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseFocusConfig(name string, c focusConfig) (focusPeriod, error) {
+	fp := focusPeriod{Name: name, Source: "config"}
+	switch days := strings.ToLower(strings.TrimSpace(c.Days)); days {
+	case "", "daily":
+	case "weekdays":
+		fp.Days = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	case "weekends":
+		fp.Days = []time.Weekday{time.Saturday, time.Sunday}
+	default:
+		wds, err := parseWeekdays(days)
+		if err != nil {
+			return focusPeriod{}, err
+		}
+		fp.Days = wds
+	}
+	from, to, ok := strings.Cut(c.Hours, "-")
+	if !ok {
+		return focusPeriod{}, fmt.Errorf("invalid hours %q: use HH:MM-HH:MM", c.Hours)
+	}
+	sh, sm, err := timeparse.ParseClock(strings.TrimSpace(from))
+	if err != nil {
+		return focusPeriod{}, err
+	}
+	eh, em, err := timeparse.ParseClock(strings.TrimSpace(to))
+	if err != nil {
+		return focusPeriod{}, err
+	}
+	fp.Start, fp.End = sh*60+sm, eh*60+em
+	return fp, nil
+}
+
+func loadFocusPeriods(cfg map[string]focusConfig) ([]focusPeriod, error) {
+	names := make([]string, 0, len(cfg))
+	for name := range cfg {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := []focusPeriod{}
+	for _, name := range names {
+		fp, err := parseFocusConfig(name, cfg[name])
+		if err != nil {
+			return nil, fmt.Errorf("focus.%s: %w", name, err)
+		}
+		out = append(out, fp)
+	}
+	if raw, err := os.ReadFile(focusConfigurationsPath()); err == nil {
+		out = append(out, systemFocusPeriods(raw)...)
+	}
+	return out, nil
+}
+
+var focusConfigurationsPath = func() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "Library", "DoNotDisturb", "DB", "ModeConfigurations.json")
+}
+
+type dndModeConfigurations struct {
+	Data []struct {
+		ModeConfigurations map[string]struct {
+			Mode struct {
+				Name string `json:"name"`
+			} `json:"mode"`
+			Triggers struct {
+				Triggers []struct {
+					Class       string `json:"class"`
+					Enabled     int    `json:"enabledSetting"`
+					StartHour   int    `json:"timePeriodStartTimeHour"`
+					StartMinute int    `json:"timePeriodStartTimeMinute"`
+					EndHour     int    `json:"timePeriodEndTimeHour"`
+					EndMinute   int    `json:"timePeriodEndTimeMinute"`
+					Weekdays    int    `json:"timePeriodWeekdays"`
+				} `json:"triggers"`
+			} `json:"triggers"`
+		} `json:"modeConfigurations"`
+	} `json:"data"`
+}
+
+// systemFocusPeriods reads weekday masks with bit 0 for Sunday.
+func systemFocusPeriods(raw []byte) []focusPeriod {
+	var doc dndModeConfigurations
+	if json.Unmarshal(raw, &doc) != nil {
+		return nil
+	}
+	out := []focusPeriod{}
+	for _, d := range doc.Data {
+		for _, mc := range d.ModeConfigurations {
+			for _, tr := range mc.Triggers.Triggers {
+				if tr.Class != "DNDModeConfigurationScheduleTrigger" || tr.Enabled == 1 || mc.Mode.Name == "" {
+					continue
+				}
+				fp := focusPeriod{Name: mc.Mode.Name, Start: tr.StartHour*60 + tr.StartMinute, End: tr.EndHour*60 + tr.EndMinute, Source: "system"}
+				for wd := time.Sunday; wd <= time.Saturday; wd++ {
+					if tr.Weekdays&(1<<wd) != 0 {
+						fp.Days = append(fp.Days, wd)
+					}
+				}
+				out = append(out, fp)
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+type focusContextKey struct{}
+
+type focusContext struct {
+	periods []focusPeriod
+	loc     *time.Location
+}
+
+func focusFromContext(ctx context.Context) focusContext {
+	fc, _ := ctx.Value(focusContextKey{}).(focusContext)
+	return fc
+}
+
+func (fc focusContext) at(e contract.Event) string {
+	if e.AllDay || len(fc.periods) == 0 {
+		return ""
+	}
+	t := e.Start.In(fc.loc)
+	for _, fp := range fc.periods {
+		if fp.covers(t) {
+			return fp.Name
+		}
+	}
+	return ""
+}
+
+func withEventsFocus(ctx context.Context, items []contract.Event) []contract.Event {
+	fc := focusFromContext(ctx)
+	if len(fc.periods) == 0 {
+		return items
+	}
+	for i := range items {
+		items[i].Focus = fc.at(items[i])
+	}
+	return items
+}
+
+func excludeFocusSlots(slots []slotRow, periods []focusPeriod, loc *time.Location) []slotRow {
+	out := make([]slotRow, 0, len(slots))
+	for _, s := range slots {
+		blocked := false
+		for _, fp := range periods {
+			if fp.overlaps(s.Start, s.End, loc) {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			out = append(out, s)
+		}
+	}
+	return out
+}
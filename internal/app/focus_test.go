@@ -0,0 +1,101 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestFocusPeriodCovers(t *testing.T) {
+	night, err := parseFocusConfig("sleep", focusConfig{Days: "weekdays", Hours: "22:00-07:00"})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	cases := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2026, 3, 2, 23, 0, 0, 0, time.UTC), true},  // Monday night
+		{time.Date(2026, 3, 3, 6, 30, 0, 0, time.UTC), true},  // early Tuesday, from Monday
+		{time.Date(2026, 3, 2, 6, 30, 0, 0, time.UTC), false}, // early Monday, from Sunday
+		{time.Date(2026, 3, 3, 7, 0, 0, 0, time.UTC), false},
+	}
+	for _, c := range cases {
+		if got := night.covers(c.at); got != c.want {
+			t.Fatalf("covers(%s) = %v, want %v", c.at, got, c.want)
+		}
+	}
+	if _, err := parseFocusConfig("bad", focusConfig{Hours: "9-5"}); err == nil {
+		t.Fatalf("expected an error for hours without a range")
+	}
+}
+
+func TestSystemFocusPeriods(t *testing.T) {
+	raw := []byte(`{"data":[{"modeConfigurations":{"a":{"mode":{"name":"Work"},"triggers":{"triggers":[
+		{"class":"DNDModeConfigurationScheduleTrigger","enabledSetting":2,"timePeriodStartTimeHour":9,"timePeriodStartTimeMinute":30,"timePeriodEndTimeHour":12,"timePeriodEndTimeMinute":0,"timePeriodWeekdays":62},
+		{"class":"DNDModeConfigurationScheduleTrigger","enabledSetting":1,"timePeriodStartTimeHour":14,"timePeriodEndTimeHour":15,"timePeriodWeekdays":127},
+		{"class":"DNDModeConfigurationAppTrigger"}]}}}}]}`)
+	got := systemFocusPeriods(raw)
+	if len(got) != 1 || got[0].Name != "Work" || got[0].Start != 570 || got[0].End != 720 || len(got[0].Days) != 5 || got[0].Days[0] != time.Monday {
+		t.Fatalf("unexpected periods: %+v", got)
+	}
+}
+
+func TestSlotsAvoidFocusAndEventAnnotation(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(cfg, []byte("[focus.deep-work]\ndays = \"weekdays\"\nhours = \"09:00-11:00\"\n"), 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	dnd := filepath.Join(home, "Library", "DoNotDisturb", "DB")
+	if err := os.MkdirAll(dnd, 0o755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+	system := `{"data":[{"modeConfigurations":{"x":{"mode":{"name":"Reading"},"triggers":{"triggers":[{"class":"DNDModeConfigurationScheduleTrigger","enabledSetting":2,"timePeriodStartTimeHour":12,"timePeriodStartTimeMinute":0,"timePeriodEndTimeHour":12,"timePeriodEndTimeMinute":30,"timePeriodWeekdays":127}]}}}}]}`
+	if err := os.WriteFile(filepath.Join(dnd, "ModeConfigurations.json"), []byte(system), 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: start, End: start.Add(15 * time.Minute)},
+		{ID: "b", CalendarID: "work", CalendarName: "Work", Title: "Lunch", Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)},
+		{ID: "c", CalendarID: "work", CalendarName: "Work", Title: "Review", Start: start.Add(4 * time.Hour), End: start.Add(5 * time.Hour)},
+	}})
+	args := []string{"--config", cfg, "--tz", "UTC", "--from", "2026-03-03", "--to", "2026-03-04", "--json"}
+
+	var events struct {
+		Data []contract.Event `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, append([]string{"events", "list"}, args...)...), &events); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	focus := map[string]string{}
+	for _, e := range events.Data {
+		focus[e.ID] = e.Focus
+	}
+	if focus["a"] != "deep-work" || focus["b"] != "Reading" || focus["c"] != "" {
+		t.Fatalf("unexpected focus annotations: %v", focus)
+	}
+
+	var slots struct {
+		Data []slotRow      `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	out := runWithBackend(t, fb, "slots", "--config", cfg, "--tz", "UTC", "--from", "2026-03-03T00:00:00Z", "--to", "2026-03-03T13:00:00Z", "--between", "09:00-13:00", "--duration", "30m", "--step", "30m", "--avoid-focus", "--json")
+	if err := json.Unmarshal(out, &slots); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(slots.Data) != 2 || slots.Data[0].Start.Hour() != 11 || slots.Data[1].Start.Minute() != 30 {
+		t.Fatalf("expected only 11:00 and 11:30 to survive, got %+v", slots.Data)
+	}
+	if slots.Meta["focus_skipped"] != float64(3) {
+		t.Fatalf("unexpected meta: %v", slots.Meta)
+	}
+}
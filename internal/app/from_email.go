@@ -0,0 +1,409 @@
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+type emailEvent struct {
+	Calendar string    `json:"calendar"`
+	Title    string    `json:"title"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	AllDay   bool      `json:"all_day"`
+	Location string    `json:"location,omitempty"`
+	Notes    string    `json:"notes,omitempty"`
+	URL      string    `json:"url,omitempty"`
+}
+
+type emailInvite struct {
+	Source  string       `json:"source"`
+	Subject string       `json:"subject,omitempty"`
+	From    string       `json:"from,omitempty"`
+	Matched string       `json:"matched,omitempty"`
+	Events  []emailEvent `json:"events"`
+}
+
+var errNoInviteDate = errors.New("no calendar invite or date and time found in the message")
+
+type emailParts struct {
+	calendars []string
+	plain     string
+	html      string
+}
+
+const maxEmailDepth = 8
+
+func decodeTransfer(r io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
+
+func collectEmailParts(out *emailParts, contentType, encoding, filename string, body io.Reader, depth int) error {
+	media, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		media, params = "text/plain", nil
+	}
+	if strings.HasPrefix(media, "multipart/") {
+		if depth >= maxEmailDepth || params["boundary"] == "" {
+			return nil
+		}
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			ct := firstNonEmpty(part.Header.Get("Content-Type"), "text/plain")
+			if err := collectEmailParts(out, ct, part.Header.Get("Content-Transfer-Encoding"), part.FileName(), part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	raw, err := io.ReadAll(decodeTransfer(body, encoding))
+	if err != nil {
+		return err
+	}
+	switch {
+	case media == "text/calendar", media == "application/ics", strings.HasSuffix(strings.ToLower(filename), ".ics"):
+		out.calendars = append(out.calendars, string(raw))
+	case filename != "":
+		// Other attachments are not the message text.
+	case media == "text/plain" && out.plain == "":
+		out.plain = string(raw)
+	case media == "text/html" && out.html == "":
+		out.html = string(raw)
+	}
+	return nil
+}
+
+var (
+	htmlBreakRe = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|tr|li|h[1-6])>`)
+	htmlTagRe   = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+func htmlToText(s string) string {
+	s = htmlBreakRe.ReplaceAllString(s, "\n")
+	return html.UnescapeString(htmlTagRe.ReplaceAllString(s, " "))
+}
+
+// unfoldICS joins continuation lines (RFC 5545 §3.1).
+func unfoldICS(raw string) string {
+	raw = strings.ReplaceAll(raw, "\r\n", "\n")
+	return strings.NewReplacer("\n ", "", "\n\t", "").Replace(raw)
+}
+
+var subjectPrefixRe = regexp.MustCompile(`(?i)^\s*((re|fw|fwd|aw|wg|invitation|updated invitation|invitation updated|accepted|new event)\s*:\s*)+`)
+
+func inviteTitle(subject string) string {
+	title := subjectPrefixRe.ReplaceAllString(subject, "")
+	if i := strings.Index(title, " @ "); i > 0 {
+		title = title[:i]
+	}
+	return strings.TrimSpace(title)
+}
+
+var (
+	emailMonthNames = `(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?`
+	monthFirstRe    = regexp.MustCompile(`(?i)\b` + emailMonthNames + `\s+(\d{1,2})(?:st|nd|rd|th)?\b,?(?:\s+(\d{4})\b)?`)
+	dayFirstRe      = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?\.?\s+` + emailMonthNames + `,?(?:\s+(\d{4})\b)?`)
+	isoDateRe       = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})`)
+	emailClockRe    = regexp.MustCompile(`(?i)^[\s,]*(?:T|at\s+|@\s*|from\s+|·\s*)?(\d{1,2})(?:[:.](\d{2}))?\s*([ap]\.?m\b\.?)?`)
+	emailUntilRe    = regexp.MustCompile(`(?i)^\s*(?:-|–|—|to|until)\s*(\d{1,2})(?:[:.](\d{2}))?\s*([ap]\.?m\b\.?)?`)
+	whenLineRe      = regexp.MustCompile(`(?im)^\s*(when|date and time|date & time)\s*:.*$`)
+	whereLineRe     = regexp.MustCompile(`(?im)^\s*(where|location)\s*:\s*(.+)$`)
+)
+
+type emailClock struct {
+	hour, minute int
+	meridiem     string
+	explicit     bool
+}
+
+func parseEmailClock(m []string) emailClock {
+	h, _ := strconv.Atoi(m[1])
+	mi, _ := strconv.Atoi(m[2])
+	mer := strings.ToLower(strings.ReplaceAll(m[3], ".", ""))
+	return emailClock{hour: h, minute: mi, meridiem: mer, explicit: m[2] != "" || mer != ""}
+}
+
+func (c emailClock) hour24() int {
+	switch {
+	case c.meridiem == "pm" && c.hour < 12:
+		return c.hour + 12
+	case c.meridiem == "am" && c.hour == 12:
+		return 0
+	}
+	return c.hour
+}
+
+type emailDateMatch struct {
+	pos, end        int
+	year, month, dd int
+}
+
+func findEmailDates(text string) []emailDateMatch {
+	month := func(s string) int {
+		return strings.Index("janfebmaraprmayjunjulaugsepoctnovdec", strings.ToLower(s[:3]))/3 + 1
+	}
+	var out []emailDateMatch
+	for _, m := range monthFirstRe.FindAllStringSubmatchIndex(text, -1) {
+		d := emailDateMatch{pos: m[0], end: m[1], month: month(text[m[2]:m[3]])}
+		d.dd, _ = strconv.Atoi(text[m[4]:m[5]])
+		if m[6] >= 0 {
+			d.year, _ = strconv.Atoi(text[m[6]:m[7]])
+		}
+		out = append(out, d)
+	}
+	for _, m := range dayFirstRe.FindAllStringSubmatchIndex(text, -1) {
+		d := emailDateMatch{pos: m[0], end: m[1], month: month(text[m[4]:m[5]])}
+		d.dd, _ = strconv.Atoi(text[m[2]:m[3]])
+		if m[6] >= 0 {
+			d.year, _ = strconv.Atoi(text[m[6]:m[7]])
+		}
+		out = append(out, d)
+	}
+	for _, m := range isoDateRe.FindAllStringSubmatchIndex(text, -1) {
+		d := emailDateMatch{pos: m[0], end: m[1]}
+		d.year, _ = strconv.Atoi(text[m[2]:m[3]])
+		d.month, _ = strconv.Atoi(text[m[4]:m[5]])
+		d.dd, _ = strconv.Atoi(text[m[6]:m[7]])
+		out = append(out, d)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].pos < out[j].pos })
+	return out
+}
+
+func guessEmailTime(text string, ref time.Time, loc *time.Location, dur time.Duration) (time.Time, time.Time, string, bool) {
+	candidates := whenLineRe.FindAllString(text, -1)
+	candidates = append(candidates, text)
+	for _, chunk := range candidates {
+		for _, d := range findEmailDates(chunk) {
+			if d.month < 1 || d.month > 12 || d.dd < 1 || d.dd > 31 {
+				continue
+			}
+			rest := chunk[d.end:]
+			cm := emailClockRe.FindStringSubmatchIndex(rest)
+			if cm == nil {
+				continue
+			}
+			start := parseEmailClock(submatches(rest, cm))
+			if !start.explicit || start.hour > 23 || start.minute > 59 {
+				continue
+			}
+			matchEnd := d.end + cm[1]
+			var until *emailClock
+			if um := emailUntilRe.FindStringSubmatchIndex(rest[cm[1]:]); um != nil {
+				u := parseEmailClock(submatches(rest[cm[1]:], um))
+				if u.hour <= 23 && u.minute <= 59 {
+					until = &u
+					matchEnd += um[1]
+					// "2:30 – 3:30pm" carries the meridiem on the end only.
+					if start.meridiem == "" && u.meridiem != "" && start.hour <= u.hour {
+						start.meridiem = u.meridiem
+					}
+				}
+			}
+			year := d.year
+			if year == 0 {
+				year = ref.In(loc).Year()
+			}
+			s := time.Date(year, time.Month(d.month), d.dd, start.hour24(), start.minute, 0, 0, loc)
+			if d.year == 0 && s.Before(ref.AddDate(0, 0, -1)) {
+				s = s.AddDate(1, 0, 0)
+			}
+			e := s.Add(dur)
+			if until != nil {
+				if t := time.Date(s.Year(), s.Month(), s.Day(), until.hour24(), until.minute, 0, 0, loc); t.After(s) {
+					e = t
+				}
+			}
+			return s, e, strings.TrimSpace(chunk[d.pos:matchEnd]), true
+		}
+	}
+	return time.Time{}, time.Time{}, "", false
+}
+
+func submatches(s string, idx []int) []string {
+	out := make([]string, len(idx)/2)
+	for i := range out {
+		if idx[2*i] >= 0 {
+			out[i] = s[idx[2*i]:idx[2*i+1]]
+		}
+	}
+	return out
+}
+
+func parseEmailInvite(raw []byte, calendar string, loc *time.Location, now time.Time, dur time.Duration) (emailInvite, []contract.Warning, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return emailInvite{}, nil, fmt.Errorf("read message: %w", err)
+	}
+	dec := new(mime.WordDecoder)
+	subject, err := dec.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+	from, _ := dec.DecodeHeader(msg.Header.Get("From"))
+	inv := emailInvite{Subject: strings.TrimSpace(subject), From: strings.TrimSpace(from), Events: []emailEvent{}}
+
+	var parts emailParts
+	ct := firstNonEmpty(msg.Header.Get("Content-Type"), "text/plain")
+	if err := collectEmailParts(&parts, ct, msg.Header.Get("Content-Transfer-Encoding"), "", msg.Body, 0); err != nil {
+		return emailInvite{}, nil, fmt.Errorf("read message body: %w", err)
+	}
+
+	warnings := []contract.Warning{}
+	seen := map[string]bool{}
+	for _, cal := range parts.calendars {
+		cal = unfoldICS(cal)
+		if strings.Contains(strings.ToUpper(cal), "\nMETHOD:CANCEL") {
+			warnings = append(warnings, contract.Warning{Code: contract.WarnInviteCancellation, Message: "skipped a cancellation (METHOD:CANCEL)"})
+			continue
+		}
+		items, w := parseICS(cal, calendar, loc)
+		warnings = append(warnings, warningsWithCode(contract.WarnICSEventSkipped, w)...)
+		for _, in := range items {
+			key := in.Title + "|" + in.Start.UTC().String() + "|" + in.End.UTC().String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			inv.Events = append(inv.Events, emailEventFromInput(in))
+		}
+	}
+	if len(inv.Events) > 0 {
+		inv.Source = "calendar"
+		return inv, warnings, nil
+	}
+
+	text := parts.plain
+	if strings.TrimSpace(text) == "" {
+		text = htmlToText(parts.html)
+	}
+	ref := now
+	if d, err := msg.Header.Date(); err == nil {
+		ref = d
+	}
+	start, end, matched, ok := guessEmailTime(text, ref, loc, dur)
+	if !ok {
+		return emailInvite{}, warnings, errNoInviteDate
+	}
+	ev := emailEvent{
+		Calendar: calendar,
+		Title:    firstNonEmpty(inviteTitle(inv.Subject), "Untitled"),
+		Start:    start,
+		End:      end,
+	}
+	if m := whereLineRe.FindStringSubmatch(text); m != nil {
+		ev.Location = strings.TrimSpace(m[2])
+	}
+	if u := meetingURL(contract.Event{Location: ev.Location, Notes: text}); u != "" {
+		ev.URL = u
+	}
+	inv.Source, inv.Matched = "body", matched
+	inv.Events = append(inv.Events, ev)
+	warnings = append(warnings, contract.Warning{Code: contract.WarnTimeGuessed, Message: fmt.Sprintf("time guessed from the message text (%q) in %s; check it before relying on it", matched, loc.String())})
+	return inv, warnings, nil
+}
+
+func emailEventFromInput(in backend.EventCreateInput) emailEvent {
+	return emailEvent{Calendar: in.Calendar, Title: in.Title, Start: in.Start, End: in.End, AllDay: in.AllDay, Location: in.Location, Notes: in.Notes, URL: in.URL}
+}
+
+func newEventsFromEmailCmd(opts *globalOptions) *cobra.Command {
+	var filePath, calendar, durationS string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "from-email",
+		Short: "Create events from an invite in an .eml message",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			p, be, ro, err := buildContext(c, opts, "events.from-email")
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(filePath) == "" {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--file is required"), "Pass --file <message.eml> or --file - for stdin", 2)
+			}
+			if strings.TrimSpace(calendar) == "" {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("--calendar is required"), "Pass --calendar target calendar", 2)
+			}
+			dur, err := timeparse.ParseDuration(durationS)
+			if err != nil || dur <= 0 {
+				if err == nil {
+					err = errors.New("--duration must be positive")
+				}
+				return failWithHint(p, contract.ErrInvalidUsage, err, durationHint, 2)
+			}
+			raw, err := readICSInput(filePath)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Check --file path or stdin data", 2)
+			}
+			inv, warnings, err := parseEmailInvite([]byte(raw), calendar, resolveLocation(ro.TZ), currentTime(), dur)
+			if err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Save the message with its attachments (raw source), or use `acal quick-add`", 2)
+			}
+			if len(inv.Events) == 0 {
+				return failWithHint(p, contract.ErrInvalidUsage, errors.New("the invite has no events to create"), "Check the message's calendar attachment", 2)
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			meta := map[string]any{"count": len(inv.Events), "source": inv.Source}
+			if dryRun {
+				meta["dry_run"] = true
+				return successWithMeta(ctx, p, ro, inv, meta, warnings)
+			}
+			created := make([]contract.Event, 0, len(inv.Events))
+			for _, e := range inv.Events {
+				item, err := addEventWithTimeout(ctx, be, backend.EventCreateInput{
+					Calendar: e.Calendar, Title: e.Title, Start: e.Start, End: e.End, AllDay: e.AllDay,
+					Location: e.Location, Notes: e.Notes, URL: e.URL,
+				})
+				if err != nil {
+					return failWithHint(p, contract.ErrGeneric, err, "Check calendar name and permissions; retry with --dry-run to see what was detected", 1)
+				}
+				if item != nil {
+					_ = appendHistory(historyEntry{Type: "add", EventID: item.ID, Created: item})
+					created = append(created, *item)
+				}
+			}
+			meta["count"] = len(created)
+			if inv.Matched != "" {
+				meta["matched"] = inv.Matched
+			}
+			return successWithMeta(ctx, p, ro, created, meta, warnings)
+		},
+	}
+	cmd.Flags().StringVar(&filePath, "file", "", "Message file (.eml) or - for stdin")
+	cmd.Flags().StringVar(&calendar, "calendar", "", "Target calendar for the detected events")
+	cmd.Flags().StringVar(&durationS, "duration", "1h", "Length when only a start time is found in the text")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Show what was detected without creating anything")
+	return cmd
+}
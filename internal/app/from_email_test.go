@@ -0,0 +1,128 @@
+package app
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+const inviteICS = "BEGIN:VCALENDAR\r\nMETHOD:REQUEST\r\nBEGIN:VEVENT\r\nDTSTART;TZID=Europe/Berlin:20260303T100000\r\nDTEND;TZID=Europe/Berlin:20260303T110000\r\nSUMMARY:Quarterly planning with a title long enough to be folded acros\r\n s two lines\r\nLOCATION:Room 4\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+func inviteMessage() string {
+	enc := base64.StdEncoding.EncodeToString([]byte(inviteICS))
+	return strings.Join([]string{
+		"From: Ana <ana@example.com>",
+		"Subject: Invitation: Quarterly planning @ Tue Mar 3, 2026 10am",
+		"Date: Mon, 2 Mar 2026 09:00:00 +0000",
+		"MIME-Version: 1.0",
+		`Content-Type: multipart/mixed; boundary="outer"`,
+		"",
+		"--outer",
+		`Content-Type: multipart/alternative; boundary="inner"`,
+		"",
+		"--inner",
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		"You have been invited.",
+		"--inner",
+		`Content-Type: text/calendar; charset=utf-8; method=REQUEST`,
+		"Content-Transfer-Encoding: base64",
+		"",
+		enc,
+		"--inner--",
+		"--outer",
+		`Content-Type: application/ics; name="invite.ics"`,
+		`Content-Disposition: attachment; filename="invite.ics"`,
+		"Content-Transfer-Encoding: base64",
+		"",
+		enc,
+		"--outer--",
+		"",
+	}, "\r\n")
+}
+
+func TestParseEmailInviteCalendarPart(t *testing.T) {
+	inv, _, err := parseEmailInvite([]byte(inviteMessage()), "Work", time.UTC, time.Now(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.Source != "calendar" || len(inv.Events) != 1 {
+		t.Fatalf("expected one deduplicated calendar event, got %+v", inv)
+	}
+	e := inv.Events[0]
+	if e.Title != "Quarterly planning with a title long enough to be folded across two lines" || e.Location != "Room 4" {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+	if want := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC); !e.Start.Equal(want) {
+		t.Fatalf("expected TZID to be honored, start=%s", e.Start)
+	}
+}
+
+func TestParseEmailInviteBodyHeuristics(t *testing.T) {
+	ref := time.Date(2026, 11, 20, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		body       string
+		start, end time.Time
+	}{
+		{"Hi!\nWhen: Tuesday, December 1 2:30 – 3:15pm\nWhere: Cafe\n", time.Date(2026, 12, 1, 14, 30, 0, 0, time.UTC), time.Date(2026, 12, 1, 15, 15, 0, 0, time.UTC)},
+		{"Let's meet on 3rd March at 10:00.", time.Date(2027, 3, 3, 10, 0, 0, 0, time.UTC), time.Date(2027, 3, 3, 11, 0, 0, 0, time.UTC)},
+		{"Kickoff 2026-12-05T09:00 in Amsterdam", time.Date(2026, 12, 5, 9, 0, 0, 0, time.UTC), time.Date(2026, 12, 5, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		s, e, matched, ok := guessEmailTime(tc.body, ref, time.UTC, time.Hour)
+		if !ok || !s.Equal(tc.start) || !e.Equal(tc.end) {
+			t.Fatalf("%q: got %s-%s (%q, ok=%v)", tc.body, s, e, matched, ok)
+		}
+	}
+	if _, _, _, ok := guessEmailTime("See you on March 3 in Amsterdam", ref, time.UTC, time.Hour); ok {
+		t.Fatal("a date without a clock time should not match")
+	}
+}
+
+func TestEventsFromEmailCommand(t *testing.T) {
+	dir := t.TempDir()
+	msg := "From: bob@example.com\r\nSubject: Re: Fwd: Coffee chat\r\nDate: Mon, 2 Mar 2026 09:00:00 +0000\r\nContent-Type: text/plain\r\n\r\nWhen: Mar 4, 2026 at 4pm\r\nLocation: https://meet.google.com/abc-defg-hij\r\n"
+	path := filepath.Join(dir, "m.eml")
+	if err := os.WriteFile(path, []byte(msg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fb := backend.NewMockBackend(backend.MockFixture{Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}}})
+
+	var dry struct {
+		Data     emailInvite    `json:"data"`
+		Meta     map[string]any `json:"meta"`
+		Warnings []string       `json:"warnings"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "from-email", "--file", path, "--calendar", "Work", "--tz", "UTC", "--dry-run", "--json"), &dry); err != nil {
+		t.Fatal(err)
+	}
+	if dry.Data.Source != "body" || dry.Data.Matched != "Mar 4, 2026 at 4pm" || len(dry.Data.Events) != 1 || len(dry.Warnings) != 1 {
+		t.Fatalf("unexpected detection: %+v", dry)
+	}
+	if e := dry.Data.Events[0]; e.Title != "Coffee chat" || e.URL != "https://meet.google.com/abc-defg-hij" || e.Start.Hour() != 16 {
+		t.Fatalf("unexpected detected event: %+v", e)
+	}
+
+	var created struct {
+		Data []contract.Event `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "from-email", "--file", path, "--calendar", "Work", "--tz", "UTC", "--json"), &created); err != nil {
+		t.Fatal(err)
+	}
+	if len(created.Data) != 1 || created.Data[0].Title != "Coffee chat" {
+		t.Fatalf("unexpected created events: %+v", created.Data)
+	}
+
+	empty := filepath.Join(dir, "empty.eml")
+	_ = os.WriteFile(empty, []byte("Subject: hello\r\n\r\nNo dates here.\r\n"), 0o644)
+	if code := runEventsCmd(t, fb, "events", "from-email", "--file", empty, "--calendar", "Work", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 when nothing is detected, got %d", code)
+	}
+}
@@ -27,12 +27,7 @@ type historyEntry struct {
 }
 
 func historyFilePath() string {
-	base := defaultUserConfigPath()
-	if strings.TrimSpace(base) == "" {
-		return ""
-	}
-	dir := filepath.Dir(base)
-	return filepath.Join(dir, "history.jsonl")
+	return statePath("history.jsonl")
 }
 
 func appendHistory(entry historyEntry) error {
@@ -40,6 +35,12 @@ func appendHistory(entry historyEntry) error {
 	if path == "" {
 		return nil
 	}
+	return withStateLock(func() error {
+		return appendHistoryLocked(path, entry)
+	})
+}
+
+func appendHistoryLocked(path string, entry historyEntry) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
@@ -177,9 +178,6 @@ func writeHistory(entries []historyEntry) error {
 	if path == "" {
 		return nil
 	}
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
-		return err
-	}
 	var b strings.Builder
 	for _, e := range entries {
 		line, err := json.Marshal(e)
@@ -189,16 +187,11 @@ func writeHistory(entries []historyEntry) error {
 		b.Write(line)
 		b.WriteByte('\n')
 	}
-	return os.WriteFile(path, []byte(b.String()), 0o644)
+	return writeFileAtomic(path, []byte(b.String()), 0o644)
 }
 
 func redoFilePath() string {
-	base := defaultUserConfigPath()
-	if strings.TrimSpace(base) == "" {
-		return ""
-	}
-	dir := filepath.Dir(base)
-	return filepath.Join(dir, "redo.jsonl")
+	return statePath("redo.jsonl")
 }
 
 func readRedoHistory() ([]historyEntry, error) {
@@ -234,9 +227,6 @@ func writeRedoHistory(entries []historyEntry) error {
 	if path == "" {
 		return nil
 	}
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
-		return err
-	}
 	var b strings.Builder
 	for _, e := range entries {
 		line, err := json.Marshal(e)
@@ -246,7 +236,7 @@ func writeRedoHistory(entries []historyEntry) error {
 		b.Write(line)
 		b.WriteByte('\n')
 	}
-	return os.WriteFile(path, []byte(b.String()), 0o644)
+	return writeFileAtomic(path, []byte(b.String()), 0o644)
 }
 
 func clearRedoHistory() error {
@@ -254,6 +244,17 @@ func clearRedoHistory() error {
 }
 
 func undoLastHistory(ctx context.Context, be backend.Backend, dryRun bool) (historyEntry, map[string]any, error) {
+	var entry historyEntry
+	var meta map[string]any
+	err := withStateLock(func() error {
+		var err error
+		entry, meta, err = undoLastHistoryLocked(ctx, be, dryRun)
+		return err
+	})
+	return entry, meta, err
+}
+
+func undoLastHistoryLocked(ctx context.Context, be backend.Backend, dryRun bool) (historyEntry, map[string]any, error) {
 	entries, err := readHistory()
 	if err != nil {
 		return historyEntry{}, nil, err
@@ -281,14 +282,16 @@ func undoLastHistory(ctx context.Context, be backend.Backend, dryRun bool) (hist
 			return historyEntry{}, nil, fmt.Errorf("invalid delete history entry")
 		}
 		in := backend.EventCreateInput{
-			Calendar: firstNonEmpty(last.Deleted.CalendarName, last.Deleted.CalendarID),
-			Title:    last.Deleted.Title,
-			Start:    last.Deleted.Start,
-			End:      last.Deleted.End,
-			Location: last.Deleted.Location,
-			Notes:    last.Deleted.Notes,
-			URL:      last.Deleted.URL,
-			AllDay:   last.Deleted.AllDay,
+			Calendar:    firstNonEmpty(last.Deleted.CalendarName, last.Deleted.CalendarID),
+			Title:       last.Deleted.Title,
+			Start:       last.Deleted.Start,
+			End:         last.Deleted.End,
+			Location:    last.Deleted.Location,
+			Notes:       last.Deleted.Notes,
+			URL:         last.Deleted.URL,
+			Status:      last.Deleted.Status,
+			Sensitivity: last.Deleted.Sensitivity,
+			AllDay:      last.Deleted.AllDay,
 		}
 		if strings.TrimSpace(in.Calendar) == "" {
 			return historyEntry{}, nil, fmt.Errorf("deleted entry missing calendar")
@@ -301,6 +304,7 @@ func undoLastHistory(ctx context.Context, be backend.Backend, dryRun bool) (hist
 			redoEntry.EventID = created.ID
 			redoEntry.Deleted = created
 		}
+		_, _ = takeFromTrash(last.EventID)
 	case "update":
 		if last.Prev == nil {
 			return historyEntry{}, nil, fmt.Errorf("invalid update history entry")
@@ -329,6 +333,17 @@ func undoLastHistory(ctx context.Context, be backend.Backend, dryRun bool) (hist
 }
 
 func redoLastHistory(ctx context.Context, be backend.Backend, dryRun bool) (historyEntry, map[string]any, error) {
+	var entry historyEntry
+	var meta map[string]any
+	err := withStateLock(func() error {
+		var err error
+		entry, meta, err = redoLastHistoryLocked(ctx, be, dryRun)
+		return err
+	})
+	return entry, meta, err
+}
+
+func redoLastHistoryLocked(ctx context.Context, be backend.Backend, dryRun bool) (historyEntry, map[string]any, error) {
 	redoEntries, err := readRedoHistory()
 	if err != nil {
 		return historyEntry{}, nil, err
@@ -356,6 +371,8 @@ func redoLastHistory(ctx context.Context, be backend.Backend, dryRun bool) (hist
 			Location:       last.Created.Location,
 			Notes:          last.Created.Notes,
 			URL:            last.Created.URL,
+			Status:         last.Created.Status,
+			Sensitivity:    last.Created.Sensitivity,
 			AllDay:         last.Created.AllDay,
 			ReminderOffset: nil,
 			RepeatRule:     "",
@@ -16,6 +16,7 @@ import (
 func TestHistoryAppendRead(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
 	if err := appendHistory(historyEntry{At: time.Now().UTC(), Type: "add", EventID: "e1"}); err != nil {
 		t.Fatalf("appendHistory failed: %v", err)
 	}
@@ -31,6 +32,7 @@ func TestHistoryAppendRead(t *testing.T) {
 func TestUndoLastHistoryAdd(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
 	fb := &scopeCaptureBackend{}
 	if err := appendHistory(historyEntry{At: time.Now().UTC(), Type: "add", EventID: "e1@1"}); err != nil {
 		t.Fatalf("appendHistory failed: %v", err)
@@ -47,12 +49,13 @@ func TestUndoLastHistoryAdd(t *testing.T) {
 func TestHistoryUndoCommandDryRun(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
 	if err := appendHistory(historyEntry{At: time.Now().UTC(), Type: "add", EventID: "e1@1"}); err != nil {
 		t.Fatalf("appendHistory failed: %v", err)
 	}
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -70,6 +73,7 @@ func TestHistoryUndoCommandDryRun(t *testing.T) {
 func TestRedoLastHistoryAdd(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
 	ev := &contract.Event{
 		ID:           "e1@1",
 		CalendarName: "Work",
@@ -95,6 +99,7 @@ func TestRedoLastHistoryAdd(t *testing.T) {
 func TestHistoryRedoCommandDryRun(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
 	ev := &contract.Event{
 		ID:           "e1@1",
 		CalendarName: "Work",
@@ -110,7 +115,7 @@ func TestHistoryRedoCommandDryRun(t *testing.T) {
 		t.Fatalf("undoLastHistory failed: %v", err)
 	}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -128,6 +133,7 @@ func TestHistoryRedoCommandDryRun(t *testing.T) {
 func TestHistoryListPagination(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
 	for i := 1; i <= 3; i++ {
 		if err := appendHistory(historyEntry{
 			At:      time.Date(2026, 2, 18, 9, i, 0, 0, time.UTC),
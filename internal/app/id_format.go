@@ -0,0 +1,32 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+var idFormatNames = []string{"full", "uid", "short"}
+
+func eventIDFormatter(format string) (func(contract.Event) string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "full":
+		return nil, nil
+	case "uid":
+		return func(e contract.Event) string {
+			uid, _ := backend.EventUID(e.ID)
+			return uid
+		}, nil
+	case "short":
+		return func(e contract.Event) string {
+			if e.Alias != "" {
+				return e.Alias
+			}
+			return e.ID
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid --id-format %q (use %s)", format, strings.Join(idFormatNames, "|"))
+	}
+}
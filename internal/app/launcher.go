@@ -0,0 +1,159 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+var launcherFormats = []string{"alfred", "raycast"}
+
+func parseLauncherFormat(v string) (string, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v == "" || containsString(launcherFormats, v) {
+		return v, nil
+	}
+	return "", invalidField("format", v, fmt.Errorf("invalid --format: %s", v))
+}
+
+type alfredIcon struct {
+	Type string `json:"type,omitempty"`
+	Path string `json:"path"`
+}
+
+type alfredMod struct {
+	Arg      string `json:"arg"`
+	Subtitle string `json:"subtitle"`
+	Valid    bool   `json:"valid"`
+}
+
+type alfredItem struct {
+	UID          string               `json:"uid,omitempty"`
+	Title        string               `json:"title"`
+	Subtitle     string               `json:"subtitle"`
+	Arg          string               `json:"arg,omitempty"`
+	Valid        bool                 `json:"valid"`
+	Icon         *alfredIcon          `json:"icon,omitempty"`
+	QuickLookURL string               `json:"quicklookurl,omitempty"`
+	Text         map[string]string    `json:"text,omitempty"`
+	Mods         map[string]alfredMod `json:"mods,omitempty"`
+}
+
+type raycastAccessory struct {
+	Text string `json:"text,omitempty"`
+	Tag  string `json:"tag,omitempty"`
+}
+
+type raycastAction struct {
+	Type    string `json:"type"`
+	Title   string `json:"title"`
+	URL     string `json:"url,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+type raycastItem struct {
+	ID          string             `json:"id"`
+	Title       string             `json:"title"`
+	Subtitle    string             `json:"subtitle"`
+	Icon        string             `json:"icon"`
+	Accessories []raycastAccessory `json:"accessories"`
+	Actions     []raycastAction    `json:"actions"`
+}
+
+func launcherWhen(e contract.Event, loc *time.Location, clock string) string {
+	start, end := e.Start.In(loc), e.End.In(loc)
+	if e.AllDay {
+		return start.Format("Mon 2 Jan") + ", all day"
+	}
+	return start.Format("Mon 2 Jan") + " " + start.Format(clock) + "–" + end.Format(clock)
+}
+
+func launcherSubtitle(e contract.Event, loc *time.Location, clock string) string {
+	parts := []string{launcherWhen(e, loc, clock)}
+	for _, v := range []string{firstNonEmpty(e.CalendarName, e.CalendarID), e.Location} {
+		if v = strings.TrimSpace(v); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+func renderAlfred(items []contract.Event, loc *time.Location, clock string) string {
+	out := make([]alfredItem, 0, len(items))
+	for _, e := range items {
+		it := alfredItem{
+			UID:          e.ID,
+			Title:        firstNonEmpty(strings.TrimSpace(e.Title), "(untitled)"),
+			Subtitle:     launcherSubtitle(e, loc, clock),
+			Arg:          e.ID,
+			Valid:        true,
+			Icon:         &alfredIcon{Type: "fileicon", Path: "/System/Applications/Calendar.app"},
+			QuickLookURL: e.URL,
+			Text:         map[string]string{"copy": e.ID, "largetype": e.Title + "\n" + launcherWhen(e, loc, clock)},
+		}
+		if e.MeetingURL != "" {
+			it.Mods = map[string]alfredMod{"cmd": {Arg: e.MeetingURL, Subtitle: "Join " + e.MeetingURL, Valid: true}}
+		}
+		out = append(out, it)
+	}
+	if len(out) == 0 {
+		out = append(out, alfredItem{Title: "No events", Subtitle: "Nothing matches in this range"})
+	}
+	raw, _ := json.Marshal(map[string]any{"items": out})
+	return string(raw) + "\n"
+}
+
+func renderRaycast(items []contract.Event, loc *time.Location, clock string) string {
+	out := make([]raycastItem, 0, len(items))
+	for _, e := range items {
+		it := raycastItem{
+			ID:          e.ID,
+			Title:       firstNonEmpty(strings.TrimSpace(e.Title), "(untitled)"),
+			Subtitle:    strings.TrimSpace(e.Location),
+			Icon:        "calendar",
+			Accessories: []raycastAccessory{{Text: launcherWhen(e, loc, clock)}},
+			Actions:     []raycastAction{},
+		}
+		if cal := firstNonEmpty(e.CalendarName, e.CalendarID); cal != "" {
+			it.Accessories = append(it.Accessories, raycastAccessory{Tag: cal})
+		}
+		if e.IsVideoCall {
+			it.Icon = "video"
+		}
+		if e.MeetingURL != "" {
+			it.Actions = append(it.Actions, raycastAction{Type: "open", Title: "Join Call", URL: e.MeetingURL})
+		}
+		if e.URL != "" && e.URL != e.MeetingURL {
+			it.Actions = append(it.Actions, raycastAction{Type: "open", Title: "Open URL", URL: e.URL})
+		}
+		it.Actions = append(it.Actions, raycastAction{Type: "copy", Title: "Copy Event ID", Content: e.ID})
+		out = append(out, it)
+	}
+	raw, _ := json.Marshal(map[string]any{"items": out})
+	return string(raw) + "\n"
+}
+
+func renderLauncher(format string, items []contract.Event, loc *time.Location, clock string) string {
+	if format == "raycast" {
+		return renderRaycast(items, loc, clock)
+	}
+	return renderAlfred(items, loc, clock)
+}
+
+func printLauncher(cmd *cobra.Command, p output.Printer, ro *globalOptions, format string, items []contract.Event) error {
+	if p.HidePrivate {
+		items = output.MaskPrivate(items).([]contract.Event)
+	}
+	if p.EventID != nil {
+		items = output.FormatEventIDs(items, p.EventID).([]contract.Event)
+	}
+	clock, _ := timeparse.ClockLayout(ro.TimeFormat)
+	_, _ = fmt.Fprint(cmd.OutOrStdout(), renderLauncher(format, items, resolveLocation(ro.TZ), clock))
+	return nil
+}
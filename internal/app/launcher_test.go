@@ -0,0 +1,55 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestEventsListLauncherFormats(t *testing.T) {
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "e1", CalendarID: "work", CalendarName: "Work", Title: "Design review", Location: "Room 4", Start: start, End: start.Add(time.Hour), URL: "https://meet.google.com/abc-defg-hij"},
+		},
+	})
+	args := []string{"events", "list", "--from", "2026-03-02", "--to", "2026-03-03", "--tz", "UTC"}
+
+	var alfred struct {
+		Items []alfredItem `json:"items"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, append(args, "--format", "alfred")...), &alfred); err != nil {
+		t.Fatalf("alfred output is not JSON: %v", err)
+	}
+	if len(alfred.Items) != 1 {
+		t.Fatalf("unexpected alfred items: %+v", alfred.Items)
+	}
+	it := alfred.Items[0]
+	if it.Title != "Design review" || it.Arg != "e1" || it.Subtitle != "Mon 2 Mar 10:00–11:00 · Work · Room 4" || it.Mods["cmd"].Arg != "https://meet.google.com/abc-defg-hij" {
+		t.Fatalf("unexpected alfred item: %+v", it)
+	}
+
+	var raycast struct {
+		Items []raycastItem `json:"items"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "search", "design", "--from", "2026-03-02", "--to", "2026-03-03", "--tz", "UTC", "--format", "raycast"), &raycast); err != nil {
+		t.Fatalf("raycast output is not JSON: %v", err)
+	}
+	if len(raycast.Items) != 1 || raycast.Items[0].Icon != "video" || raycast.Items[0].Actions[0].Title != "Join Call" {
+		t.Fatalf("unexpected raycast items: %+v", raycast.Items)
+	}
+
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "list", "--from", "2026-04-01", "--to", "2026-04-02", "--format", "alfred"), &alfred); err != nil {
+		t.Fatal(err)
+	}
+	if len(alfred.Items) != 1 || alfred.Items[0].Valid || alfred.Items[0].Title != "No events" {
+		t.Fatalf("expected a placeholder item, got %+v", alfred.Items)
+	}
+	if code := runEventsCmd(t, fb, "events", "list", "--format", "spotlight", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for unknown format, got %d", code)
+	}
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestMain points HOME at a scratch directory so commands that persist state
+// (history, aliases) never touch the developer's real files.
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "acal-test-home-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("HOME", home)
+	os.Unsetenv("XDG_STATE_HOME")
+	os.Unsetenv("XDG_CONFIG_HOME")
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
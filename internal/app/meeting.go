@@ -0,0 +1,79 @@
+package app
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+var meetingLinkRe = regexp.MustCompile(`https?://[^\s<>"'()\[\]]+`)
+
+var meetingHosts = []string{
+	"zoom.us",
+	"zoomgov.com",
+	"meet.google.com",
+	"teams.microsoft.com",
+	"teams.live.com",
+	"webex.com",
+	"whereby.com",
+	"gotomeeting.com",
+	"meet.goto.com",
+	"chime.aws",
+	"bluejeans.com",
+	"meet.jit.si",
+	"facetime.apple.com",
+	"join.skype.com",
+}
+
+func meetingURL(e contract.Event) string {
+	for _, field := range []string{e.URL, e.Location, e.Notes} {
+		for _, raw := range meetingLinkRe.FindAllString(field, -1) {
+			raw = strings.TrimRight(raw, ".,;:!?>")
+			if isMeetingLink(raw) {
+				return raw
+			}
+		}
+	}
+	return ""
+}
+
+func isMeetingLink(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, h := range meetingHosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+func withMeeting(e *contract.Event) *contract.Event {
+	if e != nil {
+		e.MeetingURL = meetingURL(*e)
+		e.IsVideoCall = e.MeetingURL != ""
+	}
+	return e
+}
+
+func withEventsMeeting(items []contract.Event) []contract.Event {
+	for i := range items {
+		withMeeting(&items[i])
+	}
+	return items
+}
+
+func onlyVideoCalls(items []contract.Event) []contract.Event {
+	out := make([]contract.Event, 0, len(items))
+	for _, e := range items {
+		if e.IsVideoCall {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestMeetingURL(t *testing.T) {
+	cases := []struct {
+		name string
+		e    contract.Event
+		want string
+	}{
+		{"url field", contract.Event{URL: "https://meet.google.com/abc-defg-hij"}, "https://meet.google.com/abc-defg-hij"},
+		{"zoom subdomain in notes", contract.Event{Notes: "Join: https://us02web.zoom.us/j/123456?pwd=x.\nDial-in below"}, "https://us02web.zoom.us/j/123456?pwd=x"},
+		{"teams in location", contract.Event{Location: "Microsoft Teams (https://teams.microsoft.com/l/meetup-join/19%3a)"}, "https://teams.microsoft.com/l/meetup-join/19%3a"},
+		{"url wins over notes", contract.Event{URL: "https://acme.webex.com/meet/ann", Notes: "https://zoom.us/j/1"}, "https://acme.webex.com/meet/ann"},
+		{"skips non-meeting links", contract.Event{URL: "https://example.com/doc", Notes: "Agenda https://docs.google.com/x then https://meet.jit.si/standup"}, "https://meet.jit.si/standup"},
+		{"lookalike host", contract.Event{Notes: "https://notzoom.us/j/1"}, ""},
+		{"no links", contract.Event{Title: "Lunch", Location: "Cafe"}, ""},
+	}
+	for _, tc := range cases {
+		if got := meetingURL(tc.e); got != tc.want {
+			t.Errorf("%s: meetingURL = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestOnlyVideoCallsFilter(t *testing.T) {
+	start := time.Now().Truncate(time.Hour)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "call", CalendarID: "work", CalendarName: "Work", Title: "Sync", Notes: "https://zoom.us/j/42", Start: start, End: start.Add(30 * time.Minute)},
+			{ID: "desk", CalendarID: "work", CalendarName: "Work", Title: "Focus", Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)},
+		},
+	})
+	for _, args := range [][]string{
+		{"today", "--only-video-calls", "--json"},
+		{"week", "--only-video-calls", "--json"},
+		{"events", "list", "--from", "today", "--to", "+2d", "--only-video-calls", "--json"},
+	} {
+		var env struct {
+			Data []contract.Event `json:"data"`
+		}
+		if err := json.Unmarshal(runWithBackend(t, fb, args...), &env); err != nil {
+			t.Fatalf("%v: decode failed: %v", args, err)
+		}
+		if len(env.Data) != 1 || env.Data[0].ID != "call" || !env.Data[0].IsVideoCall || env.Data[0].MeetingURL != "https://zoom.us/j/42" {
+			t.Fatalf("%v: unexpected events: %+v", args, env.Data)
+		}
+	}
+}
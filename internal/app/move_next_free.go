@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
+)
+
+var errNextFreeUsage = errors.New("invalid --to-next-free options")
+
+type nextFreeOptions struct {
+	Between       string
+	Step          string
+	Within        string
+	Calendars     []string
+	Weekends      bool
+	IncludeAllDay bool
+	Quiet         []focusPeriod
+	Loc           *time.Location
+}
+
+func nextFreeSlot(ctx context.Context, be backend.Backend, ev *contract.Event, d time.Duration, o nextFreeOptions) (slotRow, bool, error) {
+	sh, sm, eh, em, err := parseBetweenRange(o.Between)
+	if err != nil {
+		return slotRow{}, false, fmt.Errorf("%w: %v", errNextFreeUsage, err)
+	}
+	step, err := timeparse.ParseDuration(o.Step)
+	if err == nil && step <= 0 {
+		err = errors.New("--step must be positive")
+	}
+	if err != nil {
+		return slotRow{}, false, fmt.Errorf("%w: %v", errNextFreeUsage, err)
+	}
+	within, err := timeparse.ParseDuration(o.Within)
+	if err == nil && within <= 0 {
+		err = errors.New("--within must be positive")
+	}
+	if err != nil {
+		return slotRow{}, false, fmt.Errorf("%w: %v", errNextFreeUsage, err)
+	}
+	from := ev.Start.In(o.Loc).Add(step)
+	to := from.Add(within)
+	items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: from, To: to.Add(d), Calendars: o.Calendars, Overlap: true})
+	if err != nil {
+		return slotRow{}, false, err
+	}
+	others := make([]contract.Event, 0, len(items))
+	for _, it := range items {
+		if it.ID != ev.ID {
+			others = append(others, it)
+		}
+	}
+	slots, _ := freeSlots(buildBusyBlocks(others, o.IncludeAllDay), from, to, sh, sm, eh, em, d, step, o.Quiet, o.Loc)
+	for _, s := range slots {
+		if wd := s.Start.Weekday(); !o.Weekends && (wd == time.Saturday || wd == time.Sunday) {
+			continue
+		}
+		return s, true, nil
+	}
+	return slotRow{}, false, nil
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+)
+
+func createConflicts(ctx context.Context, be backend.Backend, in backend.EventCreateInput) ([]contract.Event, error) {
+	if in.AllDay || !in.Start.Before(in.End) {
+		return nil, nil
+	}
+	items, err := listEventsWithTimeout(ctx, be, backend.EventFilter{From: in.Start, To: in.End, Overlap: true})
+	if err != nil {
+		return nil, err
+	}
+	out := []contract.Event{}
+	for _, it := range items {
+		if it.AllDay || it.Availability == contract.AvailabilityFree || it.Status == contract.StatusCancelled {
+			continue
+		}
+		if it.Start.Before(in.End) && in.Start.Before(it.End) {
+			out = append(out, it)
+		}
+	}
+	return out, nil
+}
+
+func checkNoConflict(ctx context.Context, p output.Printer, be backend.Backend, in backend.EventCreateInput, loc *time.Location, quiet []focusPeriod) error {
+	if !in.AllDay && in.Start.Before(in.End) {
+		if window := quietOverlap(in.Start, in.End, quiet, loc); window != "" {
+			err := fmt.Errorf("%s–%s falls in quiet hours %s", in.Start.In(loc).Format("2006-01-02 15:04"), in.End.In(loc).Format("15:04"), window)
+			_ = p.ErrorWithDetails(contract.ErrConflict, err.Error(), "Pick another time (`acal slots`), or pass --ignore-quiet-hours", nil, map[string]any{"quiet_hours": window})
+			return WrapPrinted(5, err)
+		}
+	}
+	conflicts, err := createConflicts(ctx, be, in)
+	if err != nil {
+		return failWithHint(p, contract.ErrBackendUnavailable, err, "Run `acal doctor` for remediation", 6)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	first := conflicts[0]
+	err = fmt.Errorf("overlaps %d existing event(s), first %q %s–%s", len(conflicts), first.Title, first.Start.In(loc).Format("2006-01-02 15:04"), first.End.In(loc).Format("15:04"))
+	ids := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		ids = append(ids, c.ID)
+	}
+	_ = p.ErrorWithDetails(contract.ErrConflict, err.Error(), "Pick another time (`acal slots`), or pass --force to create it anyway", &contract.ErrorDetails{EventIDs: ids}, map[string]any{"conflicts": conflicts})
+	return WrapPrinted(5, err)
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+type person struct {
+	Handle string `json:"handle"`
+	Email  string `json:"email,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+func peopleFromConfig(raw map[string]any) map[string]person {
+	out := map[string]person{}
+	for handle, v := range raw {
+		p := person{Handle: strings.TrimPrefix(strings.TrimSpace(handle), "@")}
+		switch x := v.(type) {
+		case string:
+			if strings.Contains(x, "@") {
+				p.Email = strings.TrimSpace(x)
+			} else {
+				p.Name = strings.TrimSpace(x)
+			}
+		case map[string]any:
+			p.Email, _ = x["email"].(string)
+			p.Name, _ = x["name"].(string)
+			p.Email, p.Name = strings.TrimSpace(p.Email), strings.TrimSpace(p.Name)
+		}
+		if p.Handle != "" && (p.Email != "" || p.Name != "") {
+			out[strings.ToLower(p.Handle)] = p
+		}
+	}
+	return out
+}
+
+func lookupPerson(people map[string]person, handle string) (person, bool) {
+	p, ok := people[strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))]
+	return p, ok
+}
+
+func (p person) display() string {
+	return firstNonEmpty(p.Name, p.Email)
+}
+
+func (p person) needles() []string {
+	out := []string{}
+	for _, s := range []string{p.Email, p.Name} {
+		if s != "" {
+			out = append(out, strings.ToLower(s))
+		}
+	}
+	return out
+}
+
+func (p person) mentions(text string) bool {
+	text = strings.ToLower(text)
+	for _, n := range p.needles() {
+		if strings.Contains(text, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitPeopleQuery(query string, people map[string]person) (string, []person) {
+	if len(people) == 0 {
+		return query, nil
+	}
+	rest := []string{}
+	who := []person{}
+	for _, word := range strings.Fields(query) {
+		if p, ok := lookupPerson(people, word); ok && strings.HasPrefix(word, "@") {
+			who = append(who, p)
+			continue
+		}
+		rest = append(rest, word)
+	}
+	return strings.Join(rest, " "), who
+}
+
+func resolvePeopleNames(names []string, people map[string]person) ([]person, error) {
+	out := make([]person, 0, len(names))
+	for _, n := range names {
+		if !strings.HasPrefix(n, "@") {
+			out = append(out, person{Name: n})
+			continue
+		}
+		p, ok := lookupPerson(people, n)
+		if !ok {
+			return nil, fmt.Errorf("unknown person %s: known handles are %s", n, knownHandles(people))
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func knownHandles(people map[string]person) string {
+	if len(people) == 0 {
+		return "none"
+	}
+	out := make([]string, 0, len(people))
+	for _, p := range people {
+		out = append(out, "@"+p.Handle)
+	}
+	sort.Strings(out)
+	return strings.Join(out, ", ")
+}
+
+var personFields = []string{"title", "location", "notes", "attendee", "attendees"}
+
+func expandPredicatePeople(preds []predicate, people map[string]person) []predicate {
+	for i, pr := range preds {
+		if !strings.HasPrefix(pr.value, "@") || !containsString(personFields, pr.field) {
+			continue
+		}
+		if p, ok := lookupPerson(people, pr.value); ok {
+			preds[i].who = &p
+		}
+	}
+	return preds
+}
+
+func matchesPerson(e contract.Event, pr predicate) (bool, error) {
+	var texts []string
+	switch pr.field {
+	case "title":
+		texts = []string{e.Title}
+	case "location":
+		texts = []string{e.Location}
+	case "notes":
+		texts = []string{e.Notes}
+	default:
+		texts = extractAttendees(e.Notes)
+	}
+	found := false
+	for _, t := range texts {
+		if pr.who.mentions(t) {
+			found = true
+			break
+		}
+	}
+	switch pr.op {
+	case "==", "~":
+		return found, nil
+	case "!=":
+		return !found, nil
+	default:
+		return false, fmt.Errorf("operator %s not supported for people", pr.op)
+	}
+}
+
+func compareAttendees(attendees []string, op, expected string) (bool, error) {
+	e := strings.ToLower(strings.TrimSpace(expected))
+	found := false
+	for _, a := range attendees {
+		a = strings.ToLower(a)
+		if a == e || (op == "~" && strings.Contains(a, e)) {
+			found = true
+			break
+		}
+	}
+	switch op {
+	case "==", "~":
+		return found, nil
+	case "!=":
+		return !found, nil
+	default:
+		return false, fmt.Errorf("operator %s not supported for attendee fields", op)
+	}
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestPeopleFromConfig(t *testing.T) {
+	people := peopleFromConfig(map[string]any{
+		"alice": "alice@example.com",
+		"Bob":   "Bob Smith",
+		"carol": map[string]any{"email": "carol@example.com", "name": "Carol Jones"},
+		"empty": map[string]any{},
+	})
+	if len(people) != 3 {
+		t.Fatalf("expected 3 people, got %+v", people)
+	}
+	if p, ok := lookupPerson(people, "@ALICE"); !ok || p.Email != "alice@example.com" || p.display() != "alice@example.com" {
+		t.Fatalf("unexpected alice: %+v ok=%v", p, ok)
+	}
+	if p, ok := lookupPerson(people, "bob"); !ok || p.Name != "Bob Smith" || p.Email != "" {
+		t.Fatalf("unexpected bob: %+v ok=%v", p, ok)
+	}
+	if p := people["carol"]; !p.mentions("Attendees: CAROL@example.com") || !p.mentions("with Carol Jones") || p.mentions("Carl") {
+		t.Fatalf("unexpected carol matching: %+v", p)
+	}
+	rest, who := splitPeopleQuery("sync @alice @home", people)
+	if rest != "sync @home" || len(who) != 1 || who[0].Handle != "alice" {
+		t.Fatalf("unexpected split: %q %+v", rest, who)
+	}
+}
+
+func writePeopleConfig(t *testing.T) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg := filepath.Join(t.TempDir(), "config.toml")
+	body := "[people]\nalice = \"alice@example.com\"\n\n[people.bob]\nemail = \"bob@example.com\"\nname = \"Bob Smith\"\n"
+	if err := os.WriteFile(cfg, []byte(body), 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	return cfg
+}
+
+func TestPeopleHandlesInSearchAndQuery(t *testing.T) {
+	cfg := writePeopleConfig(t)
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Sync", Notes: "Attendees: alice@example.com, bob@example.com", Start: start, End: start.Add(time.Hour)},
+			{ID: "b", CalendarID: "work", CalendarName: "Work", Title: "Sync", Notes: "Attendees: Bob Smith", Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)},
+			{ID: "c", CalendarID: "work", CalendarName: "Work", Title: "Lunch", Notes: "Ask Bob about slides", Start: start.Add(4 * time.Hour), End: start.Add(5 * time.Hour)},
+		},
+	})
+	ids := func(out []byte) []string {
+		t.Helper()
+		var env struct {
+			Data []contract.Event `json:"data"`
+		}
+		if err := json.Unmarshal(out, &env); err != nil {
+			t.Fatalf("invalid json: %v\n%s", err, out)
+		}
+		got := []string{}
+		for _, e := range env.Data {
+			got = append(got, e.ID)
+		}
+		return got
+	}
+	range_ := []string{"--config", cfg, "--from", "2026-03-03", "--to", "2026-03-04", "--json"}
+	if got := ids(runWithBackend(t, fb, append([]string{"events", "search", "sync @bob"}, range_...)...)); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("expected @bob to match by address and by name, got %v", got)
+	}
+	if got := ids(runWithBackend(t, fb, append([]string{"events", "search", "@alice"}, range_...)...)); len(got) != 1 || got[0] != "a" {
+		t.Fatalf("expected only alice's meeting, got %v", got)
+	}
+	if got := ids(runWithBackend(t, fb, append([]string{"events", "query", "--where", "attendee==@bob"}, range_...)...)); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("expected attendee==@bob to match by address and by name, got %v", got)
+	}
+	if got := ids(runWithBackend(t, fb, append([]string{"events", "query", "--where", "attendee!=@alice", "--where", "title==Sync"}, range_...)...)); len(got) != 1 || got[0] != "b" {
+		t.Fatalf("unexpected attendee!=@alice result: %v", got)
+	}
+}
+
+func TestRotateExpandsPeopleHandles(t *testing.T) {
+	cfg := writePeopleConfig(t)
+	fb := backend.NewMockBackend(backend.MockFixture{Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}}})
+	out := runWithBackend(t, fb, "rotate", "--config", cfg, "--with", "@bob,@alice,dana", "--calendar", "Work", "--from", "2026-03-02", "--tz", "UTC", "--dry-run", "--json")
+	var env struct {
+		Data []rotationRow `json:"data"`
+	}
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, out)
+	}
+	if len(env.Data) != 3 || env.Data[0].Name != "Bob Smith" || env.Data[0].Email != "bob@example.com" || env.Data[0].Title != "1:1 with Bob Smith" ||
+		env.Data[1].Name != "alice@example.com" || env.Data[2].Name != "dana" || env.Data[2].Email != "" {
+		t.Fatalf("unexpected rotation: %+v", env.Data)
+	}
+	if code := runEventsCmd(t, fb, "rotate", "--config", cfg, "--with", "@zed", "--calendar", "Work", "--dry-run", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for unknown handle, got %d", code)
+	}
+}
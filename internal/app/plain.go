@@ -0,0 +1,38 @@
+package app
+
+import (
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+)
+
+func init() {
+	output.RegisterPlainColumns(contract.ErrorCodeInfo{}, []string{"code", "exit_code", "retryable", "description"})
+	output.RegisterPlainColumns(busyBlock{}, []string{"start", "end", "minutes"})
+	output.RegisterPlainColumns(slotRow{}, []string{"start", "end", "minutes"})
+	output.RegisterPlainColumns(fairSlot{}, []string{"start", "end", "minutes", "fairness", "score"})
+	output.RegisterPlainColumns(searchHit{}, []string{"score", "id", "calendar_name", "title", "start", "location"})
+	output.RegisterPlainColumns(selftestCheck{}, []string{"name", "status", "cases", "message"})
+	output.RegisterPlainColumns(commandDescription{}, []string{"name", "usage", "short"})
+	output.RegisterPlainColumns(compareRow{}, []string{"group", "key", "baseline_count", "current_count", "count_delta", "baseline_minutes", "current_minutes", "minutes_delta", "trend"})
+	output.RegisterPlainColumns(auditFinding{}, []string{"kind", "action", "start", "title", "ids"})
+	output.RegisterPlainColumns(lintViolation{}, []string{"rule", "start", "title", "ids", "fix"})
+	output.RegisterPlainColumns(conflictRow{}, []string{"left_id", "right_id", "overlap_start", "overlap_end", "overlap_minutes", "left_title", "right_title"})
+	output.RegisterPlainColumns(recurringConflictRow{}, []string{"left_series", "right_series", "occurrences", "weekday", "first_overlap", "last_overlap", "left_title", "right_title"})
+	output.RegisterPlainColumns(rotationRow{}, []string{"occurrence", "name", "start", "end", "status", "title"})
+	output.RegisterPlainColumns(roomStatus{}, []string{"room", "calendar_id", "free"})
+	output.RegisterPlainColumns(focusDay{}, []string{"date", "meetings", "busy_minutes", "focus_minutes", "longest_free_minutes", "context_switches", "fragmentation"})
+	output.RegisterPlainColumns(daySummary{}, []string{"date", "total", "all_day", "timed", "continued"})
+	output.RegisterPlainColumns(savedQuery{}, []string{"name", "from", "to", "limit"})
+	output.RegisterPlainColumns(restoreRow{}, []string{"status", "source_id", "id", "calendar", "start", "title"})
+	output.RegisterPlainColumns(trashEntry{}, []string{"trashed_at", "event_id", "scope"})
+	output.RegisterPlainColumns(backend.DeletedEvent{}, []string{"deleted_at", "id", "calendar_name", "start", "title"})
+	output.RegisterPlainColumns(timeParseResult{}, []string{"input", "resolved", "tz", "day_start", "day_end"})
+	output.RegisterPlainColumns(stateFile{}, []string{"name", "path", "exists", "bytes"})
+	output.RegisterPlainColumns(historyEntry{}, []string{"at", "type", "event_id", "tx_id"})
+	output.RegisterPlainColumns(holiday{}, []string{"date", "name", "source"})
+	output.RegisterPlainColumns(oooPeriod{}, []string{"id", "start", "end", "days", "title"})
+	output.RegisterPlainColumns(daemonStatus{}, []string{"running", "socket", "pid", "events", "loaded_at", "refreshes", "served"})
+	output.RegisterPlainColumns(revealResult{}, []string{"app", "opened", "url", "id", "title"})
+	output.RegisterPlainColumns(mirrorAction{}, []string{"action", "source_id", "mirror_id", "start", "title"})
+}
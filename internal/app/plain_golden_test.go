@@ -0,0 +1,87 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestPlainGolden(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	fb := &fakeBackend{
+		checks: []contract.DoctorCheck{
+			{Name: "osascript", Status: "ok", Message: "osascript found"},
+		},
+		events: []contract.Event{
+			{
+				ID:           "evt-1@792417600",
+				CalendarID:   "cal-1",
+				CalendarName: "Work",
+				Title:        "Standup",
+				Start:        time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC),
+				End:          time.Date(2026, 2, 10, 10, 30, 0, 0, time.UTC),
+				Location:     "Room\t4A",
+			},
+			{
+				ID:           "evt-2@792419400",
+				CalendarID:   "cal-2",
+				CalendarName: "Personal",
+				Title:        "Dentist",
+				Start:        time.Date(2026, 2, 10, 10, 15, 0, 0, time.UTC),
+				End:          time.Date(2026, 2, 10, 11, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+
+	cases := []struct {
+		name string
+		args []string
+	}{
+		{name: "today", args: []string{"today", "--day", "2026-02-10", "--tz", "UTC", "--plain"}},
+		{name: "today_header", args: []string{"today", "--day", "2026-02-10", "--tz", "UTC", "--plain", "--header"}},
+		{name: "events_list_fields", args: []string{"events", "list", "--from", "2026-02-10", "--to", "2026-02-11", "--tz", "UTC", "--plain", "--header", "--fields", "title,start,tags"}},
+		{name: "slots", args: []string{"slots", "--from", "2026-02-10T09:00", "--to", "2026-02-10T12:00", "--between", "09:00-12:00", "--duration", "30m", "--step", "30m", "--tz", "UTC", "--plain", "--header"}},
+		{name: "freebusy", args: []string{"freebusy", "--from", "2026-02-10", "--to", "2026-02-12", "--tz", "UTC", "--plain"}},
+		{name: "events_conflicts", args: []string{"events", "conflicts", "--from", "2026-02-10", "--to", "2026-02-12", "--tz", "UTC", "--plain", "--header"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewRootCommand()
+			var stdout, stderr bytes.Buffer
+			cmd.SetOut(&stdout)
+			cmd.SetErr(&stderr)
+			cmd.SetArgs(tc.args)
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("execute failed: %v (stderr=%s)", err, stderr.String())
+			}
+			assertGoldenText(t, filepath.Join("plain", tc.name+".txt"), stdout.String())
+		})
+	}
+}
+
+func assertGoldenText(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name)
+	if os.Getenv("UPDATE_GOLDEN") == "1" {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("write golden %s: %v", path, err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s: %v", path, err)
+	}
+	if got != string(want) {
+		t.Fatalf("golden mismatch for %s\n--- got ---\n%s\n--- want ---\n%s", name, got, string(want))
+	}
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const pluginPrefix = "acal-"
+
+func splitPluginArgs(root *cobra.Command, args []string) (globals []string, name string, rest []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return nil, "", nil
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return args[:i], arg, args[i+1:]
+		}
+		if strings.Contains(arg, "=") {
+			continue
+		}
+		flags := root.PersistentFlags()
+		f := flags.Lookup(strings.TrimLeft(arg, "-"))
+		if !strings.HasPrefix(arg, "--") && len(arg) == 2 {
+			f = flags.ShorthandLookup(arg[1:])
+		}
+		if f != nil && f.NoOptDefVal == "" {
+			i++
+		}
+	}
+	return nil, "", nil
+}
+
+func isBuiltinCommand(root *cobra.Command, name string) bool {
+	switch name {
+	case "help", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func findPlugin(root *cobra.Command, args []string) (path string, globals, rest []string, ok bool) {
+	globals, name, rest := splitPluginArgs(root, args)
+	if name == "" || strings.ContainsAny(name, `/\`) || isBuiltinCommand(root, name) {
+		return "", nil, nil, false
+	}
+	path, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return "", nil, nil, false
+	}
+	return path, globals, rest, true
+}
+
+func pluginEnv(ro *globalOptions) []string {
+	mode := "auto"
+	switch {
+	case ro.JSON:
+		mode = "json"
+	case ro.JSONL:
+		mode = "jsonl"
+	case ro.Plain:
+		mode = "plain"
+	}
+	vars := map[string]string{
+		"ACAL_OUTPUT":  mode,
+		"ACAL_BACKEND": ro.Backend,
+		"ACAL_PROFILE": ro.Profile,
+		"ACAL_TIMEOUT": ro.Timeout.String(),
+	}
+	if tz := firstNonEmpty(ro.TZ, env("TZ")); tz != "" {
+		vars["ACAL_TZ"] = tz
+		vars["ACAL_TIMEZONE"] = tz
+	}
+	for k, v := range map[string]string{
+		"ACAL_CONFIG":      ro.Config,
+		"ACAL_NOW":         ro.Now,
+		"ACAL_LOCALE":      ro.Locale,
+		"ACAL_TIME_FORMAT": ro.TimeFormat,
+		"ACAL_ID_FORMAT":   ro.IDFormat,
+		"ACAL_THEME":       ro.Theme,
+		"ACAL_WEEK_START":  ro.WeekStart,
+		"ACAL_FIELDS":      ro.Fields,
+		"ACAL_CALDAV_URL":  ro.CalDAVURL,
+		"ACAL_CALDAV_USER": ro.CalDAVUser,
+		"ACAL_MOCK_FILE":   ro.MockFile,
+	} {
+		if v != "" {
+			vars[k] = v
+		}
+	}
+	for k, on := range map[string]bool{"ACAL_HIDE_PRIVATE": ro.HidePrivate, "ACAL_NO_INPUT": ro.NoInput} {
+		if on {
+			vars[k] = "true"
+		}
+	}
+	if ro.NoColor {
+		vars["NO_COLOR"] = "1"
+	}
+	if exe, err := os.Executable(); err == nil {
+		vars["ACAL_BIN"] = exe
+	}
+	out := make([]string, 0, len(vars))
+	for k, v := range vars {
+		out = append(out, k+"="+v)
+	}
+	return out
+}
+
+func runPlugin(root *cobra.Command, opts *globalOptions, args []string, stdin io.Reader, stdout, stderr io.Writer) (int, bool) {
+	path, globals, rest, ok := findPlugin(root, args)
+	if !ok {
+		return 0, false
+	}
+	fail := func(err error) (int, bool) {
+		renderTopLevelError(root, err)
+		return ExitCode(err), true
+	}
+	if err := root.ParseFlags(globals); err != nil {
+		return fail(Wrap(2, err))
+	}
+	ro, err := resolveGlobalOptions(root, opts)
+	if err != nil {
+		return fail(Wrap(2, err))
+	}
+	if conflictCount(ro.JSON, ro.JSONL, ro.Plain) > 1 {
+		return fail(Wrap(2, errors.New("--json, --jsonl, and --plain are mutually exclusive")))
+	}
+	c := exec.Command(path, rest...)
+	c.Stdin, c.Stdout, c.Stderr = stdin, stdout, stderr
+	c.Env = append(os.Environ(), pluginEnv(ro)...)
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if code := exitErr.ExitCode(); code > 0 {
+				return code, true
+			}
+			return 1, true
+		}
+		return fail(Wrap(1, fmt.Errorf("run %s: %w", path, err)))
+	}
+	return 0, true
+}
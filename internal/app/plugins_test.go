@@ -0,0 +1,55 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSplitPluginArgs(t *testing.T) {
+	root := NewRootCommand()
+	globals, name, rest := splitPluginArgs(root, []string{"--tz", "UTC", "-q", "--json", "hello", "--tz", "x"})
+	if name != "hello" || strings.Join(globals, " ") != "--tz UTC -q --json" || strings.Join(rest, " ") != "--tz x" {
+		t.Fatalf("unexpected split: %v %q %v", globals, name, rest)
+	}
+	if _, name, _ := splitPluginArgs(root, []string{"--", "hello"}); name != "" {
+		t.Fatalf("expected no plugin after --, got %q", name)
+	}
+}
+
+func TestRunPluginPassesGlobalOptions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixture is a shell script")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"$ACAL_OUTPUT $ACAL_TZ $ACAL_BACKEND $*\"\nexit 3\n"
+	if err := os.WriteFile(filepath.Join(dir, "acal-hello"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "acal-today"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	t.Setenv("ACAL_BACKEND", "")
+
+	root, opts := newRootCommand()
+	var out, errOut bytes.Buffer
+	code, ok := runPlugin(root, opts, []string{"--json", "--tz", "Europe/Athens", "--backend", "mock", "hello", "--json", "a"}, nil, &out, &errOut)
+	if !ok || code != 3 {
+		t.Fatalf("expected plugin exit 3, got ok=%v code=%d stderr=%s", ok, code, errOut.String())
+	}
+	if got := out.String(); got != "json Europe/Athens mock --json a\n" {
+		t.Fatalf("unexpected plugin output: %q", got)
+	}
+
+	root, opts = newRootCommand()
+	if _, ok := runPlugin(root, opts, []string{"today"}, nil, &out, &errOut); ok {
+		t.Fatal("built-in commands must win over plugins")
+	}
+	if _, ok := runPlugin(root, opts, []string{"missing"}, nil, &out, &errOut); ok {
+		t.Fatal("unknown commands without a plugin must fall through to cobra")
+	}
+}
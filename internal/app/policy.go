@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+var errCalendarWriteDenied = errors.New("calendar is not writable under write policy")
+
+type writePolicy struct {
+	Writable  []string
+	Protected []string
+}
+
+type writePolicyContextKey struct{}
+
+func (p writePolicy) empty() bool {
+	return len(p.Writable) == 0 && len(p.Protected) == 0
+}
+
+func (p writePolicy) allows(c contract.Calendar) bool {
+	match := func(names []string) bool {
+		for _, n := range names {
+			n = strings.TrimSpace(n)
+			if n != "" && (strings.EqualFold(n, c.ID) || strings.EqualFold(n, c.Name)) {
+				return true
+			}
+		}
+		return false
+	}
+	if match(p.Protected) {
+		return false
+	}
+	return len(p.Writable) == 0 || match(p.Writable)
+}
+
+func writePolicyFromContext(ctx context.Context) writePolicy {
+	p, _ := ctx.Value(writePolicyContextKey{}).(writePolicy)
+	return p
+}
+
+func applyWritePolicy(ctx context.Context, items []contract.Calendar) []contract.Calendar {
+	p := writePolicyFromContext(ctx)
+	if p.empty() {
+		return items
+	}
+	for i := range items {
+		if !p.allows(items[i]) {
+			items[i].Writable = false
+		}
+	}
+	return items
+}
+
+func checkCalendarWritePolicy(ctx context.Context, be backend.Backend, calendar string) error {
+	p := writePolicyFromContext(ctx)
+	if p.empty() {
+		return nil
+	}
+	target := contract.Calendar{ID: calendar, Name: calendar}
+	cals, err := listCalendarsWithTimeout(ctx, be)
+	if err != nil {
+		return err
+	}
+	for _, c := range cals {
+		if strings.EqualFold(c.ID, calendar) || strings.EqualFold(c.Name, calendar) {
+			target = c
+			break
+		}
+	}
+	if !p.allows(target) {
+		return fmt.Errorf("%w: %s", errCalendarWriteDenied, firstNonEmpty(target.Name, target.ID))
+	}
+	return nil
+}
+
+func checkEventWritePolicy(ctx context.Context, be backend.Backend, id string) error {
+	p := writePolicyFromContext(ctx)
+	if p.empty() {
+		return nil
+	}
+	item, err := getEventByIDWithTimeout(ctx, be, id)
+	if err != nil {
+		return err
+	}
+	c := contract.Calendar{ID: item.CalendarID, Name: item.CalendarName}
+	if !p.allows(c) {
+		return fmt.Errorf("%w: %s", errCalendarWriteDenied, firstNonEmpty(c.Name, c.ID))
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestWritePolicyAllows(t *testing.T) {
+	work := contract.Calendar{ID: "work-id", Name: "Work"}
+	family := contract.Calendar{ID: "family-id", Name: "Family"}
+	tests := []struct {
+		name   string
+		policy writePolicy
+		cal    contract.Calendar
+		want   bool
+	}{
+		{name: "empty policy", policy: writePolicy{}, cal: family, want: true},
+		{name: "allowlisted by name", policy: writePolicy{Writable: []string{"work"}}, cal: work, want: true},
+		{name: "allowlisted by id", policy: writePolicy{Writable: []string{"work-id"}}, cal: work, want: true},
+		{name: "not allowlisted", policy: writePolicy{Writable: []string{"Work"}}, cal: family, want: false},
+		{name: "protected", policy: writePolicy{Protected: []string{"Family"}}, cal: family, want: false},
+		{name: "protected wins over writable", policy: writePolicy{Writable: []string{"Family"}, Protected: []string{"family-id"}}, cal: family, want: false},
+	}
+	for _, tc := range tests {
+		if got := tc.policy.allows(tc.cal); got != tc.want {
+			t.Fatalf("%s: got %v want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestWritePolicyEnforcedFromConfig(t *testing.T) {
+	cfg := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", cfg)
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	if err := os.MkdirAll(filepath.Join(cfg, "acal"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cfg, "acal", "config.toml"), []byte("writable_calendars = [\"Work\", \"Agent\"]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}, {ID: "family", Name: "Family", Writable: true}},
+		Events:    []contract.Event{{ID: "dinner", CalendarID: "family", CalendarName: "Family", Title: "Dinner", Start: start, End: start.Add(time.Hour)}},
+	})
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+	run := func(args ...string) (int, []byte) {
+		cmd := NewRootCommand()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs(append(args, "--json"))
+		return ExitCode(cmd.Execute()), out.Bytes()
+	}
+
+	if code, _ := run("events", "add", "--calendar", "Family", "--title", "Sneaky", "--start", "2026-03-03T10:00:00Z", "--duration", "30m"); code != 3 {
+		t.Fatalf("expected add to protected calendar to exit 3, got %d", code)
+	}
+	if code, _ := run("events", "delete", "dinner", "--force"); code != 3 {
+		t.Fatalf("expected delete in protected calendar to exit 3, got %d", code)
+	}
+	if code, _ := run("events", "update", "dinner", "--title", "Renamed"); code != 3 {
+		t.Fatalf("expected update in protected calendar to exit 3, got %d", code)
+	}
+	if code, _ := run("events", "add", "--calendar", "work", "--title", "Focus", "--start", "2026-03-03T10:00:00Z", "--duration", "30m"); code != 0 {
+		t.Fatalf("expected add to allowlisted calendar to succeed, got %d", code)
+	}
+	if item, err := fb.GetEventByID(t.Context(), "dinner"); err != nil || item.Title != "Dinner" {
+		t.Fatalf("protected event must be untouched, got %+v (%v)", item, err)
+	}
+
+	code, out := run("calendars", "list")
+	if code != 0 {
+		t.Fatalf("calendars list failed: %d", code)
+	}
+	var env struct {
+		Data []contract.Calendar `json:"data"`
+	}
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range env.Data {
+		if c.Writable != (c.Name == "Work") {
+			t.Fatalf("unexpected writable flag for %s: %v", c.Name, c.Writable)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/agis/acal/internal/output"
+)
+
+var projectionSources = map[string][]string{
+	"location":    {"location"},
+	"notes":       {"notes"},
+	"url":         {"url"},
+	"tag":         {"notes"},
+	"tags":        {"notes"},
+	"attendee":    {"notes"},
+	"attendees":   {"notes"},
+	"meetingurl":  {"url", "location", "notes"},
+	"isvideocall": {"url", "location", "notes"},
+	"etag":        {"location", "notes", "url"},
+}
+
+func eventProjection(p output.Printer, needs ...string) []string {
+	if len(p.Fields) == 0 || p.EffectiveSuccessMode() != output.ModePlain {
+		return nil
+	}
+	out := make([]string, 0, len(p.Fields)+len(needs))
+	for _, f := range append(append([]string(nil), p.Fields...), needs...) {
+		f = strings.ToLower(strings.TrimSpace(f))
+		out = append(out, f)
+		out = append(out, projectionSources[strings.ReplaceAll(f, "_", "")]...)
+	}
+	return out
+}
+
+func videoCallNeeds(videoOnly bool) []string {
+	if videoOnly {
+		return []string{"meeting_url"}
+	}
+	return nil
+}
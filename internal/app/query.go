@@ -1,18 +1,24 @@
 package app
 
 import (
+	"container/heap"
+	"context"
 	"fmt"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/agis/acal/internal/backend"
 	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/timeparse"
 )
 
 type predicate struct {
 	field string
 	op    string
 	value string
+	// who is set when value is a [people] handle such as @alice.
+	who *person
 }
 
 func parsePredicates(wheres []string) ([]predicate, error) {
@@ -73,6 +79,9 @@ func matchesAll(e contract.Event, preds []predicate) (bool, error) {
 }
 
 func matchesOne(e contract.Event, p predicate) (bool, error) {
+	if p.who != nil {
+		return matchesPerson(e, p)
+	}
 	switch p.field {
 	case "title":
 		return compareString(e.Title, p.op, p.value)
@@ -86,10 +95,24 @@ func matchesOne(e contract.Event, p predicate) (bool, error) {
 		return compareString(e.Notes, p.op, p.value)
 	case "id":
 		return compareString(e.ID, p.op, p.value)
+	case "status":
+		return compareString(e.Status, p.op, p.value)
+	case "availability":
+		return compareString(e.Availability, p.op, p.value)
+	case "sensitivity":
+		return compareString(e.Sensitivity, p.op, p.value)
+	case "tag", "tags":
+		return compareTags(parseTagsMarker(e.Notes), p.op, p.value)
+	case "attendee", "attendees":
+		return compareAttendees(extractAttendees(e.Notes), p.op, p.value)
 	case "start":
 		return compareTime(e.Start, p.op, p.value)
 	case "end":
 		return compareTime(e.End, p.op, p.value)
+	case "created_at":
+		return compareTime(e.CreatedAt, p.op, p.value)
+	case "updated_at":
+		return compareTime(e.UpdatedAt, p.op, p.value)
 	default:
 		return false, fmt.Errorf("unsupported field in --where: %s", p.field)
 	}
@@ -113,7 +136,11 @@ func compareString(actual, op, expected string) (bool, error) {
 func compareTime(actual time.Time, op, expected string) (bool, error) {
 	parsed, err := time.Parse(time.RFC3339, expected)
 	if err != nil {
-		return false, fmt.Errorf("time predicate expects RFC3339 value, got %q", expected)
+		d, derr := timeparse.ParseDuration(expected)
+		if derr != nil || !strings.ContainsAny(expected[:1], "+-") {
+			return false, fmt.Errorf("time predicate expects RFC3339 value or offset like -7d, got %q", expected)
+		}
+		parsed = currentTime().Add(d)
 	}
 	switch op {
 	case "==":
@@ -134,24 +161,121 @@ func compareTime(actual time.Time, op, expected string) (bool, error) {
 }
 
 func sortEvents(items []contract.Event, sortField, order string) {
-	desc := strings.EqualFold(order, "desc")
-	sort.Slice(items, func(i, j int) bool {
-		var less bool
-		switch strings.ToLower(sortField) {
-		case "title":
-			less = items[i].Title < items[j].Title
-		case "end":
-			less = items[i].End.Before(items[j].End)
-		case "updated_at":
-			less = items[i].UpdatedAt.Before(items[j].UpdatedAt)
-		case "calendar":
-			less = items[i].CalendarName < items[j].CalendarName
-		default:
-			less = items[i].Start.Before(items[j].Start)
+	less := eventLess(sortField, order)
+	sort.SliceStable(items, func(i, j int) bool { return less(&items[i], &items[j]) })
+}
+
+func eventLess(sortField, order string) func(a, b *contract.Event) bool {
+	var less func(a, b *contract.Event) bool
+	switch strings.ToLower(sortField) {
+	case "title":
+		less = func(a, b *contract.Event) bool { return a.Title < b.Title }
+	case "end":
+		less = func(a, b *contract.Event) bool { return a.End.Before(b.End) }
+	case "created_at":
+		less = func(a, b *contract.Event) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	case "updated_at":
+		less = func(a, b *contract.Event) bool { return a.UpdatedAt.Before(b.UpdatedAt) }
+	case "calendar":
+		less = func(a, b *contract.Event) bool { return a.CalendarName < b.CalendarName }
+	default:
+		less = func(a, b *contract.Event) bool { return a.Start.Before(b.Start) }
+	}
+	if strings.EqualFold(order, "desc") {
+		return func(a, b *contract.Event) bool { return less(b, a) }
+	}
+	return less
+}
+
+func isBackendOrder(sortField, order string) bool {
+	switch strings.ToLower(sortField) {
+	case "title", "end", "created_at", "updated_at", "calendar":
+		return false
+	}
+	return !strings.EqualFold(order, "desc")
+}
+
+func validatePredicates(preds []predicate) error {
+	for _, p := range preds {
+		if _, err := matchesOne(contract.Event{}, p); err != nil {
+			return err
 		}
-		if desc {
-			return !less
+	}
+	return nil
+}
+
+func runEventQuery(ctx context.Context, be backend.Backend, f backend.EventFilter, preds []predicate, sortField, order string, limit int) ([]contract.Event, error) {
+	recordRequestFilter(ctx, f)
+	f.Limit = 0
+	if len(preds) == 0 && isBackendOrder(sortField, order) {
+		f.Limit = limit
+	}
+	top := &eventTopN{limit: limit, less: eventLess(sortField, order)}
+	err := streamEventsWithTimeout(ctx, be, f, func(e contract.Event) error {
+		ok, err := matchesAll(e, preds)
+		if ok {
+			top.add(e)
 		}
-		return less
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+	return top.sorted(), nil
+}
+
+type rankedEvent struct {
+	event contract.Event
+	seq   int
+}
+
+type eventTopN struct {
+	limit int
+	less  func(a, b *contract.Event) bool
+	items []rankedEvent
+	seq   int
+}
+
+func (h *eventTopN) before(a, b *rankedEvent) bool {
+	if h.less(&a.event, &b.event) {
+		return true
+	}
+	if h.less(&b.event, &a.event) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+func (h *eventTopN) Len() int           { return len(h.items) }
+func (h *eventTopN) Less(i, j int) bool { return h.before(&h.items[j], &h.items[i]) }
+func (h *eventTopN) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *eventTopN) Push(x any)         { h.items = append(h.items, x.(rankedEvent)) }
+
+func (h *eventTopN) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+func (h *eventTopN) add(e contract.Event) {
+	r := rankedEvent{event: e, seq: h.seq}
+	h.seq++
+	switch {
+	case h.limit <= 0:
+		h.items = append(h.items, r)
+	case len(h.items) < h.limit:
+		heap.Push(h, r)
+	case h.before(&r, &h.items[0]):
+		h.items[0] = r
+		heap.Fix(h, 0)
+	}
+}
+
+func (h *eventTopN) sorted() []contract.Event {
+	sort.Slice(h.items, func(i, j int) bool { return h.before(&h.items[i], &h.items[j]) })
+	out := make([]contract.Event, len(h.items))
+	for i := range h.items {
+		out[i] = h.items[i].event
+	}
+	return out
 }
@@ -1,6 +1,9 @@
 package app
 
 import (
+	"fmt"
+	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
@@ -82,3 +85,99 @@ func mustRFC3339(t *testing.T, s string) time.Time {
 	}
 	return v
 }
+
+func TestApplyPredicatesStatusAndAvailability(t *testing.T) {
+	items := []contract.Event{
+		{ID: "a", Status: contract.StatusTentative, Availability: contract.AvailabilityFree},
+		{ID: "b", Status: contract.StatusConfirmed, Availability: contract.AvailabilityBusy},
+	}
+	preds, err := parsePredicates([]string{"availability==free", "status!=confirmed"})
+	if err != nil {
+		t.Fatalf("parsePredicates error: %v", err)
+	}
+	got, err := applyPredicates(items, preds)
+	if err != nil {
+		t.Fatalf("applyPredicates error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "a" {
+		t.Fatalf("unexpected filtered events: %+v", got)
+	}
+}
+
+func TestEventTopNMatchesFullSort(t *testing.T) {
+	base := mustRFC3339(t, "2026-03-01T00:00:00Z")
+	r := rand.New(rand.NewSource(7))
+	items := make([]contract.Event, 200)
+	for i := range items {
+		start := base.Add(time.Duration(r.Intn(500)) * time.Hour)
+		items[i] = contract.Event{ID: fmt.Sprint(i), Title: fmt.Sprintf("t%02d", r.Intn(30)), Start: start, End: start.Add(time.Duration(r.Intn(4)+1) * time.Hour)}
+	}
+	for _, field := range []string{"start", "end", "title"} {
+		for _, order := range []string{"asc", "desc"} {
+			want := append([]contract.Event(nil), items...)
+			sortEvents(want, field, order)
+			top := &eventTopN{limit: 15, less: eventLess(field, order)}
+			for _, e := range items {
+				top.add(e)
+			}
+			got := top.sorted()
+			if len(got) != 15 {
+				t.Fatalf("%s %s: kept %d events", field, order, len(got))
+			}
+			for i := range got {
+				if got[i].ID != want[i].ID {
+					t.Fatalf("%s %s: position %d got %s want %s", field, order, i, got[i].ID, want[i].ID)
+				}
+			}
+		}
+	}
+}
+
+func TestEventsQueryLimitAppliesAfterPredicates(t *testing.T) {
+	start := mustRFC3339(t, "2026-03-03T09:00:00Z")
+	fb := &scopeCaptureBackend{events: []contract.Event{
+		{ID: "a", Title: "Standup", Start: start, End: start.Add(time.Hour)},
+		{ID: "b", Title: "Lunch", Start: start.Add(3 * time.Hour), End: start.Add(4 * time.Hour)},
+		{ID: "c", Title: "Retro", Start: start.Add(5 * time.Hour), End: start.Add(6 * time.Hour)},
+	}}
+	out := string(runWithBackend(t, fb, "events", "query", "--from", "2026-03-03", "--to", "2026-03-04", "--where", "title!=standup", "--sort", "start", "--order", "desc", "--limit", "1", "--plain", "--fields", "id"))
+	if strings.TrimSpace(out) != "c" {
+		t.Fatalf("unexpected query result %q", out)
+	}
+	if fb.lastFilter.Limit != 0 {
+		t.Fatalf("limit must not reach the backend before filtering, got %d", fb.lastFilter.Limit)
+	}
+	runWithBackend(t, fb, "events", "query", "--from", "2026-03-03", "--to", "2026-03-04", "--limit", "2", "--json")
+	if fb.lastFilter.Limit != 2 {
+		t.Fatalf("unfiltered start-order query should push its limit down, got %d", fb.lastFilter.Limit)
+	}
+}
+
+func TestApplyPredicatesCreatedAtRelative(t *testing.T) {
+	if err := setPinnedNow("2026-03-10T12:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pinnedNow.Store(nil) })
+	items := []contract.Event{
+		{ID: "old", CreatedAt: mustRFC3339(t, "2026-01-02T09:00:00Z")},
+		{ID: "new", CreatedAt: mustRFC3339(t, "2026-03-08T09:00:00Z")},
+	}
+	preds, err := parsePredicates([]string{"created_at>=-7d"})
+	if err != nil {
+		t.Fatalf("parsePredicates error: %v", err)
+	}
+	got, err := applyPredicates(items, preds)
+	if err != nil {
+		t.Fatalf("applyPredicates error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "new" {
+		t.Fatalf("expected only the recently created event, got %+v", got)
+	}
+	sortEvents(items, "created_at", "desc")
+	if items[0].ID != "new" {
+		t.Fatalf("expected newest first, got %s", items[0].ID)
+	}
+	if err := validatePredicates([]predicate{{field: "created_at", op: ">=", value: "7d"}}); err == nil {
+		t.Fatal("expected an unsigned offset to be rejected")
+	}
+}
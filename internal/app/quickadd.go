@@ -2,8 +2,7 @@ package app
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
+	"os"
 	"strings"
 	"time"
 
@@ -14,8 +13,6 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var clockRe = regexp.MustCompile(`^\d{1,2}:\d{2}$`)
-
 func newQuickAddCmd(opts *globalOptions) *cobra.Command {
 	return newQuickAddCommand(opts, "quick-add <text>", "Create an event from natural text", "quick-add")
 }
@@ -29,10 +26,16 @@ func newQuickAddCommand(opts *globalOptions, use, short, commandName string) *co
 	var duration string
 	var dryRun bool
 	var allDay bool
+	var fromClipboard, yes, noConflict, force, ignoreQuiet bool
 	cmd := &cobra.Command{
 		Use:   use,
 		Short: short,
-		Args:  cobra.ExactArgs(1),
+		Args: func(c *cobra.Command, args []string) error {
+			if fromClipboard {
+				return cobra.NoArgs(c, args)
+			}
+			return cobra.ExactArgs(1)(c, args)
+		},
 		RunE: func(c *cobra.Command, args []string) error {
 			p, be, ro, err := buildContext(c, opts, commandName)
 			if err != nil {
@@ -43,24 +46,74 @@ func newQuickAddCommand(opts *globalOptions, use, short, commandName string) *co
 			loc := resolveLocation(ro.TZ)
 			defaultDuration := 60 * time.Minute
 			if strings.TrimSpace(duration) != "" {
-				parsed, err := time.ParseDuration(duration)
+				parsed, err := timeparse.ParseDuration(duration)
 				if err != nil || parsed <= 0 {
-					_ = p.Error(contract.ErrInvalidUsage, "invalid --duration", "Use a positive Go duration like 30m or 1h")
+					_ = p.Error(contract.ErrInvalidUsage, "invalid --duration", durationHint)
 					return WrapPrinted(2, fmt.Errorf("invalid --duration: %q", duration))
 				}
 				defaultDuration = parsed
 			}
-			in, err := parseQuickAddInput(args[0], time.Now(), loc, calendar, defaultDuration, allDay)
-			if err != nil {
-				_ = p.Error(contract.ErrInvalidUsage, err.Error(), `Example: acal quick-add "tomorrow 10:00 Standup @Work 30m"`)
-				return WrapPrinted(2, err)
+			var in backend.EventCreateInput
+			meta := map[string]any{"dry_run": true}
+			if fromClipboard {
+				text, err := readClipboard()
+				if err != nil {
+					return failWithHint(p, contract.ErrGeneric, err, "Clipboard access needs pbpaste (macOS)", 1)
+				}
+				var matched string
+				in, matched, err = parseClipboardText(text, currentTime(), loc, calendar, defaultDuration, allDay)
+				if err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Copy text with a title and a date and time, or pass the text as an argument", 2)
+				}
+				if d, ok, err := quickAddDefaultDuration(c, ro, in.Calendar); err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Fix calendar_defaults in config", 2)
+				} else if ok {
+					in, _, _ = parseClipboardText(text, currentTime(), loc, calendar, d, allDay)
+					meta["calendar_defaults"] = []string{"duration"}
+				}
+				meta["source"], meta["matched"] = "clipboard", matched
+				// Extraction is a guess, so only --yes or a confirmation creates the event.
+				if !dryRun && !yes {
+					confirmed := false
+					if !ro.NoInput && stdinInteractive() {
+						_, _ = fmt.Fprintf(c.ErrOrStderr(), "%s\n  %s – %s\n  calendar %s\n", in.Title, in.Start.In(loc).Format("Mon 2006-01-02 15:04"), in.End.In(loc).Format("15:04"), in.Calendar)
+						if in.Location != "" {
+							_, _ = fmt.Fprintf(c.ErrOrStderr(), "  at %s\n", in.Location)
+						}
+						confirmed, err = promptYesNo(os.Stdin, c.ErrOrStderr(), "Create this event?")
+						if err != nil {
+							return failWithHint(p, contract.ErrInvalidUsage, err, "Pass --yes to create without asking", 2)
+						}
+					}
+					dryRun = !confirmed
+				}
+			} else {
+				in, err = parseQuickAddInput(args[0], currentTime(), loc, calendar, defaultDuration, allDay)
+				if err != nil {
+					_ = p.Error(contract.ErrInvalidUsage, err.Error(), `Example: acal quick-add "tomorrow 10:00 Standup @Work 30m"`)
+					return WrapPrinted(2, err)
+				}
+				if d, ok, err := quickAddDefaultDuration(c, ro, in.Calendar); err != nil {
+					return failWithHint(p, contract.ErrInvalidUsage, err, "Fix calendar_defaults in config", 2)
+				} else if ok {
+					in, _ = parseQuickAddInput(args[0], currentTime(), loc, calendar, d, allDay)
+					meta["calendar_defaults"] = []string{"duration"}
+				}
 			}
-			if dryRun {
-				if p.EffectiveSuccessMode() == output.ModePlain {
-					_, _ = fmt.Fprintf(c.OutOrStdout(), "dry-run\t%s\t%s\t%s\t%s\n", in.Start.Format(time.RFC3339), in.End.Format(time.RFC3339), in.Calendar, in.Title)
-					return nil
+			defaults, _ := calendarDefaultsFor(ro.CalendarDefaults, in.Calendar)
+			if ok, err := defaults.applyReminder(&in); err != nil {
+				return failWithHint(p, contract.ErrInvalidUsage, err, "Fix calendar_defaults in config", 2)
+			} else if ok {
+				applied, _ := meta["calendar_defaults"].([]string)
+				meta["calendar_defaults"] = append(applied, "reminder")
+			}
+			if noConflict && !force {
+				if err := checkNoConflict(ctx, p, be, in, loc, quietHoursFor(ro, ignoreQuiet)); err != nil {
+					return err
 				}
-				return successWithMeta(ctx, p, ro, in, map[string]any{"dry_run": true}, nil)
+			}
+			if dryRun {
+				return successDryRun(ctx, p, ro, []dryRunPreview{previewAdd(in)}, meta, nil)
 			}
 			item, err := addEventWithTimeout(ctx, be, in)
 			if err != nil {
@@ -74,13 +127,22 @@ func newQuickAddCommand(opts *globalOptions, use, short, commandName string) *co
 			if item != nil {
 				_ = appendHistory(historyEntry{Type: "add", EventID: item.ID, Created: item})
 			}
-			return successWithMeta(ctx, p, ro, item, map[string]any{"count": 1}, nil)
+			done := map[string]any{"count": 1}
+			if applied, ok := meta["calendar_defaults"]; ok {
+				done["calendar_defaults"] = applied
+			}
+			return successWithMeta(ctx, p, ro, item, done, nil)
 		},
 	}
 	cmd.Flags().StringVar(&calendar, "calendar", "", "Default calendar if @Calendar is missing")
 	cmd.Flags().StringVar(&duration, "duration", "1h", "Default duration if missing in text")
 	cmd.Flags().BoolVar(&allDay, "all-day", false, "Create an all-day event")
 	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview without writing")
+	cmd.Flags().BoolVar(&fromClipboard, "from-clipboard", false, "Extract the event from clipboard text (pbpaste) and propose it")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "With --from-clipboard: create without asking")
+	cmd.Flags().BoolVar(&noConflict, "no-conflict", false, "Refuse to create the event if it overlaps an existing one or quiet hours (exit 5)")
+	cmd.Flags().BoolVar(&ignoreQuiet, "ignore-quiet-hours", false, "Let --no-conflict accept times inside quiet_hours")
+	cmd.Flags().BoolVar(&force, "force", false, "Create even if --no-conflict finds an overlap")
 	return cmd
 }
 
@@ -100,13 +162,23 @@ func parseQuickAddInput(input string, now time.Time, loc *time.Location, default
 	duration := defaultDuration
 	calendar := strings.TrimSpace(defaultCalendar)
 	titleParts := make([]string, 0, len(tokens)-consumed)
-	for _, tok := range tokens[consumed:] {
+	rest := tokens[consumed:]
+	for i := 0; i < len(rest); i++ {
+		tok := rest[i]
 		if strings.HasPrefix(tok, "@") && len(tok) > 1 {
 			if calendar == "" {
 				calendar = strings.TrimSpace(tok[1:])
 				continue
 			}
 		}
+		// Requiring a leading digit keeps title words like "a day" out.
+		if i+1 < len(rest) && tok[0] >= '0' && tok[0] <= '9' {
+			if d, ok := parseQuickAddDuration(tok + " " + rest[i+1]); ok {
+				duration = d
+				i++
+				continue
+			}
+		}
 		if d, ok := parseQuickAddDuration(tok); ok {
 			duration = d
 			continue
@@ -121,7 +193,7 @@ func parseQuickAddInput(input string, now time.Time, loc *time.Location, default
 		return backend.EventCreateInput{}, fmt.Errorf("missing calendar; include @Calendar or --calendar")
 	}
 	if !allDay && !hasTime {
-		return backend.EventCreateInput{}, fmt.Errorf("missing time; include HH:MM or use --all-day")
+		return backend.EventCreateInput{}, fmt.Errorf("missing time; include HH:MM or 3pm, or use --all-day")
 	}
 	if duration <= 0 {
 		return backend.EventCreateInput{}, fmt.Errorf("duration must be positive")
@@ -145,52 +217,46 @@ func parseQuickAddStart(tokens []string, now time.Time, loc *time.Location) (tim
 	if len(tokens) == 0 {
 		return time.Time{}, 0, false, fmt.Errorf("missing date/time")
 	}
-	if len(tokens) >= 2 && isDayToken(tokens[0]) && clockRe.MatchString(tokens[1]) {
+	if len(tokens) >= 2 && isDayToken(tokens[0]) && timeparse.IsClock(tokens[1]) {
 		day, err := timeparse.ParseDateTime(tokens[0], now, loc)
 		if err != nil {
 			return time.Time{}, 0, false, fmt.Errorf("invalid day: %w", err)
 		}
-		hour, minute, err := parseClock(tokens[1])
+		hour, minute, err := timeparse.ParseClock(tokens[1])
 		if err != nil {
 			return time.Time{}, 0, false, err
 		}
 		start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
 		return start, 2, true, nil
 	}
+	if len(tokens) >= 3 && timeparse.IsClock(tokens[2]) && !strings.Contains(tokens[1], ":") {
+		// Month-name dates span two tokens: "3 märz 10:00", "march 3 10:00".
+		if day, err := timeparse.ParseDateTime(tokens[0]+" "+tokens[1], now, loc); err == nil {
+			hour, minute, err := timeparse.ParseClock(tokens[2])
+			if err != nil {
+				return time.Time{}, 0, false, err
+			}
+			return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc), 3, true, nil
+		}
+	}
 	if len(tokens) >= 2 {
 		joined := tokens[0] + " " + tokens[1]
 		if ts, err := timeparse.ParseDateTime(joined, now, loc); err == nil {
-			return ts, 2, true, nil
+			return ts, 2, timeparse.IsClock(tokens[1]), nil
 		}
 	}
 	ts, err := timeparse.ParseDateTime(tokens[0], now, loc)
 	if err != nil {
 		return time.Time{}, 0, false, fmt.Errorf("invalid date/time")
 	}
-	return ts, 1, strings.Contains(tokens[0], ":"), nil
-}
-
-func parseClock(s string) (int, int, error) {
-	parts := strings.Split(s, ":")
-	if len(parts) != 2 {
-		return 0, 0, fmt.Errorf("invalid time: %s", s)
-	}
-	hour, err := strconv.Atoi(parts[0])
-	if err != nil || hour < 0 || hour > 23 {
-		return 0, 0, fmt.Errorf("invalid time: %s", s)
-	}
-	minute, err := strconv.Atoi(parts[1])
-	if err != nil || minute < 0 || minute > 59 {
-		return 0, 0, fmt.Errorf("invalid time: %s", s)
-	}
-	return hour, minute, nil
+	return ts, 1, timeparse.IsClock(tokens[0]) || strings.Contains(tokens[0], ":"), nil
 }
 
 func parseQuickAddDuration(token string) (time.Duration, bool) {
 	if token == "" {
 		return 0, false
 	}
-	d, err := time.ParseDuration(token)
+	d, err := timeparse.ParseDuration(token)
 	if err != nil || d <= 0 {
 		return 0, false
 	}
@@ -199,7 +265,7 @@ func parseQuickAddDuration(token string) (time.Duration, bool) {
 
 func isDayToken(token string) bool {
 	s := strings.ToLower(strings.TrimSpace(token))
-	if s == "today" || s == "tomorrow" || s == "yesterday" {
+	if timeparse.IsDayWord(s) {
 		return true
 	}
 	if strings.HasSuffix(s, "d") && (strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-")) {
@@ -210,3 +276,14 @@ func isDayToken(token string) bool {
 	}
 	return false
 }
+
+func quickAddDefaultDuration(c *cobra.Command, ro *globalOptions, cal string) (time.Duration, bool, error) {
+	if c.Flags().Changed("duration") {
+		return 0, false, nil
+	}
+	defaults, ok := calendarDefaultsFor(ro.CalendarDefaults, cal)
+	if !ok {
+		return 0, false, nil
+	}
+	return defaults.duration()
+}
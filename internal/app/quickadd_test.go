@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/agis/acal/internal/timeparse"
 )
 
 func TestParseQuickAddInputBasic(t *testing.T) {
@@ -84,7 +86,63 @@ func TestQuickAddDryRunPlainOutput(t *testing.T) {
 	if err := cmd.Execute(); err != nil {
 		t.Fatalf("quick-add failed: %v", err)
 	}
-	if got := out.String(); !strings.Contains(got, "dry-run\t") || !strings.Contains(got, "\tWork\tStandup") {
+	if got := out.String(); !strings.HasPrefix(got, "+ add \"Standup\"\n") || !strings.Contains(got, "    calendar: \"Work\"\n") {
 		t.Fatalf("expected readable plain quick-add output, got: %q", got)
 	}
 }
+
+func TestParseQuickAddInputLocalizedDay(t *testing.T) {
+	if err := timeparse.SetLocale("es"); err != nil {
+		t.Fatalf("SetLocale failed: %v", err)
+	}
+	t.Cleanup(func() { _ = timeparse.SetLocale("") })
+	now := time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC) // a Monday
+	in, err := parseQuickAddInput("mañana 10:00 Standup @Work 30m", now, time.UTC, "", time.Hour, false)
+	if err != nil {
+		t.Fatalf("parseQuickAddInput error: %v", err)
+	}
+	if got, want := in.Start.Format(time.RFC3339), "2026-02-17T10:00:00Z"; got != want {
+		t.Fatalf("start mismatch: got %s want %s", got, want)
+	}
+	in, err = parseQuickAddInput("3 marzo 14:30 Dentist @Home", now, time.UTC, "", time.Hour, false)
+	if err != nil {
+		t.Fatalf("parseQuickAddInput error: %v", err)
+	}
+	if in.Title != "Dentist" || in.Start.Format(time.RFC3339) != "2026-03-03T14:30:00Z" {
+		t.Fatalf("unexpected input: %+v", in)
+	}
+}
+
+func TestParseQuickAddInputTwelveHourClock(t *testing.T) {
+	now := time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)
+	for input, want := range map[string]string{
+		"tomorrow 3pm Standup @Work":      "2026-02-17T15:00:00Z",
+		"2026-02-20 10:30am Review @Work": "2026-02-20T10:30:00Z",
+		"3pm Coffee @Work":                "2026-02-16T15:00:00Z",
+	} {
+		in, err := parseQuickAddInput(input, now, time.UTC, "", time.Hour, false)
+		if err != nil {
+			t.Fatalf("parseQuickAddInput(%q) error: %v", input, err)
+		}
+		if got := in.Start.Format(time.RFC3339); got != want {
+			t.Fatalf("parseQuickAddInput(%q) start = %s, want %s", input, got, want)
+		}
+	}
+}
+
+func TestParseQuickAddInputSpelledDuration(t *testing.T) {
+	now := time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)
+	for input, want := range map[string]time.Duration{
+		"tomorrow 10:00 Workshop @Work 90 minutes": 90 * time.Minute,
+		"tomorrow 10:00 Offsite @Work 2d":          48 * time.Hour,
+		"tomorrow 10:00 Plan a day off @Work":      time.Hour,
+	} {
+		in, err := parseQuickAddInput(input, now, time.UTC, "", time.Hour, false)
+		if err != nil {
+			t.Fatalf("parseQuickAddInput(%q) error: %v", input, err)
+		}
+		if got := in.End.Sub(in.Start); got != want {
+			t.Fatalf("parseQuickAddInput(%q) duration = %s, want %s", input, got, want)
+		}
+	}
+}
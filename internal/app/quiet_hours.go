@@ -0,0 +1,50 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func parseQuietHours(specs []string) ([]focusPeriod, error) {
+	out := []focusPeriod{}
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		qp, err := parseFocusConfig(spec, focusConfig{Hours: spec})
+		if err != nil {
+			return nil, fmt.Errorf("quiet_hours: %w", err)
+		}
+		qp.Source = "quiet_hours"
+		out = append(out, qp)
+	}
+	return out, nil
+}
+
+func quietHoursFor(ro *globalOptions, ignore bool) []focusPeriod {
+	if ignore {
+		return nil
+	}
+	return ro.QuietPeriods
+}
+
+// freeSlots is buildSlots minus quiet windows; skipped counts the slots removed.
+func freeSlots(blocks []busyBlock, from, to time.Time, startHour, startMinute, endHour, endMinute int, duration, step time.Duration, quiet []focusPeriod, loc *time.Location) (slots []slotRow, skipped int) {
+	all := buildSlots(blocks, from, to, startHour, startMinute, endHour, endMinute, duration, step)
+	if len(quiet) == 0 {
+		return all, 0
+	}
+	slots = excludeFocusSlots(all, quiet, loc)
+	return slots, len(all) - len(slots)
+}
+
+func quietOverlap(start, end time.Time, quiet []focusPeriod, loc *time.Location) string {
+	for _, qp := range quiet {
+		if qp.overlaps(start, end, loc) {
+			return qp.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestParseQuietHours(t *testing.T) {
+	quiet, err := parseQuietHours([]string{"22:00-07:00", " 12:00-13:00 ", ""})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(quiet) != 2 || quiet[0].Start != 22*60 || quiet[0].End != 7*60 || quiet[1].Name != "12:00-13:00" {
+		t.Fatalf("unexpected windows: %+v", quiet)
+	}
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC) }
+	cases := []struct {
+		start, end time.Time
+		want       string
+	}{
+		{at(3, 6, 30), at(3, 7, 30), "22:00-07:00"},
+		{at(3, 21, 30), at(3, 22, 30), "22:00-07:00"},
+		{at(3, 12, 45), at(3, 13, 15), "12:00-13:00"},
+		{at(3, 7, 0), at(3, 12, 0), ""},
+	}
+	for _, c := range cases {
+		if got := quietOverlap(c.start, c.end, quiet, time.UTC); got != c.want {
+			t.Fatalf("quietOverlap(%s, %s) = %q, want %q", c.start, c.end, got, c.want)
+		}
+	}
+	if _, err := parseQuietHours([]string{"late"}); err == nil {
+		t.Fatalf("expected an error for an entry without a range")
+	}
+}
+
+func TestQuietHoursRespectedByDefault(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(cfg, []byte("quiet_hours = [\"22:00-07:00\", \"12:00-13:00\"]\n"), 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	t.Setenv("ACAL_CONFIG", cfg)
+	at := func(hour, min int) time.Time { return time.Date(2026, 3, 3, hour, min, 0, 0, time.UTC) }
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "review", CalendarID: "work", CalendarName: "Work", Title: "Review", Start: at(11, 0), End: at(12, 0)},
+		},
+	})
+
+	var slots struct {
+		Data []slotRow      `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	args := []string{"slots", "--tz", "UTC", "--from", "2026-03-03T00:00:00Z", "--to", "2026-03-03T15:00:00Z", "--between", "06:00-15:00", "--duration", "1h", "--step", "1h", "--json"}
+	if err := json.Unmarshal(runWithBackend(t, fb, args...), &slots); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	// 06:00 and 12:00 are quiet, 11:00 is busy.
+	if len(slots.Data) != 6 || slots.Data[0].Start.Hour() != 7 || slots.Data[5].Start.Hour() != 14 || slots.Meta["quiet_skipped"] != float64(2) {
+		t.Fatalf("expected quiet slots dropped, got %+v %v", slots.Data, slots.Meta)
+	}
+	slots.Meta = nil
+	if err := json.Unmarshal(runWithBackend(t, fb, append(args, "--ignore-quiet-hours")...), &slots); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(slots.Data) != 8 || slots.Meta["quiet_skipped"] != nil {
+		t.Fatalf("expected --ignore-quiet-hours to keep every free slot, got %+v %v", slots.Data, slots.Meta)
+	}
+
+	var page struct {
+		Data availabilityPage `json:"data"`
+	}
+	publish := []string{"availability", "publish", "--tz", "UTC", "--from", "2026-03-03T00:00:00Z", "--to", "2026-03-03T15:00:00Z", "--between", "06:00-15:00", "--duration", "1h", "--json"}
+	if err := json.Unmarshal(runWithBackend(t, fb, publish...), &page); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(page.Data.Slots) != 6 {
+		t.Fatalf("expected availability publish to drop quiet slots, got %+v", page.Data.Slots)
+	}
+
+	var rotation struct {
+		Data []rotationRow `json:"data"`
+	}
+	rotate := []string{"rotate", "--with", "Ana", "--calendar", "Work", "--tz", "UTC", "--from", "2026-03-03T12:00:00Z", "--every", "2h", "--between", "06:00-15:00", "--dry-run", "--json"}
+	if err := json.Unmarshal(runWithBackend(t, fb, rotate...), &rotation); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(rotation.Data) != 1 || rotation.Data[0].Start == nil || !rotation.Data[0].Start.Equal(at(13, 0)) {
+		t.Fatalf("expected rotate to skip lunch, got %+v", rotation.Data)
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, append(rotate, "--ignore-quiet-hours")...), &rotation); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rotation.Data[0].Start == nil || !rotation.Data[0].Start.Equal(at(12, 0)) {
+		t.Fatalf("expected --ignore-quiet-hours to allow lunch, got %+v", rotation.Data)
+	}
+
+	var moved struct {
+		Data []dryRunPreview `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "move", "review", "--to-next-free", "--tz", "UTC", "--dry-run", "--json"), &moved); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !moved.Data[0].After.Start.Equal(at(13, 0)) {
+		t.Fatalf("expected --to-next-free to skip lunch, got %v", moved.Data[0].After.Start)
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "move", "review", "--to-next-free", "--ignore-quiet-hours", "--tz", "UTC", "--dry-run", "--json"), &moved); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !moved.Data[0].After.Start.Equal(at(11, 15)) {
+		t.Fatalf("expected --ignore-quiet-hours to allow lunch, got %v", moved.Data[0].After.Start)
+	}
+
+	run := func(args ...string) (int, string) {
+		cmd := NewRootCommand()
+		var stderr bytes.Buffer
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(&stderr)
+		cmd.SetArgs(append(args, "--tz", "UTC", "--json"))
+		return ExitCode(cmd.Execute()), stderr.String()
+	}
+	code, stderr := run("events", "add", "--calendar", "Work", "--title", "Late call", "--start", "2026-03-03T21:30:00Z", "--duration", "1h", "--no-conflict", "--dry-run")
+	var env struct {
+		Error contract.ErrorBody `json:"error"`
+		Meta  map[string]any     `json:"meta"`
+	}
+	if err := json.Unmarshal([]byte(stderr), &env); err != nil {
+		t.Fatalf("decode error envelope: %v (%q)", err, stderr)
+	}
+	if code != 5 || env.Error.Code != contract.ErrConflict || env.Meta["quiet_hours"] != "22:00-07:00" {
+		t.Fatalf("expected a quiet hours conflict, got exit %d %+v", code, env)
+	}
+	if code, _ := run("events", "add", "--calendar", "Work", "--title", "Late call", "--start", "2026-03-03T21:30:00Z", "--duration", "1h", "--no-conflict", "--ignore-quiet-hours", "--dry-run"); code != 0 {
+		t.Fatalf("--ignore-quiet-hours should override, got exit %d", code)
+	}
+	if code, _ := run("events", "add", "--calendar", "Work", "--title", "Late call", "--start", "2026-03-03T21:30:00Z", "--duration", "1h", "--dry-run"); code != 0 {
+		t.Fatalf("quiet hours only apply with --no-conflict, got exit %d", code)
+	}
+	if code, _ := run("quick-add", "2026-03-03 12:30 Catch-up @Work 15m", "--no-conflict", "--dry-run"); code != 5 {
+		t.Fatalf("quick-add into lunch should conflict, got exit %d", code)
+	}
+	t.Setenv("ACAL_QUIET_HOURS", "25:00-07:00")
+	if code, _ := run("slots"); code != 2 {
+		t.Fatalf("expected exit 2 for invalid quiet_hours, got %d", code)
+	}
+}
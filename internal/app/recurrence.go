@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/agis/acal/internal/timeparse"
 )
 
 type repeatSpec struct {
@@ -25,6 +27,8 @@ func parseRepeatSpec(v string, anchor time.Time) (repeatSpec, error) {
 		return repeatSpec{}, fmt.Errorf("invalid repeat rule: too many ':' segments")
 	}
 	count := 0
+	var span time.Duration
+	var spanErr error
 	if strings.Contains(s, "*") {
 		parts := strings.SplitN(s, "*", 2)
 		if strings.TrimSpace(parts[0]) == "" {
@@ -32,12 +36,14 @@ func parseRepeatSpec(v string, anchor time.Time) (repeatSpec, error) {
 		}
 		s = strings.TrimSpace(parts[0])
 		n, err := strconv.Atoi(strings.TrimSpace(parts[1]))
-		if err != nil || n <= 0 {
+		if err != nil {
+			// A span like daily*2w is resolved to a count once the frequency is known.
+			if span, spanErr = timeparse.ParseDuration(parts[1]); spanErr != nil || span <= 0 {
+				return repeatSpec{}, fmt.Errorf("invalid repeat count")
+			}
+		} else if n <= 0 {
 			return repeatSpec{}, fmt.Errorf("invalid repeat count")
 		}
-		if n > 366 {
-			return repeatSpec{}, fmt.Errorf("repeat count too large (max 366)")
-		}
 		count = n
 	}
 	sp := repeatSpec{Count: count}
@@ -60,6 +66,22 @@ func parseRepeatSpec(v string, anchor time.Time) (repeatSpec, error) {
 	if sp.Frequency == "weekly" && len(sp.Weekdays) == 0 {
 		sp.Weekdays = []time.Weekday{anchor.Weekday()}
 	}
+	if span > 0 {
+		switch sp.Frequency {
+		case "daily":
+			sp.Count = int(span / (24 * time.Hour))
+		case "weekly":
+			sp.Count = int(span/(7*24*time.Hour)) * len(sp.Weekdays)
+		default:
+			return repeatSpec{}, fmt.Errorf("repeat spans need daily or weekly; use a count like monthly*3")
+		}
+		if sp.Count == 0 {
+			return repeatSpec{}, fmt.Errorf("repeat span shorter than one %s", map[string]string{"daily": "day", "weekly": "week"}[sp.Frequency])
+		}
+	}
+	if sp.Count > 366 {
+		return repeatSpec{}, fmt.Errorf("repeat count too large (max 366)")
+	}
 	switch sp.Frequency {
 	case "daily", "weekly", "monthly", "yearly":
 		if sp.Count == 0 {
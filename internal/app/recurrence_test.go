@@ -25,6 +25,16 @@ func TestParseRepeatSpecWeekly(t *testing.T) {
 	}
 }
 
+func TestParseRepeatSpecSpanCount(t *testing.T) {
+	anchor := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
+	for in, want := range map[string]int{"daily*2w": 14, "weekly:mon,wed*3w": 6, "daily*10 days": 10} {
+		spec, err := parseRepeatSpec(in, anchor)
+		if err != nil || spec.Count != want {
+			t.Fatalf("parseRepeatSpec(%q) = %+v, %v; want count %d", in, spec, err, want)
+		}
+	}
+}
+
 func TestExpandRepeatDaily(t *testing.T) {
 	start := time.Date(2026, 2, 20, 10, 0, 0, 0, time.UTC)
 	spec := repeatSpec{Frequency: "daily", Count: 3}
@@ -40,7 +50,7 @@ func TestExpandRepeatDaily(t *testing.T) {
 func TestEventsAddRepeatCreatesSeries(t *testing.T) {
 	fb := &scopeCaptureBackend{}
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return fb, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	cmd := NewRootCommand()
@@ -75,6 +85,11 @@ func TestParseRepeatSpecValidationMatrix(t *testing.T) {
 		{in: "monthly:mon*2", wantErr: true},
 		{in: "hourly*2", wantErr: true},
 		{in: "*2", wantErr: true},
+		{in: "daily*2w", wantErr: false},
+		{in: "weekly*3 weeks", wantErr: false},
+		{in: "daily*12h", wantErr: true},
+		{in: "monthly*8w", wantErr: true},
+		{in: "daily*2 years", wantErr: true},
 	}
 	for _, tc := range tests {
 		_, err := parseRepeatSpec(tc.in, anchor)
@@ -5,12 +5,14 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/agis/acal/internal/timeparse"
 )
 
 var reminderLineRE = regexp.MustCompile(`(?m)^acal:reminder=([+-]?[0-9]+[smhd])\s*$`)
 
 func normalizeReminderOffset(v string) (time.Duration, error) {
-	d, err := time.ParseDuration(strings.TrimSpace(v))
+	d, err := timeparse.ParseDuration(v)
 	if err != nil {
 		return 0, err
 	}
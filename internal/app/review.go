@@ -0,0 +1,82 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+var openReviewTerminal = func() (*os.File, error) {
+	return os.OpenFile("/dev/tty", os.O_RDWR, 0)
+}
+
+var errReviewNoTerminal = errors.New("--review needs a terminal to run the editor")
+
+const reviewHeader = `# Review the operations below, one JSON object per line after its input
+# line number. Delete a line to skip it, or edit the JSON to change the
+# operation; keep the number so results still point at the input line.
+# Lines starting with # are ignored; remove every line to run nothing.
+`
+
+type reviewLine struct {
+	Line int
+	Op   string
+}
+
+func reviewEditor() string {
+	return firstNonEmpty(strings.TrimSpace(os.Getenv("VISUAL")), strings.TrimSpace(os.Getenv("EDITOR")), "vi")
+}
+
+func reviewInEditor(ops []reviewLine, stderr io.Writer) ([]reviewLine, error) {
+	tty, err := openReviewTerminal()
+	if err != nil {
+		return nil, errReviewNoTerminal
+	}
+	defer tty.Close()
+	f, err := os.CreateTemp("", "acal-review-*.jsonl")
+	if err != nil {
+		return nil, err
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	var b strings.Builder
+	b.WriteString(reviewHeader)
+	for _, op := range ops {
+		fmt.Fprintf(&b, "%d %s\n", op.Line, op.Op)
+	}
+	_, err = f.WriteString(b.String())
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	editor := reviewEditor()
+	cmd := exec.Command("sh", "-c", editor+` "$@"`, editor, path)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = tty, tty, stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	kept := []reviewLine{}
+	for _, line := range strings.Split(strings.ReplaceAll(string(raw), "\r\n", "\n"), "\n") {
+		s := strings.TrimSpace(line)
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+		num, op, _ := strings.Cut(s, " ")
+		n, err := strconv.Atoi(num)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("reviewed line %q does not start with its input line number", s)
+		}
+		kept = append(kept, reviewLine{Line: n, Op: strings.TrimSpace(op)})
+	}
+	return kept, nil
+}
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/agis/acal/internal/backend"
@@ -21,25 +24,72 @@ import (
 var backendFactory = selectBackend
 
 type globalOptions struct {
-	JSON           bool
-	JSONL          bool
-	Plain          bool
-	Fields         string
-	Quiet          bool
-	Verbose        bool
-	NoColor        bool
-	NoInput        bool
-	FailOnDegraded bool
-	Profile        string
-	Config         string
-	Backend        string
-	TZ             string
-	Timeout        time.Duration
-	SchemaVersion  string
-}
+	JSON               bool
+	JSONL              bool
+	Plain              bool
+	Fields             string
+	Header             bool
+	NoHeader           bool
+	Quiet              bool
+	Verbose            bool
+	NoColor            bool
+	NoInput            bool
+	FailOnDegraded     bool
+	Profile            string
+	Config             string
+	Backend            string
+	TZ                 string
+	Timeout            time.Duration
+	Retries            int
+	RetryBackoff       time.Duration
+	MaxWritesPerSec    float64
+	SharedReads        bool
+	UseDaemon          bool
+	SchemaVersion      string
+	CalDAVURL          string
+	CalDAVUser         string
+	MockFile           string
+	HolidaysCalendar   string
+	HolidaysFile       string
+	NotesTemplate      string
+	WritableCalendars  []string
+	ProtectedCalendars []string
+	Rooms              []string
+	QuietHours         []string
+	QuietPeriods       []focusPeriod
+	SoftDelete         bool
+	HidePrivate        bool
+	Locale             string
+	TimeFormat         string
+	IDFormat           string
+	Theme              string
+	EchoRequest        bool
+	Now                string
+	ToInclusive        bool
+	ToExclusive        bool
+	Range              string
+	WeekStart          string
+	Backends           map[string]backendConfig
+	CalendarDefaults   map[string]calendarDefaults
+	People             map[string]person
+	Focus              map[string]focusConfig
+	FocusPeriods       []focusPeriod
+	Lint               lintConfig
+	Annotations        annotationConfig
+}
+
+// interruptContext is canceled on the first SIGINT or SIGTERM.
+var interruptContext = context.Background()
 
 func Execute() int {
-	cmd := NewRootCommand()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+	interruptContext = ctx
+	cmd, opts := newRootCommand()
+	if code, ok := runPlugin(cmd, opts, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); ok {
+		return code
+	}
 	err := cmd.Execute()
 	if err != nil {
 		renderTopLevelError(cmd, err)
@@ -48,11 +98,18 @@ func Execute() int {
 }
 
 func NewRootCommand() *cobra.Command {
+	root, _ := newRootCommand()
+	return root
+}
+
+func newRootCommand() (*cobra.Command, *globalOptions) {
 	opts := &globalOptions{
-		Profile:       "default",
-		Backend:       "osascript",
-		Timeout:       15 * time.Second,
-		SchemaVersion: contract.SchemaVersion,
+		Profile:         "default",
+		Backend:         "osascript",
+		Timeout:         15 * time.Second,
+		RetryBackoff:    200 * time.Millisecond,
+		MaxWritesPerSec: backend.DefaultMaxWritesPerSecond,
+		SchemaVersion:   contract.SchemaVersion,
 	}
 
 	root := &cobra.Command{
@@ -68,37 +125,76 @@ func NewRootCommand() *cobra.Command {
 	root.PersistentFlags().BoolVar(&opts.JSONL, "jsonl", false, "Output newline-delimited JSON")
 	root.PersistentFlags().BoolVar(&opts.Plain, "plain", false, "Output stable plain text")
 	root.PersistentFlags().StringVar(&opts.Fields, "fields", "", "Projected fields, comma-separated")
+	root.PersistentFlags().BoolVar(&opts.Header, "header", false, "Print a column header line in plain output")
+	root.PersistentFlags().BoolVar(&opts.NoHeader, "no-header", false, "Omit the column header line in plain output (default)")
 	root.PersistentFlags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Reduce success output")
 	root.PersistentFlags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Verbose diagnostics")
 	root.PersistentFlags().BoolVar(&opts.NoColor, "no-color", false, "Disable color output")
+	root.PersistentFlags().StringVar(&opts.Theme, "theme", "", "Color theme: default|high-contrast|light|mono")
+	root.PersistentFlags().BoolVar(&opts.HidePrivate, "hide-private", false, "Mask titles and details of private events")
+	root.PersistentFlags().StringVar(&opts.Locale, "locale", "", "Locale for date words and plain-mode dates (e.g. de, es, fr)")
+	root.PersistentFlags().StringVar(&opts.TimeFormat, "time-format", "", "Clock in plain-mode dates: 12h|24h")
+	root.PersistentFlags().StringVar(&opts.IDFormat, "id-format", "", "Event IDs in output: full|uid|short")
+	root.PersistentFlags().BoolVar(&opts.EchoRequest, "echo-request", false, "Include the resolved range, calendars, and predicates under \"request\" in JSON output")
 	root.PersistentFlags().BoolVar(&opts.NoInput, "no-input", false, "Disable prompts")
 	root.PersistentFlags().BoolVar(&opts.FailOnDegraded, "fail-on-degraded", false, "Fail if backend health is degraded")
 	root.PersistentFlags().StringVar(&opts.Profile, "profile", "default", "Config profile")
 	root.PersistentFlags().StringVar(&opts.Config, "config", "", "Config file path")
-	root.PersistentFlags().StringVar(&opts.Backend, "backend", "osascript", "Backend: osascript|eventkit")
+	root.PersistentFlags().StringVar(&opts.Backend, "backend", "osascript", "Backend: osascript|caldav|mock|eventkit|all|<configured name>")
 	root.PersistentFlags().StringVar(&opts.TZ, "tz", "", "IANA timezone for output")
+	root.PersistentFlags().StringVar(&opts.Now, "now", "", "Pin the reference time for relative inputs (RFC3339)")
+	root.PersistentFlags().BoolVar(&opts.ToInclusive, "to-inclusive", false, "Cover the whole --to day, even when --to has a clock time")
+	root.PersistentFlags().BoolVar(&opts.ToExclusive, "to-exclusive", false, "End ranges just before --to, even when --to is a bare date")
+	root.PersistentFlags().StringVar(&opts.Range, "range", "", "Set --from and --to to one range keyword (this-week, last-month, q3, ytd, ...) or day")
 	root.PersistentFlags().DurationVar(&opts.Timeout, "timeout", 15*time.Second, "Backend call timeout (e.g. 10s, 1m, 0 to disable)")
+	root.PersistentFlags().IntVar(&opts.Retries, "retries", 0, "Retries for transient backend failures (AppleScript and SQLite)")
+	root.PersistentFlags().DurationVar(&opts.RetryBackoff, "retry-backoff", 200*time.Millisecond, "Initial retry backoff, doubled per attempt")
+	root.PersistentFlags().Float64Var(&opts.MaxWritesPerSec, "max-writes-per-sec", backend.DefaultMaxWritesPerSecond, "Pace osascript writes to at most N per second (0 disables pacing)")
 	root.PersistentFlags().StringVar(&opts.SchemaVersion, "schema-version", contract.SchemaVersion, "Output schema version")
+	root.PersistentFlags().StringVar(&opts.CalDAVURL, "caldav-url", "", "CalDAV calendar home URL (caldav backend)")
+	root.PersistentFlags().StringVar(&opts.CalDAVUser, "caldav-user", "", "CalDAV username (password via ACAL_CALDAV_PASSWORD)")
+	root.PersistentFlags().StringVar(&opts.MockFile, "mock-file", "", "JSON fixture file for the mock backend")
 
 	root.AddCommand(newSetupCmd(opts))
 	root.AddCommand(newStatusCmd(opts))
 	root.AddCommand(newVersionCmd())
 	root.AddCommand(newDoctorCmd(opts))
+	root.AddCommand(newDaemonCmd(opts))
 	root.AddCommand(newCalendarsCmd(opts))
 	root.AddCommand(newEventsCmd(opts))
 	root.AddCommand(newAgendaCmd(opts))
+	root.AddCommand(newNextCmd(opts))
+	root.AddCommand(newUpcomingCmd(opts))
+	root.AddCommand(newDigestCmd(opts))
+	root.AddCommand(newLintCmd(opts))
 	root.AddCommand(newFreebusyCmd(opts))
 	root.AddCommand(newSlotsCmd(opts))
+	root.AddCommand(newAvailabilityCmd(opts))
+	root.AddCommand(newStatsCmd(opts))
+	root.AddCommand(newRoomsCmd(opts))
+	root.AddCommand(newRotateCmd(opts))
+	root.AddCommand(newCompareCmd(opts))
 	root.AddCommand(newTodayCmd(opts))
 	root.AddCommand(newWeekCmd(opts))
 	root.AddCommand(newMonthCmd(opts))
 	root.AddCommand(newViewCmd(opts))
 	root.AddCommand(newHistoryCmd(opts))
 	root.AddCommand(newQueriesCmd(opts))
+	root.AddCommand(newStateCmd(opts))
+	root.AddCommand(newBackupCmd(opts))
+	root.AddCommand(newRestoreCmd(opts))
 	root.AddCommand(newQuickAddCmd(opts))
+	root.AddCommand(newHandleCmd(opts))
+	root.AddCommand(newOOOCmd(opts))
+	root.AddCommand(newHolidaysCmd(opts))
+	root.AddCommand(newSchemaCmd(opts))
+	root.AddCommand(newDescribeCmd(opts))
+	root.AddCommand(newSelftestCmd(opts))
+	root.AddCommand(newTimeCmd(opts))
+	root.AddCommand(newErrorsCmd(opts))
 	root.AddCommand(newCompletionCmd(root))
 
-	return root
+	return root, opts
 }
 
 func buildContext(cmd *cobra.Command, opts *globalOptions, command string) (output.Printer, backend.Backend, *globalOptions, error) {
@@ -118,20 +214,62 @@ func buildContext(cmd *cobra.Command, opts *globalOptions, command string) (outp
 		mode = output.ModePlain
 	}
 
+	if err := timeparse.SetLocale(resolved.Locale); err != nil {
+		return output.Printer{}, nil, nil, Wrap(2, err)
+	}
+	if err := setPinnedNow(resolved.Now); err != nil {
+		return output.Printer{}, nil, nil, Wrap(2, err)
+	}
+	if err := setWeekStart(resolved.WeekStart, resolved.Locale); err != nil {
+		return output.Printer{}, nil, nil, Wrap(2, err)
+	}
+	if err := setToBound(resolved.ToInclusive, resolved.ToExclusive); err != nil {
+		return output.Printer{}, nil, nil, Wrap(2, err)
+	}
+	if err := applyRangeFlag(cmd, resolved.Range, resolveLocation(resolved.TZ)); err != nil {
+		return output.Printer{}, nil, nil, Wrap(2, err)
+	}
+	clock, err := timeparse.ClockLayout(resolved.TimeFormat)
+	if err != nil {
+		return output.Printer{}, nil, nil, Wrap(2, err)
+	}
+	theme, err := output.LookupTheme(resolved.Theme)
+	if err != nil {
+		return output.Printer{}, nil, nil, Wrap(2, err)
+	}
+	eventID, err := eventIDFormatter(resolved.IDFormat)
+	if err != nil {
+		return output.Printer{}, nil, nil, Wrap(2, err)
+	}
+	if resolved.FocusPeriods, err = loadFocusPeriods(resolved.Focus); err != nil {
+		return output.Printer{}, nil, nil, Wrap(2, err)
+	}
+	if resolved.QuietPeriods, err = parseQuietHours(resolved.QuietHours); err != nil {
+		return output.Printer{}, nil, nil, Wrap(2, err)
+	}
+
 	printer := output.Printer{
 		Mode:          mode,
 		Command:       command,
 		Fields:        splitCSV(resolved.Fields),
 		Quiet:         resolved.Quiet,
+		Header:        resolved.Header && !resolved.NoHeader,
 		NoColor:       resolved.NoColor,
+		Theme:         theme,
 		SchemaVersion: resolved.SchemaVersion,
+		HidePrivate:   resolved.HidePrivate,
+		EventID:       eventID,
 		Out:           cmd.OutOrStdout(),
 		Err:           cmd.ErrOrStderr(),
 	}
+	if resolved.Locale != "" || resolved.TimeFormat != "" {
+		l, _ := timeparse.LookupLocale(firstNonEmpty(resolved.Locale, "en"))
+		printer.FormatTime = func(t time.Time) string { return l.FormatTime(t, clock) }
+	}
 
-	be, err := backendFactory(resolved.Backend)
+	be, err := backendFactory(resolved)
 	if err != nil {
-		_ = printer.Error(contract.ErrInvalidUsage, err.Error(), "Use --backend osascript")
+		_ = printer.Error(contract.ErrInvalidUsage, err.Error(), "Use --backend osascript|caldav|mock")
 		return printer, nil, nil, WrapPrinted(2, err)
 	}
 	if resolved.FailOnDegraded && !isHealthCommand(command) {
@@ -147,14 +285,33 @@ func buildContext(cmd *cobra.Command, opts *globalOptions, command string) (outp
 		}
 	}
 	if resolved.Verbose {
-		_, _ = fmt.Fprintf(printer.Err, "acal: command=%s backend=%s mode=%s tz=%s profile=%s timeout=%s\n", command, resolved.Backend, mode, resolved.TZ, resolved.Profile, resolved.Timeout)
+		_, _ = fmt.Fprintf(printer.Err, "acal: command=%s backend=%s mode=%s tz=%s profile=%s timeout=%s retries=%d\n", command, resolved.Backend, mode, resolved.TZ, resolved.Profile, resolved.Timeout, resolved.Retries)
 	}
 	return printer, be, resolved, nil
 }
 
 func commandContext(ro *globalOptions) (context.Context, context.CancelFunc) {
 	timing := &timingRecorder{calls: map[string]time.Duration{}}
-	base := context.WithValue(context.Background(), timingContextKey{}, timing)
+	base := context.WithValue(interruptContext, timingContextKey{}, timing)
+	base = backend.WithAttemptRecorder(base, backend.NewAttemptRecorder())
+	base = backend.WithWarningRecorder(base, backend.NewWarningRecorder())
+	if ro != nil && ro.EchoRequest {
+		base = context.WithValue(base, requestEchoContextKey{}, &requestRecorder{})
+	}
+	if ro != nil {
+		base = backend.WithRetryPolicy(base, backend.RetryPolicy{Retries: ro.Retries, Backoff: ro.RetryBackoff})
+		base = backend.WithMaxWritesPerSecond(base, ro.MaxWritesPerSec)
+		if dir := stateDir(); ro.SharedReads && dir != "" {
+			base = backend.WithSharedReads(base, filepath.Join(dir, "reads"))
+		}
+		if socket := daemonSocketPath(); ro.UseDaemon && socket != "" {
+			base = context.WithValue(base, daemonClientContextKey{}, daemonClient{socket: socket, key: daemonBackendKey(ro)})
+		}
+		base = context.WithValue(base, writePolicyContextKey{}, writePolicy{Writable: ro.WritableCalendars, Protected: ro.ProtectedCalendars})
+		if len(ro.FocusPeriods) > 0 {
+			base = context.WithValue(base, focusContextKey{}, focusContext{periods: ro.FocusPeriods, loc: resolveLocation(ro.TZ)})
+		}
+	}
 	if ro == nil || ro.Timeout <= 0 {
 		return context.WithCancel(base)
 	}
@@ -237,13 +394,69 @@ func listCalendarsWithTimeout(ctx context.Context, be backend.Backend) ([]contra
 }
 
 func listEventsWithTimeout(ctx context.Context, be backend.Backend, f backend.EventFilter) ([]contract.Event, error) {
+	recordRequestFilter(ctx, f)
+	if items, ok := daemonListEvents(ctx, be, f); ok {
+		return withEventsFocus(ctx, withEventsDerived(items)), nil
+	}
 	start := time.Now()
 	v, err := withTimeout(ctx, func() ([]contract.Event, error) {
 		return be.ListEvents(ctx, f)
 	})
 	err = annotateBackendError(ctx, "backend.list_events", err)
 	recordTiming(ctx, "backend.list_events", time.Since(start))
-	return v, err
+	return withEventsFocus(ctx, withEventsDerived(v)), err
+}
+
+func streamEventsWithTimeout(ctx context.Context, be backend.Backend, f backend.EventFilter, emit func(contract.Event) error) error {
+	streamer, ok := be.(backend.EventStreamer)
+	if !ok {
+		items, err := listEventsWithTimeout(ctx, be, f)
+		if err != nil {
+			return err
+		}
+		for _, e := range items {
+			if err := emit(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	recordRequestFilter(ctx, f)
+	if items, ok := daemonListEvents(ctx, be, f); ok {
+		for _, e := range withEventsFocus(ctx, withEventsDerived(items)) {
+			if err := emit(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	start := time.Now()
+	aliases := loadAliasIndex()
+	focus := focusFromContext(ctx)
+	_, err := withTimeout(ctx, func() (struct{}, error) {
+		return struct{}{}, streamer.StreamEvents(ctx, f, func(e contract.Event) error {
+			withETag(withMeeting(withTags(&e)))
+			e.Focus = focus.at(e)
+			if e.ID != "" {
+				e.Alias = aliases.assign(e.ID)
+			}
+			return emit(e)
+		})
+	})
+	if err == nil {
+		_ = aliases.save()
+	}
+	err = annotateBackendError(ctx, "backend.stream_events", err)
+	recordTiming(ctx, "backend.stream_events", time.Since(start))
+	return err
+}
+
+func withDerived(e *contract.Event) *contract.Event {
+	return withAlias(withETag(withMeeting(withTags(e))))
+}
+
+func withEventsDerived(items []contract.Event) []contract.Event {
+	return withEventsAlias(withEventsETag(withEventsMeeting(withEventsTags(items))))
 }
 
 func getEventByIDWithTimeout(ctx context.Context, be backend.Backend, id string) (*contract.Event, error) {
@@ -253,30 +466,63 @@ func getEventByIDWithTimeout(ctx context.Context, be backend.Backend, id string)
 	})
 	err = annotateBackendError(ctx, "backend.get_event_by_id", err)
 	recordTiming(ctx, "backend.get_event_by_id", time.Since(start))
+	v = withDerived(v)
+	if v != nil {
+		v.Focus = focusFromContext(ctx).at(*v)
+	}
 	return v, err
 }
 
 func addEventWithTimeout(ctx context.Context, be backend.Backend, in backend.EventCreateInput) (*contract.Event, error) {
+	if err := checkCalendarWritePolicy(ctx, be, in.Calendar); err != nil {
+		return nil, err
+	}
 	start := time.Now()
 	v, err := withTimeout(ctx, func() (*contract.Event, error) {
 		return be.AddEvent(ctx, in)
 	})
 	err = annotateBackendError(ctx, "backend.add_event", err)
 	recordTiming(ctx, "backend.add_event", time.Since(start))
-	return v, err
+	return withDerived(v), err
 }
 
 func updateEventWithTimeout(ctx context.Context, be backend.Backend, id string, in backend.EventUpdateInput) (*contract.Event, error) {
+	if err := checkEventWritePolicy(ctx, be, id); err != nil {
+		return nil, err
+	}
 	start := time.Now()
 	v, err := withTimeout(ctx, func() (*contract.Event, error) {
 		return be.UpdateEvent(ctx, id, in)
 	})
 	err = annotateBackendError(ctx, "backend.update_event", err)
 	recordTiming(ctx, "backend.update_event", time.Since(start))
+	return withDerived(v), err
+}
+
+func respondToEventWithTimeout(ctx context.Context, be backend.Backend, id string, in backend.RSVPInput) (*backend.RSVPResult, error) {
+	responder, ok := be.(backend.Responder)
+	if !ok {
+		return nil, backend.ErrRSVPUnsupported
+	}
+	if err := checkEventWritePolicy(ctx, be, id); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	v, err := withTimeout(ctx, func() (*backend.RSVPResult, error) {
+		return responder.RespondToEvent(ctx, id, in)
+	})
+	err = annotateBackendError(ctx, "backend.respond_to_event", err)
+	recordTiming(ctx, "backend.respond_to_event", time.Since(start))
+	if v != nil {
+		v.Event = *withDerived(&v.Event)
+	}
 	return v, err
 }
 
 func deleteEventWithTimeout(ctx context.Context, be backend.Backend, id string, scope backend.RecurrenceScope) error {
+	if err := checkEventWritePolicy(ctx, be, id); err != nil {
+		return err
+	}
 	start := time.Now()
 	_, err := withTimeout(ctx, func() (struct{}, error) {
 		return struct{}{}, be.DeleteEvent(ctx, id, scope)
@@ -296,6 +542,48 @@ func reminderOffsetWithTimeout(ctx context.Context, be backend.Backend, id strin
 	return v, err
 }
 
+func listBirthdaysWithTimeout(ctx context.Context, be backend.Backend) ([]backend.Birthday, error) {
+	lister, ok := be.(backend.BirthdayLister)
+	if !ok {
+		return nil, errBirthdaysUnsupported
+	}
+	start := time.Now()
+	v, err := withTimeout(ctx, func() ([]backend.Birthday, error) {
+		return lister.ListBirthdays(ctx)
+	})
+	err = annotateBackendError(ctx, "backend.list_birthdays", err)
+	recordTiming(ctx, "backend.list_birthdays", time.Since(start))
+	return v, err
+}
+
+func inspectSeriesWithTimeout(ctx context.Context, be backend.Backend, uid string, from, to time.Time) (*backend.Series, error) {
+	inspector, ok := be.(backend.SeriesInspector)
+	if !ok {
+		return nil, backend.ErrSeriesUnsupported
+	}
+	start := time.Now()
+	v, err := withTimeout(ctx, func() (*backend.Series, error) {
+		return inspector.InspectSeries(ctx, uid, from, to)
+	})
+	err = annotateBackendError(ctx, "backend.inspect_series", err)
+	recordTiming(ctx, "backend.inspect_series", time.Since(start))
+	return v, err
+}
+
+func listDeletedEventsWithTimeout(ctx context.Context, be backend.Backend, since time.Time) ([]backend.DeletedEvent, error) {
+	lister, ok := be.(backend.DeletedEventLister)
+	if !ok {
+		return nil, backend.ErrDeletedUnsupported
+	}
+	start := time.Now()
+	v, err := withTimeout(ctx, func() ([]backend.DeletedEvent, error) {
+		return lister.ListDeletedEvents(ctx, since)
+	})
+	err = annotateBackendError(ctx, "backend.list_deleted_events", err)
+	recordTiming(ctx, "backend.list_deleted_events", time.Since(start))
+	return v, err
+}
+
 func recordTiming(ctx context.Context, name string, d time.Duration) {
 	rec, _ := ctx.Value(timingContextKey{}).(*timingRecorder)
 	if rec == nil {
@@ -304,7 +592,7 @@ func recordTiming(ctx context.Context, name string, d time.Duration) {
 	rec.add(name, d)
 }
 
-func successWithMeta(ctx context.Context, p output.Printer, ro *globalOptions, data any, meta map[string]any, warnings []string) error {
+func successWithMeta(ctx context.Context, p output.Printer, ro *globalOptions, data any, meta map[string]any, warnings []contract.Warning) error {
 	if ro != nil && ro.Verbose {
 		timings := backendTimings(ctx)
 		if len(timings) > 0 {
@@ -314,8 +602,35 @@ func successWithMeta(ctx context.Context, p output.Printer, ro *globalOptions, d
 			meta["timings"] = timings
 			_, _ = fmt.Fprintf(p.Err, "acal: timings=%v\n", timings)
 		}
+		if attempts := backend.AttemptsFromContext(ctx); len(attempts) > 0 {
+			if meta == nil {
+				meta = map[string]any{}
+			}
+			meta["attempts"] = attempts
+			_, _ = fmt.Fprintf(p.Err, "acal: attempts=%v\n", attempts)
+		}
+	}
+	if ro != nil && ro.EchoRequest {
+		if req := requestFromContext(ctx, ro.TZ); req != nil {
+			p.Request = req
+		}
+	}
+	return p.Success(data, meta, commandWarnings(ctx, warnings))
+}
+
+func commandWarnings(ctx context.Context, warnings []contract.Warning) []contract.Warning {
+	return append(warnings, backend.WarningsFromContext(ctx)...)
+}
+
+func warningsWithCode(code contract.WarningCode, messages []string) []contract.Warning {
+	if len(messages) == 0 {
+		return nil
+	}
+	out := make([]contract.Warning, 0, len(messages))
+	for _, m := range messages {
+		out = append(out, contract.Warning{Code: code, Message: m})
 	}
-	return p.Success(data, meta, warnings)
+	return out
 }
 
 func isHealthCommand(command string) bool {
@@ -335,7 +650,7 @@ func renderTopLevelError(cmd *cobra.Command, err error) {
 			SchemaVersion: contract.SchemaVersion,
 			Err:           cmd.ErrOrStderr(),
 		}
-		_ = printer.Error(errorCodeForExit(ExitCode(err)), err.Error(), "")
+		_ = printer.ErrorWithDetails(errorCodeForExit(ExitCode(err)), err.Error(), "", errorDetails(err), nil)
 		return
 	}
 	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "error: %s\n", err.Error())
@@ -356,28 +671,41 @@ func wantsStructuredErrorOutput(args []string) bool {
 }
 
 func errorCodeForExit(code int) contract.ErrorCode {
-	switch code {
-	case 2:
-		return contract.ErrInvalidUsage
-	case 4:
-		return contract.ErrNotFound
-	case 6:
-		return contract.ErrBackendUnavailable
-	case 7:
-		return contract.ErrConcurrency
-	default:
-		return contract.ErrGeneric
+	for _, info := range contract.ErrorCodes {
+		if info.ExitCode == code {
+			return info.Code
+		}
 	}
+	return contract.ErrGeneric
 }
 
-func selectBackend(name string) (backend.Backend, error) {
-	switch strings.ToLower(strings.TrimSpace(name)) {
+func selectBackend(opts *globalOptions) (backend.Backend, error) {
+	if cfg, ok := opts.Backends[strings.TrimSpace(opts.Backend)]; ok {
+		return backendFromConfig(strings.TrimSpace(opts.Backend), cfg)
+	}
+	switch strings.ToLower(strings.TrimSpace(opts.Backend)) {
+	case "all":
+		return newAggregateBackend(opts.Backends)
 	case "", "osascript":
 		return backend.NewOsaScriptBackend(), nil
+	case "caldav":
+		if strings.TrimSpace(opts.CalDAVURL) == "" {
+			return nil, fmt.Errorf("caldav backend requires --caldav-url or ACAL_CALDAV_URL")
+		}
+		return backend.NewCalDAVBackend(backend.CalDAVConfig{
+			URL:      opts.CalDAVURL,
+			User:     opts.CalDAVUser,
+			Password: env("ACAL_CALDAV_PASSWORD"),
+		}), nil
+	case "mock":
+		if strings.TrimSpace(opts.MockFile) == "" {
+			return nil, fmt.Errorf("mock backend requires --mock-file or ACAL_MOCK_FILE")
+		}
+		return backend.LoadMockBackend(opts.MockFile)
 	case "eventkit":
 		return nil, fmt.Errorf("eventkit backend not implemented yet")
 	default:
-		return nil, fmt.Errorf("unknown backend: %s", name)
+		return nil, fmt.Errorf("unknown backend: %s", opts.Backend)
 	}
 }
 
@@ -386,20 +714,9 @@ func buildEventFilter(fromS, toS string, calendars []string, limit int) (backend
 }
 
 func buildEventFilterWithTZ(fromS, toS string, calendars []string, limit int, tz string) (backend.EventFilter, error) {
-	loc := resolveLocation(tz)
-	from, err := timeparse.ParseDateTime(fromS, time.Now(), loc)
+	from, to, err := resolveRange(fromS, toS, resolveLocation(tz))
 	if err != nil {
-		return backend.EventFilter{}, fmt.Errorf("invalid --from: %w", err)
-	}
-	to, err := timeparse.ParseDateTime(toS, time.Now(), loc)
-	if err != nil {
-		return backend.EventFilter{}, fmt.Errorf("invalid --to: %w", err)
-	}
-	if to.Before(from) {
-		return backend.EventFilter{}, fmt.Errorf("--to must not be earlier than --from")
-	}
-	if to.Hour() == 0 && to.Minute() == 0 && to.Second() == 0 {
-		to = to.Add(24*time.Hour - time.Second)
+		return backend.EventFilter{}, err
 	}
 	return backend.EventFilter{From: from, To: to, Calendars: calendars, Limit: limit}, nil
 }
@@ -416,33 +733,34 @@ func resolveLocation(tz string) *time.Location {
 func dayBounds(anchor time.Time) (time.Time, time.Time) {
 	y, m, d := anchor.Date()
 	start := time.Date(y, m, d, 0, 0, 0, 0, anchor.Location())
-	end := start.AddDate(0, 0, 1).Add(-time.Second)
-	return start, end
+	return start, start.AddDate(0, 0, 1)
 }
 
 func weekBounds(anchor time.Time, weekStart time.Weekday) (time.Time, time.Time) {
 	anchorStart, _ := dayBounds(anchor)
 	delta := (int(anchorStart.Weekday()) - int(weekStart) + 7) % 7
 	start := anchorStart.AddDate(0, 0, -delta)
-	end := start.AddDate(0, 0, 7).Add(-time.Second)
-	return start, end
+	return start, start.AddDate(0, 0, 7)
 }
 
 func monthBounds(anchor time.Time) (time.Time, time.Time) {
 	y, m, _ := anchor.Date()
 	start := time.Date(y, m, 1, 0, 0, 0, 0, anchor.Location())
-	end := start.AddDate(0, 1, 0).Add(-time.Second)
-	return start, end
+	return start, start.AddDate(0, 1, 0)
 }
 
 func parseWeekStart(v string) (time.Weekday, error) {
 	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "":
+		return currentWeekStart(), nil
 	case "monday", "mon":
 		return time.Monday, nil
 	case "sunday", "sun":
 		return time.Sunday, nil
+	case "saturday", "sat":
+		return time.Saturday, nil
 	default:
-		return time.Sunday, fmt.Errorf("invalid --week-start: %s", v)
+		return time.Sunday, invalidField("week-start", v, fmt.Errorf("invalid --week-start: %s", v))
 	}
 }
 
@@ -457,7 +775,46 @@ func parseRecurrenceScope(v string) (backend.RecurrenceScope, error) {
 	case "series":
 		return backend.ScopeSeries, nil
 	default:
-		return backend.ScopeAuto, fmt.Errorf("invalid --scope: %s", v)
+		return backend.ScopeAuto, invalidField("scope", v, fmt.Errorf("invalid --scope: %s", v))
+	}
+}
+
+func parseEventStatus(v string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "", "none":
+		return "", nil
+	case "confirmed":
+		return contract.StatusConfirmed, nil
+	case "tentative":
+		return contract.StatusTentative, nil
+	case "cancelled", "canceled":
+		return contract.StatusCancelled, nil
+	default:
+		return "", invalidField("status", v, fmt.Errorf("invalid --status: %s", v))
+	}
+}
+
+func parseAvailability(v string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "busy":
+		return contract.AvailabilityBusy, nil
+	case "free":
+		return contract.AvailabilityFree, nil
+	default:
+		return "", invalidField("availability", v, fmt.Errorf("invalid --availability: %s", v))
+	}
+}
+
+func parseSensitivity(v string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "public":
+		return contract.SensitivityPublic, nil
+	case "private":
+		return contract.SensitivityPrivate, nil
+	case "confidential":
+		return contract.SensitivityConfidential, nil
+	default:
+		return "", invalidField("sensitivity", v, fmt.Errorf("invalid --sensitivity: %s", v))
 	}
 }
 
@@ -472,12 +829,14 @@ func parseMonthOrDate(v string, now time.Time, loc *time.Location) (time.Time, e
 	return timeparse.ParseDateTime(s, now, loc)
 }
 
+const durationHint = "Use a duration like 30m, 1h30m, 2d, or '90 minutes'"
+
 func resolveEnd(endS, durationS string, start time.Time, loc *time.Location) (time.Time, error) {
 	if strings.TrimSpace(endS) != "" && strings.TrimSpace(durationS) != "" {
 		return time.Time{}, fmt.Errorf("use either --end or --duration, not both")
 	}
 	if strings.TrimSpace(endS) != "" {
-		end, err := timeparse.ParseDateTime(endS, time.Now(), loc)
+		end, err := timeparse.ParseDateTime(endS, currentTime(), loc)
 		if err != nil {
 			return time.Time{}, err
 		}
@@ -487,7 +846,7 @@ func resolveEnd(endS, durationS string, start time.Time, loc *time.Location) (ti
 		return end, nil
 	}
 	if strings.TrimSpace(durationS) != "" {
-		d, err := time.ParseDuration(durationS)
+		d, err := timeparse.ParseDuration(durationS)
 		if err != nil {
 			return time.Time{}, err
 		}
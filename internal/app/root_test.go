@@ -3,8 +3,10 @@ package app
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -38,7 +40,7 @@ func TestDayBounds(t *testing.T) {
 	if got, want := start.Format(time.RFC3339), "2026-02-10T00:00:00+02:00"; got != want {
 		t.Fatalf("start=%s want=%s", got, want)
 	}
-	if got, want := end.Format(time.RFC3339), "2026-02-10T23:59:59+02:00"; got != want {
+	if got, want := end.Format(time.RFC3339), "2026-02-11T00:00:00+02:00"; got != want {
 		t.Fatalf("end=%s want=%s", got, want)
 	}
 }
@@ -50,7 +52,7 @@ func TestWeekBoundsMondayStart(t *testing.T) {
 	if got, want := start.Format(time.RFC3339), "2026-02-09T00:00:00Z"; got != want {
 		t.Fatalf("start=%s want=%s", got, want)
 	}
-	if got, want := end.Format(time.RFC3339), "2026-02-15T23:59:59Z"; got != want {
+	if got, want := end.Format(time.RFC3339), "2026-02-16T00:00:00Z"; got != want {
 		t.Fatalf("end=%s want=%s", got, want)
 	}
 }
@@ -62,7 +64,7 @@ func TestWeekBoundsSundayStart(t *testing.T) {
 	if got, want := start.Format(time.RFC3339), "2026-02-08T00:00:00Z"; got != want {
 		t.Fatalf("start=%s want=%s", got, want)
 	}
-	if got, want := end.Format(time.RFC3339), "2026-02-14T23:59:59Z"; got != want {
+	if got, want := end.Format(time.RFC3339), "2026-02-15T00:00:00Z"; got != want {
 		t.Fatalf("end=%s want=%s", got, want)
 	}
 }
@@ -74,7 +76,7 @@ func TestMonthBounds(t *testing.T) {
 	if got, want := start.Format(time.RFC3339), "2026-02-01T00:00:00Z"; got != want {
 		t.Fatalf("start=%s want=%s", got, want)
 	}
-	if got, want := end.Format(time.RFC3339), "2026-02-28T23:59:59Z"; got != want {
+	if got, want := end.Format(time.RFC3339), "2026-03-01T00:00:00Z"; got != want {
 		t.Fatalf("end=%s want=%s", got, want)
 	}
 }
@@ -93,6 +95,59 @@ func TestParseWeekStart(t *testing.T) {
 	}
 }
 
+func TestWeekStartFromConfigAndLocale(t *testing.T) {
+	t.Cleanup(func() { weekStartDay.Store(nil) })
+	fb := backend.NewMockBackend(backend.MockFixture{})
+	weekOf := func(args ...string) string {
+		var env struct {
+			Meta map[string]any `json:"meta"`
+		}
+		out := runWithBackend(t, fb, append([]string{"week", "--of", "2026-02-11", "--tz", "UTC", "--json"}, args...)...)
+		if err := json.Unmarshal(out, &env); err != nil {
+			t.Fatal(err)
+		}
+		return env.Meta["from"].(string) + "/" + env.Meta["week_start"].(string)
+	}
+	if got := weekOf(); got != "2026-02-09/Monday" {
+		t.Fatalf("default week = %s", got)
+	}
+	if got := weekOf("--locale", "en_US.UTF-8"); got != "2026-02-08/Sunday" {
+		t.Fatalf("en_US week = %s", got)
+	}
+	cfg := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(cfg, []byte("week_start = \"saturday\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ACAL_CONFIG", cfg)
+	if got := weekOf("--locale", "en_US"); got != "2026-02-07/Saturday" {
+		t.Fatalf("week_start must win over the locale, got %s", got)
+	}
+	if got := weekOf("--week-start", "monday"); got != "2026-02-09/Monday" {
+		t.Fatalf("--week-start must win over week_start, got %s", got)
+	}
+	if got := timeParseFrom(t, fb, "this-week"); got != "2026-02-07" {
+		t.Fatalf("this-week must follow week_start, got %s", got)
+	}
+	if err := os.WriteFile(cfg, []byte("week_start = \"friday\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if code := runEventsCmd(t, fb, "week"); code != 2 {
+		t.Fatalf("invalid week_start exit = %d, want 2", code)
+	}
+}
+
+func timeParseFrom(t *testing.T, fb backend.Backend, expr string) string {
+	t.Helper()
+	var env struct {
+		Data timeParseResult `json:"data"`
+	}
+	out := runWithBackend(t, fb, "time", "parse", expr, "--now", "2026-02-11T12:00:00Z", "--tz", "UTC", "--json")
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatal(err)
+	}
+	return env.Data.AsFrom.Format("2006-01-02")
+}
+
 func TestParseMonthOrDate(t *testing.T) {
 	loc := time.UTC
 	now := time.Date(2026, 2, 11, 9, 0, 0, 0, loc)
@@ -220,17 +275,23 @@ func TestStdinInteractiveFalseForPipe(t *testing.T) {
 }
 
 func TestSelectBackend(t *testing.T) {
-	be, err := selectBackend("osascript")
+	be, err := selectBackend(&globalOptions{Backend: "osascript"})
 	if err != nil {
 		t.Fatalf("selectBackend osascript error: %v", err)
 	}
 	if be == nil {
 		t.Fatalf("expected backend instance")
 	}
-	if _, err := selectBackend("eventkit"); err == nil {
+	if _, err := selectBackend(&globalOptions{Backend: "caldav"}); err == nil {
+		t.Fatalf("expected caldav url required error")
+	}
+	if be, err := selectBackend(&globalOptions{Backend: "caldav", CalDAVURL: "https://dav.example.com/cal/"}); err != nil || be == nil {
+		t.Fatalf("selectBackend caldav error: %v", err)
+	}
+	if _, err := selectBackend(&globalOptions{Backend: "eventkit"}); err == nil {
 		t.Fatalf("expected eventkit not-implemented error")
 	}
-	if _, err := selectBackend("bad-backend"); err == nil {
+	if _, err := selectBackend(&globalOptions{Backend: "bad-backend"}); err == nil {
 		t.Fatalf("expected unknown backend error")
 	}
 }
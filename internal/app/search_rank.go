@@ -0,0 +1,142 @@
+package app
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+type searchHit struct {
+	contract.Event
+	Score   float64  `json:"score"`
+	Matched []string `json:"matched"`
+}
+
+var searchStopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "at": true, "for": true, "in": true, "my": true,
+	"of": true, "on": true, "or": true, "the": true, "to": true, "with": true,
+}
+
+func searchTerms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	terms := []string{}
+	for _, w := range words {
+		if !searchStopwords[w] && !containsString(terms, w) {
+			terms = append(terms, w)
+		}
+	}
+	if len(terms) == 0 {
+		return words
+	}
+	return terms
+}
+
+var rankFields = []struct {
+	name   string
+	weight float64
+	value  func(contract.Event) string
+}{
+	{"title", 3, func(e contract.Event) string { return e.Title }},
+	{"location", 2, func(e contract.Event) string { return e.Location }},
+	{"notes", 1, func(e contract.Event) string { return e.Notes }},
+}
+
+func termMatch(text, term string) float64 {
+	best := 0.0
+	for i := 0; ; {
+		j := strings.Index(text[i:], term)
+		if j < 0 {
+			return best
+		}
+		start, end := i+j, i+j+len(term)
+		if wordBoundary(text, start-1) && wordBoundary(text, end) {
+			return 2
+		}
+		best = 1
+		i = start + 1
+	}
+}
+
+func wordBoundary(text string, i int) bool {
+	if i < 0 || i >= len(text) {
+		return true
+	}
+	r := rune(text[i])
+	return r < 0x80 && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func scoreEvent(ev contract.Event, terms [][]string, phrase, field string, now time.Time) (float64, []string) {
+	if len(terms) == 0 {
+		return 0, nil
+	}
+	total, found := 0.0, 0
+	matched := []string{}
+	for _, alts := range terms {
+		best := 0.0
+		for _, f := range rankFields {
+			if field != "all" && field != f.name {
+				continue
+			}
+			for _, term := range alts {
+				if m := termMatch(strings.ToLower(f.value(ev)), term) * f.weight; m > 0 {
+					best = math.Max(best, m)
+					if !containsString(matched, f.name) {
+						matched = append(matched, f.name)
+					}
+				}
+			}
+		}
+		if best > 0 {
+			total += best
+			found++
+		}
+	}
+	if found == 0 {
+		return 0, nil
+	}
+	if strings.Contains(phrase, " ") && (field == "all" || field == "title") && strings.Contains(strings.ToLower(ev.Title), phrase) {
+		total += 6
+	}
+	days := math.Abs(ev.Start.Sub(now).Hours()) / 24
+	score := total * float64(found) / float64(len(terms)) * (1 + 0.5/(1+days/7))
+	sort.SliceStable(matched, func(i, j int) bool { return rankFieldIndex(matched[i]) < rankFieldIndex(matched[j]) })
+	return math.Round(score*100) / 100, matched
+}
+
+func rankFieldIndex(name string) int {
+	for i, f := range rankFields {
+		if f.name == name {
+			return i
+		}
+	}
+	return len(rankFields)
+}
+
+func rankEvents(items []contract.Event, query string, who []person, field string, now time.Time) []searchHit {
+	words := searchTerms(query)
+	terms := make([][]string, 0, len(words)+len(who))
+	for _, w := range words {
+		terms = append(terms, []string{w})
+	}
+	for _, p := range who {
+		terms = append(terms, p.needles())
+	}
+	phrase := strings.Join(words, " ")
+	out := []searchHit{}
+	for _, ev := range items {
+		if score, matched := scoreEvent(ev, terms, phrase, field, now); score > 0 {
+			out = append(out, searchHit{Event: ev, Score: score, Matched: matched})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Start.Before(out[j].Start)
+	})
+	return out
+}
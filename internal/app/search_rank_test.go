@@ -0,0 +1,64 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestRankEventsPrefersTitleWordsAndNearbyEvents(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	items := []contract.Event{
+		{ID: "notes", Title: "Planning", Notes: "sync with alex on budget", Start: now.Add(24 * time.Hour)},
+		{ID: "far", Title: "Sync with Alex", Start: now.AddDate(0, 0, 28)},
+		{ID: "near", Title: "Alex / Sam sync", Start: now.Add(48 * time.Hour)},
+		{ID: "partial", Title: "Async review", Start: now.Add(time.Hour)},
+		{ID: "other", Title: "Dentist", Start: now.Add(time.Hour)},
+	}
+	hits := rankEvents(items, "the sync with Alex", nil, "all", now)
+	ids := []string{}
+	for _, h := range hits {
+		ids = append(ids, h.ID)
+	}
+	if len(ids) != 4 || ids[0] != "near" || ids[1] != "far" || ids[len(ids)-1] != "partial" {
+		t.Fatalf("unexpected ranking: %v (%+v)", ids, hits)
+	}
+	if hits[0].Matched[0] != "title" || hits[2].Matched[0] != "notes" {
+		t.Fatalf("unexpected matched fields: %+v", hits)
+	}
+	if got := rankEvents(items, "alex", nil, "title", now); len(got) != 2 {
+		t.Fatalf("expected --field title to skip the notes hit, got %+v", got)
+	}
+}
+
+func TestEventsSearchTopJSON(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Sync with Alex", Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)},
+			{ID: "b", CalendarID: "work", CalendarName: "Work", Title: "Team sync", Location: "Alex's office", Start: now.Add(3 * time.Hour), End: now.Add(4 * time.Hour)},
+			{ID: "c", CalendarID: "work", CalendarName: "Work", Title: "Lunch", Start: now.Add(5 * time.Hour), End: now.Add(6 * time.Hour)},
+		},
+	})
+	out := runWithBackend(t, fb, "events", "search", "the sync with Alex", "--top", "1", "--now", "2026-03-02T09:00:00Z", "--json")
+	var env struct {
+		Data []searchHit    `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, out)
+	}
+	if len(env.Data) != 1 || env.Data[0].ID != "a" || env.Data[0].Score <= 0 {
+		t.Fatalf("unexpected hits: %+v", env.Data)
+	}
+	if env.Meta["matches"] != float64(2) || env.Meta["count"] != float64(1) || env.Meta["ranked"] != true {
+		t.Fatalf("unexpected meta: %v", env.Meta)
+	}
+	if code := runEventsCmd(t, fb, "events", "search", "sync", "--top", "-1", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for negative --top, got %d", code)
+	}
+}
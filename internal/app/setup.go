@@ -1,8 +1,11 @@
 package app
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 
+	"github.com/agis/acal/internal/backend"
 	"github.com/agis/acal/internal/contract"
 	"github.com/spf13/cobra"
 )
@@ -47,12 +50,12 @@ func newSetupCmd(opts *globalOptions) *cobra.Command {
 	}
 }
 
-func buildSetupResult(checks []contract.DoctorCheck, derr error, backend string) setupResult {
+func buildSetupResult(checks []contract.DoctorCheck, derr error, backendName string) setupResult {
 	res := setupResult{
 		Ready:      true,
 		Degraded:   false,
 		Checks:     checks,
-		Backend:    strings.TrimSpace(backend),
+		Backend:    strings.TrimSpace(backendName),
 		Permission: "macOS TCC stores approvals per app identity; keep a stable terminal and acal install path",
 	}
 
@@ -65,14 +68,41 @@ func buildSetupResult(checks []contract.DoctorCheck, derr error, backend string)
 		return "", false
 	}
 
+	if name := strings.ToLower(res.Backend); name != "" && name != "osascript" {
+		for _, c := range checks {
+			switch strings.ToLower(strings.TrimSpace(c.Status)) {
+			case "fail":
+				res.Ready = false
+				res.NextSteps = append(res.NextSteps, fmt.Sprintf("Fix failing check `%s`: %s", c.Name, c.Message))
+			case "warn":
+				res.Degraded = true
+				res.Notes = append(res.Notes, c.Message)
+			}
+		}
+		if derr != nil {
+			res.Ready = false
+		}
+		if res.Ready {
+			res.NextSteps = append(res.NextSteps, "Verify read access with: `acal today --json`")
+		}
+		if derr != nil && !res.Ready {
+			res.Notes = append(res.Notes, derr.Error())
+		}
+		return res
+	}
+
 	osascriptStatus, hasOsa := has("osascript")
 	accessStatus, hasAccess := has("calendar_access")
 	dbReadStatus, hasDBRead := has("calendar_db_read")
 	dbStatus, hasDB := has("calendar_db")
+	schemaStatus, hasSchema := has("calendar_db_schema")
 
 	if !hasOsa || osascriptStatus != "ok" {
 		res.Ready = false
 		res.NextSteps = append(res.NextSteps, "Install or expose `osascript` in PATH (default on macOS).")
+		if errors.Is(derr, backend.ErrBackendUnavailable) {
+			res.NextSteps = append(res.NextSteps, "On non-macOS systems use `--backend caldav` or `--backend mock`.")
+		}
 	}
 	if !hasAccess || accessStatus != "ok" {
 		res.Ready = false
@@ -83,6 +113,11 @@ func buildSetupResult(checks []contract.DoctorCheck, derr error, backend string)
 		res.Notes = append(res.Notes, "Calendar database reads are unavailable; acal may run in slower/degraded mode.")
 		res.NextSteps = append(res.NextSteps, "Optional: grant Full Disk Access to your terminal for faster/stabler DB-based reads.")
 	}
+	if hasSchema && schemaStatus != "ok" {
+		res.Degraded = true
+		res.Notes = append(res.Notes, "Calendar database schema is not recognized by this acal version; events are read through AppleScript.")
+		res.NextSteps = append(res.NextSteps, "Check for an acal release that supports this macOS version.")
+	}
 	if hasDB && dbStatus != "ok" {
 		res.Degraded = true
 		res.Notes = append(res.Notes, "Calendar DB path was not detected.")
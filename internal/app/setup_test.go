@@ -2,6 +2,7 @@ package app
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/agis/acal/internal/contract"
@@ -54,3 +55,42 @@ func TestBuildSetupResultDegradedOnly(t *testing.T) {
 		t.Fatalf("expected degraded=true")
 	}
 }
+
+func TestBuildSetupResultUnsupportedSchema(t *testing.T) {
+	checks := []contract.DoctorCheck{
+		{Name: "osascript", Status: "ok"},
+		{Name: "calendar_access", Status: "ok"},
+		{Name: "calendar_db", Status: "ok"},
+		{Name: "calendar_db_read", Status: "ok"},
+		{Name: "calendar_db_schema", Status: "warn"},
+	}
+	res := buildSetupResult(checks, nil, "osascript")
+	if !res.Ready || !res.Degraded {
+		t.Fatalf("expected ready but degraded result, got %+v", res)
+	}
+	if !strings.Contains(strings.Join(res.Notes, " "), "schema") {
+		t.Fatalf("expected a schema note, got %+v", res.Notes)
+	}
+}
+
+func TestBuildSetupResultNonOsaScriptBackend(t *testing.T) {
+	checks := []contract.DoctorCheck{
+		{Name: "mock", Status: "ok"},
+	}
+	res := buildSetupResult(checks, nil, "mock")
+	if !res.Ready || res.Degraded {
+		t.Fatalf("expected ready mock backend, got %+v", res)
+	}
+
+	checks = []contract.DoctorCheck{
+		{Name: "caldav_config", Status: "ok"},
+		{Name: "caldav_access", Status: "fail", Message: "permission denied"},
+	}
+	res = buildSetupResult(checks, errors.New("permission denied"), "caldav")
+	if res.Ready {
+		t.Fatalf("expected not ready caldav backend")
+	}
+	if len(res.NextSteps) != 1 || !strings.Contains(res.NextSteps[0], "caldav_access") {
+		t.Fatalf("unexpected next steps: %+v", res.NextSteps)
+	}
+}
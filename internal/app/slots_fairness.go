@@ -0,0 +1,127 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type participant struct {
+	Name        string
+	Loc         *time.Location
+	StartHour   int
+	StartMinute int
+	EndHour     int
+	EndMinute   int
+	Weekends    bool
+}
+
+func parseParticipant(v string) (participant, error) {
+	p := participant{StartHour: 9, EndHour: 17}
+	for _, kv := range strings.Split(v, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok {
+			return participant{}, fmt.Errorf("invalid --participant %q: want key=value pairs", v)
+		}
+		val = strings.TrimSpace(val)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "tz":
+			loc, err := time.LoadLocation(val)
+			if err != nil {
+				return participant{}, fmt.Errorf("invalid --participant tz %q: %w", val, err)
+			}
+			p.Loc = loc
+		case "name":
+			p.Name = val
+		case "hours":
+			sh, sm, eh, em, err := parseBetweenRange(val)
+			if err != nil {
+				return participant{}, fmt.Errorf("invalid --participant hours %q: %w", val, err)
+			}
+			p.StartHour, p.StartMinute, p.EndHour, p.EndMinute = sh, sm, eh, em
+		case "weekends":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return participant{}, fmt.Errorf("invalid --participant weekends %q", val)
+			}
+			p.Weekends = b
+		default:
+			return participant{}, fmt.Errorf("invalid --participant key %q: use tz, name, hours, weekends", key)
+		}
+	}
+	if p.Loc == nil {
+		return participant{}, fmt.Errorf("invalid --participant %q: tz is required", v)
+	}
+	if p.Name == "" {
+		p.Name = p.Loc.String()
+	}
+	return p, nil
+}
+
+func (p participant) fit(start, end time.Time) float64 {
+	ls, le := start.In(p.Loc), end.In(p.Loc)
+	var inside time.Duration
+	for day := ls; ; day = day.AddDate(0, 0, 1) {
+		y, m, d := day.Date()
+		if wd := day.Weekday(); p.Weekends || (wd != time.Saturday && wd != time.Sunday) {
+			ws := time.Date(y, m, d, p.StartHour, p.StartMinute, 0, 0, p.Loc)
+			we := time.Date(y, m, d, p.EndHour, p.EndMinute, 0, 0, p.Loc)
+			if s, e := maxTime(ls, ws), minTime(le, we); e.After(s) {
+				inside += e.Sub(s)
+			}
+		}
+		if ey, em, ed := le.Date(); y == ey && m == em && d == ed {
+			break
+		}
+	}
+	return math.Round(inside.Seconds()/end.Sub(start).Seconds()*100) / 100
+}
+
+type participantTime struct {
+	Name       string    `json:"name"`
+	TZ         string    `json:"tz"`
+	LocalStart time.Time `json:"local_start"`
+	LocalEnd   time.Time `json:"local_end"`
+	Fit        float64   `json:"fit"`
+}
+
+type fairSlot struct {
+	Start        time.Time         `json:"start"`
+	End          time.Time         `json:"end"`
+	Minutes      int64             `json:"minutes"`
+	Fairness     float64           `json:"fairness"`
+	Score        float64           `json:"score"`
+	Participants []participantTime `json:"participants"`
+}
+
+func rankFairSlots(slots []slotRow, people []participant, minFit float64) []fairSlot {
+	out := make([]fairSlot, 0, len(slots))
+	for _, s := range slots {
+		fs := fairSlot{Start: s.Start, End: s.End, Minutes: s.Minutes, Fairness: 1, Participants: make([]participantTime, 0, len(people))}
+		var sum float64
+		for _, p := range people {
+			f := p.fit(s.Start, s.End)
+			fs.Participants = append(fs.Participants, participantTime{Name: p.Name, TZ: p.Loc.String(), LocalStart: s.Start.In(p.Loc), LocalEnd: s.End.In(p.Loc), Fit: f})
+			sum += f
+			fs.Fairness = math.Min(fs.Fairness, f)
+		}
+		if fs.Fairness < minFit {
+			continue
+		}
+		fs.Score = math.Round(sum/float64(len(people))*100) / 100
+		out = append(out, fs)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Fairness != out[j].Fairness {
+			return out[i].Fairness > out[j].Fairness
+		}
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Start.Before(out[j].Start)
+	})
+	return out
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestParticipantFit(t *testing.T) {
+	p, err := parseParticipant("tz=America/New_York,name=Lee,hours=9am-5pm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 3, 3, 13, 30, 0, 0, time.UTC) // 08:30 in New York
+	if got := p.fit(start, start.Add(time.Hour)); got != 0.5 {
+		t.Fatalf("expected half the slot inside hours, got %v", got)
+	}
+	sat := time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC)
+	if got := p.fit(sat, sat.Add(time.Hour)); got != 0 {
+		t.Fatalf("weekends are outside working hours by default, got %v", got)
+	}
+	if _, err := parseParticipant("name=Lee"); err == nil {
+		t.Fatal("expected tz to be required")
+	}
+}
+
+func TestSlotsRankByParticipantFairness(t *testing.T) {
+	fb := backend.NewMockBackend(backend.MockFixture{Calendars: []contract.Calendar{{ID: "work", Name: "Work"}}})
+	var env struct {
+		Data []fairSlot     `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	args := []string{"slots", "--from", "2026-03-03T00:00:00Z", "--to", "2026-03-03T23:59:00Z", "--between", "06:00-20:00",
+		"--duration", "1h", "--step", "1h", "--tz", "UTC",
+		"--participant", "tz=Europe/Athens,name=Ana", "--participant", "tz=America/New_York", "--json"}
+	if err := json.Unmarshal(runWithBackend(t, fb, args...), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 14 || env.Meta["participants"] != float64(2) {
+		t.Fatalf("expected all 14 slots scored, got %d (%+v)", len(env.Data), env.Meta)
+	}
+	top := env.Data[0]
+	if top.Start.Hour() != 14 || top.Fairness != 1 || top.Participants[0].Name != "Ana" || top.Participants[1].Name != "America/New_York" {
+		t.Fatalf("expected 14:00 UTC to be the fairest slot, got %+v", top)
+	}
+	if top.Participants[0].LocalStart.Hour() != 16 || top.Participants[1].LocalStart.Hour() != 9 {
+		t.Fatalf("unexpected local times: %+v", top.Participants)
+	}
+
+	if err := json.Unmarshal(runWithBackend(t, fb, append(args, "--min-fit", "1")...), &env); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Data) != 1 {
+		t.Fatalf("expected only the fully fair slot with --min-fit 1, got %d", len(env.Data))
+	}
+}
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	stateLockTimeout = 30 * time.Second
+	stateLockPoll    = 25 * time.Millisecond
+)
+
+var errStateLocked = errors.New("acal state is locked by another process")
+
+var stateFileNames = []string{"history.jsonl", "redo.jsonl", "queries.json", "trash.jsonl", "aliases.json"}
+
+func stateDir() string {
+	if xdg := env("XDG_STATE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "acal")
+	}
+	home := env("HOME")
+	if home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".local", "state", "acal")
+}
+
+func legacyStateDir() string {
+	base := defaultUserConfigPath()
+	if strings.TrimSpace(base) == "" {
+		return ""
+	}
+	return filepath.Dir(base)
+}
+
+func statePath(name string) string {
+	dir := stateDir()
+	if dir == "" {
+		return ""
+	}
+	path := filepath.Join(dir, name)
+	if _, err := migrateLegacyStateFile(name, path); err != nil {
+		return filepath.Join(legacyStateDir(), name)
+	}
+	return path
+}
+
+func migrateLegacyStateFile(name, path string) (bool, error) {
+	legacy := legacyStateDir()
+	if legacy == "" || legacy == filepath.Dir(path) {
+		return false, nil
+	}
+	old := filepath.Join(legacy, name)
+	if _, err := os.Stat(old); err != nil {
+		return false, nil
+	}
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, err
+	}
+	if err := os.Rename(old, path); err == nil {
+		return true, nil
+	}
+	raw, err := os.ReadFile(old)
+	if err != nil {
+		return false, err
+	}
+	if err := writeFileAtomic(path, raw, 0o644); err != nil {
+		return false, err
+	}
+	return true, os.Remove(old)
+}
+
+func stateLockPath() string {
+	dir := stateDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "state.lock")
+}
+
+// withStateLock serializes state file updates across processes; it is not reentrant.
+func withStateLock(fn func() error) error {
+	path := stateLockPath()
+	if path == "" {
+		return fn()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	deadline := time.Now().Add(stateLockTimeout)
+	for {
+		ok, err := tryLockFile(f)
+		if err != nil {
+			return fmt.Errorf("lock %s: %w", path, err)
+		}
+		if ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w (%s)", errStateLocked, path)
+		}
+		time.Sleep(stateLockPoll)
+	}
+	defer unlockFile(f)
+	return fn()
+}
+
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentStateMutationsAreSerialized(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*n)
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			errs <- appendHistory(historyEntry{Type: "add", EventID: fmt.Sprintf("evt-%d", i)})
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("q%d", i)
+			errs <- updateSavedQueries(func(store map[string]savedQuery) error {
+				store[name] = savedQuery{Name: name, From: "today", To: "+1d"}
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err := readHistory()
+	if err != nil || len(entries) != n {
+		t.Fatalf("expected %d history entries, got %d (%v)", n, len(entries), err)
+	}
+	store, err := loadSavedQueries()
+	if err != nil || len(store) != n {
+		t.Fatalf("expected %d saved queries, got %d (%v)", n, len(store), err)
+	}
+}
+
+func TestWriteFileAtomicLeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "queries.json")
+	for _, body := range []string{"first", "second"} {
+		if err := writeFileAtomic(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil || string(raw) != "second" {
+		t.Fatalf("unexpected contents %q (%v)", raw, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("unexpected mode %v (%v)", info.Mode(), err)
+	}
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 {
+		t.Fatalf("expected only the target file, got %d entries", len(files))
+	}
+}
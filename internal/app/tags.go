@@ -0,0 +1,127 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+const tagsMarkerPrefix = "acal:tags="
+
+func parseTagsMarker(notes string) []string {
+	tags := []string{}
+	for _, line := range strings.Split(notes, "\n") {
+		s := strings.TrimSpace(line)
+		if !strings.HasPrefix(s, tagsMarkerPrefix) {
+			continue
+		}
+		for _, raw := range strings.Split(strings.TrimPrefix(s, tagsMarkerPrefix), ",") {
+			if tag, err := normalizeTag(raw); err == nil && !containsString(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func setTagsMarker(notes string, tags []string) string {
+	clean := clearTagsMarker(notes)
+	if len(tags) == 0 {
+		return clean
+	}
+	marker := tagsMarkerPrefix + strings.Join(tags, ",")
+	if clean == "" {
+		return marker
+	}
+	return clean + "\n" + marker
+}
+
+func clearTagsMarker(notes string) string {
+	lines := strings.Split(notes, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), tagsMarkerPrefix) {
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+func normalizeTag(v string) (string, error) {
+	tag := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(v), "#"))
+	if tag == "" {
+		return "", fmt.Errorf("empty tag")
+	}
+	for _, r := range tag {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_', r == '.', r == '/':
+		default:
+			return "", fmt.Errorf("invalid tag %q: use letters, digits, '-', '_', '.', '/'", v)
+		}
+	}
+	return tag, nil
+}
+
+func applyTagOps(current []string, ops []string) ([]string, error) {
+	tags := append([]string{}, current...)
+	for _, op := range ops {
+		op = strings.TrimSpace(op)
+		remove := strings.HasPrefix(op, "-")
+		tag, err := normalizeTag(strings.TrimLeft(op, "+-"))
+		if err != nil {
+			return nil, err
+		}
+		if remove {
+			out := tags[:0]
+			for _, t := range tags {
+				if t != tag {
+					out = append(out, t)
+				}
+			}
+			tags = out
+			continue
+		}
+		if !containsString(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+func withTags(e *contract.Event) *contract.Event {
+	if e != nil {
+		e.Tags = parseTagsMarker(e.Notes)
+	}
+	return e
+}
+
+func withEventsTags(items []contract.Event) []contract.Event {
+	for i := range items {
+		withTags(&items[i])
+	}
+	return items
+}
+
+func compareTags(tags []string, op, expected string) (bool, error) {
+	e := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(expected), "#"))
+	switch op {
+	case "==":
+		return containsString(tags, e), nil
+	case "!=":
+		return !containsString(tags, e), nil
+	case "~":
+		for _, t := range tags {
+			if strings.Contains(t, e) {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("operator %s not supported for tag fields", op)
+	}
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestParseTagsMarker(t *testing.T) {
+	notes := "agenda\nacal:tags=Work,#1on1,work\nacal:reminder=-15m"
+	got := parseTagsMarker(notes)
+	if !reflect.DeepEqual(got, []string{"1on1", "work"}) {
+		t.Fatalf("unexpected tags: %#v", got)
+	}
+	if got := parseTagsMarker("no markers"); got == nil || len(got) != 0 {
+		t.Fatalf("expected empty non-nil tags, got %#v", got)
+	}
+}
+
+func TestSetTagsMarkerReplacesAndClears(t *testing.T) {
+	notes := "line1\nacal:tags=old\nline2"
+	got := setTagsMarker(notes, []string{"a", "b"})
+	if got != "line1\nline2\nacal:tags=a,b" {
+		t.Fatalf("unexpected notes: %q", got)
+	}
+	if got := setTagsMarker(got, nil); got != "line1\nline2" {
+		t.Fatalf("unexpected notes after clear: %q", got)
+	}
+}
+
+func TestApplyTagOps(t *testing.T) {
+	got, err := applyTagOps([]string{"work", "1on1"}, []string{"+focus", "-1on1", "Work"})
+	if err != nil {
+		t.Fatalf("applyTagOps error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"focus", "work"}) {
+		t.Fatalf("unexpected tags: %#v", got)
+	}
+	if _, err := applyTagOps(nil, []string{"+two words"}); err == nil {
+		t.Fatalf("expected error for invalid tag")
+	}
+}
+
+func TestApplyPredicatesTag(t *testing.T) {
+	items := []contract.Event{
+		{ID: "1", Notes: "acal:tags=work,1on1"},
+		{ID: "2", Notes: "acal:tags=personal"},
+		{ID: "3"},
+	}
+	cases := []struct {
+		where string
+		want  []string
+	}{
+		{where: "tag==work", want: []string{"1"}},
+		{where: "tag!=work", want: []string{"2", "3"}},
+		{where: "tags~on", want: []string{"1", "2"}},
+	}
+	for _, tc := range cases {
+		preds, err := parsePredicates([]string{tc.where})
+		if err != nil {
+			t.Fatalf("parsePredicates(%q) error: %v", tc.where, err)
+		}
+		got, err := applyPredicates(items, preds)
+		if err != nil {
+			t.Fatalf("applyPredicates(%q) error: %v", tc.where, err)
+		}
+		ids := []string{}
+		for _, e := range got {
+			ids = append(ids, e.ID)
+		}
+		if !reflect.DeepEqual(ids, tc.want) {
+			t.Fatalf("%s: got=%v want=%v", tc.where, ids, tc.want)
+		}
+	}
+	preds, _ := parsePredicates([]string{"tag>work"})
+	if _, err := applyPredicates(items, preds); err == nil {
+		t.Fatalf("expected error for unsupported tag operator")
+	}
+}
@@ -49,7 +49,7 @@ func (b *blockingBackend) DeleteEvent(context.Context, string, backend.Recurrenc
 
 func TestEventsListTimeoutIncludesBackendPhase(t *testing.T) {
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return &blockingBackend{}, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return &blockingBackend{}, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	var out bytes.Buffer
@@ -76,7 +76,7 @@ func TestEventsListTimeoutIncludesBackendPhase(t *testing.T) {
 
 func TestEventsAddTimeoutIncludesBackendPhase(t *testing.T) {
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return &blockingBackend{}, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return &blockingBackend{}, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	var out bytes.Buffer
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/agis/acal/internal/timeparse"
+	"github.com/spf13/cobra"
+)
+
+type toBound int32
+
+const (
+	// toAuto covers the whole --to day unless the value has a clock time.
+	toAuto toBound = iota
+	// toInclusive covers the whole --to day, even with a clock time.
+	toInclusive
+	// toExclusive ends just before --to, even at midnight.
+	toExclusive
+)
+
+var rangeToBound atomic.Int32
+
+func setToBound(inclusive, exclusive bool) error {
+	rangeToBound.Store(int32(toAuto))
+	switch {
+	case inclusive && exclusive:
+		return errors.New("--to-inclusive and --to-exclusive are mutually exclusive")
+	case inclusive:
+		rangeToBound.Store(int32(toInclusive))
+	case exclusive:
+		rangeToBound.Store(int32(toExclusive))
+	}
+	return nil
+}
+
+// nextMidnight avoids adding 24h, which is off by an hour across DST.
+func nextMidnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+}
+
+func rangeEnd(to time.Time, bound toBound) time.Time {
+	midnight := to.Hour() == 0 && to.Minute() == 0 && to.Second() == 0 && to.Nanosecond() == 0
+	switch {
+	case bound == toExclusive:
+		return to
+	case bound == toInclusive, midnight:
+		return nextMidnight(to)
+	}
+	return to
+}
+
+const rangeKeywordHint = "Use a range keyword like this-week, last-month, next-quarter, q3, q1-2027, ytd, or mtd"
+
+func rangeKeyword(s string, loc *time.Location) (time.Time, time.Time, bool) {
+	return timeparse.ParseRange(s, currentTime(), loc, currentWeekStart())
+}
+
+func resolveRange(fromS, toS string, loc *time.Location) (time.Time, time.Time, error) {
+	now := currentTime()
+	from, _, ok := rangeKeyword(fromS, loc)
+	if !ok {
+		var err error
+		if from, err = timeparse.ParseDateTime(fromS, now, loc); err != nil {
+			return time.Time{}, time.Time{}, invalidField("from", fromS, fmt.Errorf("invalid --from: %w", err))
+		}
+	}
+	_, to, ok := rangeKeyword(toS, loc)
+	if !ok {
+		parsed, err := timeparse.ParseDateTime(toS, now, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, invalidField("to", toS, fmt.Errorf("invalid --to: %w", err))
+		}
+		if parsed.Before(from) {
+			return time.Time{}, time.Time{}, invalidField("to", toS, errors.New("--to must not be earlier than --from"))
+		}
+		to = rangeEnd(parsed, toBound(rangeToBound.Load()))
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, invalidField("to", toS, errors.New("--to must not be earlier than --from"))
+	}
+	return from, to, nil
+}
+
+func applyRangeFlag(cmd *cobra.Command, value string, loc *time.Location) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	fromF, toF := cmd.Flags().Lookup("from"), cmd.Flags().Lookup("to")
+	if fromF == nil || toF == nil {
+		return fmt.Errorf("--range is not supported by %q, which has no --from/--to", cmd.CommandPath())
+	}
+	if fromF.Changed || toF.Changed {
+		return errors.New("--range replaces --from/--to; pass one or the other")
+	}
+	if _, _, ok := rangeKeyword(value, loc); !ok {
+		if _, err := timeparse.ParseDateTime(value, currentTime(), loc); err != nil {
+			return invalidField("range", value, fmt.Errorf("invalid --range %q: %s", value, rangeKeywordHint))
+		}
+	}
+	if err := fromF.Value.Set(value); err != nil {
+		return err
+	}
+	return toF.Value.Set(value)
+}
+
+func rangeLastDay(to time.Time) time.Time {
+	return to.Add(-time.Nanosecond)
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestResolveRangeToBounds(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	if err := setPinnedNow("2026-03-02T09:00:00+01:00"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		pinnedNow.Store(nil)
+		_ = setToBound(false, false)
+	})
+	cases := []struct {
+		name                 string
+		inclusive, exclusive bool
+		to, want             string
+	}{
+		{"bare date covers the day", false, false, "2026-03-05", "2026-03-06T00:00:00+01:00"},
+		{"clock time is the exclusive end", false, false, "2026-03-05 17:00", "2026-03-05T17:00:00+01:00"},
+		{"dst day is 23 hours long", false, false, "2026-03-29", "2026-03-30T00:00:00+02:00"},
+		{"inclusive covers a clock day", true, false, "2026-03-05 17:00", "2026-03-06T00:00:00+01:00"},
+		{"exclusive keeps midnight", false, true, "2026-03-05", "2026-03-05T00:00:00+01:00"},
+	}
+	for _, tc := range cases {
+		if err := setToBound(tc.inclusive, tc.exclusive); err != nil {
+			t.Fatal(err)
+		}
+		from, to, err := resolveRange("today", tc.to, loc)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got := to.Format(time.RFC3339); got != tc.want {
+			t.Fatalf("%s: to=%s want %s", tc.name, got, tc.want)
+		}
+		if got := from.Format(time.RFC3339); got != "2026-03-02T00:00:00+01:00" {
+			t.Fatalf("%s: from=%s", tc.name, got)
+		}
+	}
+	if err := setToBound(true, true); err == nil {
+		t.Fatal("expected --to-inclusive with --to-exclusive to fail")
+	}
+	if _, _, err := resolveRange("2026-03-05", "2026-03-04", loc); err == nil {
+		t.Fatal("expected --to before --from to fail")
+	}
+}
+
+func TestEventsListToIsHalfOpen(t *testing.T) {
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: "standup", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: start, End: start.Add(15 * time.Minute)},
+		{ID: "late", CalendarID: "work", CalendarName: "Work", Title: "Late", Start: start.Add(13 * time.Hour), End: start.Add(13*time.Hour + 30*time.Minute)},
+	}})
+	t.Cleanup(func() { _ = setToBound(false, false) })
+	count := func(args ...string) int {
+		var env struct {
+			Data []contract.Event `json:"data"`
+		}
+		out := runWithBackend(t, fb, append([]string{"events", "list", "--from", "2026-03-03", "--tz", "UTC", "--json"}, args...)...)
+		if err := json.Unmarshal(out, &env); err != nil {
+			t.Fatal(err)
+		}
+		return len(env.Data)
+	}
+	if n := count("--to", "2026-03-03 10:00"); n != 0 {
+		t.Fatalf("an event starting at a clock --to is outside the range, got %d", n)
+	}
+	if n := count("--to", "2026-03-03"); n != 2 {
+		t.Fatalf("a bare --to date covers the whole day, got %d", n)
+	}
+	if n := count("--to", "2026-03-03 10:00", "--to-inclusive"); n != 2 {
+		t.Fatalf("--to-inclusive covers the whole --to day, got %d", n)
+	}
+	if n := count("--to", "2026-03-04", "--to-exclusive"); n != 2 {
+		t.Fatalf("--to-exclusive ends at midnight before the --to day, got %d", n)
+	}
+	if n := count("--to", "2026-03-03", "--to-exclusive"); n != 0 {
+		t.Fatalf("--to-exclusive at --from leaves an empty range, got %d", n)
+	}
+}
+
+func TestEventsListRangeKeyword(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2026, 3, day, 10, 0, 0, 0, time.UTC) }
+	fb := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: "sun", CalendarID: "work", Title: "Sunday", Start: at(1), End: at(1).Add(time.Hour)},
+		{ID: "mon", CalendarID: "work", Title: "Monday", Start: at(2), End: at(2).Add(time.Hour)},
+		{ID: "sun2", CalendarID: "work", Title: "Next Sunday", Start: at(8), End: at(8).Add(time.Hour)},
+		{ID: "mon2", CalendarID: "work", Title: "Next Monday", Start: at(9), End: at(9).Add(time.Hour)},
+	}})
+	t.Cleanup(func() { pinnedNow.Store(nil) })
+	ids := func(args ...string) []string {
+		var env struct {
+			Data []contract.Event `json:"data"`
+		}
+		out := runWithBackend(t, fb, append([]string{"events", "list", "--now", "2026-03-04T12:00:00Z", "--tz", "UTC", "--json"}, args...)...)
+		if err := json.Unmarshal(out, &env); err != nil {
+			t.Fatal(err)
+		}
+		got := []string{}
+		for _, ev := range env.Data {
+			got = append(got, ev.ID)
+		}
+		return got
+	}
+	if got := ids("--range", "this-week"); len(got) != 2 || got[0] != "mon" || got[1] != "sun2" {
+		t.Fatalf("--range this-week = %v, want [mon sun2]", got)
+	}
+	if got := ids("--from", "last-week", "--to", "this-week"); len(got) != 3 || got[0] != "sun" {
+		t.Fatalf("--from last-week --to this-week = %v, want [sun mon sun2]", got)
+	}
+	if code := runEventsCmd(t, fb, "events", "list", "--range", "this-week", "--from", "today"); code != 2 {
+		t.Fatalf("--range with --from exit = %d, want 2", code)
+	}
+	if code := runEventsCmd(t, fb, "events", "list", "--range", "fortnight"); code != 2 {
+		t.Fatalf("unknown --range exit = %d, want 2", code)
+	}
+	if code := runEventsCmd(t, fb, "calendars", "list", "--range", "this-week"); code != 2 {
+		t.Fatalf("--range on a command without --from/--to exit = %d, want 2", code)
+	}
+}
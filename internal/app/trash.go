@@ -0,0 +1,227 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+var errNotInTrash = errors.New("event not found in trash")
+
+var errSoftDeleteSeries = errors.New("soft delete keeps a single occurrence and cannot restore a recurring series")
+
+type trashEntry struct {
+	TrashedAt time.Time               `json:"trashed_at"`
+	EventID   string                  `json:"event_id"`
+	Scope     backend.RecurrenceScope `json:"scope"`
+	Event     contract.Event          `json:"event"`
+}
+
+func trashFilePath() string {
+	return statePath("trash.jsonl")
+}
+
+func readTrash() ([]trashEntry, error) {
+	path := trashFilePath()
+	if path == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := []trashEntry{}
+	for _, line := range strings.Split(string(raw), "\n") {
+		s := strings.TrimSpace(line)
+		if s == "" {
+			continue
+		}
+		var e trashEntry
+		if err := json.Unmarshal([]byte(s), &e); err != nil {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func writeTrash(entries []trashEntry) error {
+	path := trashFilePath()
+	if path == "" {
+		return nil
+	}
+	var b strings.Builder
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	return writeFileAtomic(path, []byte(b.String()), 0o644)
+}
+
+func addToTrash(entry trashEntry) error {
+	if entry.TrashedAt.IsZero() {
+		entry.TrashedAt = time.Now().UTC()
+	}
+	return withStateLock(func() error {
+		entries, err := readTrash()
+		if err != nil {
+			return err
+		}
+		return writeTrash(append(entries, entry))
+	})
+}
+
+// takeFromTrash expects the caller to hold the state lock.
+func takeFromTrash(id string) (trashEntry, error) {
+	entries, err := readTrash()
+	if err != nil {
+		return trashEntry{}, err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].EventID == id {
+			found := entries[i]
+			return found, writeTrash(append(entries[:i:i], entries[i+1:]...))
+		}
+	}
+	return trashEntry{}, fmt.Errorf("%w: %s", errNotInTrash, id)
+}
+
+func checkSoftDeleteScope(ctx context.Context, be backend.Backend, id string, scope backend.RecurrenceScope, item *contract.Event) error {
+	switch {
+	case scope == backend.ScopeThis:
+		return nil
+	case scope == backend.ScopeFuture:
+		return errSoftDeleteSeries
+	case scope == backend.ScopeAuto && seriesUID(id) != id:
+		return nil
+	}
+	s, err := inspectSeriesWithTimeout(ctx, be, seriesUID(id), item.Start, item.Start.AddDate(1, 0, 0))
+	if err != nil {
+		return nil
+	}
+	if strings.TrimSpace(s.Rule) != "" || len(s.Occurrences) > 1 {
+		return errSoftDeleteSeries
+	}
+	return nil
+}
+
+func removeFromTrash(id string) error {
+	return withStateLock(func() error {
+		_, err := takeFromTrash(id)
+		return err
+	})
+}
+
+func newEventsTrashCmd(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "trash",
+		Short: "List soft-deleted events",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, _, _, err := buildContext(cmd, opts, "events.trash")
+			if err != nil {
+				return err
+			}
+			entries, err := readTrash()
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Check trash file permissions (`acal state path`)", 1)
+			}
+			return p.Success(entries, map[string]any{"count": len(entries)}, nil)
+		},
+	}
+}
+
+func newEventsRestoreCmd(opts *globalOptions) *cobra.Command {
+	var calendar string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "restore <event-id>",
+		Short: "Re-create a soft-deleted event from the trash",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, be, ro, err := buildContext(cmd, opts, "events.restore")
+			if err != nil {
+				return err
+			}
+			ctx, cancel := commandContext(ro)
+			defer cancel()
+			id := resolveAlias(args[0])
+			var entry trashEntry
+			var item *contract.Event
+			err = withStateLock(func() error {
+				entries, err := readTrash()
+				if err != nil {
+					return err
+				}
+				found := false
+				for i := len(entries) - 1; i >= 0 && !found; i-- {
+					if entries[i].EventID == id {
+						entry, found = entries[i], true
+					}
+				}
+				if !found {
+					return fmt.Errorf("%w: %s", errNotInTrash, id)
+				}
+				ev := entry.Event
+				in := backend.EventCreateInput{
+					Calendar:    firstNonEmpty(calendar, ev.CalendarName, ev.CalendarID),
+					Title:       ev.Title,
+					Start:       ev.Start,
+					End:         ev.End,
+					Location:    ev.Location,
+					Notes:       ev.Notes,
+					URL:         ev.URL,
+					Status:      ev.Status,
+					Sensitivity: ev.Sensitivity,
+					AllDay:      ev.AllDay,
+				}
+				if dryRun {
+					item = &contract.Event{CalendarName: in.Calendar, Title: in.Title, Start: in.Start, End: in.End, Location: in.Location, Notes: in.Notes, URL: in.URL, AllDay: in.AllDay}
+					return nil
+				}
+				if item, err = addEventWithTimeout(ctx, be, in); err != nil {
+					return err
+				}
+				_, err = takeFromTrash(id)
+				return err
+			})
+			if errors.Is(err, errNotInTrash) {
+				return failWithHint(p, contract.ErrNotFound, err, "Run `acal events trash` to list restorable events", 4)
+			}
+			if err != nil {
+				return failWithHint(p, contract.ErrGeneric, err, "Check calendar name and permissions, or pass --calendar", 1)
+			}
+			meta := map[string]any{"count": 1, "restored_from": id}
+			var warnings []contract.Warning
+			if entry.Scope == backend.ScopeSeries || entry.Scope == backend.ScopeFuture {
+				warnings = append(warnings, contract.Warning{Code: contract.WarnRecurrenceUnavailable, Message: fmt.Sprintf("trashed with --scope %s; only the snapshot occurrence is restored, without its recurrence", entry.Scope)})
+			}
+			if dryRun {
+				meta["dry_run"] = true
+				return successWithMeta(ctx, p, ro, item, meta, warnings)
+			}
+			if item != nil {
+				_ = appendHistory(historyEntry{Type: "add", EventID: item.ID, Created: item})
+			}
+			return successWithMeta(ctx, p, ro, item, meta, warnings)
+		},
+	}
+	cmd.Flags().StringVar(&calendar, "calendar", "", "Restore into this calendar instead of the original")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Preview without writing")
+	return cmd
+}
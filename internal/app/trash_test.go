@@ -0,0 +1,129 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events:    []contract.Event{{ID: "standup", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: start, End: start.Add(15 * time.Minute), Notes: "daily"}},
+	})
+
+	var del struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "delete", "standup", "--soft", "--force", "--json"), &del); err != nil {
+		t.Fatal(err)
+	}
+	if del.Data["trashed"] != true {
+		t.Fatalf("expected trashed delete, got %v", del.Data)
+	}
+	if _, err := fb.GetEventByID(t.Context(), "standup"); err == nil {
+		t.Fatal("expected event to be removed from the calendar")
+	}
+
+	var trash struct {
+		Data []trashEntry `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "trash", "--json"), &trash); err != nil {
+		t.Fatal(err)
+	}
+	if len(trash.Data) != 1 || trash.Data[0].EventID != "standup" || trash.Data[0].Event.Title != "Standup" {
+		t.Fatalf("unexpected trash: %+v", trash.Data)
+	}
+
+	var restored struct {
+		Data contract.Event `json:"data"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "restore", "standup", "--json"), &restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Data.ID == "" || restored.Data.Title != "Standup" || restored.Data.Notes != "daily" || !restored.Data.Start.Equal(start) {
+		t.Fatalf("unexpected restored event: %+v", restored.Data)
+	}
+	if entries, _ := readTrash(); len(entries) != 0 {
+		t.Fatalf("expected empty trash after restore, got %d", len(entries))
+	}
+	if id := lastCreatedID(mustReadHistory(t)); id != restored.Data.ID {
+		t.Fatalf("expected restore recorded in history, got %q", id)
+	}
+}
+
+func TestSoftDeleteConfigAndHardOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv("ACAL_SOFT_DELETE", "true")
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "A", Start: start, End: start.Add(time.Hour)},
+			{ID: "b", CalendarID: "work", CalendarName: "Work", Title: "B", Start: start, End: start.Add(time.Hour)},
+		},
+	})
+	runWithBackend(t, fb, "events", "delete", "a", "--force", "--json")
+	runWithBackend(t, fb, "events", "delete", "b", "--force", "--hard", "--json")
+	entries, err := readTrash()
+	if err != nil || len(entries) != 1 || entries[0].EventID != "a" {
+		t.Fatalf("expected only a in trash, got %+v (%v)", entries, err)
+	}
+}
+
+func mustReadHistory(t *testing.T) []historyEntry {
+	t.Helper()
+	entries, err := readHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func TestSoftDeleteRecurringEvent(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	occ := start.AddDate(0, 0, 7)
+	fb := &seriesInspectorBackend{
+		MockBackend: backend.NewMockBackend(backend.MockFixture{
+			Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+			Events: []contract.Event{
+				{ID: "standup", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: start, End: start.Add(15 * time.Minute)},
+				{ID: "standup@1", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: occ, End: occ.Add(15 * time.Minute)},
+			},
+		}),
+		series: &backend.Series{UID: "standup", Rule: "FREQ=WEEKLY", Occurrences: []backend.SeriesOccurrence{{ID: "standup", Start: start}, {ID: "standup@1", Start: occ}}},
+	}
+	if code := runEventsCmd(t, fb, "events", "delete", "standup", "--soft", "--force", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for a soft series delete, got %d", code)
+	}
+	if code := runEventsCmd(t, fb, "events", "delete", "standup@1", "--scope", "future", "--soft", "--force", "--json"); code != 2 {
+		t.Fatalf("expected exit 2 for a soft future delete, got %d", code)
+	}
+	if entries, _ := readTrash(); len(entries) != 0 {
+		t.Fatalf("expected nothing trashed, got %+v", entries)
+	}
+	if _, err := fb.GetEventByID(t.Context(), "standup"); err != nil {
+		t.Fatalf("expected the series to survive a refused soft delete: %v", err)
+	}
+
+	runWithBackend(t, fb, "events", "delete", "standup@1", "--scope", "this", "--soft", "--force", "--json")
+	var restored struct {
+		Data     contract.Event `json:"data"`
+		Warnings []string       `json:"warnings"`
+	}
+	if err := json.Unmarshal(runWithBackend(t, fb, "events", "restore", "standup@1", "--json"), &restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Data.Title != "Standup" || !restored.Data.Start.Equal(occ) || len(restored.Warnings) != 0 {
+		t.Fatalf("unexpected restored occurrence: %+v", restored)
+	}
+}
@@ -50,7 +50,7 @@ func (b *strictNoCallBackend) DeleteEvent(context.Context, string, backend.Recur
 
 func TestUpdateInvalidScopeFailsBeforeBackendLookup(t *testing.T) {
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return &strictNoCallBackend{}, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return &strictNoCallBackend{}, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	var out bytes.Buffer
@@ -73,7 +73,7 @@ func TestUpdateInvalidScopeFailsBeforeBackendLookup(t *testing.T) {
 
 func TestMoveInvalidFlagsFailBeforeBackendLookup(t *testing.T) {
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return &strictNoCallBackend{}, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return &strictNoCallBackend{}, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	var out bytes.Buffer
@@ -96,7 +96,7 @@ func TestMoveInvalidFlagsFailBeforeBackendLookup(t *testing.T) {
 
 func TestCopyInvalidToFailsBeforeBackendLookup(t *testing.T) {
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return &strictNoCallBackend{}, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return &strictNoCallBackend{}, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	var out bytes.Buffer
@@ -119,7 +119,7 @@ func TestCopyInvalidToFailsBeforeBackendLookup(t *testing.T) {
 
 func TestRemindInvalidOffsetFailsBeforeBackendLookup(t *testing.T) {
 	origFactory := backendFactory
-	backendFactory = func(string) (backend.Backend, error) { return &strictNoCallBackend{}, nil }
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return &strictNoCallBackend{}, nil }
 	t.Cleanup(func() { backendFactory = origFactory })
 
 	var out bytes.Buffer
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type monthGrid struct {
+	Month     string      `json:"month"`
+	WeekStart string      `json:"week_start"`
+	Weeks     [][]gridDay `json:"weeks"`
+}
+
+type gridDay struct {
+	Date    string `json:"date"`
+	Day     int    `json:"day"`
+	InMonth bool   `json:"in_month"`
+	Today   bool   `json:"today"`
+	Count   int    `json:"count"`
+}
+
+func buildMonthGrid(rows []daySummary, start time.Time, weekStart time.Weekday, now time.Time) monthGrid {
+	counts := map[string]int{}
+	for _, r := range rows {
+		counts[r.Date] = r.Total
+	}
+	today := now.In(start.Location()).Format("2006-01-02")
+	first, _ := weekBounds(start, weekStart)
+	next := start.AddDate(0, 1, 0)
+	g := monthGrid{Month: start.Format("2006-01"), WeekStart: weekStart.String()}
+	for day := first; day.Before(next); {
+		week := make([]gridDay, 0, 7)
+		for i := 0; i < 7; i++ {
+			key := day.Format("2006-01-02")
+			week = append(week, gridDay{
+				Date:    key,
+				Day:     day.Day(),
+				InMonth: day.Month() == start.Month(),
+				Today:   key == today,
+				Count:   counts[key],
+			})
+			day = day.AddDate(0, 0, 1)
+		}
+		g.Weeks = append(g.Weeks, week)
+	}
+	return g
+}
+
+func renderMonthGrid(w io.Writer, g monthGrid, start time.Time) {
+	var b strings.Builder
+	b.WriteString(start.Format("January 2006"))
+	b.WriteString("\n")
+	var header strings.Builder
+	if len(g.Weeks) > 0 {
+		for _, d := range g.Weeks[0] {
+			t, _ := time.Parse("2006-01-02", d.Date)
+			fmt.Fprintf(&header, " %-6s", t.Weekday().String()[:2])
+		}
+	}
+	b.WriteString(strings.TrimRight(header.String(), " "))
+	b.WriteString("\n")
+	for _, week := range g.Weeks {
+		var line strings.Builder
+		for _, d := range week {
+			line.WriteString(gridCell(d))
+		}
+		b.WriteString(strings.TrimRight(line.String(), " "))
+		b.WriteString("\n")
+	}
+	_, _ = io.WriteString(w, b.String())
+}
+
+func gridCell(d gridDay) string {
+	if !d.InMonth {
+		return strings.Repeat(" ", 7)
+	}
+	left, right := " ", " "
+	if d.Today {
+		left, right = "[", "]"
+	}
+	count := "."
+	if d.Count > 0 {
+		count = strconv.Itoa(d.Count)
+	}
+	return fmt.Sprintf("%s%2d%s%-3s", left, d.Day, right, count)
+}
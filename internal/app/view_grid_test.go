@@ -0,0 +1,46 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestBuildMonthGridPadsWeeksAndMarksToday(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	rows := []daySummary{{Date: "2026-03-02", Total: 2}, {Date: "2026-03-03", Total: 1}}
+	g := buildMonthGrid(rows, start, time.Monday, time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC))
+	if g.Month != "2026-03" || g.WeekStart != "Monday" {
+		t.Fatalf("unexpected grid header: %+v", g)
+	}
+	if len(g.Weeks) != 6 {
+		t.Fatalf("expected 6 weeks for March 2026 starting monday, got %d", len(g.Weeks))
+	}
+	first := g.Weeks[0]
+	if first[0].Date != "2026-02-23" || first[0].InMonth || !first[6].InMonth || first[6].Day != 1 {
+		t.Fatalf("unexpected first week: %+v", first)
+	}
+	mon := g.Weeks[1][0]
+	if mon.Date != "2026-03-02" || mon.Count != 2 || mon.Today {
+		t.Fatalf("unexpected 2026-03-02 cell: %+v", mon)
+	}
+	if tue := g.Weeks[1][1]; !tue.Today || tue.Count != 1 {
+		t.Fatalf("expected today marker on 2026-03-03, got %+v", tue)
+	}
+}
+
+func TestRenderMonthGrid(t *testing.T) {
+	start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	g := buildMonthGrid([]daySummary{{Date: "2026-02-10", Total: 12}}, start, time.Sunday, time.Date(2026, 2, 14, 9, 0, 0, 0, time.UTC))
+	var out bytes.Buffer
+	renderMonthGrid(&out, g, start)
+	want := "February 2026\n" +
+		" Su     Mo     Tu     We     Th     Fr     Sa\n" +
+		"  1 .    2 .    3 .    4 .    5 .    6 .    7 .\n" +
+		"  8 .    9 .   10 12  11 .   12 .   13 .  [14].\n" +
+		" 15 .   16 .   17 .   18 .   19 .   20 .   21 .\n" +
+		" 22 .   23 .   24 .   25 .   26 .   27 .   28 .\n"
+	if got := out.String(); got != want {
+		t.Fatalf("unexpected grid:\n%s\nwant:\n%s", got, want)
+	}
+}
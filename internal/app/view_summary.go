@@ -7,34 +7,44 @@ import (
 )
 
 type daySummary struct {
-	Date   string `json:"date"`
-	Total  int    `json:"total"`
-	AllDay int    `json:"all_day"`
-	Timed  int    `json:"timed"`
+	Date      string `json:"date"`
+	Total     int    `json:"total"`
+	AllDay    int    `json:"all_day"`
+	Timed     int    `json:"timed"`
+	Continued int    `json:"continued"`
 }
 
 func summarizeEventsByDay(events []contract.Event, from, to time.Time, loc *time.Location) []daySummary {
 	if to.Before(from) {
 		return nil
 	}
+	start, _ := dayBounds(from.In(loc))
+	end, _ := dayBounds(rangeLastDay(to).In(loc))
 	buckets := map[string]*daySummary{}
 	for _, e := range events {
-		day := e.Start.In(loc).Format("2006-01-02")
-		row, ok := buckets[day]
-		if !ok {
-			row = &daySummary{Date: day}
-			buckets[day] = row
-		}
-		row.Total++
-		if e.AllDay {
-			row.AllDay++
-		} else {
-			row.Timed++
+		first, last := eventDaySpan(e, loc)
+		for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+			if d.Before(start) || d.After(end) {
+				continue
+			}
+			day := d.Format("2006-01-02")
+			row, ok := buckets[day]
+			if !ok {
+				row = &daySummary{Date: day}
+				buckets[day] = row
+			}
+			row.Total++
+			if e.AllDay {
+				row.AllDay++
+			} else {
+				row.Timed++
+			}
+			if d.After(first) {
+				row.Continued++
+			}
 		}
 	}
 
-	start, _ := dayBounds(from.In(loc))
-	end, _ := dayBounds(to.In(loc))
 	rows := make([]daySummary, 0, int(end.Sub(start)/(24*time.Hour))+1)
 	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
 		key := d.Format("2006-01-02")
@@ -46,3 +56,20 @@ func summarizeEventsByDay(events []contract.Event, from, to time.Time, loc *time
 	}
 	return rows
 }
+
+// End is exclusive: an event ending exactly at midnight does not cover the next day.
+func eventDaySpan(e contract.Event, loc *time.Location) (time.Time, time.Time) {
+	first, _ := dayBounds(e.Start.In(loc))
+	if !e.End.After(e.Start) {
+		return first, first
+	}
+	last, _ := dayBounds(e.End.Add(-time.Nanosecond).In(loc))
+	return first, last
+}
+
+func markContinued(items []contract.Event, from time.Time) []contract.Event {
+	for i := range items {
+		items[i].Continued = items[i].Start.Before(from)
+	}
+	return items
+}
@@ -1,9 +1,13 @@
 package app
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"testing"
 	"time"
 
+	"github.com/agis/acal/internal/backend"
 	"github.com/agis/acal/internal/contract"
 )
 
@@ -30,3 +34,56 @@ func TestSummarizeEventsByDay(t *testing.T) {
 		t.Fatalf("unexpected day 3 summary: %+v", rows[2])
 	}
 }
+
+func TestSummarizeEventsByDaySplitsMultiDayEvents(t *testing.T) {
+	loc := time.UTC
+	from := time.Date(2026, 2, 9, 0, 0, 0, 0, loc)
+	to := time.Date(2026, 2, 12, 23, 59, 59, 0, loc)
+	events := []contract.Event{
+		{Start: time.Date(2026, 2, 8, 0, 0, 0, 0, loc), End: time.Date(2026, 2, 11, 0, 0, 0, 0, loc), AllDay: true},
+		{Start: time.Date(2026, 2, 11, 22, 0, 0, 0, loc), End: time.Date(2026, 2, 12, 2, 0, 0, 0, loc)},
+	}
+	rows := summarizeEventsByDay(events, from, to, loc)
+	want := []daySummary{
+		{Date: "2026-02-09", Total: 1, AllDay: 1, Continued: 1},
+		{Date: "2026-02-10", Total: 1, AllDay: 1, Continued: 1},
+		{Date: "2026-02-11", Total: 1, Timed: 1},
+		{Date: "2026-02-12", Total: 1, Timed: 1, Continued: 1},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %+v", len(want), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Fatalf("row %d: got %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestTodayIncludesEventsContinuingIntoDay(t *testing.T) {
+	fb := backend.NewMockBackend(backend.MockFixture{Events: []contract.Event{
+		{ID: "conf", CalendarName: "Work", Title: "Conference", Start: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), AllDay: true},
+		{ID: "sync", CalendarName: "Work", Title: "Sync", Start: time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 3, 10, 30, 0, 0, time.UTC)},
+	}})
+	origFactory := backendFactory
+	backendFactory = func(*globalOptions) (backend.Backend, error) { return fb, nil }
+	t.Cleanup(func() { backendFactory = origFactory })
+
+	cmd := NewRootCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"today", "--day", "2026-03-03", "--tz", "UTC", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	var got struct {
+		Data []contract.Event `json:"data"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(got.Data) != 2 || got.Data[0].ID != "conf" || !got.Data[0].Continued || got.Data[1].Continued {
+		t.Fatalf("expected continued conference plus sync, got %+v", got.Data)
+	}
+}
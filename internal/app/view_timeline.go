@@ -0,0 +1,259 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+)
+
+type timelineDay struct {
+	Date      string          `json:"date"`
+	Events    []timelineEvent `json:"events"`
+	AllDay    int             `json:"all_day"`
+	Busy      int64           `json:"busy_minutes"`
+	Conflicts []conflictRow   `json:"conflicts"`
+}
+
+type timelineEvent struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Calendar string    `json:"calendar"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Busy     bool      `json:"busy"`
+	Conflict bool      `json:"conflict"`
+}
+
+func timelineBusy(ev contract.Event) bool {
+	return ev.Status != contract.StatusCancelled && ev.Availability != contract.AvailabilityFree
+}
+
+func buildTimeline(items []contract.Event, start, end time.Time, loc *time.Location) []timelineDay {
+	days := []timelineDay{}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		td := timelineDay{Date: day.Format("2006-01-02"), Events: []timelineEvent{}, Conflicts: []conflictRow{}}
+		busy := []contract.Event{}
+		for _, ev := range items {
+			if !ev.Start.Before(next) || !ev.End.After(day) {
+				continue
+			}
+			if ev.AllDay {
+				td.AllDay++
+				continue
+			}
+			clipped := ev
+			clipped.Start, clipped.End = maxTime(ev.Start, day).In(loc), minTime(ev.End, next).In(loc)
+			te := timelineEvent{ID: ev.ID, Title: ev.Title, Calendar: firstNonEmpty(ev.CalendarName, ev.CalendarID), Start: clipped.Start, End: clipped.End, Busy: timelineBusy(ev)}
+			if te.Busy {
+				busy = append(busy, clipped)
+			}
+			td.Events = append(td.Events, te)
+		}
+		sort.SliceStable(td.Events, func(i, j int) bool { return td.Events[i].Start.Before(td.Events[j].Start) })
+		if rows := buildConflictRows(busy, false); rows != nil {
+			td.Conflicts = rows
+		}
+		conflicted := map[string]bool{}
+		for _, r := range td.Conflicts {
+			conflicted[r.LeftID], conflicted[r.RightID] = true, true
+		}
+		for i := range td.Events {
+			td.Events[i].Conflict = conflicted[td.Events[i].ID]
+		}
+		td.Busy = busyMinutes(busy)
+		days = append(days, td)
+	}
+	return days
+}
+
+func busyMinutes(items []contract.Event) int64 {
+	sorted := append([]contract.Event(nil), items...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+	var total time.Duration
+	var curStart, curEnd time.Time
+	for i, ev := range sorted {
+		if i > 0 && !ev.Start.After(curEnd) {
+			curEnd = maxTime(curEnd, ev.End)
+			continue
+		}
+		total += curEnd.Sub(curStart)
+		curStart, curEnd = ev.Start, ev.End
+	}
+	total += curEnd.Sub(curStart)
+	return int64(total.Minutes())
+}
+
+func timelineHours(days []timelineDay) (int, int) {
+	from, to := 8, 18
+	for _, d := range days {
+		for _, ev := range d.Events {
+			midnight, _ := dayBounds(ev.Start)
+			from = min(from, ev.Start.Hour())
+			to = max(to, int((ev.End.Sub(midnight)+time.Hour-1)/time.Hour))
+		}
+	}
+	return from, to
+}
+
+const (
+	timelineFree     = '.'
+	timelineOpen     = '-'
+	timelineBooked   = '#'
+	timelineConflict = '!'
+)
+
+type timelineColors struct {
+	theme     output.Theme
+	calendars map[string]string
+}
+
+func newTimelineColors(theme output.Theme, defaults map[string]calendarDefaults) (*timelineColors, error) {
+	tc := &timelineColors{theme: theme, calendars: map[string]string{}}
+	for name, d := range defaults {
+		if strings.TrimSpace(d.Color) == "" {
+			continue
+		}
+		style, err := output.ParseColor(d.Color)
+		if err != nil {
+			return nil, fmt.Errorf("calendar_defaults.%s: %w", name, err)
+		}
+		tc.calendars[strings.ToLower(name)] = style
+	}
+	return tc, nil
+}
+
+type timelineCell struct {
+	glyph    rune
+	calendar string
+}
+
+func (tc *timelineColors) style(c timelineCell) string {
+	switch c.glyph {
+	case timelineConflict:
+		return tc.theme.Conflict
+	case timelineOpen:
+		return tc.theme.Open
+	case timelineBooked:
+		if style, ok := tc.calendars[strings.ToLower(c.calendar)]; ok {
+			return style
+		}
+		return tc.theme.Busy
+	}
+	return ""
+}
+
+func renderTimeline(w io.Writer, days []timelineDay, loc *time.Location, colors *timelineColors) {
+	from, to := timelineHours(days)
+	step := 15 * time.Minute
+	if to-from > 12 {
+		step = 30 * time.Minute
+	}
+	perHour := int(time.Hour / step)
+	var b strings.Builder
+	label := strings.Repeat(" ", 11)
+	var header strings.Builder
+	header.WriteString(label)
+	every := 1
+	if perHour < 3 {
+		every = 2
+	}
+	for h := from; h < to; h += every {
+		fmt.Fprintf(&header, "%-*s", perHour*every, fmt.Sprintf("%02d", h))
+	}
+	b.WriteString(strings.TrimRight(header.String(), " "))
+	b.WriteString("\n")
+	for _, d := range days {
+		day, _ := time.ParseInLocation("2006-01-02", d.Date, loc)
+		origin := day.Add(time.Duration(from) * time.Hour)
+		cells := make([]timelineCell, (to-from)*perHour)
+		for i := range cells {
+			cs, ce := origin.Add(time.Duration(i)*step), origin.Add(time.Duration(i+1)*step)
+			busy, open, calendar := 0, false, ""
+			for _, ev := range d.Events {
+				if ev.Start.Before(ce) && ev.End.After(cs) {
+					if ev.Busy {
+						busy++
+						calendar = ev.Calendar
+					} else {
+						open = true
+					}
+				}
+			}
+			switch {
+			case busy > 1:
+				cells[i] = timelineCell{glyph: timelineConflict}
+			case busy == 1:
+				cells[i] = timelineCell{glyph: timelineBooked, calendar: calendar}
+			case open:
+				cells[i] = timelineCell{glyph: timelineOpen}
+			default:
+				cells[i] = timelineCell{glyph: timelineFree}
+			}
+		}
+		fmt.Fprintf(&b, "%s |%s| %s\n", day.Format("Mon 01-02"), timelineBar(cells, colors), timelineSummary(d))
+		for _, r := range d.Conflicts {
+			fmt.Fprintf(&b, "%s ! %s-%s %s / %s\n", label, r.OverlapStart.In(loc).Format("15:04"), r.OverlapEnd.In(loc).Format("15:04"), r.LeftTitle, r.RightTitle)
+		}
+	}
+	fmt.Fprintf(&b, "%s %c open  %c free or cancelled event  %c busy  %c conflict\n", label, timelineFree, timelineOpen, timelineBooked, timelineConflict)
+	_, _ = io.WriteString(w, b.String())
+}
+
+func timelineBar(cells []timelineCell, colors *timelineColors) string {
+	var b strings.Builder
+	for i := 0; i < len(cells); {
+		j := i
+		for j < len(cells) && cells[j] == cells[i] {
+			j++
+		}
+		run := strings.Repeat(string(cells[i].glyph), j-i)
+		if colors != nil {
+			run = output.Paint(colors.style(cells[i]), run)
+		}
+		b.WriteString(run)
+		i = j
+	}
+	return b.String()
+}
+
+func timelineSummary(d timelineDay) string {
+	parts := []string{fmt.Sprintf("events=%d", len(d.Events)), "busy=" + formatMinutes(d.Busy)}
+	if d.AllDay > 0 {
+		parts = append(parts, fmt.Sprintf("all_day=%d", d.AllDay))
+	}
+	if len(d.Conflicts) > 0 {
+		parts = append(parts, fmt.Sprintf("conflicts=%d", len(d.Conflicts)))
+	}
+	return strings.Join(parts, " ")
+}
+
+func successTimeline(ctx context.Context, p output.Printer, ro *globalOptions, days []timelineDay, loc *time.Location, meta map[string]any, warnings []contract.Warning) error {
+	events, conflicts := 0, 0
+	for _, d := range days {
+		events += len(d.Events)
+		conflicts += len(d.Conflicts)
+	}
+	meta["count"], meta["events"], meta["conflicts"], meta["timeline"] = len(days), events, conflicts, true
+	if p.EffectiveSuccessMode() == output.ModePlain {
+		colors, err := newTimelineColors(p.Palette(), ro.CalendarDefaults)
+		if err != nil {
+			return failWithHint(p, contract.ErrInvalidUsage, err, "Use a color name, a 256-color index, or #rrggbb", 2)
+		}
+		if !p.OutColors() {
+			colors = nil
+		}
+		p.PrintWarnings(commandWarnings(ctx, warnings))
+		if !p.Quiet {
+			renderTimeline(p.Out, days, loc, colors)
+		}
+		return nil
+	}
+	return successWithMeta(ctx, p, ro, days, meta, warnings)
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/backend"
+	"github.com/agis/acal/internal/contract"
+	"github.com/agis/acal/internal/output"
+)
+
+func TestBuildTimelineMarksConflicts(t *testing.T) {
+	day := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	items := []contract.Event{
+		{ID: "a", Title: "Standup", Start: at(9, 0), End: at(10, 0)},
+		{ID: "b", Title: "Review", Start: at(9, 30), End: at(10, 30)},
+		{ID: "c", Title: "Focus", Start: at(11, 0), End: at(12, 0), Availability: contract.AvailabilityFree},
+		{ID: "d", Title: "Late", Start: at(23, 0), End: at(25, 0)},
+		{ID: "e", Title: "Offsite", Start: day, End: day.AddDate(0, 0, 1), AllDay: true},
+	}
+	days := buildTimeline(items, day, day.AddDate(0, 0, 2), time.UTC)
+	if len(days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(days))
+	}
+	first := days[0]
+	if len(first.Events) != 4 || first.AllDay != 1 || len(first.Conflicts) != 1 {
+		t.Fatalf("unexpected first day: %+v", first)
+	}
+	if !first.Events[0].Conflict || !first.Events[1].Conflict || first.Events[2].Conflict || first.Events[2].Busy {
+		t.Fatalf("unexpected conflict marks: %+v", first.Events)
+	}
+	if first.Busy != 150 {
+		t.Fatalf("expected 150 busy minutes, got %d", first.Busy)
+	}
+	if second := days[1]; len(second.Events) != 1 || !second.Events[0].Start.Equal(at(24, 0)) {
+		t.Fatalf("expected the late event clipped into the second day: %+v", second)
+	}
+
+	var out bytes.Buffer
+	renderTimeline(&out, days[:1], time.UTC, nil)
+	lines := strings.Split(out.String(), "\n")
+	if !strings.HasPrefix(lines[1], "Tue 03-03 |") || !strings.Contains(lines[1], "..#!#.") || !strings.Contains(lines[1], "conflicts=1") {
+		t.Fatalf("unexpected bar:\n%s", out.String())
+	}
+	if !strings.Contains(lines[2], "! 09:30-10:00 Standup / Review") {
+		t.Fatalf("expected conflict line:\n%s", out.String())
+	}
+}
+
+func TestWeekTimelineJSON(t *testing.T) {
+	start := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)
+	fb := backend.NewMockBackend(backend.MockFixture{
+		Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}},
+		Events: []contract.Event{
+			{ID: "a", CalendarID: "work", CalendarName: "Work", Title: "Standup", Start: start, End: start.Add(time.Hour)},
+			{ID: "b", CalendarID: "work", CalendarName: "Work", Title: "Review", Start: start.Add(30 * time.Minute), End: start.Add(90 * time.Minute)},
+		},
+	})
+	var env struct {
+		Data []timelineDay  `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	out := runWithBackend(t, fb, "week", "--of", "2026-03-03", "--week-start", "monday", "--timeline", "--tz", "UTC", "--json")
+	if err := json.Unmarshal(out, &env); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, out)
+	}
+	if len(env.Data) != 7 || env.Meta["conflicts"] != float64(1) || env.Meta["events"] != float64(2) {
+		t.Fatalf("unexpected timeline: meta=%v days=%d", env.Meta, len(env.Data))
+	}
+	if got := env.Data[1]; got.Date != "2026-03-03" || len(got.Conflicts) != 1 {
+		t.Fatalf("unexpected tuesday: %+v", got)
+	}
+}
+
+func TestRenderTimelineColorsByThemeAndCalendar(t *testing.T) {
+	day := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	items := []contract.Event{
+		{ID: "a", CalendarName: "Work", Title: "Standup", Start: at(9), End: at(11)},
+		{ID: "b", CalendarName: "Home", Title: "Call", Start: at(10), End: at(12)},
+		{ID: "c", CalendarName: "Gym", Title: "Run", Start: at(14), End: at(15)},
+	}
+	theme, _ := output.LookupTheme("default")
+	colors, err := newTimelineColors(theme, map[string]calendarDefaults{"work": {Color: "green"}, "Home": {Duration: "30m"}})
+	if err != nil {
+		t.Fatalf("newTimelineColors failed: %v", err)
+	}
+	var out bytes.Buffer
+	renderTimeline(&out, buildTimeline(items, day, day.AddDate(0, 0, 1), time.UTC), time.UTC, colors)
+	got := out.String()
+	for _, want := range []string{"\x1b[32m####\x1b[0m", "\x1b[1;31m!!!!\x1b[0m", "\x1b[34m####\x1b[0m"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in:\n%q", want, got)
+		}
+	}
+
+	if _, err := newTimelineColors(theme, map[string]calendarDefaults{"Work": {Color: "teal"}}); err == nil {
+		t.Fatalf("expected error for invalid calendar color")
+	}
+}
+
+func TestTimelineThemeAndCalendarColorValidation(t *testing.T) {
+	fb := backend.NewMockBackend(backend.MockFixture{Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}}})
+	if code := runEventsCmd(t, fb, "today", "--timeline", "--theme", "neon", "--plain"); code != 2 {
+		t.Fatalf("expected exit 2 for unknown theme, got %d", code)
+	}
+	t.Setenv("ACAL_THEME", "mono")
+	if code := runEventsCmd(t, fb, "today", "--timeline", "--plain"); code != 0 {
+		t.Fatalf("expected exit 0 with ACAL_THEME=mono, got %d", code)
+	}
+	cfg := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(cfg, []byte("[calendar_defaults.Work]\ncolor = \"teal\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code := runEventsCmd(t, fb, "today", "--timeline", "--config", cfg, "--plain"); code != 2 {
+		t.Fatalf("expected exit 2 for invalid calendar color, got %d", code)
+	}
+}
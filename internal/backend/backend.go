@@ -2,11 +2,22 @@ package backend
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/agis/acal/internal/contract"
 )
 
+var (
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrBackendUnavailable = errors.New("backend unavailable")
+	ErrUnsupportedSchema  = errors.New("unsupported calendar database schema")
+)
+
+// EventFilter selects events in [From, To); Overlap also matches events running at From.
 type EventFilter struct {
 	Calendars []string
 	From      time.Time
@@ -14,6 +25,31 @@ type EventFilter struct {
 	Limit     int
 	Query     string
 	Field     string
+	Overlap   bool
+	// Fields lists what the caller reads; backends may skip other bulky fields.
+	Fields []string
+}
+
+func (f EventFilter) Wants(field string) bool {
+	if len(f.Fields) == 0 {
+		return true
+	}
+	for _, v := range f.Fields {
+		if strings.EqualFold(v, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f EventFilter) includes(start, end time.Time) bool {
+	if !start.Before(f.To) {
+		return false
+	}
+	if !start.Before(f.From) {
+		return true
+	}
+	return f.Overlap && end.After(f.From)
 }
 
 type RecurrenceScope string
@@ -34,6 +70,9 @@ type EventCreateInput struct {
 	Notes          string
 	URL            string
 	AllDay         bool
+	Status         string
+	Availability   string
+	Sensitivity    string
 	ReminderOffset *time.Duration
 	RepeatRule     string
 }
@@ -46,10 +85,15 @@ type EventUpdateInput struct {
 	Notes          *string
 	URL            *string
 	AllDay         *bool
+	Status         *string
+	Availability   *string
+	Sensitivity    *string
 	Scope          RecurrenceScope
 	ReminderOffset *time.Duration
-	ClearReminder  bool
-	RepeatRule     *string
+	// ReminderAt replaces the alarms with one at a fixed time.
+	ReminderAt    *time.Time
+	ClearReminder bool
+	RepeatRule    *string
 }
 
 type Backend interface {
@@ -62,3 +106,51 @@ type Backend interface {
 	UpdateEvent(context.Context, string, EventUpdateInput) (*contract.Event, error)
 	DeleteEvent(context.Context, string, RecurrenceScope) error
 }
+
+type EventStreamer interface {
+	StreamEvents(ctx context.Context, f EventFilter, emit func(contract.Event) error) error
+}
+
+type ChangeStamper interface {
+	ChangeStamp(context.Context) (string, error)
+}
+
+func FilterEvents(events []contract.Event, f EventFilter) []contract.Event {
+	items := []contract.Event{}
+	for _, e := range events {
+		if !f.includes(e.Start, e.End) {
+			continue
+		}
+		if len(f.Calendars) > 0 && !containsFold(f.Calendars, e.CalendarID) && !containsFold(f.Calendars, e.CalendarName) {
+			continue
+		}
+		if !matchesEventQuery(e, f.Query, f.Field) {
+			continue
+		}
+		items = append(items, e)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Start.Before(items[j].Start) })
+	if f.Limit > 0 && len(items) > f.Limit {
+		items = items[:f.Limit]
+	}
+	return items
+}
+
+func resolveRecurrenceScope(scope RecurrenceScope, occurrence int64) (RecurrenceScope, error) {
+	switch scope {
+	case "", ScopeAuto:
+		if occurrence > 0 {
+			return ScopeThis, nil
+		}
+		return ScopeSeries, nil
+	case ScopeThis, ScopeFuture:
+		if occurrence <= 0 {
+			return "", fmt.Errorf("scope %q requires an occurrence event id (<uid>@<occurrence>)", scope)
+		}
+		return scope, nil
+	case ScopeSeries:
+		return ScopeSeries, nil
+	default:
+		return "", fmt.Errorf("invalid recurrence scope: %q", scope)
+	}
+}
@@ -0,0 +1,158 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+const (
+	BirthdaysCalendarID   = "acal-birthdays"
+	BirthdaysCalendarName = "Birthdays"
+	// Contacts stores birthdays without a year in 1604.
+	contactsYearless = 1604
+)
+
+type Birthday struct {
+	ContactID string     `json:"contact_id"`
+	Name      string     `json:"name"`
+	Month     time.Month `json:"month"`
+	Day       int        `json:"day"`
+	Year      int        `json:"year,omitempty"`
+}
+
+type BirthdayLister interface {
+	ListBirthdays(context.Context) ([]Birthday, error)
+}
+
+func BirthdaysCalendar() contract.Calendar {
+	return contract.Calendar{ID: BirthdaysCalendarID, Name: BirthdaysCalendarName, Writable: false, Source: "contacts"}
+}
+
+func BirthdayEvents(items []Birthday, from, to time.Time, loc *time.Location) []contract.Event {
+	out := []contract.Event{}
+	for _, b := range items {
+		for year := from.In(loc).Year(); year <= to.In(loc).Year(); year++ {
+			start := birthdayDate(year, b.Month, b.Day, loc)
+			end := start.AddDate(0, 0, 1)
+			if !end.After(from) || !start.Before(to) {
+				continue
+			}
+			e := contract.Event{
+				ID:           fmt.Sprintf("birthday:%s@%s", b.ContactID, start.Format("20060102")),
+				CalendarID:   BirthdaysCalendarID,
+				CalendarName: BirthdaysCalendarName,
+				Title:        b.Name + "'s Birthday",
+				Start:        start,
+				End:          end,
+				AllDay:       true,
+				Source:       "contacts",
+			}
+			if b.Year > 0 && year > b.Year {
+				e.Notes = fmt.Sprintf("Turns %d", year-b.Year)
+			}
+			out = append(out, e)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].Start.Equal(out[j].Start) {
+			return out[i].Start.Before(out[j].Start)
+		}
+		return out[i].Title < out[j].Title
+	})
+	return out
+}
+
+func birthdayDate(year int, month time.Month, day int, loc *time.Location) time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if d.Month() != month {
+		// Feb 29 birthdays land on Feb 28 in common years.
+		d = time.Date(year, month+1, 0, 0, 0, 0, 0, loc)
+	}
+	return d
+}
+
+// contactsBirthdayDate rounds local-midnight values from older macOS to the nearest UTC day.
+func contactsBirthdayDate(unix int64) time.Time {
+	d := time.Unix(unix, 0).UTC()
+	if d.Hour() == 12 && d.Minute() == 0 && d.Second() == 0 {
+		return d
+	}
+	return d.Add(12 * time.Hour).Truncate(24 * time.Hour)
+}
+
+func findContactsDBs() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	base := filepath.Join(home, "Library", "Application Support", "AddressBook")
+	paths, _ := filepath.Glob(filepath.Join(base, "Sources", "*", "AddressBook-v22.abcddb"))
+	if _, err := os.Stat(filepath.Join(base, "AddressBook-v22.abcddb")); err == nil {
+		paths = append([]string{filepath.Join(base, "AddressBook-v22.abcddb")}, paths...)
+	}
+	return paths
+}
+
+const contactsBirthdaysQuery = `
+SELECT
+  COALESCE(ZUNIQUEID, CAST(Z_PK AS TEXT)) AS id,
+  TRIM(COALESCE(ZFIRSTNAME, '') || ' ' || COALESCE(ZLASTNAME, '')) AS name,
+  COALESCE(ZORGANIZATION, '') AS org,
+  CAST(ZBIRTHDAY AS INTEGER) + %d AS birthday_unix
+FROM ZABCDRECORD
+WHERE ZBIRTHDAY IS NOT NULL;
+`
+
+func listBirthdaysViaSQLite(ctx context.Context, dbPaths []string) ([]Birthday, error) {
+	seen := map[string]bool{}
+	out := []Birthday{}
+	for _, path := range dbPaths {
+		db, release, err := openCalendarReadDB(path)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := db.QueryContext(ctx, fmt.Sprintf(contactsBirthdaysQuery, cocoaEpochOffset))
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for rows.Next() {
+			var id, name, org string
+			var unix int64
+			if err := rows.Scan(&id, &name, &org, &unix); err != nil {
+				rows.Close()
+				release()
+				return nil, err
+			}
+			id = strings.TrimSpace(id)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if name == "" {
+				name = org
+			}
+			d := contactsBirthdayDate(unix)
+			b := Birthday{ContactID: id, Name: name, Month: d.Month(), Day: d.Day()}
+			if d.Year() != contactsYearless {
+				b.Year = d.Year()
+			}
+			out = append(out, b)
+		}
+		err = rows.Err()
+		rows.Close()
+		release()
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
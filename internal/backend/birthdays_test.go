@@ -0,0 +1,77 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBirthdayEventsExpandsAcrossYears(t *testing.T) {
+	items := []Birthday{
+		{ContactID: "c1", Name: "Ada", Month: time.December, Day: 31, Year: 1990},
+		{ContactID: "c2", Name: "Leap", Month: time.February, Day: 29},
+	}
+	from := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
+	got := BirthdayEvents(items, from, to, time.UTC)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 occurrences, got %+v", got)
+	}
+	if got[0].ID != "birthday:c1@20261231" || got[0].Title != "Ada's Birthday" || got[0].Notes != "Turns 36" || !got[0].AllDay || got[0].CalendarName != BirthdaysCalendarName {
+		t.Fatalf("unexpected first birthday: %+v", got[0])
+	}
+	if !got[1].Start.Equal(time.Date(2027, 2, 28, 0, 0, 0, 0, time.UTC)) || got[1].Notes != "" {
+		t.Fatalf("expected Feb 29 birthday on Feb 28 in a common year, got %+v", got[1])
+	}
+}
+
+func TestListBirthdaysViaSQLite(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "AddressBook-v22.abcddb")
+	db, err := sql.Open("sqlite", "file:"+dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmts := []string{
+		`CREATE TABLE ZABCDRECORD (Z_PK INTEGER PRIMARY KEY, ZUNIQUEID TEXT, ZFIRSTNAME TEXT, ZLASTNAME TEXT, ZORGANIZATION TEXT, ZBIRTHDAY REAL)`,
+		`INSERT INTO ZABCDRECORD VALUES (1, 'u1', 'Ada', 'Lovelace', NULL, ` + cocoaDate(1990, 12, 10) + `)`,
+		`INSERT INTO ZABCDRECORD VALUES (2, 'u2', NULL, NULL, 'Acme', ` + cocoaDate(contactsYearless, 4, 2) + `)`,
+		`INSERT INTO ZABCDRECORD VALUES (3, 'u3', 'No', 'Birthday', NULL, NULL)`,
+	}
+	for _, s := range stmts {
+		if _, err := db.Exec(s); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+	_ = db.Close()
+
+	got, err := listBirthdaysViaSQLite(context.Background(), []string{dbPath, dbPath})
+	if err != nil {
+		t.Fatalf("listBirthdaysViaSQLite failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 deduplicated birthdays, got %+v", got)
+	}
+	if got[0] != (Birthday{ContactID: "u2", Name: "Acme", Month: time.April, Day: 2}) {
+		t.Fatalf("unexpected yearless birthday: %+v", got[0])
+	}
+	if got[1] != (Birthday{ContactID: "u1", Name: "Ada Lovelace", Month: time.December, Day: 10, Year: 1990}) {
+		t.Fatalf("unexpected birthday: %+v", got[1])
+	}
+}
+
+func cocoaDate(year int, month time.Month, day int) string {
+	return strconv.FormatInt(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix()-cocoaEpochOffset, 10)
+}
+
+func TestContactsBirthdayDateHandlesStoredTimes(t *testing.T) {
+	// 1990-12-10 as stored at noon UTC, midnight PST and midnight CET.
+	for _, cocoa := range []int64{-317476800, -317491200, -317523600} {
+		d := contactsBirthdayDate(cocoa + cocoaEpochOffset)
+		if d.Year() != 1990 || d.Month() != time.December || d.Day() != 10 {
+			t.Fatalf("stored %d: expected 1990-12-10, got %s", cocoa, d)
+		}
+	}
+}
@@ -0,0 +1,1106 @@
+package backend
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+type CalDAVConfig struct {
+	URL      string
+	User     string
+	Password string
+	Client   *http.Client
+}
+
+type CalDAVBackend struct {
+	cfg    CalDAVConfig
+	client *http.Client
+}
+
+func NewCalDAVBackend(cfg CalDAVConfig) *CalDAVBackend {
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &CalDAVBackend{cfg: cfg, client: client}
+}
+
+type calDAVResource struct {
+	URL      string
+	ETag     string
+	Data     string
+	Calendar contract.Calendar
+}
+
+type davMultistatus struct {
+	Responses []davResponse `xml:"response"`
+}
+
+type davResponse struct {
+	Href      string        `xml:"href"`
+	Propstats []davPropstat `xml:"propstat"`
+}
+
+type davPropstat struct {
+	Prop   davProp `xml:"prop"`
+	Status string  `xml:"status"`
+}
+
+type davProp struct {
+	DisplayName  string `xml:"displayname"`
+	ResourceType struct {
+		Calendar    *struct{} `xml:"calendar"`
+		Shared      *struct{} `xml:"shared"`
+		SharedOwner *struct{} `xml:"shared-owner"`
+	} `xml:"resourcetype"`
+	Privileges []struct {
+		Write        *struct{} `xml:"write"`
+		WriteContent *struct{} `xml:"write-content"`
+		All          *struct{} `xml:"all"`
+	} `xml:"current-user-privilege-set>privilege"`
+	Components []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"supported-calendar-component-set>comp"`
+	ETag         string `xml:"getetag"`
+	CalendarData string `xml:"calendar-data"`
+}
+
+func (b *CalDAVBackend) Doctor(ctx context.Context) ([]contract.DoctorCheck, error) {
+	checks := []contract.DoctorCheck{}
+	if strings.TrimSpace(b.cfg.URL) == "" {
+		checks = append(checks, contract.DoctorCheck{Name: "caldav_config", Status: "fail", Message: "caldav url not configured"})
+		return checks, fmt.Errorf("caldav url not configured")
+	}
+	checks = append(checks, contract.DoctorCheck{Name: "caldav_config", Status: "ok", Message: "caldav url configured"})
+
+	cals, err := b.ListCalendars(ctx)
+	if err != nil {
+		checks = append(checks, contract.DoctorCheck{Name: "caldav_access", Status: "fail", Message: err.Error()})
+		return checks, err
+	}
+	checks = append(checks, contract.DoctorCheck{Name: "caldav_access", Status: "ok", Message: "CalDAV server reachable"})
+	if len(cals) == 0 {
+		checks = append(checks, contract.DoctorCheck{Name: "caldav_calendars", Status: "warn", Message: "no calendars found at caldav url"})
+		return checks, nil
+	}
+	checks = append(checks, contract.DoctorCheck{Name: "caldav_calendars", Status: "ok", Message: fmt.Sprintf("%d calendars found", len(cals))})
+	return checks, nil
+}
+
+func (b *CalDAVBackend) ListCalendars(ctx context.Context) ([]contract.Calendar, error) {
+	body := `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop>
+    <d:displayname/>
+    <d:resourcetype/>
+    <d:current-user-privilege-set/>
+    <c:supported-calendar-component-set/>
+  </d:prop>
+</d:propfind>`
+	ms, err := b.multistatus(ctx, "PROPFIND", b.cfg.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	account := b.cfg.URL
+	if u, err := url.Parse(b.cfg.URL); err == nil && u.Host != "" {
+		account = u.Host
+	}
+	items := make([]contract.Calendar, 0, len(ms.Responses))
+	for _, r := range ms.Responses {
+		prop := okProp(r)
+		if prop.ResourceType.Calendar == nil || !supportsVEvent(prop) {
+			continue
+		}
+		href := b.resolve(r.Href)
+		name := strings.TrimSpace(prop.DisplayName)
+		if name == "" {
+			name = lastPathSegment(href)
+		}
+		items = append(items, contract.Calendar{
+			ID:          href,
+			Name:        name,
+			Writable:    isWritable(prop),
+			Account:     account,
+			AccountType: "caldav",
+			Shared:      prop.ResourceType.Shared != nil || prop.ResourceType.SharedOwner != nil,
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items, nil
+}
+
+func (b *CalDAVBackend) ListEvents(ctx context.Context, f EventFilter) ([]contract.Event, error) {
+	if f.From.IsZero() || f.To.IsZero() {
+		return nil, fmt.Errorf("from/to required")
+	}
+	if f.To.Before(f.From) {
+		return nil, fmt.Errorf("invalid time range")
+	}
+	cals, err := b.ListCalendars(ctx)
+	if err != nil {
+		return nil, err
+	}
+	start := f.From.UTC().Format(icsUTCLayout)
+	end := f.To.UTC().Format(icsUTCLayout)
+	body := fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop>
+    <d:getetag/>
+    <c:calendar-data><c:expand start="%s" end="%s"/></c:calendar-data>
+  </d:prop>
+  <c:filter>
+    <c:comp-filter name="VCALENDAR">
+      <c:comp-filter name="VEVENT"><c:time-range start="%s" end="%s"/></c:comp-filter>
+    </c:comp-filter>
+  </c:filter>
+</c:calendar-query>`, start, end, start, end)
+
+	items := []contract.Event{}
+	for _, cal := range cals {
+		if len(f.Calendars) > 0 && !containsFold(f.Calendars, cal.ID) && !containsFold(f.Calendars, cal.Name) {
+			continue
+		}
+		ms, err := b.multistatus(ctx, "REPORT", cal.ID, body)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range ms.Responses {
+			data := okProp(r).CalendarData
+			if strings.TrimSpace(data) == "" {
+				continue
+			}
+			vcal, err := parseICSCalendar(data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", b.resolve(r.Href), err)
+			}
+			for _, ve := range vcal.children("VEVENT") {
+				e, err := eventFromVEvent(ve, cal)
+				if err != nil {
+					return nil, err
+				}
+				if !f.includes(e.Start, e.End) {
+					continue
+				}
+				if !matchesEventQuery(e, f.Query, f.Field) {
+					continue
+				}
+				items = append(items, e)
+			}
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Start.Equal(items[j].Start) {
+			return items[i].ID < items[j].ID
+		}
+		return items[i].Start.Before(items[j].Start)
+	})
+	if f.Limit > 0 && len(items) > f.Limit {
+		items = items[:f.Limit]
+	}
+	return items, nil
+}
+
+func (b *CalDAVBackend) GetEventByID(ctx context.Context, id string) (*contract.Event, error) {
+	uid, occ := parseCalDAVEventID(id)
+	if uid == "" {
+		return nil, fmt.Errorf("invalid event id")
+	}
+	res, err := b.findResource(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	vcal, err := parseICSCalendar(res.Data)
+	if err != nil {
+		return nil, err
+	}
+	return occurrenceEvent(vcal, uid, occ, res.Calendar)
+}
+
+func (b *CalDAVBackend) GetReminderOffset(ctx context.Context, id string) (*time.Duration, error) {
+	uid, occ := parseCalDAVEventID(id)
+	if uid == "" {
+		return nil, fmt.Errorf("invalid event id")
+	}
+	res, err := b.findResource(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	vcal, err := parseICSCalendar(res.Data)
+	if err != nil {
+		return nil, err
+	}
+	ve := findVEvent(vcal, occ)
+	if ve == nil {
+		ve = findVEvent(vcal, 0)
+	}
+	if ve == nil {
+		return nil, errors.New("event not found")
+	}
+	alarms := ve.children("VALARM")
+	if len(alarms) == 0 {
+		return nil, nil
+	}
+	trigger, _ := alarms[0].prop("TRIGGER")
+	if strings.EqualFold(trigger.Params["VALUE"], "DATE-TIME") {
+		at, _, err := parseICSTime(trigger)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reminder trigger: %w", err)
+		}
+		dtstart, _ := ve.prop("DTSTART")
+		start, _, err := parseICSTime(dtstart)
+		if err != nil {
+			return nil, err
+		}
+		d := at.Sub(start)
+		return &d, nil
+	}
+	d, err := parseICSDuration(trigger.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reminder trigger: %w", err)
+	}
+	return &d, nil
+}
+
+func (b *CalDAVBackend) AddEvent(ctx context.Context, in EventCreateInput) (*contract.Event, error) {
+	if strings.TrimSpace(in.Calendar) == "" || strings.TrimSpace(in.Title) == "" {
+		return nil, fmt.Errorf("calendar and title required")
+	}
+	if in.Start.IsZero() || in.End.IsZero() || !in.End.After(in.Start) {
+		return nil, fmt.Errorf("invalid start/end")
+	}
+	cal, err := b.findCalendar(ctx, in.Calendar)
+	if err != nil {
+		return nil, err
+	}
+	rrule, err := repeatRuleToRRULE(in.RepeatRule)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := newCalDAVUID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	ve := &icsComponent{Name: "VEVENT", Props: []string{
+		"UID:" + uid,
+		"DTSTAMP:" + now.Format(icsUTCLayout),
+		"CREATED:" + now.Format(icsUTCLayout),
+		"LAST-MODIFIED:" + now.Format(icsUTCLayout),
+		formatICSTimeProp("DTSTART", in.Start, in.AllDay),
+		formatICSTimeProp("DTEND", in.End, in.AllDay),
+		"SEQUENCE:0",
+	}}
+	ve.setText("SUMMARY", in.Title)
+	ve.setText("LOCATION", in.Location)
+	ve.setText("DESCRIPTION", in.Notes)
+	if in.URL != "" {
+		ve.setProp("URL", "URL:"+in.URL)
+	}
+	setVEventStatus(ve, in.Status)
+	setVEventAvailability(ve, in.Availability)
+	setVEventSensitivity(ve, in.Sensitivity)
+	if rrule != "" {
+		ve.setProp("RRULE", "RRULE:"+rrule)
+	}
+	if in.ReminderOffset != nil {
+		ve.Children = append(ve.Children, newVAlarm(*in.ReminderOffset))
+	}
+	vcal := &icsComponent{Name: "VCALENDAR", Props: []string{"VERSION:2.0", "PRODID:" + icsDefaultProduct}, Children: []*icsComponent{ve}}
+
+	target := strings.TrimSuffix(cal.ID, "/") + "/" + url.PathEscape(uid) + ".ics"
+	if err := b.put(ctx, target, vcal.String(), "", true); err != nil {
+		return nil, err
+	}
+	e, err := eventFromVEvent(ve, cal)
+	if err != nil {
+		return nil, err
+	}
+	if rrule != "" {
+		e.ID = fmt.Sprintf("%s@%d", uid, in.Start.Unix())
+	}
+	return &e, nil
+}
+
+func (b *CalDAVBackend) UpdateEvent(ctx context.Context, id string, in EventUpdateInput) (*contract.Event, error) {
+	return b.editVEvent(ctx, id, in.Scope, "updates", func(target *icsComponent, series bool) error {
+		return applyVEventPatch(target, in, series)
+	})
+}
+
+func (b *CalDAVBackend) RespondToEvent(ctx context.Context, id string, in RSVPInput) (*RSVPResult, error) {
+	partstat, ok := RSVPResponses[in.Response]
+	if !ok {
+		return nil, fmt.Errorf("invalid response %q: use accept, decline, or tentative", in.Response)
+	}
+	addr := strings.ToLower(strings.TrimSpace(in.Attendee))
+	if addr == "" && strings.Contains(b.cfg.User, "@") {
+		addr = strings.ToLower(b.cfg.User)
+	}
+	if addr == "" {
+		return nil, errors.New("caldav user is not an email address; pass the address to answer as")
+	}
+	res := &RSVPResult{Attendee: addr, Response: in.Response, Comment: in.Comment}
+	e, err := b.editVEvent(ctx, id, in.Scope, "responses", func(target *icsComponent, _ bool) error {
+		previous, err := rsvpAttendee(target, addr, partstat, in.Comment)
+		res.Previous = previous
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Event = *e
+	return res, nil
+}
+
+func (b *CalDAVBackend) editVEvent(ctx context.Context, id string, scope RecurrenceScope, what string, edit func(target *icsComponent, series bool) error) (*contract.Event, error) {
+	uid, occ := parseCalDAVEventID(id)
+	if uid == "" {
+		return nil, fmt.Errorf("invalid event id")
+	}
+	scope, err := resolveRecurrenceScope(scope, occ)
+	if err != nil {
+		return nil, err
+	}
+	if scope == ScopeFuture {
+		return nil, fmt.Errorf("scope %q is not supported by the caldav backend for %s; use --scope this or series", scope, what)
+	}
+	res, err := b.findResource(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	vcal, err := parseICSCalendar(res.Data)
+	if err != nil {
+		return nil, err
+	}
+	master := findVEvent(vcal, 0)
+	target := master
+	if scope == ScopeThis {
+		target = findVEvent(vcal, occ)
+		if target == nil {
+			if master == nil {
+				return nil, errors.New("event not found")
+			}
+			target, err = newOverride(master, occ)
+			if err != nil {
+				return nil, err
+			}
+			vcal.Children = append(vcal.Children, target)
+		}
+	}
+	if target == nil {
+		return nil, errors.New("event not found")
+	}
+	if err := edit(target, scope == ScopeSeries); err != nil {
+		return nil, err
+	}
+	if err := b.put(ctx, res.URL, vcal.String(), res.ETag, false); err != nil {
+		return nil, err
+	}
+	e, err := eventFromVEvent(target, res.Calendar)
+	if err != nil {
+		return nil, err
+	}
+	if occ > 0 && scope == ScopeThis {
+		e.ID = id
+	}
+	return &e, nil
+}
+
+func (b *CalDAVBackend) DeleteEvent(ctx context.Context, id string, scope RecurrenceScope) error {
+	uid, occ := parseCalDAVEventID(id)
+	if uid == "" {
+		return fmt.Errorf("invalid event id")
+	}
+	resolvedScope, err := resolveRecurrenceScope(scope, occ)
+	if err != nil {
+		return err
+	}
+	res, err := b.findResource(ctx, uid)
+	if err != nil {
+		return err
+	}
+	vcal, err := parseICSCalendar(res.Data)
+	if err != nil {
+		return err
+	}
+	master := findVEvent(vcal, 0)
+	if resolvedScope == ScopeSeries || master == nil {
+		return b.delete(ctx, res.URL, res.ETag)
+	}
+	start, allDay, err := parseICSTime(mustProp(master, "DTSTART"))
+	if err != nil {
+		return err
+	}
+	occTime := time.Unix(occ, 0)
+	if resolvedScope == ScopeFuture && !occTime.After(start) {
+		return b.delete(ctx, res.URL, res.ETag)
+	}
+	kept := vcal.Children[:0]
+	for _, c := range vcal.Children {
+		if c.Name == "VEVENT" && c != master {
+			recID := recurrenceUnix(c)
+			if recID == occ || (resolvedScope == ScopeFuture && recID > occ) {
+				continue
+			}
+		}
+		kept = append(kept, c)
+	}
+	vcal.Children = kept
+	if resolvedScope == ScopeFuture {
+		until := occTime.Add(-time.Second).UTC().Format(icsUTCLayout)
+		if allDay {
+			until = occTime.AddDate(0, 0, -1).In(start.Location()).Format(icsDateLayout)
+		}
+		if rrule := master.value("RRULE"); rrule != "" {
+			master.setProp("RRULE", "RRULE:"+truncateRRULE(rrule, until))
+		}
+	} else {
+		master.Props = append(master.Props, formatICSTimeProp("EXDATE", occTime.In(start.Location()), allDay))
+	}
+	touchVEvent(master)
+	return b.put(ctx, res.URL, vcal.String(), res.ETag, false)
+}
+
+func (b *CalDAVBackend) InspectSeries(ctx context.Context, uid string, from, to time.Time) (*Series, error) {
+	res, err := b.findResource(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	vcal, err := parseICSCalendar(res.Data)
+	if err != nil {
+		return nil, err
+	}
+	master := findVEvent(vcal, 0)
+	if master == nil {
+		return nil, errors.New("event not found")
+	}
+	e, err := eventFromVEvent(master, res.Calendar)
+	if err != nil {
+		return nil, err
+	}
+	s := &Series{
+		UID:            uid,
+		CalendarID:     e.CalendarID,
+		CalendarName:   e.CalendarName,
+		Title:          e.Title,
+		Start:          e.Start,
+		End:            e.End,
+		Rule:           master.value("RRULE"),
+		ExceptionDates: []time.Time{},
+		Occurrences:    []SeriesOccurrence{},
+	}
+	for _, line := range master.Props {
+		p := parseICSProp(line)
+		if p.Name != "EXDATE" {
+			continue
+		}
+		for _, v := range strings.Split(p.Value, ",") {
+			if t, _, err := parseICSTime(icsProp{Name: p.Name, Params: p.Params, Value: v}); err == nil {
+				s.ExceptionDates = append(s.ExceptionDates, t)
+			}
+		}
+	}
+	detached := map[int64]bool{}
+	for _, ve := range vcal.children("VEVENT") {
+		if occ := recurrenceUnix(ve); occ != 0 {
+			detached[occ] = true
+		}
+	}
+	items, err := b.ListEvents(ctx, EventFilter{Calendars: []string{res.Calendar.ID}, From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	for _, it := range items {
+		itemUID, occ := parseCalDAVEventID(it.ID)
+		if itemUID != uid {
+			continue
+		}
+		o := SeriesOccurrence{ID: it.ID, Start: it.Start, End: it.End, Detached: detached[occ]}
+		if o.Detached {
+			orig := time.Unix(occ, 0).In(it.Start.Location())
+			o.OriginalStart = &orig
+		}
+		s.Occurrences = append(s.Occurrences, o)
+	}
+	return s, nil
+}
+
+func (b *CalDAVBackend) findCalendar(ctx context.Context, name string) (contract.Calendar, error) {
+	cals, err := b.ListCalendars(ctx)
+	if err != nil {
+		return contract.Calendar{}, err
+	}
+	for _, c := range cals {
+		if c.ID == name || strings.EqualFold(c.Name, strings.TrimSpace(name)) {
+			if !c.Writable {
+				return contract.Calendar{}, fmt.Errorf("calendar is read-only: %s", c.Name)
+			}
+			return c, nil
+		}
+	}
+	return contract.Calendar{}, fmt.Errorf("calendar not found")
+}
+
+func (b *CalDAVBackend) findResource(ctx context.Context, uid string) (*calDAVResource, error) {
+	cals, err := b.ListCalendars(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body := fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop>
+    <d:getetag/>
+    <c:calendar-data/>
+  </d:prop>
+  <c:filter>
+    <c:comp-filter name="VCALENDAR">
+      <c:comp-filter name="VEVENT">
+        <c:prop-filter name="UID"><c:text-match collation="i;octet">%s</c:text-match></c:prop-filter>
+      </c:comp-filter>
+    </c:comp-filter>
+  </c:filter>
+</c:calendar-query>`, xmlEscape(uid))
+	for _, cal := range cals {
+		ms, err := b.multistatus(ctx, "REPORT", cal.ID, body)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range ms.Responses {
+			prop := okProp(r)
+			vcal, err := parseICSCalendar(prop.CalendarData)
+			if err != nil {
+				continue
+			}
+			for _, ve := range vcal.children("VEVENT") {
+				if ve.value("UID") == uid {
+					return &calDAVResource{URL: b.resolve(r.Href), ETag: prop.ETag, Data: prop.CalendarData, Calendar: cal}, nil
+				}
+			}
+		}
+	}
+	return nil, errors.New("event not found")
+}
+
+func (b *CalDAVBackend) multistatus(ctx context.Context, method, target, body string) (*davMultistatus, error) {
+	resp, data, err := b.do(ctx, method, target, map[string]string{
+		"Depth":        "1",
+		"Content-Type": "application/xml; charset=utf-8",
+	}, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, calDAVStatusError(method, target, resp.StatusCode)
+	}
+	var ms davMultistatus
+	if err := xml.Unmarshal(data, &ms); err != nil {
+		return nil, fmt.Errorf("caldav %s %s: invalid multistatus response: %w", method, target, err)
+	}
+	return &ms, nil
+}
+
+func (b *CalDAVBackend) put(ctx context.Context, target, ics, etag string, create bool) error {
+	headers := map[string]string{"Content-Type": "text/calendar; charset=utf-8"}
+	if create {
+		headers["If-None-Match"] = "*"
+	} else if etag != "" {
+		headers["If-Match"] = etag
+	}
+	resp, _, err := b.do(ctx, http.MethodPut, target, headers, ics)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	default:
+		return calDAVStatusError(http.MethodPut, target, resp.StatusCode)
+	}
+}
+
+func (b *CalDAVBackend) delete(ctx context.Context, target, etag string) error {
+	headers := map[string]string{}
+	if etag != "" {
+		headers["If-Match"] = etag
+	}
+	resp, _, err := b.do(ctx, http.MethodDelete, target, headers, "")
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return calDAVStatusError(http.MethodDelete, target, resp.StatusCode)
+	}
+}
+
+func (b *CalDAVBackend) do(ctx context.Context, method, target string, headers map[string]string, body string) (*http.Response, []byte, error) {
+	if strings.TrimSpace(target) == "" {
+		return nil, nil, fmt.Errorf("caldav url not configured")
+	}
+	var rd io.Reader
+	if body != "" {
+		rd = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, rd)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if b.cfg.User != "" || b.cfg.Password != "" {
+		req.SetBasicAuth(b.cfg.User, b.cfg.Password)
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		return nil, nil, fmt.Errorf("caldav %s %s failed: %w", method, target, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("caldav %s %s: read response: %w", method, target, err)
+	}
+	return resp, data, nil
+}
+
+func (b *CalDAVBackend) resolve(href string) string {
+	base, err := url.Parse(b.cfg.URL)
+	if err != nil {
+		return href
+	}
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return href
+	}
+	return base.ResolveReference(ref).String()
+}
+
+func calDAVStatusError(method, target string, status int) error {
+	switch status {
+	case http.StatusPreconditionFailed:
+		return fmt.Errorf("caldav %s %s: %w (event changed on server)", method, target, ErrPreconditionFailed)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("caldav %s %s: permission denied (status %d)", method, target, status)
+	case http.StatusNotFound:
+		return fmt.Errorf("caldav %s %s: not found", method, target)
+	default:
+		return fmt.Errorf("caldav %s %s: unexpected status %d", method, target, status)
+	}
+}
+
+func okProp(r davResponse) davProp {
+	var out davProp
+	for _, ps := range r.Propstats {
+		if ps.Status != "" && !strings.Contains(ps.Status, " 200") {
+			continue
+		}
+		p := ps.Prop
+		if p.DisplayName != "" {
+			out.DisplayName = p.DisplayName
+		}
+		if p.ResourceType.Calendar != nil {
+			out.ResourceType = p.ResourceType
+		}
+		if len(p.Privileges) > 0 {
+			out.Privileges = p.Privileges
+		}
+		if len(p.Components) > 0 {
+			out.Components = p.Components
+		}
+		if p.ETag != "" {
+			out.ETag = p.ETag
+		}
+		if p.CalendarData != "" {
+			out.CalendarData = p.CalendarData
+		}
+	}
+	return out
+}
+
+func supportsVEvent(p davProp) bool {
+	if len(p.Components) == 0 {
+		return true
+	}
+	for _, c := range p.Components {
+		if strings.EqualFold(c.Name, "VEVENT") {
+			return true
+		}
+	}
+	return false
+}
+
+func isWritable(p davProp) bool {
+	if len(p.Privileges) == 0 {
+		return true
+	}
+	for _, priv := range p.Privileges {
+		if priv.Write != nil || priv.WriteContent != nil || priv.All != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func lastPathSegment(href string) string {
+	u, err := url.Parse(href)
+	if err == nil {
+		href = u.Path
+	}
+	parts := strings.Split(strings.Trim(href, "/"), "/")
+	name, err := url.PathUnescape(parts[len(parts)-1])
+	if err != nil {
+		return parts[len(parts)-1]
+	}
+	return name
+}
+
+func parseCalDAVEventID(id string) (string, int64) {
+	id = strings.TrimSpace(id)
+	i := strings.LastIndex(id, "@")
+	if i < 0 {
+		return id, 0
+	}
+	occ, err := strconv.ParseInt(id[i+1:], 10, 64)
+	if err != nil {
+		return id, 0
+	}
+	return id[:i], occ
+}
+
+func findVEvent(vcal *icsComponent, occ int64) *icsComponent {
+	for _, ve := range vcal.children("VEVENT") {
+		if recurrenceUnix(ve) == occ {
+			return ve
+		}
+	}
+	return nil
+}
+
+func recurrenceUnix(ve *icsComponent) int64 {
+	p, ok := ve.prop("RECURRENCE-ID")
+	if !ok {
+		return 0
+	}
+	t, _, err := parseICSTime(p)
+	if err != nil {
+		return 0
+	}
+	return t.Unix()
+}
+
+func mustProp(ve *icsComponent, name string) icsProp {
+	p, _ := ve.prop(name)
+	return p
+}
+
+func eventFromVEvent(ve *icsComponent, cal contract.Calendar) (contract.Event, error) {
+	uid := ve.value("UID")
+	start, allDay, err := parseICSTime(mustProp(ve, "DTSTART"))
+	if err != nil {
+		return contract.Event{}, fmt.Errorf("event %s: invalid DTSTART: %w", uid, err)
+	}
+	end := start
+	if p, ok := ve.prop("DTEND"); ok {
+		end, _, err = parseICSTime(p)
+		if err != nil {
+			return contract.Event{}, fmt.Errorf("event %s: invalid DTEND: %w", uid, err)
+		}
+	} else if v := ve.value("DURATION"); v != "" {
+		d, err := parseICSDuration(v)
+		if err != nil {
+			return contract.Event{}, fmt.Errorf("event %s: %w", uid, err)
+		}
+		end = start.Add(d)
+	} else if allDay {
+		end = start.AddDate(0, 0, 1)
+	}
+	id := uid
+	if occ := recurrenceUnix(ve); occ != 0 {
+		id = fmt.Sprintf("%s@%d", uid, occ)
+	}
+	seq, _ := strconv.Atoi(strings.TrimSpace(ve.value("SEQUENCE")))
+	var created, updated time.Time
+	if p, ok := ve.prop("CREATED"); ok {
+		if t, _, err := parseICSTime(p); err == nil {
+			created = t
+		}
+	}
+	for _, name := range []string{"LAST-MODIFIED", "DTSTAMP"} {
+		if p, ok := ve.prop(name); ok {
+			if t, _, err := parseICSTime(p); err == nil {
+				updated = t
+				break
+			}
+		}
+	}
+	return contract.Event{
+		ID:           id,
+		CalendarID:   cal.ID,
+		CalendarName: cal.Name,
+		Title:        ve.text("SUMMARY"),
+		Start:        start,
+		End:          end,
+		AllDay:       allDay,
+		Location:     ve.text("LOCATION"),
+		Notes:        ve.text("DESCRIPTION"),
+		URL:          ve.value("URL"),
+		Status:       vEventStatus(ve),
+		Availability: vEventAvailability(ve),
+		Sensitivity:  vEventSensitivity(ve),
+		Sequence:     seq,
+		CreatedAt:    created,
+		UpdatedAt:    updated,
+	}, nil
+}
+
+func occurrenceEvent(vcal *icsComponent, uid string, occ int64, cal contract.Calendar) (*contract.Event, error) {
+	if ve := findVEvent(vcal, occ); ve != nil {
+		e, err := eventFromVEvent(ve, cal)
+		if err != nil {
+			return nil, err
+		}
+		return &e, nil
+	}
+	master := findVEvent(vcal, 0)
+	if master == nil || occ == 0 {
+		return nil, errors.New("event not found")
+	}
+	e, err := eventFromVEvent(master, cal)
+	if err != nil {
+		return nil, err
+	}
+	if !master.has("RRULE") && !master.has("RDATE") && e.Start.Unix() != occ {
+		return nil, errors.New("event not found")
+	}
+	dur := e.End.Sub(e.Start)
+	e.Start = time.Unix(occ, 0).In(e.Start.Location())
+	e.End = e.Start.Add(dur)
+	e.ID = fmt.Sprintf("%s@%d", uid, occ)
+	return &e, nil
+}
+
+func newOverride(master *icsComponent, occ int64) (*icsComponent, error) {
+	start, allDay, err := parseICSTime(mustProp(master, "DTSTART"))
+	if err != nil {
+		return nil, err
+	}
+	e, err := eventFromVEvent(master, contract.Calendar{})
+	if err != nil {
+		return nil, err
+	}
+	occStart := time.Unix(occ, 0).In(start.Location())
+	o := master.clone()
+	for _, name := range []string{"RRULE", "RDATE", "EXDATE", "DURATION"} {
+		o.removeProp(name)
+	}
+	o.setProp("RECURRENCE-ID", formatICSTimeProp("RECURRENCE-ID", occStart, allDay))
+	o.setProp("DTSTART", formatICSTimeProp("DTSTART", occStart, allDay))
+	o.setProp("DTEND", formatICSTimeProp("DTEND", occStart.Add(e.End.Sub(e.Start)), allDay))
+	return o, nil
+}
+
+func applyVEventPatch(ve *icsComponent, in EventUpdateInput, series bool) error {
+	if in.Title != nil {
+		ve.setText("SUMMARY", *in.Title)
+	}
+	if in.Location != nil {
+		ve.setText("LOCATION", *in.Location)
+	}
+	if in.Notes != nil {
+		ve.setText("DESCRIPTION", *in.Notes)
+	}
+	if in.URL != nil {
+		if *in.URL == "" {
+			ve.removeProp("URL")
+		} else {
+			ve.setProp("URL", "URL:"+*in.URL)
+		}
+	}
+	if in.Status != nil {
+		setVEventStatus(ve, *in.Status)
+	}
+	if in.Availability != nil {
+		setVEventAvailability(ve, *in.Availability)
+	}
+	if in.Sensitivity != nil {
+		setVEventSensitivity(ve, *in.Sensitivity)
+	}
+	if in.Start != nil || in.End != nil || in.AllDay != nil {
+		current, err := eventFromVEvent(ve, contract.Calendar{})
+		if err != nil {
+			return err
+		}
+		start, end, allDay := current.Start, current.End, current.AllDay
+		if in.Start != nil {
+			dur := end.Sub(start)
+			start = *in.Start
+			end = start.Add(dur)
+		}
+		if in.End != nil {
+			end = *in.End
+		}
+		if in.AllDay != nil {
+			allDay = *in.AllDay
+		}
+		if !end.After(start) {
+			return fmt.Errorf("invalid start/end")
+		}
+		ve.removeProp("DURATION")
+		ve.setProp("DTSTART", formatICSTimeProp("DTSTART", start, allDay))
+		ve.setProp("DTEND", formatICSTimeProp("DTEND", end, allDay))
+	}
+	if in.RepeatRule != nil {
+		if !series {
+			return fmt.Errorf("repeat rules can only be changed with --scope series")
+		}
+		rrule, err := repeatRuleToRRULE(*in.RepeatRule)
+		if err != nil {
+			return err
+		}
+		if rrule == "" {
+			ve.removeProp("RRULE")
+		} else {
+			ve.setProp("RRULE", "RRULE:"+rrule)
+		}
+	}
+	if in.ClearReminder || in.ReminderOffset != nil || in.ReminderAt != nil {
+		ve.removeChildren("VALARM")
+	}
+	if in.ReminderOffset != nil {
+		ve.Children = append(ve.Children, newVAlarm(*in.ReminderOffset))
+	}
+	if in.ReminderAt != nil {
+		alarm := newVAlarm(0)
+		alarm.setProp("TRIGGER", "TRIGGER;VALUE=DATE-TIME:"+in.ReminderAt.UTC().Format(icsUTCLayout))
+		ve.Children = append(ve.Children, alarm)
+	}
+	touchVEvent(ve)
+	return nil
+}
+
+func vEventStatus(ve *icsComponent) string {
+	switch strings.ToUpper(strings.TrimSpace(ve.value("STATUS"))) {
+	case "CONFIRMED":
+		return contract.StatusConfirmed
+	case "TENTATIVE":
+		return contract.StatusTentative
+	case "CANCELLED":
+		return contract.StatusCancelled
+	default:
+		return ""
+	}
+}
+
+func setVEventStatus(ve *icsComponent, status string) {
+	if status == "" {
+		ve.removeProp("STATUS")
+		return
+	}
+	ve.setProp("STATUS", "STATUS:"+strings.ToUpper(status))
+}
+
+// vEventAvailability maps TRANSP, which RFC 5545 defaults to OPAQUE (busy).
+func vEventAvailability(ve *icsComponent) string {
+	if strings.EqualFold(strings.TrimSpace(ve.value("TRANSP")), "TRANSPARENT") {
+		return contract.AvailabilityFree
+	}
+	return contract.AvailabilityBusy
+}
+
+func setVEventAvailability(ve *icsComponent, availability string) {
+	switch availability {
+	case "":
+		return
+	case contract.AvailabilityFree:
+		ve.setProp("TRANSP", "TRANSP:TRANSPARENT")
+	default:
+		ve.setProp("TRANSP", "TRANSP:OPAQUE")
+	}
+}
+
+// vEventSensitivity maps CLASS, which RFC 5545 defaults to PUBLIC.
+func vEventSensitivity(ve *icsComponent) string {
+	switch strings.ToUpper(strings.TrimSpace(ve.value("CLASS"))) {
+	case "PRIVATE":
+		return contract.SensitivityPrivate
+	case "CONFIDENTIAL":
+		return contract.SensitivityConfidential
+	default:
+		return contract.SensitivityPublic
+	}
+}
+
+func setVEventSensitivity(ve *icsComponent, sensitivity string) {
+	if sensitivity == "" {
+		return
+	}
+	ve.setProp("CLASS", "CLASS:"+strings.ToUpper(sensitivity))
+}
+
+func touchVEvent(ve *icsComponent) {
+	seq, _ := strconv.Atoi(strings.TrimSpace(ve.value("SEQUENCE")))
+	now := time.Now().UTC().Format(icsUTCLayout)
+	ve.setProp("SEQUENCE", "SEQUENCE:"+strconv.Itoa(seq+1))
+	ve.setProp("DTSTAMP", "DTSTAMP:"+now)
+	ve.setProp("LAST-MODIFIED", "LAST-MODIFIED:"+now)
+}
+
+func newVAlarm(offset time.Duration) *icsComponent {
+	return &icsComponent{Name: "VALARM", Props: []string{
+		"ACTION:DISPLAY",
+		"DESCRIPTION:Reminder",
+		"TRIGGER:" + formatICSDuration(offset),
+	}}
+}
+
+func matchesEventQuery(e contract.Event, query, field string) bool {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(field)) {
+	case "", "all":
+		return strings.Contains(strings.ToLower(e.Title), q) ||
+			strings.Contains(strings.ToLower(e.Location), q) ||
+			strings.Contains(strings.ToLower(e.Notes), q)
+	case "title", "location", "notes":
+		return strings.Contains(strings.ToLower(selectField(e, strings.ToLower(strings.TrimSpace(field)))), q)
+	default:
+		return false
+	}
+}
+
+func newCalDAVUID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	buf[6] = (buf[6] & 0x0f) | 0x40
+	buf[8] = (buf[8] & 0x3f) | 0x80
+	h := strings.ToUpper(hex.EncodeToString(buf))
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], nil
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,427 @@
+package backend
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type icsComponent struct {
+	Name     string
+	Props    []string
+	Children []*icsComponent
+}
+
+type icsProp struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+const (
+	icsDateLayout     = "20060102"
+	icsLocalLayout    = "20060102T150405"
+	icsUTCLayout      = "20060102T150405Z"
+	icsMaxLineOctets  = 75
+	icsDefaultProduct = "-//acal//acal//EN"
+)
+
+func parseICSCalendar(data string) (*icsComponent, error) {
+	root := &icsComponent{}
+	stack := []*icsComponent{root}
+	for _, line := range unfoldICSLines(data) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		upper := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(upper, "BEGIN:"):
+			c := &icsComponent{Name: strings.ToUpper(strings.TrimSpace(line[len("BEGIN:"):]))}
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, c)
+			stack = append(stack, c)
+		case strings.HasPrefix(upper, "END:"):
+			name := strings.ToUpper(strings.TrimSpace(line[len("END:"):]))
+			if len(stack) < 2 || stack[len(stack)-1].Name != name {
+				return nil, fmt.Errorf("invalid ics: unexpected END:%s", name)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) < 2 {
+				continue
+			}
+			c := stack[len(stack)-1]
+			c.Props = append(c.Props, line)
+		}
+	}
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("invalid ics: unterminated %s", stack[len(stack)-1].Name)
+	}
+	for _, c := range root.Children {
+		if c.Name == "VCALENDAR" {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid ics: missing VCALENDAR")
+}
+
+func unfoldICSLines(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+	raw := strings.Split(data, "\n")
+	out := make([]string, 0, len(raw))
+	for _, line := range raw {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(out) > 0 {
+			out[len(out)-1] += line[1:]
+			continue
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+func (c *icsComponent) String() string {
+	var b strings.Builder
+	c.write(&b)
+	return b.String()
+}
+
+func (c *icsComponent) write(b *strings.Builder) {
+	writeICSLine(b, "BEGIN:"+c.Name)
+	for _, p := range c.Props {
+		writeICSLine(b, p)
+	}
+	for _, child := range c.Children {
+		child.write(b)
+	}
+	writeICSLine(b, "END:"+c.Name)
+}
+
+func writeICSLine(b *strings.Builder, line string) {
+	first := true
+	for len(line) > 0 {
+		limit := icsMaxLineOctets
+		if !first {
+			limit--
+			b.WriteString(" ")
+		}
+		if len(line) <= limit {
+			b.WriteString(line)
+			break
+		}
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n")
+		line = line[cut:]
+		first = false
+	}
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool { return b&0xC0 != 0x80 }
+
+func parseICSProp(line string) icsProp {
+	p := icsProp{Params: map[string]string{}}
+	nameEnd := -1
+	valueStart := -1
+	inQuotes := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ';':
+			if !inQuotes && nameEnd < 0 {
+				nameEnd = i
+			}
+		case ':':
+			if !inQuotes {
+				valueStart = i
+			}
+		}
+		if valueStart >= 0 {
+			break
+		}
+	}
+	if valueStart < 0 {
+		p.Name = strings.ToUpper(strings.TrimSpace(line))
+		return p
+	}
+	if nameEnd < 0 || nameEnd > valueStart {
+		nameEnd = valueStart
+	}
+	p.Name = strings.ToUpper(strings.TrimSpace(line[:nameEnd]))
+	p.Value = line[valueStart+1:]
+	if nameEnd < valueStart {
+		for _, param := range splitICSParams(line[nameEnd+1 : valueStart]) {
+			k, v, ok := strings.Cut(param, "=")
+			if !ok {
+				continue
+			}
+			p.Params[strings.ToUpper(strings.TrimSpace(k))] = strings.Trim(strings.TrimSpace(v), `"`)
+		}
+	}
+	return p
+}
+
+func splitICSParams(s string) []string {
+	var out []string
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ';':
+			if !inQuotes {
+				out = append(out, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(out, s[start:])
+}
+
+func (c *icsComponent) prop(name string) (icsProp, bool) {
+	for _, line := range c.Props {
+		p := parseICSProp(line)
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return icsProp{}, false
+}
+
+func (c *icsComponent) value(name string) string {
+	p, _ := c.prop(name)
+	return p.Value
+}
+
+func (c *icsComponent) has(name string) bool {
+	_, ok := c.prop(name)
+	return ok
+}
+
+func (c *icsComponent) removeProp(name string) {
+	out := c.Props[:0]
+	for _, line := range c.Props {
+		if parseICSProp(line).Name != name {
+			out = append(out, line)
+		}
+	}
+	c.Props = out
+}
+
+func (c *icsComponent) setProp(name, line string) {
+	c.removeProp(name)
+	c.Props = append(c.Props, line)
+}
+
+func (c *icsComponent) setText(name, value string) {
+	if value == "" {
+		c.removeProp(name)
+		return
+	}
+	c.setProp(name, name+":"+escapeICSText(value))
+}
+
+func (c *icsComponent) text(name string) string {
+	return unescapeICSText(c.value(name))
+}
+
+func (c *icsComponent) children(name string) []*icsComponent {
+	var out []*icsComponent
+	for _, child := range c.Children {
+		if child.Name == name {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+func (c *icsComponent) removeChildren(name string) {
+	out := c.Children[:0]
+	for _, child := range c.Children {
+		if child.Name != name {
+			out = append(out, child)
+		}
+	}
+	c.Children = out
+}
+
+func (c *icsComponent) clone() *icsComponent {
+	cp := &icsComponent{Name: c.Name, Props: append([]string(nil), c.Props...)}
+	for _, child := range c.Children {
+		cp.Children = append(cp.Children, child.clone())
+	}
+	return cp
+}
+
+func escapeICSText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+func unescapeICSText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func parseICSTime(p icsProp) (time.Time, bool, error) {
+	v := strings.TrimSpace(p.Value)
+	if strings.EqualFold(p.Params["VALUE"], "DATE") || len(v) == len(icsDateLayout) {
+		t, err := time.ParseInLocation(icsDateLayout, v, time.Local)
+		return t, true, err
+	}
+	if strings.HasSuffix(v, "Z") {
+		t, err := time.Parse(icsUTCLayout, v)
+		return t, false, err
+	}
+	loc := time.Local
+	if tzid := p.Params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation(icsLocalLayout, v, loc)
+	return t, false, err
+}
+
+func formatICSTimeProp(name string, t time.Time, allDay bool) string {
+	if allDay {
+		return name + ";VALUE=DATE:" + t.Format(icsDateLayout)
+	}
+	return name + ":" + t.UTC().Format(icsUTCLayout)
+}
+
+func parseICSDuration(v string) (time.Duration, error) {
+	s := strings.ToUpper(strings.TrimSpace(v))
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+		s = s[1:]
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("invalid ics duration: %s", v)
+	}
+	s = s[1:]
+	var total time.Duration
+	inTime := false
+	num := ""
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+		case r == 'T':
+			inTime = true
+		default:
+			n, err := strconv.Atoi(num)
+			if err != nil {
+				return 0, fmt.Errorf("invalid ics duration: %s", v)
+			}
+			num = ""
+			switch {
+			case r == 'W':
+				total += time.Duration(n) * 7 * 24 * time.Hour
+			case r == 'D':
+				total += time.Duration(n) * 24 * time.Hour
+			case r == 'H' && inTime:
+				total += time.Duration(n) * time.Hour
+			case r == 'M' && inTime:
+				total += time.Duration(n) * time.Minute
+			case r == 'S' && inTime:
+				total += time.Duration(n) * time.Second
+			default:
+				return 0, fmt.Errorf("invalid ics duration: %s", v)
+			}
+		}
+	}
+	if num != "" {
+		return 0, fmt.Errorf("invalid ics duration: %s", v)
+	}
+	return sign * total, nil
+}
+
+func formatICSDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	if d == 0 {
+		return "PT0S"
+	}
+	if d%time.Minute != 0 {
+		return fmt.Sprintf("%sPT%dS", sign, int64(d/time.Second))
+	}
+	return fmt.Sprintf("%sPT%dM", sign, int64(d/time.Minute))
+}
+
+func repeatRuleToRRULE(rule string) (string, error) {
+	s := strings.ToLower(strings.TrimSpace(rule))
+	if s == "" {
+		return "", nil
+	}
+	count := ""
+	if left, right, ok := strings.Cut(s, "*"); ok {
+		s, count = left, strings.TrimSpace(right)
+	}
+	freq, days, _ := strings.Cut(s, ":")
+	var parts []string
+	switch strings.TrimSpace(freq) {
+	case "daily", "weekly", "monthly", "yearly":
+		parts = append(parts, "FREQ="+strings.ToUpper(strings.TrimSpace(freq)))
+	default:
+		return "", fmt.Errorf("unsupported repeat rule: %s", rule)
+	}
+	if days != "" {
+		byDay := make([]string, 0, 7)
+		for _, d := range strings.Split(days, ",") {
+			d = strings.TrimSpace(d)
+			if len(d) < 2 {
+				return "", fmt.Errorf("unsupported repeat rule: %s", rule)
+			}
+			byDay = append(byDay, strings.ToUpper(d[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(byDay, ","))
+	}
+	if count != "" {
+		if _, err := strconv.Atoi(count); err != nil {
+			return "", fmt.Errorf("unsupported repeat rule: %s", rule)
+		}
+		parts = append(parts, "COUNT="+count)
+	}
+	return strings.Join(parts, ";"), nil
+}
+
+func truncateRRULE(rrule string, until string) string {
+	parts := strings.Split(rrule, ";")
+	out := make([]string, 0, len(parts)+1)
+	for _, part := range parts {
+		key, _, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(strings.TrimSpace(key)) {
+		case "COUNT", "UNTIL", "":
+			continue
+		}
+		out = append(out, part)
+	}
+	return strings.Join(append(out, "UNTIL="+until), ";")
+}
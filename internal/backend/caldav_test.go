@@ -0,0 +1,474 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeCalDAVServer struct {
+	mu        sync.Mutex
+	resources map[string]string
+	etags     map[string]int
+}
+
+func newFakeCalDAVServer(t *testing.T) (*fakeCalDAVServer, *httptest.Server) {
+	t.Helper()
+	fs := &fakeCalDAVServer{resources: map[string]string{}, etags: map[string]int{}}
+	srv := httptest.NewServer(http.HandlerFunc(fs.serve))
+	t.Cleanup(srv.Close)
+	return fs, srv
+}
+
+func (fs *fakeCalDAVServer) etag(path string) string {
+	return fmt.Sprintf(`"%d"`, fs.etags[path])
+}
+
+func (fs *fakeCalDAVServer) serve(w http.ResponseWriter, r *http.Request) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	switch r.Method {
+	case "PROPFIND":
+		w.WriteHeader(http.StatusMultiStatus)
+		fmt.Fprint(w, `<?xml version="1.0"?>
+<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">
+  <d:response><d:href>/cal/</d:href><d:propstat><d:prop><d:resourcetype><d:collection/></d:resourcetype></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+  <d:response><d:href>/cal/work/</d:href><d:propstat><d:prop><d:displayname>Work</d:displayname><d:resourcetype><d:collection/><c:calendar/><cs:shared-owner/></d:resourcetype><d:current-user-privilege-set><d:privilege><d:read/></d:privilege><d:privilege><d:write/></d:privilege></d:current-user-privilege-set></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+  <d:response><d:href>/cal/holidays/</d:href><d:propstat><d:prop><d:displayname>Holidays</d:displayname><d:resourcetype><d:collection/><c:calendar/></d:resourcetype><d:current-user-privilege-set><d:privilege><d:read/></d:privilege></d:current-user-privilege-set></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+  <d:response><d:href>/cal/tasks/</d:href><d:propstat><d:prop><d:displayname>Tasks</d:displayname><d:resourcetype><d:collection/><c:calendar/></d:resourcetype><c:supported-calendar-component-set><c:comp name="VTODO"/></c:supported-calendar-component-set></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+</d:multistatus>`)
+	case "REPORT":
+		if !strings.Contains(string(body), "calendar-query") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		fmt.Fprint(w, `<?xml version="1.0"?><d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">`)
+		for path, data := range fs.resources {
+			if !strings.HasPrefix(path, r.URL.Path) {
+				continue
+			}
+			fmt.Fprintf(w, `<d:response><d:href>%s</d:href><d:propstat><d:prop><d:getetag>%s</d:getetag><c:calendar-data>%s</c:calendar-data></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`, path, xmlEscape(fs.etag(path)), xmlEscape(data))
+		}
+		fmt.Fprint(w, `</d:multistatus>`)
+	case http.MethodPut:
+		_, exists := fs.resources[r.URL.Path]
+		if r.Header.Get("If-None-Match") == "*" && exists {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		if m := r.Header.Get("If-Match"); m != "" && (!exists || m != fs.etag(r.URL.Path)) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		fs.resources[r.URL.Path] = string(body)
+		fs.etags[r.URL.Path]++
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		if _, ok := fs.resources[r.URL.Path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if m := r.Header.Get("If-Match"); m != "" && m != fs.etag(r.URL.Path) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		delete(fs.resources, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestCalDAVListCalendars(t *testing.T) {
+	_, srv := newFakeCalDAVServer(t)
+	b := NewCalDAVBackend(CalDAVConfig{URL: srv.URL + "/cal/", User: "me", Password: "secret"})
+	cals, err := b.ListCalendars(context.Background())
+	if err != nil {
+		t.Fatalf("ListCalendars failed: %v", err)
+	}
+	if len(cals) != 2 {
+		t.Fatalf("expected 2 event calendars, got %+v", cals)
+	}
+	if cals[0].Name != "Holidays" || cals[0].Writable {
+		t.Fatalf("expected read-only Holidays first, got %+v", cals[0])
+	}
+	if cals[1].Name != "Work" || !cals[1].Writable || cals[1].ID != srv.URL+"/cal/work/" {
+		t.Fatalf("unexpected Work calendar: %+v", cals[1])
+	}
+	if !cals[1].Shared || cals[0].Shared || cals[1].AccountType != "caldav" || cals[1].Account != strings.TrimPrefix(srv.URL, "http://") {
+		t.Fatalf("unexpected sharing/account fields: %+v", cals)
+	}
+}
+
+func TestCalDAVAddUpdateDelete(t *testing.T) {
+	fs, srv := newFakeCalDAVServer(t)
+	b := NewCalDAVBackend(CalDAVConfig{URL: srv.URL + "/cal/"})
+	ctx := context.Background()
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	reminder := -15 * time.Minute
+
+	created, err := b.AddEvent(ctx, EventCreateInput{
+		Calendar:       "work",
+		Title:          "Planning, Q2",
+		Start:          start,
+		End:            start.Add(30 * time.Minute),
+		Notes:          "line one\nline two",
+		ReminderOffset: &reminder,
+	})
+	if err != nil {
+		t.Fatalf("AddEvent failed: %v", err)
+	}
+	if _, err := b.AddEvent(ctx, EventCreateInput{Calendar: "Holidays", Title: "x", Start: start, End: start.Add(time.Hour)}); err == nil {
+		t.Fatalf("expected read-only calendar error")
+	}
+
+	items, err := b.ListEvents(ctx, EventFilter{From: start.Add(-time.Hour), To: start.Add(time.Hour), Query: "q2"})
+	if err != nil {
+		t.Fatalf("ListEvents failed: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != created.ID || items[0].Title != "Planning, Q2" || items[0].Notes != "line one\nline two" {
+		t.Fatalf("unexpected listed events: %+v", items)
+	}
+	if items[0].CreatedAt.IsZero() {
+		t.Fatalf("expected created_at from CREATED: %+v", items[0])
+	}
+	got, err := b.GetReminderOffset(ctx, created.ID)
+	if err != nil || got == nil || *got != reminder {
+		t.Fatalf("unexpected reminder: %v %v", got, err)
+	}
+
+	title := "Planning"
+	updated, err := b.UpdateEvent(ctx, created.ID, EventUpdateInput{Title: &title, Scope: ScopeAuto})
+	if err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	if updated.Title != "Planning" || updated.Sequence != 1 {
+		t.Fatalf("unexpected updated event: %+v", updated)
+	}
+
+	res, err := b.findResource(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("findResource failed: %v", err)
+	}
+	fs.mu.Lock()
+	fs.etags[strings.TrimPrefix(res.URL, srv.URL)]++
+	fs.mu.Unlock()
+	err = b.put(ctx, res.URL, res.Data, res.ETag, false)
+	if !errors.Is(err, ErrPreconditionFailed) {
+		t.Fatalf("expected precondition failure on stale etag, got %v", err)
+	}
+
+	if err := b.DeleteEvent(ctx, created.ID, ScopeAuto); err != nil {
+		t.Fatalf("DeleteEvent failed: %v", err)
+	}
+	if _, err := b.GetEventByID(ctx, created.ID); err == nil {
+		t.Fatalf("expected event to be gone")
+	}
+}
+
+func TestCalDAVRecurringOccurrenceScopes(t *testing.T) {
+	fs, srv := newFakeCalDAVServer(t)
+	b := NewCalDAVBackend(CalDAVConfig{URL: srv.URL + "/cal/"})
+	ctx := context.Background()
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	created, err := b.AddEvent(ctx, EventCreateInput{
+		Calendar:   "Work",
+		Title:      "Standup",
+		Start:      start,
+		End:        start.Add(15 * time.Minute),
+		RepeatRule: "weekly:mon,wed*6",
+	})
+	if err != nil {
+		t.Fatalf("AddEvent failed: %v", err)
+	}
+	uid, occ := parseCalDAVEventID(created.ID)
+	if occ != start.Unix() {
+		t.Fatalf("expected occurrence id, got %q", created.ID)
+	}
+
+	second := start.AddDate(0, 0, 2)
+	secondID := fmt.Sprintf("%s@%d", uid, second.Unix())
+	item, err := b.GetEventByID(ctx, secondID)
+	if err != nil {
+		t.Fatalf("GetEventByID occurrence failed: %v", err)
+	}
+	if !item.Start.Equal(second) || !item.End.Equal(second.Add(15*time.Minute)) {
+		t.Fatalf("unexpected occurrence times: %+v", item)
+	}
+
+	loc := "Room 4"
+	if _, err := b.UpdateEvent(ctx, secondID, EventUpdateInput{Location: &loc, Scope: ScopeThis}); err != nil {
+		t.Fatalf("UpdateEvent this failed: %v", err)
+	}
+	if err := b.DeleteEvent(ctx, fmt.Sprintf("%s@%d", uid, start.AddDate(0, 0, 7).Unix()), ScopeThis); err != nil {
+		t.Fatalf("DeleteEvent this failed: %v", err)
+	}
+	if err := b.DeleteEvent(ctx, fmt.Sprintf("%s@%d", uid, start.AddDate(0, 0, 9).Unix()), ScopeFuture); err != nil {
+		t.Fatalf("DeleteEvent future failed: %v", err)
+	}
+
+	fs.mu.Lock()
+	var data string
+	for _, v := range fs.resources {
+		data = v
+	}
+	fs.mu.Unlock()
+	unfolded := strings.Join(unfoldICSLines(data), "\n")
+	for _, want := range []string{
+		"RECURRENCE-ID:20260304T090000Z",
+		"LOCATION:Room 4",
+		"EXDATE:20260309T090000Z",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20260311T085959Z",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Fatalf("expected %q in stored ics:\n%s", want, unfolded)
+		}
+	}
+}
+
+func TestParseCalDAVEventID(t *testing.T) {
+	cases := []struct {
+		in  string
+		uid string
+		occ int64
+	}{
+		{in: "ABC-123", uid: "ABC-123"},
+		{in: "ABC-123@1772442000", uid: "ABC-123", occ: 1772442000},
+		{in: "abc@fastmail.com", uid: "abc@fastmail.com"},
+		{in: "abc@fastmail.com@1772442000", uid: "abc@fastmail.com", occ: 1772442000},
+	}
+	for _, tc := range cases {
+		uid, occ := parseCalDAVEventID(tc.in)
+		if uid != tc.uid || occ != tc.occ {
+			t.Fatalf("parseCalDAVEventID(%q) = %q, %d", tc.in, uid, occ)
+		}
+	}
+}
+
+func TestICSHelpers(t *testing.T) {
+	if d, err := parseICSDuration("-PT1H30M"); err != nil || d != -90*time.Minute {
+		t.Fatalf("unexpected duration: %v %v", d, err)
+	}
+	if d, err := parseICSDuration("P1DT2H"); err != nil || d != 26*time.Hour {
+		t.Fatalf("unexpected duration: %v %v", d, err)
+	}
+	if _, err := parseICSDuration("PT5"); err == nil {
+		t.Fatalf("expected invalid duration error")
+	}
+	if got := formatICSDuration(-15 * time.Minute); got != "-PT15M" {
+		t.Fatalf("unexpected trigger: %q", got)
+	}
+	if got, _ := repeatRuleToRRULE("monthly*3"); got != "FREQ=MONTHLY;COUNT=3" {
+		t.Fatalf("unexpected rrule: %q", got)
+	}
+	if _, err := repeatRuleToRRULE("hourly*3"); err == nil {
+		t.Fatalf("expected unsupported repeat error")
+	}
+
+	long := "DESCRIPTION:" + escapeICSText(strings.Repeat("é", 80)+"; done")
+	c := &icsComponent{Name: "VCALENDAR", Children: []*icsComponent{{Name: "VEVENT", Props: []string{long, `DTSTART;TZID="Europe/Berlin":20260302T100000`}}}}
+	out := c.String()
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > icsMaxLineOctets {
+			t.Fatalf("line exceeds fold limit: %d", len(line))
+		}
+	}
+	parsed, err := parseICSCalendar(out)
+	if err != nil {
+		t.Fatalf("parseICSCalendar failed: %v", err)
+	}
+	ve := parsed.children("VEVENT")[0]
+	if got := ve.text("DESCRIPTION"); got != strings.Repeat("é", 80)+"; done" {
+		t.Fatalf("roundtrip mismatch: %q", got)
+	}
+	st, allDay, err := parseICSTime(mustProp(ve, "DTSTART"))
+	if err != nil || allDay {
+		t.Fatalf("unexpected DTSTART parse: %v %v", allDay, err)
+	}
+	if st.UTC().Hour() != 9 {
+		t.Fatalf("expected TZID conversion, got %v", st.UTC())
+	}
+}
+
+func TestCalDAVInspectSeries(t *testing.T) {
+	_, srv := newFakeCalDAVServer(t)
+	b := NewCalDAVBackend(CalDAVConfig{URL: srv.URL + "/cal/"})
+	ctx := context.Background()
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	created, err := b.AddEvent(ctx, EventCreateInput{Calendar: "Work", Title: "Standup", Start: start, End: start.Add(15 * time.Minute), RepeatRule: "weekly:mon*4"})
+	if err != nil {
+		t.Fatalf("AddEvent failed: %v", err)
+	}
+	uid, _ := parseCalDAVEventID(created.ID)
+	moved := start.AddDate(0, 0, 7)
+	newStart := moved.Add(time.Hour)
+	if _, err := b.UpdateEvent(ctx, fmt.Sprintf("%s@%d", uid, moved.Unix()), EventUpdateInput{Start: &newStart, Scope: ScopeThis}); err != nil {
+		t.Fatalf("UpdateEvent this failed: %v", err)
+	}
+	if err := b.DeleteEvent(ctx, fmt.Sprintf("%s@%d", uid, start.AddDate(0, 0, 14).Unix()), ScopeThis); err != nil {
+		t.Fatalf("DeleteEvent this failed: %v", err)
+	}
+
+	s, err := b.InspectSeries(ctx, uid, start.AddDate(0, 0, -1), start.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("InspectSeries failed: %v", err)
+	}
+	if s.Title != "Standup" || s.CalendarName != "Work" || !strings.Contains(s.Rule, "FREQ=WEEKLY") {
+		t.Fatalf("unexpected series: %+v", s)
+	}
+	if len(s.ExceptionDates) != 1 || !s.ExceptionDates[0].Equal(start.AddDate(0, 0, 14)) {
+		t.Fatalf("unexpected exception dates: %+v", s.ExceptionDates)
+	}
+	var detached []SeriesOccurrence
+	for _, o := range s.Occurrences {
+		if o.Detached {
+			detached = append(detached, o)
+		}
+	}
+	if len(detached) != 1 || !detached[0].Start.Equal(newStart) || detached[0].OriginalStart == nil || !detached[0].OriginalStart.Equal(moved) {
+		t.Fatalf("unexpected detached occurrences: %+v", s.Occurrences)
+	}
+}
+
+func TestCalDAVStatusAndAvailability(t *testing.T) {
+	fs, srv := newFakeCalDAVServer(t)
+	b := NewCalDAVBackend(CalDAVConfig{URL: srv.URL + "/cal/"})
+	ctx := context.Background()
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	created, err := b.AddEvent(ctx, EventCreateInput{Calendar: "Work", Title: "Focus", Start: start, End: start.Add(time.Hour), Status: "tentative", Availability: "free"})
+	if err != nil {
+		t.Fatalf("AddEvent failed: %v", err)
+	}
+	if created.Status != "tentative" || created.Availability != "free" {
+		t.Fatalf("unexpected created event: %+v", created)
+	}
+
+	none, busy := "", "busy"
+	updated, err := b.UpdateEvent(ctx, created.ID, EventUpdateInput{Status: &none, Availability: &busy})
+	if err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	if updated.Status != "" || updated.Availability != "busy" {
+		t.Fatalf("unexpected updated event: %+v", updated)
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	for _, data := range fs.resources {
+		if strings.Contains(data, "STATUS:") || !strings.Contains(data, "TRANSP:OPAQUE") {
+			t.Fatalf("unexpected stored ics:\n%s", data)
+		}
+	}
+}
+
+func TestCalDAVSensitivity(t *testing.T) {
+	fs, srv := newFakeCalDAVServer(t)
+	b := NewCalDAVBackend(CalDAVConfig{URL: srv.URL + "/cal/"})
+	ctx := context.Background()
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	created, err := b.AddEvent(ctx, EventCreateInput{Calendar: "Work", Title: "Doctor", Start: start, End: start.Add(time.Hour), Sensitivity: "private"})
+	if err != nil {
+		t.Fatalf("AddEvent failed: %v", err)
+	}
+	if created.Sensitivity != "private" {
+		t.Fatalf("unexpected created event: %+v", created)
+	}
+
+	confidential := "confidential"
+	updated, err := b.UpdateEvent(ctx, created.ID, EventUpdateInput{Sensitivity: &confidential})
+	if err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	if updated.Sensitivity != "confidential" {
+		t.Fatalf("unexpected updated event: %+v", updated)
+	}
+	fs.mu.Lock()
+	for _, data := range fs.resources {
+		if !strings.Contains(data, "CLASS:CONFIDENTIAL") {
+			t.Fatalf("unexpected stored ics:\n%s", data)
+		}
+	}
+	fs.mu.Unlock()
+
+	plain, err := b.AddEvent(ctx, EventCreateInput{Calendar: "Work", Title: "Lunch", Start: start, End: start.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("AddEvent failed: %v", err)
+	}
+	if plain.Sensitivity != "public" {
+		t.Fatalf("expected CLASS to default to public, got %q", plain.Sensitivity)
+	}
+}
+
+func TestCalDAVRespondToEvent(t *testing.T) {
+	fs, srv := newFakeCalDAVServer(t)
+	b := NewCalDAVBackend(CalDAVConfig{URL: srv.URL + "/cal/", User: "Me@Example.com"})
+	ctx := context.Background()
+	fs.resources["/cal/work/invite.ics"] = strings.Join([]string{
+		"BEGIN:VCALENDAR", "VERSION:2.0", "BEGIN:VEVENT", "UID:invite", "DTSTART:20260302T090000Z", "DTEND:20260302T100000Z", "SUMMARY:Planning",
+		"ORGANIZER:mailto:boss@example.com",
+		`ATTENDEE;CN="Doe; Jane";PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:me@example.com`,
+		"ATTENDEE;PARTSTAT=ACCEPTED:mailto:boss@example.com",
+		"END:VEVENT", "END:VCALENDAR", "",
+	}, "\r\n")
+
+	res, err := b.RespondToEvent(ctx, "invite", RSVPInput{Response: "tentative", Comment: `might be "late"`})
+	if err != nil {
+		t.Fatalf("RespondToEvent failed: %v", err)
+	}
+	if res.Attendee != "me@example.com" || res.Previous != "needs-action" || res.Event.Title != "Planning" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	fs.mu.Lock()
+	data := strings.ReplaceAll(fs.resources["/cal/work/invite.ics"], "\r\n ", "")
+	fs.mu.Unlock()
+	if !strings.Contains(data, `ATTENDEE;CN="Doe; Jane";PARTSTAT=TENTATIVE;X-RESPONSE-COMMENT="might be 'late'":mailto:me@example.com`) || strings.Contains(data, "RSVP=TRUE") {
+		t.Fatalf("unexpected stored ics:\n%s", data)
+	}
+	if !strings.Contains(data, "ATTENDEE;PARTSTAT=ACCEPTED:mailto:boss@example.com") {
+		t.Fatalf("other attendees should be untouched:\n%s", data)
+	}
+
+	if _, err := b.RespondToEvent(ctx, "invite", RSVPInput{Response: "accept", Attendee: "someone@example.com"}); !errors.Is(err, ErrNotInvited) {
+		t.Fatalf("expected ErrNotInvited, got %v", err)
+	}
+}
+
+func TestCalDAVReminderAt(t *testing.T) {
+	fs, srv := newFakeCalDAVServer(t)
+	b := NewCalDAVBackend(CalDAVConfig{URL: srv.URL + "/cal/"})
+	ctx := context.Background()
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	reminder := -15 * time.Minute
+
+	created, err := b.AddEvent(ctx, EventCreateInput{Calendar: "Work", Title: "Standup", Start: start, End: start.Add(15 * time.Minute), ReminderOffset: &reminder})
+	if err != nil {
+		t.Fatalf("AddEvent failed: %v", err)
+	}
+	at := start.Add(10 * time.Minute)
+	if _, err := b.UpdateEvent(ctx, created.ID, EventUpdateInput{ReminderAt: &at}); err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	got, err := b.GetReminderOffset(ctx, created.ID)
+	if err != nil || got == nil || *got != 10*time.Minute {
+		t.Fatalf("expected the alarm 10m after the start, got %v err=%v", got, err)
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	for _, data := range fs.resources {
+		if strings.Count(data, "BEGIN:VALARM") != 1 || !strings.Contains(data, "TRIGGER;VALUE=DATE-TIME:20260302T091000Z") {
+			t.Fatalf("unexpected stored ics:\n%s", data)
+		}
+	}
+}
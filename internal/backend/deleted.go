@@ -0,0 +1,23 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrDeletedUnsupported = errors.New("backend does not keep a record of deleted events")
+
+type DeletedEvent struct {
+	ID           string    `json:"id"`
+	CalendarID   string    `json:"calendar_id"`
+	CalendarName string    `json:"calendar_name"`
+	Title        string    `json:"title"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	DeletedAt    time.Time `json:"deleted_at"`
+}
+
+type DeletedEventLister interface {
+	ListDeletedEvents(ctx context.Context, since time.Time) ([]DeletedEvent, error)
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package backend
+
+import "os"
+
+func tryLockFile(*os.File) (bool, error) {
+	return true, nil
+}
+
+func unlockFile(*os.File) error {
+	return nil
+}
@@ -0,0 +1,468 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+var errMockRepeatUpdate = errors.New("the mock backend cannot change the repeat rule of an existing event")
+
+type MockFixture struct {
+	Calendars []contract.Calendar `json:"calendars"`
+	Events    []contract.Event    `json:"events"`
+	Birthdays []Birthday          `json:"birthdays"`
+	Deleted   []DeletedEvent      `json:"deleted"`
+}
+
+type MockBackend struct {
+	mu        sync.Mutex
+	calendars []contract.Calendar
+	events    []contract.Event
+	birthdays []Birthday
+	deleted   []DeletedEvent
+	reminders map[string]time.Duration
+	responses map[string]string
+	nextID    int
+	writes    int
+}
+
+func NewMockBackend(fx MockFixture) *MockBackend {
+	b := &MockBackend{
+		calendars: append([]contract.Calendar(nil), fx.Calendars...),
+		events:    append([]contract.Event(nil), fx.Events...),
+		birthdays: append([]Birthday(nil), fx.Birthdays...),
+		deleted:   append([]DeletedEvent(nil), fx.Deleted...),
+		reminders: map[string]time.Duration{},
+		responses: map[string]string{},
+	}
+	if len(b.calendars) == 0 {
+		seen := map[string]bool{}
+		for _, e := range b.events {
+			name := firstNonEmptyString(e.CalendarName, e.CalendarID)
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			b.calendars = append(b.calendars, contract.Calendar{ID: firstNonEmptyString(e.CalendarID, name), Name: name, Writable: true})
+		}
+	}
+	return b
+}
+
+func LoadMockBackend(path string) (*MockBackend, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read mock file: %w", err)
+	}
+	var fx MockFixture
+	trimmed := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal(raw, &fx.Events)
+	} else {
+		err = json.Unmarshal(raw, &fx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse mock file %s: %w", path, err)
+	}
+	return NewMockBackend(fx), nil
+}
+
+func (b *MockBackend) Doctor(context.Context) ([]contract.DoctorCheck, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return []contract.DoctorCheck{
+		{Name: "mock", Status: "ok", Message: fmt.Sprintf("mock backend with %d calendars and %d events", len(b.calendars), len(b.events))},
+	}, nil
+}
+
+func (b *MockBackend) ListCalendars(context.Context) ([]contract.Calendar, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]contract.Calendar{}, b.calendars...), nil
+}
+
+func (b *MockBackend) ListBirthdays(context.Context) ([]Birthday, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Birthday{}, b.birthdays...), nil
+}
+
+func (b *MockBackend) ListDeletedEvents(_ context.Context, since time.Time) ([]DeletedEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	items := []DeletedEvent{}
+	for _, d := range b.deleted {
+		if d.DeletedAt.IsZero() || !d.DeletedAt.Before(since) {
+			items = append(items, d)
+		}
+	}
+	return items, nil
+}
+
+func (b *MockBackend) ListEvents(_ context.Context, f EventFilter) ([]contract.Event, error) {
+	if f.From.IsZero() || f.To.IsZero() {
+		return nil, fmt.Errorf("from/to required")
+	}
+	if f.To.Before(f.From) {
+		return nil, fmt.Errorf("invalid time range")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return FilterEvents(b.events, f), nil
+}
+
+func (b *MockBackend) ChangeStamp(context.Context) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strconv.Itoa(b.writes), nil
+}
+
+func (b *MockBackend) StreamEvents(ctx context.Context, f EventFilter, emit func(contract.Event) error) error {
+	items, err := b.ListEvents(ctx, f)
+	if err != nil {
+		return err
+	}
+	for _, e := range items {
+		if err := emit(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *MockBackend) GetEventByID(_ context.Context, id string) (*contract.Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := b.indexOf(id)
+	if i < 0 {
+		return nil, errors.New("event not found")
+	}
+	cp := b.events[i]
+	return &cp, nil
+}
+
+func (b *MockBackend) GetReminderOffset(_ context.Context, id string) (*time.Duration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.indexOf(id) < 0 {
+		return nil, errors.New("event not found")
+	}
+	d, ok := b.reminders[id]
+	if !ok {
+		return nil, nil
+	}
+	return &d, nil
+}
+
+func (b *MockBackend) AddEvent(_ context.Context, in EventCreateInput) (*contract.Event, error) {
+	if strings.TrimSpace(in.Calendar) == "" || strings.TrimSpace(in.Title) == "" {
+		return nil, fmt.Errorf("calendar and title required")
+	}
+	if in.Start.IsZero() || in.End.IsZero() || !in.End.After(in.Start) {
+		return nil, fmt.Errorf("invalid start/end")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var cal *contract.Calendar
+	for i := range b.calendars {
+		if b.calendars[i].ID == in.Calendar || strings.EqualFold(b.calendars[i].Name, strings.TrimSpace(in.Calendar)) {
+			cal = &b.calendars[i]
+			break
+		}
+	}
+	if cal == nil {
+		return nil, fmt.Errorf("calendar not found")
+	}
+	if !cal.Writable {
+		return nil, fmt.Errorf("calendar is read-only: %s", cal.Name)
+	}
+	starts, err := mockRepeatStarts(in.Start, in.RepeatRule)
+	if err != nil {
+		return nil, err
+	}
+	b.nextID++
+	dur := in.End.Sub(in.Start)
+	var first contract.Event
+	for i, st := range starts {
+		e := contract.Event{
+			ID:           fmt.Sprintf("mock-%d@%d", b.nextID, st.Unix()),
+			CalendarID:   cal.ID,
+			CalendarName: cal.Name,
+			Title:        in.Title,
+			Start:        st,
+			End:          st.Add(dur),
+			AllDay:       in.AllDay,
+			Location:     in.Location,
+			Notes:        in.Notes,
+			URL:          in.URL,
+			Status:       in.Status,
+			Availability: in.Availability,
+			Sensitivity:  in.Sensitivity,
+			CreatedAt:    time.Now().UTC(),
+			UpdatedAt:    time.Now().UTC(),
+		}
+		b.events = append(b.events, e)
+		if in.ReminderOffset != nil {
+			b.reminders[e.ID] = *in.ReminderOffset
+		}
+		if i == 0 {
+			first = e
+		}
+	}
+	b.writes++
+	return &first, nil
+}
+
+func (b *MockBackend) UpdateEvent(_ context.Context, id string, in EventUpdateInput) (*contract.Event, error) {
+	scope, err := resolveRecurrenceScope(in.Scope, mockOccurrence(id))
+	if err != nil {
+		return nil, err
+	}
+	if in.RepeatRule != nil {
+		return nil, errMockRepeatUpdate
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := b.indexOf(id)
+	if i < 0 {
+		return nil, errors.New("event not found")
+	}
+	target := b.events[i]
+	updated, err := b.patchEvent(target, in)
+	if err != nil {
+		return nil, err
+	}
+	startShift, endShift := updated.Start.Sub(target.Start), updated.End.Sub(target.End)
+	for j, e := range b.events {
+		if j == i || !inMockScope(e, target, scope) {
+			continue
+		}
+		moved := in
+		if in.Start != nil {
+			st := e.Start.Add(startShift)
+			moved.Start = &st
+		}
+		if in.End != nil {
+			end := e.End.Add(endShift)
+			moved.End = &end
+		}
+		if in.ReminderAt != nil {
+			at := in.ReminderAt.Add(e.Start.Sub(target.Start))
+			moved.ReminderAt = &at
+		}
+		if b.events[j], err = b.patchEvent(e, moved); err != nil {
+			return nil, err
+		}
+	}
+	b.events[i] = updated
+	b.writes++
+	return &updated, nil
+}
+
+func (b *MockBackend) patchEvent(e contract.Event, in EventUpdateInput) (contract.Event, error) {
+	id := e.ID
+	if in.Title != nil {
+		e.Title = *in.Title
+	}
+	if in.Start != nil {
+		dur := e.End.Sub(e.Start)
+		e.Start = *in.Start
+		e.End = e.Start.Add(dur)
+	}
+	if in.End != nil {
+		e.End = *in.End
+	}
+	if !e.End.After(e.Start) {
+		return contract.Event{}, fmt.Errorf("invalid start/end")
+	}
+	if in.Location != nil {
+		e.Location = *in.Location
+	}
+	if in.Notes != nil {
+		e.Notes = *in.Notes
+	}
+	if in.URL != nil {
+		e.URL = *in.URL
+	}
+	if in.AllDay != nil {
+		e.AllDay = *in.AllDay
+	}
+	if in.Status != nil {
+		e.Status = *in.Status
+	}
+	if in.Availability != nil {
+		e.Availability = *in.Availability
+	}
+	if in.Sensitivity != nil {
+		e.Sensitivity = *in.Sensitivity
+	}
+	if in.ClearReminder {
+		delete(b.reminders, id)
+	}
+	if in.ReminderOffset != nil {
+		b.reminders[id] = *in.ReminderOffset
+	}
+	if in.ReminderAt != nil {
+		b.reminders[id] = in.ReminderAt.Sub(e.Start)
+	}
+	e.Sequence++
+	e.UpdatedAt = time.Now().UTC()
+	return e, nil
+}
+
+func (b *MockBackend) DeleteEvent(_ context.Context, id string, scope RecurrenceScope) error {
+	scope, err := resolveRecurrenceScope(scope, mockOccurrence(id))
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := b.indexOf(id)
+	if i < 0 {
+		return errors.New("event not found")
+	}
+	target := b.events[i]
+	kept := b.events[:0]
+	for _, e := range b.events {
+		if !inMockScope(e, target, scope) {
+			kept = append(kept, e)
+			continue
+		}
+		b.deleted = append(b.deleted, DeletedEvent{ID: e.ID, CalendarID: e.CalendarID, CalendarName: e.CalendarName, Title: e.Title, Start: e.Start, End: e.End, DeletedAt: time.Now().UTC()})
+		delete(b.reminders, e.ID)
+	}
+	b.events = kept
+	b.writes++
+	return nil
+}
+
+func (b *MockBackend) RespondToEvent(_ context.Context, id string, in RSVPInput) (*RSVPResult, error) {
+	if _, ok := RSVPResponses[in.Response]; !ok {
+		return nil, fmt.Errorf("invalid response %q: use accept, decline, or tentative", in.Response)
+	}
+	if _, err := resolveRecurrenceScope(in.Scope, mockOccurrence(id)); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := b.indexOf(id)
+	if i < 0 {
+		return nil, errors.New("event not found")
+	}
+	res := &RSVPResult{
+		Event:    b.events[i],
+		Attendee: firstNonEmptyString(strings.ToLower(strings.TrimSpace(in.Attendee)), "me"),
+		Response: in.Response,
+		Previous: firstNonEmptyString(b.responses[id], "needs-action"),
+		Comment:  in.Comment,
+	}
+	b.responses[id] = strings.ToLower(RSVPResponses[in.Response])
+	return res, nil
+}
+
+func (b *MockBackend) indexOf(id string) int {
+	id = strings.TrimSpace(id)
+	for i, e := range b.events {
+		if e.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func inMockScope(e, target contract.Event, scope RecurrenceScope) bool {
+	if e.ID == target.ID {
+		return true
+	}
+	uid, _ := EventUID(e.ID)
+	targetUID, _ := EventUID(target.ID)
+	switch scope {
+	case ScopeSeries:
+		return uid == targetUID
+	case ScopeFuture:
+		return uid == targetUID && !e.Start.Before(target.Start)
+	default:
+		return false
+	}
+}
+
+var mockWeekdays = map[string]time.Weekday{
+	"su": time.Sunday, "mo": time.Monday, "tu": time.Tuesday, "we": time.Wednesday,
+	"th": time.Thursday, "fr": time.Friday, "sa": time.Saturday,
+}
+
+// mockRepeatStarts expands a canonical repeat rule such as weekly:mon,wed*6
+// into occurrence starts, the first being start itself.
+func mockRepeatStarts(start time.Time, rule string) ([]time.Time, error) {
+	s := strings.ToLower(strings.TrimSpace(rule))
+	if s == "" {
+		return []time.Time{start}, nil
+	}
+	freq, count := s, 10
+	if left, right, ok := strings.Cut(s, "*"); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(right))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("unsupported repeat rule: %s", rule)
+		}
+		freq, count = left, n
+	}
+	freq, days, _ := strings.Cut(freq, ":")
+	weekdays := map[time.Weekday]bool{}
+	for _, d := range strings.Split(days, ",") {
+		if d = strings.TrimSpace(d); d == "" {
+			continue
+		}
+		wd, ok := mockWeekdays[d[:min(2, len(d))]]
+		if !ok {
+			return nil, fmt.Errorf("unsupported repeat rule: %s", rule)
+		}
+		weekdays[wd] = true
+	}
+	if len(weekdays) == 0 {
+		weekdays[start.Weekday()] = true
+	}
+	out := []time.Time{start}
+	for len(out) < count {
+		last := out[len(out)-1]
+		switch strings.TrimSpace(freq) {
+		case "daily":
+			out = append(out, last.AddDate(0, 0, 1))
+		case "weekly":
+			next := last.AddDate(0, 0, 1)
+			for !weekdays[next.Weekday()] {
+				next = next.AddDate(0, 0, 1)
+			}
+			out = append(out, next)
+		case "monthly":
+			out = append(out, start.AddDate(0, len(out), 0))
+		case "yearly":
+			out = append(out, start.AddDate(len(out), 0, 0))
+		default:
+			return nil, fmt.Errorf("unsupported repeat rule: %s", rule)
+		}
+	}
+	return out, nil
+}
+
+func mockOccurrence(id string) int64 {
+	_, occ := parseCalDAVEventID(id)
+	return occ
+}
+
+func firstNonEmptyString(vals ...string) string {
+	for _, v := range vals {
+		if strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
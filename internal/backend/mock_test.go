@@ -0,0 +1,139 @@
+package backend
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestLoadMockBackendArrayFixture(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	raw := `[{"id":"a@1","calendar_name":"Work","title":"Standup","start":"2026-03-02T09:00:00Z","end":"2026-03-02T09:15:00Z"}]`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	b, err := LoadMockBackend(path)
+	if err != nil {
+		t.Fatalf("LoadMockBackend failed: %v", err)
+	}
+	cals, _ := b.ListCalendars(context.Background())
+	if len(cals) != 1 || cals[0].Name != "Work" || !cals[0].Writable {
+		t.Fatalf("expected calendar derived from events, got %+v", cals)
+	}
+	if _, err := LoadMockBackend(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatalf("expected missing file error")
+	}
+}
+
+func TestMockBackendOperations(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	b := NewMockBackend(MockFixture{})
+	ctx := context.Background()
+	if _, err := b.AddEvent(ctx, EventCreateInput{Calendar: "Work", Title: "x", Start: start, End: start.Add(time.Hour)}); err == nil {
+		t.Fatalf("expected calendar not found error")
+	}
+
+	b = NewMockBackend(MockFixture{Calendars: []contract.Calendar{
+		{ID: "work", Name: "Work", Writable: true},
+		{ID: "holidays", Name: "Holidays"},
+	}})
+	if _, err := b.AddEvent(ctx, EventCreateInput{Calendar: "holidays", Title: "x", Start: start, End: start.Add(time.Hour)}); err == nil {
+		t.Fatalf("expected read-only calendar error")
+	}
+	reminder := -10 * time.Minute
+	created, err := b.AddEvent(ctx, EventCreateInput{Calendar: "work", Title: "Planning", Start: start, End: start.Add(time.Hour), ReminderOffset: &reminder})
+	if err != nil {
+		t.Fatalf("AddEvent failed: %v", err)
+	}
+	items, err := b.ListEvents(ctx, EventFilter{From: start.Add(-time.Hour), To: start.Add(time.Hour), Calendars: []string{"Work"}, Query: "plan"})
+	if err != nil || len(items) != 1 || items[0].ID != created.ID {
+		t.Fatalf("unexpected list result: %+v %v", items, err)
+	}
+	if got, _ := b.GetReminderOffset(ctx, created.ID); got == nil || *got != reminder {
+		t.Fatalf("unexpected reminder: %v", got)
+	}
+
+	shift := start.Add(30 * time.Minute)
+	updated, err := b.UpdateEvent(ctx, created.ID, EventUpdateInput{Start: &shift, ClearReminder: true})
+	if err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	if !updated.End.Equal(shift.Add(time.Hour)) || updated.Sequence != 1 {
+		t.Fatalf("unexpected updated event: %+v", updated)
+	}
+	if got, _ := b.GetReminderOffset(ctx, created.ID); got != nil {
+		t.Fatalf("expected reminder cleared, got %v", got)
+	}
+
+	if err := b.DeleteEvent(ctx, created.ID, ScopeAuto); err != nil {
+		t.Fatalf("DeleteEvent failed: %v", err)
+	}
+	if _, err := b.GetEventByID(ctx, created.ID); err == nil {
+		t.Fatalf("expected event to be deleted")
+	}
+}
+
+func TestMockBackendOverlapFilterIncludesSpanningEvents(t *testing.T) {
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	b := NewMockBackend(MockFixture{Events: []contract.Event{
+		{ID: "conf", CalendarName: "Work", Title: "Conference", Start: day.AddDate(0, 0, -1), End: day.AddDate(0, 0, 2), AllDay: true},
+		{ID: "late", CalendarName: "Work", Title: "Late shift", Start: day.Add(-2 * time.Hour), End: day.Add(2 * time.Hour)},
+		{ID: "ended", CalendarName: "Work", Title: "Ended at midnight", Start: day.Add(-time.Hour), End: day},
+	}})
+	ctx := context.Background()
+	f := EventFilter{From: day, To: day.Add(24*time.Hour - time.Second)}
+	items, err := b.ListEvents(ctx, f)
+	if err != nil || len(items) != 0 {
+		t.Fatalf("expected start-only filter to skip spanning events, got %+v %v", items, err)
+	}
+	f.Overlap = true
+	items, err = b.ListEvents(ctx, f)
+	if err != nil || len(items) != 2 || items[0].ID != "conf" || items[1].ID != "late" {
+		t.Fatalf("expected overlapping events only, got %+v %v", items, err)
+	}
+}
+
+func TestMockBackendExpandsRepeatRule(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // Monday
+	b := NewMockBackend(MockFixture{Calendars: []contract.Calendar{{ID: "work", Name: "Work", Writable: true}}})
+	ctx := context.Background()
+	created, err := b.AddEvent(ctx, EventCreateInput{Calendar: "work", Title: "Standup", Start: start, End: start.Add(15 * time.Minute), RepeatRule: "weekly:mon,wed*4"})
+	if err != nil {
+		t.Fatalf("AddEvent failed: %v", err)
+	}
+	window := EventFilter{From: start, To: start.AddDate(0, 1, 0)}
+	items, _ := b.ListEvents(ctx, window)
+	want := []time.Time{start, start.AddDate(0, 0, 2), start.AddDate(0, 0, 7), start.AddDate(0, 0, 9)}
+	if len(items) != len(want) || items[0].ID != created.ID {
+		t.Fatalf("expected %d occurrences starting with %s, got %+v", len(want), created.ID, items)
+	}
+	for i, e := range items {
+		uid, _ := EventUID(e.ID)
+		if !e.Start.Equal(want[i]) || uid != "mock-1" {
+			t.Fatalf("unexpected occurrence %d: %+v", i, e)
+		}
+	}
+
+	title := "Sync"
+	if _, err := b.UpdateEvent(ctx, items[2].ID, EventUpdateInput{Title: &title, Scope: ScopeFuture}); err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	items, _ = b.ListEvents(ctx, window)
+	if items[1].Title != "Standup" || items[2].Title != "Sync" || items[3].Title != "Sync" {
+		t.Fatalf("expected future scope to retitle the last two, got %+v", items)
+	}
+	rule := "daily*2"
+	if _, err := b.UpdateEvent(ctx, created.ID, EventUpdateInput{RepeatRule: &rule}); err == nil {
+		t.Fatalf("expected repeat rule changes to be rejected")
+	}
+	if err := b.DeleteEvent(ctx, created.ID, ScopeSeries); err != nil {
+		t.Fatalf("DeleteEvent failed: %v", err)
+	}
+	if items, _ = b.ListEvents(ctx, window); len(items) != 0 {
+		t.Fatalf("expected series delete to remove every occurrence, got %+v", items)
+	}
+}
@@ -0,0 +1,259 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+const sourceIDSeparator = ":"
+
+type NamedBackend struct {
+	Name      string
+	Backend   Backend
+	Calendars []string
+}
+
+type MultiBackend struct {
+	members []NamedBackend
+}
+
+func NewMultiBackend(members []NamedBackend) *MultiBackend {
+	sorted := append([]NamedBackend(nil), members...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return &MultiBackend{members: sorted}
+}
+
+func (b *MultiBackend) Doctor(ctx context.Context) ([]contract.DoctorCheck, error) {
+	checks := []contract.DoctorCheck{}
+	var errs []error
+	for _, m := range b.members {
+		memberChecks, err := m.Backend.Doctor(ctx)
+		for _, c := range memberChecks {
+			c.Name = m.Name + "." + c.Name
+			checks = append(checks, c)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.Name, err))
+		}
+	}
+	return checks, errors.Join(errs...)
+}
+
+func (b *MultiBackend) ListCalendars(ctx context.Context) ([]contract.Calendar, error) {
+	items := []contract.Calendar{}
+	for _, m := range b.members {
+		cals, err := m.Backend.ListCalendars(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Name, err)
+		}
+		for _, c := range cals {
+			c.Source = m.Name
+			items = append(items, c)
+		}
+	}
+	return items, nil
+}
+
+func (b *MultiBackend) ListBirthdays(ctx context.Context) ([]Birthday, error) {
+	items := []Birthday{}
+	seen := map[string]bool{}
+	for _, m := range b.members {
+		lister, ok := m.Backend.(BirthdayLister)
+		if !ok {
+			continue
+		}
+		bs, err := lister.ListBirthdays(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Name, err)
+		}
+		for _, bd := range bs {
+			if !seen[bd.ContactID] {
+				seen[bd.ContactID] = true
+				items = append(items, bd)
+			}
+		}
+	}
+	return items, nil
+}
+
+func (b *MultiBackend) ListDeletedEvents(ctx context.Context, since time.Time) ([]DeletedEvent, error) {
+	items := []DeletedEvent{}
+	supported := false
+	for _, m := range b.members {
+		lister, ok := m.Backend.(DeletedEventLister)
+		if !ok {
+			continue
+		}
+		supported = true
+		ds, err := lister.ListDeletedEvents(ctx, since)
+		if errors.Is(err, ErrDeletedUnsupported) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Name, err)
+		}
+		for _, d := range ds {
+			d.ID = m.Name + sourceIDSeparator + d.ID
+			items = append(items, d)
+		}
+	}
+	if !supported {
+		return nil, ErrDeletedUnsupported
+	}
+	return items, nil
+}
+
+func (b *MultiBackend) ListEvents(ctx context.Context, f EventFilter) ([]contract.Event, error) {
+	items := []contract.Event{}
+	for _, m := range b.members {
+		events, err := m.Backend.ListEvents(ctx, f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Name, err)
+		}
+		for _, e := range events {
+			items = append(items, withSource(e, m.Name))
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Start.Equal(items[j].Start) {
+			return items[i].ID < items[j].ID
+		}
+		return items[i].Start.Before(items[j].Start)
+	})
+	if f.Limit > 0 && len(items) > f.Limit {
+		items = items[:f.Limit]
+	}
+	return items, nil
+}
+
+func (b *MultiBackend) GetEventByID(ctx context.Context, id string) (*contract.Event, error) {
+	m, inner, err := b.route(id)
+	if err != nil {
+		return nil, err
+	}
+	return sourced(m.Name)(m.Backend.GetEventByID(ctx, inner))
+}
+
+func (b *MultiBackend) GetReminderOffset(ctx context.Context, id string) (*time.Duration, error) {
+	m, inner, err := b.route(id)
+	if err != nil {
+		return nil, err
+	}
+	return m.Backend.GetReminderOffset(ctx, inner)
+}
+
+func (b *MultiBackend) AddEvent(ctx context.Context, in EventCreateInput) (*contract.Event, error) {
+	m, err := b.routeCalendar(ctx, in.Calendar)
+	if err != nil {
+		return nil, err
+	}
+	return sourced(m.Name)(m.Backend.AddEvent(ctx, in))
+}
+
+func (b *MultiBackend) UpdateEvent(ctx context.Context, id string, in EventUpdateInput) (*contract.Event, error) {
+	m, inner, err := b.route(id)
+	if err != nil {
+		return nil, err
+	}
+	return sourced(m.Name)(m.Backend.UpdateEvent(ctx, inner, in))
+}
+
+func (b *MultiBackend) DeleteEvent(ctx context.Context, id string, scope RecurrenceScope) error {
+	m, inner, err := b.route(id)
+	if err != nil {
+		return err
+	}
+	return m.Backend.DeleteEvent(ctx, inner, scope)
+}
+
+func (b *MultiBackend) InspectSeries(ctx context.Context, uid string, from, to time.Time) (*Series, error) {
+	m, inner, err := b.route(uid)
+	if err != nil {
+		return nil, err
+	}
+	inspector, ok := m.Backend.(SeriesInspector)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", m.Name, ErrSeriesUnsupported)
+	}
+	s, err := inspector.InspectSeries(ctx, inner, from, to)
+	if err != nil {
+		return nil, err
+	}
+	s.UID = m.Name + sourceIDSeparator + s.UID
+	for i := range s.Occurrences {
+		s.Occurrences[i].ID = m.Name + sourceIDSeparator + s.Occurrences[i].ID
+	}
+	return s, nil
+}
+
+func (b *MultiBackend) RespondToEvent(ctx context.Context, id string, in RSVPInput) (*RSVPResult, error) {
+	m, inner, err := b.route(id)
+	if err != nil {
+		return nil, err
+	}
+	responder, ok := m.Backend.(Responder)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", m.Name, ErrRSVPUnsupported)
+	}
+	res, err := responder.RespondToEvent(ctx, inner, in)
+	if err != nil {
+		return nil, err
+	}
+	res.Event = withSource(res.Event, m.Name)
+	return res, nil
+}
+
+func (b *MultiBackend) route(id string) (NamedBackend, string, error) {
+	source, inner, ok := strings.Cut(strings.TrimSpace(id), sourceIDSeparator)
+	if ok {
+		for _, m := range b.members {
+			if m.Name == source {
+				return m, inner, nil
+			}
+		}
+	}
+	return NamedBackend{}, "", fmt.Errorf("event id %q has no known backend prefix (expected <backend>%s<id>)", id, sourceIDSeparator)
+}
+
+func (b *MultiBackend) routeCalendar(ctx context.Context, calendar string) (NamedBackend, error) {
+	name := strings.TrimSpace(calendar)
+	for _, m := range b.members {
+		if containsFold(m.Calendars, name) {
+			return m, nil
+		}
+	}
+	for _, m := range b.members {
+		cals, err := m.Backend.ListCalendars(ctx)
+		if err != nil {
+			continue
+		}
+		for _, c := range cals {
+			if c.ID == name || strings.EqualFold(c.Name, name) {
+				return m, nil
+			}
+		}
+	}
+	return NamedBackend{}, fmt.Errorf("calendar not found in any backend: %s", calendar)
+}
+
+func withSource(e contract.Event, source string) contract.Event {
+	e.Source = source
+	e.ID = source + sourceIDSeparator + e.ID
+	return e
+}
+
+func sourced(source string) func(*contract.Event, error) (*contract.Event, error) {
+	return func(e *contract.Event, err error) (*contract.Event, error) {
+		if err != nil || e == nil {
+			return e, err
+		}
+		out := withSource(*e, source)
+		return &out, nil
+	}
+}
@@ -0,0 +1,119 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+type stubBackend struct {
+	calendars []contract.Calendar
+	events    []contract.Event
+	lastID    string
+	added     []EventCreateInput
+}
+
+func (s *stubBackend) Doctor(context.Context) ([]contract.DoctorCheck, error) {
+	return []contract.DoctorCheck{{Name: "access", Status: "ok"}}, nil
+}
+
+func (s *stubBackend) ListCalendars(context.Context) ([]contract.Calendar, error) {
+	return s.calendars, nil
+}
+
+func (s *stubBackend) ListEvents(context.Context, EventFilter) ([]contract.Event, error) {
+	return s.events, nil
+}
+
+func (s *stubBackend) GetEventByID(_ context.Context, id string) (*contract.Event, error) {
+	s.lastID = id
+	return &contract.Event{ID: id}, nil
+}
+
+func (s *stubBackend) GetReminderOffset(_ context.Context, id string) (*time.Duration, error) {
+	s.lastID = id
+	return nil, nil
+}
+
+func (s *stubBackend) AddEvent(_ context.Context, in EventCreateInput) (*contract.Event, error) {
+	s.added = append(s.added, in)
+	return &contract.Event{ID: "new", CalendarName: in.Calendar}, nil
+}
+
+func (s *stubBackend) UpdateEvent(_ context.Context, id string, _ EventUpdateInput) (*contract.Event, error) {
+	s.lastID = id
+	return &contract.Event{ID: id}, nil
+}
+
+func (s *stubBackend) DeleteEvent(_ context.Context, id string, _ RecurrenceScope) error {
+	s.lastID = id
+	return nil
+}
+
+func TestMultiBackendMergesAndRoutes(t *testing.T) {
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	apple := &stubBackend{
+		calendars: []contract.Calendar{{ID: "A1", Name: "Home", Writable: true}},
+		events:    []contract.Event{{ID: "a@1", Title: "Dentist", Start: base.Add(2 * time.Hour)}},
+	}
+	fastmail := &stubBackend{
+		calendars: []contract.Calendar{{ID: "/cal/work/", Name: "Work", Writable: true}},
+		events:    []contract.Event{{ID: "f@1", Title: "Standup", Start: base}},
+	}
+	b := NewMultiBackend([]NamedBackend{
+		{Name: "fastmail", Backend: fastmail, Calendars: []string{"Team"}},
+		{Name: "apple", Backend: apple},
+	})
+	ctx := context.Background()
+
+	items, err := b.ListEvents(ctx, EventFilter{From: base, To: base.Add(24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("ListEvents failed: %v", err)
+	}
+	if len(items) != 2 || items[0].ID != "fastmail:f@1" || items[0].Source != "fastmail" || items[1].ID != "apple:a@1" {
+		t.Fatalf("unexpected merged events: %+v", items)
+	}
+
+	cals, err := b.ListCalendars(ctx)
+	if err != nil || len(cals) != 2 || cals[0].Source != "apple" || cals[1].Source != "fastmail" {
+		t.Fatalf("unexpected merged calendars: %+v %v", cals, err)
+	}
+
+	if _, err := b.UpdateEvent(ctx, "apple:a@1", EventUpdateInput{}); err != nil || apple.lastID != "a@1" {
+		t.Fatalf("expected update routed to apple, got id=%q err=%v", apple.lastID, err)
+	}
+	if err := b.DeleteEvent(ctx, "fastmail:f@1", ScopeAuto); err != nil || fastmail.lastID != "f@1" {
+		t.Fatalf("expected delete routed to fastmail, got id=%q err=%v", fastmail.lastID, err)
+	}
+	if _, err := b.GetEventByID(ctx, "f@1"); err == nil || !strings.Contains(err.Error(), "backend prefix") {
+		t.Fatalf("expected unprefixed id error, got %v", err)
+	}
+
+	created, err := b.AddEvent(ctx, EventCreateInput{Calendar: "home"})
+	if err != nil || len(apple.added) != 1 || created.ID != "apple:new" {
+		t.Fatalf("expected add routed by calendar name, got %+v %v", created, err)
+	}
+	if _, err := b.AddEvent(ctx, EventCreateInput{Calendar: "Team"}); err != nil || len(fastmail.added) != 1 {
+		t.Fatalf("expected add routed by configured calendar mapping, got %v", err)
+	}
+	if _, err := b.AddEvent(ctx, EventCreateInput{Calendar: "Nope"}); err == nil {
+		t.Fatalf("expected unknown calendar error")
+	}
+
+	checks, err := b.Doctor(ctx)
+	if err != nil || len(checks) != 2 || checks[0].Name != "apple.access" {
+		t.Fatalf("unexpected doctor checks: %+v %v", checks, err)
+	}
+}
+
+func TestMultiBackendInspectSeriesRequiresInspector(t *testing.T) {
+	b := NewMultiBackend([]NamedBackend{{Name: "apple", Backend: &stubBackend{}}})
+	now := time.Now()
+	if _, err := b.InspectSeries(context.Background(), "apple:uid", now, now); !errors.Is(err, ErrSeriesUnsupported) {
+		t.Fatalf("expected ErrSeriesUnsupported, got %v", err)
+	}
+}
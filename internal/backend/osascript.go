@@ -1,29 +1,24 @@
+//go:build darwin
+
 package backend
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 	"os/exec"
 	"sort"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/agis/acal/internal/contract"
-	_ "modernc.org/sqlite"
 )
 
-const cocoaEpochOffset = int64(978307200)
-
 type OsaScriptBackend struct{}
 
 func NewOsaScriptBackend() *OsaScriptBackend { return &OsaScriptBackend{} }
 
-var calendarReadDBCache sync.Map
-
 func (b *OsaScriptBackend) Doctor(ctx context.Context) ([]contract.DoctorCheck, error) {
 	checks := []contract.DoctorCheck{}
 	if _, err := exec.LookPath("osascript"); err != nil {
@@ -43,23 +38,30 @@ func (b *OsaScriptBackend) Doctor(ctx context.Context) ([]contract.DoctorCheck,
 	}
 	checks = append(checks, contract.DoctorCheck{Name: "calendar_access", Status: "ok", Message: "Calendar automation reachable"})
 
-	if _, err := findCalendarDB(); err != nil {
+	dbPath, err := findCalendarDB()
+	if err != nil {
 		checks = append(checks, contract.DoctorCheck{Name: "calendar_db", Status: "fail", Message: err.Error()})
 		return checks, err
 	}
-	dbPath, _ := findCalendarDB()
 	if err := checkCalendarDBReadable(ctx, dbPath); err != nil {
 		msg := err.Error()
-		checks = append(checks, contract.DoctorCheck{Name: "calendar_db_read", Status: "fail", Message: msg})
+		checks = append(checks, contract.DoctorCheck{Name: "calendar_db_read", Status: "fail", Message: msg, Path: dbPath})
 		return checks, fmt.Errorf("calendar database exists but is not readable: %s", msg)
 	}
-	checks = append(checks, contract.DoctorCheck{Name: "calendar_db", Status: "ok", Message: "Calendar database found"})
+	checks = append(checks, contract.DoctorCheck{Name: "calendar_db", Status: "ok", Message: "Calendar database found", Path: dbPath})
 	checks = append(checks, contract.DoctorCheck{Name: "calendar_db_read", Status: "ok", Message: "Calendar database readable"})
+	// An unrecognized schema only costs speed: event reads fall back to
+	// AppleScript.
+	if schema, err := detectCalendarSchema(ctx, dbPath); err != nil {
+		checks = append(checks, contract.DoctorCheck{Name: "calendar_db_schema", Status: "warn", Message: err.Error() + "; events are read through the slower AppleScript fallback"})
+	} else {
+		checks = append(checks, contract.DoctorCheck{Name: "calendar_db_schema", Status: "ok", Message: schema.describe()})
+	}
 	return checks, nil
 }
 
 func (b *OsaScriptBackend) ListCalendars(ctx context.Context) ([]contract.Calendar, error) {
-	out, err := runAppleScript(ctx, []string{
+	script := append(append([]string{}, appleScriptEncodeField...),
 		`set rows to {}`,
 		`tell application "Calendar"`,
 		`repeat with c in calendars`,
@@ -69,7 +71,7 @@ func (b *OsaScriptBackend) ListCalendars(ctx context.Context) ([]contract.Calend
 		`on error`,
 		`set calID to (name of c as text)`,
 		`end try`,
-		`set rowText to calID & tab & (name of c as text) & tab & (writable of c as text)`,
+		`set rowText to my encodeField(calID) & tab & my encodeField(name of c as text) & tab & (writable of c as text)`,
 		`copy rowText to end of rows`,
 		`end repeat`,
 		`end tell`,
@@ -77,253 +79,227 @@ func (b *OsaScriptBackend) ListCalendars(ctx context.Context) ([]contract.Calend
 		`set joined to rows as text`,
 		`set AppleScript's text item delimiters to ""`,
 		`return joined`,
-	})
+	)
+	out, err := runAppleScript(ctx, script)
 	if err != nil {
 		return nil, err
 	}
 
-	lines := splitLines(out)
-	items := make([]contract.Calendar, 0, len(lines))
-	for _, line := range lines {
-		parts := strings.Split(line, "\t")
+	rows := splitRows(out)
+	items := make([]contract.Calendar, 0, len(rows))
+	for _, parts := range rows {
 		if len(parts) < 3 {
 			continue
 		}
 		items = append(items, contract.Calendar{
-			ID:       strings.TrimSpace(parts[0]),
-			Name:     strings.TrimSpace(parts[1]),
+			ID:       strings.TrimSpace(decodeAppleScriptField(parts[0])),
+			Name:     trimIfEdgeSpace(decodeAppleScriptField(parts[1])),
 			Writable: strings.EqualFold(strings.TrimSpace(parts[2]), "true"),
 		})
 	}
+	b.annotateCalendarAccounts(ctx, items)
 	return items, nil
 }
 
-func (b *OsaScriptBackend) ListEvents(ctx context.Context, f EventFilter) ([]contract.Event, error) {
-	if f.From.IsZero() || f.To.IsZero() {
-		return nil, fmt.Errorf("from/to required")
-	}
+func (b *OsaScriptBackend) annotateCalendarAccounts(ctx context.Context, items []contract.Calendar) {
 	dbPath, err := findCalendarDB()
 	if err != nil {
-		return nil, err
-	}
-
-	fromCocoa := f.From.Unix() - cocoaEpochOffset
-	toCocoa := f.To.Unix() - cocoaEpochOffset
-	if toCocoa < fromCocoa {
-		return nil, fmt.Errorf("invalid time range")
+		return
 	}
-
-	query := buildListEventsQuery(fromCocoa, toCocoa, f)
-
-	items, err := listEventsViaSQLite(ctx, dbPath, query, f.Limit)
+	accounts, err := listCalendarAccountsViaSQLite(ctx, dbPath)
 	if err != nil {
-		if !shouldFallbackFromSQLite(err) {
-			return nil, err
-		}
-		msg := err.Error()
-		items, fbErr := b.listEventsViaAppleScript(ctx, f)
-		if fbErr == nil {
-			return items, nil
+		return
+	}
+	for i := range items {
+		a, ok := accounts[items[i].ID]
+		if !ok {
+			continue
 		}
-		if isDBAccessDenied(msg) {
-			return nil, fmt.Errorf("sqlite query failed: %s (AppleScript fallback failed: %v)", msg, fbErr)
+		items[i].Account, items[i].AccountType = a.Name, a.Type
+		items[i].Subscribed = a.Type == "subscribed"
+		items[i].Shared, items[i].Delegated = a.Shared, a.Delegated
+		if items[i].Subscribed {
+			items[i].Writable = false
 		}
-		return nil, fmt.Errorf("sqlite query failed: %s (fallback failed: %v)", msg, fbErr)
 	}
-
-	return items, nil
 }
 
-func buildListEventsQuery(fromCocoa, toCocoa int64, f EventFilter) string {
-	limitClause := ""
-	if f.Limit > 0 {
-		limitClause = fmt.Sprintf("\nLIMIT %d", f.Limit)
-	}
-	calendarClause := ""
-	if len(f.Calendars) > 0 {
-		calVals := make([]string, 0, len(f.Calendars))
-		for _, c := range f.Calendars {
-			v := strings.ToLower(strings.TrimSpace(c))
-			if v == "" {
-				continue
-			}
-			calVals = append(calVals, sqlQuote(v))
-		}
-		if len(calVals) > 0 {
-			in := strings.Join(calVals, ",")
-			calendarClause = fmt.Sprintf("\n  AND (lower(COALESCE(c.UUID, CAST(c.ROWID AS TEXT))) IN (%s) OR lower(COALESCE(c.title, '')) IN (%s))", in, in)
-		}
+func (b *OsaScriptBackend) ChangeStamp(context.Context) (string, error) {
+	dbPath, err := findCalendarDB()
+	if err != nil {
+		return "", err
 	}
-	queryClause := ""
-	if q := strings.ToLower(strings.TrimSpace(f.Query)); q != "" {
-		p := sqlLikeLiteral(q)
-		switch strings.ToLower(strings.TrimSpace(f.Field)) {
-		case "", "all":
-			queryClause = fmt.Sprintf("\n  AND (lower(COALESCE(ci.summary, '')) LIKE %s ESCAPE '\\' OR lower(COALESCE(l.title, '')) LIKE %s ESCAPE '\\' OR lower(COALESCE(ci.description, '')) LIKE %s ESCAPE '\\')", p, p, p)
-		case "title":
-			queryClause = fmt.Sprintf("\n  AND lower(COALESCE(ci.summary, '')) LIKE %s ESCAPE '\\'", p)
-		case "location":
-			queryClause = fmt.Sprintf("\n  AND lower(COALESCE(l.title, '')) LIKE %s ESCAPE '\\'", p)
-		case "notes":
-			queryClause = fmt.Sprintf("\n  AND lower(COALESCE(ci.description, '')) LIKE %s ESCAPE '\\'", p)
-		default:
-			queryClause = "\n  AND 1=0"
-		}
+	stamp, err := fileChangeStamp(dbPath, dbPath+"-wal")
+	if err != nil {
+		return "", err
 	}
-	return fmt.Sprintf(`
-SELECT
-  (COALESCE(ci.unique_identifier, ci.UUID, CAST(ci.ROWID AS TEXT)) || '@' || CAST(oc.occurrence_start_date AS INTEGER)) AS id,
-  COALESCE(c.UUID, CAST(c.ROWID AS TEXT)) AS cal_id,
-  COALESCE(c.title, '') AS cal_name,
-  COALESCE(ci.summary, '') AS title,
-  CAST(oc.occurrence_start_date AS INTEGER) + %d AS start_unix,
-  CAST(oc.occurrence_end_date AS INTEGER) + %d AS end_unix,
-  COALESCE(ci.all_day, 0) AS all_day,
-  COALESCE(l.title, '') AS location,
-  COALESCE(ci.description, '') AS notes,
-  COALESCE(ci.url, '') AS url,
-  COALESCE(ci.sequence_num, 0) AS seq,
-  CAST(COALESCE(ci.last_modified, 0) AS INTEGER) + %d AS updated_unix
-FROM OccurrenceCache oc
-JOIN CalendarItem ci ON ci.ROWID = oc.event_id
-JOIN Calendar c ON c.ROWID = oc.calendar_id
-LEFT JOIN Location l ON l.item_owner_id = ci.ROWID
-WHERE oc.next_reminder_date IS NULL
-  AND oc.occurrence_start_date >= %d
-  AND oc.occurrence_start_date <= %d
-%s%s
-ORDER BY oc.occurrence_start_date ASC%s;
-`, cocoaEpochOffset, cocoaEpochOffset, cocoaEpochOffset, fromCocoa, toCocoa, calendarClause, queryClause, limitClause)
-}
-
-func sqlQuote(v string) string {
-	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
-}
-
-func sqlLikeLiteral(v string) string {
-	s := strings.ReplaceAll(v, "\\", "\\\\")
-	s = strings.ReplaceAll(s, "%", "\\%")
-	s = strings.ReplaceAll(s, "_", "\\_")
-	return sqlQuote("%" + s + "%")
+	refreshCalendarReadDB(dbPath, stamp)
+	return stamp, nil
 }
 
-func checkCalendarDBReadable(ctx context.Context, dbPath string) error {
-	db, err := openCalendarReadDB(dbPath)
-	if err != nil {
-		return err
-	}
-	var one int
-	return db.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+func (b *OsaScriptBackend) ListEvents(ctx context.Context, f EventFilter) ([]contract.Event, error) {
+	return sharedListEvents(ctx, f, func() ([]contract.Event, error) {
+		return b.listEvents(ctx, f)
+	})
 }
 
-func listEventsViaSQLite(ctx context.Context, dbPath, query string, expectedRows int) ([]contract.Event, error) {
-	db, err := openCalendarReadDB(dbPath)
-	if err != nil {
-		return nil, err
+func (b *OsaScriptBackend) listEvents(ctx context.Context, f EventFilter) ([]contract.Event, error) {
+	dbPath, query, err := listEventsSQLiteQuery(ctx, f)
+	var detectErr *schemaDetectionError
+	if errors.As(err, &detectErr) {
+		return b.listEventsFallback(ctx, f, detectErr.err)
 	}
-	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	items := make([]contract.Event, 0, initialEventCapacity(expectedRows))
-	for rows.Next() {
-		var id, calID, calName, title, location, notes, url string
-		var startUnix, endUnix, allDayRaw, seq, updatedUnix int64
-		if err := rows.Scan(&id, &calID, &calName, &title, &startUnix, &endUnix, &allDayRaw, &location, &notes, &url, &seq, &updatedUnix); err != nil {
+	items, err := withRetries(ctx, "sqlite", isTransientSQLiteError, func() ([]contract.Event, error) {
+		return listEventsViaSQLite(ctx, dbPath, query, f.Limit)
+	})
+	if err != nil {
+		if !shouldFallbackFromSQLite(err) {
 			return nil, err
 		}
-		items = append(items, contract.Event{
-			ID:           trimIfEdgeSpace(id),
-			CalendarID:   trimIfEdgeSpace(calID),
-			CalendarName: trimIfEdgeSpace(calName),
-			Title:        trimIfEdgeSpace(title),
-			Start:        time.Unix(startUnix, 0),
-			End:          time.Unix(endUnix, 0),
-			AllDay:       allDayRaw == 1,
-			Location:     trimIfEdgeSpace(location),
-			Notes:        trimIfEdgeSpace(notes),
-			URL:          trimIfEdgeSpace(url),
-			Sequence:     int(seq),
-			UpdatedAt:    time.Unix(updatedUnix, 0),
-		})
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return b.listEventsFallback(ctx, f, err)
 	}
+	warnOnOccurrenceCacheLag(ctx, dbPath, f.To)
 	return items, nil
 }
 
-func initialEventCapacity(expectedRows int) int {
-	switch {
-	case expectedRows <= 0:
-		return 64
-	case expectedRows > 2048:
-		return 2048
-	default:
-		return expectedRows
+func (b *OsaScriptBackend) StreamEvents(ctx context.Context, f EventFilter, emit func(contract.Event) error) error {
+	dbPath, query, err := listEventsSQLiteQuery(ctx, f)
+	var detectErr *schemaDetectionError
+	if errors.As(err, &detectErr) {
+		err = detectErr.err
+	} else if err != nil {
+		return err
+	}
+
+	emitted := 0
+	if detectErr == nil {
+		_, err = withRetries(ctx, "sqlite", func(err error) bool {
+			return emitted == 0 && isTransientSQLiteError(err)
+		}, func() (struct{}, error) {
+			return struct{}{}, streamEventsViaSQLite(ctx, dbPath, query, func(e contract.Event) error {
+				emitted++
+				return emit(e)
+			})
+		})
+		if err == nil {
+			warnOnOccurrenceCacheLag(ctx, dbPath, f.To)
+			return nil
+		}
+		if emitted > 0 || !shouldFallbackFromSQLite(err) {
+			return err
+		}
+	}
+	items, err := b.listEventsFallback(ctx, f, err)
+	if err != nil {
+		return err
+	}
+	for _, e := range items {
+		if err := emit(e); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func openCalendarReadDB(dbPath string) (*sql.DB, error) {
-	dsn := calendarSQLiteDSN(dbPath)
-	if v, ok := calendarReadDBCache.Load(dsn); ok {
-		return v.(*sql.DB), nil
+func listEventsSQLiteQuery(ctx context.Context, f EventFilter) (string, sqliteQuery, error) {
+	if f.From.IsZero() || f.To.IsZero() {
+		return "", sqliteQuery{}, fmt.Errorf("from/to required")
+	}
+	dbPath, err := findCalendarDB()
+	if err != nil {
+		return "", sqliteQuery{}, err
 	}
 
-	db, err := sql.Open("sqlite", dsn)
+	fromCocoa := f.From.Unix() - cocoaEpochOffset
+	toCocoa := f.To.Unix() - cocoaEpochOffset
+	if toCocoa < fromCocoa {
+		return "", sqliteQuery{}, fmt.Errorf("invalid time range")
+	}
+	schema, err := listEventsSchema(ctx, dbPath)
 	if err != nil {
-		return nil, err
+		return "", sqliteQuery{}, err
 	}
-	db.SetMaxOpenConns(1)
-	db.SetMaxIdleConns(1)
+	return dbPath, buildListEventsQuery(schema, fromCocoa, toCocoa, f), nil
+}
 
-	if existing, loaded := calendarReadDBCache.LoadOrStore(dsn, db); loaded {
-		_ = db.Close()
-		return existing.(*sql.DB), nil
+func (b *OsaScriptBackend) listEventsFallback(ctx context.Context, f EventFilter, err error) ([]contract.Event, error) {
+	items, fbErr := b.listEventsViaAppleScript(ctx, f)
+	if fbErr != nil {
+		return nil, sqliteFallbackError(err, fbErr)
 	}
-	return db, nil
+	warnAppleScriptFallback(ctx, err)
+	return items, nil
 }
 
-func calendarSQLiteDSN(dbPath string) string {
-	return "file:" + dbPath + "?mode=ro&immutable=1"
+func warnAppleScriptFallback(ctx context.Context, err error) {
+	RecordWarning(ctx, contract.WarnAppleScriptFallback, "Calendar database read failed ("+err.Error()+"); events were listed through the slower AppleScript fallback")
 }
 
-func shouldFallbackFromSQLite(err error) bool {
-	if err == nil {
-		return false
+func sqliteFallbackError(err, fbErr error) error {
+	msg := err.Error()
+	if isDBAccessDenied(msg) {
+		return fmt.Errorf("sqlite query failed: %w (AppleScript fallback failed: %v)", err, fbErr)
 	}
-	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	return fmt.Errorf("sqlite query failed: %w (fallback failed: %v)", err, fbErr)
 }
 
 func (b *OsaScriptBackend) listEventsViaAppleScript(ctx context.Context, f EventFilter) ([]contract.Event, error) {
-	fromUnix := strconv.FormatInt(f.From.Unix(), 10)
-	toUnix := strconv.FormatInt(f.To.Unix(), 10)
-	out, err := runAppleScript(ctx, []string{
-		`on cleanText(v)`,
-		`set s to v as text`,
-		`set AppleScript's text item delimiters to tab`,
-		`set parts to text items of s`,
-		`set AppleScript's text item delimiters to " "`,
-		`set s to parts as text`,
-		`set AppleScript's text item delimiters to return`,
-		`set parts to text items of s`,
-		`set AppleScript's text item delimiters to " "`,
-		`set s to parts as text`,
-		`set AppleScript's text item delimiters to linefeed`,
-		`set parts to text items of s`,
-		`set AppleScript's text item delimiters to " "`,
-		`set s to parts as text`,
-		`set AppleScript's text item delimiters to ""`,
-		`return s`,
-		`end cleanText`,
+	var items []contract.Event
+	var slowest time.Duration
+	windows := splitWindows(f.From, f.To, appleScriptWindow)
+	for i, w := range windows {
+		if i > 0 && !windowFits(ctx, slowest) {
+			RecordWarning(ctx, contract.WarnFallbackIncomplete, fmt.Sprintf("AppleScript fallback ran out of time; events from %s on are missing", w.From.Format(time.RFC3339)))
+			break
+		}
+		started := time.Now()
+		// Only the first window reaches back for events already under way.
+		batch, err := b.listEventWindowViaAppleScript(ctx, f, w, f.Overlap && i == 0)
+		if err != nil {
+			return nil, err
+		}
+		if d := time.Since(started); d > slowest {
+			slowest = d
+		}
+		for _, e := range batch {
+			if matchesAppleScriptFilter(e, f) {
+				items = append(items, e)
+			}
+		}
+		if f.Limit > 0 && len(items) >= f.Limit {
+			break
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Start.Equal(items[j].Start) {
+			return items[i].ID < items[j].ID
+		}
+		return items[i].Start.Before(items[j].Start)
+	})
+	if f.Limit > 0 && len(items) > f.Limit {
+		items = items[:f.Limit]
+	}
+	return items, nil
+}
+
+func (b *OsaScriptBackend) listEventWindowViaAppleScript(ctx context.Context, f EventFilter, w timeWindow, overlap bool) ([]contract.Event, error) {
+	fromUnix := strconv.FormatInt(w.From.Unix(), 10)
+	toUnix := strconv.FormatInt(w.To.Unix(), 10)
+	script := append(append([]string{}, appleScriptEncodeField...),
 		`on run argv`,
 		`set fromUnix to item 1 of argv as integer`,
 		`set toUnix to item 2 of argv as integer`,
 		`set epoch to date "1/1/1970 00:00:00"`,
 		`set fromDate to epoch + fromUnix`,
 		`set toDate to epoch + toUnix`,
+		`set overlapText to item 3 of argv`,
+		`set detailsText to item 4 of argv`,
+		`set wanted to paragraphs of item 5 of argv`,
+		`set eventTimeout to item 6 of argv as integer`,
 		`set rows to {}`,
 		`tell application "Calendar"`,
 		`repeat with c in calendars`,
@@ -333,22 +309,48 @@ func (b *OsaScriptBackend) listEventsViaAppleScript(ctx context.Context, f Event
 		`on error`,
 		`set calID to (name of c as text)`,
 		`end try`,
-		`set calName to my cleanText(name of c as text)`,
-		`repeat with e in (every event of c whose start date >= fromDate and start date <= toDate)`,
+		`set rawName to (name of c as text)`,
+		`if wanted is {} or wanted contains rawName or wanted contains calID then`,
+		`set calID to my encodeField(calID)`,
+		`set calName to my encodeField(rawName)`,
+		`set matched to {}`,
+		`try`,
+		`with timeout of eventTimeout seconds`,
+		`if overlapText is "true" then`,
+		`set matched to (every event of c whose start date < toDate and end date > fromDate)`,
+		`else`,
+		`set matched to (every event of c whose start date >= fromDate and start date < toDate)`,
+		`end if`,
+		`end timeout`,
+		`on error`,
+		`copy ("!" & tab & calName) to end of rows`,
+		`end try`,
+		`repeat with e in matched`,
 		`set evStartDate to start date of e`,
-		`set evUID to (uid of e as text)`,
-		`set evTitle to my cleanText(summary of e as text)`,
+		`set evUID to my encodeField(uid of e as text)`,
+		`set evTitle to my encodeField(summary of e as text)`,
 		`set evEndDate to end date of e`,
 		`set evStartUnix to ((evStartDate - epoch) as integer)`,
 		`set evEndUnix to ((evEndDate - epoch) as integer)`,
 		`set evAllDay to (allday event of e as text)`,
 		`set evLoc to ""`,
 		`try`,
-		`set evLoc to my cleanText(location of e as text)`,
+		`set evLoc to my encodeOptional(location of e)`,
 		`end try`,
-		`set rowText to evUID & tab & calID & tab & calName & tab & evTitle & tab & (evStartUnix as text) & tab & (evEndUnix as text) & tab & evAllDay & tab & evLoc & tab & "" & tab & ""`,
+		`set evNotes to ""`,
+		`set evURL to ""`,
+		`if detailsText is "true" then`,
+		`try`,
+		`set evNotes to my encodeOptional(description of e)`,
+		`end try`,
+		`try`,
+		`set evURL to my encodeOptional(url of e)`,
+		`end try`,
+		`end if`,
+		`set rowText to evUID & tab & calID & tab & calName & tab & evTitle & tab & (evStartUnix as text) & tab & (evEndUnix as text) & tab & evAllDay & tab & evLoc & tab & evNotes & tab & evURL`,
 		`copy rowText to end of rows`,
 		`end repeat`,
+		`end if`,
 		`end repeat`,
 		`end tell`,
 		`set AppleScript's text item delimiters to linefeed`,
@@ -356,69 +358,26 @@ func (b *OsaScriptBackend) listEventsViaAppleScript(ctx context.Context, f Event
 		`set AppleScript's text item delimiters to ""`,
 		`return joined`,
 		`end run`,
-	}, fromUnix, toUnix)
+	)
+	details := f.Wants("notes") || f.Wants("url")
+	timeout := strconv.Itoa(appleScriptEventTimeout(ctx))
+	out, err := runAppleScript(ctx, script, fromUnix, toUnix, boolToScript(overlap), boolToScript(details), strings.Join(f.Calendars, "\n"), timeout)
 	if err != nil {
 		return nil, err
 	}
-	lines := splitLines(out)
-	items := make([]contract.Event, 0, len(lines))
-	for _, line := range lines {
-		parts := strings.Split(line, "\t")
-		if len(parts) < 10 {
-			continue
-		}
-		startUnix, err := strconv.ParseInt(strings.TrimSpace(parts[4]), 10, 64)
-		if err != nil {
+	rows := splitRows(out)
+	items := make([]contract.Event, 0, len(rows))
+	for _, parts := range rows {
+		if len(parts) == 2 && parts[0] == "!" {
+			RecordWarning(ctx, contract.WarnFallbackIncomplete, fmt.Sprintf("Calendar.app did not answer for calendar %q between %s and %s; its events there are missing", decodeAppleScriptField(parts[1]), w.From.Format(time.RFC3339), w.To.Format(time.RFC3339)))
 			continue
 		}
-		endUnix, err := strconv.ParseInt(strings.TrimSpace(parts[5]), 10, 64)
-		if err != nil {
-			continue
-		}
-		start := time.Unix(startUnix, 0).In(f.From.Location())
-		end := time.Unix(endUnix, 0).In(f.From.Location())
-		startCocoa := start.Unix() - cocoaEpochOffset
-		e := contract.Event{
-			ID:           fmt.Sprintf("%s@%d", strings.TrimSpace(parts[0]), startCocoa),
-			CalendarID:   strings.TrimSpace(parts[1]),
-			CalendarName: strings.TrimSpace(parts[2]),
-			Title:        strings.TrimSpace(parts[3]),
-			Start:        start,
-			End:          end,
-			AllDay:       strings.EqualFold(strings.TrimSpace(parts[6]), "true"),
-			Location:     strings.TrimSpace(parts[7]),
-			Notes:        strings.TrimSpace(parts[8]),
-			URL:          strings.TrimSpace(parts[9]),
-			Sequence:     0,
-			UpdatedAt:    time.Time{},
-		}
-		if len(f.Calendars) > 0 && !containsFold(f.Calendars, e.CalendarID) && !containsFold(f.Calendars, e.CalendarName) {
+		e, ok := appleScriptEventRow(parts, f.From.Location())
+		if !ok {
 			continue
 		}
-		if f.Query != "" {
-			needle := strings.ToLower(f.Query)
-			field := strings.ToLower(f.Field)
-			if field == "" || field == "all" {
-				if !strings.Contains(strings.ToLower(e.Title), needle) && !strings.Contains(strings.ToLower(e.Location), needle) && !strings.Contains(strings.ToLower(e.Notes), needle) {
-					continue
-				}
-			} else {
-				if !strings.Contains(strings.ToLower(selectField(e, field)), needle) {
-					continue
-				}
-			}
-		}
 		items = append(items, e)
 	}
-	sort.Slice(items, func(i, j int) bool {
-		if items[i].Start.Equal(items[j].Start) {
-			return items[i].ID < items[j].ID
-		}
-		return items[i].Start.Before(items[j].Start)
-	})
-	if f.Limit > 0 && len(items) > f.Limit {
-		items = items[:f.Limit]
-	}
 	return items, nil
 }
 
@@ -0,0 +1,18 @@
+//go:build darwin
+
+package backend
+
+import (
+	"context"
+	"fmt"
+)
+
+func (b *OsaScriptBackend) ListBirthdays(ctx context.Context) ([]Birthday, error) {
+	paths := findContactsDBs()
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("contacts database not found")
+	}
+	return withRetries(ctx, "sqlite", isTransientSQLiteError, func() ([]Birthday, error) {
+		return listBirthdaysViaSQLite(ctx, paths)
+	})
+}
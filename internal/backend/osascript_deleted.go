@@ -0,0 +1,18 @@
+//go:build darwin
+
+package backend
+
+import (
+	"context"
+	"time"
+)
+
+func (b *OsaScriptBackend) ListDeletedEvents(ctx context.Context, since time.Time) ([]DeletedEvent, error) {
+	dbPath, err := findCalendarDB()
+	if err != nil {
+		return nil, err
+	}
+	return withRetries(ctx, "sqlite", isTransientSQLiteError, func() ([]DeletedEvent, error) {
+		return listDeletedEventsViaSQLite(ctx, dbPath, since)
+	})
+}
@@ -1,42 +1,158 @@
 package backend
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/agis/acal/internal/contract"
 )
 
+const calendarDBOverrideEnv = "ACAL_CALENDAR_DB"
+
+var sqliteHeader = []byte("SQLite format 3\x00")
+
 func findCalendarDB() (string, error) {
+	override, home := strings.TrimSpace(os.Getenv(calendarDBOverrideEnv)), os.Getenv("HOME")
+	key := override + "\x00" + home
+	if v, ok := foundCalendarDBs.Load(key); ok {
+		return v.(string), nil
+	}
+	p, err := discoverCalendarDB(override, home)
+	if err != nil {
+		return "", err
+	}
+	foundCalendarDBs.Store(key, p)
+	return p, nil
+}
+
+var foundCalendarDBs sync.Map
+
+func discoverCalendarDB(override, home string) (string, error) {
+	if override != "" {
+		if err := validateCalendarDB(override); err != nil {
+			return "", fmt.Errorf("%s=%s: %w", calendarDBOverrideEnv, override, err)
+		}
+		return override, nil
+	}
 	candidates := []string{
-		filepath.Join(os.Getenv("HOME"), "Library/Group Containers/group.com.apple.calendar/Calendar.sqlitedb"),
-		filepath.Join(os.Getenv("HOME"), "Library/Calendars/Calendar.sqlitedb"),
+		filepath.Join(home, "Library/Group Containers/group.com.apple.calendar/Calendar.sqlitedb"),
+		filepath.Join(home, "Library/Calendars/Calendar.sqlitedb"),
 	}
+	candidates = append(candidates, groupContainerCalendarDBs(home)...)
 	for _, p := range candidates {
-		if _, err := os.Stat(p); err == nil {
+		if validateCalendarDB(p) == nil {
 			return p, nil
 		}
 	}
-	return "", fmt.Errorf("calendar database not found")
+	return "", fmt.Errorf("calendar database not found in ~/Library/Group Containers or ~/Library/Calendars; set %s to its path", calendarDBOverrideEnv)
+}
+
+func groupContainerCalendarDBs(home string) []string {
+	matches, _ := filepath.Glob(filepath.Join(home, "Library/Group Containers/*/Calendar.sqlitedb"))
+	mtime := func(p string) int64 {
+		info, err := os.Stat(p)
+		if err != nil {
+			return 0
+		}
+		return info.ModTime().UnixNano()
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		ci := strings.Contains(strings.ToLower(filepath.Base(filepath.Dir(matches[i]))), "calendar")
+		cj := strings.Contains(strings.ToLower(filepath.Base(filepath.Dir(matches[j]))), "calendar")
+		if ci != cj {
+			return ci
+		}
+		return mtime(matches[i]) > mtime(matches[j])
+	})
+	return matches
+}
+
+func validateCalendarDB(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("not a regular file")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	header := make([]byte, len(sqliteHeader))
+	_, err = io.ReadFull(f, header)
+	_ = f.Close()
+	if err != nil || !bytes.Equal(header, sqliteHeader) {
+		return fmt.Errorf("not a SQLite database")
+	}
+	db, err := sql.Open("sqlite", calendarSQLiteDSN(path))
+	if err != nil {
+		return nil
+	}
+	defer db.Close()
+	var n int
+	if err := db.QueryRow(`SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name IN ('CalendarItem', 'Calendar')`).Scan(&n); err != nil {
+		return nil
+	}
+	if n < 2 {
+		return fmt.Errorf("not a Calendar database (no Calendar and CalendarItem tables)")
+	}
+	return nil
+}
+
+func fileChangeStamp(paths ...string) (string, error) {
+	var b strings.Builder
+	found := false
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		found = true
+		fmt.Fprintf(&b, "%s:%d:%d;", filepath.Base(p), info.Size(), info.ModTime().UnixNano())
+	}
+	if !found {
+		return "", fmt.Errorf("calendar database not found")
+	}
+	return b.String(), nil
 }
 
 func runAppleScript(ctx context.Context, lines []string, args ...string) (string, error) {
+	return runAppleScriptAs(ctx, "osascript", nil, func(err error) bool { return isTransientAppleScriptError(err.Error()) }, lines, args...)
+}
+
+// runAppleScriptWrite retries only writes that never ran; a timeout may arrive after the change.
+func runAppleScriptWrite(ctx context.Context, lines []string, args ...string) (string, error) {
+	pace := func(ctx context.Context) error { return osascriptWrites.wait(ctx, maxWritesPerSecond(ctx)) }
+	return runAppleScriptAs(ctx, "osascript.write", pace, isScriptNotStarted, lines, args...)
+}
+
+func runAppleScriptAs(ctx context.Context, name string, pace func(context.Context) error, transient func(error) bool, lines []string, args ...string) (string, error) {
 	cmdArgs := []string{"-s", "s"}
 	for _, line := range lines {
 		cmdArgs = append(cmdArgs, "-e", line)
 	}
 	cmdArgs = append(cmdArgs, args...)
-	retries, backoff := osascriptRetryPolicy()
-	var lastErr error
-	for attempt := 0; attempt <= retries; attempt++ {
-		cmd := exec.CommandContext(ctx, "osascript", cmdArgs...)
-		out, err := cmd.CombinedOutput()
+	return withRetries(ctx, name, transient, func() (string, error) {
+		if pace != nil {
+			if err := pace(ctx); err != nil {
+				return "", &scriptNotStartedError{err: err}
+			}
+		}
+		out, err := runHelperCommand(ctx, "osascript", cmdArgs...)
 		if err == nil {
 			return string(out), nil
 		}
@@ -44,18 +160,36 @@ func runAppleScript(ctx context.Context, lines []string, args ...string) (string
 		if msg == "" {
 			msg = err.Error()
 		}
-		lastErr = fmt.Errorf("osascript failed: %s", msg)
-		if attempt == retries || !isTransientAppleScriptError(msg) {
-			break
-		}
-		wait := backoff * time.Duration(1<<attempt)
-		select {
-		case <-ctx.Done():
-			return "", ctx.Err()
-		case <-time.After(wait):
+		cmdErr := &CommandError{Command: "osascript", Output: msg}
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) && ctx.Err() == nil {
+			return "", &scriptNotStartedError{err: cmdErr}
 		}
+		return "", cmdErr
+	})
+}
+
+const helperWaitDelay = 2 * time.Second
+
+// runHelperCommand kills the helper's whole process group on cancel.
+func runHelperCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	killGroupOnCancel(cmd)
+	cmd.WaitDelay = helperWaitDelay
+	out, err := cmd.CombinedOutput()
+	if err != nil && ctx.Err() != nil {
+		return out, ctx.Err()
 	}
-	return "", lastErr
+	return out, err
+}
+
+type CommandError struct {
+	Command string
+	Output  string
+}
+
+func (e *CommandError) Error() string {
+	return e.Command + " failed: " + e.Output
 }
 
 func osascriptRetryPolicy() (int, time.Duration) {
@@ -100,6 +234,82 @@ func trimOuterQuotes(s string) string {
 	return s
 }
 
+var appleScriptEncodeField = []string{
+	`on replaceText(s, a, b)`,
+	`set AppleScript's text item delimiters to a`,
+	`set parts to text items of s`,
+	`set AppleScript's text item delimiters to b`,
+	`set s to parts as text`,
+	`set AppleScript's text item delimiters to ""`,
+	`return s`,
+	`end replaceText`,
+	`on encodeField(v)`,
+	`set s to v as text`,
+	`set s to my replaceText(s, "%", "%25")`,
+	`set s to my replaceText(s, tab, "%09")`,
+	`set s to my replaceText(s, return, "%0D")`,
+	`set s to my replaceText(s, linefeed, "%0A")`,
+	`set s to my replaceText(s, quote, "%22")`,
+	`set s to my replaceText(s, "\\", "%5C")`,
+	`return s`,
+	`end encodeField`,
+	`on encodeOptional(v)`,
+	`if v is missing value then return ""`,
+	`return my encodeField(v)`,
+	`end encodeOptional`,
+}
+
+func decodeAppleScriptField(s string) string {
+	out, err := url.PathUnescape(s)
+	if err != nil {
+		return s
+	}
+	return out
+}
+
+func appleScriptEventRow(parts []string, loc *time.Location) (contract.Event, bool) {
+	if len(parts) < 10 {
+		return contract.Event{}, false
+	}
+	fields := make([]string, len(parts))
+	for i, p := range parts {
+		fields[i] = decodeAppleScriptField(p)
+	}
+	startUnix, err := strconv.ParseInt(strings.TrimSpace(fields[4]), 10, 64)
+	if err != nil {
+		return contract.Event{}, false
+	}
+	endUnix, err := strconv.ParseInt(strings.TrimSpace(fields[5]), 10, 64)
+	if err != nil {
+		return contract.Event{}, false
+	}
+	start := time.Unix(startUnix, 0).In(loc)
+	return contract.Event{
+		ID:           fmt.Sprintf("%s@%d", strings.TrimSpace(fields[0]), start.Unix()-cocoaEpochOffset),
+		CalendarID:   strings.TrimSpace(fields[1]),
+		CalendarName: trimIfEdgeSpace(fields[2]),
+		Title:        trimIfEdgeSpace(fields[3]),
+		Start:        start,
+		End:          time.Unix(endUnix, 0).In(loc),
+		AllDay:       strings.EqualFold(strings.TrimSpace(fields[6]), "true"),
+		Location:     trimIfEdgeSpace(fields[7]),
+		Notes:        trimIfEdgeSpace(fields[8]),
+		URL:          strings.TrimSpace(fields[9]),
+	}, true
+}
+
+func splitRows(s string) [][]string {
+	s = trimOuterQuotes(strings.TrimSpace(s))
+	var rows [][]string
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		rows = append(rows, strings.Split(line, "\t"))
+	}
+	return rows
+}
+
 func splitLines(s string) []string {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -119,6 +329,68 @@ func splitLines(s string) []string {
 	return out
 }
 
+const appleScriptWindow = 30 * 24 * time.Hour
+
+const appleScriptMaxEventTimeout = 120
+
+type timeWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+func splitWindows(from, to time.Time, size time.Duration) []timeWindow {
+	if !to.After(from) {
+		return []timeWindow{{From: from, To: to}}
+	}
+	var out []timeWindow
+	for start := from; start.Before(to); start = start.Add(size) {
+		end := start.Add(size)
+		if end.After(to) {
+			end = to
+		}
+		out = append(out, timeWindow{From: start, To: end})
+	}
+	return out
+}
+
+func windowFits(ctx context.Context, need time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return true
+	}
+	return time.Until(deadline) > need
+}
+
+func appleScriptEventTimeout(ctx context.Context) int {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return appleScriptMaxEventTimeout
+	}
+	secs := int(time.Until(deadline) / 2 / time.Second)
+	if secs < 1 {
+		return 1
+	}
+	if secs > appleScriptMaxEventTimeout {
+		return appleScriptMaxEventTimeout
+	}
+	return secs
+}
+
+func matchesAppleScriptFilter(e contract.Event, f EventFilter) bool {
+	if len(f.Calendars) > 0 && !containsFold(f.Calendars, e.CalendarID) && !containsFold(f.Calendars, e.CalendarName) {
+		return false
+	}
+	if f.Query == "" {
+		return true
+	}
+	needle := strings.ToLower(f.Query)
+	field := strings.ToLower(f.Field)
+	if field == "" || field == "all" {
+		return strings.Contains(strings.ToLower(e.Title), needle) || strings.Contains(strings.ToLower(e.Location), needle) || strings.Contains(strings.ToLower(e.Notes), needle)
+	}
+	return strings.Contains(strings.ToLower(selectField(e, field)), needle)
+}
+
 func containsFold(items []string, val string) bool {
 	for _, item := range items {
 		if strings.EqualFold(strings.TrimSpace(item), strings.TrimSpace(val)) {
@@ -167,3 +439,42 @@ func isDBAccessDenied(msg string) bool {
 		strings.Contains(s, "operation not permitted") ||
 		strings.Contains(s, "permission denied")
 }
+
+func boolToScript(v bool) string {
+	if v {
+		return "true"
+	}
+	return "false"
+}
+
+func splitSeriesRecurrence(rule string, occStart time.Time, before int) (string, string) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	head := truncateRRULE(rule, occStart.Add(-time.Second).UTC().Format("20060102T150405Z"))
+	parts := strings.Split(rule, ";")
+	for i, part := range parts {
+		key, val, _ := strings.Cut(part, "=")
+		if !strings.EqualFold(strings.TrimSpace(key), "COUNT") {
+			continue
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(val))
+		if err != nil {
+			break
+		}
+		remaining := count - before
+		if remaining < 1 {
+			remaining = 1
+		}
+		parts[i] = "COUNT=" + strconv.Itoa(remaining)
+	}
+	return head, strings.Join(parts, ";")
+}
+
+func recurrenceHasCount(rule string) bool {
+	for _, part := range strings.Split(rule, ";") {
+		key, _, _ := strings.Cut(part, "=")
+		if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(key, "RRULE:")), "COUNT") {
+			return true
+		}
+	}
+	return false
+}
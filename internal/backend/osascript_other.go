@@ -0,0 +1,65 @@
+//go:build !darwin
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+type OsaScriptBackend struct{}
+
+func NewOsaScriptBackend() *OsaScriptBackend { return &OsaScriptBackend{} }
+
+func osascriptUnavailable() error {
+	return fmt.Errorf("osascript backend requires macOS (running on %s): %w", runtime.GOOS, ErrBackendUnavailable)
+}
+
+func (b *OsaScriptBackend) Doctor(context.Context) ([]contract.DoctorCheck, error) {
+	err := osascriptUnavailable()
+	return []contract.DoctorCheck{{Name: "osascript", Status: "fail", Message: err.Error()}}, err
+}
+
+func (b *OsaScriptBackend) ListCalendars(context.Context) ([]contract.Calendar, error) {
+	return nil, osascriptUnavailable()
+}
+
+func (b *OsaScriptBackend) ListEvents(context.Context, EventFilter) ([]contract.Event, error) {
+	return nil, osascriptUnavailable()
+}
+
+func (b *OsaScriptBackend) GetEventByID(context.Context, string) (*contract.Event, error) {
+	return nil, osascriptUnavailable()
+}
+
+func (b *OsaScriptBackend) GetReminderOffset(context.Context, string) (*time.Duration, error) {
+	return nil, osascriptUnavailable()
+}
+
+func (b *OsaScriptBackend) AddEvent(context.Context, EventCreateInput) (*contract.Event, error) {
+	return nil, osascriptUnavailable()
+}
+
+func (b *OsaScriptBackend) UpdateEvent(context.Context, string, EventUpdateInput) (*contract.Event, error) {
+	return nil, osascriptUnavailable()
+}
+
+func (b *OsaScriptBackend) DeleteEvent(context.Context, string, RecurrenceScope) error {
+	return osascriptUnavailable()
+}
+
+func (b *OsaScriptBackend) ListBirthdays(context.Context) ([]Birthday, error) {
+	return nil, osascriptUnavailable()
+}
+
+func (b *OsaScriptBackend) InspectSeries(context.Context, string, time.Time, time.Time) (*Series, error) {
+	return nil, osascriptUnavailable()
+}
+
+func (b *OsaScriptBackend) ListDeletedEvents(context.Context, time.Time) ([]DeletedEvent, error) {
+	return nil, osascriptUnavailable()
+}
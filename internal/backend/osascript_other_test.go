@@ -0,0 +1,26 @@
+//go:build !darwin
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestOsaScriptBackendUnavailableOffDarwin(t *testing.T) {
+	b := NewOsaScriptBackend()
+	checks, err := b.Doctor(context.Background())
+	if !errors.Is(err, ErrBackendUnavailable) {
+		t.Fatalf("expected backend unavailable from doctor, got %v", err)
+	}
+	if len(checks) != 1 || checks[0].Name != "osascript" || checks[0].Status != "fail" {
+		t.Fatalf("unexpected doctor checks: %+v", checks)
+	}
+	if _, err := b.ListEvents(context.Background(), EventFilter{}); !errors.Is(err, ErrBackendUnavailable) {
+		t.Fatalf("expected backend unavailable from list, got %v", err)
+	}
+	if err := b.DeleteEvent(context.Background(), "x", ScopeAuto); !errors.Is(err, ErrBackendUnavailable) {
+		t.Fatalf("expected backend unavailable from delete, got %v", err)
+	}
+}
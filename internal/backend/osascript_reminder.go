@@ -1,3 +1,5 @@
+//go:build darwin
+
 package backend
 
 import (
@@ -37,6 +39,10 @@ func (b *OsaScriptBackend) GetReminderOffset(ctx context.Context, id string) (*t
 		`if targetEvent is not missing value then`,
 		`if (count of display alarms of targetEvent) is 0 then return "NONE"`,
 		`set a to first display alarm of targetEvent`,
+		`try`,
+		`set td to trigger date of a`,
+		`if td is not missing value then return (((td - (start date of targetEvent)) div 60) as text)`,
+		`end try`,
 		`return (trigger interval of a as text)`,
 		`end if`,
 		`end repeat`,
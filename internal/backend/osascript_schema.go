@@ -0,0 +1,189 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var calendarSchemaTables = []string{"OccurrenceCache", "CalendarItem", "Calendar", "Location"}
+
+var calendarSchemaRequired = map[string][]string{
+	"OccurrenceCache": {"event_id", "calendar_id", "occurrence_start_date", "occurrence_end_date"},
+	"CalendarItem":    {"summary"},
+	"Calendar":        {"title"},
+}
+
+const (
+	locationByOwner = "location_owner"
+	locationByID    = "location_id"
+	locationNone    = "no_location"
+)
+
+type calendarSchema struct {
+	Version int64
+	Variant string
+	columns map[string]map[string]bool
+}
+
+type UnsupportedSchemaError struct {
+	Version int64
+	Missing []string
+}
+
+func (e *UnsupportedSchemaError) Error() string {
+	return fmt.Sprintf("unsupported Calendar database schema (user_version %d): missing %s", e.Version, strings.Join(e.Missing, ", "))
+}
+
+func (e *UnsupportedSchemaError) Is(target error) bool { return target == ErrUnsupportedSchema }
+
+var calendarSchemaCache sync.Map
+
+func (s calendarSchema) has(table, column string) bool {
+	if strings.EqualFold(column, "ROWID") {
+		return s.columns[table] != nil
+	}
+	return s.columns[table][strings.ToLower(column)]
+}
+
+func (s calendarSchema) col(alias, table, column, fallback string) string {
+	if !s.has(table, column) {
+		return fallback
+	}
+	return fmt.Sprintf("COALESCE(%s.%s, %s)", alias, column, fallback)
+}
+
+func (s calendarSchema) itemID() string {
+	parts := []string{}
+	for _, c := range []string{"unique_identifier", "UUID"} {
+		if s.has("CalendarItem", c) {
+			parts = append(parts, "ci."+c)
+		}
+	}
+	return fmt.Sprintf("COALESCE(%s)", strings.Join(append(parts, "CAST(ci.ROWID AS TEXT)"), ", "))
+}
+
+func (s calendarSchema) calendarID() string {
+	if s.has("Calendar", "UUID") {
+		return "COALESCE(c.UUID, CAST(c.ROWID AS TEXT))"
+	}
+	return "CAST(c.ROWID AS TEXT)"
+}
+
+func (s calendarSchema) locationJoin() string {
+	switch s.Variant {
+	case locationByOwner:
+		return "\nLEFT JOIN Location l ON l.item_owner_id = ci.ROWID"
+	case locationByID:
+		return "\nLEFT JOIN Location l ON l.ROWID = ci.location_id"
+	default:
+		return ""
+	}
+}
+
+func (s calendarSchema) locationTitle() string {
+	if s.Variant == locationNone {
+		return "''"
+	}
+	return "COALESCE(l.title, '')"
+}
+
+func detectCalendarSchema(ctx context.Context, dbPath string) (calendarSchema, error) {
+	if v, ok := calendarSchemaCache.Load(dbPath); ok {
+		return v.(calendarSchema), nil
+	}
+	db, release, err := openCalendarReadDB(dbPath)
+	if err != nil {
+		return calendarSchema{}, err
+	}
+	defer release()
+	s, err := inspectCalendarSchema(ctx, db)
+	if err != nil {
+		return calendarSchema{}, err
+	}
+	calendarSchemaCache.Store(dbPath, s)
+	return s, nil
+}
+
+type schemaDetectionError struct {
+	err error
+}
+
+func (e *schemaDetectionError) Error() string { return e.err.Error() }
+func (e *schemaDetectionError) Unwrap() error { return e.err }
+
+func listEventsSchema(ctx context.Context, dbPath string) (calendarSchema, error) {
+	s, err := detectCalendarSchema(ctx, dbPath)
+	if err != nil && shouldFallbackFromSQLite(err) {
+		return calendarSchema{}, &schemaDetectionError{err: err}
+	}
+	return s, err
+}
+
+func inspectCalendarSchema(ctx context.Context, db *sql.DB) (calendarSchema, error) {
+	s := calendarSchema{columns: map[string]map[string]bool{}}
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&s.Version); err != nil {
+		return calendarSchema{}, err
+	}
+	for _, table := range calendarSchemaTables {
+		cols, err := tableColumns(ctx, db, table)
+		if err != nil {
+			return calendarSchema{}, err
+		}
+		if len(cols) > 0 {
+			s.columns[table] = cols
+		}
+	}
+	var missing []string
+	for table, cols := range calendarSchemaRequired {
+		if s.columns[table] == nil {
+			missing = append(missing, table)
+			continue
+		}
+		for _, c := range cols {
+			if !s.has(table, c) {
+				missing = append(missing, table+"."+c)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return calendarSchema{}, &UnsupportedSchemaError{Version: s.Version, Missing: missing}
+	}
+	switch {
+	case s.has("Location", "item_owner_id") && s.has("Location", "title"):
+		s.Variant = locationByOwner
+	case s.has("CalendarItem", "location_id") && s.has("Location", "title"):
+		s.Variant = locationByID
+	default:
+		s.Variant = locationNone
+	}
+	return s, nil
+}
+
+func tableColumns(ctx context.Context, db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, "SELECT lower(name) FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		cols[name] = true
+	}
+	if len(cols) == 0 {
+		return nil, rows.Err()
+	}
+	return cols, rows.Err()
+}
+
+func (s calendarSchema) describe() string {
+	return fmt.Sprintf("Calendar database schema recognized (user_version %d, %s)", s.Version, strings.ReplaceAll(s.Variant, "_", " "))
+}
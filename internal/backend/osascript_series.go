@@ -0,0 +1,319 @@
+//go:build darwin
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+type osaSeriesInfo struct {
+	Start      time.Time
+	End        time.Time
+	Recurrence string
+}
+
+func (b *OsaScriptBackend) seriesInfo(ctx context.Context, uid string) (osaSeriesInfo, error) {
+	out, err := runAppleScript(ctx, []string{
+		`on run argv`,
+		`set uidText to item 1 of argv`,
+		`set epoch to date "1/1/1970 00:00:00"`,
+		`tell application "Calendar"`,
+		`repeat with c in calendars`,
+		`set masterEvent to missing value`,
+		`try`,
+		`set masterEvent to first event of c whose uid is uidText`,
+		`end try`,
+		`if masterEvent is not missing value then`,
+		`set ruleText to recurrence of masterEvent`,
+		`if ruleText is missing value then set ruleText to ""`,
+		`return (((start date of masterEvent) - epoch) as integer as text) & tab & (((end date of masterEvent) - epoch) as integer as text) & tab & ruleText`,
+		`end if`,
+		`end repeat`,
+		`error "event not found"`,
+		`end tell`,
+		`end run`,
+	}, uid)
+	if err != nil {
+		return osaSeriesInfo{}, err
+	}
+	parts := strings.Split(trimOuterQuotes(strings.TrimSpace(out)), "\t")
+	if len(parts) < 2 {
+		return osaSeriesInfo{}, fmt.Errorf("unexpected series info: %q", out)
+	}
+	startUnix, err := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+	if err != nil {
+		return osaSeriesInfo{}, fmt.Errorf("invalid series start: %s", parts[0])
+	}
+	endUnix, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+	if err != nil {
+		return osaSeriesInfo{}, fmt.Errorf("invalid series end: %s", parts[1])
+	}
+	info := osaSeriesInfo{Start: time.Unix(startUnix, 0), End: time.Unix(endUnix, 0)}
+	if len(parts) > 2 {
+		info.Recurrence = strings.TrimSpace(parts[2])
+	}
+	return info, nil
+}
+
+func (b *OsaScriptBackend) occurrencesBefore(ctx context.Context, uid string, from, occStart time.Time) (int, error) {
+	if !occStart.After(from) {
+		return 0, nil
+	}
+	dbPath, err := findCalendarDB()
+	if err != nil {
+		return 0, err
+	}
+	s, err := withRetries(ctx, "sqlite", isTransientSQLiteError, func() (*Series, error) {
+		return inspectSeriesViaSQLite(ctx, dbPath, uid, from, occStart)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(s.Occurrences), nil
+}
+
+func (b *OsaScriptBackend) splitRecurrence(ctx context.Context, uid string, info osaSeriesInfo, occStart time.Time) (string, string, error) {
+	before := 0
+	if recurrenceHasCount(info.Recurrence) {
+		n, err := b.occurrencesBefore(ctx, uid, info.Start, occStart)
+		if err != nil {
+			return "", "", fmt.Errorf("count occurrences before split: %w", err)
+		}
+		before = n
+	}
+	head, tail := splitSeriesRecurrence(info.Recurrence, occStart, before)
+	return head, tail, nil
+}
+
+func (b *OsaScriptBackend) deleteFuture(ctx context.Context, uid string, occ int64) error {
+	info, err := b.seriesInfo(ctx, uid)
+	if err != nil {
+		return err
+	}
+	occStart := time.Unix(occ+cocoaEpochOffset, 0)
+	if info.Recurrence == "" || !occStart.After(info.Start) {
+		return b.DeleteEvent(ctx, uid, ScopeSeries)
+	}
+	head, _, err := b.splitRecurrence(ctx, uid, info, occStart)
+	if err != nil {
+		return err
+	}
+	_, err = runAppleScriptWrite(ctx, []string{
+		`on run argv`,
+		`set uidText to item 1 of argv`,
+		`set headText to item 2 of argv`,
+		`tell application "Calendar"`,
+		`repeat with c in calendars`,
+		`set masterEvent to missing value`,
+		`try`,
+		`set masterEvent to first event of c whose uid is uidText`,
+		`end try`,
+		`if masterEvent is not missing value then`,
+		`set recurrence of masterEvent to headText`,
+		`return "ok"`,
+		`end if`,
+		`end repeat`,
+		`error "event not found"`,
+		`end tell`,
+		`end run`,
+	}, uid, head)
+	return err
+}
+
+func (b *OsaScriptBackend) updateFuture(ctx context.Context, uid string, occ int64, in EventUpdateInput) (*contract.Event, error) {
+	info, err := b.seriesInfo(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	occStart := time.Unix(occ+cocoaEpochOffset, 0)
+	if info.Recurrence == "" || !occStart.After(info.Start) {
+		in.Scope = ScopeSeries
+		return b.UpdateEvent(ctx, uid, in)
+	}
+	// Calendar.app cannot set availability or privacy on the new tail event.
+	current, err := b.findByUID(ctx, uid, occStart, occStart.Add(info.End.Sub(info.Start)))
+	if err != nil {
+		return nil, fmt.Errorf("read series before split: %w", err)
+	}
+	if current.Availability != "" && current.Availability != contract.AvailabilityBusy {
+		return nil, fmt.Errorf("%w: splitting would reset availability %q on the new series; use --scope this or series", errOsaAvailability, current.Availability)
+	}
+	if current.Sensitivity != "" && current.Sensitivity != contract.SensitivityPublic {
+		return nil, fmt.Errorf("%w: splitting would reset sensitivity %q on the new series; use --scope this or series", errOsaSensitivity, current.Sensitivity)
+	}
+	head, tail, err := b.splitRecurrence(ctx, uid, info, occStart)
+	if err != nil {
+		return nil, err
+	}
+	if in.RepeatRule != nil {
+		if tail, err = repeatRuleToRRULE(*in.RepeatRule); err != nil {
+			return nil, err
+		}
+	}
+	newStart := occStart
+	if in.Start != nil {
+		newStart = *in.Start
+	}
+	newEnd := newStart.Add(info.End.Sub(info.Start))
+	if in.End != nil {
+		newEnd = *in.End
+	}
+	if !newEnd.After(newStart) {
+		return nil, fmt.Errorf("invalid start/end")
+	}
+
+	keep := "__ACAL_KEEP__"
+	title, location, notes, url, allDay, reminderMins, status := keep, keep, keep, keep, keep, keep, keep
+	if in.Title != nil {
+		title = *in.Title
+	}
+	if in.Location != nil {
+		location = *in.Location
+	}
+	if in.Notes != nil {
+		notes = *in.Notes
+	}
+	if in.URL != nil {
+		url = *in.URL
+	}
+	if in.AllDay != nil {
+		allDay = boolToScript(*in.AllDay)
+	}
+	if in.ReminderOffset != nil {
+		reminderMins = strconv.Itoa(int(in.ReminderOffset.Minutes()))
+	}
+	if in.Status != nil {
+		status = *in.Status
+	}
+	out, err := runAppleScriptWrite(ctx, []string{
+		`on run argv`,
+		`set uidText to item 1 of argv`,
+		`set headText to item 2 of argv`,
+		`set tailText to item 3 of argv`,
+		`set startText to item 4 of argv`,
+		`set endText to item 5 of argv`,
+		`set titleText to item 6 of argv`,
+		`set locText to item 7 of argv`,
+		`set notesText to item 8 of argv`,
+		`set urlText to item 9 of argv`,
+		`set allDayText to item 10 of argv`,
+		`set reminderText to item 11 of argv`,
+		`set clearReminderText to item 12 of argv`,
+		`set statusText to item 13 of argv`,
+		`set epoch to date "1/1/1970 00:00:00"`,
+		`tell application "Calendar"`,
+		`repeat with c in calendars`,
+		`set masterEvent to missing value`,
+		`try`,
+		`set masterEvent to first event of c whose uid is uidText`,
+		`end try`,
+		`if masterEvent is not missing value then`,
+		`set newEvent to make new event at end of events of c with properties {summary:(summary of masterEvent), start date:(epoch + (startText as integer)), end date:(epoch + (endText as integer)), allday event:(allday event of masterEvent)}`,
+		`try`,
+		`set location of newEvent to (location of masterEvent)`,
+		`end try`,
+		`try`,
+		`set description of newEvent to (description of masterEvent)`,
+		`end try`,
+		`try`,
+		`set url of newEvent to (url of masterEvent)`,
+		`end try`,
+		`try`,
+		`set status of newEvent to (status of masterEvent)`,
+		`end try`,
+		`try`,
+		`repeat with a in display alarms of masterEvent`,
+		`make new display alarm at end of display alarms of newEvent with properties {trigger interval:(trigger interval of a)}`,
+		`end repeat`,
+		`end try`,
+		`if tailText is not "" then set recurrence of newEvent to tailText`,
+		`set recurrence of masterEvent to headText`,
+		`if titleText is not "__ACAL_KEEP__" then set summary of newEvent to titleText`,
+		`if locText is not "__ACAL_KEEP__" then set location of newEvent to locText`,
+		`if notesText is not "__ACAL_KEEP__" then set description of newEvent to notesText`,
+		`if urlText is not "__ACAL_KEEP__" then set url of newEvent to urlText`,
+		`if allDayText is "true" then set allday event of newEvent to true`,
+		`if allDayText is "false" then set allday event of newEvent to false`,
+		`if statusText is "confirmed" then set status of newEvent to confirmed`,
+		`if statusText is "tentative" then set status of newEvent to tentative`,
+		`if statusText is "cancelled" then set status of newEvent to cancelled`,
+		`if statusText is "" then set status of newEvent to none`,
+		`if clearReminderText is "true" then delete every display alarm of newEvent`,
+		`if reminderText is not "__ACAL_KEEP__" then`,
+		`delete every display alarm of newEvent`,
+		`make new display alarm at end of display alarms of newEvent with properties {trigger interval:(reminderText as integer)}`,
+		`end if`,
+		`return uid of newEvent as text`,
+		`end if`,
+		`end repeat`,
+		`error "event not found"`,
+		`end tell`,
+		`end run`,
+	}, uid, head, tail, strconv.FormatInt(newStart.Unix(), 10), strconv.FormatInt(newEnd.Unix(), 10), title, location, notes, url, allDay, reminderMins, boolToScript(in.ClearReminder), status)
+	if err != nil {
+		return nil, err
+	}
+	newUID := strings.TrimSpace(trimOuterQuotes(strings.TrimSpace(out)))
+	if newUID == "" {
+		return nil, fmt.Errorf("failed to create split series")
+	}
+	if item, ferr := b.findByUID(ctx, newUID, newStart, newEnd); ferr == nil {
+		return item, nil
+	}
+	// OccurrenceCache can lag immediately after writes; return a deterministic ID anyway.
+	item := &contract.Event{
+		ID:           fmt.Sprintf("%s@%d", newUID, newStart.Unix()-cocoaEpochOffset),
+		CalendarID:   current.CalendarID,
+		CalendarName: current.CalendarName,
+		Title:        current.Title,
+		Start:        newStart,
+		End:          newEnd,
+		Location:     current.Location,
+		Notes:        current.Notes,
+		URL:          current.URL,
+		Status:       current.Status,
+		Availability: current.Availability,
+		Sensitivity:  current.Sensitivity,
+	}
+	if in.Title != nil {
+		item.Title = *in.Title
+	}
+	if in.Location != nil {
+		item.Location = *in.Location
+	}
+	if in.Notes != nil {
+		item.Notes = *in.Notes
+	}
+	if in.URL != nil {
+		item.URL = *in.URL
+	}
+	if in.Status != nil {
+		item.Status = *in.Status
+	}
+	return item, nil
+}
+
+func (b *OsaScriptBackend) InspectSeries(ctx context.Context, uid string, from, to time.Time) (*Series, error) {
+	dbPath, err := findCalendarDB()
+	if err != nil {
+		return nil, err
+	}
+	s, err := withRetries(ctx, "sqlite", isTransientSQLiteError, func() (*Series, error) {
+		return inspectSeriesViaSQLite(ctx, dbPath, uid, from, to)
+	})
+	if err != nil {
+		return nil, err
+	}
+	info, err := b.seriesInfo(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	s.Rule = info.Recurrence
+	return s, nil
+}
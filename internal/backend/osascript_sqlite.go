@@ -0,0 +1,329 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+	_ "modernc.org/sqlite"
+)
+
+const cocoaEpochOffset = int64(978307200)
+
+// sqliteQuery keeps user input in Args, never in the SQL text.
+type sqliteQuery struct {
+	SQL  string
+	Args []any
+}
+
+func buildListEventsQuery(s calendarSchema, fromCocoa, toCocoa int64, f EventFilter) sqliteQuery {
+	var args []any
+	limitClause := ""
+	if f.Limit > 0 {
+		limitClause = fmt.Sprintf("\nLIMIT %d", f.Limit)
+	}
+	calendarClause := ""
+	if len(f.Calendars) > 0 {
+		calVals := make([]any, 0, len(f.Calendars))
+		for _, c := range f.Calendars {
+			v := strings.ToLower(strings.TrimSpace(c))
+			if v == "" {
+				continue
+			}
+			calVals = append(calVals, v)
+		}
+		if len(calVals) > 0 {
+			in := sqlPlaceholders(len(calVals))
+			calendarClause = fmt.Sprintf("\n  AND (lower(%s) IN (%s) OR lower(COALESCE(c.title, '')) IN (%s))", s.calendarID(), in, in)
+			args = append(append(args, calVals...), calVals...)
+		}
+	}
+	title, location, notes := "COALESCE(ci.summary, '')", s.locationTitle(), s.col("ci", "CalendarItem", "description", "''")
+	queryClause := ""
+	if q := strings.ToLower(strings.TrimSpace(f.Query)); q != "" {
+		p := sqlLikePattern(q)
+		switch strings.ToLower(strings.TrimSpace(f.Field)) {
+		case "", "all":
+			queryClause = fmt.Sprintf("\n  AND (lower(%s) LIKE ? ESCAPE '\\' OR lower(%s) LIKE ? ESCAPE '\\' OR lower(%s) LIKE ? ESCAPE '\\')", title, location, notes)
+			args = append(args, p, p, p)
+		case "title":
+			queryClause = fmt.Sprintf("\n  AND lower(%s) LIKE ? ESCAPE '\\'", title)
+			args = append(args, p)
+		case "location":
+			queryClause = fmt.Sprintf("\n  AND lower(%s) LIKE ? ESCAPE '\\'", location)
+			args = append(args, p)
+		case "notes":
+			queryClause = fmt.Sprintf("\n  AND lower(%s) LIKE ? ESCAPE '\\'", notes)
+			args = append(args, p)
+		default:
+			queryClause = "\n  AND 1=0"
+		}
+	}
+	// Skipped columns stay as '' so the scan stays fixed.
+	locationCol, notesCol, urlCol := location, notes, s.col("ci", "CalendarItem", "url", "''")
+	if !f.Wants("location") {
+		locationCol = "''"
+	}
+	if !f.Wants("notes") {
+		notesCol = "''"
+	}
+	if !f.Wants("url") {
+		urlCol = "''"
+	}
+	reminderClause := ""
+	if s.has("OccurrenceCache", "next_reminder_date") {
+		reminderClause = "oc.next_reminder_date IS NULL\n  AND "
+	}
+	rangeClause := fmt.Sprintf("oc.occurrence_start_date >= %d", fromCocoa)
+	if f.Overlap {
+		rangeClause = fmt.Sprintf("(oc.occurrence_start_date >= %d OR COALESCE(oc.occurrence_end_date, oc.occurrence_start_date) > %d)", fromCocoa, fromCocoa)
+	}
+	return sqliteQuery{SQL: fmt.Sprintf(`
+SELECT
+  (%s || '@' || CAST(oc.occurrence_start_date AS INTEGER)) AS id,
+  %s AS cal_id,
+  COALESCE(c.title, '') AS cal_name,
+  %s AS title,
+  CAST(oc.occurrence_start_date AS INTEGER) + %d AS start_unix,
+  CAST(oc.occurrence_end_date AS INTEGER) + %d AS end_unix,
+  %s AS all_day,
+  %s AS location,
+  %s AS notes,
+  %s AS url,
+  %s AS status,
+  %s AS availability,
+  %s AS seq,
+  CAST(%s AS INTEGER) + %d AS created_unix,
+  CAST(%s AS INTEGER) + %d AS updated_unix
+FROM OccurrenceCache oc
+JOIN CalendarItem ci ON ci.ROWID = oc.event_id
+JOIN Calendar c ON c.ROWID = oc.calendar_id%s
+WHERE %s%s
+  AND oc.occurrence_start_date < %d
+%s%s
+ORDER BY oc.occurrence_start_date ASC%s;
+`, s.itemID(), s.calendarID(), title, cocoaEpochOffset, cocoaEpochOffset,
+		s.col("ci", "CalendarItem", "all_day", "0"), locationCol, notesCol, urlCol,
+		s.col("ci", "CalendarItem", "status", "0"), s.col("ci", "CalendarItem", "availability", "0"), s.col("ci", "CalendarItem", "sequence_num", "0"),
+		s.col("ci", "CalendarItem", "creation_date", "0"), cocoaEpochOffset, s.col("ci", "CalendarItem", "last_modified", "0"), cocoaEpochOffset,
+		s.locationJoin(), reminderClause, rangeClause, toCocoa, calendarClause, queryClause, limitClause), Args: args}
+}
+
+const occurrenceCacheLagMargin = 7 * 24 * time.Hour
+
+var occurrenceCacheEndQuery = fmt.Sprintf(`
+SELECT CAST(COALESCE(MAX(oc.occurrence_start_date), 0) AS INTEGER) + %d
+FROM OccurrenceCache oc
+JOIN Recurrence r ON r.owner_id = oc.event_id
+WHERE r.end_date IS NULL AND COALESCE(r.count, 0) = 0;
+`, cocoaEpochOffset)
+
+func warnOnOccurrenceCacheLag(ctx context.Context, dbPath string, to time.Time) {
+	db, release, err := openCalendarReadDB(dbPath)
+	if err != nil {
+		return
+	}
+	defer release()
+	var endUnix int64
+	if err := db.QueryRowContext(ctx, occurrenceCacheEndQuery).Scan(&endUnix); err != nil || endUnix <= cocoaEpochOffset {
+		return
+	}
+	end := time.Unix(endUnix, 0)
+	if to.Sub(end) <= occurrenceCacheLagMargin {
+		return
+	}
+	RecordWarning(ctx, contract.WarnOccurrenceCacheLag, fmt.Sprintf("Calendar.app has expanded repeating events only up to %s; later occurrences are missing until it extends its cache", end.UTC().Format("2006-01-02")))
+}
+
+func sqlPlaceholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+func sqlLikePattern(v string) string {
+	s := strings.ReplaceAll(v, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "%", "\\%")
+	s = strings.ReplaceAll(s, "_", "\\_")
+	return "%" + s + "%"
+}
+
+func checkCalendarDBReadable(ctx context.Context, dbPath string) error {
+	db, release, err := openCalendarReadDB(dbPath)
+	if err != nil {
+		return err
+	}
+	defer release()
+	var one int
+	return db.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+}
+
+func listEventsViaSQLite(ctx context.Context, dbPath string, query sqliteQuery, expectedRows int) ([]contract.Event, error) {
+	items := make([]contract.Event, 0, initialEventCapacity(expectedRows))
+	err := streamEventsViaSQLite(ctx, dbPath, query, func(e contract.Event) error {
+		items = append(items, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func streamEventsViaSQLite(ctx context.Context, dbPath string, query sqliteQuery, emit func(contract.Event) error) error {
+	db, release, err := openCalendarReadDB(dbPath)
+	if err != nil {
+		return err
+	}
+	defer release()
+	rows, err := db.QueryContext(ctx, query.SQL, query.Args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, calID, calName, title, location, notes, url string
+		var startUnix, endUnix, allDayRaw, statusRaw, availabilityRaw, seq, createdUnix, updatedUnix int64
+		if err := rows.Scan(&id, &calID, &calName, &title, &startUnix, &endUnix, &allDayRaw, &location, &notes, &url, &statusRaw, &availabilityRaw, &seq, &createdUnix, &updatedUnix); err != nil {
+			return err
+		}
+		err := emit(contract.Event{
+			ID:           trimIfEdgeSpace(id),
+			CalendarID:   trimIfEdgeSpace(calID),
+			CalendarName: trimIfEdgeSpace(calName),
+			Title:        trimIfEdgeSpace(title),
+			Start:        time.Unix(startUnix, 0),
+			End:          time.Unix(endUnix, 0),
+			AllDay:       allDayRaw == 1,
+			Location:     trimIfEdgeSpace(location),
+			Notes:        trimIfEdgeSpace(notes),
+			URL:          trimIfEdgeSpace(url),
+			Status:       eventKitStatus(statusRaw),
+			Availability: eventKitAvailability(availabilityRaw),
+			Sequence:     int(seq),
+			CreatedAt:    time.Unix(createdUnix, 0),
+			UpdatedAt:    time.Unix(updatedUnix, 0),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func eventKitStatus(v int64) string {
+	switch v {
+	case 1:
+		return contract.StatusConfirmed
+	case 2:
+		return contract.StatusTentative
+	case 3:
+		return contract.StatusCancelled
+	default:
+		return ""
+	}
+}
+
+func eventKitAvailability(v int64) string {
+	switch v {
+	case 0:
+		return contract.AvailabilityBusy
+	case 1:
+		return contract.AvailabilityFree
+	case 2:
+		return contract.AvailabilityTentative
+	case 3:
+		return contract.AvailabilityUnavailable
+	default:
+		return ""
+	}
+}
+
+func initialEventCapacity(expectedRows int) int {
+	switch {
+	case expectedRows <= 0:
+		return 64
+	case expectedRows > 2048:
+		return 2048
+	default:
+		return expectedRows
+	}
+}
+
+// calendarReadHandle is refcounted; a retired one closes after its last release.
+type calendarReadHandle struct {
+	db      *sql.DB
+	users   int
+	retired bool
+}
+
+var (
+	calendarReadDBMu    sync.Mutex
+	calendarReadDBCache = map[string]*calendarReadHandle{}
+)
+
+// openCalendarReadDB callers must call release when done.
+func openCalendarReadDB(dbPath string) (db *sql.DB, release func(), err error) {
+	dsn := calendarSQLiteDSN(dbPath)
+	calendarReadDBMu.Lock()
+	defer calendarReadDBMu.Unlock()
+	h, ok := calendarReadDBCache[dsn]
+	if !ok {
+		db, err := sql.Open("sqlite", dsn)
+		if err != nil {
+			return nil, nil, err
+		}
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		h = &calendarReadHandle{db: db}
+		calendarReadDBCache[dsn] = h
+	}
+	h.users++
+	var once sync.Once
+	return h.db, func() { once.Do(func() { releaseCalendarReadDB(h) }) }, nil
+}
+
+func releaseCalendarReadDB(h *calendarReadHandle) {
+	calendarReadDBMu.Lock()
+	defer calendarReadDBMu.Unlock()
+	h.users--
+	if h.retired && h.users == 0 {
+		_ = h.db.Close()
+	}
+}
+
+var calendarReadDBStamps sync.Map
+
+func refreshCalendarReadDB(dbPath, stamp string) {
+	prev, loaded := calendarReadDBStamps.Swap(dbPath, stamp)
+	if !loaded || prev.(string) == stamp {
+		return
+	}
+	dsn := calendarSQLiteDSN(dbPath)
+	calendarReadDBMu.Lock()
+	defer calendarReadDBMu.Unlock()
+	h, ok := calendarReadDBCache[dsn]
+	if !ok {
+		return
+	}
+	delete(calendarReadDBCache, dsn)
+	h.retired = true
+	if h.users == 0 {
+		_ = h.db.Close()
+	}
+}
+
+func calendarSQLiteDSN(dbPath string) string {
+	return "file:" + dbPath + "?mode=ro&immutable=1"
+}
+
+func shouldFallbackFromSQLite(err error) bool {
+	if err == nil {
+		return false
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
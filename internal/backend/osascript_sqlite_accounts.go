@@ -0,0 +1,107 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type calendarAccount struct {
+	Name      string
+	Type      string
+	Shared    bool
+	Delegated bool
+}
+
+func buildCalendarAccountsQuery(calendarCols, storeCols map[string]bool) string {
+	shared, delegated := "0", "0"
+	if calendarCols["sharing_status"] {
+		shared = "CAST(COALESCE(c.sharing_status, 0) AS INTEGER)"
+	}
+	if storeCols["delegated_account_owner_store_id"] {
+		delegated = "CASE WHEN COALESCE(s.delegated_account_owner_store_id, '') <> '' THEN 1 ELSE 0 END"
+	}
+	return fmt.Sprintf(`
+SELECT
+  COALESCE(c.UUID, CAST(c.ROWID AS TEXT)) AS cal_id,
+  COALESCE(s.name, '') AS account,
+  CAST(COALESCE(s.type, -1) AS INTEGER) AS account_type,
+  %s AS sharing_status,
+  %s AS delegated
+FROM Calendar c
+LEFT JOIN Store s ON s.ROWID = c.store_id;
+`, shared, delegated)
+}
+
+func eventKitSourceType(v int64) string {
+	switch v {
+	case 0:
+		return "local"
+	case 1:
+		return "exchange"
+	case 2:
+		return "caldav"
+	case 3:
+		return "mobileme"
+	case 4:
+		return "subscribed"
+	case 5:
+		return "birthdays"
+	default:
+		return ""
+	}
+}
+
+func sqliteTableColumns(ctx context.Context, db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		cols[strings.ToLower(name)] = true
+	}
+	return cols, rows.Err()
+}
+
+func listCalendarAccountsViaSQLite(ctx context.Context, dbPath string) (map[string]calendarAccount, error) {
+	db, release, err := openCalendarReadDB(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	calendarCols, err := sqliteTableColumns(ctx, db, "Calendar")
+	if err != nil {
+		return nil, err
+	}
+	storeCols, err := sqliteTableColumns(ctx, db, "Store")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, buildCalendarAccountsQuery(calendarCols, storeCols))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string]calendarAccount{}
+	for rows.Next() {
+		var id, name string
+		var typ, sharing, delegated int64
+		if err := rows.Scan(&id, &name, &typ, &sharing, &delegated); err != nil {
+			return nil, err
+		}
+		out[strings.TrimSpace(id)] = calendarAccount{
+			Name:      strings.TrimSpace(name),
+			Type:      eventKitSourceType(typ),
+			Shared:    sharing != 0,
+			Delegated: delegated != 0,
+		}
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,92 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CalendarItemChanges type 2 rows are deletions.
+const calendarItemChangeDeleted = 2
+
+func buildDeletedEventsQuery(cols map[string]bool, sinceCocoa int64) string {
+	optional := func(col, expr, fallback string) string {
+		if cols[col] {
+			return expr
+		}
+		return fallback
+	}
+	id := optional("unique_identifier", "COALESCE(ch.unique_identifier, CAST(ch.record AS TEXT))", "CAST(ch.record AS TEXT)")
+	calJoin, calID, calName := "", "''", "''"
+	if cols["calendar_id"] {
+		calJoin = "LEFT JOIN Calendar c ON c.ROWID = ch.calendar_id"
+		calID, calName = "COALESCE(c.UUID, CAST(ch.calendar_id AS TEXT), '')", "COALESCE(c.title, '')"
+	}
+	title := optional("summary", "COALESCE(ch.summary, '')", "''")
+	start := optional("start_date", fmt.Sprintf("CASE WHEN ch.start_date IS NULL THEN 0 ELSE CAST(ch.start_date AS INTEGER) + %d END", cocoaEpochOffset), "0")
+	end := optional("end_date", fmt.Sprintf("CASE WHEN ch.end_date IS NULL THEN 0 ELSE CAST(ch.end_date AS INTEGER) + %d END", cocoaEpochOffset), "0")
+	deleted := optional("timestamp", fmt.Sprintf("CASE WHEN ch.timestamp IS NULL THEN 0 ELSE CAST(ch.timestamp AS INTEGER) + %d END", cocoaEpochOffset), "0")
+	since := ""
+	if cols["timestamp"] {
+		since = fmt.Sprintf("\n  AND (ch.timestamp IS NULL OR ch.timestamp >= %d)", sinceCocoa)
+	}
+	return fmt.Sprintf(`
+SELECT
+  %s AS id,
+  %s AS cal_id,
+  %s AS cal_name,
+  %s AS title,
+  %s AS start_unix,
+  %s AS end_unix,
+  %s AS deleted_unix
+FROM CalendarItemChanges ch
+%s
+WHERE ch.type = %d%s
+ORDER BY ch.ROWID ASC;
+`, id, calID, calName, title, start, end, deleted, calJoin, calendarItemChangeDeleted, since)
+}
+
+func listDeletedEventsViaSQLite(ctx context.Context, dbPath string, since time.Time) ([]DeletedEvent, error) {
+	db, release, err := openCalendarReadDB(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	cols, err := sqliteTableColumns(ctx, db, "CalendarItemChanges")
+	if err != nil {
+		return nil, err
+	}
+	if !cols["record"] || !cols["type"] {
+		return nil, ErrDeletedUnsupported
+	}
+	rows, err := db.QueryContext(ctx, buildDeletedEventsQuery(cols, since.Unix()-cocoaEpochOffset))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DeletedEvent{}
+	seen := map[string]bool{}
+	for rows.Next() {
+		var d DeletedEvent
+		var startUnix, endUnix, deletedUnix int64
+		if err := rows.Scan(&d.ID, &d.CalendarID, &d.CalendarName, &d.Title, &startUnix, &endUnix, &deletedUnix); err != nil {
+			return nil, err
+		}
+		d.ID, d.CalendarID, d.CalendarName, d.Title = trimIfEdgeSpace(d.ID), trimIfEdgeSpace(d.CalendarID), trimIfEdgeSpace(d.CalendarName), trimIfEdgeSpace(d.Title)
+		if seen[d.ID] {
+			continue
+		}
+		seen[d.ID] = true
+		if startUnix != 0 {
+			d.Start = time.Unix(startUnix, 0)
+		}
+		if endUnix != 0 {
+			d.End = time.Unix(endUnix, 0)
+		}
+		if deletedUnix != 0 {
+			d.DeletedAt = time.Unix(deletedUnix, 0)
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
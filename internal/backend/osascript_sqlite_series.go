@@ -0,0 +1,110 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func buildSeriesMasterQuery(uid string) sqliteQuery {
+	return sqliteQuery{SQL: fmt.Sprintf(`
+SELECT
+  m.ROWID,
+  COALESCE(c.UUID, CAST(c.ROWID AS TEXT)) AS cal_id,
+  COALESCE(c.title, '') AS cal_name,
+  COALESCE(m.summary, '') AS title,
+  CAST(COALESCE(m.start_date, 0) AS INTEGER) + %d AS start_unix,
+  CAST(COALESCE(m.end_date, m.start_date, 0) AS INTEGER) + %d AS end_unix
+FROM CalendarItem m
+LEFT JOIN Calendar c ON c.ROWID = m.calendar_id
+WHERE COALESCE(m.unique_identifier, m.UUID, CAST(m.ROWID AS TEXT)) = ?
+  AND COALESCE(m.orig_item_id, 0) = 0
+ORDER BY m.ROWID ASC
+LIMIT 1;
+`, cocoaEpochOffset, cocoaEpochOffset), Args: []any{uid}}
+}
+
+func buildSeriesOccurrencesQuery(masterID, fromCocoa, toCocoa int64) string {
+	return fmt.Sprintf(`
+SELECT
+  (COALESCE(ci.unique_identifier, ci.UUID, CAST(ci.ROWID AS TEXT)) || '@' || CAST(oc.occurrence_start_date AS INTEGER)) AS id,
+  CAST(oc.occurrence_start_date AS INTEGER) + %d AS start_unix,
+  CAST(oc.occurrence_end_date AS INTEGER) + %d AS end_unix,
+  CASE WHEN ci.ROWID = %d THEN 0 ELSE 1 END AS detached,
+  CAST(COALESCE(ci.orig_date, oc.occurrence_start_date) AS INTEGER) + %d AS original_unix
+FROM OccurrenceCache oc
+JOIN CalendarItem ci ON ci.ROWID = oc.event_id
+WHERE (ci.ROWID = %d OR ci.orig_item_id = %d)
+  AND oc.next_reminder_date IS NULL
+  AND oc.occurrence_start_date >= %d
+  AND oc.occurrence_start_date < %d
+ORDER BY oc.occurrence_start_date ASC;
+`, cocoaEpochOffset, cocoaEpochOffset, masterID, cocoaEpochOffset, masterID, masterID, fromCocoa, toCocoa)
+}
+
+func buildSeriesExceptionsQuery(masterID int64) string {
+	return fmt.Sprintf(`
+SELECT CAST(date AS INTEGER) + %d AS exception_unix
+FROM ExceptionDate
+WHERE owner_id = %d
+ORDER BY date ASC;
+`, cocoaEpochOffset, masterID)
+}
+
+func inspectSeriesViaSQLite(ctx context.Context, dbPath, uid string, from, to time.Time) (*Series, error) {
+	db, release, err := openCalendarReadDB(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	var masterID, startUnix, endUnix int64
+	s := &Series{UID: uid, ExceptionDates: []time.Time{}, Occurrences: []SeriesOccurrence{}}
+	master := buildSeriesMasterQuery(uid)
+	err = db.QueryRowContext(ctx, master.SQL, master.Args...).Scan(&masterID, &s.CalendarID, &s.CalendarName, &s.Title, &startUnix, &endUnix)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("event not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.Start, s.End = time.Unix(startUnix, 0), time.Unix(endUnix, 0)
+	s.CalendarID, s.CalendarName, s.Title = trimIfEdgeSpace(s.CalendarID), trimIfEdgeSpace(s.CalendarName), trimIfEdgeSpace(s.Title)
+
+	rows, err := db.QueryContext(ctx, buildSeriesOccurrencesQuery(masterID, from.Unix()-cocoaEpochOffset, to.Unix()-cocoaEpochOffset))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var occStart, occEnd, detached, origUnix int64
+		if err := rows.Scan(&id, &occStart, &occEnd, &detached, &origUnix); err != nil {
+			return nil, err
+		}
+		occ := SeriesOccurrence{ID: trimIfEdgeSpace(id), Start: time.Unix(occStart, 0), End: time.Unix(occEnd, 0), Detached: detached == 1}
+		if occ.Detached {
+			orig := time.Unix(origUnix, 0)
+			occ.OriginalStart = &orig
+		}
+		s.Occurrences = append(s.Occurrences, occ)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	exRows, err := db.QueryContext(ctx, buildSeriesExceptionsQuery(masterID))
+	if err != nil {
+		return nil, err
+	}
+	defer exRows.Close()
+	for exRows.Next() {
+		var exUnix int64
+		if err := exRows.Scan(&exUnix); err != nil {
+			return nil, err
+		}
+		s.ExceptionDates = append(s.ExceptionDates, time.Unix(exUnix, 0))
+	}
+	return s, exRows.Err()
+}
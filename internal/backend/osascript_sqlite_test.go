@@ -3,14 +3,20 @@ package backend
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
 )
 
 func TestListEventsViaSQLiteReadsRows(t *testing.T) {
 	dbPath := buildSQLiteFixture(t, 3)
-	q := buildListEventsQuery(1, 10, EventFilter{})
+	q := buildListEventsQuery(fixtureSchema(t, dbPath), 1, 10, EventFilter{})
 
 	items, err := listEventsViaSQLite(context.Background(), dbPath, q, 3)
 	if err != nil {
@@ -25,11 +31,72 @@ func TestListEventsViaSQLiteReadsRows(t *testing.T) {
 	if items[2].Location != "room-3" {
 		t.Fatalf("third location mismatch: got=%q want=room-3", items[2].Location)
 	}
+	if items[0].Status != "confirmed" || items[0].Availability != "free" {
+		t.Fatalf("first status/availability mismatch: %q/%q", items[0].Status, items[0].Availability)
+	}
+	if items[1].Status != "tentative" || items[1].Availability != "busy" {
+		t.Fatalf("second status/availability mismatch: %q/%q", items[1].Status, items[1].Availability)
+	}
+}
+
+func TestListEventsViaSQLiteProjectionSkipsBulkyColumns(t *testing.T) {
+	dbPath := buildSQLiteFixture(t, 3)
+	q := buildListEventsQuery(fixtureSchema(t, dbPath), 1, 10, EventFilter{Fields: []string{"id", "title", "start"}})
+	if strings.Contains(q.SQL, "ci.description") || strings.Contains(q.SQL, "COALESCE(l.title") {
+		t.Fatalf("projected query still selects notes/location:\n%s", q.SQL)
+	}
+	items, err := listEventsViaSQLite(context.Background(), dbPath, q, 3)
+	if err != nil {
+		t.Fatalf("listEventsViaSQLite failed: %v", err)
+	}
+	if len(items) != 3 || items[2].Title != "event-3" || items[2].Location != "" || items[2].Notes != "" {
+		t.Fatalf("unexpected projected rows: %+v", items)
+	}
+	q = buildListEventsQuery(fixtureSchema(t, dbPath), 1, 10, EventFilter{Fields: []string{"title", "location"}})
+	if !strings.Contains(q.SQL, "COALESCE(l.title, '') AS location") || strings.Contains(q.SQL, "ci.description") {
+		t.Fatalf("location projection not honored:\n%s", q.SQL)
+	}
+}
+
+func TestStreamEventsViaSQLiteStopsOnEmitError(t *testing.T) {
+	dbPath := buildSQLiteFixture(t, 5)
+	q := buildListEventsQuery(fixtureSchema(t, dbPath), 1, 10, EventFilter{})
+	stop := errors.New("stop")
+	var seen []string
+	err := streamEventsViaSQLite(context.Background(), dbPath, q, func(e contract.Event) error {
+		seen = append(seen, e.Title)
+		if i := int64(len(seen)); e.CreatedAt.Unix() != i+50+cocoaEpochOffset || e.UpdatedAt.Unix() != i+100+cocoaEpochOffset {
+			t.Fatalf("unexpected timestamps for %s: created=%v updated=%v", e.Title, e.CreatedAt, e.UpdatedAt)
+		}
+		if len(seen) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected emit error to be returned, got %v", err)
+	}
+	if strings.Join(seen, ",") != "event-1,event-2" {
+		t.Fatalf("unexpected streamed rows: %v", seen)
+	}
+}
+
+func BenchmarkListEventsViaSQLiteProjected(b *testing.B) {
+	dbPath := buildSQLiteFixture(b, 250)
+	q := buildListEventsQuery(fixtureSchema(b, dbPath), 1, 1000, EventFilter{Fields: []string{"id", "title", "start"}})
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := listEventsViaSQLite(ctx, dbPath, q, 250); err != nil {
+			b.Fatalf("listEventsViaSQLite failed: %v", err)
+		}
+	}
 }
 
 func BenchmarkListEventsViaSQLite(b *testing.B) {
 	dbPath := buildSQLiteFixture(b, 250)
-	q := buildListEventsQuery(1, 1000, EventFilter{})
+	q := buildListEventsQuery(fixtureSchema(b, dbPath), 1, 1000, EventFilter{})
 	ctx := context.Background()
 
 	b.ResetTimer()
@@ -46,19 +113,38 @@ func BenchmarkListEventsViaSQLite(b *testing.B) {
 
 func TestOpenCalendarReadDBCachesByPath(t *testing.T) {
 	dbPath := buildSQLiteFixture(t, 1)
-	db1, err := openCalendarReadDB(dbPath)
+	db1, release1, err := openCalendarReadDB(dbPath)
 	if err != nil {
 		t.Fatalf("openCalendarReadDB first call failed: %v", err)
 	}
-	db2, err := openCalendarReadDB(dbPath)
+	defer release1()
+	db2, release2, err := openCalendarReadDB(dbPath)
 	if err != nil {
 		t.Fatalf("openCalendarReadDB second call failed: %v", err)
 	}
+	defer release2()
 	if db1 != db2 {
 		t.Fatalf("expected cached database handle reuse")
 	}
 }
 
+// testCalendarSchema matches the tables buildSQLiteFixture creates.
+var testCalendarSchema = calendarSchema{Variant: locationByOwner, columns: map[string]map[string]bool{
+	"OccurrenceCache": {"event_id": true, "calendar_id": true, "occurrence_start_date": true, "occurrence_end_date": true, "next_reminder_date": true},
+	"CalendarItem":    {"rowid": true, "unique_identifier": true, "uuid": true, "summary": true, "all_day": true, "description": true, "url": true, "status": true, "availability": true, "sequence_num": true, "creation_date": true, "last_modified": true},
+	"Calendar":        {"rowid": true, "uuid": true, "title": true},
+	"Location":        {"item_owner_id": true, "title": true},
+}}
+
+func fixtureSchema(tb testing.TB, dbPath string) calendarSchema {
+	tb.Helper()
+	s, err := detectCalendarSchema(context.Background(), dbPath)
+	if err != nil {
+		tb.Fatalf("detectCalendarSchema failed: %v", err)
+	}
+	return s
+}
+
 func buildSQLiteFixture(tb testing.TB, rows int) string {
 	tb.Helper()
 	dir := tb.TempDir()
@@ -80,7 +166,10 @@ func buildSQLiteFixture(tb testing.TB, rows int) string {
 			all_day INTEGER,
 			description TEXT,
 			url TEXT,
+			status INTEGER,
+			availability INTEGER,
 			sequence_num INTEGER,
+			creation_date INTEGER,
 			last_modified INTEGER
 		)`,
 		`CREATE TABLE OccurrenceCache (
@@ -103,9 +192,9 @@ func buildSQLiteFixture(tb testing.TB, rows int) string {
 
 	for i := 1; i <= rows; i++ {
 		if _, err := db.Exec(
-			`INSERT INTO CalendarItem (ROWID, unique_identifier, UUID, summary, all_day, description, url, sequence_num, last_modified)
-			 VALUES (?, ?, ?, ?, 0, ?, ?, ?, ?)`,
-			i, fmt.Sprintf("uid-%d", i), fmt.Sprintf("uuid-%d", i), fmt.Sprintf("event-%d", i), fmt.Sprintf("note-%d", i), fmt.Sprintf("https://e/%d", i), i, i+100,
+			`INSERT INTO CalendarItem (ROWID, unique_identifier, UUID, summary, all_day, description, url, status, availability, sequence_num, creation_date, last_modified)
+			 VALUES (?, ?, ?, ?, 0, ?, ?, ?, ?, ?, ?, ?)`,
+			i, fmt.Sprintf("uid-%d", i), fmt.Sprintf("uuid-%d", i), fmt.Sprintf("event-%d", i), fmt.Sprintf("note-%d", i), fmt.Sprintf("https://e/%d", i), i%4, i%2, i, i+50, i+100,
 		); err != nil {
 			tb.Fatalf("seed calendar item: %v", err)
 		}
@@ -123,3 +212,410 @@ func buildSQLiteFixture(tb testing.TB, rows int) string {
 
 	return dbPath
 }
+
+func TestInspectSeriesViaSQLite(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "Calendar.sqlitedb")
+	db, err := sql.Open("sqlite", "file:"+dbPath)
+	if err != nil {
+		t.Fatalf("open fixture db: %v", err)
+	}
+	defer db.Close()
+	for _, stmt := range []string{
+		`CREATE TABLE Calendar (ROWID INTEGER PRIMARY KEY, UUID TEXT, title TEXT)`,
+		`CREATE TABLE CalendarItem (ROWID INTEGER PRIMARY KEY, unique_identifier TEXT, UUID TEXT, summary TEXT, calendar_id INTEGER, start_date INTEGER, end_date INTEGER, orig_item_id INTEGER, orig_date INTEGER)`,
+		`CREATE TABLE OccurrenceCache (event_id INTEGER, calendar_id INTEGER, occurrence_start_date INTEGER, occurrence_end_date INTEGER, next_reminder_date INTEGER)`,
+		`CREATE TABLE ExceptionDate (ROWID INTEGER PRIMARY KEY, date INTEGER, owner_id INTEGER)`,
+		`INSERT INTO Calendar VALUES (1, 'cal-1', 'Work')`,
+		`INSERT INTO CalendarItem VALUES (1, 'series-1', 'uuid-1', 'Standup', 1, 1000, 1900, 0, NULL)`,
+		`INSERT INTO CalendarItem VALUES (2, 'series-1', 'uuid-2', 'Standup (moved)', 1, 3000, 3900, 1, 2000)`,
+		`INSERT INTO CalendarItem VALUES (3, 'other', 'uuid-3', 'Other', 1, 1000, 1900, 0, NULL)`,
+		`INSERT INTO OccurrenceCache VALUES (1, 1, 1000, 1900, NULL)`,
+		`INSERT INTO OccurrenceCache VALUES (2, 1, 3000, 3900, NULL)`,
+		`INSERT INTO OccurrenceCache VALUES (1, 1, 4000, 4900, NULL)`,
+		`INSERT INTO OccurrenceCache VALUES (3, 1, 1000, 1900, NULL)`,
+		`INSERT INTO ExceptionDate VALUES (1, 5000, 1)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("seed fixture: %v", err)
+		}
+	}
+
+	from := time.Unix(cocoaEpochOffset, 0)
+	s, err := inspectSeriesViaSQLite(context.Background(), dbPath, "series-1", from, from.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("inspectSeriesViaSQLite failed: %v", err)
+	}
+	if s.Title != "Standup" || s.CalendarName != "Work" || s.Start.Unix() != 1000+cocoaEpochOffset {
+		t.Fatalf("unexpected master: %+v", s)
+	}
+	if len(s.Occurrences) != 3 {
+		t.Fatalf("expected 3 occurrences, got %+v", s.Occurrences)
+	}
+	moved := s.Occurrences[1]
+	if !moved.Detached || moved.ID != "series-1@3000" || moved.OriginalStart == nil || moved.OriginalStart.Unix() != 2000+cocoaEpochOffset {
+		t.Fatalf("expected detached occurrence, got %+v", moved)
+	}
+	if s.Occurrences[0].Detached || s.Occurrences[2].Detached {
+		t.Fatalf("master occurrences should not be detached: %+v", s.Occurrences)
+	}
+	if len(s.ExceptionDates) != 1 || s.ExceptionDates[0].Unix() != 5000+cocoaEpochOffset {
+		t.Fatalf("unexpected exception dates: %+v", s.ExceptionDates)
+	}
+
+	if _, err := inspectSeriesViaSQLite(context.Background(), dbPath, "missing", from, from.Add(time.Hour)); err == nil {
+		t.Fatalf("expected error for unknown uid")
+	}
+}
+
+func TestWarnOnOccurrenceCacheLag(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "Calendar.sqlitedb")
+	db, err := sql.Open("sqlite", "file:"+dbPath)
+	if err != nil {
+		t.Fatalf("open fixture db: %v", err)
+	}
+	defer db.Close()
+	day := int64(86400)
+	for _, stmt := range []string{
+		`CREATE TABLE OccurrenceCache (event_id INTEGER, calendar_id INTEGER, occurrence_start_date INTEGER, occurrence_end_date INTEGER, next_reminder_date INTEGER)`,
+		`CREATE TABLE Recurrence (ROWID INTEGER PRIMARY KEY, owner_id INTEGER, end_date INTEGER, count INTEGER)`,
+		`INSERT INTO Recurrence VALUES (1, 1, NULL, 0)`,
+		`INSERT INTO Recurrence VALUES (2, 2, NULL, 5)`,
+		fmt.Sprintf(`INSERT INTO OccurrenceCache VALUES (1, 1, %d, %d, NULL)`, 100*day, 100*day+3600),
+		fmt.Sprintf(`INSERT INTO OccurrenceCache VALUES (2, 1, %d, %d, NULL)`, 300*day, 300*day+3600),
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("seed fixture: %v", err)
+		}
+	}
+	cacheEnd := time.Unix(cocoaEpochOffset+100*day, 0)
+
+	rec := NewWarningRecorder()
+	ctx := WithWarningRecorder(context.Background(), rec)
+	warnOnOccurrenceCacheLag(ctx, dbPath, cacheEnd.Add(3*24*time.Hour))
+	if got := rec.Snapshot(); len(got) != 0 {
+		t.Fatalf("expected no warning within the margin, got %+v", got)
+	}
+	warnOnOccurrenceCacheLag(ctx, dbPath, cacheEnd.Add(30*24*time.Hour))
+	warnOnOccurrenceCacheLag(ctx, dbPath, cacheEnd.Add(30*24*time.Hour))
+	got := rec.Snapshot()
+	if len(got) != 1 || got[0].Code != contract.WarnOccurrenceCacheLag || !strings.Contains(got[0].Message, cacheEnd.UTC().Format("2006-01-02")) {
+		t.Fatalf("expected one occurrence_cache_lag warning, got %+v", got)
+	}
+}
+
+func TestListCalendarAccountsViaSQLite(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "Calendar.sqlitedb")
+	db, err := sql.Open("sqlite", "file:"+dbPath)
+	if err != nil {
+		t.Fatalf("open fixture db: %v", err)
+	}
+	defer db.Close()
+	for _, stmt := range []string{
+		`CREATE TABLE Store (ROWID INTEGER PRIMARY KEY, name TEXT, type INTEGER, delegated_account_owner_store_id TEXT)`,
+		`CREATE TABLE Calendar (ROWID INTEGER PRIMARY KEY, UUID TEXT, title TEXT, store_id INTEGER, sharing_status INTEGER)`,
+		`INSERT INTO Store VALUES (1, 'iCloud', 2, NULL), (2, 'On My Mac', 0, NULL), (3, 'Holidays', 4, NULL), (4, 'boss@example.com', 1, 'owner-store')`,
+		`INSERT INTO Calendar VALUES (1, 'cal-1', 'Work', 1, 2), (2, 'cal-2', 'Local', 2, 0), (3, 'cal-3', 'Orphan', 9, NULL), (4, 'cal-4', 'Holidays', 3, 0), (5, 'cal-5', 'Boss', 4, 0)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("seed fixture: %v", err)
+		}
+	}
+
+	got, err := listCalendarAccountsViaSQLite(context.Background(), dbPath)
+	if err != nil {
+		t.Fatalf("listCalendarAccountsViaSQLite failed: %v", err)
+	}
+	want := map[string]calendarAccount{
+		"cal-1": {Name: "iCloud", Type: "caldav", Shared: true},
+		"cal-2": {Name: "On My Mac", Type: "local"},
+		"cal-3": {},
+		"cal-4": {Name: "Holidays", Type: "subscribed"},
+		"cal-5": {Name: "boss@example.com", Type: "exchange", Delegated: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected accounts: %+v", got)
+	}
+	for id, w := range want {
+		if got[id] != w {
+			t.Fatalf("account for %s = %+v, want %+v", id, got[id], w)
+		}
+	}
+}
+
+func TestListCalendarAccountsViaSQLiteOlderSchema(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "Calendar.sqlitedb")
+	db, err := sql.Open("sqlite", "file:"+dbPath)
+	if err != nil {
+		t.Fatalf("open fixture db: %v", err)
+	}
+	defer db.Close()
+	for _, stmt := range []string{
+		`CREATE TABLE Store (ROWID INTEGER PRIMARY KEY, name TEXT, type INTEGER)`,
+		`CREATE TABLE Calendar (ROWID INTEGER PRIMARY KEY, UUID TEXT, title TEXT, store_id INTEGER)`,
+		`INSERT INTO Store VALUES (1, 'iCloud', 2)`,
+		`INSERT INTO Calendar VALUES (1, 'cal-1', 'Work', 1)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("seed fixture: %v", err)
+		}
+	}
+	got, err := listCalendarAccountsViaSQLite(context.Background(), dbPath)
+	if err != nil {
+		t.Fatalf("listCalendarAccountsViaSQLite failed: %v", err)
+	}
+	if got["cal-1"] != (calendarAccount{Name: "iCloud", Type: "caldav"}) {
+		t.Fatalf("unexpected account: %+v", got["cal-1"])
+	}
+}
+
+func TestListDeletedEventsViaSQLite(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "Calendar.sqlitedb")
+	db, err := sql.Open("sqlite", "file:"+dbPath)
+	if err != nil {
+		t.Fatalf("open fixture db: %v", err)
+	}
+	defer db.Close()
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	cocoa := func(ts time.Time) int64 { return ts.Unix() - cocoaEpochOffset }
+	for _, stmt := range []string{
+		`CREATE TABLE Calendar (ROWID INTEGER PRIMARY KEY, UUID TEXT, title TEXT)`,
+		`CREATE TABLE CalendarItemChanges (ROWID INTEGER PRIMARY KEY, record INTEGER, type INTEGER, unique_identifier TEXT, calendar_id INTEGER, summary TEXT, start_date INTEGER, end_date INTEGER, timestamp INTEGER)`,
+		`INSERT INTO Calendar VALUES (1, 'cal-1', 'Work')`,
+		fmt.Sprintf(`INSERT INTO CalendarItemChanges VALUES
+			(1, 10, 2, 'evt-old', 1, 'Old', NULL, NULL, %d),
+			(2, 11, 1, 'evt-edit', 1, 'Edited', NULL, NULL, %d),
+			(3, 12, 2, 'evt-gone', 1, 'Standup', %d, %d, %d),
+			(4, 12, 2, 'evt-gone', 1, 'Standup', NULL, NULL, %d),
+			(5, 13, 2, NULL, 9, NULL, NULL, NULL, NULL)`,
+			cocoa(since.Add(-time.Hour)), cocoa(since.Add(time.Hour)),
+			cocoa(since.Add(48*time.Hour)), cocoa(since.Add(49*time.Hour)), cocoa(since.Add(2*time.Hour)), cocoa(since.Add(3*time.Hour))),
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("seed fixture: %v", err)
+		}
+	}
+
+	got, err := listDeletedEventsViaSQLite(context.Background(), dbPath, since)
+	if err != nil {
+		t.Fatalf("listDeletedEventsViaSQLite failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected the tombstone and the bare record, got %+v", got)
+	}
+	gone := got[0]
+	if gone.ID != "evt-gone" || gone.CalendarName != "Work" || gone.Title != "Standup" || !gone.Start.Equal(since.Add(48*time.Hour)) || !gone.DeletedAt.Equal(since.Add(2*time.Hour)) {
+		t.Fatalf("unexpected tombstone: %+v", gone)
+	}
+	if bare := got[1]; bare.ID != "13" || bare.CalendarID != "9" || !bare.Start.IsZero() || !bare.DeletedAt.IsZero() {
+		t.Fatalf("unexpected bare record: %+v", bare)
+	}
+}
+
+func TestListDeletedEventsViaSQLiteWithoutChangeLog(t *testing.T) {
+	dbPath := buildSQLiteFixture(t, 1)
+	if _, err := listDeletedEventsViaSQLite(context.Background(), dbPath, time.Now()); !errors.Is(err, ErrDeletedUnsupported) {
+		t.Fatalf("expected ErrDeletedUnsupported, got %v", err)
+	}
+}
+
+func TestDetectCalendarSchemaVariants(t *testing.T) {
+	s := fixtureSchema(t, buildSQLiteFixture(t, 1))
+	if s.Variant != locationByOwner || !s.has("CalendarItem", "url") {
+		t.Fatalf("unexpected schema for current fixture: %+v", s)
+	}
+
+	dbPath := filepath.Join(t.TempDir(), "Calendar.sqlitedb")
+	db, err := sql.Open("sqlite", "file:"+dbPath)
+	if err != nil {
+		t.Fatalf("open fixture db: %v", err)
+	}
+	defer db.Close()
+	for _, stmt := range []string{
+		`PRAGMA user_version = 12`,
+		`CREATE TABLE Calendar (ROWID INTEGER PRIMARY KEY, title TEXT)`,
+		`CREATE TABLE CalendarItem (ROWID INTEGER PRIMARY KEY, UUID TEXT, summary TEXT, location_id INTEGER)`,
+		`CREATE TABLE OccurrenceCache (event_id INTEGER, calendar_id INTEGER, occurrence_start_date INTEGER, occurrence_end_date INTEGER)`,
+		`CREATE TABLE Location (ROWID INTEGER PRIMARY KEY, title TEXT)`,
+		`INSERT INTO Calendar VALUES (1, 'Work')`,
+		`INSERT INTO Location VALUES (7, 'Room 2')`,
+		`INSERT INTO CalendarItem VALUES (1, 'uuid-1', 'Standup', 7)`,
+		`INSERT INTO OccurrenceCache VALUES (1, 1, 5, 6)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("seed fixture: %v", err)
+		}
+	}
+	s = fixtureSchema(t, dbPath)
+	if s.Version != 12 || s.Variant != locationByID {
+		t.Fatalf("unexpected schema for older fixture: %+v", s)
+	}
+	items, err := listEventsViaSQLite(context.Background(), dbPath, buildListEventsQuery(s, 1, 10, EventFilter{Calendars: []string{"1"}, Query: "room", Field: "location"}), 1)
+	if err != nil {
+		t.Fatalf("listEventsViaSQLite failed on older schema: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "uuid-1@5" || items[0].CalendarID != "1" || items[0].Location != "Room 2" || items[0].URL != "" {
+		t.Fatalf("unexpected rows from older schema: %+v", items)
+	}
+}
+
+func TestDetectCalendarSchemaUnsupported(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "Calendar.sqlitedb")
+	db, err := sql.Open("sqlite", "file:"+dbPath)
+	if err != nil {
+		t.Fatalf("open fixture db: %v", err)
+	}
+	defer db.Close()
+	for _, stmt := range []string{
+		`CREATE TABLE Calendar (ROWID INTEGER PRIMARY KEY, name TEXT)`,
+		`CREATE TABLE CalendarItem (ROWID INTEGER PRIMARY KEY, summary TEXT)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("seed fixture: %v", err)
+		}
+	}
+	_, err = detectCalendarSchema(context.Background(), dbPath)
+	var schemaErr *UnsupportedSchemaError
+	if !errors.Is(err, ErrUnsupportedSchema) || !errors.As(err, &schemaErr) {
+		t.Fatalf("expected ErrUnsupportedSchema, got %v", err)
+	}
+	if strings.Join(schemaErr.Missing, ",") != "Calendar.title,OccurrenceCache" {
+		t.Fatalf("unexpected missing list: %v", schemaErr.Missing)
+	}
+}
+
+func TestListEventsSchemaFallsBackOnAnyDetectionError(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "Calendar.sqlitedb")
+	if err := os.WriteFile(dbPath, []byte("not a database"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := listEventsSchema(context.Background(), dbPath)
+	var detectErr *schemaDetectionError
+	if !errors.As(err, &detectErr) || errors.Is(err, ErrUnsupportedSchema) {
+		t.Fatalf("expected a non-schema detection error to fall back, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = listEventsSchema(ctx, buildSQLiteFixture(t, 1))
+	if !errors.Is(err, context.Canceled) || errors.As(err, &detectErr) {
+		t.Fatalf("expected a canceled detection to fail without fallback, got %v", err)
+	}
+}
+
+func TestRefreshCalendarReadDBReopensOnStampChange(t *testing.T) {
+	dbPath := buildSQLiteFixture(t, 1)
+	db1, release1, err := openCalendarReadDB(dbPath)
+	if err != nil {
+		t.Fatalf("openCalendarReadDB failed: %v", err)
+	}
+	refreshCalendarReadDB(dbPath, "a")
+	refreshCalendarReadDB(dbPath, "a")
+	db2, release2, _ := openCalendarReadDB(dbPath)
+	release2()
+	if db2 != db1 {
+		t.Fatalf("expected the handle to survive an unchanged stamp")
+	}
+	refreshCalendarReadDB(dbPath, "b")
+	db3, release3, err := openCalendarReadDB(dbPath)
+	if err != nil {
+		t.Fatalf("openCalendarReadDB after refresh failed: %v", err)
+	}
+	defer release3()
+	if db3 == db1 {
+		t.Fatalf("expected a new handle after the stamp moved")
+	}
+	var one int
+	if err := db1.QueryRow("SELECT 1").Scan(&one); err != nil {
+		t.Fatalf("expected the retired handle to stay open while in use: %v", err)
+	}
+	release1()
+	if err := db1.Ping(); err == nil {
+		t.Fatalf("expected the retired handle to close after its last release")
+	}
+}
+
+func TestDiscoverCalendarDB(t *testing.T) {
+	home := t.TempDir()
+	fixture := buildSQLiteFixture(t, 1)
+	raw, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	place := func(rel string, data []byte) string {
+		p := filepath.Join(home, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	if _, err := discoverCalendarDB("", home); err == nil || !strings.Contains(err.Error(), calendarDBOverrideEnv) {
+		t.Fatalf("expected not-found error naming the override, got %v", err)
+	}
+	place("Library/Group Containers/ABCD.com.example.notes/Calendar.sqlitedb", []byte("not a database"))
+	grouped := place("Library/Group Containers/XYZ.group.com.apple.calendar2/Calendar.sqlitedb", raw)
+	if got, err := discoverCalendarDB("", home); err != nil || got != grouped {
+		t.Fatalf("expected the group container database, got %q %v", got, err)
+	}
+	legacy := place("Library/Calendars/Calendar.sqlitedb", raw)
+	if got, err := discoverCalendarDB("", home); err != nil || got != legacy {
+		t.Fatalf("expected the known location to win over the glob, got %q %v", got, err)
+	}
+
+	if got, err := discoverCalendarDB(fixture, home); err != nil || got != fixture {
+		t.Fatalf("expected the override, got %q %v", got, err)
+	}
+	bogus := place("bogus.sqlitedb", []byte("not a database"))
+	if _, err := discoverCalendarDB(bogus, home); err == nil || !strings.Contains(err.Error(), "not a SQLite database") {
+		t.Fatalf("expected the override to be validated, got %v", err)
+	}
+	other := filepath.Join(t.TempDir(), "other.sqlitedb")
+	odb, err := sql.Open("sqlite", "file:"+other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := odb.Exec(`CREATE TABLE Notes (id INTEGER)`); err != nil {
+		t.Fatal(err)
+	}
+	_ = odb.Close()
+	if _, err := discoverCalendarDB(other, home); err == nil || !strings.Contains(err.Error(), "not a Calendar database") {
+		t.Fatalf("expected a non-calendar SQLite file to be rejected, got %v", err)
+	}
+}
+
+// FuzzBuildListEventsQueryBindsInput checks that calendar names, search
+// terms, and series uids never reach the SQL text: the statement is the same whatever the
+// input, runs cleanly, and only matches the fixture's real calendar.
+func FuzzBuildListEventsQueryBindsInput(f *testing.F) {
+	for _, seed := range []string{"Work", "cal-1", "x' OR '1'='1", "'); DROP TABLE CalendarItem; --", `Work"; SELECT 1; --`, "100%_\\", "é'ü;\x00"} {
+		f.Add(seed)
+	}
+	dbPath := buildSQLiteFixture(f, 3)
+	schema := fixtureSchema(f, dbPath)
+	f.Fuzz(func(t *testing.T, v string) {
+		if strings.TrimSpace(v) == "" {
+			t.Skip()
+		}
+		for _, field := range []string{"all", "title", "location", "notes"} {
+			filter := EventFilter{Calendars: []string{v}, Query: v, Field: field}
+			q := buildListEventsQuery(schema, 1, 10, filter)
+			if want := buildListEventsQuery(schema, 1, 10, EventFilter{Calendars: []string{"x"}, Query: "x", Field: field}).SQL; q.SQL != want {
+				t.Fatalf("input %q changed the statement:\n%s", v, q.SQL)
+			}
+			items, err := listEventsViaSQLite(context.Background(), dbPath, q, 0)
+			if err != nil {
+				t.Fatalf("query with input %q failed: %v", v, err)
+			}
+			name := strings.ToLower(strings.TrimSpace(v))
+			if len(items) > 0 && name != "work" && name != "cal-1" {
+				t.Fatalf("input %q matched %d events", v, len(items))
+			}
+		}
+		if q := buildSeriesMasterQuery(v); q.SQL != buildSeriesMasterQuery("x").SQL || len(q.Args) != 1 || q.Args[0] != v {
+			t.Fatalf("uid %q was not bound: %s %v", v, q.SQL, q.Args)
+		}
+	})
+}
@@ -3,8 +3,10 @@ package backend
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseEventID(t *testing.T) {
@@ -148,28 +150,43 @@ func TestOsaScriptRetryPolicyFromEnv(t *testing.T) {
 }
 
 func TestBuildListEventsQueryLimitClause(t *testing.T) {
-	q := buildListEventsQuery(1, 2, EventFilter{Limit: 25})
+	q := buildListEventsQuery(testCalendarSchema, 1, 2, EventFilter{Limit: 25}).SQL
 	if !strings.Contains(q, "LIMIT 25") {
 		t.Fatalf("expected LIMIT clause in query, got: %s", q)
 	}
 }
 
 func TestBuildListEventsQueryNoLimitClause(t *testing.T) {
-	q := buildListEventsQuery(1, 2, EventFilter{})
+	q := buildListEventsQuery(testCalendarSchema, 1, 2, EventFilter{}).SQL
 	if strings.Contains(q, "LIMIT ") {
 		t.Fatalf("did not expect LIMIT clause in query, got: %s", q)
 	}
 }
 
+func TestBuildListEventsQueryOverlapRange(t *testing.T) {
+	q := buildListEventsQuery(testCalendarSchema, 100, 200, EventFilter{}).SQL
+	if !strings.Contains(q, "AND oc.occurrence_start_date >= 100") || strings.Contains(q, "OR COALESCE(oc.occurrence_end_date") {
+		t.Fatalf("expected start-only range by default, got: %s", q)
+	}
+	q = buildListEventsQuery(testCalendarSchema, 100, 200, EventFilter{Overlap: true}).SQL
+	if !strings.Contains(q, "COALESCE(oc.occurrence_end_date, oc.occurrence_start_date) > 100") || !strings.Contains(q, "AND oc.occurrence_start_date < 200") {
+		t.Fatalf("expected overlap range, got: %s", q)
+	}
+}
+
 func TestBuildListEventsQueryPushesCalendarPredicate(t *testing.T) {
-	q := buildListEventsQuery(1, 2, EventFilter{Calendars: []string{"Work", "cal-1"}})
-	if !strings.Contains(q, "lower(COALESCE(c.UUID") || !strings.Contains(q, "IN ('work','cal-1')") {
+	q := buildListEventsQuery(testCalendarSchema, 1, 2, EventFilter{Calendars: []string{"Work", "cal-1"}}).SQL
+	if !strings.Contains(q, "lower(COALESCE(c.UUID") || !strings.Contains(q, "IN (?,?)") {
 		t.Fatalf("expected calendar pushdown, got: %s", q)
 	}
+	args := buildListEventsQuery(testCalendarSchema, 1, 2, EventFilter{Calendars: []string{"Work", "cal-1"}}).Args
+	if fmt.Sprint(args) != "[work cal-1 work cal-1]" {
+		t.Fatalf("expected calendar names as bound arguments, got: %v", args)
+	}
 }
 
 func TestBuildListEventsQueryPushesQueryPredicate(t *testing.T) {
-	q := buildListEventsQuery(1, 2, EventFilter{Query: "Standup", Field: "title"})
+	q := buildListEventsQuery(testCalendarSchema, 1, 2, EventFilter{Query: "Standup", Field: "title"}).SQL
 	if !strings.Contains(q, "lower(COALESCE(ci.summary, '')) LIKE") {
 		t.Fatalf("expected title LIKE pushdown, got: %s", q)
 	}
@@ -179,7 +196,7 @@ func TestBuildListEventsQueryPushesQueryPredicate(t *testing.T) {
 }
 
 func TestBuildListEventsQueryUnknownFieldUsesNoResultsPredicate(t *testing.T) {
-	q := buildListEventsQuery(1, 2, EventFilter{Query: "x", Field: "bogus"})
+	q := buildListEventsQuery(testCalendarSchema, 1, 2, EventFilter{Query: "x", Field: "bogus"}).SQL
 	if !strings.Contains(q, "AND 1=0") {
 		t.Fatalf("expected impossible predicate for unknown field, got: %s", q)
 	}
@@ -221,3 +238,109 @@ func TestShouldFallbackFromSQLite(t *testing.T) {
 		t.Fatalf("did not expect fallback for nil error")
 	}
 }
+
+func TestSplitSeriesRecurrence(t *testing.T) {
+	occ := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	head, tail := splitSeriesRecurrence("RRULE:FREQ=WEEKLY;INTERVAL=1;COUNT=10;BYDAY=MO", occ, 4)
+	if head != "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO;UNTIL=20260309T085959Z" {
+		t.Fatalf("unexpected head rule: %s", head)
+	}
+	if tail != "FREQ=WEEKLY;INTERVAL=1;COUNT=6;BYDAY=MO" {
+		t.Fatalf("unexpected tail rule: %s", tail)
+	}
+	head, tail = splitSeriesRecurrence("FREQ=DAILY;UNTIL=20260401T000000Z", occ, 0)
+	if head != "FREQ=DAILY;UNTIL=20260309T085959Z" || tail != "FREQ=DAILY;UNTIL=20260401T000000Z" {
+		t.Fatalf("unexpected split: head=%s tail=%s", head, tail)
+	}
+	if !recurrenceHasCount("FREQ=DAILY;COUNT=3") || recurrenceHasCount("FREQ=DAILY") {
+		t.Fatalf("recurrenceHasCount mismatch")
+	}
+}
+
+func TestWithRetriesUsesContextPolicyAndRecordsAttempts(t *testing.T) {
+	rec := NewAttemptRecorder()
+	ctx := WithAttemptRecorder(WithRetryPolicy(context.Background(), RetryPolicy{Retries: 2, Backoff: time.Millisecond}), rec)
+	calls := 0
+	_, err := withRetries(ctx, "sqlite", isTransientSQLiteError, func() (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, errors.New("database is locked")
+		}
+		return 1, nil
+	})
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if got := rec.Snapshot()["sqlite"]; got != 3 {
+		t.Fatalf("expected 3 recorded attempts, got %d", got)
+	}
+
+	calls = 0
+	_, err = withRetries(ctx, "osascript", func(err error) bool { return isTransientAppleScriptError(err.Error()) }, func() (int, error) {
+		calls++
+		return 0, errors.New("osascript failed: syntax error")
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("expected single attempt for non-transient error, calls=%d err=%v", calls, err)
+	}
+}
+
+func TestAppleScriptEventRowsRoundTripEncodedText(t *testing.T) {
+	out := "\"uid%5C1\tcal-1\tWork%09Team\tPlan%0Anext%0D%0Astep %22Q3%22 100%25\t1772442000\t1772445600\tfalse\t\tline1%0A%09line2\t\n" +
+		"uid-2\tcal-1\tWork\tShort\t1772449200\t1772452800\ttrue\tRoom 1\t\t\"\n"
+	rows := splitRows(out)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows with trailing empty fields kept, got %d: %q", len(rows), rows)
+	}
+	e, ok := appleScriptEventRow(rows[0], time.UTC)
+	if !ok {
+		t.Fatalf("expected row to parse: %q", rows[0])
+	}
+	if e.Title != "Plan\nnext\r\nstep \"Q3\" 100%" || e.CalendarName != "Work\tTeam" || e.Notes != "line1\n\tline2" || e.Location != "" {
+		t.Fatalf("text did not round-trip: %+v", e)
+	}
+	if e.ID != "uid\\1@"+fmt.Sprint(int64(1772442000)-cocoaEpochOffset) {
+		t.Fatalf("unexpected id: %s", e.ID)
+	}
+	e, ok = appleScriptEventRow(rows[1], time.UTC)
+	if !ok || !e.AllDay || e.Location != "Room 1" || e.URL != "" {
+		t.Fatalf("unexpected second row: %+v %v", e, ok)
+	}
+	if decodeAppleScriptField("50% off") != "50% off" {
+		t.Fatalf("expected undecodable text to pass through")
+	}
+}
+
+func TestSplitWindowsCoversRangeInChunks(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 75)
+	windows := splitWindows(from, to, appleScriptWindow)
+	if len(windows) != 3 {
+		t.Fatalf("expected 3 windows, got %+v", windows)
+	}
+	if !windows[0].From.Equal(from) || !windows[2].To.Equal(to) {
+		t.Fatalf("windows do not span the range: %+v", windows)
+	}
+	for i := 1; i < len(windows); i++ {
+		if !windows[i].From.Equal(windows[i-1].To) {
+			t.Fatalf("gap or overlap between windows %d and %d: %+v", i-1, i, windows)
+		}
+	}
+	if got := splitWindows(from, from, appleScriptWindow); len(got) != 1 {
+		t.Fatalf("expected one empty window for an empty range, got %+v", got)
+	}
+}
+
+func TestAppleScriptEventTimeoutFollowsDeadline(t *testing.T) {
+	if got := appleScriptEventTimeout(context.Background()); got != appleScriptMaxEventTimeout {
+		t.Fatalf("expected the default timeout without a deadline, got %d", got)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if got := appleScriptEventTimeout(ctx); got < 4 || got > 5 {
+		t.Fatalf("expected about half the remaining time, got %d", got)
+	}
+	if !windowFits(ctx, time.Second) || windowFits(ctx, time.Minute) {
+		t.Fatalf("windowFits should compare against the remaining time")
+	}
+}
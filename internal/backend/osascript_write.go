@@ -1,7 +1,10 @@
+//go:build darwin
+
 package backend
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -10,6 +13,10 @@ import (
 	"github.com/agis/acal/internal/contract"
 )
 
+var errOsaAvailability = errors.New("availability cannot be set via the osascript backend")
+
+var errOsaSensitivity = errors.New("sensitivity cannot be set via the osascript backend")
+
 func (b *OsaScriptBackend) AddEvent(ctx context.Context, in EventCreateInput) (*contract.Event, error) {
 	if strings.TrimSpace(in.Calendar) == "" || strings.TrimSpace(in.Title) == "" {
 		return nil, fmt.Errorf("calendar and title required")
@@ -17,6 +24,12 @@ func (b *OsaScriptBackend) AddEvent(ctx context.Context, in EventCreateInput) (*
 	if in.Start.IsZero() || in.End.IsZero() || !in.End.After(in.Start) {
 		return nil, fmt.Errorf("invalid start/end")
 	}
+	if in.Availability != "" && in.Availability != contract.AvailabilityBusy {
+		return nil, errOsaAvailability
+	}
+	if in.Sensitivity != "" && in.Sensitivity != contract.SensitivityPublic {
+		return nil, errOsaSensitivity
+	}
 
 	allDay := boolToScript(in.AllDay)
 	startUnix := strconv.FormatInt(in.Start.Unix(), 10)
@@ -26,7 +39,7 @@ func (b *OsaScriptBackend) AddEvent(ctx context.Context, in EventCreateInput) (*
 	if in.ReminderOffset != nil {
 		reminderMins = strconv.Itoa(int(in.ReminderOffset.Minutes()))
 	}
-	out, err := runAppleScript(ctx, []string{
+	out, err := runAppleScriptWrite(ctx, []string{
 		`on run argv`,
 		`set calName to item 1 of argv`,
 		`set titleText to item 2 of argv`,
@@ -38,6 +51,7 @@ func (b *OsaScriptBackend) AddEvent(ctx context.Context, in EventCreateInput) (*
 		`set allDayText to item 8 of argv`,
 		`set repeatText to item 9 of argv`,
 		`set reminderText to item 10 of argv`,
+		`set statusText to item 11 of argv`,
 		`set epoch to date "1/1/1970 00:00:00"`,
 		`set startDate to (epoch + (startText as integer))`,
 		`set endDate to (epoch + (endText as integer))`,
@@ -53,6 +67,9 @@ func (b *OsaScriptBackend) AddEvent(ctx context.Context, in EventCreateInput) (*
 		`if locationText is not "" then set location of newEvent to locationText`,
 		`if notesText is not "" then set description of newEvent to notesText`,
 		`if urlText is not "" then set url of newEvent to urlText`,
+		`if statusText is "confirmed" then set status of newEvent to confirmed`,
+		`if statusText is "tentative" then set status of newEvent to tentative`,
+		`if statusText is "cancelled" then set status of newEvent to cancelled`,
 		`if repeatText is not "" then`,
 		`if repeatText starts with "daily" then set recurrence of newEvent to daily`,
 		`if repeatText starts with "weekly" then set recurrence of newEvent to weekly`,
@@ -66,7 +83,7 @@ func (b *OsaScriptBackend) AddEvent(ctx context.Context, in EventCreateInput) (*
 		`return uid of newEvent as text`,
 		`end tell`,
 		`end run`,
-	}, in.Calendar, in.Title, startUnix, endUnix, in.Location, in.Notes, in.URL, allDay, repeatText, reminderMins)
+	}, in.Calendar, in.Title, startUnix, endUnix, in.Location, in.Notes, in.URL, allDay, repeatText, reminderMins, in.Status)
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +107,7 @@ func (b *OsaScriptBackend) AddEvent(ctx context.Context, in EventCreateInput) (*
 		Location:     in.Location,
 		Notes:        in.Notes,
 		URL:          in.URL,
+		Status:       in.Status,
 	}, nil
 }
 
@@ -102,6 +120,15 @@ func (b *OsaScriptBackend) UpdateEvent(ctx context.Context, id string, in EventU
 	if err != nil {
 		return nil, err
 	}
+	if in.Availability != nil {
+		return nil, errOsaAvailability
+	}
+	if in.Sensitivity != nil {
+		return nil, errOsaSensitivity
+	}
+	if scope == ScopeFuture {
+		return b.updateFuture(ctx, uid, occ, in)
+	}
 
 	keep := "__ACAL_KEEP__"
 	allDay := keep
@@ -140,16 +167,24 @@ func (b *OsaScriptBackend) UpdateEvent(ctx context.Context, id string, in EventU
 	if in.ReminderOffset != nil {
 		reminderMins = strconv.Itoa(int(in.ReminderOffset.Minutes()))
 	}
+	reminderAt := keep
+	if in.ReminderAt != nil {
+		reminderAt = strconv.FormatInt(in.ReminderAt.Unix(), 10)
+	}
 	clearReminder := "false"
 	if in.ClearReminder {
 		clearReminder = "true"
 	}
+	status := keep
+	if in.Status != nil {
+		status = *in.Status
+	}
 	occUnix := "0"
 	if occ > 0 {
 		occUnix = strconv.FormatInt(occ+cocoaEpochOffset, 10)
 	}
 
-	out, err := runAppleScript(ctx, []string{
+	out, err := runAppleScriptWrite(ctx, []string{
 		`on run argv`,
 		`set uidText to item 1 of argv`,
 		`set scopeText to item 2 of argv`,
@@ -164,6 +199,8 @@ func (b *OsaScriptBackend) UpdateEvent(ctx context.Context, id string, in EventU
 		`set repeatText to item 11 of argv`,
 		`set reminderText to item 12 of argv`,
 		`set clearReminderText to item 13 of argv`,
+		`set statusText to item 14 of argv`,
+		`set reminderAtText to item 15 of argv`,
 		`set epoch to date "1/1/1970 00:00:00"`,
 		`tell application "Calendar"`,
 		`set updatedUID to uidText`,
@@ -176,12 +213,6 @@ func (b *OsaScriptBackend) UpdateEvent(ctx context.Context, id string, in EventU
 		`on error`,
 		`set targetEvents to {}`,
 		`end try`,
-		`else if scopeText is "future" then`,
-		`try`,
-		`set targetEvents to every event of c whose uid is uidText and ((start date of it - epoch) as integer) is greater than or equal to occUnix`,
-		`on error`,
-		`set targetEvents to {}`,
-		`end try`,
 		`else`,
 		`try`,
 		`set targetEvents to {first event of c whose uid is uidText and ((start date of it - epoch) as integer) is occUnix}`,
@@ -216,20 +247,35 @@ func (b *OsaScriptBackend) UpdateEvent(ctx context.Context, id string, in EventU
 		`if repeatText starts with "yearly" then set recurrence of targetRef to yearly`,
 		`end if`,
 		`end if`,
+		`if statusText is not "__ACAL_KEEP__" then`,
+		`if statusText is "confirmed" then`,
+		`set status of targetRef to confirmed`,
+		`else if statusText is "tentative" then`,
+		`set status of targetRef to tentative`,
+		`else if statusText is "cancelled" then`,
+		`set status of targetRef to cancelled`,
+		`else`,
+		`set status of targetRef to none`,
+		`end if`,
+		`end if`,
 		`if clearReminderText is "true" then delete every display alarm of targetRef`,
 		`if reminderText is not "__ACAL_KEEP__" then`,
 		`delete every display alarm of targetRef`,
 		`make new display alarm at end of display alarms of targetRef with properties {trigger interval:(reminderText as integer)}`,
 		`end if`,
+		`if reminderAtText is not "__ACAL_KEEP__" then`,
+		`delete every display alarm of targetRef`,
+		`make new display alarm at end of display alarms of targetRef with properties {trigger date:(epoch + (reminderAtText as integer))}`,
+		`end if`,
 		`end repeat`,
-		`if scopeText is not "future" then exit repeat`,
+		`exit repeat`,
 		`end if`,
 		`end repeat`,
 		`if foundAny is false then error "event not found"`,
 		`return updatedUID`,
 		`end tell`,
 		`end run`,
-	}, uid, string(scope), occUnix, title, start, end, location, notes, url, allDay, repeatText, reminderMins, clearReminder)
+	}, uid, string(scope), occUnix, title, start, end, location, notes, url, allDay, repeatText, reminderMins, clearReminder, status, reminderAt)
 	if err != nil {
 		return nil, err
 	}
@@ -272,6 +318,9 @@ func (b *OsaScriptBackend) UpdateEvent(ctx context.Context, id string, in EventU
 	if in.AllDay != nil {
 		fallback.AllDay = *in.AllDay
 	}
+	if in.Status != nil {
+		fallback.Status = *in.Status
+	}
 	return fallback, nil
 }
 
@@ -284,11 +333,14 @@ func (b *OsaScriptBackend) DeleteEvent(ctx context.Context, id string, scope Rec
 	if err != nil {
 		return err
 	}
+	if resolvedScope == ScopeFuture {
+		return b.deleteFuture(ctx, uid, occ)
+	}
 	occUnix := "0"
 	if occ > 0 {
 		occUnix = strconv.FormatInt(occ+cocoaEpochOffset, 10)
 	}
-	_, err = runAppleScript(ctx, []string{
+	_, err = runAppleScriptWrite(ctx, []string{
 		`on run argv`,
 		`set uidText to item 1 of argv`,
 		`set scopeText to item 2 of argv`,
@@ -304,12 +356,6 @@ func (b *OsaScriptBackend) DeleteEvent(ctx context.Context, id string, scope Rec
 		`on error`,
 		`set targetEvents to {}`,
 		`end try`,
-		`else if scopeText is "future" then`,
-		`try`,
-		`set targetEvents to every event of c whose uid is uidText and ((start date of it - epoch) as integer) is greater than or equal to occUnix`,
-		`on error`,
-		`set targetEvents to {}`,
-		`end try`,
 		`else`,
 		`try`,
 		`set targetEvents to {first event of c whose uid is uidText and ((start date of it - epoch) as integer) is occUnix}`,
@@ -322,7 +368,7 @@ func (b *OsaScriptBackend) DeleteEvent(ctx context.Context, id string, scope Rec
 		`repeat with targetEvent in targetEvents`,
 		`delete (contents of targetEvent)`,
 		`end repeat`,
-		`if scopeText is not "future" then exit repeat`,
+		`exit repeat`,
 		`end if`,
 		`end repeat`,
 		`if foundAny is false then error "event not found"`,
@@ -333,32 +379,6 @@ func (b *OsaScriptBackend) DeleteEvent(ctx context.Context, id string, scope Rec
 	return err
 }
 
-func resolveRecurrenceScope(scope RecurrenceScope, occurrenceCocoa int64) (RecurrenceScope, error) {
-	switch scope {
-	case "", ScopeAuto:
-		if occurrenceCocoa > 0 {
-			return ScopeThis, nil
-		}
-		return ScopeSeries, nil
-	case ScopeThis, ScopeFuture:
-		if occurrenceCocoa <= 0 {
-			return "", fmt.Errorf("scope %q requires an occurrence event id (<uid>@<occurrence>)", scope)
-		}
-		return scope, nil
-	case ScopeSeries:
-		return ScopeSeries, nil
-	default:
-		return "", fmt.Errorf("invalid recurrence scope: %q", scope)
-	}
-}
-
-func boolToScript(v bool) string {
-	if v {
-		return "true"
-	}
-	return "false"
-}
-
 func (b *OsaScriptBackend) findByUID(ctx context.Context, uid string, start, end time.Time) (*contract.Event, error) {
 	from := start.Add(-24 * time.Hour)
 	to := end.Add(24 * time.Hour)
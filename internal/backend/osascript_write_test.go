@@ -0,0 +1,23 @@
+//go:build darwin
+
+package backend
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAddEventMakesOneAttemptOnTimeout(t *testing.T) {
+	fakeOsascript(t)
+	attempts := NewAttemptRecorder()
+	ctx := WithAttemptRecorder(WithRetryPolicy(context.Background(), RetryPolicy{Retries: 3, Backoff: time.Millisecond}), attempts)
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	_, err := NewOsaScriptBackend().AddEvent(ctx, EventCreateInput{Calendar: "Work", Title: "Standup", Start: start, End: start.Add(15 * time.Minute)})
+	if err == nil {
+		t.Fatalf("expected AddEvent to fail")
+	}
+	if got := attempts.Snapshot()["osascript.write"]; got != 1 {
+		t.Fatalf("expected exactly one AddEvent attempt, got %d", got)
+	}
+}
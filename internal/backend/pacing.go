@@ -0,0 +1,71 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const DefaultMaxWritesPerSecond = 4.0
+
+type writeRateContextKey struct{}
+
+var osascriptWrites = &writeLimiter{}
+
+type writeLimiter struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+func WithMaxWritesPerSecond(ctx context.Context, rate float64) context.Context {
+	return context.WithValue(ctx, writeRateContextKey{}, rate)
+}
+
+func maxWritesPerSecond(ctx context.Context) float64 {
+	if v, ok := ctx.Value(writeRateContextKey{}).(float64); ok {
+		return v
+	}
+	return DefaultMaxWritesPerSecond
+}
+
+func (l *writeLimiter) wait(ctx context.Context, rate float64) error {
+	if rate <= 0 {
+		return nil
+	}
+	interval := time.Duration(float64(time.Second) / rate)
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(interval)
+	l.mu.Unlock()
+	d := slot.Sub(now)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// scriptNotStartedError is a write that failed before osascript ran, so
+// retrying it cannot create a duplicate.
+type scriptNotStartedError struct {
+	err error
+}
+
+func (e *scriptNotStartedError) Error() string { return e.err.Error() }
+func (e *scriptNotStartedError) Unwrap() error { return e.err }
+
+func isScriptNotStarted(err error) bool {
+	var notStarted *scriptNotStartedError
+	return errors.As(err, &notStarted)
+}
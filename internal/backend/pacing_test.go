@@ -0,0 +1,41 @@
+package backend
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWriteLimiterPacesBursts(t *testing.T) {
+	l := &writeLimiter{}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.wait(context.Background(), 50); err != nil {
+			t.Fatalf("wait failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected 3 writes at 50/s to take >=40ms, took %s", elapsed)
+	}
+}
+
+func TestWriteLimiterDisabledAndCanceled(t *testing.T) {
+	l := &writeLimiter{}
+	for i := 0; i < 100; i++ {
+		if err := l.wait(context.Background(), 0); err != nil {
+			t.Fatalf("disabled limiter should not fail: %v", err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = l.wait(ctx, 1)
+	if err := l.wait(ctx, 1); err == nil {
+		t.Fatalf("expected canceled wait to fail")
+	}
+}
+
+func TestMaxWritesPerSecondDefault(t *testing.T) {
+	if got := maxWritesPerSecond(context.Background()); got != DefaultMaxWritesPerSecond {
+		t.Fatalf("expected default write rate, got %v", got)
+	}
+}
@@ -0,0 +1,69 @@
+//go:build unix
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The background sleep inherits the output pipe, so the call only returns
+// before helperWaitDelay if the kill reached it as well as sh.
+func TestRunHelperCommandKillsProcessGroupOnCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := runHelperCommand(ctx, "sh", "-c", "sleep 30 & wait")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= helperWaitDelay {
+		t.Fatalf("child outlived the cancel: returned after %s", elapsed)
+	}
+}
+
+func TestRunHelperCommandKeepsExitError(t *testing.T) {
+	out, err := runHelperCommand(context.Background(), "sh", "-c", "echo boom >&2; exit 3")
+	var exitErr interface{ ExitCode() int }
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 || strings.TrimSpace(string(out)) != "boom" {
+		t.Fatalf("expected exit 3 with output, got %v %q", err, out)
+	}
+}
+
+// fakeOsascript puts an osascript on PATH that always fails with an
+// AppleEvent timeout.
+func fakeOsascript(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho 'execution error: Calendar got an error: AppleEvent timed out. (-1712)' >&2\nexit 1\n"
+	if err := os.WriteFile(filepath.Join(dir, "osascript"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestAppleScriptWritesDoNotRetryTimeouts(t *testing.T) {
+	fakeOsascript(t)
+	policy := RetryPolicy{Retries: 2, Backoff: time.Millisecond}
+	writes := NewAttemptRecorder()
+	ctx := WithAttemptRecorder(WithMaxWritesPerSecond(WithRetryPolicy(context.Background(), policy), 0), writes)
+	if _, err := runAppleScriptWrite(ctx, []string{"return 1"}); err == nil {
+		t.Fatalf("expected the write to fail")
+	}
+	if got := writes.Snapshot()["osascript.write"]; got != 1 {
+		t.Fatalf("expected one write attempt on a timeout, got %d", got)
+	}
+	reads := NewAttemptRecorder()
+	ctx = WithAttemptRecorder(WithRetryPolicy(context.Background(), policy), reads)
+	if _, err := runAppleScript(ctx, []string{"return 1"}); err == nil {
+		t.Fatalf("expected the read to fail")
+	}
+	if got := reads.Snapshot()["osascript"]; got != 3 {
+		t.Fatalf("expected reads to keep retrying timeouts, got %d attempts", got)
+	}
+}
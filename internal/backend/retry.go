@@ -0,0 +1,117 @@
+package backend
+
+import (
+	"context"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type RetryPolicy struct {
+	Retries int
+	Backoff time.Duration
+}
+
+type AttemptRecorder struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+type retryPolicyContextKey struct{}
+
+type attemptRecorderContextKey struct{}
+
+func WithRetryPolicy(ctx context.Context, p RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyContextKey{}, p)
+}
+
+func WithAttemptRecorder(ctx context.Context, r *AttemptRecorder) context.Context {
+	return context.WithValue(ctx, attemptRecorderContextKey{}, r)
+}
+
+func NewAttemptRecorder() *AttemptRecorder {
+	return &AttemptRecorder{counts: map[string]int{}}
+}
+
+func AttemptsFromContext(ctx context.Context) map[string]int {
+	r, _ := ctx.Value(attemptRecorderContextKey{}).(*AttemptRecorder)
+	if r == nil {
+		return nil
+	}
+	return r.Snapshot()
+}
+
+func (r *AttemptRecorder) Snapshot() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := make([]string, 0, len(r.counts))
+	for k := range r.counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make(map[string]int, len(keys))
+	for _, k := range keys {
+		out[k] = r.counts[k]
+	}
+	return out
+}
+
+func (r *AttemptRecorder) add(name string, n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[name] += n
+}
+
+func retryPolicyFor(ctx context.Context) (int, time.Duration) {
+	if p, ok := ctx.Value(retryPolicyContextKey{}).(RetryPolicy); ok {
+		backoff := p.Backoff
+		if backoff <= 0 {
+			backoff = 200 * time.Millisecond
+		}
+		return max(p.Retries, 0), backoff
+	}
+	return osascriptRetryPolicy()
+}
+
+func recordAttempts(ctx context.Context, name string, n int) {
+	if r, ok := ctx.Value(attemptRecorderContextKey{}).(*AttemptRecorder); ok && r != nil {
+		r.add(name, n)
+	}
+}
+
+func withRetries[T any](ctx context.Context, name string, transient func(error) bool, fn func() (T, error)) (T, error) {
+	retries, backoff := retryPolicyFor(ctx)
+	var zero T
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		v, err := fn()
+		recordAttempts(ctx, name, 1)
+		if err == nil {
+			return v, nil
+		}
+		lastErr = err
+		if attempt == retries || !transient(err) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		case <-time.After(jitteredBackoff(backoff, attempt)):
+		}
+	}
+	return zero, lastErr
+}
+
+func jitteredBackoff(backoff time.Duration, attempt int) time.Duration {
+	wait := backoff * time.Duration(1<<attempt)
+	return wait + rand.N(wait/2+1)
+}
+
+func isTransientSQLiteError(err error) bool {
+	s := strings.ToLower(err.Error())
+	return strings.Contains(s, "database is locked") ||
+		strings.Contains(s, "sqlite_busy") ||
+		strings.Contains(s, "database table is locked")
+}
@@ -0,0 +1,85 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+var (
+	ErrRSVPUnsupported = errors.New("backend cannot answer invitations")
+	ErrNotInvited      = errors.New("you are not an attendee of this event")
+)
+
+var RSVPResponses = map[string]string{
+	"accept":    "ACCEPTED",
+	"decline":   "DECLINED",
+	"tentative": "TENTATIVE",
+}
+
+type RSVPInput struct {
+	// Response is accept, decline, or tentative.
+	Response string
+	Comment  string
+	// Attendee is the address to answer as; empty uses the backend's account.
+	Attendee string
+	Scope    RecurrenceScope
+}
+
+type RSVPResult struct {
+	Event    contract.Event `json:"event"`
+	Attendee string         `json:"attendee"`
+	Response string         `json:"response"`
+	Previous string         `json:"previous"`
+	Comment  string         `json:"comment,omitempty"`
+}
+
+type Responder interface {
+	RespondToEvent(ctx context.Context, id string, in RSVPInput) (*RSVPResult, error)
+}
+
+func rsvpAttendee(ve *icsComponent, addr, partstat, comment string) (string, error) {
+	for i, line := range ve.Props {
+		p := parseICSProp(line)
+		if p.Name != "ATTENDEE" || !strings.EqualFold(strings.TrimPrefix(strings.ToLower(p.Value), "mailto:"), addr) {
+			continue
+		}
+		previous := strings.ToLower(firstNonEmptyString(p.Params["PARTSTAT"], "NEEDS-ACTION"))
+		ve.Props[i] = rewriteAttendeeLine(line, partstat, comment)
+		return previous, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrNotInvited, addr)
+}
+
+func rewriteAttendeeLine(line, partstat, comment string) string {
+	colon := -1
+	inQuotes := false
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ':':
+			if !inQuotes {
+				colon = i
+			}
+		}
+	}
+	parts := splitICSParams(line[:colon])
+	out := []string{parts[0]}
+	for _, param := range parts[1:] {
+		k, _, _ := strings.Cut(param, "=")
+		switch strings.ToUpper(strings.TrimSpace(k)) {
+		case "PARTSTAT", "RSVP", "X-RESPONSE-COMMENT":
+			continue
+		}
+		out = append(out, param)
+	}
+	out = append(out, "PARTSTAT="+partstat)
+	if comment = strings.Join(strings.Fields(strings.ReplaceAll(comment, `"`, "'")), " "); comment != "" {
+		out = append(out, `X-RESPONSE-COMMENT="`+comment+`"`)
+	}
+	return strings.Join(out, ";") + line[colon:]
+}
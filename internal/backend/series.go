@@ -0,0 +1,47 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrSeriesUnsupported = errors.New("backend does not expose recurrence details")
+
+func EventUID(id string) (string, bool) {
+	id = strings.TrimSpace(id)
+	i := strings.LastIndex(id, "@")
+	if i <= 0 {
+		return id, false
+	}
+	if _, err := strconv.ParseInt(id[i+1:], 10, 64); err != nil {
+		return id, false
+	}
+	return id[:i], true
+}
+
+type SeriesOccurrence struct {
+	ID            string     `json:"id"`
+	Start         time.Time  `json:"start"`
+	End           time.Time  `json:"end"`
+	Detached      bool       `json:"detached"`
+	OriginalStart *time.Time `json:"original_start,omitempty"`
+}
+
+type Series struct {
+	UID            string             `json:"uid"`
+	CalendarID     string             `json:"calendar_id"`
+	CalendarName   string             `json:"calendar_name"`
+	Title          string             `json:"title"`
+	Start          time.Time          `json:"start"`
+	End            time.Time          `json:"end"`
+	Rule           string             `json:"rule"`
+	ExceptionDates []time.Time        `json:"exception_dates"`
+	Occurrences    []SeriesOccurrence `json:"occurrences"`
+}
+
+type SeriesInspector interface {
+	InspectSeries(ctx context.Context, uid string, from, to time.Time) (*Series, error)
+}
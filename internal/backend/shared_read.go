@@ -0,0 +1,153 @@
+package backend
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+const (
+	sharedReadPoll = 25 * time.Millisecond
+	sharedReadKeep = 24 * time.Hour
+)
+
+type sharedReadDirContextKey struct{}
+
+// WithSharedReads lets concurrent acal processes share identical ListEvents scans.
+func WithSharedReads(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, sharedReadDirContextKey{}, dir)
+}
+
+type sharedReadResult struct {
+	StartedAt time.Time          `json:"started_at"`
+	WrittenAt time.Time          `json:"written_at"`
+	Events    []contract.Event   `json:"events"`
+	Warnings  []contract.Warning `json:"warnings,omitempty"`
+}
+
+// sharedListEvents only hands a waiter the result of a scan that started after it began waiting.
+func sharedListEvents(ctx context.Context, f EventFilter, list func() ([]contract.Event, error)) ([]contract.Event, error) {
+	dir, _ := ctx.Value(sharedReadDirContextKey{}).(string)
+	if strings.TrimSpace(dir) == "" {
+		return list()
+	}
+	key, err := sharedReadKey(f)
+	if err != nil {
+		return list()
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return list()
+	}
+	lock, err := os.OpenFile(filepath.Join(dir, key+".lock"), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return list()
+	}
+	defer lock.Close()
+	resultPath := filepath.Join(dir, key+".json")
+
+	waitStart := time.Now()
+	waited := false
+	for {
+		ok, err := tryLockFile(lock)
+		if err != nil {
+			return list()
+		}
+		if ok {
+			break
+		}
+		waited = true
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(sharedReadPoll):
+		}
+	}
+	defer unlockFile(lock)
+	_ = os.Chtimes(lock.Name(), time.Now(), time.Now())
+
+	if waited {
+		if res, ok := readSharedResult(resultPath, waitStart); ok {
+			recordAttempts(ctx, "shared_read", 1)
+			for _, w := range res.Warnings {
+				RecordWarning(ctx, w.Code, w.Message)
+			}
+			return res.Events, nil
+		}
+	}
+	before := len(WarningsFromContext(ctx))
+	started := time.Now()
+	items, err := list()
+	if err != nil {
+		return nil, err
+	}
+	res := sharedReadResult{StartedAt: started, WrittenAt: time.Now(), Events: items}
+	if ws := WarningsFromContext(ctx); len(ws) > before {
+		res.Warnings = ws[before:]
+	}
+	writeSharedResult(resultPath, res)
+	pruneSharedReads(dir, res.WrittenAt)
+	return items, nil
+}
+
+func sharedReadKey(f EventFilter) (string, error) {
+	raw, err := json.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+	prefix := "list_events\x00" + os.Getenv(calendarDBOverrideEnv) + "\x00"
+	sum := sha256.Sum256(append([]byte(prefix), raw...))
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+func readSharedResult(path string, notBefore time.Time) (sharedReadResult, bool) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return sharedReadResult{}, false
+	}
+	var res sharedReadResult
+	if err := json.Unmarshal(raw, &res); err != nil || res.StartedAt.Before(notBefore) {
+		return sharedReadResult{}, false
+	}
+	return res, true
+}
+
+func writeSharedResult(path string, res sharedReadResult) {
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), path)
+}
+
+func pruneSharedReads(dir string, now time.Time) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || e.IsDir() || now.Sub(info.ModTime()) < sharedReadKeep {
+			continue
+		}
+		_ = os.Remove(filepath.Join(dir, e.Name()))
+	}
+}
@@ -0,0 +1,103 @@
+//go:build unix
+
+package backend
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+func TestSharedListEventsHandsResultToWaiter(t *testing.T) {
+	dir := t.TempDir()
+	f := EventFilter{From: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)}
+	var scans atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	// The first scan is already running when the waiters arrive, so neither
+	// may reuse it; one of them scans next and the other reuses that.
+	leaderDone := make(chan []contract.Event)
+	go func() {
+		items, _ := sharedListEvents(WithSharedReads(context.Background(), dir), f, func() ([]contract.Event, error) {
+			scans.Add(1)
+			close(started)
+			<-release
+			return []contract.Event{{ID: "evt-0", Title: "Stale"}}, nil
+		})
+		leaderDone <- items
+	}()
+	<-started
+
+	type waiter struct {
+		rec      *WarningRecorder
+		attempts *AttemptRecorder
+		done     chan []contract.Event
+	}
+	waiters := make([]waiter, 2)
+	for i := range waiters {
+		w := waiter{rec: NewWarningRecorder(), attempts: NewAttemptRecorder(), done: make(chan []contract.Event)}
+		waiters[i] = w
+		ctx := WithAttemptRecorder(WithWarningRecorder(WithSharedReads(context.Background(), dir), w.rec), w.attempts)
+		go func() {
+			items, _ := sharedListEvents(ctx, f, func() ([]contract.Event, error) {
+				scans.Add(1)
+				RecordWarning(ctx, contract.WarnAppleScriptFallback, "fell back")
+				return []contract.Event{{ID: "evt-1", Title: "Standup"}}, nil
+			})
+			w.done <- items
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	if got := <-leaderDone; len(got) != 1 || got[0].Title != "Stale" {
+		t.Fatalf("unexpected leader result: %+v", got)
+	}
+	reused := 0
+	for _, w := range waiters {
+		got := <-w.done
+		if len(got) != 1 || got[0].Title != "Standup" {
+			t.Fatalf("expected a waiter to get a scan that started after it asked, got %+v", got)
+		}
+		if ws := w.rec.Snapshot(); len(ws) != 1 || ws[0].Code != contract.WarnAppleScriptFallback {
+			t.Fatalf("expected the scan's warning on every waiter, got %+v", ws)
+		}
+		reused += w.attempts.Snapshot()["shared_read"]
+	}
+	if scans.Load() != 2 || reused != 1 {
+		t.Fatalf("expected two scans and one reuse, got %d scans and %d reuses", scans.Load(), reused)
+	}
+}
+
+func TestSharedListEventsIgnoresOlderResults(t *testing.T) {
+	ctx := WithSharedReads(context.Background(), t.TempDir())
+	f := EventFilter{Query: "standup"}
+	scans := 0
+	list := func() ([]contract.Event, error) {
+		scans++
+		return []contract.Event{{ID: "evt-1"}}, nil
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := sharedListEvents(ctx, f, list); err != nil {
+			t.Fatalf("sharedListEvents failed: %v", err)
+		}
+	}
+	if scans != 2 {
+		t.Fatalf("sequential calls must each scan, got %d scans", scans)
+	}
+}
+
+func TestSharedReadKeyCoversCalendarDBOverride(t *testing.T) {
+	f := EventFilter{Query: "standup"}
+	t.Setenv(calendarDBOverrideEnv, "/tmp/a.sqlitedb")
+	a, _ := sharedReadKey(f)
+	t.Setenv(calendarDBOverrideEnv, "/tmp/b.sqlitedb")
+	b, _ := sharedReadKey(f)
+	if a == b {
+		t.Fatalf("expected different databases to use different keys")
+	}
+}
@@ -0,0 +1,54 @@
+package backend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+type WarningRecorder struct {
+	mu       sync.Mutex
+	warnings []contract.Warning
+}
+
+type warningRecorderContextKey struct{}
+
+func WithWarningRecorder(ctx context.Context, r *WarningRecorder) context.Context {
+	return context.WithValue(ctx, warningRecorderContextKey{}, r)
+}
+
+func NewWarningRecorder() *WarningRecorder {
+	return &WarningRecorder{}
+}
+
+func WarningsFromContext(ctx context.Context) []contract.Warning {
+	r, _ := ctx.Value(warningRecorderContextKey{}).(*WarningRecorder)
+	if r == nil {
+		return nil
+	}
+	return r.Snapshot()
+}
+
+func (r *WarningRecorder) Snapshot() []contract.Warning {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]contract.Warning(nil), r.warnings...)
+}
+
+func (r *WarningRecorder) add(w contract.Warning) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, have := range r.warnings {
+		if have == w {
+			return
+		}
+	}
+	r.warnings = append(r.warnings, w)
+}
+
+func RecordWarning(ctx context.Context, code contract.WarningCode, message string) {
+	if r, ok := ctx.Value(warningRecorderContextKey{}).(*WarningRecorder); ok && r != nil {
+		r.add(contract.Warning{Code: code, Message: message})
+	}
+}
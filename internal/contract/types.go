@@ -14,8 +14,41 @@ const (
 	ErrConflict           ErrorCode = "CONFLICT"
 	ErrBackendUnavailable ErrorCode = "BACKEND_UNAVAILABLE"
 	ErrConcurrency        ErrorCode = "CONCURRENCY_CONFLICT"
+	ErrUnsupportedSchema  ErrorCode = "UNSUPPORTED_SCHEMA"
 )
 
+type ErrorCodeInfo struct {
+	Code        ErrorCode `json:"code"`
+	ExitCode    int       `json:"exit_code"`
+	Retryable   bool      `json:"retryable"`
+	Description string    `json:"description"`
+}
+
+var ErrorCodes = []ErrorCodeInfo{
+	{Code: ErrGeneric, ExitCode: 1, Retryable: false, Description: "Runtime or processing failure"},
+	{Code: ErrInvalidUsage, ExitCode: 2, Retryable: false, Description: "Invalid flags, arguments, or input validation failure"},
+	{Code: ErrPermissionDenied, ExitCode: 3, Retryable: false, Description: "Calendar access denied by the OS or server"},
+	{Code: ErrNotFound, ExitCode: 4, Retryable: false, Description: "Event, calendar, query, or schema not found"},
+	{Code: ErrConflict, ExitCode: 5, Retryable: false, Description: "Write conflicts with existing calendar state"},
+	{Code: ErrBackendUnavailable, ExitCode: 6, Retryable: true, Description: "Backend unreachable, timed out, or not ready"},
+	{Code: ErrConcurrency, ExitCode: 7, Retryable: true, Description: "Sequence mismatch; re-fetch the event and retry"},
+	{Code: ErrUnsupportedSchema, ExitCode: 8, Retryable: false, Description: "Calendar database schema not recognized by this acal version"},
+}
+
+func LookupErrorCode(code ErrorCode) (ErrorCodeInfo, bool) {
+	for _, info := range ErrorCodes {
+		if info.Code == code {
+			return info, true
+		}
+	}
+	return ErrorCodeInfo{}, false
+}
+
+func (c ErrorCode) Retryable() bool {
+	info, _ := LookupErrorCode(c)
+	return info.Retryable
+}
+
 type ErrorEnvelope struct {
 	SchemaVersion string         `json:"schema_version"`
 	Error         ErrorBody      `json:"error"`
@@ -23,9 +56,60 @@ type ErrorEnvelope struct {
 }
 
 type ErrorBody struct {
-	Code    ErrorCode `json:"code"`
-	Message string    `json:"message"`
-	Hint    string    `json:"hint,omitempty"`
+	Code      ErrorCode `json:"code"`
+	Message   string    `json:"message"`
+	Hint      string    `json:"hint,omitempty"`
+	Retryable bool      `json:"retryable"`
+	// Details is set only for the parts that apply.
+	Details *ErrorDetails `json:"details,omitempty"`
+}
+
+type ErrorDetails struct {
+	Fields   []FieldError         `json:"fields,omitempty"`
+	EventIDs []string             `json:"event_ids,omitempty"`
+	Backend  *BackendErrorDetails `json:"backend,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+type BackendErrorDetails struct {
+	Phase    string `json:"phase,omitempty"`
+	Kind     string `json:"kind,omitempty"`
+	Deadline string `json:"deadline,omitempty"`
+	Command  string `json:"command,omitempty"`
+	Stderr   string `json:"stderr,omitempty"`
+}
+
+type WarningCode string
+
+const (
+	WarnAppleScriptFallback   WarningCode = "applescript_fallback_used"
+	WarnOccurrenceCacheLag    WarningCode = "occurrence_cache_lag"
+	WarnBirthdaysUnavailable  WarningCode = "birthdays_unavailable"
+	WarnRecurrenceUnavailable WarningCode = "recurrence_details_unavailable"
+	WarnICSEventSkipped       WarningCode = "ics_event_skipped"
+	WarnInviteCancellation    WarningCode = "invite_cancellation_skipped"
+	WarnTimeGuessed           WarningCode = "time_guessed"
+	WarnRoomUnmatched         WarningCode = "room_unmatched"
+	WarnNoSlot                WarningCode = "no_slot"
+	WarnFocusPeriodsMissing   WarningCode = "focus_periods_missing"
+	WarnPartialFailure        WarningCode = "partial_failure"
+	WarnDeletionUndated       WarningCode = "deletion_undated"
+	WarnNothingToRun          WarningCode = "nothing_to_run"
+	WarnRuleDisabled          WarningCode = "rule_disabled"
+	WarnEnvironmentDegraded   WarningCode = "environment_degraded"
+	WarnFallbackIncomplete    WarningCode = "applescript_fallback_incomplete"
+	WarnAnnotationUnavailable WarningCode = "annotation_unavailable"
+	WarnRecurrenceNotExpanded WarningCode = "recurrence_not_expanded"
+)
+
+type Warning struct {
+	Code    WarningCode `json:"code"`
+	Message string      `json:"message"`
 }
 
 type SuccessEnvelope struct {
@@ -35,16 +119,27 @@ type SuccessEnvelope struct {
 	Data          any            `json:"data"`
 	Meta          map[string]any `json:"meta"`
 	Warnings      []string       `json:"warnings"`
+	// WarningCodes parallels Warnings.
+	WarningCodes []WarningCode `json:"warning_codes"`
+	// Request echoes the resolved inputs when --echo-request is set.
+	Request any `json:"request,omitempty"`
 }
 
 type Calendar struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Writable bool   `json:"writable"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Writable    bool   `json:"writable"`
+	Source      string `json:"source,omitempty"`
+	Account     string `json:"account,omitempty"`
+	AccountType string `json:"account_type,omitempty"`
+	Subscribed  bool   `json:"subscribed,omitempty"`
+	Shared      bool   `json:"shared,omitempty"`
+	Delegated   bool   `json:"delegated,omitempty"`
 }
 
 type Event struct {
 	ID           string    `json:"id"`
+	Alias        string    `json:"alias,omitempty"`
 	CalendarID   string    `json:"calendar_id"`
 	CalendarName string    `json:"calendar_name"`
 	Title        string    `json:"title"`
@@ -54,12 +149,39 @@ type Event struct {
 	Location     string    `json:"location"`
 	Notes        string    `json:"notes"`
 	URL          string    `json:"url"`
+	Status       string    `json:"status,omitempty"`
+	Availability string    `json:"availability,omitempty"`
+	Sensitivity  string    `json:"sensitivity,omitempty"`
 	Sequence     int       `json:"sequence"`
+	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	ETag         string    `json:"etag"`
+	Tags         []string  `json:"tags"`
+	MeetingURL   string    `json:"meeting_url,omitempty"`
+	IsVideoCall  bool      `json:"is_video_call,omitempty"`
+	Continued    bool      `json:"continued,omitempty"`
+	Focus        string    `json:"focus,omitempty"`
+	Source       string    `json:"source,omitempty"`
 }
 
+const (
+	StatusConfirmed = "confirmed"
+	StatusTentative = "tentative"
+	StatusCancelled = "cancelled"
+
+	AvailabilityBusy        = "busy"
+	AvailabilityFree        = "free"
+	AvailabilityTentative   = "tentative"
+	AvailabilityUnavailable = "unavailable"
+
+	SensitivityPublic       = "public"
+	SensitivityPrivate      = "private"
+	SensitivityConfidential = "confidential"
+)
+
 type DoctorCheck struct {
 	Name    string `json:"name"`
 	Status  string `json:"status"`
 	Message string `json:"message"`
+	Path    string `json:"path,omitempty"`
 }
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+var (
+	plainColumnsMu sync.RWMutex
+	plainColumns   = map[reflect.Type][]string{
+		reflect.TypeOf(contract.Event{}):       {"id", "calendar_name", "title", "start", "end", "all_day", "location"},
+		reflect.TypeOf(contract.Calendar{}):    {"id", "name", "writable"},
+		reflect.TypeOf(contract.DoctorCheck{}): {"name", "status", "message"},
+	}
+)
+
+func RegisterPlainColumns(sample any, columns []string) {
+	plainColumnsMu.Lock()
+	defer plainColumnsMu.Unlock()
+	plainColumns[indirectType(reflect.TypeOf(sample))] = append([]string(nil), columns...)
+}
+
+func PlainColumns(t reflect.Type) []string {
+	if t == nil {
+		return nil
+	}
+	plainColumnsMu.RLock()
+	defer plainColumnsMu.RUnlock()
+	return plainColumns[indirectType(t)]
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"reflect"
+
+	"github.com/agis/acal/internal/contract"
+)
+
+var eventType = reflect.TypeOf(contract.Event{})
+
+func MapEvents(data any, fn func(contract.Event) contract.Event) any {
+	v := reflect.ValueOf(data)
+	if !v.IsValid() {
+		return data
+	}
+	return mapEventValue(v, fn).Interface()
+}
+
+func FormatEventIDs(data any, format func(contract.Event) string) any {
+	return MapEvents(data, func(e contract.Event) contract.Event {
+		e.ID = format(e)
+		return e
+	})
+}
+
+func mapEventValue(v reflect.Value, fn func(contract.Event) contract.Event) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(mapEventValue(v.Elem(), fn))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(mapEventValue(v.Elem(), fn))
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(mapEventValue(v.Index(i), fn))
+		}
+		return out
+	case reflect.Struct:
+		if v.Type() == eventType {
+			return reflect.ValueOf(fn(v.Interface().(contract.Event)))
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if f := out.Field(i); f.CanSet() {
+				f.Set(mapEventValue(v.Field(i), fn))
+			}
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -26,13 +26,21 @@ type Printer struct {
 	Command       string
 	Fields        []string
 	Quiet         bool
+	Header        bool
 	NoColor       bool
+	Theme         Theme
 	SchemaVersion string
-	Out           io.Writer
-	Err           io.Writer
+	HidePrivate   bool
+	EventID       func(contract.Event) string
+	Request       any
+	// FormatTime renders timestamps in plain output; nil means RFC3339.
+	FormatTime func(time.Time) string
+	Out        io.Writer
+	Err        io.Writer
 }
 
-func (p Printer) Success(data any, meta map[string]any, warnings []string) error {
+func (p Printer) Success(data any, meta map[string]any, warnings []contract.Warning) error {
+	data = p.present(data)
 	switch p.EffectiveSuccessMode() {
 	case ModeJSON:
 		env := contract.SuccessEnvelope{
@@ -41,7 +49,13 @@ func (p Printer) Success(data any, meta map[string]any, warnings []string) error
 			GeneratedAt:   time.Now().UTC(),
 			Data:          data,
 			Meta:          meta,
-			Warnings:      warnings,
+			Warnings:      make([]string, 0, len(warnings)),
+			WarningCodes:  make([]contract.WarningCode, 0, len(warnings)),
+			Request:       p.Request,
+		}
+		for _, w := range warnings {
+			env.Warnings = append(env.Warnings, w.Message)
+			env.WarningCodes = append(env.WarningCodes, w.Code)
 		}
 		enc := json.NewEncoder(p.outWriter())
 		enc.SetIndent("", "  ")
@@ -59,20 +73,52 @@ func (p Printer) Success(data any, meta map[string]any, warnings []string) error
 		}
 		return json.NewEncoder(p.outWriter()).Encode(data)
 	default:
+		p.PrintWarnings(warnings)
 		return p.printPlain(data)
 	}
 }
 
+func (p Printer) PrintWarnings(warnings []contract.Warning) {
+	if p.Quiet {
+		return
+	}
+	label := "warning"
+	if p.colorsEnabled() {
+		label = Paint(p.Palette().Warning, label)
+	}
+	for _, w := range warnings {
+		_, _ = fmt.Fprintf(p.errWriter(), "%s: %s\n", label, w.Message)
+	}
+}
+
+func (p Printer) StreamItem(item any) error {
+	return json.NewEncoder(p.outWriter()).Encode(p.present(item))
+}
+
+func (p Printer) present(data any) any {
+	if p.HidePrivate {
+		data = MaskPrivate(data)
+	}
+	if p.EventID != nil {
+		data = FormatEventIDs(data, p.EventID)
+	}
+	return data
+}
+
 func (p Printer) Error(code contract.ErrorCode, message, hint string) error {
 	return p.ErrorWithMeta(code, message, hint, nil)
 }
 
 func (p Printer) ErrorWithMeta(code contract.ErrorCode, message, hint string, meta map[string]any) error {
+	return p.ErrorWithDetails(code, message, hint, nil, meta)
+}
+
+func (p Printer) ErrorWithDetails(code contract.ErrorCode, message, hint string, details *contract.ErrorDetails, meta map[string]any) error {
 	mode := p.EffectiveErrorMode()
 	if mode == ModeJSON || mode == ModeJSONL {
 		env := contract.ErrorEnvelope{
 			SchemaVersion: p.schemaVersion(),
-			Error:         contract.ErrorBody{Code: code, Message: message, Hint: hint},
+			Error:         contract.ErrorBody{Code: code, Message: message, Hint: hint, Retryable: code.Retryable(), Details: details},
 			Meta:          meta,
 		}
 		enc := json.NewEncoder(p.errWriter())
@@ -122,15 +168,28 @@ func (p Printer) printPlain(data any) error {
 		}
 		return nil
 	}
+	rowType := v.Type()
+	if v.Kind() == reflect.Slice {
+		rowType = rowType.Elem()
+	}
+	columns := p.Fields
+	if len(columns) == 0 {
+		columns = PlainColumns(rowType)
+	}
+	if p.Header && len(columns) > 0 {
+		if _, err := fmt.Fprintln(p.outWriter(), strings.Join(columns, "\t")); err != nil {
+			return err
+		}
+	}
 	if v.Kind() == reflect.Slice {
 		for i := 0; i < v.Len(); i++ {
-			if _, err := fmt.Fprintln(p.outWriter(), flatten(v.Index(i).Interface(), p.Fields)); err != nil {
+			if _, err := fmt.Fprintln(p.outWriter(), flattenWith(v.Index(i).Interface(), columns, p.FormatTime)); err != nil {
 				return err
 			}
 		}
 		return nil
 	}
-	_, err := fmt.Fprintln(p.outWriter(), flatten(data, p.Fields))
+	_, err := fmt.Fprintln(p.outWriter(), flattenWith(data, columns, p.FormatTime))
 	return err
 }
 
@@ -150,12 +209,27 @@ func (p Printer) errWriter() io.Writer {
 
 func (p Printer) errorLabel() string {
 	if p.colorsEnabled() {
-		return "\x1b[31merror\x1b[0m"
+		return Paint(p.Palette().Error, "error")
 	}
 	return "error"
 }
 
+func (p Printer) Palette() Theme {
+	if p.Theme.Name == "" {
+		return themes["default"]
+	}
+	return p.Theme
+}
+
 func (p Printer) colorsEnabled() bool {
+	return p.colorsEnabledFor(p.errWriter())
+}
+
+func (p Printer) OutColors() bool {
+	return p.colorsEnabledFor(p.outWriter())
+}
+
+func (p Printer) colorsEnabledFor(w io.Writer) bool {
 	if p.NoColor {
 		return false
 	}
@@ -165,7 +239,7 @@ func (p Printer) colorsEnabled() bool {
 	if strings.EqualFold(strings.TrimSpace(os.Getenv("TERM")), "dumb") {
 		return false
 	}
-	return p.writerIsTerminal(p.errWriter())
+	return p.writerIsTerminal(w)
 }
 
 func (p Printer) writerIsTerminal(w io.Writer) bool {
@@ -181,6 +255,10 @@ func (p Printer) writerIsTerminal(w io.Writer) bool {
 }
 
 func flatten(v any, fields []string) string {
+	return flattenWith(v, fields, nil)
+}
+
+func flattenWith(v any, fields []string, formatTime func(time.Time) string) string {
 	if len(fields) == 0 {
 		b, _ := json.Marshal(v)
 		return string(b)
@@ -195,14 +273,62 @@ func flatten(v any, fields []string) string {
 	}
 	parts := make([]string, 0, len(fields))
 	for _, f := range fields {
-		fv := rv.FieldByNameFunc(func(name string) bool {
-			return strings.EqualFold(name, strings.ReplaceAll(f, "_", "")) || strings.EqualFold(name, f)
-		})
+		fv := plainField(rv, f)
 		if !fv.IsValid() {
 			parts = append(parts, "")
 			continue
 		}
-		parts = append(parts, fmt.Sprint(fv.Interface()))
+		parts = append(parts, plainValue(fv, formatTime))
 	}
 	return strings.Join(parts, "\t")
 }
+
+func plainField(rv reflect.Value, name string) reflect.Value {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if tag != "" && strings.EqualFold(tag, name) {
+			return rv.Field(i)
+		}
+	}
+	return rv.FieldByNameFunc(func(field string) bool {
+		return strings.EqualFold(field, strings.ReplaceAll(name, "_", "")) || strings.EqualFold(field, name)
+	})
+}
+
+var plainEscaper = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+
+func plainValue(v reflect.Value, formatTime func(time.Time) string) string {
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		if formatTime != nil {
+			return formatTime(t)
+		}
+		return t.Format(time.RFC3339)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return plainEscaper.Replace(v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.String {
+			items := make([]string, v.Len())
+			for i := range items {
+				items[i] = plainEscaper.Replace(v.Index(i).String())
+			}
+			return strings.Join(items, ",")
+		}
+	case reflect.Map, reflect.Struct:
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+	b, _ := json.Marshal(v.Interface())
+	return string(b)
+}
@@ -2,6 +2,7 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -57,6 +58,43 @@ func TestPrinterWritesToInjectedWriters(t *testing.T) {
 	}
 }
 
+func TestPrinterSuccessWarnings(t *testing.T) {
+	warnings := []contract.Warning{{Code: contract.WarnAppleScriptFallback, Message: "listed through AppleScript"}}
+	var out, errb bytes.Buffer
+	p := Printer{Mode: ModeJSON, Command: "today", Out: &out, Err: &errb}
+	if err := p.Success([]contract.Event{}, nil, nil); err != nil {
+		t.Fatalf("success failed: %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, `"warnings": []`) || !strings.Contains(got, `"warning_codes": []`) {
+		t.Fatalf("expected empty warning arrays, got %q", got)
+	}
+	out.Reset()
+	if err := p.Success([]contract.Event{}, nil, warnings); err != nil {
+		t.Fatalf("success failed: %v", err)
+	}
+	var env contract.SuccessEnvelope
+	if err := json.Unmarshal(out.Bytes(), &env); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if len(env.Warnings) != 1 || env.Warnings[0] != "listed through AppleScript" || len(env.WarningCodes) != 1 || env.WarningCodes[0] != contract.WarnAppleScriptFallback {
+		t.Fatalf("unexpected warnings: %v %v", env.Warnings, env.WarningCodes)
+	}
+
+	p.Mode = ModePlain
+	if err := p.Success([]contract.Event{}, nil, warnings); err != nil {
+		t.Fatalf("success failed: %v", err)
+	}
+	if got := errb.String(); got != "warning: listed through AppleScript\n" {
+		t.Fatalf("unexpected plain stderr: %q", got)
+	}
+	errb.Reset()
+	p.Quiet = true
+	_ = p.Success([]contract.Event{}, nil, warnings)
+	if errb.Len() != 0 {
+		t.Fatalf("expected --quiet to drop warnings, got %q", errb.String())
+	}
+}
+
 func TestPrinterErrorRespectsNoColorAndEnv(t *testing.T) {
 	t.Setenv("NO_COLOR", "1")
 	var errb bytes.Buffer
@@ -92,3 +130,42 @@ func TestPrinterErrorWithMetaJSON(t *testing.T) {
 		t.Fatalf("expected meta fields in json error, got: %q", got)
 	}
 }
+
+func TestMaskPrivateCopiesNestedEvents(t *testing.T) {
+	type wrapper struct {
+		Event  contract.Event
+		Events []contract.Event
+		Ptr    *contract.Event
+	}
+	private := contract.Event{ID: "p", Title: "Therapy", Notes: "room 4", Tags: []string{"health"}, Sensitivity: contract.SensitivityConfidential}
+	public := contract.Event{ID: "q", Title: "Standup", Sensitivity: contract.SensitivityPublic}
+	in := wrapper{Event: private, Events: []contract.Event{public, private}, Ptr: &private}
+
+	got := MaskPrivate(in).(wrapper)
+	if got.Event.Title != "Private event" || got.Event.Notes != "" || len(got.Event.Tags) != 0 {
+		t.Fatalf("event not masked: %+v", got.Event)
+	}
+	if got.Events[0].Title != "Standup" || got.Events[1].Title != "Private event" || got.Ptr.Title != "Private event" {
+		t.Fatalf("nested events not masked: %+v", got)
+	}
+	if private.Title != "Therapy" || in.Events[1].Title != "Therapy" {
+		t.Fatal("MaskPrivate modified its input")
+	}
+}
+
+func TestPrinterFormatTimeHook(t *testing.T) {
+	var out bytes.Buffer
+	p := Printer{
+		Mode:       ModePlain,
+		Fields:     []string{"title", "start"},
+		FormatTime: func(t time.Time) string { return t.Format("02.01.2006 15:04") },
+		Out:        &out,
+	}
+	e := contract.Event{Title: "Standup", Start: time.Date(2026, 2, 16, 10, 0, 0, 0, time.UTC)}
+	if err := p.Success([]contract.Event{e}, nil, nil); err != nil {
+		t.Fatalf("Success failed: %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "Standup\t16.02.2026 10:00" {
+		t.Fatalf("unexpected plain output: %q", got)
+	}
+}
@@ -0,0 +1,22 @@
+package output
+
+import "github.com/agis/acal/internal/contract"
+
+const privateTitle = "Private event"
+
+func MaskPrivate(data any) any {
+	return MapEvents(data, maskEvent)
+}
+
+func maskEvent(e contract.Event) contract.Event {
+	if e.Sensitivity != contract.SensitivityPrivate && e.Sensitivity != contract.SensitivityConfidential {
+		return e
+	}
+	e.Title = privateTitle
+	e.Location = ""
+	e.Notes = ""
+	e.URL = ""
+	e.MeetingURL = ""
+	e.Tags = []string{}
+	return e
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Theme struct {
+	Name     string
+	Error    string
+	Warning  string
+	Busy     string
+	Open     string
+	Conflict string
+}
+
+var themes = map[string]Theme{
+	"default":       {Name: "default", Error: "31", Warning: "33", Busy: "34", Open: "2", Conflict: "1;31"},
+	"high-contrast": {Name: "high-contrast", Error: "1;97;41", Warning: "1;93", Busy: "1;96", Open: "37", Conflict: "1;97;41"},
+	"light":         {Name: "light", Error: "38;5;160", Warning: "38;5;130", Busy: "38;5;25", Open: "38;5;250", Conflict: "1;38;5;160"},
+	"mono":          {Name: "mono", Error: "1", Warning: "1", Conflict: "1;7"},
+}
+
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func LookupTheme(name string) (Theme, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = "default"
+	}
+	t, ok := themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q: use %s", name, strings.Join(ThemeNames(), ", "))
+	}
+	return t, nil
+}
+
+func Paint(style, s string) string {
+	if style == "" || s == "" {
+		return s
+	}
+	return "\x1b[" + style + "m" + s + "\x1b[0m"
+}
+
+var colorNames = map[string]int{"black": 0, "red": 1, "green": 2, "yellow": 3, "blue": 4, "magenta": 5, "cyan": 6, "white": 7}
+
+func ParseColor(s string) (string, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	if n, ok := colorNames[v]; ok {
+		return strconv.Itoa(30 + n), nil
+	}
+	if n, ok := colorNames[strings.TrimPrefix(v, "bright-")]; ok && strings.HasPrefix(v, "bright-") {
+		return strconv.Itoa(90 + n), nil
+	}
+	if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 255 {
+		return "38;5;" + v, nil
+	}
+	if len(v) == 7 && v[0] == '#' {
+		if rgb, err := strconv.ParseUint(v[1:], 16, 32); err == nil {
+			return fmt.Sprintf("38;2;%d;%d;%d", rgb>>16, rgb>>8&0xff, rgb&0xff), nil
+		}
+	}
+	return "", fmt.Errorf("invalid color %q: use a name like green or bright-red, a 256-color index, or #rrggbb", s)
+}
@@ -0,0 +1,44 @@
+package output
+
+import "testing"
+
+func TestLookupTheme(t *testing.T) {
+	def, err := LookupTheme("")
+	if err != nil || def.Name != "default" {
+		t.Fatalf("expected default theme, got %+v err=%v", def, err)
+	}
+	if mono, err := LookupTheme("Mono"); err != nil || mono.Busy != "" {
+		t.Fatalf("expected uncolored busy in mono, got %+v err=%v", mono, err)
+	}
+	if _, err := LookupTheme("neon"); err == nil {
+		t.Fatalf("expected error for unknown theme")
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	for in, want := range map[string]string{
+		"green":      "32",
+		"bright-red": "91",
+		"208":        "38;5;208",
+		"#FF8800":    "38;2;255;136;0",
+	} {
+		got, err := ParseColor(in)
+		if err != nil || got != want {
+			t.Fatalf("ParseColor(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "bright-", "256", "#ff88", "teal"} {
+		if _, err := ParseColor(in); err == nil {
+			t.Fatalf("expected error for %q", in)
+		}
+	}
+}
+
+func TestPaint(t *testing.T) {
+	if got := Paint("1;31", "!!"); got != "\x1b[1;31m!!\x1b[0m" {
+		t.Fatalf("unexpected paint: %q", got)
+	}
+	if got := Paint("", "##"); got != "##" {
+		t.Fatalf("expected unstyled text, got %q", got)
+	}
+}
@@ -0,0 +1,76 @@
+package timeparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func ParseClock(s string) (int, int, error) {
+	raw := strings.ToLower(strings.TrimSpace(s))
+	meridiem := ""
+	for _, suffix := range []string{"am", "pm"} {
+		if strings.HasSuffix(raw, suffix) {
+			meridiem = suffix
+			raw = strings.TrimSuffix(raw, suffix)
+			break
+		}
+	}
+	hourS, minuteS, hasMinutes := strings.Cut(raw, ":")
+	if !hasMinutes && meridiem == "" {
+		return 0, 0, fmt.Errorf("invalid time: %s", s)
+	}
+	if !isDigits(hourS, 1, 2) || (hasMinutes && !isDigits(minuteS, 2, 2)) {
+		return 0, 0, fmt.Errorf("invalid time: %s", s)
+	}
+	hour, _ := strconv.Atoi(hourS)
+	minute := 0
+	if hasMinutes {
+		if minute, _ = strconv.Atoi(minuteS); minute > 59 {
+			return 0, 0, fmt.Errorf("invalid time: %s", s)
+		}
+	}
+	switch meridiem {
+	case "":
+		if hour > 23 {
+			return 0, 0, fmt.Errorf("invalid time: %s", s)
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("invalid time: %s", s)
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+	return hour, minute, nil
+}
+
+func IsClock(s string) bool {
+	_, _, err := ParseClock(s)
+	return err == nil
+}
+
+func ClockLayout(format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "24h":
+		return "15:04", nil
+	case "12h":
+		return "3:04pm", nil
+	default:
+		return "", fmt.Errorf("invalid time format: %s (use 12h|24h)", format)
+	}
+}
+
+func isDigits(s string, minLen, maxLen int) bool {
+	if len(s) < minLen || len(s) > maxLen {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
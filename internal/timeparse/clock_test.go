@@ -0,0 +1,30 @@
+package timeparse
+
+import "testing"
+
+func TestParseClock(t *testing.T) {
+	cases := []struct {
+		in           string
+		hour, minute int
+	}{
+		{"09:30", 9, 30},
+		{"3pm", 15, 0},
+		{"10:30am", 10, 30},
+		{"12am", 0, 0},
+		{"12:15PM", 12, 15},
+	}
+	for _, tc := range cases {
+		h, m, err := ParseClock(tc.in)
+		if err != nil || h != tc.hour || m != tc.minute {
+			t.Fatalf("ParseClock(%q) = %d:%d, %v; want %d:%d", tc.in, h, m, err, tc.hour, tc.minute)
+		}
+	}
+	for _, bad := range []string{"10", "13pm", "0am", "24:00", "9:5", "+1:00", "pm"} {
+		if _, _, err := ParseClock(bad); err == nil {
+			t.Fatalf("ParseClock(%q) should fail", bad)
+		}
+	}
+	if _, err := ClockLayout("13h"); err == nil {
+		t.Fatal("expected invalid time format error")
+	}
+}
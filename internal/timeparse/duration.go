@@ -0,0 +1,63 @@
+package timeparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationPartRe    = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zµ]+)`)
+	durationArticleRe = regexp.MustCompile(`\ban?\s+`)
+)
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond, "us": time.Microsecond, "µs": time.Microsecond, "ms": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "wk": 7 * 24 * time.Hour, "wks": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+func ParseDuration(input string) (time.Duration, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	s = strings.NewReplacer(",", " ", " and ", " ").Replace(s)
+	s = strings.ReplaceAll(s, "half an hour", "30m")
+	s = strings.ReplaceAll(s, "half hour", "30m")
+	s = durationArticleRe.ReplaceAllString(s, "1 ")
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration: %q", input)
+	}
+	var total time.Duration
+	for s != "" {
+		m := durationPartRe.FindStringSubmatch(s)
+		if m == nil {
+			return 0, fmt.Errorf("invalid duration: %q", input)
+		}
+		unit, ok := durationUnits[m[2]]
+		if !ok {
+			return 0, fmt.Errorf("invalid duration: %q (unknown unit %q)", input, m[2])
+		}
+		n, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %q", input)
+		}
+		total += time.Duration(n * float64(unit))
+		s = strings.TrimSpace(s[len(m[0]):])
+	}
+	return sign * total, nil
+}
@@ -0,0 +1,38 @@
+package timeparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"30m", 30 * time.Minute},
+		{"-1h30m", -90 * time.Minute},
+		{"+15m", 15 * time.Minute},
+		{"1d", 24 * time.Hour},
+		{"2d3h", 51 * time.Hour},
+		{"1w", 7 * 24 * time.Hour},
+		{"90 minutes", 90 * time.Minute},
+		{"1 hour 30 mins", 90 * time.Minute},
+		{"2 days and 3 hours", 51 * time.Hour},
+		{"1.5 hours", 90 * time.Minute},
+		{"an hour", time.Hour},
+		{"half an hour", 30 * time.Minute},
+		{"0", 0},
+	}
+	for _, tc := range cases {
+		got, err := ParseDuration(tc.in)
+		if err != nil || got != tc.want {
+			t.Fatalf("ParseDuration(%q) = %s, %v; want %s", tc.in, got, err, tc.want)
+		}
+	}
+	for _, bad := range []string{"", "abc", "10", "5 fortnights", "1h garbage"} {
+		if _, err := ParseDuration(bad); err == nil {
+			t.Fatalf("ParseDuration(%q) should fail", bad)
+		}
+	}
+}
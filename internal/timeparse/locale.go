@@ -0,0 +1,265 @@
+package timeparse
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type Locale struct {
+	Code       string
+	Today      []string
+	Tomorrow   []string
+	Yesterday  []string
+	Weekdays   [7][]string
+	Months     [12][]string
+	DateLayout string
+}
+
+var locales = map[string]*Locale{
+	"en": {
+		Code: "en", Today: []string{"today"}, Tomorrow: []string{"tomorrow"}, Yesterday: []string{"yesterday"},
+		Weekdays: [7][]string{
+			{"Sun", "Sunday"}, {"Mon", "Monday"}, {"Tue", "Tuesday", "Tues"}, {"Wed", "Wednesday"},
+			{"Thu", "Thursday", "Thur", "Thurs"}, {"Fri", "Friday"}, {"Sat", "Saturday"},
+		},
+		Months: [12][]string{
+			{"Jan", "January"}, {"Feb", "February"}, {"Mar", "March"}, {"Apr", "April"}, {"May"}, {"Jun", "June"},
+			{"Jul", "July"}, {"Aug", "August"}, {"Sep", "September", "Sept"}, {"Oct", "October"}, {"Nov", "November"}, {"Dec", "December"},
+		},
+		DateLayout: "2006-01-02",
+	},
+	"de": {
+		Code: "de", Today: []string{"heute"}, Tomorrow: []string{"morgen"}, Yesterday: []string{"gestern"},
+		Weekdays: [7][]string{
+			{"So", "Sonntag"}, {"Mo", "Montag"}, {"Di", "Dienstag"}, {"Mi", "Mittwoch"},
+			{"Do", "Donnerstag"}, {"Fr", "Freitag"}, {"Sa", "Samstag", "Sonnabend"},
+		},
+		Months: [12][]string{
+			{"Jan", "Januar", "Jänner"}, {"Feb", "Februar"}, {"Mär", "März", "Maerz", "Mrz"}, {"Apr", "April"}, {"Mai"}, {"Jun", "Juni"},
+			{"Jul", "Juli"}, {"Aug", "August"}, {"Sep", "September", "Sept"}, {"Okt", "Oktober"}, {"Nov", "November"}, {"Dez", "Dezember"},
+		},
+		DateLayout: "02.01.2006",
+	},
+	"es": {
+		Code: "es", Today: []string{"hoy"}, Tomorrow: []string{"mañana", "manana"}, Yesterday: []string{"ayer"},
+		Weekdays: [7][]string{
+			{"dom", "domingo"}, {"lun", "lunes"}, {"mar", "martes"}, {"mié", "miércoles", "mie", "miercoles"},
+			{"jue", "jueves"}, {"vie", "viernes"}, {"sáb", "sábado", "sab", "sabado"},
+		},
+		Months: [12][]string{
+			{"ene", "enero"}, {"feb", "febrero"}, {"mar", "marzo"}, {"abr", "abril"}, {"may", "mayo"}, {"jun", "junio"},
+			{"jul", "julio"}, {"ago", "agosto"}, {"sept", "septiembre", "sep", "setiembre"}, {"oct", "octubre"}, {"nov", "noviembre"}, {"dic", "diciembre"},
+		},
+		DateLayout: "02/01/2006",
+	},
+	"fr": {
+		Code: "fr", Today: []string{"aujourd'hui", "aujourdhui"}, Tomorrow: []string{"demain"}, Yesterday: []string{"hier"},
+		Weekdays: [7][]string{
+			{"dim", "dimanche"}, {"lun", "lundi"}, {"mar", "mardi"}, {"mer", "mercredi"},
+			{"jeu", "jeudi"}, {"ven", "vendredi"}, {"sam", "samedi"},
+		},
+		Months: [12][]string{
+			{"janv", "janvier"}, {"févr", "février", "fevr", "fevrier"}, {"mars"}, {"avr", "avril"}, {"mai"}, {"juin"},
+			{"juil", "juillet"}, {"août", "aout"}, {"sept", "septembre"}, {"oct", "octobre"}, {"nov", "novembre"}, {"déc", "décembre", "dec", "decembre"},
+		},
+		DateLayout: "02/01/2006",
+	},
+	"it": {
+		Code: "it", Today: []string{"oggi"}, Tomorrow: []string{"domani"}, Yesterday: []string{"ieri"},
+		Weekdays: [7][]string{
+			{"dom", "domenica"}, {"lun", "lunedì", "lunedi"}, {"mar", "martedì", "martedi"}, {"mer", "mercoledì", "mercoledi"},
+			{"gio", "giovedì", "giovedi"}, {"ven", "venerdì", "venerdi"}, {"sab", "sabato"},
+		},
+		Months: [12][]string{
+			{"gen", "gennaio"}, {"feb", "febbraio"}, {"mar", "marzo"}, {"apr", "aprile"}, {"mag", "maggio"}, {"giu", "giugno"},
+			{"lug", "luglio"}, {"ago", "agosto"}, {"set", "settembre"}, {"ott", "ottobre"}, {"nov", "novembre"}, {"dic", "dicembre"},
+		},
+		DateLayout: "02/01/2006",
+	},
+	"nl": {
+		Code: "nl", Today: []string{"vandaag"}, Tomorrow: []string{"morgen"}, Yesterday: []string{"gisteren"},
+		Weekdays: [7][]string{
+			{"zo", "zondag"}, {"ma", "maandag"}, {"di", "dinsdag"}, {"wo", "woensdag"},
+			{"do", "donderdag"}, {"vr", "vrijdag"}, {"za", "zaterdag"},
+		},
+		Months: [12][]string{
+			{"jan", "januari"}, {"feb", "februari"}, {"mrt", "maart"}, {"apr", "april"}, {"mei"}, {"jun", "juni"},
+			{"jul", "juli"}, {"aug", "augustus"}, {"sep", "september"}, {"okt", "oktober"}, {"nov", "november"}, {"dec", "december"},
+		},
+		DateLayout: "02-01-2006",
+	},
+	"pt": {
+		Code: "pt", Today: []string{"hoje"}, Tomorrow: []string{"amanhã", "amanha"}, Yesterday: []string{"ontem"},
+		Weekdays: [7][]string{
+			{"dom", "domingo"}, {"seg", "segunda", "segunda-feira"}, {"ter", "terça", "terça-feira", "terca", "terca-feira"},
+			{"qua", "quarta", "quarta-feira"}, {"qui", "quinta", "quinta-feira"}, {"sex", "sexta", "sexta-feira"}, {"sáb", "sábado", "sab", "sabado"},
+		},
+		Months: [12][]string{
+			{"jan", "janeiro"}, {"fev", "fevereiro"}, {"mar", "março", "marco"}, {"abr", "abril"}, {"mai", "maio"}, {"jun", "junho"},
+			{"jul", "julho"}, {"ago", "agosto"}, {"set", "setembro"}, {"out", "outubro"}, {"nov", "novembro"}, {"dez", "dezembro"},
+		},
+		DateLayout: "02/01/2006",
+	},
+}
+
+var activeLocale atomic.Pointer[Locale]
+
+func Locales() []string {
+	out := make([]string, 0, len(locales))
+	for code := range locales {
+		out = append(out, code)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func LookupLocale(code string) (*Locale, error) {
+	base := strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(base, "_-."); i >= 0 {
+		base = base[:i]
+	}
+	if l, ok := locales[base]; ok {
+		return l, nil
+	}
+	return nil, fmt.Errorf("unsupported locale: %s (supported: %s)", code, strings.Join(Locales(), ", "))
+}
+
+func SetLocale(code string) error {
+	if strings.TrimSpace(code) == "" {
+		activeLocale.Store(nil)
+		return nil
+	}
+	l, err := LookupLocale(code)
+	if err != nil {
+		return err
+	}
+	activeLocale.Store(l)
+	return nil
+}
+
+func (l *Locale) FormatTime(t time.Time, clock string) string {
+	return l.Weekdays[t.Weekday()][0] + " " + t.Format(l.DateLayout+" "+clock)
+}
+
+func candidateLocales() []*Locale {
+	en := locales["en"]
+	if l := activeLocale.Load(); l != nil && l != en {
+		return []*Locale{l, en}
+	}
+	return []*Locale{en}
+}
+
+func matchWord(s string, words []string) bool {
+	for _, w := range words {
+		if strings.ToLower(w) == s {
+			return true
+		}
+	}
+	return false
+}
+
+func IsDayWord(token string) bool {
+	s := strings.ToLower(strings.TrimSpace(token))
+	if _, ok := relativeDay(s); ok {
+		return true
+	}
+	_, ok := weekdayNamed(s)
+	return ok
+}
+
+func relativeDay(s string) (int, bool) {
+	for _, l := range candidateLocales() {
+		switch {
+		case matchWord(s, l.Today):
+			return 0, true
+		case matchWord(s, l.Tomorrow):
+			return 1, true
+		case matchWord(s, l.Yesterday):
+			return -1, true
+		}
+	}
+	return 0, false
+}
+
+func weekdayNamed(s string) (time.Weekday, bool) {
+	s = strings.TrimRight(s, ".,")
+	for _, l := range candidateLocales() {
+		for i, names := range l.Weekdays {
+			if matchWord(s, names) {
+				return time.Weekday(i), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func monthNamed(s string) (time.Month, bool) {
+	s = strings.TrimRight(s, ".,")
+	for _, l := range candidateLocales() {
+		for i, names := range l.Months {
+			if matchWord(s, names) {
+				return time.Month(i + 1), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func parseNamedDate(s string, today time.Time) (time.Time, bool) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 || len(fields) > 3 {
+		return time.Time{}, false
+	}
+	day, dayErr := strconv.Atoi(strings.TrimRight(fields[0], ".,"))
+	month, ok := monthNamed(fields[1])
+	if dayErr != nil || !ok {
+		if month, ok = monthNamed(fields[0]); !ok {
+			return time.Time{}, false
+		}
+		if day, dayErr = strconv.Atoi(strings.TrimRight(fields[1], ".,")); dayErr != nil {
+			return time.Time{}, false
+		}
+	}
+	year := today.Year()
+	if len(fields) == 3 {
+		y, err := strconv.Atoi(fields[2])
+		if err != nil || y < 1000 {
+			return time.Time{}, false
+		}
+		year = y
+	}
+	t := time.Date(year, month, day, 0, 0, 0, 0, today.Location())
+	if t.Day() != day || t.Month() != month {
+		return time.Time{}, false
+	}
+	if len(fields) == 2 && t.Before(today) {
+		t = t.AddDate(1, 0, 0)
+	}
+	return t, true
+}
+
+var (
+	sundayFirstRegions   = map[string]bool{"BR": true, "CA": true, "CN": true, "HK": true, "IL": true, "IN": true, "JP": true, "KR": true, "MX": true, "PH": true, "PT": true, "SA": true, "TW": true, "US": true, "ZA": true}
+	saturdayFirstRegions = map[string]bool{"AE": true, "DZ": true, "EG": true, "IQ": true, "JO": true, "KW": true, "QA": true}
+)
+
+func DefaultWeekStart(code string) time.Weekday {
+	tag := strings.TrimSpace(code)
+	if i := strings.IndexByte(tag, '.'); i >= 0 {
+		tag = tag[:i]
+	}
+	if i := strings.IndexAny(tag, "_-"); i >= 0 {
+		region := strings.ToUpper(tag[i+1:])
+		switch {
+		case sundayFirstRegions[region]:
+			return time.Sunday
+		case saturdayFirstRegions[region]:
+			return time.Saturday
+		}
+	}
+	return time.Monday
+}
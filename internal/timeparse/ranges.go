@@ -0,0 +1,76 @@
+package timeparse
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+func ParseRange(input string, now time.Time, loc *time.Location, weekStart time.Weekday) (time.Time, time.Time, bool) {
+	s := strings.NewReplacer(" ", "-", "_", "-").Replace(strings.TrimSpace(strings.ToLower(input)))
+	now = now.In(loc)
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	if q, year, ok := parseQuarter(s, y); ok {
+		start := time.Date(year, time.Month(3*(q-1)+1), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 3, 0), true
+	}
+	switch s {
+	case "ytd":
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, loc), today.AddDate(0, 0, 1), true
+	case "mtd":
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc), today.AddDate(0, 0, 1), true
+	}
+	rel, unit, found := strings.Cut(s, "-")
+	if !found {
+		return time.Time{}, time.Time{}, false
+	}
+	var shift int
+	switch rel {
+	case "this":
+		shift = 0
+	case "last":
+		shift = -1
+	case "next":
+		shift = 1
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+	switch unit {
+	case "week":
+		start := today.AddDate(0, 0, -((int(today.Weekday())-int(weekStart)+7)%7)+7*shift)
+		return start, start.AddDate(0, 0, 7), true
+	case "month":
+		start := time.Date(y, m+time.Month(shift), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 1, 0), true
+	case "quarter":
+		start := time.Date(y, time.Month(3*((int(m)-1)/3)+1+3*shift), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 3, 0), true
+	case "year":
+		start := time.Date(y+shift, time.January, 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(1, 0, 0), true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+func parseQuarter(s string, thisYear int) (int, int, bool) {
+	q, yearS := s, ""
+	if a, b, ok := strings.Cut(s, "-"); ok {
+		q, yearS = a, b
+		if strings.HasPrefix(b, "q") {
+			q, yearS = b, a
+		}
+	}
+	if len(q) != 2 || q[0] != 'q' || q[1] < '1' || q[1] > '4' {
+		return 0, 0, false
+	}
+	year := thisYear
+	if yearS != "" {
+		v, err := strconv.Atoi(yearS)
+		if err != nil || len(yearS) != 4 {
+			return 0, 0, false
+		}
+		year = v
+	}
+	return int(q[1] - '0'), year, true
+}
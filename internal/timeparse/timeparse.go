@@ -41,6 +41,28 @@ func ParseDateTime(input string, now time.Time, loc *time.Location) (time.Time,
 		}
 	}
 
+	today, _ := ParseDateTime("today", now, loc)
+	if n, ok := relativeDay(s); ok {
+		return today.AddDate(0, 0, n), nil
+	}
+	if wd, ok := weekdayNamed(s); ok {
+		return today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7), nil
+	}
+	if ts, ok := parseNamedDate(s, today); ok {
+		return ts, nil
+	}
+	// "3pm" alone is today; otherwise everything before the clock names the day.
+	i := strings.LastIndex(s, " ")
+	if hour, minute, err := ParseClock(s[i+1:]); err == nil {
+		day, derr := today, error(nil)
+		if i >= 0 {
+			day, derr = ParseDateTime(s[:i], now, loc)
+		}
+		if derr == nil {
+			return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc), nil
+		}
+	}
+
 	layouts := []string{
 		time.RFC3339,
 		"2006-01-02T15:04",
@@ -17,6 +17,9 @@ func TestParseDateTime(t *testing.T) {
 		{"tomorrow", "2026-02-09T00:00:00Z"},
 		{"+7d", "2026-02-15T00:00:00Z"},
 		{"2026-02-20", "2026-02-20T00:00:00Z"},
+		{"3pm", "2026-02-08T15:00:00Z"},
+		{"tomorrow 10:30am", "2026-02-09T10:30:00Z"},
+		{"2026-02-20 9pm", "2026-02-20T21:00:00Z"},
 	}
 
 	for _, tc := range cases {
@@ -29,3 +32,108 @@ func TestParseDateTime(t *testing.T) {
 		}
 	}
 }
+
+func TestParseDateTimeLocalized(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2026, 2, 8, 15, 0, 0, 0, loc) // a Sunday
+	if err := SetLocale("de_DE.UTF-8"); err != nil {
+		t.Fatalf("SetLocale failed: %v", err)
+	}
+	t.Cleanup(func() { _ = SetLocale("") })
+
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"Heute", "2026-02-08T00:00:00Z"},
+		{"morgen", "2026-02-09T00:00:00Z"},
+		{"Dienstag", "2026-02-10T00:00:00Z"},
+		{"sunday", "2026-02-08T00:00:00Z"},
+		{"3. März 2026", "2026-03-03T00:00:00Z"},
+		{"march 3", "2026-03-03T00:00:00Z"},
+		{"1 jan", "2027-01-01T00:00:00Z"},
+	}
+	for _, tc := range cases {
+		got, err := ParseDateTime(tc.in, now, loc)
+		if err != nil {
+			t.Fatalf("ParseDateTime(%q) error: %v", tc.in, err)
+		}
+		if got.UTC().Format(time.RFC3339) != tc.want {
+			t.Fatalf("ParseDateTime(%q) = %s, want %s", tc.in, got.UTC().Format(time.RFC3339), tc.want)
+		}
+	}
+	if _, err := ParseDateTime("31 feb", now, loc); err == nil {
+		t.Fatal("expected invalid day-of-month error")
+	}
+	if _, err := ParseDateTime("mañana", now, loc); err == nil {
+		t.Fatal("Spanish words should need --locale es")
+	}
+	if err := SetLocale("xx"); err == nil {
+		t.Fatal("expected unsupported locale error")
+	}
+}
+
+func TestLocaleFormatTime(t *testing.T) {
+	l, err := LookupLocale("es")
+	if err != nil {
+		t.Fatalf("LookupLocale failed: %v", err)
+	}
+	got := l.FormatTime(time.Date(2026, 3, 4, 9, 5, 0, 0, time.UTC), "15:04")
+	if got != "mié 04/03/2026 09:05" {
+		t.Fatalf("unexpected format: %q", got)
+	}
+}
+
+func TestDefaultWeekStart(t *testing.T) {
+	for code, want := range map[string]time.Weekday{
+		"":            time.Monday,
+		"de":          time.Monday,
+		"en_GB.UTF-8": time.Monday,
+		"en_US.UTF-8": time.Sunday,
+		"pt-BR":       time.Sunday,
+		"ar_EG":       time.Saturday,
+	} {
+		if got := DefaultWeekStart(code); got != want {
+			t.Fatalf("DefaultWeekStart(%q) = %s, want %s", code, got, want)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2026, 2, 11, 15, 0, 0, 0, loc) // a Wednesday
+	cases := []struct {
+		in, start, end string
+	}{
+		{"this-week", "2026-02-09", "2026-02-16"},
+		{"Last Week", "2026-02-02", "2026-02-09"},
+		{"next_week", "2026-02-16", "2026-02-23"},
+		{"last-month", "2026-01-01", "2026-02-01"},
+		{"next-month", "2026-03-01", "2026-04-01"},
+		{"this-quarter", "2026-01-01", "2026-04-01"},
+		{"last-quarter", "2025-10-01", "2026-01-01"},
+		{"next-year", "2027-01-01", "2028-01-01"},
+		{"q3", "2026-07-01", "2026-10-01"},
+		{"q4-2025", "2025-10-01", "2026-01-01"},
+		{"2027-q1", "2027-01-01", "2027-04-01"},
+		{"ytd", "2026-01-01", "2026-02-12"},
+		{"mtd", "2026-02-01", "2026-02-12"},
+	}
+	for _, tc := range cases {
+		start, end, ok := ParseRange(tc.in, now, loc, time.Monday)
+		if !ok {
+			t.Fatalf("ParseRange(%q) not recognized", tc.in)
+		}
+		if got := start.Format("2006-01-02") + " " + end.Format("2006-01-02"); got != tc.start+" "+tc.end {
+			t.Fatalf("ParseRange(%q) = %s, want %s %s", tc.in, got, tc.start, tc.end)
+		}
+	}
+	if start, _, _ := ParseRange("this-week", now, loc, time.Sunday); start.Format("2006-01-02") != "2026-02-08" {
+		t.Fatalf("sunday week start = %s", start.Format("2006-01-02"))
+	}
+	for _, in := range []string{"today", "q5", "q1-26", "this-decade", "+7d"} {
+		if _, _, ok := ParseRange(in, now, loc, time.Monday); ok {
+			t.Fatalf("ParseRange(%q) should not be a range keyword", in)
+		}
+	}
+}